@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccidentClaimHandler stellt Schadenfälle zu Unfallmeldungen bereit
+type AccidentClaimHandler struct {
+	claimService *service.AccidentClaimService
+}
+
+// NewAccidentClaimHandler erstellt einen neuen AccidentClaimHandler
+func NewAccidentClaimHandler(services *service.Services) *AccidentClaimHandler {
+	return &AccidentClaimHandler{
+		claimService: services.AccidentClaim,
+	}
+}
+
+// AccidentClaimRequest enthält die Angaben eines Schadenfalls
+type AccidentClaimRequest struct {
+	ReportID           string             `json:"reportId"` // Nur beim Anlegen
+	DriverID           string             `json:"driverId"`
+	IncidentDate       string             `json:"incidentDate"` // YYYY-MM-DDTHH:MM (Europe/Berlin), ohne Angabe Zeitpunkt der Meldung
+	Location           string             `json:"location"`
+	Description        string             `json:"description"`
+	Parties            []model.ClaimParty `json:"parties"`
+	PoliceReportNumber string             `json:"policeReportNumber"`
+	Fault              model.ClaimFault   `json:"fault"`      // unknown, own, third_party, shared
+	FaultShare         int                `json:"faultShare"` // Eigener Haftungsanteil bei Teilschuld in Prozent
+	InsuranceCompany   string             `json:"insuranceCompany"`
+	InsuranceNumber    string             `json:"insuranceNumber"`
+	ClaimNumber        string             `json:"claimNumber"`
+	WorkOrderID        string             `json:"workOrderId"`
+	RepairCost         float64            `json:"repairCost"`
+	Deductible         float64            `json:"deductible"`
+	DowntimeStart      string             `json:"downtimeStart"` // YYYY-MM-DD
+	DowntimeEnd        string             `json:"downtimeEnd"`   // YYYY-MM-DD
+}
+
+// ClaimStatusRequest enthält einen Statuswechsel
+type ClaimStatusRequest struct {
+	Status model.ClaimStatus `json:"status" binding:"required"`
+	Note   string            `json:"note"`
+}
+
+// ClaimNoteRequest enthält eine Notiz für den Verlauf
+type ClaimNoteRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ClaimReimbursementRequest enthält eine Erstattung
+type ClaimReimbursementRequest struct {
+	Amount float64 `json:"amount" binding:"required"`
+	Date   string  `json:"date"`  // Optional: YYYY-MM-DD, sonst heute
+	Payer  string  `json:"payer"` // z.B. eigene Versicherung oder Versicherung des Unfallgegners
+}
+
+// GetClaims gibt die Schadenfälle zurück (?vehicleId=&driverId=&status=open,reported)
+func (h *AccidentClaimHandler) GetClaims(c *gin.Context) {
+	var filter model.AccidentClaimFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	if filter.DriverID, err = optionalObjectID(c.Query("driverId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrer-ID"})
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		for _, value := range strings.Split(status, ",") {
+			filter.Statuses = append(filter.Statuses, model.ClaimStatus(strings.TrimSpace(value)))
+		}
+	}
+
+	claims, err := h.claimService.GetClaims(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Schadenfälle"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"claims": claims, "count": len(claims)})
+}
+
+// GetClaim gibt einen Schadenfall mit Kennzahlen zurück
+func (h *AccidentClaimHandler) GetClaim(c *gin.Context) {
+	claim, err := h.claimService.GetClaim(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"claim":        claim,
+		"netCost":      claim.NetCost(),
+		"downtimeDays": claim.DowntimeDays(),
+	})
+}
+
+// CreateClaim legt den Schadenfall zu einer Unfallmeldung an
+func (h *AccidentClaimHandler) CreateClaim(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req AccidentClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ReportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Die Unfallmeldung (reportId) ist erforderlich"})
+		return
+	}
+	input, err := accidentClaimInput(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.CreateClaim(user, input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, claim)
+}
+
+// UpdateClaim ändert die Angaben eines Schadenfalls
+func (h *AccidentClaimHandler) UpdateClaim(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req AccidentClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := accidentClaimInput(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.UpdateClaim(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+// DeleteClaim löscht einen Schadenfall
+func (h *AccidentClaimHandler) DeleteClaim(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.claimService.DeleteClaim(user, c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schadenfall erfolgreich gelöscht"})
+}
+
+// ChangeStatus setzt den Status eines Schadenfalls
+func (h *AccidentClaimHandler) ChangeStatus(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req ClaimStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.ChangeStatus(user, c.Param("id"), req.Status, req.Note)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+// AddNote ergänzt den Verlauf um eine Notiz
+func (h *AccidentClaimHandler) AddNote(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req ClaimNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.AddNote(user, c.Param("id"), req.Text)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, claim)
+}
+
+// RecordReimbursement verbucht eine Erstattung
+func (h *AccidentClaimHandler) RecordReimbursement(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req ClaimReimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseClaimDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Datum, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	claim, err := h.claimService.RecordReimbursement(user, c.Param("id"), req.Amount, date, req.Payer)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, claim)
+}
+
+// GetDocuments gibt die Unterlagen eines Schadenfalls zurück
+func (h *AccidentClaimHandler) GetDocuments(c *gin.Context) {
+	documents, err := h.claimService.GetDocuments(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"documents": documents, "count": len(documents)})
+}
+
+// UploadDocument lädt eine Unterlage zum Schadenfall hoch (Formularfelder file und name)
+func (h *AccidentClaimHandler) UploadDocument(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keine Datei gefunden"})
+		return
+	}
+	defer file.Close()
+
+	// Datei-Größe prüfen (max 10MB)
+	if header.Size > 10<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datei zu groß (max. 10MB)"})
+		return
+	}
+
+	allowedTypes := map[string]bool{
+		"application/pdf": true,
+		"image/jpeg":      true,
+		"image/jpg":       true,
+		"image/png":       true,
+		"image/webp":      true,
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		// Content-Type aus Dateiendung ermitteln
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".pdf":
+			contentType = "application/pdf"
+		case ".jpg", ".jpeg":
+			contentType = "image/jpeg"
+		case ".png":
+			contentType = "image/png"
+		case ".webp":
+			contentType = "image/webp"
+		}
+	}
+
+	if !allowedTypes[contentType] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nur PDF-Dateien und Bilder sind für Schadenunterlagen erlaubt"})
+		return
+	}
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Lesen der Datei"})
+		return
+	}
+
+	document, err := h.claimService.AddDocument(user, c.Param("id"), c.PostForm("name"), header.Filename, contentType, fileData)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Dokument erfolgreich hochgeladen",
+		"document": gin.H{
+			"id":          document.ID.Hex(),
+			"name":        document.Name,
+			"fileName":    document.FileName,
+			"contentType": document.ContentType,
+			"size":        document.Size,
+		},
+	})
+}
+
+// respondError übersetzt Fehler des AccidentClaimService in HTTP-Antworten
+func (h *AccidentClaimHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrClaimNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schadenfall nicht gefunden"})
+	case errors.Is(err, service.ErrClaimExists), errors.Is(err, service.ErrClaimState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// accidentClaimInput übernimmt die Anfrage in die Eingabe des AccidentClaimService
+func accidentClaimInput(req AccidentClaimRequest) (service.AccidentClaimInput, error) {
+	incidentDate, err := parseClaimTime(req.IncidentDate)
+	if err != nil {
+		return service.AccidentClaimInput{}, fmt.Errorf("ungültiges unfalldatum, erwartet wird YYYY-MM-DDTHH:MM")
+	}
+	downtimeStart, err := parseClaimDate(req.DowntimeStart)
+	if err != nil {
+		return service.AccidentClaimInput{}, fmt.Errorf("ungültiger beginn der ausfallzeit, erwartet wird YYYY-MM-DD")
+	}
+	downtimeEnd, err := parseClaimDate(req.DowntimeEnd)
+	if err != nil {
+		return service.AccidentClaimInput{}, fmt.Errorf("ungültiges ende der ausfallzeit, erwartet wird YYYY-MM-DD")
+	}
+
+	return service.AccidentClaimInput{
+		ReportID:           req.ReportID,
+		DriverID:           req.DriverID,
+		IncidentDate:       incidentDate,
+		Location:           req.Location,
+		Description:        req.Description,
+		Parties:            req.Parties,
+		PoliceReportNumber: req.PoliceReportNumber,
+		Fault:              req.Fault,
+		FaultShare:         req.FaultShare,
+		InsuranceCompany:   req.InsuranceCompany,
+		InsuranceNumber:    req.InsuranceNumber,
+		ClaimNumber:        req.ClaimNumber,
+		WorkOrderID:        req.WorkOrderID,
+		RepairCost:         req.RepairCost,
+		Deductible:         req.Deductible,
+		DowntimeStart:      downtimeStart,
+		DowntimeEnd:        downtimeEnd,
+	}, nil
+}
+
+// parseClaimTime liest einen optionalen Zeitpunkt im Format YYYY-MM-DDTHH:MM (Europe/Berlin)
+func parseClaimTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiger zeitpunkt: %s", value)
+	}
+	return &t, nil
+}
+
+// parseClaimDate liest ein optionales Datum im Format YYYY-MM-DD
+func parseClaimDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiges datum: %s", value)
+	}
+	return &t, nil
+}
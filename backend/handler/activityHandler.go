@@ -8,26 +8,32 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // ActivityHandler repräsentiert den Handler für Aktivitäts-Operationen
 type ActivityHandler struct {
-	activityRepo *repository.ActivityRepository
-	vehicleRepo  *repository.VehicleRepository
-	driverRepo   *repository.DriverRepository
+	activityRepo repository.ActivityRepository
+	vehicleRepo  repository.VehicleRepository
+	driverRepo   repository.DriverRepository
 }
 
 // NewActivityHandler erstellt einen neuen ActivityHandler
-func NewActivityHandler() *ActivityHandler {
+func NewActivityHandler(repos *repository.Repositories) *ActivityHandler {
 	return &ActivityHandler{
-		activityRepo: repository.NewActivityRepository(),
-		vehicleRepo:  repository.NewVehicleRepository(),
-		driverRepo:   repository.NewDriverRepository(),
+		activityRepo: repos.Activity,
+		vehicleRepo:  repos.Vehicle,
+		driverRepo:   repos.Driver,
 	}
 }
 
 // GetActivities behandelt die Anfrage, Aktivitäten abzurufen
 func (h *ActivityHandler) GetActivities(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	// Parameter für Paginierung
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	skip, _ := strconv.Atoi(c.DefaultQuery("skip", "0"))
@@ -35,6 +41,11 @@ func (h *ActivityHandler) GetActivities(c *gin.Context) {
 	// Optional: Filter nach Typ
 	activityType := c.Query("type")
 
+	if format != "" {
+		h.exportActivities(c, format, model.ActivityType(activityType), limit, skip)
+		return
+	}
+
 	var activities []*model.Activity
 	var err error
 
@@ -83,6 +94,74 @@ func (h *ActivityHandler) GetActivities(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"activities": result})
 }
 
+// exportActivities exportiert Aktivitäten seitenweise als CSV oder XLSX. Ohne ?limit=
+// werden alle Aktivitäten ab ?skip= exportiert.
+func (h *ActivityHandler) exportActivities(c *gin.Context, format exportFormat, activityType model.ActivityType, limit, skip int) {
+	if c.Query("limit") == "" {
+		limit = 0
+	}
+
+	// Erste Seite vor dem Start des Downloads laden, damit Fehler noch als JSON gemeldet werden können
+	page, err := h.findActivities(activityType, exportBatch(limit, 0), skip)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Aktivitäten"})
+		return
+	}
+
+	export := startExport(c, format, "aktivitaeten", "Zeitpunkt", "Typ", "Beschreibung", "Fahrzeug", "Fahrer")
+	defer export.Close()
+
+	vehicleNames := map[primitive.ObjectID]string{}
+	driverNames := map[primitive.ObjectID]string{}
+	written := 0
+	for len(page) > 0 {
+		for _, activity := range page {
+			vehicleName, known := vehicleNames[activity.VehicleID]
+			if !known && !activity.VehicleID.IsZero() {
+				if vehicle, err := h.vehicleRepo.FindByID(activity.VehicleID.Hex()); err == nil {
+					vehicleName = vehicle.Brand + " " + vehicle.Model + " (" + vehicle.LicensePlate + ")"
+				}
+				vehicleNames[activity.VehicleID] = vehicleName
+			}
+			driverName, known := driverNames[activity.DriverID]
+			if !known && !activity.DriverID.IsZero() {
+				if driver, err := h.driverRepo.FindByID(activity.DriverID.Hex()); err == nil {
+					driverName = driver.FirstName + " " + driver.LastName
+				}
+				driverNames[activity.DriverID] = driverName
+			}
+
+			if err := export.Row(activity.Timestamp, string(activity.Type), activity.Description, vehicleName, driverName); err != nil {
+				return
+			}
+		}
+
+		written += len(page)
+		if len(page) < exportPageSize || (limit > 0 && written >= limit) {
+			return
+		}
+		if page, err = h.findActivities(activityType, exportBatch(limit, written), skip+written); err != nil {
+			return
+		}
+	}
+}
+
+// findActivities lädt eine Seite Aktivitäten, optional nach Typ gefiltert
+func (h *ActivityHandler) findActivities(activityType model.ActivityType, limit, skip int) ([]*model.Activity, error) {
+	if activityType != "" {
+		return h.activityRepo.FindByType(activityType, limit, skip)
+	}
+	return h.activityRepo.FindAll(limit, skip)
+}
+
+// exportBatch liefert die Größe der nächsten Seite beim Export von höchstens limit Einträgen (0 = alle)
+func exportBatch(limit, written int) int {
+	if limit > 0 && limit-written < exportPageSize {
+		return limit - written
+	}
+	return exportPageSize
+}
+
 // GetVehicleActivities behandelt die Anfrage, alle Aktivitäten für ein Fahrzeug abzurufen
 func (h *ActivityHandler) GetVehicleActivities(c *gin.Context) {
 	vehicleID := c.Param("vehicleId")
@@ -16,13 +16,13 @@ import (
 
 // AuthHandler repräsentiert den Handler für Authentifizierungsoperationen
 type AuthHandler struct {
-	userRepo *repository.UserRepository
+	userRepo repository.UserRepository
 }
 
 // NewAuthHandler erstellt einen neuen AuthHandler
-func NewAuthHandler() *AuthHandler {
+func NewAuthHandler(repos *repository.Repositories) *AuthHandler {
 	return &AuthHandler{
-		userRepo: repository.NewUserRepository(),
+		userRepo: repos.User,
 	}
 }
 
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkImportHandler stellt den Massenimport von Fahrzeugen und Fahrern bereit
+type BulkImportHandler struct {
+	importService *service.BulkImportService
+	scheduler     *service.JobScheduler
+}
+
+// NewBulkImportHandler erstellt einen neuen BulkImportHandler
+func NewBulkImportHandler(services *service.Services) *BulkImportHandler {
+	return &BulkImportHandler{
+		importService: services.BulkImport,
+		scheduler:     services.Scheduler,
+	}
+}
+
+// BulkImportMappingRequest enthält die Zuordnung Feld -> Spaltenüberschrift
+type BulkImportMappingRequest struct {
+	Mapping map[string]string `json:"mapping" binding:"required"`
+}
+
+// Upload lädt eine Fahrzeug- oder Fahrerliste hoch und gibt den Probelauf mit den Fehlern je Zeile zurück.
+// Erwartet multipart/form-data mit "file" (CSV oder XLSX), "entity" (vehicles|drivers)
+// und optional "mapping" (JSON, Feld -> Spaltenüberschrift).
+func (h *BulkImportHandler) Upload(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fehler beim Parsen der Formulardaten"})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keine Datei gefunden"})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > 10<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datei zu groß (max. 10MB)"})
+		return
+	}
+
+	entity := model.BulkImportEntity(c.PostForm("entity"))
+	if model.BulkImportFields(entity) == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Importart, erlaubt sind vehicles und drivers"})
+		return
+	}
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Spaltenzuordnung"})
+			return
+		}
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fehler beim Lesen der Datei"})
+		return
+	}
+
+	result, err := h.importService.Upload(user.ID, entity, header.Filename, data, mapping)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetBatches gibt die letzten Importe zurück (?limit=)
+func (h *BulkImportHandler) GetBatches(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Limit (1-100)"})
+		return
+	}
+
+	batches, err := h.importService.GetRecentBatches(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Importe"})
+		return
+	}
+	if batches == nil {
+		batches = []*model.BulkImportBatch{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"batches": batches})
+}
+
+// GetBatch gibt einen Import mit allen Zeilen zurück
+func (h *BulkImportHandler) GetBatch(c *gin.Context) {
+	result, err := h.importService.GetBatch(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateMapping ändert die Spaltenzuordnung und gibt den erneuten Probelauf zurück
+func (h *BulkImportHandler) UpdateMapping(c *gin.Context) {
+	var req BulkImportMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.importService.UpdateMapping(c.Param("id"), req.Mapping)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CommitBatch beauftragt die Übernahme der gültigen Zeilen und startet den Hintergrundjob
+func (h *BulkImportHandler) CommitBatch(c *gin.Context) {
+	batch, err := h.importService.Commit(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	// Läuft der Job bereits, übernimmt ihn der nächste Intervall-Lauf
+	if err := h.scheduler.Trigger(service.JobBulkImport); err != nil && !errors.Is(err, service.ErrJobRunning) {
+		log.Printf("⚠️  Job %s konnte nicht gestartet werden: %v", service.JobBulkImport, err)
+	}
+
+	c.JSON(http.StatusAccepted, batch)
+}
+
+// DiscardBatch verwirft einen Probelauf
+func (h *BulkImportHandler) DiscardBatch(c *gin.Context) {
+	if err := h.importService.Discard(c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Import verworfen"})
+}
+
+// respondError übersetzt Fehler des BulkImportService in HTTP-Antworten
+func (h *BulkImportHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBulkImportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBulkImportState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler verwaltet die iCalendar-Feeds für Reservierungen und deren Abonnements
+type CalendarHandler struct {
+	calendarService *service.CalendarService
+}
+
+// NewCalendarHandler erstellt einen neuen CalendarHandler
+func NewCalendarHandler(services *service.Services) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: services.Calendar,
+	}
+}
+
+// CreateCalendarFeedRequest repräsentiert die Anfrage zum Anlegen eines Kalender-Abonnements
+type CreateCalendarFeedRequest struct {
+	Scope    model.CalendarFeedScope `json:"scope" binding:"required"`
+	TargetID string                  `json:"targetId"` // Fahrzeug- bzw. Fahrer-ID
+	Name     string                  `json:"name"`
+}
+
+// calendarFeedResponse ergänzt ein Abonnement um die vollständige Abo-URL
+type calendarFeedResponse struct {
+	*model.CalendarFeedToken
+	URL string `json:"url"`
+}
+
+// GetFeed liefert einen iCalendar-Feed anhand des Tokens aus der URL (ohne Anmeldung)
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.calendarService.RenderFeed(token)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarFeedNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Kalender nicht gefunden"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Erstellen des Kalenders"})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="fleetflow.ics"`)
+	c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
+
+// GetFeedTokens gibt alle Kalender-Abonnements des angemeldeten Benutzers zurück
+func (h *CalendarHandler) GetFeedTokens(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	tokens, err := h.calendarService.GetFeedTokens(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Kalender-Abonnements"})
+		return
+	}
+
+	response := make([]calendarFeedResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, calendarFeedResponse{CalendarFeedToken: token, URL: feedURL(c, token.Token)})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feeds": response})
+}
+
+// CreateFeedToken legt ein neues Kalender-Abonnement an
+func (h *CalendarHandler) CreateFeedToken(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req CreateCalendarFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := h.calendarService.CreateFeedToken(user, req.Scope, req.TargetID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, calendarFeedResponse{CalendarFeedToken: token, URL: feedURL(c, token.Token)})
+}
+
+// RevokeFeedToken widerruft ein Kalender-Abonnement des angemeldeten Benutzers
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.calendarService.RevokeFeedToken(user.ID, c.Param("id")); err != nil {
+		if errors.Is(err, service.ErrCalendarFeedNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Kalender-Abonnement nicht gefunden"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Widerrufen des Kalender-Abonnements"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Kalender-Abonnement widerrufen"})
+}
+
+// currentUser liest den von der AuthMiddleware gesetzten Benutzer aus dem Kontext
+func currentUser(c *gin.Context) (*model.User, bool) {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*model.User)
+	return user, ok
+}
+
+// feedURL baut die vollständige Abo-URL eines Feeds aus der aktuellen Anfrage
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/calendar/" + token + ".ics"
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChargingSessionHandler stellt Ladevorgänge, Erstattungen und Energieberichte bereit
+type ChargingSessionHandler struct {
+	chargingService *service.ChargingService
+}
+
+// NewChargingSessionHandler erstellt einen neuen ChargingSessionHandler
+func NewChargingSessionHandler(services *service.Services) *ChargingSessionHandler {
+	return &ChargingSessionHandler{
+		chargingService: services.Charging,
+	}
+}
+
+// ChargingSessionRequest enthält die Angaben eines Ladevorgangs
+type ChargingSessionRequest struct {
+	VehicleID     string                     `json:"vehicleId" binding:"required"`
+	DriverID      string                     `json:"driverId"`
+	StartTime     string                     `json:"startTime" binding:"required"` // YYYY-MM-DDTHH:MM (Europe/Berlin)
+	EndTime       string                     `json:"endTime" binding:"required"`   // YYYY-MM-DDTHH:MM (Europe/Berlin)
+	EnergyKWh     float64                    `json:"energyKWh" binding:"required"`
+	ChargePointID string                     `json:"chargePointId"`
+	LocationType  model.ChargingLocationType `json:"locationType" binding:"required"`
+	Location      string                     `json:"location"`
+	Tariff        string                     `json:"tariff"`
+	PricePerKWh   float64                    `json:"pricePerKWh"`
+	Cost          float64                    `json:"cost"`
+	Mileage       int                        `json:"mileage"`
+	Notes         string                     `json:"notes"`
+}
+
+// input wandelt die Anfrage in die Eingabe des ChargingService um
+func (r *ChargingSessionRequest) input() (service.ChargingSessionInput, error) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		loc = time.Local
+	}
+
+	startTime, err := time.ParseInLocation("2006-01-02T15:04", r.StartTime, loc)
+	if err != nil {
+		return service.ChargingSessionInput{}, fmt.Errorf("ungültige Startzeit, erwartet wird YYYY-MM-DDTHH:MM")
+	}
+	endTime, err := time.ParseInLocation("2006-01-02T15:04", r.EndTime, loc)
+	if err != nil {
+		return service.ChargingSessionInput{}, fmt.Errorf("ungültige Endzeit, erwartet wird YYYY-MM-DDTHH:MM")
+	}
+
+	return service.ChargingSessionInput{
+		VehicleID:     r.VehicleID,
+		DriverID:      r.DriverID,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		EnergyKWh:     r.EnergyKWh,
+		ChargePointID: r.ChargePointID,
+		LocationType:  r.LocationType,
+		Location:      r.Location,
+		Tariff:        r.Tariff,
+		PricePerKWh:   r.PricePerKWh,
+		Cost:          r.Cost,
+		Mileage:       r.Mileage,
+		Notes:         r.Notes,
+	}, nil
+}
+
+// GetSessions gibt die Ladevorgänge zurück (?vehicleId=&driverId=&from=&to=&locationType=&status=)
+func (h *ChargingSessionHandler) GetSessions(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	filter, ok := chargingFilter(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.chargingService.GetSessions(user, filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions, "count": len(sessions)})
+}
+
+// CreateSession erfasst einen Ladevorgang
+func (h *ChargingSessionHandler) CreateSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req ChargingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := req.input()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.chargingService.CreateSession(user, input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetSession gibt einen Ladevorgang zurück
+func (h *ChargingSessionHandler) GetSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	session, err := h.chargingService.GetSession(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// UpdateSession ändert einen Ladevorgang, solange über die Erstattung nicht entschieden wurde
+func (h *ChargingSessionHandler) UpdateSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req ChargingSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := req.input()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.chargingService.UpdateSession(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// DeleteSession löscht einen Ladevorgang, solange über die Erstattung nicht entschieden wurde
+func (h *ChargingSessionHandler) DeleteSession(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.chargingService.DeleteSession(user, c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ladevorgang gelöscht"})
+}
+
+// GetReimbursements gibt die Erstattungsanträge für das Laden zu Hause zurück (?status=pending|approved|rejected)
+func (h *ChargingSessionHandler) GetReimbursements(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	status := model.ReimbursementStatus(c.DefaultQuery("status", string(model.ReimbursementPending)))
+	switch status {
+	case model.ReimbursementPending, model.ReimbursementApproved, model.ReimbursementRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Status, erlaubt sind pending, approved und rejected"})
+		return
+	}
+
+	sessions, err := h.chargingService.GetSessions(user, model.ChargingSessionFilter{
+		LocationType:        model.ChargingLocationHome,
+		ReimbursementStatus: status,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	var total float64
+	for _, session := range sessions {
+		total += session.Reimbursement.Amount
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions, "count": len(sessions), "total": total})
+}
+
+// ApproveReimbursement gibt die Erstattung eines Ladevorgangs zu Hause frei
+func (h *ChargingSessionHandler) ApproveReimbursement(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	session, err := h.chargingService.ApproveReimbursement(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// RejectReimbursement lehnt die Erstattung eines Ladevorgangs zu Hause mit Begründung ab
+func (h *ChargingSessionHandler) RejectReimbursement(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Eine Begründung für die Ablehnung ist erforderlich"})
+		return
+	}
+
+	session, err := h.chargingService.RejectReimbursement(user, c.Param("id"), req.Comment)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// GetEnergyReport gibt den Energiebericht eines Fahrzeugs zurück (?from=&to=&format=csv|json)
+func (h *ChargingSessionHandler) GetEnergyReport(c *gin.Context) {
+	now := time.Now()
+	from := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+	to := now.AddDate(0, 0, 1)
+	if value := c.Query("from"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			return
+		}
+		from = date
+	}
+	if value := c.Query("to"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
+			return
+		}
+		to = date.AddDate(0, 0, 1) // Enddatum einschließlich
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Das Enddatum muss nach dem Startdatum liegen"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Format, erlaubt sind csv und json"})
+		return
+	}
+
+	report, err := h.chargingService.GetEnergyReport(c.Param("vehicleId"), from, to)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	header := []string{"Monat", "Ladevorgänge", "Energie (kWh)", "Kosten (EUR)"}
+	rows := make([][]string, 0, len(report.ByMonth)+1)
+	for _, month := range report.ByMonth {
+		rows = append(rows, []string{
+			month.Month,
+			strconv.Itoa(month.Sessions),
+			csvAmount(month.EnergyKWh),
+			csvAmount(month.Cost),
+		})
+	}
+	rows = append(rows, []string{"Gesamt", strconv.Itoa(report.Sessions), csvAmount(report.EnergyKWh), csvAmount(report.Cost)})
+
+	writeCSV(c, fmt.Sprintf("energiebericht-%s-%s.csv", report.LicensePlate, from.Format("2006-01-02")), header, rows)
+}
+
+// respondError übersetzt Fehler des ChargingService in HTTP-Antworten
+func (h *ChargingSessionHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrChargingSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Ladevorgang nicht gefunden"})
+	case errors.Is(err, service.ErrChargingSessionLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrChargingForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// chargingFilter liest die Filterparameter für Ladevorgänge aus der Anfrage
+func chargingFilter(c *gin.Context) (model.ChargingSessionFilter, bool) {
+	var filter model.ChargingSessionFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return filter, false
+	}
+	if filter.DriverID, err = optionalObjectID(c.Query("driverId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrer-ID"})
+		return filter, false
+	}
+	if from := c.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			return filter, false
+		}
+		filter.From = &date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
+			return filter, false
+		}
+		end := date.AddDate(0, 0, 1) // Enddatum einschließlich
+		filter.To = &end
+	}
+	filter.LocationType = model.ChargingLocationType(c.Query("locationType"))
+	filter.ReimbursementStatus = model.ReimbursementStatus(c.Query("status"))
+	return filter, true
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeCSV sendet eine CSV-Datei als Download. Semikolon als Trennzeichen und UTF-8-BOM,
+// damit Excel mit deutschen Ländereinstellungen Umlaute und Spalten korrekt erkennt.
+func writeCSV(c *gin.Context, filename string, header []string, rows [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Status(http.StatusOK)
+
+	if _, err := c.Writer.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return
+	}
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Comma = ';'
+	_ = writer.Write(header)
+	_ = writer.WriteAll(rows)
+}
+
+// csvAmount formatiert einen Betrag mit Dezimalkomma und zwei Nachkommastellen
+func csvAmount(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', 2, 64), ".", ",", 1)
+}
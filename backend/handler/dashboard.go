@@ -3,34 +3,40 @@ package handler
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
 
 	"FleetFlow/backend/model"
 	"FleetFlow/backend/repository"
+	"FleetFlow/backend/service"
 	"github.com/gin-gonic/gin"
 )
 
 // DashboardHandler enthält alle Handler für Dashboard-bezogene Anfragen
 type DashboardHandler struct {
-	vehicleRepo     *repository.VehicleRepository
-	driverRepo      *repository.DriverRepository
-	maintenanceRepo *repository.MaintenanceRepository
-	usageRepo       *repository.VehicleUsageRepository
-	fuelCostRepo    *repository.FuelCostRepository
-	activityRepo    *repository.ActivityRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	maintenanceRepo repository.MaintenanceRepository
+	usageRepo       repository.VehicleUsageRepository
+	fuelCostRepo    repository.FuelCostRepository
+	activityRepo    repository.ActivityRepository
+
+	consumptionService *service.FuelConsumptionService
 }
 
 // NewDashboardHandler erstellt einen neuen DashboardHandler
-func NewDashboardHandler() *DashboardHandler {
+func NewDashboardHandler(repos *repository.Repositories, services *service.Services) *DashboardHandler {
 	return &DashboardHandler{
-		vehicleRepo:     repository.NewVehicleRepository(),
-		driverRepo:      repository.NewDriverRepository(),
-		maintenanceRepo: repository.NewMaintenanceRepository(),
-		usageRepo:       repository.NewVehicleUsageRepository(),
-		fuelCostRepo:    repository.NewFuelCostRepository(),
-		activityRepo:    repository.NewActivityRepository(),
+		vehicleRepo:     repos.Vehicle,
+		driverRepo:      repos.Driver,
+		maintenanceRepo: repos.Maintenance,
+		usageRepo:       repos.VehicleUsage,
+		fuelCostRepo:    repos.FuelCost,
+		activityRepo:    repos.Activity,
+
+		consumptionService: services.FuelConsumption,
 	}
 }
 
@@ -48,6 +54,10 @@ type FinancingBreakdown struct {
 	LeasedCount    int     `json:"leasedCount"`
 	FinancedCosts  float64 `json:"financedCosts"`
 	LeasedCosts    float64 `json:"leasedCosts"`
+	// Aus den Tilgungsplänen der Finanzierungen
+	OutstandingBalance    float64 `json:"outstandingBalance"`
+	RemainingInterest     float64 `json:"remainingInterest"`
+	InconsistentContracts int     `json:"inconsistentContracts"`
 }
 
 type ExpiringContract struct {
@@ -129,6 +139,9 @@ func (h *DashboardHandler) GetCompleteDashboardData(c *gin.Context) {
 	// Kraftstoffkosten nach Fahrzeug (Top 5)
 	fuelCostData := h.getFuelCostsByVehicleData()
 
+	// Auffälligkeiten beim Tanken (letzte 90 Tage)
+	fuelAnomalies, fuelAnomalyCount := h.getRecentFuelAnomalies()
+
 	// Wartungskosten der letzten 12 Monate
 	maintenanceCostData := h.getMaintenanceCostData()
 
@@ -178,6 +191,8 @@ func (h *DashboardHandler) GetCompleteDashboardData(c *gin.Context) {
 		"recentActivities":      recentActivities,
 		"fuelCostVehicleLabels": fuelCostData.Labels,
 		"fuelCostVehicleData":   fuelCostData.Data,
+		"fuelAnomalies":         fuelAnomalies,
+		"fuelAnomalyCount":      fuelAnomalyCount,
 		// Finanzierungsstatistiken (kombiniert Finanzierung + Leasing)
 		"totalFinancingCosts":   financingStats.TotalMonthlyCosts,
 		"financingBreakdown":    financingStats.Breakdown,
@@ -204,6 +219,31 @@ func (h *DashboardHandler) GetCompleteDashboardData(c *gin.Context) {
 	})
 }
 
+// getRecentFuelAnomalies liefert die neuesten Auffälligkeiten beim Tanken der letzten 90 Tage (max 5) und deren Anzahl
+func (h *DashboardHandler) getRecentFuelAnomalies() ([]gin.H, int) {
+	from := time.Now().AddDate(0, 0, -90)
+	anomalies, err := h.consumptionService.GetAnomalies(&from, nil)
+	if err != nil {
+		return []gin.H{}, 0
+	}
+
+	result := make([]gin.H, 0, 5)
+	for _, anomaly := range anomalies {
+		if len(result) == 5 {
+			break
+		}
+		result = append(result, gin.H{
+			"vehicleId":    anomaly.VehicleID.Hex(),
+			"licensePlate": anomaly.LicensePlate,
+			"type":         string(anomaly.Type),
+			"typeText":     anomaly.TypeText,
+			"message":      anomaly.Message,
+			"date":         anomaly.Date,
+		})
+	}
+	return result, len(anomalies)
+}
+
 // calculateFinancingStatistics berechnet Finanzierungsstatistiken
 func (h *DashboardHandler) calculateFinancingStatistics(vehicles []*model.Vehicle) FinancingStatistics {
 	stats := FinancingStatistics{
@@ -223,8 +263,23 @@ func (h *DashboardHandler) calculateFinancingStatistics(vehicles []*model.Vehicl
 
 		case model.AcquisitionTypeFinanced:
 			stats.Breakdown.FinancedCount++
-			financedCosts += vehicle.FinanceMonthlyRate
-			totalMonthlyCosts += vehicle.FinanceMonthlyRate
+			// Rate laut Tilgungsplan: berechnet, wenn sie fehlt, und nach der letzten Rate entfallen
+			schedule := service.BuildAmortizationSchedule(vehicle, now)
+			monthlyRate := schedule.MonthlyRate
+			if len(schedule.Entries) > 0 && schedule.NextPaymentDate == nil {
+				monthlyRate = 0
+			}
+			financedCosts += monthlyRate
+			totalMonthlyCosts += monthlyRate
+			stats.Breakdown.OutstandingBalance += schedule.CurrentBalance
+			for _, entry := range schedule.Entries {
+				if entry.Date.After(now) {
+					stats.Breakdown.RemainingInterest += entry.Interest
+				}
+			}
+			if len(schedule.Issues) > 0 {
+				stats.Breakdown.InconsistentContracts++
+			}
 
 			// Prüfe auf auslaufende Finanzierungsverträge
 			if !vehicle.FinanceEndDate.IsZero() && vehicle.FinanceEndDate.Before(threeMonthsFromNow) && vehicle.FinanceEndDate.After(now) {
@@ -258,6 +313,8 @@ func (h *DashboardHandler) calculateFinancingStatistics(vehicles []*model.Vehicl
 	stats.TotalMonthlyCosts = totalMonthlyCosts
 	stats.Breakdown.FinancedCosts = financedCosts
 	stats.Breakdown.LeasedCosts = leasedCosts
+	stats.Breakdown.OutstandingBalance = math.Round(stats.Breakdown.OutstandingBalance*100) / 100
+	stats.Breakdown.RemainingInterest = math.Round(stats.Breakdown.RemainingInterest*100) / 100
 	stats.ExpiringContracts = expiringContracts
 
 	// Durchschnittliche Kosten pro Fahrzeug
@@ -404,8 +461,7 @@ func (h *DashboardHandler) getUpcomingMaintenanceNextMonth() []gin.H {
 	}
 
 	// 2. Geplante Wartungseinträge prüfen
-	maintenanceRepo := repository.NewMaintenanceRepository()
-	upcomingMaintenances, err := maintenanceRepo.FindUpcoming(now, endOfNextMonth)
+	upcomingMaintenances, err := h.maintenanceRepo.FindUpcoming(now, endOfNextMonth)
 	if err == nil {
 		// Vehicle-Map für schnelle Zugriffe erstellen
 		vehicleMap := make(map[string]*model.Vehicle)
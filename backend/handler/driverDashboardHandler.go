@@ -13,19 +13,19 @@ import (
 
 // DriverDashboardHandler verwaltet das Fahrer-Dashboard
 type DriverDashboardHandler struct {
-	vehicleRepo     *repository.VehicleRepository
-	reservationRepo *repository.VehicleReservationRepository
-	reportRepo      *repository.VehicleReportRepository
+	vehicleRepo     repository.VehicleRepository
+	reservationRepo repository.VehicleReservationRepository
+	reportRepo      repository.VehicleReportRepository
 	activityService *service.ActivityService
 }
 
 // NewDriverDashboardHandler erstellt einen neuen Handler
-func NewDriverDashboardHandler() *DriverDashboardHandler {
+func NewDriverDashboardHandler(repos *repository.Repositories, services *service.Services) *DriverDashboardHandler {
 	return &DriverDashboardHandler{
-		vehicleRepo:     repository.NewVehicleRepository(),
-		reservationRepo: repository.NewVehicleReservationRepository(),
-		reportRepo:      repository.NewVehicleReportRepository(),
-		activityService: service.NewActivityService(),
+		vehicleRepo:     repos.Vehicle,
+		reservationRepo: repos.VehicleReservation,
+		reportRepo:      repos.VehicleReport,
+		activityService: services.Activity,
 	}
 }
 
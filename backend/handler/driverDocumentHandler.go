@@ -18,17 +18,17 @@ import (
 
 // DriverDocumentHandler repräsentiert den Handler für Fahrerdokumente
 type DriverDocumentHandler struct {
-	documentRepo    *repository.DriverDocumentRepository
-	driverRepo      *repository.DriverRepository
+	documentRepo    repository.DriverDocumentRepository
+	driverRepo      repository.DriverRepository
 	activityService *service.ActivityService
 }
 
 // NewDriverDocumentHandler erstellt einen neuen DriverDocumentHandler
-func NewDriverDocumentHandler() *DriverDocumentHandler {
+func NewDriverDocumentHandler(repos *repository.Repositories, services *service.Services) *DriverDocumentHandler {
 	return &DriverDocumentHandler{
-		documentRepo:    repository.NewDriverDocumentRepository(),
-		driverRepo:      repository.NewDriverRepository(),
-		activityService: service.NewActivityService(),
+		documentRepo:    repos.DriverDocument,
+		driverRepo:      repos.Driver,
+		activityService: services.Activity,
 	}
 }
 
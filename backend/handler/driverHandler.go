@@ -9,21 +9,24 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
+	"strings"
 )
 
 // DriverHandler repräsentiert den Handler für Fahrer-Operationen
 type DriverHandler struct {
-	driverRepo        *repository.DriverRepository
-	vehicleRepo       *repository.VehicleRepository
+	driverRepo        repository.DriverRepository
+	vehicleRepo       repository.VehicleRepository
+	driverDocRepo     repository.DriverDocumentRepository
 	assignmentService *service.AssignmentService
 }
 
 // NewDriverHandler erstellt einen neuen DriverHandler
-func NewDriverHandler() *DriverHandler {
+func NewDriverHandler(repos *repository.Repositories, services *service.Services) *DriverHandler {
 	return &DriverHandler{
-		driverRepo:        repository.NewDriverRepository(),
-		vehicleRepo:       repository.NewVehicleRepository(),
-		assignmentService: service.NewAssignmentService(),
+		driverRepo:        repos.Driver,
+		vehicleRepo:       repos.Vehicle,
+		driverDocRepo:     repos.DriverDocument,
+		assignmentService: services.Assignment,
 	}
 }
 
@@ -37,6 +40,10 @@ type CreateDriverRequest struct {
 	AssignedVehicleID string               `json:"assignedVehicleId"` // Hinzugefügtes Feld
 	LicenseClasses    []model.LicenseClass `json:"licenseClasses"`
 	Notes             string               `json:"notes"`
+
+	BenefitMethod         model.BenefitMethod `json:"benefitMethod"`
+	CommuteDistanceKm     int                 `json:"commuteDistanceKm"`
+	CommuteDailyValuation bool                `json:"commuteDailyValuation"`
 }
 
 // AssignVehicleRequest repräsentiert die Anfrage zum Zuweisen eines Fahrzeugs
@@ -66,7 +73,17 @@ func getUserIDFromContext(c *gin.Context) primitive.ObjectID {
 
 // GetDrivers behandelt die Anfrage, alle Fahrer abzurufen
 func (h *DriverHandler) GetDrivers(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	statusFilter := c.Query("status")
+	if format != "" {
+		h.exportDrivers(c, format, model.DriverStatus(statusFilter))
+		return
+	}
+
 	var drivers []*model.Driver
 	var err error
 
@@ -102,8 +119,7 @@ func (h *DriverHandler) GetDrivers(c *gin.Context) {
 		}
 
 		// Führerschein-Status prüfen
-		driverDocRepo := repository.NewDriverDocumentRepository()
-		licenses, err := driverDocRepo.FindByDriverAndType(driver.ID.Hex(), model.DriverDocumentTypeLicense)
+		licenses, err := h.driverDocRepo.FindByDriverAndType(driver.ID.Hex(), model.DriverDocumentTypeLicense)
 		if err == nil && len(licenses) > 0 {
 			dwd.HasLicense = true
 			// Prüfen ob abgelaufen
@@ -118,6 +134,45 @@ func (h *DriverHandler) GetDrivers(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"drivers": result})
 }
 
+// exportDrivers exportiert die Fahrerliste seitenweise als CSV oder XLSX, optional nach Status gefiltert
+func (h *DriverHandler) exportDrivers(c *gin.Context, format exportFormat, status model.DriverStatus) {
+	fetch := func(limit, skip int) ([]*model.Driver, error) {
+		return h.driverRepo.FindPage(status, limit, skip)
+	}
+	drivers, err := fetch(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Fahrer"})
+		return
+	}
+
+	export := startExport(c, format, "fahrer",
+		"Personalnummer", "Nachname", "Vorname", "E-Mail", "Telefon", "Status", "Führerscheinklassen",
+		"Fahrzeug", "Führerschein hinterlegt", "Führerschein abgelaufen", "Entfernung Arbeitsstätte km", "Notizen")
+	defer export.Close()
+
+	exportPages(drivers, fetch, func(driver *model.Driver) error {
+		vehicleName := ""
+		if vehicle, err := h.assignmentService.GetAssignedVehicle(driver.ID.Hex()); err == nil && vehicle != nil {
+			vehicleName = vehicle.Brand + " " + vehicle.Model + " (" + vehicle.LicensePlate + ")"
+		}
+		hasLicense, licenseExpired := false, false
+		if licenses, err := h.driverDocRepo.FindByDriverAndType(driver.ID.Hex(), model.DriverDocumentTypeLicense); err == nil && len(licenses) > 0 {
+			hasLicense = true
+			licenseExpired = licenses[0].IsExpired()
+		}
+		classes := make([]string, len(driver.LicenseClasses))
+		for i, class := range driver.LicenseClasses {
+			classes[i] = string(class)
+		}
+
+		return export.Row(
+			driver.DriverNumber, driver.LastName, driver.FirstName, driver.Email, driver.Phone,
+			exportText(model.DriverStatusText, driver.Status), strings.Join(classes, ", "),
+			vehicleName, hasLicense, licenseExpired, driver.CommuteDistanceKm, driver.Notes,
+		)
+	})
+}
+
 // GetDriver behandelt die Anfrage, einen Fahrer anhand seiner ID abzurufen
 func (h *DriverHandler) GetDriver(c *gin.Context) {
 	id := c.Param("id")
@@ -139,15 +194,18 @@ func (h *DriverHandler) GetDriver(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"driver": gin.H{
-			"id":                driver.ID.Hex(),
-			"firstName":         driver.FirstName,
-			"lastName":          driver.LastName,
-			"email":             driver.Email,
-			"phone":             driver.Phone,
-			"status":            driver.Status,
-			"licenseClasses":    driver.LicenseClasses,
-			"notes":             driver.Notes,
-			"assignedVehicleId": assignedVehicleId, // Für Frontend-Kompatibilität
+			"id":                    driver.ID.Hex(),
+			"firstName":             driver.FirstName,
+			"lastName":              driver.LastName,
+			"email":                 driver.Email,
+			"phone":                 driver.Phone,
+			"status":                driver.Status,
+			"licenseClasses":        driver.LicenseClasses,
+			"notes":                 driver.Notes,
+			"benefitMethod":         driver.BenefitMethod,
+			"commuteDistanceKm":     driver.CommuteDistanceKm,
+			"commuteDailyValuation": driver.CommuteDailyValuation,
+			"assignedVehicleId":     assignedVehicleId, // Für Frontend-Kompatibilität
 		},
 		"vehicleName": vehicleName,
 	})
@@ -171,6 +229,15 @@ func (h *DriverHandler) CreateDriver(c *gin.Context) {
 		return
 	}
 
+	if !model.IsValidBenefitMethod(req.BenefitMethod) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Methode für den geldwerten Vorteil"})
+		return
+	}
+	if req.CommuteDistanceKm < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Die Entfernung zur Arbeitsstätte darf nicht negativ sein"})
+		return
+	}
+
 	// Status standardmäßig auf verfügbar setzen, wenn nicht angegeben
 	status := req.Status
 	if status == "" {
@@ -186,6 +253,10 @@ func (h *DriverHandler) CreateDriver(c *gin.Context) {
 		Status:         status,
 		LicenseClasses: req.LicenseClasses,
 		Notes:          req.Notes,
+
+		BenefitMethod:         req.BenefitMethod,
+		CommuteDistanceKm:     req.CommuteDistanceKm,
+		CommuteDailyValuation: req.CommuteDailyValuation,
 	}
 
 	// Fahrer in der Datenbank speichern
@@ -239,6 +310,15 @@ func (h *DriverHandler) UpdateDriver(c *gin.Context) {
 		}
 	}
 
+	if !model.IsValidBenefitMethod(req.BenefitMethod) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Methode für den geldwerten Vorteil"})
+		return
+	}
+	if req.CommuteDistanceKm < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Die Entfernung zur Arbeitsstätte darf nicht negativ sein"})
+		return
+	}
+
 	// Status validieren
 	status := req.Status
 	if status == "" {
@@ -253,6 +333,9 @@ func (h *DriverHandler) UpdateDriver(c *gin.Context) {
 	driver.Status = status
 	driver.LicenseClasses = req.LicenseClasses
 	driver.Notes = req.Notes
+	driver.BenefitMethod = req.BenefitMethod
+	driver.CommuteDistanceKm = req.CommuteDistanceKm
+	driver.CommuteDailyValuation = req.CommuteDailyValuation
 
 	// Fahrer in der Datenbank aktualisieren
 	if err := h.driverRepo.Update(driver); err != nil {
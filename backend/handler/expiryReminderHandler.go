@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExpiryReminderHandler stellt die Ablauferinnerungen und deren Einstellungen bereit
+type ExpiryReminderHandler struct {
+	reminderService *service.ExpiryReminderService
+}
+
+// NewExpiryReminderHandler erstellt einen neuen ExpiryReminderHandler
+func NewExpiryReminderHandler(services *service.Services) *ExpiryReminderHandler {
+	return &ExpiryReminderHandler{
+		reminderService: services.ExpiryReminder,
+	}
+}
+
+// GetReminders gibt die zuletzt versendeten Ablauferinnerungen zurück
+func (h *ExpiryReminderHandler) GetReminders(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	reminders, err := h.reminderService.GetRecentReminders(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Ablauferinnerungen"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reminders": reminders})
+}
+
+// GetSettings gibt die Einstellungen der Ablauferinnerungen zurück
+func (h *ExpiryReminderHandler) GetSettings(c *gin.Context) {
+	settings, err := h.reminderService.GetSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Einstellungen"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// SaveSettings speichert die Einstellungen der Ablauferinnerungen
+func (h *ExpiryReminderHandler) SaveSettings(c *gin.Context) {
+	var request struct {
+		Enabled        bool  `json:"enabled"`
+		OffsetsDays    []int `json:"offsetsDays"`
+		NotifyDrivers  bool  `json:"notifyDrivers"`
+		NotifyManagers bool  `json:"notifyManagers"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings := &model.ExpiryReminderSettings{
+		Enabled:        request.Enabled,
+		OffsetsDays:    request.OffsetsDays,
+		NotifyDrivers:  request.NotifyDrivers,
+		NotifyManagers: request.NotifyManagers,
+	}
+
+	if err := h.reminderService.SaveSettings(settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Einstellungen erfolgreich gespeichert",
+		"settings": settings,
+	})
+}
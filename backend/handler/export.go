@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFormat ist das Dateiformat eines Listen-Exports
+type exportFormat string
+
+const (
+	exportFormatCSV  exportFormat = "csv"
+	exportFormatXLSX exportFormat = "xlsx"
+
+	// exportFlushRows ist die Anzahl Zeilen, nach der die Antwort an den Client übertragen wird
+	exportFlushRows = 500
+	// exportPageSize ist die Seitengröße beim Export paginierter Listen
+	exportPageSize = 500
+)
+
+// exportDate kennzeichnet ein Datum ohne Uhrzeit in einer Exportzeile
+type exportDate time.Time
+
+// requestedExport liest ?format=csv|xlsx aus. Ohne Angabe oder mit json bleibt es bei JSON;
+// bei einem unbekannten Format wird 400 gesendet und ok ist false.
+func requestedExport(c *gin.Context) (format exportFormat, ok bool) {
+	switch strings.ToLower(c.Query("format")) {
+	case "", "json":
+		return "", true
+	case "csv":
+		return exportFormatCSV, true
+	case "xlsx":
+		return exportFormatXLSX, true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Format, erlaubt sind csv, xlsx und json"})
+	return "", false
+}
+
+// tableExport schreibt eine Liste zeilenweise als CSV oder XLSX in die Antwort, ohne die
+// Datei im Speicher aufzubauen. CSV verwendet Dezimalkomma und deutsches Datumsformat,
+// XLSX echte Zahlen- und Datumszellen.
+type tableExport struct {
+	c        *gin.Context
+	csv      *csv.Writer
+	xlsx     *xlsxWriter
+	location *time.Location
+	rows     int
+	err      error
+}
+
+// startExport sendet die Kopfzeilen der Antwort und die Spaltenüberschriften
+func startExport(c *gin.Context, format exportFormat, name string, header ...string) *tableExport {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		location = time.Local
+	}
+	e := &tableExport{c: c, location: location}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().In(location).Format("2006-01-02"), format)
+	if format == exportFormatXLSX {
+		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	} else {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Status(http.StatusOK)
+
+	if format == exportFormatXLSX {
+		e.xlsx, e.err = newXLSXWriter(c.Writer, name)
+		if e.err == nil {
+			cells := make([]xlsxCell, len(header))
+			for i, title := range header {
+				cells[i] = xlsxCell{text: title, isText: true, style: xlsxStyleHeader}
+			}
+			e.err = e.xlsx.WriteRow(cells)
+		}
+		return e
+	}
+
+	// UTF-8-BOM und Semikolon wie bei writeCSV, damit Excel die Datei direkt öffnet
+	if _, e.err = c.Writer.Write([]byte("\xEF\xBB\xBF")); e.err != nil {
+		return e
+	}
+	e.csv = csv.NewWriter(c.Writer)
+	e.csv.Comma = ';'
+	e.err = e.csv.Write(header)
+	return e
+}
+
+// Row schreibt eine Zeile. Unterstützt werden string, int, float64 (zwei Nachkommastellen),
+// bool, time.Time (Datum mit Uhrzeit), exportDate und *time.Time; nil und Nullzeiten bleiben leer.
+// Nach einem Schreibfehler, etwa einem abgebrochenen Download, liefert Row nur noch den Fehler.
+func (e *tableExport) Row(values ...interface{}) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.xlsx != nil {
+		cells := make([]xlsxCell, len(values))
+		for i, value := range values {
+			cells[i] = e.xlsxCell(value)
+		}
+		e.err = e.xlsx.WriteRow(cells)
+	} else {
+		record := make([]string, len(values))
+		for i, value := range values {
+			record[i] = e.csvValue(value)
+		}
+		e.err = e.csv.Write(record)
+	}
+
+	e.rows++
+	if e.err == nil && e.rows%exportFlushRows == 0 {
+		e.flush()
+	}
+	return e.err
+}
+
+// Close schließt die Datei ab und überträgt den Rest der Antwort
+func (e *tableExport) Close() {
+	if e.err != nil {
+		return
+	}
+	if e.xlsx != nil {
+		e.err = e.xlsx.Close()
+	}
+	e.flush()
+}
+
+func (e *tableExport) flush() {
+	if e.csv != nil {
+		e.csv.Flush()
+		if e.err == nil {
+			e.err = e.csv.Error()
+		}
+	}
+	e.c.Writer.Flush()
+}
+
+func (e *tableExport) csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		// Formeln aus Freitextfeldern nicht von der Tabellenkalkulation ausführen lassen
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return csvAmount(v)
+	case bool:
+		if v {
+			return "Ja"
+		}
+		return "Nein"
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.In(e.location).Format("02.01.2006 15:04")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return e.csvValue(*v)
+	case exportDate:
+		if time.Time(v).IsZero() {
+			return ""
+		}
+		return time.Time(v).In(e.location).Format("02.01.2006")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (e *tableExport) xlsxCell(value interface{}) xlsxCell {
+	switch v := value.(type) {
+	case nil:
+		return xlsxCell{empty: true}
+	case string:
+		return xlsxCell{text: v, isText: true, empty: v == ""}
+	case int:
+		return xlsxCell{number: float64(v), style: xlsxStyleInteger}
+	case float64:
+		return xlsxCell{number: v, style: xlsxStyleAmount}
+	case bool:
+		return e.xlsxCell(e.csvValue(v))
+	case time.Time:
+		if v.IsZero() {
+			return xlsxCell{empty: true}
+		}
+		return xlsxCell{number: xlsxSerial(v, e.location), style: xlsxStyleDateTime}
+	case *time.Time:
+		if v == nil {
+			return xlsxCell{empty: true}
+		}
+		return e.xlsxCell(*v)
+	case exportDate:
+		if time.Time(v).IsZero() {
+			return xlsxCell{empty: true}
+		}
+		return xlsxCell{number: float64(int(xlsxSerial(time.Time(v), e.location))), style: xlsxStyleDate}
+	default:
+		return xlsxCell{text: fmt.Sprint(v), isText: true}
+	}
+}
+
+// exportText liefert den Anzeigenamen eines Aufzählungswerts, ohne Eintrag den Wert selbst
+func exportText[K ~string](texts map[K]string, value K) string {
+	if text, ok := texts[value]; ok {
+		return text
+	}
+	return string(value)
+}
+
+// exportPages schreibt die bereits geladene erste Seite und alle weiteren Seiten von fetch
+// zeilenweise mit row. Die erste Seite lädt der Aufrufer vor startExport, damit Fehler noch
+// als JSON gemeldet werden können; danach endet der Export beim ersten Lese- oder Schreibfehler.
+func exportPages[T any](page []T, fetch func(limit, skip int) ([]T, error), row func(T) error) {
+	written := 0
+	for len(page) > 0 {
+		for _, item := range page {
+			if err := row(item); err != nil {
+				return
+			}
+		}
+
+		written += len(page)
+		if len(page) < exportPageSize {
+			return
+		}
+		var err error
+		if page, err = fetch(exportPageSize, written); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FinancingHandler stellt Tilgungspläne, die Verbindlichkeiten-Vorschau und die Prüfung der Finanzierungsdaten bereit
+type FinancingHandler struct {
+	financingService *service.FinancingService
+}
+
+// NewFinancingHandler erstellt einen neuen FinancingHandler
+func NewFinancingHandler(services *service.Services) *FinancingHandler {
+	return &FinancingHandler{
+		financingService: services.Financing,
+	}
+}
+
+// GetSchedule gibt den Tilgungsplan eines finanzierten Fahrzeugs zurück
+func (h *FinancingHandler) GetSchedule(c *gin.Context) {
+	schedule, err := h.financingService.GetSchedule(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// GetLiabilityForecast gibt die monatlichen Raten und Restschulden der Flotte zurück (?months=, Standard 24)
+func (h *FinancingHandler) GetLiabilityForecast(c *gin.Context) {
+	months := 0
+	if value := c.Query("months"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Anzahl Monate"})
+			return
+		}
+		months = parsed
+	}
+
+	forecast, err := h.financingService.GetLiabilityForecast(months)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, forecast)
+}
+
+// GetIssues gibt alle Unstimmigkeiten in den Finanzierungsdaten der Flotte zurück
+func (h *FinancingHandler) GetIssues(c *gin.Context) {
+	issues, err := h.financingService.GetIssues()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Prüfen der Finanzierungsdaten"})
+		return
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == model.FinancingIssueError {
+			errorCount++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"issues": issues, "count": len(issues), "errors": errorCount})
+}
+
+// respondError übersetzt Fehler des FinancingService in HTTP-Antworten
+func (h *FinancingHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrFinancingVehicleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fahrzeug nicht gefunden"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FuelConsumptionHandler stellt Verbrauchsauswertungen und Auffälligkeiten beim Tanken bereit
+type FuelConsumptionHandler struct {
+	consumptionService *service.FuelConsumptionService
+}
+
+// NewFuelConsumptionHandler erstellt einen neuen FuelConsumptionHandler
+func NewFuelConsumptionHandler(services *service.Services) *FuelConsumptionHandler {
+	return &FuelConsumptionHandler{
+		consumptionService: services.FuelConsumption,
+	}
+}
+
+// GetVehicleConsumption gibt Verbrauchsintervalle, Monatstrend und Auffälligkeiten eines Fahrzeugs zurück
+func (h *FuelConsumptionHandler) GetVehicleConsumption(c *gin.Context) {
+	result, err := h.consumptionService.GetVehicleConsumption(c.Param("vehicleId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetFleetComparison vergleicht den Verbrauch von Fahrzeuggruppen (?groupBy=brand|model|fuelType)
+func (h *FuelConsumptionHandler) GetFleetComparison(c *gin.Context) {
+	groupBy := c.DefaultQuery("groupBy", "model")
+
+	groups, err := h.consumptionService.CompareFleet(groupBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groupBy": groupBy, "groups": groups})
+}
+
+// GetAnomalies gibt die Auffälligkeiten beim Tanken zurück (?from=&to=&vehicleId=&type=)
+func (h *FuelConsumptionHandler) GetAnomalies(c *gin.Context) {
+	var from, to *time.Time
+	if value := c.Query("from"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			return
+		}
+		from = &date
+	}
+	if value := c.Query("to"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
+			return
+		}
+		end := date.AddDate(0, 0, 1) // Enddatum einschließlich
+		to = &end
+	}
+
+	anomalies, err := h.consumptionService.GetAnomalies(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	vehicleID := c.Query("vehicleId")
+	anomalyType := model.FuelAnomalyType(c.Query("type"))
+	filtered := make([]model.FuelAnomaly, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		if vehicleID != "" && anomaly.VehicleID.Hex() != vehicleID {
+			continue
+		}
+		if anomalyType != "" && anomaly.Type != anomalyType {
+			continue
+		}
+		filtered = append(filtered, anomaly)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"anomalies": filtered, "count": len(filtered)})
+}
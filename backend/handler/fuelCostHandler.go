@@ -15,19 +15,19 @@ import (
 
 // FuelCostHandler repräsentiert den Handler für Tankkosten-Operationen
 type FuelCostHandler struct {
-	fuelCostRepo   *repository.FuelCostRepository
-	vehicleRepo    *repository.VehicleRepository
-	driverRepo     *repository.DriverRepository
+	fuelCostRepo   repository.FuelCostRepository
+	vehicleRepo    repository.VehicleRepository
+	driverRepo     repository.DriverRepository
 	mileageService *service.VehicleMileageService
 }
 
 // NewFuelCostHandler erstellt einen neuen FuelCostHandler
-func NewFuelCostHandler() *FuelCostHandler {
+func NewFuelCostHandler(repos *repository.Repositories, services *service.Services) *FuelCostHandler {
 	return &FuelCostHandler{
-		fuelCostRepo:   repository.NewFuelCostRepository(),
-		vehicleRepo:    repository.NewVehicleRepository(),
-		driverRepo:     repository.NewDriverRepository(),
-		mileageService: service.NewVehicleMileageService(),
+		fuelCostRepo:   repos.FuelCost,
+		vehicleRepo:    repos.Vehicle,
+		driverRepo:     repos.Driver,
+		mileageService: services.VehicleMileage,
 	}
 }
 
@@ -48,6 +48,16 @@ type CreateFuelCostRequest struct {
 
 // GetFuelCosts behandelt die Anfrage, alle Tankkosteneinträge abzurufen
 func (h *FuelCostHandler) GetFuelCosts(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
+	if format != "" {
+		h.exportFuelCosts(c, format)
+		return
+	}
+
 	entries, err := h.fuelCostRepo.FindAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Tankkosteneinträge"})
@@ -85,6 +95,40 @@ func (h *FuelCostHandler) GetFuelCosts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"fuelCosts": result})
 }
 
+// exportFuelCosts exportiert die Tankkosteneinträge seitenweise als CSV oder XLSX
+func (h *FuelCostHandler) exportFuelCosts(c *gin.Context, format exportFormat) {
+	entries, err := h.fuelCostRepo.FindPage(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Tankkosteneinträge"})
+		return
+	}
+
+	export := startExport(c, format, "tankkosten",
+		"Datum", "Kennzeichen", "Fahrzeug", "Fahrer", "Kraftstoff", "Menge", "Preis je Einheit",
+		"Gesamtkosten", "Kilometerstand", "Ort", "Belegnummer", "Notizen")
+	defer export.Close()
+
+	exportPages(entries, h.fuelCostRepo.FindPage, func(entry *model.FuelCost) error {
+		licensePlate, vehicleName := "", ""
+		if vehicle, err := h.vehicleRepo.FindByID(entry.VehicleID.Hex()); err == nil {
+			licensePlate = vehicle.LicensePlate
+			vehicleName = vehicle.Brand + " " + vehicle.Model
+		}
+		driverName := ""
+		if !entry.DriverID.IsZero() {
+			if driver, err := h.driverRepo.FindByID(entry.DriverID.Hex()); err == nil {
+				driverName = driver.FirstName + " " + driver.LastName
+			}
+		}
+
+		return export.Row(
+			exportDate(entry.Date), licensePlate, vehicleName, driverName, string(entry.FuelType),
+			entry.Amount, entry.PricePerUnit, entry.TotalCost, entry.Mileage, entry.Location,
+			entry.ReceiptNumber, entry.Notes,
+		)
+	})
+}
+
 // GetVehicleFuelCosts behandelt die Anfrage, alle Tankkosteneinträge für ein Fahrzeug abzurufen
 func (h *FuelCostHandler) GetVehicleFuelCosts(c *gin.Context) {
 	vehicleID := c.Param("vehicleId")
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FuelImportHandler stellt den Import von Tankkartenauszügen bereit
+type FuelImportHandler struct {
+	importService *service.FuelImportService
+}
+
+// NewFuelImportHandler erstellt einen neuen FuelImportHandler
+func NewFuelImportHandler(services *service.Services) *FuelImportHandler {
+	return &FuelImportHandler{
+		importService: services.FuelImport,
+	}
+}
+
+// AssignRowRequest enthält das Fahrzeug, dem eine Zeile der Prüfliste zugeordnet wird
+type AssignRowRequest struct {
+	VehicleID string `json:"vehicleId" binding:"required"`
+}
+
+// GetProviders gibt alle Anbieterformate zurück
+func (h *FuelImportHandler) GetProviders(c *gin.Context) {
+	providers, err := h.importService.GetProviders()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Anbieterformate"})
+		return
+	}
+	if providers == nil {
+		providers = []*model.FuelCardProvider{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"providers": providers})
+}
+
+// CreateProvider legt ein neues Anbieterformat an
+func (h *FuelImportHandler) CreateProvider(c *gin.Context) {
+	var provider model.FuelCardProvider
+	if err := c.ShouldBindJSON(&provider); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.importService.CreateProvider(&provider); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, provider)
+}
+
+// UpdateProvider aktualisiert ein Anbieterformat
+func (h *FuelImportHandler) UpdateProvider(c *gin.Context) {
+	var provider model.FuelCardProvider
+	if err := c.ShouldBindJSON(&provider); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.importService.UpdateProvider(c.Param("id"), &provider); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// DeleteProvider löscht ein Anbieterformat
+func (h *FuelImportHandler) DeleteProvider(c *gin.Context) {
+	if err := h.importService.DeleteProvider(c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Anbieterformat gelöscht"})
+}
+
+// Preview lädt einen Tankkartenauszug hoch und gibt die Vorschau des Imports zurück.
+// Erwartet multipart/form-data mit "file" und "providerId".
+func (h *FuelImportHandler) Preview(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fehler beim Parsen der Formulardaten"})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keine Datei gefunden"})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > 10<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datei zu groß (max. 10MB)"})
+		return
+	}
+
+	providerID := c.PostForm("providerId")
+	if providerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Anbieterformat ist erforderlich"})
+		return
+	}
+
+	result, err := h.importService.Preview(user.ID, providerID, header.Filename, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetBatches gibt die letzten Importe zurück (?limit=)
+func (h *FuelImportHandler) GetBatches(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Limit (1-100)"})
+		return
+	}
+
+	batches, err := h.importService.GetRecentBatches(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Importe"})
+		return
+	}
+	if batches == nil {
+		batches = []*model.FuelImportBatch{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"batches": batches})
+}
+
+// GetBatch gibt einen Import mit allen Zeilen zurück
+func (h *FuelImportHandler) GetBatch(c *gin.Context) {
+	result, err := h.importService.GetBatch(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CommitBatch übernimmt die zugeordneten Zeilen einer Vorschau als Tankkosten
+func (h *FuelImportHandler) CommitBatch(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	result, err := h.importService.Commit(user.ID, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DiscardBatch verwirft eine Vorschau
+func (h *FuelImportHandler) DiscardBatch(c *gin.Context) {
+	if err := h.importService.Discard(c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Import verworfen"})
+}
+
+// GetReviewQueue gibt alle Zeilen zurück, denen noch kein Fahrzeug zugeordnet ist
+func (h *FuelImportHandler) GetReviewQueue(c *gin.Context) {
+	rows, err := h.importService.GetReviewQueue()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Prüfliste"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rows": rows})
+}
+
+// AssignRow ordnet eine Zeile der Prüfliste einem Fahrzeug zu und übernimmt sie als Tankkosten
+func (h *FuelImportHandler) AssignRow(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req AssignRowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	row, err := h.importService.ResolveRow(user.ID, c.Param("rowId"), req.VehicleID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, row)
+}
+
+// DiscardRow entfernt eine Zeile aus der Prüfliste
+func (h *FuelImportHandler) DiscardRow(c *gin.Context) {
+	row, err := h.importService.DiscardRow(c.Param("rowId"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, row)
+}
+
+// respondError übersetzt Fehler des FuelImportService in HTTP-Antworten
+func (h *FuelImportHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrFuelImportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrFuelImportState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandoverHandler verwaltet die Übergabeprotokolle bei Ausgabe und Rückgabe reservierter Fahrzeuge
+type HandoverHandler struct {
+	handoverService *service.HandoverService
+}
+
+// NewHandoverHandler erstellt einen neuen HandoverHandler
+func NewHandoverHandler(services *service.Services) *HandoverHandler {
+	return &HandoverHandler{
+		handoverService: services.Handover,
+	}
+}
+
+// HandoverRequest repräsentiert die Anfrage zum Erfassen eines Übergabeprotokolls
+type HandoverRequest struct {
+	Mileage     int                    `json:"mileage" binding:"required"`
+	EnergyLevel int                    `json:"energyLevel"` // Tank- bzw. Ladestand in Prozent
+	Cleanliness int                    `json:"cleanliness" binding:"required"`
+	Damages     []model.HandoverDamage `json:"damages"`
+	Notes       string                 `json:"notes"`
+}
+
+// input wandelt die Anfrage in die Service-Eingabe um
+func (r HandoverRequest) input() service.HandoverInput {
+	return service.HandoverInput{
+		Mileage:     r.Mileage,
+		EnergyLevel: r.EnergyLevel,
+		Cleanliness: r.Cleanliness,
+		Damages:     r.Damages,
+		Notes:       r.Notes,
+	}
+}
+
+// CheckOut erfasst das Ausgabeprotokoll einer Reservierung
+func (h *HandoverHandler) CheckOut(c *gin.Context) {
+	var req HandoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	handover, err := h.handoverService.CheckOut(user, c.Param("id"), req.input())
+	if err != nil {
+		respondHandoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handover)
+}
+
+// CheckIn erfasst das Rückgabeprotokoll einer Reservierung und schließt sie ab
+func (h *HandoverHandler) CheckIn(c *gin.Context) {
+	var req HandoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	handover, err := h.handoverService.CheckIn(user, c.Param("id"), req.input())
+	if err != nil {
+		respondHandoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handover)
+}
+
+// GetHandovers gibt Aus- und Rückgabeprotokoll einer Reservierung zurück
+func (h *HandoverHandler) GetHandovers(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	handovers, err := h.handoverService.GetHandovers(user, c.Param("id"))
+	if err != nil {
+		respondHandoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handovers)
+}
+
+// UploadPhoto lädt ein Foto zu einem Übergabeprotokoll hoch
+func (h *HandoverHandler) UploadPhoto(c *gin.Context) {
+	// Datei aus Form extrahieren
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keine Datei gefunden"})
+		return
+	}
+	defer file.Close()
+
+	// Datei-Größe prüfen (max 10MB)
+	if header.Size > 10<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Datei zu groß (max. 10MB)"})
+		return
+	}
+
+	allowedTypes := map[string]bool{
+		"image/jpeg": true,
+		"image/jpg":  true,
+		"image/png":  true,
+		"image/webp": true,
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		// Content-Type aus Dateiendung ermitteln
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".jpg", ".jpeg":
+			contentType = "image/jpeg"
+		case ".png":
+			contentType = "image/png"
+		case ".webp":
+			contentType = "image/webp"
+		}
+	}
+
+	if !allowedTypes[contentType] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nur Bildformate sind für Übergabefotos erlaubt"})
+		return
+	}
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Lesen der Datei"})
+		return
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	document, err := h.handoverService.AddPhoto(user, c.Param("handoverId"), header.Filename, contentType, fileData)
+	if err != nil {
+		respondHandoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Foto erfolgreich hochgeladen",
+		"document": gin.H{
+			"id":          document.ID.Hex(),
+			"name":        document.Name,
+			"fileName":    document.FileName,
+			"contentType": document.ContentType,
+			"size":        document.Size,
+		},
+	})
+}
+
+// respondHandoverError übersetzt Fehler des HandoverService in HTTP-Antworten
+func respondHandoverError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrHandoverForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
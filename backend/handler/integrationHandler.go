@@ -13,9 +13,9 @@ type IntegrationHandler struct {
 }
 
 // NewIntegrationHandler erstellt einen neuen IntegrationHandler
-func NewIntegrationHandler() *IntegrationHandler {
+func NewIntegrationHandler(services *service.Services) *IntegrationHandler {
 	return &IntegrationHandler{
-		peopleFlowService: service.NewPeopleFlowService(),
+		peopleFlowService: services.PeopleFlow,
 	}
 }
 
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"FleetFlow/backend/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler stellt die Verwaltung der Hintergrundjobs für Administratoren bereit
+type JobHandler struct {
+	scheduler *service.JobScheduler
+}
+
+// NewJobHandler erstellt einen neuen JobHandler
+func NewJobHandler(services *service.Services) *JobHandler {
+	return &JobHandler{
+		scheduler: services.Scheduler,
+	}
+}
+
+// GetJobs gibt alle registrierten Jobs mit Zeitplan und letztem Ergebnis zurück
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	jobs, err := h.scheduler.Jobs()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Jobs"})
+		return
+	}
+
+	result := make([]gin.H, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, gin.H{
+			"job":     job,
+			"running": job.IsRunning(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":             result,
+		"schedulerRunning": h.scheduler.IsRunning(),
+	})
+}
+
+// GetJobRuns gibt die Laufhistorie eines Jobs zurück
+func (h *JobHandler) GetJobRuns(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+
+	runs, err := h.scheduler.Runs(c.Param("name"), limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// PauseJob pausiert einen Job
+func (h *JobHandler) PauseJob(c *gin.Context) {
+	if err := h.scheduler.Pause(c.Param("name")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job pausiert"})
+}
+
+// ResumeJob setzt einen pausierten Job fort
+func (h *JobHandler) ResumeJob(c *gin.Context) {
+	if err := h.scheduler.Resume(c.Param("name")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job fortgesetzt"})
+}
+
+// TriggerJob startet einen Job sofort im Hintergrund
+func (h *JobHandler) TriggerJob(c *gin.Context) {
+	if err := h.scheduler.Trigger(c.Param("name")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Job gestartet"})
+}
+
+// respondError bildet Scheduler-Fehler auf HTTP-Statuscodes ab
+func (h *JobHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job nicht gefunden"})
+	case errors.Is(err, service.ErrJobRunning):
+		c.JSON(http.StatusConflict, gin.H{"error": "Job wird bereits ausgeführt"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
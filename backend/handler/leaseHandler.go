@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeaseHandler stellt Mehrkilometer-Hochrechnungen, Tauschvorschläge und Leasingrückgaben bereit
+type LeaseHandler struct {
+	leaseService *service.LeaseService
+}
+
+// NewLeaseHandler erstellt einen neuen LeaseHandler
+func NewLeaseHandler(services *service.Services) *LeaseHandler {
+	return &LeaseHandler{
+		leaseService: services.Lease,
+	}
+}
+
+// LeaseReturnStartRequest legt eine Rückgabe vorzeitig an
+type LeaseReturnStartRequest struct {
+	VehicleID string `json:"vehicleId" binding:"required"`
+}
+
+// LeaseReturnRequest enthält Rückgabetermin und Notizen
+type LeaseReturnRequest struct {
+	AppointmentDate string `json:"appointmentDate"` // YYYY-MM-DD
+	Notes           string `json:"notes"`
+}
+
+// LeaseReturnItemRequest hakt einen Checklistenpunkt ab
+type LeaseReturnItemRequest struct {
+	Done  bool   `json:"done"`
+	Notes string `json:"notes"`
+}
+
+// LeaseReturnCompleteRequest enthält die Angaben bei Rückgabe des Fahrzeugs
+type LeaseReturnCompleteRequest struct {
+	ReturnMileage    int     `json:"returnMileage" binding:"required"`
+	SettlementAmount float64 `json:"settlementAmount"` // Ohne Angabe aus den Mehrkilometern berechnet
+	Notes            string  `json:"notes"`
+}
+
+// GetForecasts gibt die Hochrechnung aller laufenden Leasingverträge zurück
+func (h *LeaseHandler) GetForecasts(c *gin.Context) {
+	forecasts, err := h.leaseService.GetForecasts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Hochrechnen der Leasingverträge"})
+		return
+	}
+
+	exceeding := 0
+	for _, forecast := range forecasts {
+		if forecast.OnTrackToExceed {
+			exceeding++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"forecasts": forecasts, "count": len(forecasts), "exceeding": exceeding})
+}
+
+// GetForecast gibt die Hochrechnung für ein Fahrzeug zurück
+func (h *LeaseHandler) GetForecast(c *gin.Context) {
+	forecast, err := h.leaseService.GetForecast(c.Param("vehicleId"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, forecast)
+}
+
+// GetSwapSuggestions gibt Vorschläge zum Tausch von Leasingfahrzeugen zwischen Fahrern zurück
+func (h *LeaseHandler) GetSwapSuggestions(c *gin.Context) {
+	suggestions, err := h.leaseService.GetSwapSuggestions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Ermitteln der Tauschvorschläge"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions, "count": len(suggestions)})
+}
+
+// GetReturns gibt die Leasingrückgaben zurück (?status=open|completed)
+func (h *LeaseHandler) GetReturns(c *gin.Context) {
+	status := model.LeaseReturnStatus(c.Query("status"))
+	switch status {
+	case "", model.LeaseReturnStatusOpen, model.LeaseReturnStatusCompleted:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Status"})
+		return
+	}
+
+	returns, err := h.leaseService.GetReturns(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Leasingrückgaben"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"returns": returns, "count": len(returns)})
+}
+
+// GetReturn gibt eine Leasingrückgabe zurück
+func (h *LeaseHandler) GetReturn(c *gin.Context) {
+	leaseReturn, err := h.leaseService.GetReturn(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"return": leaseReturn, "openItems": leaseReturn.OpenItems()})
+}
+
+// StartReturn legt die Rückgabe-Checkliste vor Beginn der Vorlaufzeit an
+func (h *LeaseHandler) StartReturn(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LeaseReturnStartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaseReturn, err := h.leaseService.StartReturn(user, req.VehicleID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, leaseReturn)
+}
+
+// UpdateReturn ändert Rückgabetermin und Notizen
+func (h *LeaseHandler) UpdateReturn(c *gin.Context) {
+	var req LeaseReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	appointment, err := parseLeaseDate(req.AppointmentDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaseReturn, err := h.leaseService.UpdateReturn(c.Param("id"), service.LeaseReturnInput{
+		AppointmentDate: appointment,
+		Notes:           req.Notes,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, leaseReturn)
+}
+
+// UpdateItem hakt einen Punkt der Checkliste ab oder öffnet ihn wieder
+func (h *LeaseHandler) UpdateItem(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LeaseReturnItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaseReturn, err := h.leaseService.UpdateItem(user, c.Param("id"), c.Param("key"), req.Done, req.Notes)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, leaseReturn)
+}
+
+// CompleteReturn schließt die Rückgabe mit dem Kilometerstand bei Rückgabe ab
+func (h *LeaseHandler) CompleteReturn(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LeaseReturnCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaseReturn, err := h.leaseService.CompleteReturn(user, c.Param("id"), service.LeaseReturnCompletion{
+		ReturnMileage:    req.ReturnMileage,
+		SettlementAmount: req.SettlementAmount,
+		Notes:            req.Notes,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, leaseReturn)
+}
+
+// DeleteReturn löscht eine offene Leasingrückgabe
+func (h *LeaseHandler) DeleteReturn(c *gin.Context) {
+	if err := h.leaseService.DeleteReturn(c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Leasingrückgabe erfolgreich gelöscht"})
+}
+
+// respondError übersetzt Fehler des LeaseService in HTTP-Antworten
+func (h *LeaseHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrLeaseReturnNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leasingrückgabe nicht gefunden"})
+	case errors.Is(err, service.ErrLeaseReturnExists), errors.Is(err, service.ErrLeaseReturnState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// parseLeaseDate wandelt ein optionales Datum (YYYY-MM-DD) um
+func parseLeaseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiges datum: %s", value)
+	}
+	return &t, nil
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LogbookHandler stellt das elektronische Fahrtenbuch bereit
+type LogbookHandler struct {
+	logbookService *service.LogbookService
+}
+
+// NewLogbookHandler erstellt einen neuen LogbookHandler
+func NewLogbookHandler(services *service.Services) *LogbookHandler {
+	return &LogbookHandler{
+		logbookService: services.Logbook,
+	}
+}
+
+// LogbookEntryRequest enthält die Angaben einer Fahrt
+type LogbookEntryRequest struct {
+	VehicleID        string         `json:"vehicleId"`
+	DriverID         string         `json:"driverId"`
+	Date             string         `json:"date" binding:"required"` // YYYY-MM-DD
+	StartMileage     int            `json:"startMileage"`
+	EndMileage       int            `json:"endMileage" binding:"required"`
+	Route            string         `json:"route"`
+	BusinessPartner  string         `json:"businessPartner"`
+	Purpose          string         `json:"purpose"`
+	TripType         model.TripType `json:"tripType" binding:"required"`
+	CorrectionReason string         `json:"correctionReason"`
+}
+
+// input wandelt die Anfrage in die Eingabe des LogbookService um
+func (r *LogbookEntryRequest) input() (service.LogbookInput, error) {
+	date, err := time.Parse("2006-01-02", r.Date)
+	if err != nil {
+		return service.LogbookInput{}, fmt.Errorf("ungültiges Datum, erwartet wird YYYY-MM-DD")
+	}
+
+	return service.LogbookInput{
+		VehicleID:        r.VehicleID,
+		DriverID:         r.DriverID,
+		Date:             date,
+		StartMileage:     r.StartMileage,
+		EndMileage:       r.EndMileage,
+		Route:            r.Route,
+		BusinessPartner:  r.BusinessPartner,
+		Purpose:          r.Purpose,
+		TripType:         r.TripType,
+		CorrectionReason: r.CorrectionReason,
+	}, nil
+}
+
+// GetEntries gibt die aktuellen Fahrtenbucheinträge zurück (?vehicleId=&driverId=&from=&to=)
+func (h *LogbookHandler) GetEntries(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var filter model.LogbookFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	if filter.DriverID, err = optionalObjectID(c.Query("driverId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrer-ID"})
+		return
+	}
+	if from := c.Query("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			return
+		}
+		filter.From = &date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
+			return
+		}
+		end := date.AddDate(0, 0, 1) // Enddatum einschließlich
+		filter.To = &end
+	}
+
+	entries, err := h.logbookService.GetEntries(user, filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries, "summary": service.SummarizeLogbook(entries)})
+}
+
+// CreateEntry erfasst eine neue Fahrt
+func (h *LogbookHandler) CreateEntry(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LogbookEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := req.input()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.logbookService.CreateEntry(user, input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetEntry gibt die aktuelle Version eines Eintrags zurück
+func (h *LogbookHandler) GetEntry(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	entry, err := h.logbookService.GetEntry(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateEntry ändert einen Eintrag innerhalb der Bearbeitungsfrist
+func (h *LogbookHandler) UpdateEntry(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LogbookEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := req.input()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.logbookService.UpdateEntry(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// CorrectEntry legt eine Korrekturversion eines Eintrags an
+func (h *LogbookHandler) CorrectEntry(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req LogbookEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := req.input()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.logbookService.CorrectEntry(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetVersions gibt alle Versionen eines Eintrags zurück
+func (h *LogbookHandler) GetVersions(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	versions, err := h.logbookService.GetVersions(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// ExportYear exportiert das Fahrtenbuch eines Jahres (?year=&vehicleId=&driverId=&format=csv|json)
+func (h *LogbookHandler) ExportYear(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+	if err != nil || year < 2000 || year > 2100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Jahr"})
+		return
+	}
+	vehicleID, err := optionalObjectID(c.Query("vehicleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	driverID, err := optionalObjectID(c.Query("driverId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrer-ID"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Format, erlaubt sind csv und json"})
+		return
+	}
+
+	annual, err := h.logbookService.GetAnnualLog(user, year, vehicleID, driverID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, annual)
+		return
+	}
+
+	header := []string{"Nr.", "Datum", "Kennzeichen", "Fahrer", "Km-Stand Beginn", "Km-Stand Ende", "Gefahrene km",
+		"Fahrtart", "Reiseroute", "Geschäftspartner", "Zweck", "Version", "Korrekturgrund"}
+	rows := make([][]string, 0, len(annual.Entries))
+	for _, row := range annual.Entries {
+		rows = append(rows, []string{
+			strconv.Itoa(row.Sequence),
+			row.Date.Format("02.01.2006"),
+			row.LicensePlate,
+			row.DriverName,
+			strconv.Itoa(row.StartMileage),
+			strconv.Itoa(row.EndMileage),
+			strconv.Itoa(row.Distance),
+			model.TripTypeText[row.TripType],
+			row.Route,
+			row.BusinessPartner,
+			row.Purpose,
+			strconv.Itoa(row.Version),
+			row.CorrectionReason,
+		})
+	}
+
+	writeCSV(c, fmt.Sprintf("fahrtenbuch-%d.csv", year), header, rows)
+}
+
+// respondError übersetzt Fehler des LogbookService in HTTP-Antworten
+func (h *LogbookHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrLogbookEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fahrtenbucheintrag nicht gefunden"})
+	case errors.Is(err, service.ErrLogbookEntryLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLogbookForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// optionalObjectID wandelt einen optionalen Query-Parameter in eine ObjectID um
+func optionalObjectID(value string) (*primitive.ObjectID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
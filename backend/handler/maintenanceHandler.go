@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 	"time"
 
@@ -15,17 +16,19 @@ import (
 
 // MaintenanceHandler repräsentiert den Handler für Wartungs-Operationen
 type MaintenanceHandler struct {
-	maintenanceRepo   *repository.MaintenanceRepository
-	vehicleRepo       *repository.VehicleRepository
+	maintenanceRepo   repository.MaintenanceRepository
+	vehicleRepo       repository.VehicleRepository
 	mileageService    *service.VehicleMileageService
+	planService       *service.MaintenancePlanService
 }
 
 // NewMaintenanceHandler erstellt einen neuen MaintenanceHandler
-func NewMaintenanceHandler() *MaintenanceHandler {
+func NewMaintenanceHandler(repos *repository.Repositories, services *service.Services) *MaintenanceHandler {
 	return &MaintenanceHandler{
-		maintenanceRepo: repository.NewMaintenanceRepository(),
-		vehicleRepo:     repository.NewVehicleRepository(),
-		mileageService:  service.NewVehicleMileageService(),
+		maintenanceRepo: repos.Maintenance,
+		vehicleRepo:     repos.Vehicle,
+		mileageService:  services.VehicleMileage,
+		planService:     services.MaintenancePlan,
 	}
 }
 
@@ -42,6 +45,16 @@ type CreateMaintenanceRequest struct {
 
 // GetMaintenanceEntries behandelt die Anfrage, alle Wartungseinträge abzurufen
 func (h *MaintenanceHandler) GetMaintenanceEntries(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
+	if format != "" {
+		h.exportMaintenanceEntries(c, format)
+		return
+	}
+
 	entries, err := h.maintenanceRepo.FindAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Wartungseinträge"})
@@ -70,6 +83,40 @@ func (h *MaintenanceHandler) GetMaintenanceEntries(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"maintenance": result})
 }
 
+// exportMaintenanceEntries exportiert die Wartungseinträge seitenweise als CSV oder XLSX
+func (h *MaintenanceHandler) exportMaintenanceEntries(c *gin.Context, format exportFormat) {
+	entries, err := h.maintenanceRepo.FindPage(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Wartungseinträge"})
+		return
+	}
+
+	export := startExport(c, format, "wartungen",
+		"Datum", "Kennzeichen", "Fahrzeug", "Art", "Kilometerstand", "Kosten", "Werkstatt", "Notizen")
+	defer export.Close()
+
+	exportPages(entries, h.maintenanceRepo.FindPage, func(entry *model.Maintenance) error {
+		licensePlate, vehicleName := "", ""
+		if vehicle, err := h.vehicleRepo.FindByID(entry.VehicleID.Hex()); err == nil {
+			licensePlate = vehicle.LicensePlate
+			vehicleName = vehicle.Brand + " " + vehicle.Model
+		}
+		// 0 bedeutet keine Angabe und bleibt im Export leer
+		var mileage, cost interface{}
+		if entry.Mileage > 0 {
+			mileage = entry.Mileage
+		}
+		if entry.Cost > 0 {
+			cost = entry.Cost
+		}
+
+		return export.Row(
+			exportDate(entry.Date), licensePlate, vehicleName, exportText(model.MaintenanceTypeText, entry.Type),
+			mileage, cost, entry.Workshop, entry.Notes,
+		)
+	})
+}
+
 // GetVehicleMaintenanceEntries behandelt die Anfrage, alle Wartungseinträge für ein Fahrzeug abzurufen
 func (h *MaintenanceHandler) GetVehicleMaintenanceEntries(c *gin.Context) {
 	vehicleID := c.Param("vehicleId")
@@ -167,6 +214,11 @@ func (h *MaintenanceHandler) CreateMaintenanceEntry(c *gin.Context) {
 		// log.Printf("Fehler beim Aktualisieren des Kilometerstands: %v", err)
 	}
 
+	// Passende Wartungspläne zurücksetzen
+	if err := h.planService.CompleteFromMaintenance(entry); err != nil {
+		log.Printf("Fehler beim Zurücksetzen der Wartungspläne: %v", err)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{"maintenance": entry})
 }
 
@@ -235,6 +287,11 @@ func (h *MaintenanceHandler) UpdateMaintenanceEntry(c *gin.Context) {
 		// log.Printf("Fehler beim Aktualisieren des Kilometerstands: %v", err)
 	}
 
+	// Passende Wartungspläne zurücksetzen, falls Typ oder Datum geändert wurden
+	if err := h.planService.CompleteFromMaintenance(entry); err != nil {
+		log.Printf("Fehler beim Zurücksetzen der Wartungspläne: %v", err)
+	}
+
 	c.JSON(http.StatusOK, gin.H{"maintenance": entry})
 }
 
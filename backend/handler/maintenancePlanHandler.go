@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenancePlanHandler stellt Wartungspläne und die daraus fälligen Wartungen bereit
+type MaintenancePlanHandler struct {
+	planService *service.MaintenancePlanService
+}
+
+// NewMaintenancePlanHandler erstellt einen neuen MaintenancePlanHandler
+func NewMaintenancePlanHandler(services *service.Services) *MaintenancePlanHandler {
+	return &MaintenancePlanHandler{
+		planService: services.MaintenancePlan,
+	}
+}
+
+// MaintenancePlanRequest enthält die Angaben eines Wartungsplans
+type MaintenancePlanRequest struct {
+	Name           string                `json:"name" binding:"required"`
+	Type           model.MaintenanceType `json:"type" binding:"required"`
+	VehicleID      string                `json:"vehicleId"` // Plan für ein einzelnes Fahrzeug ...
+	Brand          string                `json:"brand"`     // ... oder für eine Marke
+	Model          string                `json:"model"`     // Optional: nur dieses Modell der Marke
+	IntervalKm     int                   `json:"intervalKm"`
+	IntervalMonths int                   `json:"intervalMonths"`
+	LeadKm         int                   `json:"leadKm"`   // Optional: Standard 1000 km
+	LeadDays       int                   `json:"leadDays"` // Optional: Standard 30 Tage
+	Active         *bool                 `json:"active"`   // Optional: Standard aktiv
+	Notes          string                `json:"notes"`
+}
+
+// input wandelt die Anfrage in die Eingabe des MaintenancePlanService um
+func (r *MaintenancePlanRequest) input() service.MaintenancePlanInput {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return service.MaintenancePlanInput{
+		Name:           r.Name,
+		Type:           r.Type,
+		VehicleID:      r.VehicleID,
+		Brand:          r.Brand,
+		Model:          r.Model,
+		IntervalKm:     r.IntervalKm,
+		IntervalMonths: r.IntervalMonths,
+		LeadKm:         r.LeadKm,
+		LeadDays:       r.LeadDays,
+		Active:         active,
+		Notes:          r.Notes,
+	}
+}
+
+// GetPlans gibt alle Wartungspläne zurück
+func (h *MaintenancePlanHandler) GetPlans(c *gin.Context) {
+	plans, err := h.planService.GetPlans()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Wartungspläne"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"plans": plans})
+}
+
+// GetPlan gibt einen Wartungsplan mit seinen Zyklen zurück
+func (h *MaintenancePlanHandler) GetPlan(c *gin.Context) {
+	plan, err := h.planService.GetPlan(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	dues, err := h.planService.GetDues(model.MaintenanceDueFilter{PlanID: &plan.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Wartungszyklen"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"plan": plan, "dues": dues})
+}
+
+// CreatePlan legt einen Wartungsplan an
+func (h *MaintenancePlanHandler) CreatePlan(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req MaintenancePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.planService.CreatePlan(user, req.input())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
+
+// UpdatePlan ändert einen Wartungsplan
+func (h *MaintenancePlanHandler) UpdatePlan(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req MaintenancePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.planService.UpdatePlan(user, c.Param("id"), req.input())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// DeletePlan löscht einen Wartungsplan mit allen Zyklen
+func (h *MaintenancePlanHandler) DeletePlan(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.planService.DeletePlan(user, c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Wartungsplan gelöscht"})
+}
+
+// GetDues gibt die Wartungszyklen aller Pläne zurück (?vehicleId=&status=due,overdue)
+// Ohne Status werden alle offenen Zyklen geliefert.
+func (h *MaintenancePlanHandler) GetDues(c *gin.Context) {
+	var filter model.MaintenanceDueFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	filter.Type = model.MaintenanceType(c.Query("type"))
+
+	statuses := c.DefaultQuery("status", "scheduled,due,overdue")
+	for _, value := range strings.Split(statuses, ",") {
+		status := model.MaintenanceDueStatus(strings.TrimSpace(value))
+		if _, ok := model.MaintenanceDueStatusText[status]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Status, erlaubt sind scheduled, due, overdue und completed"})
+			return
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	dues, err := h.planService.GetDues(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der fälligen Wartungen"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dues": dues, "count": len(dues)})
+}
+
+// respondError übersetzt Fehler des MaintenancePlanService in HTTP-Antworten
+func (h *MaintenancePlanHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMaintenancePlanNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Wartungsplan nicht gefunden"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
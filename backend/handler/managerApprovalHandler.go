@@ -13,16 +13,16 @@ import (
 // ManagerApprovalHandler verwaltet die Manager-Genehmigungsseite
 type ManagerApprovalHandler struct {
 	reservationService *service.ReservationService
-	vehicleRepo        *repository.VehicleRepository
-	driverRepo         *repository.DriverRepository
+	vehicleRepo        repository.VehicleRepository
+	driverRepo         repository.DriverRepository
 }
 
 // NewManagerApprovalHandler erstellt einen neuen ManagerApprovalHandler
-func NewManagerApprovalHandler() *ManagerApprovalHandler {
+func NewManagerApprovalHandler(repos *repository.Repositories, services *service.Services) *ManagerApprovalHandler {
 	return &ManagerApprovalHandler{
-		reservationService: service.NewReservationService(),
-		vehicleRepo:        repository.NewVehicleRepository(),
-		driverRepo:         repository.NewDriverRepository(),
+		reservationService: services.Reservation,
+		vehicleRepo:        repos.Vehicle,
+		driverRepo:         repos.Driver,
 	}
 }
 
@@ -18,9 +18,9 @@ type PeopleFlowHandler struct {
 }
 
 // NewPeopleFlowHandler erstellt einen neuen PeopleFlowHandler
-func NewPeopleFlowHandler() *PeopleFlowHandler {
+func NewPeopleFlowHandler(services *service.Services) *PeopleFlowHandler {
 	return &PeopleFlowHandler{
-		service: service.NewPeopleFlowService(), // Zurück zum originalen Service
+		service: services.PeopleFlow, // Zurück zum originalen Service
 	}
 }
 
@@ -2,7 +2,6 @@
 package handler
 
 import (
-	"FleetFlow/backend/db"
 	"FleetFlow/backend/model"
 	"FleetFlow/backend/repository"
 	"io"
@@ -11,22 +10,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/gridfs"
 )
 
 // ProfileHandler verwaltet die Profiloperationen
 type ProfileHandler struct {
-	userRepo    *repository.UserRepository
-	bookingRepo *repository.VehicleUsageRepository // Neu: für Buchungen/Nutzungen
-	vehicleRepo *repository.VehicleRepository      // Neu: für Statistiken
+	userRepo    repository.UserRepository
+	bookingRepo repository.VehicleUsageRepository // Neu: für Buchungen/Nutzungen
+	vehicleRepo repository.VehicleRepository      // Neu: für Statistiken
+	fileRepo    repository.FileRepository         // Ablage der Profilbilder
+	smtpRepo    repository.SMTPRepository         // Benachrichtigungseinstellungen
 }
 
 // NewProfileHandler erstellt einen neuen ProfileHandler
-func NewProfileHandler() *ProfileHandler {
+func NewProfileHandler(repos *repository.Repositories) *ProfileHandler {
 	return &ProfileHandler{
-		userRepo:    repository.NewUserRepository(),
-		bookingRepo: repository.NewVehicleUsageRepository(),
-		vehicleRepo: repository.NewVehicleRepository(),
+		userRepo:    repos.User,
+		bookingRepo: repos.VehicleUsage,
+		vehicleRepo: repos.Vehicle,
+		fileRepo:    repos.Files,
+		smtpRepo:    repos.SMTP,
 	}
 }
 
@@ -252,29 +254,105 @@ func (h *ProfileHandler) ChangePassword(c *gin.Context) {
 
 // GetNotificationSettings holt die Benachrichtigungseinstellungen des Benutzers
 func (h *ProfileHandler) GetNotificationSettings(c *gin.Context) {
-	// Hier würden normalerweise die Benachrichtigungseinstellungen aus der Datenbank geladen
-	// Beispielhafte Implementierung:
-	settings := gin.H{
-		"emailNotifications": true,
-		"bookingReminders":   true,
-		"fuelReminders":      true,
-		"maintenanceAlerts":  false,
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	settings, err := h.smtpRepo.GetNotificationSettings(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Benachrichtigungseinstellungen"})
+		return
 	}
 
 	c.JSON(http.StatusOK, settings)
 }
 
+// NotificationSettingsInput repräsentiert die Eingabedaten für die Benachrichtigungseinstellungen.
+// Nicht übermittelte Felder behalten den gespeicherten Wert bzw. den Standardwert.
+type NotificationSettingsInput struct {
+	EmailNotifications   *bool  `json:"emailNotifications"`
+	BookingReminders     *bool  `json:"bookingReminders"`
+	FuelReminders        *bool  `json:"fuelReminders"`
+	MaintenanceAlerts    *bool  `json:"maintenanceAlerts"`
+	UrgentReports        *bool  `json:"urgentReports"`
+	ReservationRequests  *bool  `json:"reservationRequests"`
+	ReservationDecisions *bool  `json:"reservationDecisions"`
+	ExpiryReminders      *bool  `json:"expiryReminders"`
+	QuietHoursEnabled    *bool  `json:"quietHoursEnabled"`
+	QuietHoursStart      string `json:"quietHoursStart"`
+	QuietHoursEnd        string `json:"quietHoursEnd"`
+}
+
+// apply überträgt die übermittelten Felder auf die gespeicherten Einstellungen
+func (input *NotificationSettingsInput) apply(settings *model.NotificationSettings) {
+	set := func(target *bool, value *bool) {
+		if value != nil {
+			*target = *value
+		}
+	}
+	set(&settings.EmailNotifications, input.EmailNotifications)
+	set(&settings.BookingReminders, input.BookingReminders)
+	set(&settings.FuelReminders, input.FuelReminders)
+	set(&settings.MaintenanceAlerts, input.MaintenanceAlerts)
+	set(&settings.UrgentReports, input.UrgentReports)
+	set(&settings.ReservationRequests, input.ReservationRequests)
+	set(&settings.ReservationDecisions, input.ReservationDecisions)
+	set(&settings.ExpiryReminders, input.ExpiryReminders)
+	set(&settings.QuietHoursEnabled, input.QuietHoursEnabled)
+	if input.QuietHoursStart != "" {
+		settings.QuietHoursStart = input.QuietHoursStart
+	}
+	if input.QuietHoursEnd != "" {
+		settings.QuietHoursEnd = input.QuietHoursEnd
+	}
+}
+
 // UpdateNotificationSettings aktualisiert die Benachrichtigungseinstellungen
 func (h *ProfileHandler) UpdateNotificationSettings(c *gin.Context) {
-	var settings map[string]bool
-	if err := c.ShouldBindJSON(&settings); err != nil {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var input NotificationSettingsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Eingabedaten"})
 		return
 	}
 
-	// Hier würden die Einstellungen in der Datenbank gespeichert
-	// Beispielhafte Implementierung:
-	log.Printf("Benachrichtigungseinstellungen aktualisiert: %v", settings)
+	// Gespeicherte Einstellungen (oder Standardwerte) als Grundlage, damit fehlende Felder nichts abschalten
+	settings, err := h.smtpRepo.GetNotificationSettings(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Benachrichtigungseinstellungen"})
+		return
+	}
+	input.apply(settings)
+
+	// Leere Uhrzeiten fallen auf die Standard-Ruhezeit zurück
+	if settings.QuietHoursStart == "" {
+		settings.QuietHoursStart = "22:00"
+	}
+	if settings.QuietHoursEnd == "" {
+		settings.QuietHoursEnd = "07:00"
+	}
+	start, okStart := model.ParseClock(settings.QuietHoursStart)
+	end, okEnd := model.ParseClock(settings.QuietHoursEnd)
+	if !okStart || !okEnd {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ruhezeiten müssen im Format HH:MM angegeben werden"})
+		return
+	}
+	if settings.QuietHoursEnabled && start == end {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Beginn und Ende der Ruhezeit dürfen nicht gleich sein"})
+		return
+	}
+
+	if err := h.smtpRepo.SaveNotificationSettings(settings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Speichern der Benachrichtigungseinstellungen"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Benachrichtigungseinstellungen erfolgreich gespeichert",
@@ -322,34 +400,14 @@ func (h *ProfileHandler) UploadProfilePicture(c *gin.Context) {
 		h.deleteProfilePictureFile(*user.ProfilePicture)
 	}
 
-	// GridFS Bucket erstellen
-	bucket, err := gridfs.NewBucket(db.DBClient.Database("FleetFlow"))
+	// Datei hochladen
+	fileID, err := h.fileRepo.Upload("profile_picture_"+user.ID.Hex(), file)
 	if err != nil {
-		log.Printf("Fehler beim Erstellen des GridFS Buckets: %v", err)
+		log.Printf("Fehler beim Hochladen der Datei: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Speichern der Datei"})
 		return
 	}
 
-	// Datei in GridFS hochladen
-	uploadStream, err := bucket.OpenUploadStream("profile_picture_"+user.ID.Hex())
-	if err != nil {
-		log.Printf("Fehler beim Öffnen des Upload Streams: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Speichern der Datei"})
-		return
-	}
-	defer uploadStream.Close()
-
-	// Datei kopieren
-	_, err = io.Copy(uploadStream, file)
-	if err != nil {
-		log.Printf("Fehler beim Kopieren der Datei: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Speichern der Datei"})
-		return
-	}
-
-	// File ID holen
-	fileID := uploadStream.FileID.(primitive.ObjectID)
-
 	// User Profil mit neuer Bild-ID aktualisieren
 	user.ProfilePicture = &fileID
 	if err := h.userRepo.Update(user); err != nil {
@@ -387,16 +445,8 @@ func (h *ProfileHandler) GetProfilePicture(c *gin.Context) {
 		return
 	}
 
-	// GridFS Bucket erstellen
-	bucket, err := gridfs.NewBucket(db.DBClient.Database("FleetFlow"))
-	if err != nil {
-		log.Printf("Fehler beim Erstellen des GridFS Buckets: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Datei"})
-		return
-	}
-
-	// Datei aus GridFS laden
-	downloadStream, err := bucket.OpenDownloadStream(*user.ProfilePicture)
+	// Datei laden
+	downloadStream, err := h.fileRepo.Open(*user.ProfilePicture)
 	if err != nil {
 		log.Printf("Fehler beim Öffnen des Download Streams: %v", err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Profilbild nicht gefunden"})
@@ -458,12 +508,7 @@ func (h *ProfileHandler) DeleteProfilePicture(c *gin.Context) {
 	})
 }
 
-// deleteProfilePictureFile ist eine Hilfsfunktion zum Löschen von Dateien aus GridFS
+// deleteProfilePictureFile ist eine Hilfsfunktion zum Löschen von Profilbildern
 func (h *ProfileHandler) deleteProfilePictureFile(fileID primitive.ObjectID) error {
-	bucket, err := gridfs.NewBucket(db.DBClient.Database("FleetFlow"))
-	if err != nil {
-		return err
-	}
-
-	return bucket.Delete(fileID)
+	return h.fileRepo.Delete(fileID)
 }
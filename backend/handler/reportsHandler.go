@@ -3,6 +3,7 @@ package handler
 import (
 	"FleetFlow/backend/model"
 	"FleetFlow/backend/repository"
+	"math"
 	"net/http"
 	"sort"
 	"time"
@@ -12,21 +13,25 @@ import (
 
 // ReportsHandler repräsentiert den Handler für Reports und Statistiken
 type ReportsHandler struct {
-	vehicleRepo     *repository.VehicleRepository
-	driverRepo      *repository.DriverRepository
-	maintenanceRepo *repository.MaintenanceRepository
-	fuelCostRepo    *repository.FuelCostRepository
-	usageRepo       *repository.VehicleUsageRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	maintenanceRepo repository.MaintenanceRepository
+	fuelCostRepo    repository.FuelCostRepository
+	usageRepo       repository.VehicleUsageRepository
+	fineRepo        repository.TrafficFineRepository
+	claimRepo       repository.AccidentClaimRepository
 }
 
 // NewReportsHandler erstellt einen neuen ReportsHandler
-func NewReportsHandler() *ReportsHandler {
+func NewReportsHandler(repos *repository.Repositories) *ReportsHandler {
 	return &ReportsHandler{
-		vehicleRepo:     repository.NewVehicleRepository(),
-		driverRepo:      repository.NewDriverRepository(),
-		maintenanceRepo: repository.NewMaintenanceRepository(),
-		fuelCostRepo:    repository.NewFuelCostRepository(),
-		usageRepo:       repository.NewVehicleUsageRepository(),
+		vehicleRepo:     repos.Vehicle,
+		driverRepo:      repos.Driver,
+		maintenanceRepo: repos.Maintenance,
+		fuelCostRepo:    repos.FuelCost,
+		usageRepo:       repos.VehicleUsage,
+		fineRepo:        repos.TrafficFine,
+		claimRepo:       repos.AccidentClaim,
 	}
 }
 
@@ -40,6 +45,7 @@ type VehicleStats struct {
 	Mileage          int     `json:"mileage"`
 	FuelCosts        float64 `json:"fuelCosts"`
 	MaintenanceCosts float64 `json:"maintenanceCosts"`
+	ClaimCosts       float64 `json:"claimCosts"` // Schadenkosten nach Erstattungen, soweit nicht als Wartung erfasst
 	CostPerKm        float64 `json:"costPerKm"`
 	Utilization      float64 `json:"utilization"`
 	Trips            int     `json:"trips"`
@@ -53,10 +59,18 @@ type DriverStats struct {
 	TotalKilometers int     `json:"totalKilometers"`
 	TotalTrips      int     `json:"totalTrips"`
 	AvgKmPerTrip    float64 `json:"avgKmPerTrip"`
+	Fines           int     `json:"fines"`      // Zugeordnete Bußgelder ohne eingestellte Verfahren
+	FinePoints      int     `json:"finePoints"` // Punkte im Fahreignungsregister
+	FineAmount      float64 `json:"fineAmount"`
 }
 
 // GetReportsStats liefert die Hauptstatistiken für die Reports-Seite
 func (h *ReportsHandler) GetReportsStats(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	// Parameter auslesen
 	startDateStr := c.Query("startDate")
 	endDateStr := c.Query("endDate")
@@ -122,6 +136,11 @@ func (h *ReportsHandler) GetReportsStats(c *gin.Context) {
 	// Statistiken berechnen
 	stats := h.calculateStats(vehicles, drivers, fuelCosts, maintenanceEntries, usageEntries, startDate, endDate, vehicleID, driverID)
 
+	if format != "" {
+		exportReportsStats(c, format, stats, startDate, endDate)
+		return
+	}
+
 	// Chart-Daten berechnen
 	chartData := h.calculateChartData(vehicles, drivers, fuelCosts, maintenanceEntries, usageEntries, startDate, endDate)
 
@@ -143,6 +162,11 @@ func (h *ReportsHandler) GetReportsStats(c *gin.Context) {
 
 // GetVehicleRanking liefert das Fahrzeug-Ranking
 func (h *ReportsHandler) GetVehicleRanking(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	vehicles, err := h.vehicleRepo.FindAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Fahrzeuge"})
@@ -150,7 +174,7 @@ func (h *ReportsHandler) GetVehicleRanking(c *gin.Context) {
 	}
 
 	// Prüfen ob genügend Daten vorhanden sind
-	if len(vehicles) == 0 {
+	if len(vehicles) == 0 && format == "" {
 		c.JSON(http.StatusOK, gin.H{
 			"vehicles": []VehicleStats{},
 			"hasData":  false,
@@ -178,10 +202,16 @@ func (h *ReportsHandler) GetVehicleRanking(c *gin.Context) {
 		return
 	}
 
+	claims, err := h.claimRepo.Find(model.AccidentClaimFilter{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Schadenfälle"})
+		return
+	}
+
 	// Fahrzeugstatistiken berechnen
 	var vehicleStats []VehicleStats
 	for _, vehicle := range vehicles {
-		stats := h.calculateVehicleStats(vehicle, fuelCosts, maintenanceEntries, usageEntries)
+		stats := h.calculateVehicleStats(vehicle, fuelCosts, maintenanceEntries, usageEntries, claims)
 		vehicleStats = append(vehicleStats, stats)
 	}
 
@@ -190,6 +220,11 @@ func (h *ReportsHandler) GetVehicleRanking(c *gin.Context) {
 		return vehicleStats[i].CostPerKm < vehicleStats[j].CostPerKm
 	})
 
+	if format != "" {
+		exportVehicleRanking(c, format, vehicleStats)
+		return
+	}
+
 	hasEnoughData := len(fuelCosts) > 0 || len(usageEntries) > 0
 
 	var message string
@@ -206,6 +241,11 @@ func (h *ReportsHandler) GetVehicleRanking(c *gin.Context) {
 
 // GetDriverRanking liefert das Fahrer-Ranking
 func (h *ReportsHandler) GetDriverRanking(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	drivers, err := h.driverRepo.FindAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Fahrer"})
@@ -213,7 +253,7 @@ func (h *ReportsHandler) GetDriverRanking(c *gin.Context) {
 	}
 
 	// Prüfen ob genügend Daten vorhanden sind
-	if len(drivers) == 0 {
+	if len(drivers) == 0 && format == "" {
 		c.JSON(http.StatusOK, gin.H{
 			"drivers": []DriverStats{},
 			"hasData": false,
@@ -228,10 +268,16 @@ func (h *ReportsHandler) GetDriverRanking(c *gin.Context) {
 		return
 	}
 
+	fines, err := h.fineRepo.Find(model.TrafficFineFilter{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Bußgelder"})
+		return
+	}
+
 	// Fahrerstatistiken berechnen
 	var driverStats []DriverStats
 	for _, driver := range drivers {
-		stats := h.calculateDriverStats(driver, usageEntries)
+		stats := h.calculateDriverStats(driver, usageEntries, fines)
 		driverStats = append(driverStats, stats)
 	}
 
@@ -240,6 +286,11 @@ func (h *ReportsHandler) GetDriverRanking(c *gin.Context) {
 		return driverStats[i].TotalKilometers > driverStats[j].TotalKilometers
 	})
 
+	if format != "" {
+		exportDriverRanking(c, format, driverStats)
+		return
+	}
+
 	hasEnoughData := len(usageEntries) > 0
 
 	var message string
@@ -254,8 +305,72 @@ func (h *ReportsHandler) GetDriverRanking(c *gin.Context) {
 	})
 }
 
+// exportVehicleRanking exportiert das Fahrzeug-Ranking als CSV oder XLSX
+func exportVehicleRanking(c *gin.Context, format exportFormat, vehicleStats []VehicleStats) {
+	export := startExport(c, format, "fahrzeug-ranking",
+		"Rang", "Kennzeichen", "Marke", "Modell", "Kilometerstand", "Tankkosten", "Wartungskosten",
+		"Schadenkosten", "Kosten je km", "Auslastung %", "Fahrten")
+	defer export.Close()
+
+	for i, stats := range vehicleStats {
+		if err := export.Row(
+			i+1, stats.LicensePlate, stats.Brand, stats.Model, stats.Mileage, stats.FuelCosts,
+			stats.MaintenanceCosts, stats.ClaimCosts, stats.CostPerKm, stats.Utilization, stats.Trips,
+		); err != nil {
+			return
+		}
+	}
+}
+
+// exportDriverRanking exportiert das Fahrer-Ranking als CSV oder XLSX
+func exportDriverRanking(c *gin.Context, format exportFormat, driverStats []DriverStats) {
+	export := startExport(c, format, "fahrer-ranking",
+		"Rang", "Fahrer", "Status", "Gefahrene km", "Fahrten", "km je Fahrt", "Bußgelder", "Punkte", "Bußgeldbetrag")
+	defer export.Close()
+
+	for i, stats := range driverStats {
+		if err := export.Row(
+			i+1, stats.Name, exportText(model.DriverStatusText, model.DriverStatus(stats.Status)),
+			stats.TotalKilometers, stats.TotalTrips, stats.AvgKmPerTrip, stats.Fines, stats.FinePoints, stats.FineAmount,
+		); err != nil {
+			return
+		}
+	}
+}
+
+// exportReportsStats exportiert die Kennzahlen der Reports-Seite als CSV oder XLSX
+func exportReportsStats(c *gin.Context, format exportFormat, stats gin.H, startDate, endDate time.Time) {
+	export := startExport(c, format, "kennzahlen", "Kennzahl", "Wert")
+	defer export.Close()
+
+	rows := []struct {
+		label string
+		value interface{}
+	}{
+		{"Zeitraum von", exportDate(startDate)},
+		{"Zeitraum bis", exportDate(endDate)},
+		{"Fahrzeuge", stats["totalVehicles"]},
+		{"Fahrer", stats["totalDrivers"]},
+		{"Gesamtkilometer", stats["totalKilometers"]},
+		{"Tankkosten", stats["totalFuelCosts"]},
+		{"Wartungskosten", stats["totalMaintenanceCosts"]},
+		{"Finanzierungskosten", stats["totalFinancingCosts"]},
+		{"Gesamtkosten", stats["totalCosts"]},
+	}
+	for _, row := range rows {
+		if err := export.Row(row.label, row.value); err != nil {
+			return
+		}
+	}
+}
+
 // GetCostBreakdown liefert die Kostenaufstellung
 func (h *ReportsHandler) GetCostBreakdown(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	now := time.Now()
 
 	// Aktueller Monat
@@ -279,8 +394,14 @@ func (h *ReportsHandler) GetCostBreakdown(c *gin.Context) {
 	lastMonthMaintenance := h.getMaintenanceCostsByPeriod(lastMonthStart, lastMonthEnd)
 	yearMaintenance := h.getMaintenanceCostsByPeriod(yearStart, now)
 
+	// Schadenkosten
+	thisMonthClaims := h.getClaimCostsByPeriod(thisMonthStart, thisMonthEnd)
+	lastMonthClaims := h.getClaimCostsByPeriod(lastMonthStart, lastMonthEnd)
+	yearClaims := h.getClaimCostsByPeriod(yearStart, now)
+
 	// Prüfen ob genügend Daten vorhanden sind
-	hasData := thisMonthFuel > 0 || lastMonthFuel > 0 || thisMonthMaintenance > 0 || lastMonthMaintenance > 0
+	hasData := thisMonthFuel > 0 || lastMonthFuel > 0 || thisMonthMaintenance > 0 || lastMonthMaintenance > 0 ||
+		thisMonthClaims != 0 || lastMonthClaims != 0
 
 	// Änderungen berechnen
 	fuelChange := 0.0
@@ -308,15 +429,27 @@ func (h *ReportsHandler) GetCostBreakdown(c *gin.Context) {
 			"change":     maintenanceChange,
 			"yearToDate": yearMaintenance,
 		},
+		{
+			"category":   "Schäden",
+			"thisMonth":  thisMonthClaims,
+			"lastMonth":  lastMonthClaims,
+			"change":     calculateTotalChange(thisMonthClaims, lastMonthClaims),
+			"yearToDate": yearClaims,
+		},
 		{
 			"category":   "Gesamt",
-			"thisMonth":  thisMonthFuel + thisMonthMaintenance,
-			"lastMonth":  lastMonthFuel + lastMonthMaintenance,
-			"change":     calculateTotalChange(thisMonthFuel+thisMonthMaintenance, lastMonthFuel+lastMonthMaintenance),
-			"yearToDate": yearFuel + yearMaintenance,
+			"thisMonth":  thisMonthFuel + thisMonthMaintenance + thisMonthClaims,
+			"lastMonth":  lastMonthFuel + lastMonthMaintenance + lastMonthClaims,
+			"change":     calculateTotalChange(thisMonthFuel+thisMonthMaintenance+thisMonthClaims, lastMonthFuel+lastMonthMaintenance+lastMonthClaims),
+			"yearToDate": yearFuel + yearMaintenance + yearClaims,
 		},
 	}
 
+	if format != "" {
+		exportCostBreakdown(c, format, costBreakdown)
+		return
+	}
+
 	var message string
 	if !hasData {
 		message = "Keine Kostendaten verfügbar"
@@ -329,6 +462,19 @@ func (h *ReportsHandler) GetCostBreakdown(c *gin.Context) {
 	})
 }
 
+// exportCostBreakdown exportiert die Kostenaufstellung als CSV oder XLSX
+func exportCostBreakdown(c *gin.Context, format exportFormat, costBreakdown []gin.H) {
+	export := startExport(c, format, "kostenaufstellung",
+		"Kategorie", "Aktueller Monat", "Vormonat", "Änderung %", "Jahr bis heute")
+	defer export.Close()
+
+	for _, row := range costBreakdown {
+		if err := export.Row(row["category"], row["thisMonth"], row["lastMonth"], row["change"], row["yearToDate"]); err != nil {
+			return
+		}
+	}
+}
+
 // Hilfsfunktionen
 
 func (h *ReportsHandler) calculateStats(vehicles []*model.Vehicle, drivers []*model.Driver, fuelCosts []*model.FuelCost, maintenance []*model.Maintenance, usage []*model.VehicleUsage, startDate, endDate time.Time, vehicleFilter, driverFilter string) gin.H {
@@ -378,7 +524,7 @@ func (h *ReportsHandler) calculateStats(vehicles []*model.Vehicle, drivers []*mo
 	}
 }
 
-func (h *ReportsHandler) calculateVehicleStats(vehicle *model.Vehicle, fuelCosts []*model.FuelCost, maintenance []*model.Maintenance, usage []*model.VehicleUsage) VehicleStats {
+func (h *ReportsHandler) calculateVehicleStats(vehicle *model.Vehicle, fuelCosts []*model.FuelCost, maintenance []*model.Maintenance, usage []*model.VehicleUsage, claims []*model.AccidentClaim) VehicleStats {
 	vehicleID := vehicle.ID.Hex()
 
 	// Tankkosten für dieses Fahrzeug
@@ -397,6 +543,14 @@ func (h *ReportsHandler) calculateVehicleStats(vehicle *model.Vehicle, fuelCosts
 		}
 	}
 
+	// Schadenkosten für dieses Fahrzeug
+	claimCost := 0.0
+	for _, claim := range claims {
+		if claim.VehicleID.Hex() == vehicleID {
+			claimCost += claim.AdditionalCost()
+		}
+	}
+
 	// Fahrten zählen
 	trips := 0
 	for _, u := range usage {
@@ -408,7 +562,7 @@ func (h *ReportsHandler) calculateVehicleStats(vehicle *model.Vehicle, fuelCosts
 	// Kosten pro Kilometer
 	costPerKm := 0.0
 	if vehicle.Mileage > 0 {
-		costPerKm = (fuelCost + maintenanceCost) / float64(vehicle.Mileage)
+		costPerKm = (fuelCost + maintenanceCost + claimCost) / float64(vehicle.Mileage)
 	}
 
 	// Auslastung (vereinfacht)
@@ -429,13 +583,14 @@ func (h *ReportsHandler) calculateVehicleStats(vehicle *model.Vehicle, fuelCosts
 		Mileage:          vehicle.Mileage,
 		FuelCosts:        fuelCost,
 		MaintenanceCosts: maintenanceCost,
+		ClaimCosts:       math.Round(claimCost*100) / 100,
 		CostPerKm:        costPerKm,
 		Utilization:      utilization,
 		Trips:            trips,
 	}
 }
 
-func (h *ReportsHandler) calculateDriverStats(driver *model.Driver, usage []*model.VehicleUsage) DriverStats {
+func (h *ReportsHandler) calculateDriverStats(driver *model.Driver, usage []*model.VehicleUsage, fines []*model.TrafficFine) DriverStats {
 	driverID := driver.ID.Hex()
 
 	totalKilometers := 0
@@ -456,6 +611,16 @@ func (h *ReportsHandler) calculateDriverStats(driver *model.Driver, usage []*mod
 		avgKmPerTrip = float64(totalKilometers) / float64(totalTrips)
 	}
 
+	// Bußgelder für diesen Fahrer
+	fineCount, finePoints, fineAmount := 0, 0, 0.0
+	for _, f := range fines {
+		if f.DriverID != nil && *f.DriverID == driver.ID && f.Status != model.TrafficFineStatusCancelled {
+			fineCount++
+			finePoints += f.Points
+			fineAmount += f.Amount
+		}
+	}
+
 	return DriverStats{
 		ID:              driverID,
 		Name:            driver.FirstName + " " + driver.LastName,
@@ -463,6 +628,9 @@ func (h *ReportsHandler) calculateDriverStats(driver *model.Driver, usage []*mod
 		TotalKilometers: totalKilometers,
 		TotalTrips:      totalTrips,
 		AvgKmPerTrip:    avgKmPerTrip,
+		Fines:           fineCount,
+		FinePoints:      finePoints,
+		FineAmount:      math.Round(fineAmount*100) / 100,
 	}
 }
 
@@ -480,6 +648,21 @@ func (h *ReportsHandler) getFuelCostsByPeriod(start, end time.Time) float64 {
 	return total
 }
 
+// getClaimCostsByPeriod summiert die Schadenkosten nach Unfalldatum. Reparaturen über einen
+// Werkstattauftrag sind bereits in den Wartungskosten enthalten, dort zählt nur die Erstattung.
+func (h *ReportsHandler) getClaimCostsByPeriod(start, end time.Time) float64 {
+	claims, err := h.claimRepo.Find(model.AccidentClaimFilter{From: &start, To: &end})
+	if err != nil {
+		return 0.0
+	}
+
+	total := 0.0
+	for _, claim := range claims {
+		total += claim.AdditionalCost()
+	}
+	return math.Round(total*100) / 100
+}
+
 func (h *ReportsHandler) getMaintenanceCostsByPeriod(start, end time.Time) float64 {
 	maintenance, err := h.maintenanceRepo.FindAll()
 	if err != nil {
@@ -600,7 +783,8 @@ func (h *ReportsHandler) calculateChartData(vehicles []*model.Vehicle, drivers [
 	// Fahrer-Kilometer-Daten für Charts
 	var driverStats []DriverStats
 	for _, driver := range drivers {
-		stats := h.calculateDriverStats(driver, usage)
+		// Das Kilometer-Diagramm benötigt keine Bußgelder
+		stats := h.calculateDriverStats(driver, usage, nil)
 		driverStats = append(driverStats, stats)
 	}
 	
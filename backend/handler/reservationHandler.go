@@ -5,6 +5,7 @@ import (
 	"FleetFlow/backend/repository"
 	"FleetFlow/backend/service"
 	"FleetFlow/backend/utils"
+	"errors"
 	"net/http"
 	"time"
 
@@ -14,20 +15,22 @@ import (
 // ReservationHandler verwaltet alle Reservierungs-bezogenen HTTP-Anfragen
 type ReservationHandler struct {
 	reservationService  *service.ReservationService
-	vehicleRepo         *repository.VehicleRepository
-	driverRepo          *repository.DriverRepository
-	userRepo            *repository.UserRepository
+	reservationRepo     repository.VehicleReservationRepository
+	vehicleRepo         repository.VehicleRepository
+	driverRepo          repository.DriverRepository
+	userRepo            repository.UserRepository
 	notificationService *service.NotificationService
 }
 
 // NewReservationHandler erstellt einen neuen ReservationHandler
-func NewReservationHandler() *ReservationHandler {
+func NewReservationHandler(repos *repository.Repositories, services *service.Services) *ReservationHandler {
 	return &ReservationHandler{
-		reservationService:  service.NewReservationService(),
-		vehicleRepo:         repository.NewVehicleRepository(),
-		driverRepo:          repository.NewDriverRepository(),
-		userRepo:            repository.NewUserRepository(),
-		notificationService: service.NewNotificationService(),
+		reservationService:  services.Reservation,
+		reservationRepo:     repos.VehicleReservation,
+		vehicleRepo:         repos.Vehicle,
+		driverRepo:          repos.Driver,
+		userRepo:            repos.User,
+		notificationService: services.Notification,
 	}
 }
 
@@ -49,6 +52,17 @@ type UpdateReservationRequest struct {
 	Notes     string `json:"notes"`
 }
 
+// CreateReservationSeriesRequest repräsentiert die Anfrage zum Erstellen einer Reservierungsserie
+type CreateReservationSeriesRequest struct {
+	CreateReservationRequest
+	Frequency     string         `json:"frequency" binding:"required"` // daily, weekly oder monthly
+	Interval      int            `json:"interval"`
+	Weekdays      []time.Weekday `json:"weekdays"` // 0 = Sonntag
+	Until         string         `json:"until"`    // Format 2006-01-02, alternativ zu count
+	Count         int            `json:"count"`
+	SkipConflicts bool           `json:"skipConflicts"` // Kollidierende Termine auslassen statt abzubrechen
+}
+
 // ReservationResponse erweitert das Reservierungs-Model für API-Antworten
 type ReservationResponse struct {
 	model.VehicleReservation
@@ -226,6 +240,106 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	c.JSON(http.StatusCreated, reservation)
 }
 
+// CreateReservationSeries erstellt eine Serie wiederkehrender Reservierungen via API
+func (h *ReservationHandler) CreateReservationSeries(c *gin.Context) {
+	var req CreateReservationSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Zeitstempel parsen (als lokale Zeit in Europa/Berlin)
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Timezone-Fehler"})
+		return
+	}
+
+	startTime, err := time.ParseInLocation("2006-01-02T15:04", req.StartTime, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startzeit-Format"})
+		return
+	}
+
+	endTime, err := time.ParseInLocation("2006-01-02T15:04", req.EndTime, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Endzeit-Format"})
+		return
+	}
+
+	rule := model.RecurrenceRule{
+		Frequency: model.RecurrenceFrequency(req.Frequency),
+		Interval:  req.Interval,
+		Weekdays:  req.Weekdays,
+		Count:     req.Count,
+	}
+	if req.Until != "" {
+		until, err := time.ParseInLocation("2006-01-02", req.Until, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum-Format"})
+			return
+		}
+		// Enddatum gilt einschließlich des ganzen Tages
+		until = until.AddDate(0, 0, 1).Add(-time.Second)
+		rule.Until = &until
+	}
+
+	// Benutzer aus JWT-Token extrahieren
+	userID, err := utils.ExtractUserIDFromToken(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.reservationService.CreateReservationSeries(
+		req.VehicleID,
+		req.DriverID,
+		startTime,
+		endTime,
+		rule,
+		req.Purpose,
+		req.Notes,
+		req.SkipConflicts,
+		userID,
+	)
+	if errors.Is(err, service.ErrSeriesConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": result.Conflicts})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Benachrichtigung über die neue Serie anhand des ersten Termins an Manager senden
+	go func() {
+		vehicle, err := h.vehicleRepo.FindByID(req.VehicleID)
+		if err != nil {
+			return
+		}
+
+		driver, err := h.driverRepo.FindByID(req.DriverID)
+		if err != nil {
+			return
+		}
+
+		h.notificationService.NotifyNewReservationRequest(&result.Reservations[0], vehicle, driver)
+	}()
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetReservationSeries gibt eine Serie mit allen Terminen zurück
+func (h *ReservationHandler) GetReservationSeries(c *gin.Context) {
+	result, err := h.reservationService.GetReservationSeries(c.Param("seriesId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // UpdateReservation aktualisiert eine bestehende Reservierung
 func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 	reservationID := c.Param("id")
@@ -262,6 +376,21 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 		return
 	}
 
+	// Änderung auf die ganze Serie anwenden
+	if service.ReservationScope(c.Query("scope")) == service.ReservationScopeSeries {
+		result, err := h.reservationService.UpdateReservationSeries(reservationID, startTime, endTime, req.Purpose, req.Notes, userID)
+		if errors.Is(err, service.ErrSeriesConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": result.Conflicts})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
 	// Reservierung aktualisieren
 	err = h.reservationService.UpdateReservation(
 		reservationID,
@@ -291,6 +420,16 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 		return
 	}
 
+	// Ganze Serie stornieren
+	if service.ReservationScope(c.Query("scope")) == service.ReservationScopeSeries {
+		if err := h.reservationService.CancelReservationSeries(reservationID, userID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Reservierungsserie erfolgreich storniert"})
+		return
+	}
+
 	err = h.reservationService.CancelReservation(reservationID, userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -322,8 +461,17 @@ func (h *ReservationHandler) CompleteReservation(c *gin.Context) {
 
 // GetReservations gibt Reservierungen zurück (mit optionalem Filter)
 func (h *ReservationHandler) GetReservations(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	includeCompleted := c.DefaultQuery("includeCompleted", "false")
-	
+	if format != "" {
+		h.exportReservations(c, format, includeCompleted == "true")
+		return
+	}
+
 	var reservations []model.VehicleReservation
 	var err error
 	
@@ -359,6 +507,40 @@ func (h *ReservationHandler) GetReservations(c *gin.Context) {
 	c.JSON(http.StatusOK, responses)
 }
 
+// exportReservations exportiert die Reservierungen seitenweise als CSV oder XLSX
+func (h *ReservationHandler) exportReservations(c *gin.Context, format exportFormat, includeCompleted bool) {
+	fetch := func(limit, skip int) ([]model.VehicleReservation, error) {
+		return h.reservationRepo.FindPage(includeCompleted, limit, skip)
+	}
+	reservations, err := fetch(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	export := startExport(c, format, "reservierungen",
+		"Beginn", "Ende", "Kennzeichen", "Fahrzeug", "Fahrer", "Status", "Zweck", "Notizen", "Ablehnungsgrund")
+	defer export.Close()
+
+	exportPages(reservations, fetch, func(reservation model.VehicleReservation) error {
+		licensePlate, vehicleName := "", ""
+		if vehicle, err := h.vehicleRepo.FindByID(reservation.VehicleID.Hex()); err == nil {
+			licensePlate = vehicle.LicensePlate
+			vehicleName = vehicle.Brand + " " + vehicle.Model
+		}
+		driverName := ""
+		if driver, err := h.driverRepo.FindByID(reservation.DriverID.Hex()); err == nil {
+			driverName = driver.FirstName + " " + driver.LastName
+		}
+
+		return export.Row(
+			reservation.StartTime, reservation.EndTime, licensePlate, vehicleName, driverName,
+			exportText(model.ReservationStatusText, reservation.Status), reservation.Purpose,
+			reservation.Notes, reservation.RejectionNote,
+		)
+	})
+}
+
 // GetReservationsByVehicle gibt alle Reservierungen für ein bestimmtes Fahrzeug zurück
 func (h *ReservationHandler) GetReservationsByVehicle(c *gin.Context) {
 	vehicleID := c.Param("vehicleId")
@@ -424,8 +606,7 @@ func (h *ReservationHandler) GetAvailableVehicles(c *gin.Context) {
 
 	// Verfügbare Fahrzeuge filtern
 	var availableVehicles []model.Vehicle
-	reservationRepo := repository.NewVehicleReservationRepository()
-	
+
 	for _, vehicle := range allVehicles {
 		// Fahrzeuge mit nicht-verfügbarem Status überspringen (außer temporär reserviert)
 		if vehicle.Status != model.VehicleStatusAvailable && vehicle.Status != model.VehicleStatusReserved {
@@ -438,7 +619,7 @@ func (h *ReservationHandler) GetAvailableVehicles(c *gin.Context) {
 			excludeIDPtr = &excludeReservationID
 		}
 		
-		hasConflict, err := reservationRepo.CheckConflict(
+		hasConflict, err := h.reservationRepo.CheckConflict(
 			vehicle.ID.Hex(),
 			startTime,
 			endTime,
@@ -495,8 +676,7 @@ func (h *ReservationHandler) CheckReservationConflict(c *gin.Context) {
 		excludeIDPtr = &excludeID
 	}
 
-	repo := repository.NewVehicleReservationRepository()
-	conflictDetails, err := repo.CheckConflictDetails(vehicleID, startTime, endTime, excludeIDPtr)
+	conflictDetails, err := h.reservationRepo.CheckConflictDetails(vehicleID, startTime, endTime, excludeIDPtr)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -526,8 +706,7 @@ func (h *ReservationHandler) ApproveReservation(c *gin.Context) {
 	// Benachrichtigung über Genehmigung senden
 	go func() {
 		// Reservierung und zugehörige Details laden
-		reservationRepo := repository.NewVehicleReservationRepository()
-		reservation, err := reservationRepo.FindByID(reservationID)
+		reservation, err := h.reservationRepo.FindByID(reservationID)
 		if err != nil {
 			return
 		}
@@ -586,8 +765,7 @@ func (h *ReservationHandler) RejectReservation(c *gin.Context) {
 	// Benachrichtigung über Ablehnung senden
 	go func() {
 		// Reservierung und zugehörige Details laden
-		reservationRepo := repository.NewVehicleReservationRepository()
-		reservation, err := reservationRepo.FindByID(reservationID)
+		reservation, err := h.reservationRepo.FindByID(reservationID)
 		if err != nil {
 			return
 		}
@@ -3,11 +3,11 @@ package handler
 import (
 	"FleetFlow/backend/model"
 	"FleetFlow/backend/service"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // SMTPHandler verwaltet SMTP-bezogene Anfragen
@@ -16,9 +16,9 @@ type SMTPHandler struct {
 }
 
 // NewSMTPHandler erstellt einen neuen SMTPHandler
-func NewSMTPHandler() *SMTPHandler {
+func NewSMTPHandler(services *service.Services) *SMTPHandler {
 	return &SMTPHandler{
-		emailService: service.NewEmailService(),
+		emailService: services.Email,
 	}
 }
 
@@ -173,7 +173,17 @@ func (h *SMTPHandler) GetEmailLogs(c *gin.Context) {
 		offset = 0
 	}
 
-	logs, err := h.emailService.GetEmailLogs(limit, offset)
+	// Optional nach Status filtern, z.B. ?status=failed für fehlgeschlagene E-Mails
+	var logs []*model.EmailLog
+	switch status := model.EmailStatus(c.Query("status")); status {
+	case "":
+		logs, err = h.emailService.GetEmailLogs(limit, offset)
+	case model.EmailStatusPending, model.EmailStatusSent, model.EmailStatusFailed:
+		logs, err = h.emailService.GetEmailLogsByStatus(status, limit, offset)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Status"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der E-Mail-Logs"})
 		return
@@ -182,6 +192,27 @@ func (h *SMTPHandler) GetEmailLogs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"logs": logs})
 }
 
+// ResendEmail stellt eine fehlgeschlagene E-Mail erneut in die Warteschlange
+func (h *SMTPHandler) ResendEmail(c *gin.Context) {
+	emailLog, err := h.emailService.ResendEmail(c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmailNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "E-Mail nicht gefunden"})
+		case errors.Is(err, service.ErrEmailNotFailed):
+			c.JSON(http.StatusConflict, gin.H{"error": "Nur fehlgeschlagene E-Mails können erneut gesendet werden"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim erneuten Senden der E-Mail"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "E-Mail wurde erneut in die Warteschlange gestellt",
+		"log":     emailLog,
+	})
+}
+
 // GetNotificationSettings holt Benachrichtigungseinstellungen für den aktuellen Benutzer
 func (h *SMTPHandler) GetNotificationSettings(c *gin.Context) {
 	userID, exists := c.Get("userId")
@@ -219,19 +250,17 @@ func (h *SMTPHandler) SaveNotificationSettings(c *gin.Context) {
 		return
 	}
 
-	userObjectID, err := primitive.ObjectIDFromHex(userID.(string))
+	// Bestehende Einstellungen laden, damit Ereignis-Schalter und Ruhezeit erhalten bleiben
+	settings, err := h.emailService.GetNotificationSettings(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Benutzer-ID"})
 		return
 	}
 
-	settings := &model.NotificationSettings{
-		UserID:             userObjectID,
-		EmailNotifications: request.EmailNotifications,
-		BookingReminders:   request.BookingReminders,
-		FuelReminders:      request.FuelReminders,
-		MaintenanceAlerts:  request.MaintenanceAlerts,
-	}
+	settings.EmailNotifications = request.EmailNotifications
+	settings.BookingReminders = request.BookingReminders
+	settings.FuelReminders = request.FuelReminders
+	settings.MaintenanceAlerts = request.MaintenanceAlerts
 
 	if err := h.emailService.SaveNotificationSettings(settings); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Speichern der Benachrichtigungseinstellungen"})
@@ -254,7 +283,7 @@ func (h *SMTPHandler) SendTestEmail(c *gin.Context) {
 		return
 	}
 
-	if err := h.emailService.SendEmail(request.To, request.Subject, request.Body, ""); err != nil {
+	if err := h.emailService.SendEmailNow(request.To, request.Subject, request.Body, ""); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Senden der E-Mail: " + err.Error()})
 		return
 	}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaxableBenefitHandler stellt die Abrechnung des geldwerten Vorteils für die Lohnbuchhaltung bereit
+type TaxableBenefitHandler struct {
+	benefitService *service.TaxableBenefitService
+}
+
+// NewTaxableBenefitHandler erstellt einen neuen TaxableBenefitHandler
+func NewTaxableBenefitHandler(services *service.Services) *TaxableBenefitHandler {
+	return &TaxableBenefitHandler{
+		benefitService: services.TaxableBenefit,
+	}
+}
+
+// GetStatements gibt die Monatsabrechnungen aller Dienstwagenfahrer zurück (?month=YYYY-MM&method=&format=csv|json)
+func (h *TaxableBenefitHandler) GetStatements(c *gin.Context) {
+	month, method, ok := parseBenefitQuery(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Format, erlaubt sind csv und json"})
+		return
+	}
+
+	statements, err := h.benefitService.GetMonthlyStatements(month, method)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "csv" {
+		writeBenefitCSV(c, fmt.Sprintf("geldwerter-vorteil-%s.csv", month.Format("2006-01")), statements)
+		return
+	}
+
+	total := 0.0
+	for _, statement := range statements {
+		total += statement.TotalBenefit
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"month":        month.Format("2006-01"),
+		"statements":   statements,
+		"totalBenefit": total,
+	})
+}
+
+// GetDriverStatement gibt die Monatsabrechnung eines Fahrers zurück (?month=YYYY-MM&method=&format=csv|json)
+func (h *TaxableBenefitHandler) GetDriverStatement(c *gin.Context) {
+	month, method, ok := parseBenefitQuery(c)
+	if !ok {
+		return
+	}
+
+	statement, err := h.benefitService.GetDriverStatement(c.Param("driverId"), month, method)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		filename := fmt.Sprintf("geldwerter-vorteil-%s-%s.csv", month.Format("2006-01"), statement.DriverID.Hex())
+		writeBenefitCSV(c, filename, []*model.TaxableBenefitStatement{statement})
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+// parseBenefitQuery liest Monat (Standard: Vormonat) und optionale Methode aus der Anfrage
+func parseBenefitQuery(c *gin.Context) (time.Time, model.BenefitMethod, bool) {
+	previous := time.Now().AddDate(0, -1, 0)
+	month, err := service.ParseBenefitMonth(c.DefaultQuery("month", previous.Format("2006-01")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return time.Time{}, "", false
+	}
+
+	method := model.BenefitMethod(c.Query("method"))
+	if !model.IsValidBenefitMethod(method) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Methode, erlaubt sind flat_rate und logbook"})
+		return time.Time{}, "", false
+	}
+
+	return month, method, true
+}
+
+// writeBenefitCSV schreibt die Abrechnungen im Importformat der Lohnbuchhaltung
+func writeBenefitCSV(c *gin.Context, filename string, statements []*model.TaxableBenefitStatement) {
+	header := []string{"Monat", "Personalnummer", "Fahrer", "Kennzeichen", "Kraftstoff", "Methode",
+		"Bruttolistenpreis", "Minderungsfaktor", "Bemessungsgrundlage", "Entfernung km", "Fahrtage",
+		"Gesamtkosten", "Gesamt km", "Privat km", "Arbeitsweg km",
+		"Privatnutzung", "Fahrten Wohnung-Arbeitsstätte", "Geldwerter Vorteil", "Hinweise"}
+
+	rows := make([][]string, 0, len(statements))
+	for _, statement := range statements {
+		commuteDays := ""
+		if statement.CommuteDaily {
+			commuteDays = strconv.Itoa(statement.CommuteDays)
+		}
+		rows = append(rows, []string{
+			statement.Month,
+			statement.DriverNumber,
+			statement.DriverName,
+			statement.LicensePlate,
+			string(statement.FuelType),
+			model.BenefitMethodText[statement.Method],
+			csvAmount(statement.ListPrice),
+			csvAmount(statement.ListPriceFactor),
+			csvAmount(statement.AssessmentBase),
+			strconv.Itoa(statement.CommuteDistanceKm),
+			commuteDays,
+			csvAmount(statement.TotalCosts),
+			strconv.Itoa(statement.TotalKm),
+			strconv.Itoa(statement.PrivateKm),
+			strconv.Itoa(statement.CommuteKm),
+			csvAmount(statement.PrivateUseBenefit),
+			csvAmount(statement.CommuteBenefit),
+			csvAmount(statement.TotalBenefit),
+			strings.Join(statement.Warnings, ", "),
+		})
+	}
+
+	writeCSV(c, filename, header, rows)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TCOHandler stellt die Gesamtkosten (Total Cost of Ownership) je Fahrzeug, Flotte und Modell bereit
+type TCOHandler struct {
+	tcoService *service.TCOService
+}
+
+// NewTCOHandler erstellt einen neuen TCOHandler
+func NewTCOHandler(services *service.Services) *TCOHandler {
+	return &TCOHandler{
+		tcoService: services.TCO,
+	}
+}
+
+// GetVehicleTCO gibt die Gesamtkosten eines Fahrzeugs zurück (?from=&to=&method=&years=&rate=)
+func (h *TCOHandler) GetVehicleTCO(c *gin.Context) {
+	options, err := parseTCOOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tco, err := h.tcoService.GetVehicleTCO(c.Param("id"), options)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tco)
+}
+
+// GetFleetTCO gibt die Gesamtkosten aller Fahrzeuge zurück
+func (h *TCOHandler) GetFleetTCO(c *gin.Context) {
+	options, err := parseTCOOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	fleet, err := h.tcoService.GetFleetTCO(options)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, fleet)
+}
+
+// GetModelComparison vergleicht die Gesamtkosten nach Marke und Modell
+func (h *TCOHandler) GetModelComparison(c *gin.Context) {
+	options, err := parseTCOOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	comparison, err := h.tcoService.GetModelComparison(options)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
+
+// respondError übersetzt Fehler des TCOService in HTTP-Antworten
+func (h *TCOHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTCOVehicleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fahrzeug nicht gefunden"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// parseTCOOptions liest Zeitraum und Abschreibungsparameter aus der Anfrage
+func parseTCOOptions(c *gin.Context) (model.TCOOptions, error) {
+	var options model.TCOOptions
+	var err error
+	if options.From, err = parseTCODate(c.Query("from")); err != nil {
+		return options, err
+	}
+	if options.To, err = parseTCODate(c.Query("to")); err != nil {
+		return options, err
+	}
+	if options.To != nil {
+		// Das Enddatum zählt vollständig mit
+		end := options.To.AddDate(0, 0, 1).Add(-time.Second)
+		options.To = &end
+	}
+	options.Method = model.DepreciationMethod(c.Query("method"))
+	if years := c.Query("years"); years != "" {
+		if options.DepreciationYears, err = strconv.Atoi(years); err != nil {
+			return options, fmt.Errorf("ungültige nutzungsdauer: %s", years)
+		}
+	}
+	if rate := c.Query("rate"); rate != "" {
+		// Satz in Prozent, z.B. 25
+		percent, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			return options, fmt.Errorf("ungültiger abschreibungssatz: %s", rate)
+		}
+		options.DecliningRate = percent / 100
+	}
+	return options, nil
+}
+
+// parseTCODate wandelt ein optionales Datum (YYYY-MM-DD) um
+func parseTCODate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiges datum: %s", value)
+	}
+	return &t, nil
+}
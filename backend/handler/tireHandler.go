@@ -0,0 +1,433 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TireHandler stellt Reifensätze, Reifenwechsel und saisonale Wechselaktionen bereit
+type TireHandler struct {
+	tireService *service.TireService
+}
+
+// NewTireHandler erstellt einen neuen TireHandler
+func NewTireHandler(services *service.Services) *TireHandler {
+	return &TireHandler{
+		tireService: services.Tire,
+	}
+}
+
+// TireSetRequest enthält die Stammdaten eines Reifensatzes
+type TireSetRequest struct {
+	VehicleID      string             `json:"vehicleId"`
+	Season         model.TireSeason   `json:"season" binding:"required"`
+	Brand          string             `json:"brand" binding:"required"`
+	Model          string             `json:"model"`
+	Size           string             `json:"size"`    // Ohne Angabe Reifengröße des Fahrzeugs
+	RimType        string             `json:"rimType"` // Ohne Angabe Felgentyp des Fahrzeugs
+	DOTCode        string             `json:"dotCode"` // WWJJ, z.B. 2321
+	Location       model.TireLocation `json:"location"`
+	StorageDetails string             `json:"storageDetails"`
+	Retired        bool               `json:"retired"`
+	Notes          string             `json:"notes"`
+}
+
+// TireMeasurementRequest enthält eine Profilmessung in mm
+type TireMeasurementRequest struct {
+	Date       string  `json:"date"` // Optional: YYYY-MM-DD, sonst heute
+	FrontLeft  float64 `json:"frontLeft" binding:"required"`
+	FrontRight float64 `json:"frontRight" binding:"required"`
+	RearLeft   float64 `json:"rearLeft" binding:"required"`
+	RearRight  float64 `json:"rearRight" binding:"required"`
+	Mileage    int     `json:"mileage"`
+	Notes      string  `json:"notes"`
+}
+
+// TireSwapRequest enthält die Angaben eines Reifenwechsels
+type TireSwapRequest struct {
+	VehicleID      string  `json:"vehicleId" binding:"required"`
+	TireSetID      string  `json:"tireSetId" binding:"required"`
+	Date           string  `json:"date"` // Optional: YYYY-MM-DD, sonst heute
+	Mileage        int     `json:"mileage"`
+	Cost           float64 `json:"cost"`
+	Workshop       string  `json:"workshop"`
+	StorageDetails string  `json:"storageDetails"` // Lagerort des abmontierten Satzes
+}
+
+// GetSets gibt die Reifensätze zurück (?vehicleId=&location=&season=&retired=true)
+func (h *TireHandler) GetSets(c *gin.Context) {
+	filter := model.TireSetFilter{
+		Location:       model.TireLocation(c.Query("location")),
+		Season:         model.TireSeason(c.Query("season")),
+		IncludeRetired: c.Query("retired") == "true",
+	}
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+
+	sets, err := h.tireService.GetSets(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Reifensätze"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tireSets": sets, "count": len(sets)})
+}
+
+// GetSet gibt einen Reifensatz mit seinen Warnungen zurück
+func (h *TireHandler) GetSet(c *gin.Context) {
+	set, err := h.tireService.GetSet(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	warnings, err := h.tireService.GetSetWarnings(set)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Prüfen des Reifensatzes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tireSet": set, "warnings": warnings})
+}
+
+// CreateSet legt einen Reifensatz an
+func (h *TireHandler) CreateSet(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TireSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	set, err := h.tireService.CreateSet(user, tireSetInput(req))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, set)
+}
+
+// UpdateSet ändert die Stammdaten eines Reifensatzes
+func (h *TireHandler) UpdateSet(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TireSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	set, err := h.tireService.UpdateSet(user, c.Param("id"), tireSetInput(req))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, set)
+}
+
+// DeleteSet löscht einen eingelagerten Reifensatz
+func (h *TireHandler) DeleteSet(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.tireService.DeleteSet(user, c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reifensatz erfolgreich gelöscht"})
+}
+
+// AddMeasurement erfasst eine Profilmessung
+func (h *TireHandler) AddMeasurement(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TireMeasurementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseTireDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Messdatum, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	measurement := model.TireTreadMeasurement{
+		FrontLeft:  req.FrontLeft,
+		FrontRight: req.FrontRight,
+		RearLeft:   req.RearLeft,
+		RearRight:  req.RearRight,
+		Mileage:    req.Mileage,
+		Notes:      req.Notes,
+	}
+	if date != nil {
+		measurement.Date = *date
+	}
+
+	set, warnings, err := h.tireService.AddMeasurement(user, c.Param("id"), measurement)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"tireSet": set, "warnings": warnings})
+}
+
+// Swap montiert einen eingelagerten Reifensatz und lagert den bisherigen ein
+func (h *TireHandler) Swap(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TireSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseTireDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Datum, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	result, err := h.tireService.Swap(user, service.TireSwapInput{
+		VehicleID:      req.VehicleID,
+		TireSetID:      req.TireSetID,
+		Date:           date,
+		Mileage:        req.Mileage,
+		Cost:           req.Cost,
+		Workshop:       req.Workshop,
+		StorageDetails: req.StorageDetails,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetWarnings gibt alle Warnungen zu Profiltiefe und Reifenalter zurück
+func (h *TireHandler) GetWarnings(c *gin.Context) {
+	warnings, err := h.tireService.GetWarnings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Prüfen der Reifensätze"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"warnings": warnings, "count": len(warnings)})
+}
+
+// GetSettings gibt die Reifenvorgaben zurück
+func (h *TireHandler) GetSettings(c *gin.Context) {
+	settings, err := h.tireService.GetSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Reifenvorgaben"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings, "legalMinTreadDepth": model.LegalMinTreadDepth})
+}
+
+// SaveSettings speichert die Reifenvorgaben
+func (h *TireHandler) SaveSettings(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var request struct {
+		MinTreadDepthSummer float64 `json:"minTreadDepthSummer"`
+		MinTreadDepthWinter float64 `json:"minTreadDepthWinter"`
+		MaxAgeYears         int     `json:"maxAgeYears"`
+		SpringWindowStart   string  `json:"springWindowStart"`
+		SpringWindowEnd     string  `json:"springWindowEnd"`
+		AutumnWindowStart   string  `json:"autumnWindowStart"`
+		AutumnWindowEnd     string  `json:"autumnWindowEnd"`
+		SwapsPerDay         int     `json:"swapsPerDay"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings := &model.TireSettings{
+		MinTreadDepthSummer: request.MinTreadDepthSummer,
+		MinTreadDepthWinter: request.MinTreadDepthWinter,
+		MaxAgeYears:         request.MaxAgeYears,
+		SpringWindowStart:   request.SpringWindowStart,
+		SpringWindowEnd:     request.SpringWindowEnd,
+		AutumnWindowStart:   request.AutumnWindowStart,
+		AutumnWindowEnd:     request.AutumnWindowEnd,
+		SwapsPerDay:         request.SwapsPerDay,
+	}
+
+	if err := h.tireService.SaveSettings(user, settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Reifenvorgaben erfolgreich gespeichert",
+		"settings": settings,
+	})
+}
+
+// GetCampaigns gibt alle Wechselaktionen zurück
+func (h *TireHandler) GetCampaigns(c *gin.Context) {
+	campaigns, err := h.tireService.GetCampaigns()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Wechselaktionen"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"campaigns": campaigns, "count": len(campaigns)})
+}
+
+// GetCampaign gibt eine Wechselaktion zurück
+func (h *TireHandler) GetCampaign(c *gin.Context) {
+	campaign, err := h.tireService.GetCampaign(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+// PlanCampaign plant die Wechselaktion im Frühjahr oder Herbst
+func (h *TireHandler) PlanCampaign(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req struct {
+		Window model.TireSwapWindow `json:"window" binding:"required"` // spring oder autumn
+		Year   int                  `json:"year"`                      // Ohne Angabe laufendes Jahr
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Year == 0 {
+		req.Year = time.Now().Year()
+	}
+
+	campaign, err := h.tireService.PlanCampaign(user, req.Window, req.Year)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, campaign)
+}
+
+// UpdateCampaignItem verschiebt einen geplanten Wechsel oder setzt ihn aus
+func (h *TireHandler) UpdateCampaignItem(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req struct {
+		ScheduledDate string                   `json:"scheduledDate"` // Optional: YYYY-MM-DD
+		Status        model.TireSwapItemStatus `json:"status"`        // Optional: planned oder skipped
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseTireDate(req.ScheduledDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Termin, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	campaign, err := h.tireService.UpdateCampaignItem(user, c.Param("id"), c.Param("vehicleId"), service.TireCampaignItemInput{
+		ScheduledDate: date,
+		Status:        req.Status,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+// respondError übersetzt Fehler des TireService in HTTP-Antworten
+func (h *TireHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTireSetNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reifensatz nicht gefunden"})
+	case errors.Is(err, service.ErrTireCampaignNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Wechselaktion nicht gefunden"})
+	case errors.Is(err, service.ErrTireState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// tireSetInput übernimmt die Anfrage in die Eingabe des TireService
+func tireSetInput(req TireSetRequest) service.TireSetInput {
+	return service.TireSetInput{
+		VehicleID:      req.VehicleID,
+		Season:         req.Season,
+		Brand:          req.Brand,
+		Model:          req.Model,
+		Size:           req.Size,
+		RimType:        req.RimType,
+		DOTCode:        req.DOTCode,
+		Location:       req.Location,
+		StorageDetails: req.StorageDetails,
+		Retired:        req.Retired,
+		Notes:          req.Notes,
+	}
+}
+
+// parseTireDate liest ein optionales Datum im Format YYYY-MM-DD
+func parseTireDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiges datum: %s", value)
+	}
+	return &t, nil
+}
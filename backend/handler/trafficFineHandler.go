@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrafficFineHandler stellt Bußgelder und die Ermittlung des Fahrers zum Tatzeitpunkt bereit
+type TrafficFineHandler struct {
+	fineService *service.TrafficFineService
+}
+
+// NewTrafficFineHandler erstellt einen neuen TrafficFineHandler
+func NewTrafficFineHandler(services *service.Services) *TrafficFineHandler {
+	return &TrafficFineHandler{
+		fineService: services.TrafficFine,
+	}
+}
+
+// TrafficFineRequest enthält die Angaben aus dem Schreiben der Bußgeldstelle
+type TrafficFineRequest struct {
+	VehicleID        string  `json:"vehicleId"`                      // Alternativ licensePlate
+	LicensePlate     string  `json:"licensePlate"`                   // Kennzeichen laut Schreiben
+	OffenseTime      string  `json:"offenseTime" binding:"required"` // YYYY-MM-DDTHH:MM (Europe/Berlin)
+	Location         string  `json:"location"`
+	Offense          string  `json:"offense" binding:"required"`
+	Authority        string  `json:"authority"`
+	ReferenceNumber  string  `json:"referenceNumber"`
+	Amount           float64 `json:"amount"`
+	Points           int     `json:"points"`
+	ReceivedAt       string  `json:"receivedAt"`       // Optional: YYYY-MM-DD, sonst heute
+	ResponseDeadline string  `json:"responseDeadline"` // Optional: YYYY-MM-DD, sonst eine Woche nach Eingang
+	Notes            string  `json:"notes"`
+}
+
+// TrafficFineDriverRequest legt den Fahrer manuell fest
+type TrafficFineDriverRequest struct {
+	DriverID string `json:"driverId" binding:"required"`
+}
+
+// TrafficFineForwardRequest enthält die Rückmeldung an die Bußgeldstelle
+type TrafficFineForwardRequest struct {
+	ForwardedTo string `json:"forwardedTo"` // Ohne Angabe die Bußgeldstelle des Schreibens
+	Date        string `json:"date"`        // Optional: YYYY-MM-DD, sonst heute
+	Notes       string `json:"notes"`
+}
+
+// TrafficFinePaymentRequest enthält die Zahlung eines Bußgelds
+type TrafficFinePaymentRequest struct {
+	Amount float64                `json:"amount"` // Ohne Angabe Betrag des Bescheids
+	Date   string                 `json:"date"`   // Optional: YYYY-MM-DD, sonst heute
+	PaidBy model.TrafficFinePayer `json:"paidBy"` // driver (Standard) oder company
+}
+
+// TrafficFineCancelRequest enthält den Grund für die Einstellung
+type TrafficFineCancelRequest struct {
+	Notes string `json:"notes"`
+}
+
+// GetFines gibt die Bußgelder zurück (?vehicleId=&driverId=&status=open,notified&overdue=true)
+func (h *TrafficFineHandler) GetFines(c *gin.Context) {
+	var filter model.TrafficFineFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	if filter.DriverID, err = optionalObjectID(c.Query("driverId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrer-ID"})
+		return
+	}
+	if status := c.Query("status"); status != "" {
+		for _, value := range strings.Split(status, ",") {
+			filter.Statuses = append(filter.Statuses, model.TrafficFineStatus(strings.TrimSpace(value)))
+		}
+	}
+
+	fines, err := h.fineService.GetFines(filter, c.Query("overdue") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Bußgelder"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trafficFines": fines, "count": len(fines)})
+}
+
+// GetFine gibt ein Bußgeld zurück
+func (h *TrafficFineHandler) GetFine(c *gin.Context) {
+	fine, err := h.fineService.GetFine(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// ResolveDriver ermittelt den Fahrer zu Fahrzeug und Zeitpunkt, ohne ein Bußgeld anzulegen
+// (?vehicleId= oder ?licensePlate=, &at=YYYY-MM-DDTHH:MM)
+func (h *TrafficFineHandler) ResolveDriver(c *gin.Context) {
+	at, err := parseFineTime(c.Query("at"))
+	if err != nil || at == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Zeitpunkt, erwartet wird YYYY-MM-DDTHH:MM"})
+		return
+	}
+
+	resolution, err := h.fineService.ResolveDriverByPlate(c.Query("vehicleId"), c.Query("licensePlate"), *at)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resolution)
+}
+
+// CreateFine erfasst ein Bußgeld
+func (h *TrafficFineHandler) CreateFine(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := trafficFineInput(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fine, err := h.fineService.CreateFine(user, input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, fine)
+}
+
+// UpdateFine ändert die Angaben eines Bußgelds
+func (h *TrafficFineHandler) UpdateFine(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input, err := trafficFineInput(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fine, err := h.fineService.UpdateFine(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// DeleteFine löscht ein Bußgeld
+func (h *TrafficFineHandler) DeleteFine(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	if err := h.fineService.DeleteFine(user, c.Param("id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bußgeld erfolgreich gelöscht"})
+}
+
+// AssignDriver legt den Fahrer manuell fest
+func (h *TrafficFineHandler) AssignDriver(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFineDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fine, err := h.fineService.AssignDriver(user, c.Param("id"), req.DriverID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// NotifyDriver informiert den Fahrer per E-Mail
+func (h *TrafficFineHandler) NotifyDriver(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	fine, err := h.fineService.NotifyDriver(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// Forward vermerkt die Benennung des Fahrers gegenüber der Bußgeldstelle
+func (h *TrafficFineHandler) Forward(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFineForwardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseFineDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Datum, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	fine, err := h.fineService.ForwardFine(user, c.Param("id"), service.TrafficFineForwardInput{
+		ForwardedTo: req.ForwardedTo,
+		Date:        date,
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// RecordPayment vermerkt die Zahlung
+func (h *TrafficFineHandler) RecordPayment(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFinePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := parseFineDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Zahlungsdatum, erwartet wird YYYY-MM-DD"})
+		return
+	}
+
+	fine, err := h.fineService.RecordPayment(user, c.Param("id"), service.TrafficFinePaymentInput{
+		Amount: req.Amount,
+		Date:   date,
+		PaidBy: req.PaidBy,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// Cancel vermerkt die Einstellung des Verfahrens
+func (h *TrafficFineHandler) Cancel(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req TrafficFineCancelRequest
+	// Der Grund ist optional, ein leerer Body ist erlaubt
+	_ = c.ShouldBindJSON(&req)
+
+	fine, err := h.fineService.CancelFine(user, c.Param("id"), req.Notes)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fine)
+}
+
+// respondError übersetzt Fehler des TrafficFineService in HTTP-Antworten
+func (h *TrafficFineHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTrafficFineNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bußgeld nicht gefunden"})
+	case errors.Is(err, service.ErrTrafficFineState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// trafficFineInput übernimmt die Anfrage in die Eingabe des TrafficFineService
+func trafficFineInput(req TrafficFineRequest) (service.TrafficFineInput, error) {
+	offenseTime, err := parseFineTime(req.OffenseTime)
+	if err != nil || offenseTime == nil {
+		return service.TrafficFineInput{}, fmt.Errorf("ungültiger tatzeitpunkt, erwartet wird YYYY-MM-DDTHH:MM")
+	}
+	receivedAt, err := parseFineDate(req.ReceivedAt)
+	if err != nil {
+		return service.TrafficFineInput{}, fmt.Errorf("ungültiges eingangsdatum, erwartet wird YYYY-MM-DD")
+	}
+	deadline, err := parseFineDate(req.ResponseDeadline)
+	if err != nil {
+		return service.TrafficFineInput{}, fmt.Errorf("ungültige frist, erwartet wird YYYY-MM-DD")
+	}
+
+	return service.TrafficFineInput{
+		VehicleID:        req.VehicleID,
+		LicensePlate:     req.LicensePlate,
+		OffenseTime:      *offenseTime,
+		Location:         req.Location,
+		Offense:          req.Offense,
+		Authority:        req.Authority,
+		ReferenceNumber:  req.ReferenceNumber,
+		Amount:           req.Amount,
+		Points:           req.Points,
+		ReceivedAt:       receivedAt,
+		ResponseDeadline: deadline,
+		Notes:            req.Notes,
+	}, nil
+}
+
+// parseFineTime liest einen optionalen Zeitpunkt im Format YYYY-MM-DDTHH:MM (Europe/Berlin)
+func parseFineTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiger zeitpunkt: %s", value)
+	}
+	return &t, nil
+}
+
+// parseFineDate liest ein optionales Datum im Format YYYY-MM-DD
+func parseFineDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiges datum: %s", value)
+	}
+	return &t, nil
+}
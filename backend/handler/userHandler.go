@@ -15,15 +15,15 @@ import (
 
 // UserHandler repräsentiert den Handler für Benutzer-Operationen
 type UserHandler struct {
-	userRepo     *repository.UserRepository
+	userRepo     repository.UserRepository
 	emailService *service.EmailService
 }
 
 // NewUserHandler erstellt einen neuen UserHandler
-func NewUserHandler() *UserHandler {
+func NewUserHandler(repos *repository.Repositories, services *service.Services) *UserHandler {
 	return &UserHandler{
-		userRepo:     repository.NewUserRepository(),
-		emailService: service.NewEmailService(),
+		userRepo:     repos.User,
+		emailService: services.Email,
 	}
 }
 
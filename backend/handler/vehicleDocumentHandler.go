@@ -18,17 +18,17 @@ import (
 
 // VehicleDocumentHandler repräsentiert den Handler für Fahrzeugdokumente
 type VehicleDocumentHandler struct {
-	documentRepo    *repository.VehicleDocumentRepository
-	vehicleRepo     *repository.VehicleRepository
+	documentRepo    repository.VehicleDocumentRepository
+	vehicleRepo     repository.VehicleRepository
 	activityService *service.ActivityService
 }
 
 // NewVehicleDocumentHandler erstellt einen neuen VehicleDocumentHandler
-func NewVehicleDocumentHandler() *VehicleDocumentHandler {
+func NewVehicleDocumentHandler(repos *repository.Repositories, services *service.Services) *VehicleDocumentHandler {
 	return &VehicleDocumentHandler{
-		documentRepo:    repository.NewVehicleDocumentRepository(),
-		vehicleRepo:     repository.NewVehicleRepository(),
-		activityService: service.NewActivityService(),
+		documentRepo:    repos.VehicleDocument,
+		vehicleRepo:     repos.Vehicle,
+		activityService: services.Activity,
 	}
 }
 
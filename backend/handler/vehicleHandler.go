@@ -17,9 +17,9 @@ import (
 // CreateVehicleRequest repräsentiert die Anfrage zum Erstellen eines Fahrzeugs
 type CreateVehicleRequest struct {
 	LicensePlate       string              `json:"licensePlate" binding:"required"`
-	Brand              string              `json:"brand" binding:"required"`
+	Brand              string              `json:"brand"` // Ohne Angabe aus der FIN
 	Model              string              `json:"model" binding:"required"`
-	Year               int                 `json:"year" binding:"required"`
+	Year               int                 `json:"year"` // Ohne Angabe aus der FIN
 	Color              string              `json:"color"`
 	VehicleID          string              `json:"vehicleId"`
 	VIN                string              `json:"vin"`
@@ -51,10 +51,15 @@ type CreateVehicleRequest struct {
 	CurbWeight         int     `json:"curbWeight"`
 	MaxSpeed           int     `json:"maxSpeed"`
 	TowingCapacity     int     `json:"towingCapacity"`
+	TankCapacity       float64 `json:"tankCapacity"`
 	SpecialFeatures    string  `json:"specialFeatures"`
 
+	// Führerscheinklasse (leer = aus Fahrzeugdaten abgeleitet)
+	RequiredLicenseClass model.LicenseClass `json:"requiredLicenseClass"`
+
 	// Finanzierungsfelder
 	AcquisitionType        model.AcquisitionType `json:"acquisitionType"`
+	ListPrice              float64               `json:"listPrice"`
 	PurchaseDate           string                `json:"purchaseDate"`
 	PurchasePrice          float64               `json:"purchasePrice"`
 	PurchaseVendor         string                `json:"purchaseVendor"`
@@ -111,10 +116,15 @@ type UpdateVehicleRequest struct {
 	CurbWeight         int     `json:"curbWeight"`
 	MaxSpeed           int     `json:"maxSpeed"`
 	TowingCapacity     int     `json:"towingCapacity"`
+	TankCapacity       float64 `json:"tankCapacity"`
 	SpecialFeatures    string  `json:"specialFeatures"`
 
+	// Führerscheinklasse (leer = aus Fahrzeugdaten abgeleitet)
+	RequiredLicenseClass *model.LicenseClass `json:"requiredLicenseClass"`
+
 	// Finanzierungsfelder (alle optional)
 	AcquisitionType        model.AcquisitionType `json:"acquisitionType"`
+	ListPrice              *float64              `json:"listPrice"`
 	PurchaseDate           string                `json:"purchaseDate"`
 	PurchasePrice          float64               `json:"purchasePrice"`
 	PurchaseVendor         string                `json:"purchaseVendor"`
@@ -137,24 +147,34 @@ type UpdateVehicleRequest struct {
 
 // VehicleHandler repräsentiert den Handler für Fahrzeug-Operationen
 type VehicleHandler struct {
-	vehicleRepo     *repository.VehicleRepository
-	driverRepo      *repository.DriverRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
 	activityService *service.ActivityService
 }
 
 // NewVehicleHandler erstellt einen neuen VehicleHandler
-func NewVehicleHandler() *VehicleHandler {
+func NewVehicleHandler(repos *repository.Repositories, services *service.Services) *VehicleHandler {
 	return &VehicleHandler{
-		vehicleRepo:     repository.NewVehicleRepository(),
-		driverRepo:      repository.NewDriverRepository(),
-		activityService: service.NewActivityService(),
+		vehicleRepo:     repos.Vehicle,
+		driverRepo:      repos.Driver,
+		activityService: services.Activity,
 	}
 }
 
 // GetVehicles behandelt die Anfrage, alle Fahrzeuge abzurufen
 func (h *VehicleHandler) GetVehicles(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	// Statusfilter prüfen
 	statusFilter := c.Query("status")
+	if format != "" {
+		h.exportVehicles(c, format, model.VehicleStatus(statusFilter))
+		return
+	}
+
 	var vehicles []*model.Vehicle
 	var err error
 
@@ -195,6 +215,49 @@ func (h *VehicleHandler) GetVehicles(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"vehicles": result})
 }
 
+// exportVehicles exportiert die Fahrzeugliste seitenweise als CSV oder XLSX, optional nach Status gefiltert
+func (h *VehicleHandler) exportVehicles(c *gin.Context, format exportFormat, status model.VehicleStatus) {
+	fetch := func(limit, skip int) ([]*model.Vehicle, error) {
+		return h.vehicleRepo.FindPage(status, limit, skip)
+	}
+	vehicles, err := fetch(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Fahrzeuge"})
+		return
+	}
+
+	export := startExport(c, format, "fahrzeuge",
+		"Kennzeichen", "Marke", "Modell", "Baujahr", "Farbe", "Fahrzeugnummer", "FIN", "Kraftstoff",
+		"Kilometerstand", "Status", "Fahrer", "Erstzulassung", "Nächste HU", "Versicherung",
+		"Versicherungsart", "Versicherungsablauf", "Versicherungskosten", "Erwerbsart", "Monatsrate")
+	defer export.Close()
+
+	exportPages(vehicles, fetch, func(vehicle *model.Vehicle) error {
+		driverName := ""
+		if !vehicle.CurrentDriverID.IsZero() {
+			if driver, err := h.driverRepo.FindByID(vehicle.CurrentDriverID.Hex()); err == nil {
+				driverName = driver.FirstName + " " + driver.LastName
+			}
+		}
+		monthlyRate := 0.0
+		switch vehicle.AcquisitionType {
+		case model.AcquisitionTypeFinanced:
+			monthlyRate = vehicle.FinanceMonthlyRate
+		case model.AcquisitionTypeLeased:
+			monthlyRate = vehicle.LeaseMonthlyRate
+		}
+
+		return export.Row(
+			vehicle.LicensePlate, vehicle.Brand, vehicle.Model, vehicle.Year, vehicle.Color,
+			vehicle.VehicleID, vehicle.VIN, string(vehicle.FuelType), vehicle.Mileage,
+			exportText(model.VehicleStatusText, vehicle.Status), driverName,
+			exportDate(vehicle.RegistrationDate), exportDate(vehicle.NextInspectionDate),
+			vehicle.InsuranceCompany, string(vehicle.InsuranceType), exportDate(vehicle.InsuranceExpiry),
+			vehicle.InsuranceCost, exportText(model.AcquisitionTypeText, vehicle.AcquisitionType), monthlyRate,
+		)
+	})
+}
+
 // GetVehicle behandelt die Anfrage, ein Fahrzeug anhand seiner ID abzurufen
 func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 	id := c.Param("id")
@@ -238,6 +301,29 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 		return
 	}
 
+	// FIN prüfen und leere Marke bzw. leeres Baujahr aus ihr vorbelegen
+	if req.VIN != "" {
+		vin, err := decodeVehicleVIN(req.VIN, &req.Brand, &req.Year)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige FIN: " + err.Error()})
+			return
+		}
+		req.VIN = vin
+	}
+	if req.Brand == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Marke ist erforderlich"})
+		return
+	}
+	if req.Year == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Baujahr ist erforderlich"})
+		return
+	}
+
+	if req.RequiredLicenseClass != "" && !model.IsValidLicenseClass(req.RequiredLicenseClass) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Führerscheinklasse"})
+		return
+	}
+
 	// Generiere automatisch eine Fahrzeug-ID, wenn keine angegeben wurde
 	vehicleID := req.VehicleID
 	if vehicleID == "" {
@@ -368,10 +454,14 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 		CurbWeight:         req.CurbWeight,
 		MaxSpeed:           req.MaxSpeed,
 		TowingCapacity:     req.TowingCapacity,
+		TankCapacity:       req.TankCapacity,
 		SpecialFeatures:    req.SpecialFeatures,
 
+		RequiredLicenseClass: req.RequiredLicenseClass,
+
 		// Finanzierungsdaten
 		AcquisitionType:        req.AcquisitionType,
+		ListPrice:              req.ListPrice,
 		PurchaseDate:           purchaseDate,
 		PurchasePrice:          req.PurchasePrice,
 		PurchaseVendor:         req.PurchaseVendor,
@@ -481,8 +571,15 @@ func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
 	if req.VehicleID != "" {
 		vehicle.VehicleID = req.VehicleID
 	}
-	if req.VIN != "" {
-		vehicle.VIN = req.VIN
+	// Nur eine geänderte FIN prüfen, damit ältere FIN mit falscher Prüfziffer andere Änderungen nicht blockieren.
+	// Marke und Baujahr werden nur vorbelegt, wenn sie weder gesendet noch gespeichert sind.
+	if req.VIN != "" && service.NormalizeVIN(req.VIN) != service.NormalizeVIN(vehicle.VIN) {
+		vin, err := decodeVehicleVIN(req.VIN, &vehicle.Brand, &vehicle.Year)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige FIN: " + err.Error()})
+			return
+		}
+		vehicle.VIN = vin
 	}
 	if req.CardNumber != "" {
 		vehicle.CardNumber = req.CardNumber
@@ -555,14 +652,27 @@ func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
 	if req.TowingCapacity != 0 {
 		vehicle.TowingCapacity = req.TowingCapacity
 	}
+	if req.TankCapacity != 0 {
+		vehicle.TankCapacity = req.TankCapacity
+	}
 	if req.SpecialFeatures != "" {
 		vehicle.SpecialFeatures = req.SpecialFeatures
 	}
+	if req.RequiredLicenseClass != nil {
+		if *req.RequiredLicenseClass != "" && !model.IsValidLicenseClass(*req.RequiredLicenseClass) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Führerscheinklasse"})
+			return
+		}
+		vehicle.RequiredLicenseClass = *req.RequiredLicenseClass
+	}
 
 	// Finanzierungsdaten aktualisieren
 	if req.AcquisitionType != "" {
 		vehicle.AcquisitionType = req.AcquisitionType
 	}
+	if req.ListPrice != nil && *req.ListPrice >= 0 {
+		vehicle.ListPrice = *req.ListPrice
+	}
 	if req.PurchasePrice >= 0 {
 		vehicle.PurchasePrice = req.PurchasePrice
 	}
@@ -761,7 +871,11 @@ func (h *VehicleHandler) UpdateBasicInfo(c *gin.Context) {
 		CurbWeight         int     `json:"curbWeight"`
 		MaxSpeed           int     `json:"maxSpeed"`
 		TowingCapacity     int     `json:"towingCapacity"`
+		TankCapacity       float64 `json:"tankCapacity"`
 		SpecialFeatures    string  `json:"specialFeatures"`
+
+		// Führerscheinklasse (leer = aus Fahrzeugdaten abgeleitet)
+		RequiredLicenseClass *model.LicenseClass `json:"requiredLicenseClass"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -811,8 +925,15 @@ func (h *VehicleHandler) UpdateBasicInfo(c *gin.Context) {
 	if req.VehicleID != "" {
 		vehicle.VehicleID = req.VehicleID
 	}
-	if req.VIN != "" {
-		vehicle.VIN = req.VIN
+	// Nur eine geänderte FIN prüfen, damit ältere FIN mit falscher Prüfziffer andere Änderungen nicht blockieren.
+	// Marke und Baujahr werden nur vorbelegt, wenn sie weder gesendet noch gespeichert sind.
+	if req.VIN != "" && service.NormalizeVIN(req.VIN) != service.NormalizeVIN(vehicle.VIN) {
+		vin, err := decodeVehicleVIN(req.VIN, &vehicle.Brand, &vehicle.Year)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige FIN: " + err.Error()})
+			return
+		}
+		vehicle.VIN = vin
 	}
 	if req.CardNumber != "" {
 		vehicle.CardNumber = req.CardNumber
@@ -914,9 +1035,19 @@ func (h *VehicleHandler) UpdateBasicInfo(c *gin.Context) {
 	if req.TowingCapacity != 0 {
 		vehicle.TowingCapacity = req.TowingCapacity
 	}
+	if req.TankCapacity != 0 {
+		vehicle.TankCapacity = req.TankCapacity
+	}
 	if req.SpecialFeatures != "" {
 		vehicle.SpecialFeatures = req.SpecialFeatures
 	}
+	if req.RequiredLicenseClass != nil {
+		if *req.RequiredLicenseClass != "" && !model.IsValidLicenseClass(*req.RequiredLicenseClass) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Führerscheinklasse"})
+			return
+		}
+		vehicle.RequiredLicenseClass = *req.RequiredLicenseClass
+	}
 
 	// Fahrzeug in der Datenbank aktualisieren
 	if err := h.vehicleRepo.Update(vehicle); err != nil {
@@ -1017,3 +1148,29 @@ func (h *VehicleHandler) DeleteVehicle(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Fahrzeug erfolgreich gelöscht"})
 }
+
+// DecodeVIN prüft und entschlüsselt eine FIN, z.B. zum Vorbelegen des Formulars
+func (h *VehicleHandler) DecodeVIN(c *gin.Context) {
+	info, err := service.DecodeVIN(c.Param("vin"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige FIN: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, info)
+}
+
+// decodeVehicleVIN prüft eine FIN, belegt leere Marke und leeres Baujahr aus ihr vor
+// und liefert die normalisierte FIN zurück
+func decodeVehicleVIN(vin string, brand *string, year *int) (string, error) {
+	info, err := service.DecodeVIN(vin)
+	if err != nil {
+		return "", err
+	}
+	if *brand == "" {
+		*brand = info.Brand
+	}
+	if *year == 0 {
+		*year = info.ModelYear
+	}
+	return info.VIN, nil
+}
@@ -13,21 +13,21 @@ import (
 
 // VehicleReportHandler verwaltet Fahrzeugmeldungen
 type VehicleReportHandler struct {
-	reportRepo          *repository.VehicleReportRepository
-	vehicleRepo         *repository.VehicleRepository
-	driverRepo          *repository.DriverRepository
+	reportRepo          repository.VehicleReportRepository
+	vehicleRepo         repository.VehicleRepository
+	driverRepo          repository.DriverRepository
 	activityService     *service.ActivityService
 	notificationService *service.NotificationService
 }
 
 // NewVehicleReportHandler erstellt einen neuen Handler
-func NewVehicleReportHandler() *VehicleReportHandler {
+func NewVehicleReportHandler(repos *repository.Repositories, services *service.Services) *VehicleReportHandler {
 	return &VehicleReportHandler{
-		reportRepo:          repository.NewVehicleReportRepository(),
-		vehicleRepo:         repository.NewVehicleRepository(),
-		driverRepo:          repository.NewDriverRepository(),
-		activityService:     service.NewActivityService(),
-		notificationService: service.NewNotificationService(),
+		reportRepo:          repos.VehicleReport,
+		vehicleRepo:         repos.Vehicle,
+		driverRepo:          repos.Driver,
+		activityService:     services.Activity,
+		notificationService: services.Notification,
 	}
 }
 
@@ -159,12 +159,22 @@ func (h *VehicleReportHandler) GetReports(c *gin.Context) {
 		return
 	}
 
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
 	// Paginierung
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
 	// Status-Filter
 	status := c.Query("status")
+
+	if format != "" {
+		h.exportReports(c, format, model.ReportStatus(status))
+		return
+	}
 	
 	var reports []*model.VehicleReport
 	var err error
@@ -191,6 +201,47 @@ func (h *VehicleReportHandler) GetReports(c *gin.Context) {
 	})
 }
 
+// exportReports exportiert alle Meldungen seitenweise als CSV oder XLSX, optional nach Status gefiltert
+func (h *VehicleReportHandler) exportReports(c *gin.Context, format exportFormat, status model.ReportStatus) {
+	fetch := func(limit, skip int) ([]*model.VehicleReport, error) {
+		return h.reportRepo.FindPage(status, limit, skip)
+	}
+	reports, err := fetch(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Laden der Meldungen"})
+		return
+	}
+
+	export := startExport(c, format, "fahrzeugmeldungen",
+		"Gemeldet am", "Kennzeichen", "Fahrzeug", "Art", "Priorität", "Status", "Titel", "Beschreibung",
+		"Ort", "Kilometerstand", "Behoben am", "Lösung")
+	defer export.Close()
+
+	vehicles := map[primitive.ObjectID]*model.Vehicle{}
+	exportPages(reports, fetch, func(report *model.VehicleReport) error {
+		vehicle, known := vehicles[report.VehicleID]
+		if !known {
+			vehicle, _ = h.vehicleRepo.FindByID(report.VehicleID.Hex())
+			vehicles[report.VehicleID] = vehicle
+		}
+		licensePlate, vehicleName := "", ""
+		if vehicle != nil {
+			licensePlate = vehicle.LicensePlate
+			vehicleName = vehicle.Brand + " " + vehicle.Model
+		}
+		var mileage interface{}
+		if report.Mileage != nil {
+			mileage = *report.Mileage
+		}
+
+		return export.Row(
+			report.CreatedAt, licensePlate, vehicleName, report.GetTypeDisplayName(),
+			report.GetPriorityDisplayName(), report.GetStatusDisplayName(), report.Title, report.Description,
+			report.Location, mileage, report.ResolvedAt, report.Resolution,
+		)
+	})
+}
+
 // GetReportsByDriver gibt Meldungen eines Fahrers zurück
 func (h *VehicleReportHandler) GetReportsByDriver(c *gin.Context) {
 	user, exists := c.Get("user")
@@ -15,19 +15,19 @@ import (
 
 // VehicleUsageHandler repräsentiert den Handler für Fahrzeugnutzungs-Operationen
 type VehicleUsageHandler struct {
-	usageRepo      *repository.VehicleUsageRepository
-	vehicleRepo    *repository.VehicleRepository
-	driverRepo     *repository.DriverRepository
+	usageRepo      repository.VehicleUsageRepository
+	vehicleRepo    repository.VehicleRepository
+	driverRepo     repository.DriverRepository
 	mileageService *service.VehicleMileageService
 }
 
 // NewVehicleUsageHandler erstellt einen neuen VehicleUsageHandler
-func NewVehicleUsageHandler() *VehicleUsageHandler {
+func NewVehicleUsageHandler(repos *repository.Repositories, services *service.Services) *VehicleUsageHandler {
 	return &VehicleUsageHandler{
-		usageRepo:      repository.NewVehicleUsageRepository(),
-		vehicleRepo:    repository.NewVehicleRepository(),
-		driverRepo:     repository.NewDriverRepository(),
-		mileageService: service.NewVehicleMileageService(),
+		usageRepo:      repos.VehicleUsage,
+		vehicleRepo:    repos.Vehicle,
+		driverRepo:     repos.Driver,
+		mileageService: services.VehicleMileage,
 	}
 }
 
@@ -49,6 +49,16 @@ type CreateUsageRequest struct {
 
 // GetUsageEntries behandelt die Anfrage, alle Nutzungseinträge abzurufen
 func (h *VehicleUsageHandler) GetUsageEntries(c *gin.Context) {
+	format, ok := requestedExport(c)
+	if !ok {
+		return
+	}
+
+	if format != "" {
+		h.exportUsageEntries(c, format)
+		return
+	}
+
 	entries, err := h.usageRepo.FindAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Nutzungseinträge"})
@@ -84,6 +94,43 @@ func (h *VehicleUsageHandler) GetUsageEntries(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"usage": result})
 }
 
+// exportUsageEntries exportiert die Nutzungseinträge seitenweise als CSV oder XLSX
+func (h *VehicleUsageHandler) exportUsageEntries(c *gin.Context, format exportFormat) {
+	entries, err := h.usageRepo.FindPage(exportPageSize, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Nutzungseinträge"})
+		return
+	}
+
+	export := startExport(c, format, "fahrzeugnutzung",
+		"Beginn", "Ende", "Kennzeichen", "Fahrzeug", "Fahrer", "Abteilung", "Zweck",
+		"Kilometerstand Beginn", "Kilometerstand Ende", "Gefahrene km", "Status", "Notizen")
+	defer export.Close()
+
+	exportPages(entries, h.usageRepo.FindPage, func(entry *model.VehicleUsage) error {
+		licensePlate, vehicleName := "", ""
+		if vehicle, err := h.vehicleRepo.FindByID(entry.VehicleID.Hex()); err == nil {
+			licensePlate = vehicle.LicensePlate
+			vehicleName = vehicle.Brand + " " + vehicle.Model
+		}
+		driverName := ""
+		if driver, err := h.driverRepo.FindByID(entry.DriverID.Hex()); err == nil {
+			driverName = driver.FirstName + " " + driver.LastName
+		}
+		var endMileage, distance interface{}
+		if entry.EndMileage > 0 {
+			endMileage = entry.EndMileage
+			distance = entry.EndMileage - entry.StartMileage
+		}
+
+		return export.Row(
+			entry.StartDate, entry.EndDate, licensePlate, vehicleName, driverName, entry.Department,
+			entry.Purpose, entry.StartMileage, endMileage, distance,
+			exportText(model.UsageStatusText, entry.Status), entry.Notes,
+		)
+	})
+}
+
 // GetVehicleUsageEntries behandelt die Anfrage, alle Nutzungseinträge für ein Fahrzeug abzurufen
 func (h *VehicleUsageHandler) GetVehicleUsageEntries(c *gin.Context) {
 	vehicleID := c.Param("vehicleId")
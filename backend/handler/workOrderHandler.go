@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkOrderHandler stellt Werkstattaufträge zu Fahrzeugmeldungen bereit
+type WorkOrderHandler struct {
+	workOrderService *service.WorkOrderService
+}
+
+// NewWorkOrderHandler erstellt einen neuen WorkOrderHandler
+func NewWorkOrderHandler(services *service.Services) *WorkOrderHandler {
+	return &WorkOrderHandler{
+		workOrderService: services.WorkOrder,
+	}
+}
+
+// WorkOrderRequest enthält die Angaben eines Werkstattauftrags
+type WorkOrderRequest struct {
+	VehicleID       string                    `json:"vehicleId"`
+	ReportIDs       []string                  `json:"reportIds"`
+	Workshop        string                    `json:"workshop" binding:"required"`
+	Description     string                    `json:"description"`
+	MaintenanceType model.MaintenanceType     `json:"maintenanceType"`
+	LineItems       []model.WorkOrderLineItem `json:"lineItems"`
+	EstimatedCost   float64                   `json:"estimatedCost"`
+	DowntimeStart   string                    `json:"downtimeStart"` // Optional: YYYY-MM-DDTHH:MM (Europe/Berlin)
+}
+
+// WorkOrderCloseRequest enthält die Angaben zum Abschluss eines Werkstattauftrags
+type WorkOrderCloseRequest struct {
+	LineItems   []model.WorkOrderLineItem `json:"lineItems"` // Optional: Positionen laut Rechnung
+	ActualCost  float64                   `json:"actualCost"`
+	DowntimeEnd string                    `json:"downtimeEnd"` // Optional: YYYY-MM-DDTHH:MM (Europe/Berlin)
+	Mileage     int                       `json:"mileage"`
+	Resolution  string                    `json:"resolution"`
+}
+
+// GetWorkOrders gibt die Werkstattaufträge zurück (?vehicleId=&reportId=&status=open,in_progress)
+func (h *WorkOrderHandler) GetWorkOrders(c *gin.Context) {
+	var filter model.WorkOrderFilter
+	var err error
+	if filter.VehicleID, err = optionalObjectID(c.Query("vehicleId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Fahrzeug-ID"})
+		return
+	}
+	if filter.ReportID, err = optionalObjectID(c.Query("reportId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültige Meldungs-ID"})
+		return
+	}
+	if statuses := c.Query("status"); statuses != "" {
+		for _, value := range strings.Split(statuses, ",") {
+			status := model.WorkOrderStatus(strings.TrimSpace(value))
+			if _, ok := model.WorkOrderStatusText[status]; !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Status, erlaubt sind open, in_progress, closed und cancelled"})
+				return
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	orders, err := h.workOrderService.GetWorkOrders(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Werkstattaufträge"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workOrders": orders, "count": len(orders)})
+}
+
+// GetWorkOrder gibt einen Werkstattauftrag zurück
+func (h *WorkOrderHandler) GetWorkOrder(c *gin.Context) {
+	order, err := h.workOrderService.GetWorkOrder(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workOrder": order, "downtimeDays": order.DowntimeDays()})
+}
+
+// CreateWorkOrder legt einen Werkstattauftrag zu einer oder mehreren Meldungen an
+func (h *WorkOrderHandler) CreateWorkOrder(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	input, ok := h.bindInput(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.workOrderService.CreateWorkOrder(user, input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// UpdateWorkOrder ändert einen offenen oder laufenden Werkstattauftrag
+func (h *WorkOrderHandler) UpdateWorkOrder(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	input, ok := h.bindInput(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.workOrderService.UpdateWorkOrder(user, c.Param("id"), input)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// StartWorkOrder vermerkt, dass das Fahrzeug in der Werkstatt steht
+func (h *WorkOrderHandler) StartWorkOrder(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req struct {
+		DowntimeStart string `json:"downtimeStart"` // Optional: YYYY-MM-DDTHH:MM, sonst jetzt
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	start, err := parseWorkOrderTime(req.DowntimeStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Beginn der Standzeit, erwartet wird YYYY-MM-DDTHH:MM"})
+		return
+	}
+
+	order, err := h.workOrderService.StartWorkOrder(user, c.Param("id"), start)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CloseWorkOrder schließt einen Werkstattauftrag ab
+func (h *WorkOrderHandler) CloseWorkOrder(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	var req WorkOrderCloseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	end, err := parseWorkOrderTime(req.DowntimeEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Ende der Standzeit, erwartet wird YYYY-MM-DDTHH:MM"})
+		return
+	}
+
+	order, err := h.workOrderService.CloseWorkOrder(user, c.Param("id"), service.WorkOrderCloseInput{
+		LineItems:   req.LineItems,
+		ActualCost:  req.ActualCost,
+		DowntimeEnd: end,
+		Mileage:     req.Mileage,
+		Resolution:  req.Resolution,
+	})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelWorkOrder storniert einen Werkstattauftrag
+func (h *WorkOrderHandler) CancelWorkOrder(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Nicht authentifiziert"})
+		return
+	}
+
+	order, err := h.workOrderService.CancelWorkOrder(user, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// bindInput liest die Anfrage zum Anlegen oder Ändern eines Auftrags
+func (h *WorkOrderHandler) bindInput(c *gin.Context) (service.WorkOrderInput, bool) {
+	var req WorkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return service.WorkOrderInput{}, false
+	}
+	start, err := parseWorkOrderTime(req.DowntimeStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiger Beginn der Standzeit, erwartet wird YYYY-MM-DDTHH:MM"})
+		return service.WorkOrderInput{}, false
+	}
+
+	return service.WorkOrderInput{
+		VehicleID:       req.VehicleID,
+		ReportIDs:       req.ReportIDs,
+		Workshop:        req.Workshop,
+		Description:     req.Description,
+		MaintenanceType: req.MaintenanceType,
+		LineItems:       req.LineItems,
+		EstimatedCost:   req.EstimatedCost,
+		DowntimeStart:   start,
+	}, true
+}
+
+// respondError übersetzt Fehler des WorkOrderService in HTTP-Antworten
+func (h *WorkOrderHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrWorkOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Werkstattauftrag nicht gefunden"})
+	case errors.Is(err, service.ErrWorkOrderState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// parseWorkOrderTime liest einen optionalen Zeitpunkt im Format YYYY-MM-DDTHH:MM (Europe/Berlin)
+func parseWorkOrderTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+	if err != nil {
+		return nil, fmt.Errorf("ungültiger zeitpunkt: %s", value)
+	}
+	return &t, nil
+}
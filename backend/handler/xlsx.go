@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Zellformate aus xlsxStyles (Index in cellXfs)
+const (
+	xlsxStyleDefault  = 0
+	xlsxStyleHeader   = 1
+	xlsxStyleAmount   = 2
+	xlsxStyleDate     = 3
+	xlsxStyleDateTime = 4
+	xlsxStyleInteger  = 5
+)
+
+// xlsxEpoch ist der Nullpunkt der Excel-Datumszählung (1900er-System)
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+// xlsxStyles definiert Kopfzeile, Beträge, Datum, Datum mit Uhrzeit und Ganzzahlen.
+// Tausender- und Dezimaltrennzeichen setzt Excel nach den Ländereinstellungen.
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="2"><numFmt numFmtId="164" formatCode="DD.MM.YYYY"/><numFmt numFmtId="165" formatCode="DD.MM.YYYY HH:MM"/></numFmts><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="6"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="1" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs></styleSheet>`
+
+// xlsxWriter schreibt eine Arbeitsmappe mit einem Tabellenblatt zeilenweise in einen Stream.
+// Texte werden als Inline-Strings geschrieben, damit keine Stringtabelle im Speicher entsteht.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+	err   error
+}
+
+// xlsxCell ist eine Zelle mit Wert und Zellformat
+type xlsxCell struct {
+	text   string
+	number float64
+	style  int
+	isText bool
+	empty  bool
+}
+
+// newXLSXWriter schreibt die festen Bestandteile der Arbeitsmappe und öffnet das Tabellenblatt
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	x := &xlsxWriter{zip: zip.NewWriter(w)}
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` + xmlEscape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		file, err := x.zip.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x.sheet = sheet
+	x.write(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData>`)
+	return x, x.err
+}
+
+// WriteRow schreibt eine Zeile
+func (x *xlsxWriter) WriteRow(cells []xlsxCell) error {
+	x.rows++
+	row := strconv.Itoa(x.rows)
+	x.write(`<row r="` + row + `">`)
+	for i, cell := range cells {
+		if cell.empty {
+			continue
+		}
+		ref := xlsxColumn(i) + row
+		style := ""
+		if cell.style != xlsxStyleDefault {
+			style = ` s="` + strconv.Itoa(cell.style) + `"`
+		}
+		if cell.isText {
+			x.write(`<c r="` + ref + `"` + style + ` t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(cell.text) + `</t></is></c>`)
+		} else {
+			x.write(`<c r="` + ref + `"` + style + `><v>` + strconv.FormatFloat(cell.number, 'f', -1, 64) + `</v></c>`)
+		}
+	}
+	x.write(`</row>`)
+	return x.err
+}
+
+// Close schließt Tabellenblatt und Arbeitsmappe ab
+func (x *xlsxWriter) Close() error {
+	x.write(`</sheetData></worksheet>`)
+	if x.err != nil {
+		return x.err
+	}
+	return x.zip.Close()
+}
+
+func (x *xlsxWriter) write(s string) {
+	if x.err != nil {
+		return
+	}
+	_, x.err = io.WriteString(x.sheet, s)
+}
+
+// xlsxColumn liefert den Spaltenbuchstaben (A, B, …, Z, AA, …) zu einem Index ab 0
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxSerial wandelt einen Zeitpunkt in eine Excel-Seriennummer nach deutscher Ortszeit um
+func xlsxSerial(t time.Time, location *time.Location) float64 {
+	local := t.In(location)
+	wallClock := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+	return wallClock.Sub(xlsxEpoch).Hours() / 24
+}
+
+// xmlEscape maskiert Text für XML; ungültige Zeichen werden ersetzt
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // AuthMiddleware ist eine Middleware für die Benutzerauthentifizierung
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(userRepo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Token aus dem Cookie oder Auth-Header extrahieren
 		tokenString, err := extractToken(c)
@@ -34,7 +34,6 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Benutzer aus der Datenbank abrufen
-		userRepo := repository.NewUserRepository()
 		user, err := userRepo.FindByID(claims.UserID)
 		if err != nil {
 			// Benutzer nicht gefunden, zum Login umleiten
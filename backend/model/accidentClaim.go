@@ -0,0 +1,170 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClaimStatus ist der Bearbeitungsstand eines Schadenfalls
+type ClaimStatus string
+
+const (
+	ClaimStatusOpen     ClaimStatus = "open"     // Angelegt, noch nicht bei der Versicherung gemeldet
+	ClaimStatusReported ClaimStatus = "reported" // Bei der Versicherung gemeldet, Regulierung offen
+	ClaimStatusSettled  ClaimStatus = "settled"  // Von der Versicherung reguliert
+	ClaimStatusRejected ClaimStatus = "rejected" // Von der Versicherung abgelehnt
+	ClaimStatusClosed   ClaimStatus = "closed"   // Abgeschlossen
+)
+
+// ClaimStatusText enthält die Anzeigenamen der Status
+var ClaimStatusText = map[ClaimStatus]string{
+	ClaimStatusOpen:     "Offen",
+	ClaimStatusReported: "Gemeldet",
+	ClaimStatusSettled:  "Reguliert",
+	ClaimStatusRejected: "Abgelehnt",
+	ClaimStatusClosed:   "Abgeschlossen",
+}
+
+// ClaimFault ist die Einschätzung der Schuldfrage
+type ClaimFault string
+
+const (
+	ClaimFaultUnknown    ClaimFault = "unknown"     // Ungeklärt
+	ClaimFaultOwn        ClaimFault = "own"         // Eigenverschulden
+	ClaimFaultThirdParty ClaimFault = "third_party" // Fremdverschulden
+	ClaimFaultShared     ClaimFault = "shared"      // Teilschuld
+)
+
+// ClaimFaultText enthält die Anzeigenamen der Schuldfrage
+var ClaimFaultText = map[ClaimFault]string{
+	ClaimFaultUnknown:    "Ungeklärt",
+	ClaimFaultOwn:        "Eigenverschulden",
+	ClaimFaultThirdParty: "Fremdverschulden",
+	ClaimFaultShared:     "Teilschuld",
+}
+
+// ClaimPartyRole ist die Rolle eines Beteiligten am Unfall
+type ClaimPartyRole string
+
+const (
+	ClaimPartyOwnDriver ClaimPartyRole = "own_driver" // Fahrer des eigenen Fahrzeugs
+	ClaimPartyOpponent  ClaimPartyRole = "opponent"   // Unfallgegner
+	ClaimPartyWitness   ClaimPartyRole = "witness"    // Zeuge
+	ClaimPartyOther     ClaimPartyRole = "other"      // Sonstige, z.B. Geschädigter ohne Fahrzeug
+)
+
+// ClaimParty ist ein am Unfall Beteiligter
+type ClaimParty struct {
+	Role             ClaimPartyRole `bson:"role" json:"role"`
+	Name             string         `bson:"name" json:"name"`
+	Phone            string         `bson:"phone,omitempty" json:"phone,omitempty"`
+	Address          string         `bson:"address,omitempty" json:"address,omitempty"`
+	LicensePlate     string         `bson:"licensePlate,omitempty" json:"licensePlate,omitempty"`
+	InsuranceCompany string         `bson:"insuranceCompany,omitempty" json:"insuranceCompany,omitempty"`
+	InsuranceNumber  string         `bson:"insuranceNumber,omitempty" json:"insuranceNumber,omitempty"`
+	Notes            string         `bson:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// ClaimTimelineType ist die Art eines Eintrags im Verlauf eines Schadenfalls
+type ClaimTimelineType string
+
+const (
+	ClaimTimelineCreated       ClaimTimelineType = "created"
+	ClaimTimelineStatus        ClaimTimelineType = "status"
+	ClaimTimelineUpdate        ClaimTimelineType = "update"
+	ClaimTimelineNote          ClaimTimelineType = "note"
+	ClaimTimelineDocument      ClaimTimelineType = "document"
+	ClaimTimelineReimbursement ClaimTimelineType = "reimbursement"
+)
+
+// ClaimTimelineEntry ist ein Eintrag im Verlauf eines Schadenfalls
+type ClaimTimelineEntry struct {
+	Date       time.Time           `bson:"date" json:"date"`
+	Type       ClaimTimelineType   `bson:"type" json:"type"`
+	Text       string              `bson:"text" json:"text"`
+	UserID     primitive.ObjectID  `bson:"userId" json:"userId"`
+	DocumentID *primitive.ObjectID `bson:"documentId,omitempty" json:"documentId,omitempty"`
+}
+
+// AccidentClaim ist der Schadenfall zu einer Unfallmeldung
+type AccidentClaim struct {
+	ID                    primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	ReportID              primitive.ObjectID   `bson:"reportId" json:"reportId"` // Fahrzeugmeldung vom Typ "accident"
+	VehicleID             primitive.ObjectID   `bson:"vehicleId" json:"vehicleId"`
+	DriverID              *primitive.ObjectID  `bson:"driverId,omitempty" json:"driverId,omitempty"`
+	IncidentDate          time.Time            `bson:"incidentDate" json:"incidentDate"`
+	Location              string               `bson:"location" json:"location"`
+	Description           string               `bson:"description" json:"description"`
+	Parties               []ClaimParty         `bson:"parties" json:"parties"`
+	PoliceReportNumber    string               `bson:"policeReportNumber" json:"policeReportNumber"` // Aktenzeichen der Polizei
+	Fault                 ClaimFault           `bson:"fault" json:"fault"`
+	FaultShare            int                  `bson:"faultShare" json:"faultShare"`             // Eigener Haftungsanteil in Prozent
+	InsuranceCompany      string               `bson:"insuranceCompany" json:"insuranceCompany"` // Aus dem Fahrzeug übernommen
+	InsuranceNumber       string               `bson:"insuranceNumber" json:"insuranceNumber"`
+	InsuranceType         InsuranceType        `bson:"insuranceType" json:"insuranceType"`
+	ClaimNumber           string               `bson:"claimNumber" json:"claimNumber"`                     // Schadennummer des Versicherers
+	WorkOrderID           *primitive.ObjectID  `bson:"workOrderId,omitempty" json:"workOrderId,omitempty"` // Werkstattauftrag der Reparatur
+	RepairCost            float64              `bson:"repairCost" json:"repairCost"`
+	Deductible            float64              `bson:"deductible" json:"deductible"` // Selbstbeteiligung
+	ReimbursementReceived float64              `bson:"reimbursementReceived" json:"reimbursementReceived"`
+	ReimbursedAt          *time.Time           `bson:"reimbursedAt,omitempty" json:"reimbursedAt,omitempty"` // Letzte Zahlung
+	DowntimeStart         *time.Time           `bson:"downtimeStart,omitempty" json:"downtimeStart,omitempty"`
+	DowntimeEnd           *time.Time           `bson:"downtimeEnd,omitempty" json:"downtimeEnd,omitempty"`
+	DocumentIDs           []primitive.ObjectID `bson:"documentIds" json:"documentIds"` // Fahrzeugdokumente vom Typ "claim_document"
+	Timeline              []ClaimTimelineEntry `bson:"timeline" json:"timeline"`
+	Status                ClaimStatus          `bson:"status" json:"status"`
+	ClosedAt              *time.Time           `bson:"closedAt,omitempty" json:"closedAt,omitempty"`
+	CreatedBy             primitive.ObjectID   `bson:"createdBy" json:"createdBy"`
+	CreatedAt             time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt             time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// NetCost liefert die Kosten des Schadens nach Erstattungen
+func (c *AccidentClaim) NetCost() float64 {
+	return c.RepairCost - c.ReimbursementReceived
+}
+
+// AdditionalCost liefert die Kosten, die nicht bereits als Wartungseintrag erfasst sind.
+// Ist ein Werkstattauftrag verknüpft, steckt die Reparatur schon in dessen Wartungseintrag
+// und es zählt nur die Erstattung.
+func (c *AccidentClaim) AdditionalCost() float64 {
+	if c.WorkOrderID != nil {
+		return -c.ReimbursementReceived
+	}
+	return c.NetCost()
+}
+
+// DowntimeDays liefert die Ausfallzeit in angefangenen Tagen (bei laufendem Ausfall bis jetzt)
+func (c *AccidentClaim) DowntimeDays() int {
+	if c.DowntimeStart == nil {
+		return 0
+	}
+	end := time.Now()
+	if c.DowntimeEnd != nil {
+		end = *c.DowntimeEnd
+	}
+	hours := end.Sub(*c.DowntimeStart).Hours()
+	if hours <= 0 {
+		return 0
+	}
+	days := int(hours / 24)
+	if float64(days)*24 < hours {
+		days++
+	}
+	return days
+}
+
+// IsActive prüft, ob der Schadenfall noch bearbeitet wird
+func (c *AccidentClaim) IsActive() bool {
+	return c.Status != ClaimStatusClosed
+}
+
+// AccidentClaimFilter schränkt die Abfrage von Schadenfällen ein
+type AccidentClaimFilter struct {
+	VehicleID *primitive.ObjectID
+	DriverID  *primitive.ObjectID
+	Statuses  []ClaimStatus // Leer = alle Status
+	From      *time.Time    // Unfalldatum ab
+	To        *time.Time    // Unfalldatum bis einschließlich
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BulkImportEntity ist die Art der Datensätze, die ein Massenimport anlegt
+type BulkImportEntity string
+
+const (
+	BulkImportVehicles BulkImportEntity = "vehicles"
+	BulkImportDrivers  BulkImportEntity = "drivers"
+)
+
+// BulkImportEntityText enthält die Anzeigenamen der Importarten
+var BulkImportEntityText = map[BulkImportEntity]string{
+	BulkImportVehicles: "Fahrzeuge",
+	BulkImportDrivers:  "Fahrer",
+}
+
+// BulkImportField ist ein Feld, dem beim Massenimport eine Spalte der Datei zugeordnet werden kann
+type BulkImportField struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Required bool     `json:"required"`
+	Format   string   `json:"format,omitempty"` // Hinweis auf das erwartete Format
+	Aliases  []string `json:"-"`                // Spaltenüberschriften für die automatische Zuordnung
+}
+
+// VehicleImportFields sind die Felder eines Fahrzeugimports
+var VehicleImportFields = []BulkImportField{
+	{Key: "licensePlate", Label: "Kennzeichen", Required: true, Format: "z.B. B-AB 1234", Aliases: []string{"amtliches kennzeichen", "kfz-kennzeichen", "license plate", "plate"}},
+	{Key: "brand", Label: "Marke", Format: "leer = aus der FIN", Aliases: []string{"hersteller", "make", "manufacturer"}},
+	{Key: "model", Label: "Modell", Required: true, Aliases: []string{"typ", "modellbezeichnung"}},
+	{Key: "year", Label: "Baujahr", Format: "JJJJ, leer = aus der FIN", Aliases: []string{"modelljahr", "jahr"}},
+	{Key: "color", Label: "Farbe", Aliases: []string{"colour"}},
+	{Key: "vehicleId", Label: "Fahrzeugnummer", Aliases: []string{"fahrzeug-id", "interne nummer", "vehicle id"}},
+	{Key: "vin", Label: "FIN", Format: "17 Zeichen", Aliases: []string{"fahrgestellnummer", "fahrzeug-identifizierungsnummer", "vin"}},
+	{Key: "cardNumber", Label: "Tankkartennummer", Aliases: []string{"tankkarte", "fuel card"}},
+	{Key: "fuelType", Label: "Kraftstoff", Format: "Benzin, Diesel, Elektro, Hybrid (Benzin/Elektro), Hybrid (Diesel/Elektro), Wasserstoff", Aliases: []string{"kraftstoffart", "antrieb", "fuel type", "fuel"}},
+	{Key: "mileage", Label: "Kilometerstand", Aliases: []string{"km-stand", "laufleistung", "mileage", "odometer"}},
+	{Key: "registrationDate", Label: "Erstzulassung", Format: "TT.MM.JJJJ", Aliases: []string{"zulassungsdatum", "zulassung", "registration date"}},
+	{Key: "vehicleType", Label: "Fahrzeugart", Aliases: []string{"fahrzeugtyp", "vehicle type"}},
+	{Key: "requiredLicenseClass", Label: "Führerscheinklasse", Format: "z.B. B, BE, C1", Aliases: []string{"fuehrerscheinklasse", "license class"}},
+	{Key: "insuranceCompany", Label: "Versicherung", Aliases: []string{"versicherer", "insurance company"}},
+	{Key: "insuranceNumber", Label: "Versicherungsnummer", Aliases: []string{"policennummer", "insurance number"}},
+	{Key: "insuranceType", Label: "Versicherungsart", Format: "Haftpflicht, Teilkasko, Vollkasko", Aliases: []string{"deckung", "insurance type"}},
+	{Key: "insuranceExpiry", Label: "Versicherung gültig bis", Format: "TT.MM.JJJJ", Aliases: []string{"versicherungsablauf", "insurance expiry"}},
+	{Key: "insuranceCost", Label: "Versicherungskosten", Aliases: []string{"versicherungsbeitrag", "insurance cost"}},
+	{Key: "nextInspectionDate", Label: "Nächste HU", Format: "TT.MM.JJJJ", Aliases: []string{"hu", "hu/au", "tüv", "next inspection"}},
+	{Key: "acquisitionType", Label: "Erwerbsart", Format: "Kauf, Finanzierung, Leasing", Aliases: []string{"beschaffung", "acquisition type"}},
+	{Key: "listPrice", Label: "Bruttolistenpreis", Aliases: []string{"listenpreis", "list price"}},
+}
+
+// DriverImportFields sind die Felder eines Fahrerimports
+var DriverImportFields = []BulkImportField{
+	{Key: "firstName", Label: "Vorname", Required: true, Aliases: []string{"first name", "given name"}},
+	{Key: "lastName", Label: "Nachname", Required: true, Aliases: []string{"name", "familienname", "last name", "surname"}},
+	{Key: "email", Label: "E-Mail", Required: true, Aliases: []string{"email", "e-mail-adresse", "mail"}},
+	{Key: "driverNumber", Label: "Personalnummer", Aliases: []string{"fahrernummer", "mitarbeiternummer", "driver number", "employee id"}},
+	{Key: "phone", Label: "Telefon", Aliases: []string{"telefonnummer", "mobil", "handy", "phone"}},
+	{Key: "licenseClasses", Label: "Führerscheinklassen", Format: "z.B. B, BE", Aliases: []string{"fuehrerscheinklassen", "führerschein", "license classes"}},
+	{Key: "commuteDistanceKm", Label: "Entfernung zur Arbeitsstätte (km)", Aliases: []string{"arbeitsweg", "entfernung", "commute distance"}},
+	{Key: "notes", Label: "Notizen", Aliases: []string{"bemerkung", "bemerkungen", "notes"}},
+}
+
+// BulkImportFields liefert die Felder einer Importart, für unbekannte Arten nil
+func BulkImportFields(entity BulkImportEntity) []BulkImportField {
+	switch entity {
+	case BulkImportVehicles:
+		return VehicleImportFields
+	case BulkImportDrivers:
+		return DriverImportFields
+	}
+	return nil
+}
+
+// BulkImportStatus ist der Status eines Massenimports
+type BulkImportStatus string
+
+const (
+	BulkImportPreview   BulkImportStatus = "preview"   // Probelauf, Zuordnung kann noch geändert werden
+	BulkImportQueued    BulkImportStatus = "queued"    // Übernahme beauftragt, wartet auf den Hintergrundjob
+	BulkImportRunning   BulkImportStatus = "running"   // Wird vom Hintergrundjob übernommen
+	BulkImportCommitted BulkImportStatus = "committed" // Gültige Zeilen angelegt
+	BulkImportDiscarded BulkImportStatus = "discarded" // Verworfen
+)
+
+// BulkImportBatch ist eine hochgeladene Fahrzeug- oder Fahrerliste
+type BulkImportBatch struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Entity      BulkImportEntity   `bson:"entity" json:"entity"`
+	FileName    string             `bson:"fileName" json:"fileName"`
+	Headers     []string           `bson:"headers" json:"headers"`
+	Mapping     map[string]string  `bson:"mapping" json:"mapping"` // Feld -> Spaltenüberschrift
+	Status      BulkImportStatus   `bson:"status" json:"status"`
+	Counts      BulkImportCounts   `bson:"counts" json:"counts"`
+	CreatedBy   primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	QueuedAt    *time.Time         `bson:"queuedAt,omitempty" json:"queuedAt,omitempty"`
+	CompletedAt *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// BulkImportCounts zählt die Zeilen eines Massenimports nach Status
+type BulkImportCounts struct {
+	Total    int `bson:"total" json:"total"`
+	Valid    int `bson:"valid" json:"valid"`
+	Invalid  int `bson:"invalid" json:"invalid"`
+	Imported int `bson:"imported" json:"imported"`
+	Failed   int `bson:"failed" json:"failed"`
+}
+
+// BulkImportRowStatus ist der Status einer Zeile eines Massenimports
+type BulkImportRowStatus string
+
+const (
+	BulkImportRowValid    BulkImportRowStatus = "valid"    // Wird bei der Übernahme angelegt
+	BulkImportRowInvalid  BulkImportRowStatus = "invalid"  // Fehlerhaft, wird übersprungen
+	BulkImportRowImported BulkImportRowStatus = "imported" // Angelegt
+	BulkImportRowFailed   BulkImportRowStatus = "failed"   // Speichern fehlgeschlagen
+)
+
+// BulkImportRowError ist ein Fehler in einem Feld einer Importzeile
+type BulkImportRowError struct {
+	Field   string `bson:"field" json:"field"`
+	Message string `bson:"message" json:"message"`
+}
+
+// BulkImportRow ist eine Zeile einer hochgeladenen Fahrzeug- oder Fahrerliste
+type BulkImportRow struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	BatchID   primitive.ObjectID   `bson:"batchId" json:"batchId"`
+	RowNumber int                  `bson:"rowNumber" json:"rowNumber"` // Zeilennummer in der Datei
+	Raw       []string             `bson:"raw" json:"raw"`             // Zellen in Spaltenreihenfolge
+	Values    map[string]string    `bson:"values" json:"values"`       // Werte nach Zuordnung, Feld -> Wert
+	Status    BulkImportRowStatus  `bson:"status" json:"status"`
+	Errors    []BulkImportRowError `bson:"errors,omitempty" json:"errors,omitempty"`
+	Message   string               `bson:"message,omitempty" json:"message,omitempty"`
+	RecordID  *primitive.ObjectID  `bson:"recordId,omitempty" json:"recordId,omitempty"` // Angelegtes Fahrzeug bzw. angelegter Fahrer
+	CreatedAt time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
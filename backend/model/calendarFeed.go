@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarFeedScope legt fest, welche Reservierungen ein Kalender-Abonnement enthält
+type CalendarFeedScope string
+
+const (
+	CalendarFeedScopeFleet   CalendarFeedScope = "fleet"   // Alle Reservierungen der Flotte
+	CalendarFeedScopeVehicle CalendarFeedScope = "vehicle" // Reservierungen eines Fahrzeugs
+	CalendarFeedScopeDriver  CalendarFeedScope = "driver"  // Reservierungen eines Fahrers
+)
+
+// CalendarFeedToken berechtigt zum Abruf eines iCalendar-Feeds ohne Anmeldung.
+// Der Token ist Teil der Abo-URL und kann vom Benutzer jederzeit widerrufen werden.
+type CalendarFeedToken struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID  `bson:"userId" json:"userId"` // Besitzer des Abonnements
+	Token          string              `bson:"token" json:"token"`   // Geheimer Bestandteil der Feed-URL
+	Scope          CalendarFeedScope   `bson:"scope" json:"scope"`
+	TargetID       *primitive.ObjectID `bson:"targetId,omitempty" json:"targetId,omitempty"`   // Fahrzeug bzw. Fahrer (nicht bei fleet)
+	Name           string              `bson:"name" json:"name"`                               // Anzeigename des Kalenders
+	LastAccessedAt *time.Time          `bson:"lastAccessedAt,omitempty" json:"lastAccessedAt"` // Letzter Abruf durch einen Kalender-Client
+	RevokedAt      *time.Time          `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"` // Zeitpunkt des Widerrufs
+	CreatedAt      time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// IsRevoked prüft, ob der Token widerrufen wurde
+func (t *CalendarFeedToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ChargingLocationType gibt an, wo ein Elektrofahrzeug geladen wurde
+type ChargingLocationType string
+
+const (
+	ChargingLocationDepot  ChargingLocationType = "depot"  // Ladepunkt am Firmenstandort
+	ChargingLocationPublic ChargingLocationType = "public" // Öffentliche Ladesäule
+	ChargingLocationHome   ChargingLocationType = "home"   // Wallbox beim Mitarbeiter, erstattungsfähig
+)
+
+// IsValidChargingLocationType prüft, ob ein Ladeort bekannt ist
+func IsValidChargingLocationType(locationType ChargingLocationType) bool {
+	switch locationType {
+	case ChargingLocationDepot, ChargingLocationPublic, ChargingLocationHome:
+		return true
+	}
+	return false
+}
+
+// ChargingLocationTypeText gibt die deutsche Bezeichnung eines Ladeorts zurück
+func ChargingLocationTypeText(locationType ChargingLocationType) string {
+	switch locationType {
+	case ChargingLocationDepot:
+		return "Betriebshof"
+	case ChargingLocationPublic:
+		return "Öffentlich"
+	case ChargingLocationHome:
+		return "Zuhause"
+	default:
+		return string(locationType)
+	}
+}
+
+// ReimbursementStatus ist der Status eines Erstattungsantrags für das Laden zu Hause
+type ReimbursementStatus string
+
+const (
+	ReimbursementNone     ReimbursementStatus = ""         // Keine Erstattung (Betriebshof, öffentlich)
+	ReimbursementPending  ReimbursementStatus = "pending"  // Wartet auf Freigabe
+	ReimbursementApproved ReimbursementStatus = "approved" // Freigegeben
+	ReimbursementRejected ReimbursementStatus = "rejected" // Abgelehnt
+)
+
+// ChargingReimbursement ist der Erstattungsantrag eines Ladevorgangs zu Hause
+type ChargingReimbursement struct {
+	Status     ReimbursementStatus `bson:"status" json:"status"`
+	Amount     float64             `bson:"amount" json:"amount"`
+	ReviewedBy *primitive.ObjectID `bson:"reviewedBy,omitempty" json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time          `bson:"reviewedAt,omitempty" json:"reviewedAt,omitempty"`
+	Comment    string              `bson:"comment,omitempty" json:"comment,omitempty"` // Begründung bei Ablehnung
+}
+
+// ChargingSession ist ein Ladevorgang eines Elektro- oder Plug-in-Hybridfahrzeugs
+type ChargingSession struct {
+	ID            primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	VehicleID     primitive.ObjectID    `bson:"vehicleId" json:"vehicleId"`
+	DriverID      primitive.ObjectID    `bson:"driverId,omitempty" json:"driverId"`
+	StartTime     time.Time             `bson:"startTime" json:"startTime"`
+	EndTime       time.Time             `bson:"endTime" json:"endTime"`
+	EnergyKWh     float64               `bson:"energyKWh" json:"energyKWh"`
+	ChargePointID string                `bson:"chargePointId" json:"chargePointId"` // EVSE-ID oder interne Bezeichnung des Ladepunkts
+	LocationType  ChargingLocationType  `bson:"locationType" json:"locationType"`
+	Location      string                `bson:"location" json:"location"`
+	Tariff        string                `bson:"tariff" json:"tariff"`           // Name des Ladetarifs
+	PricePerKWh   float64               `bson:"pricePerKWh" json:"pricePerKWh"` // Preis pro kWh laut Tarif
+	Cost          float64               `bson:"cost" json:"cost"`               // Gesamtkosten inkl. Grundgebühren
+	Mileage       int                   `bson:"mileage" json:"mileage"`         // Kilometerstand beim Laden
+	Notes         string                `bson:"notes" json:"notes"`
+	Reimbursement ChargingReimbursement `bson:"reimbursement" json:"reimbursement"`
+	CreatedBy     primitive.ObjectID    `bson:"createdBy" json:"createdBy"`
+	CreatedAt     time.Time             `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time             `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ChargingSessionFilter schränkt die Abfrage von Ladevorgängen ein
+type ChargingSessionFilter struct {
+	VehicleID           *primitive.ObjectID
+	DriverID            *primitive.ObjectID
+	From                *time.Time // Beginn einschließlich
+	To                  *time.Time // Beginn ausschließlich
+	LocationType        ChargingLocationType
+	ReimbursementStatus ReimbursementStatus
+}
+
+// ChargingLocationSummary fasst die Ladevorgänge eines Ladeorts zusammen
+type ChargingLocationSummary struct {
+	LocationType ChargingLocationType `json:"locationType"`
+	Sessions     int                  `json:"sessions"`
+	EnergyKWh    float64              `json:"energyKWh"`
+	Cost         float64              `json:"cost"`
+}
+
+// ChargingMonthSummary fasst die Ladevorgänge eines Monats zusammen
+type ChargingMonthSummary struct {
+	Month     string  `json:"month"` // YYYY-MM
+	Sessions  int     `json:"sessions"`
+	EnergyKWh float64 `json:"energyKWh"`
+	Cost      float64 `json:"cost"`
+}
+
+// VehicleEnergyReport ist der Energiebericht eines Fahrzeugs für einen Zeitraum
+type VehicleEnergyReport struct {
+	VehicleID            primitive.ObjectID        `json:"vehicleId"`
+	LicensePlate         string                    `json:"licensePlate"`
+	From                 time.Time                 `json:"from"`
+	To                   time.Time                 `json:"to"`
+	Sessions             int                       `json:"sessions"`
+	EnergyKWh            float64                   `json:"energyKWh"`
+	Cost                 float64                   `json:"cost"`
+	AveragePricePerKWh   float64                   `json:"averagePricePerKWh"`
+	ChargingHours        float64                   `json:"chargingHours"`
+	Distance             int                       `json:"distance"`             // Strecke zwischen erstem und letztem Ladevorgang mit Kilometerstand
+	ConsumptionPer100    float64                   `json:"consumptionPer100"`    // kWh je 100 km
+	Reimbursed           float64                   `json:"reimbursed"`           // Freigegebene Erstattungen
+	PendingReimbursement float64                   `json:"pendingReimbursement"` // Offene Erstattungsanträge
+	ByLocation           []ChargingLocationSummary `json:"byLocation"`
+	ByMonth              []ChargingMonthSummary    `json:"byMonth"`
+}
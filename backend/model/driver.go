@@ -21,8 +21,10 @@ const (
 	DriverStatusReserved  DriverStatus = "reserved"
 
 	// Führerscheinklassen
+	LicenseClassAM  LicenseClass = "AM"
 	LicenseClassA   LicenseClass = "A"
 	LicenseClassA1  LicenseClass = "A1"
+	LicenseClassA2  LicenseClass = "A2"
 	LicenseClassB   LicenseClass = "B"
 	LicenseClassBE  LicenseClass = "BE"
 	LicenseClassC   LicenseClass = "C"
@@ -35,6 +37,14 @@ const (
 	LicenseClassD1E LicenseClass = "D1E"
 )
 
+// DriverStatusText enthält die Anzeigenamen der Fahrerstatus
+var DriverStatusText = map[DriverStatus]string{
+	DriverStatusAvailable: "Verfügbar",
+	DriverStatusOnDuty:    "Im Dienst",
+	DriverStatusOffDuty:   "Außer Dienst",
+	DriverStatusReserved:  "Reserviert",
+}
+
 // Driver repräsentiert einen Fahrer im System
 type Driver struct {
 	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -47,6 +57,12 @@ type Driver struct {
 	AssignedVehicleID primitive.ObjectID `bson:"assignedVehicleId,omitempty" json:"assignedVehicleId"`
 	LicenseClasses    []LicenseClass     `bson:"licenseClasses" json:"licenseClasses"`
 	Notes             string             `bson:"notes" json:"notes"`
-	CreatedAt         time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt         time.Time          `bson:"updatedAt" json:"updatedAt"`
+
+	// Angaben zur Versteuerung der Privatnutzung eines Dienstwagens
+	BenefitMethod         BenefitMethod `bson:"benefitMethod,omitempty" json:"benefitMethod"`       // Leer = 1%-Regelung
+	CommuteDistanceKm     int           `bson:"commuteDistanceKm" json:"commuteDistanceKm"`         // Einfache Entfernung Wohnung - erste Tätigkeitsstätte
+	CommuteDailyValuation bool          `bson:"commuteDailyValuation" json:"commuteDailyValuation"` // 0,002% je Fahrtag statt 0,03% pauschal
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
 }
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExpirySubjectType beschreibt, welches Datum eine Ablauferinnerung betrifft
+type ExpirySubjectType string
+
+const (
+	ExpirySubjectDriverLicense     ExpirySubjectType = "driver_license"     // Führerschein eines Fahrers
+	ExpirySubjectVehicleDocument   ExpirySubjectType = "vehicle_document"   // Fahrzeugdokument mit Ablaufdatum
+	ExpirySubjectVehicleInsurance  ExpirySubjectType = "vehicle_insurance"  // Versicherung eines Fahrzeugs
+	ExpirySubjectVehicleInspection ExpirySubjectType = "vehicle_inspection" // Nächste Hauptuntersuchung
+	ExpirySubjectVehicleLease      ExpirySubjectType = "vehicle_lease"      // Ende des Leasingvertrags
+	ExpirySubjectLeaseMileage      ExpirySubjectType = "lease_mileage"      // Drohende Mehrkilometer bei Leasingende
+)
+
+// ExpirySubjectText enthält die Anzeigenamen der Erinnerungsarten
+var ExpirySubjectText = map[ExpirySubjectType]string{
+	ExpirySubjectDriverLicense:     "Führerschein",
+	ExpirySubjectVehicleDocument:   "Fahrzeugdokument",
+	ExpirySubjectVehicleInsurance:  "Versicherung",
+	ExpirySubjectVehicleInspection: "Hauptuntersuchung",
+	ExpirySubjectVehicleLease:      "Leasingvertrag",
+	ExpirySubjectLeaseMileage:      "Leasing-Mehrkilometer",
+}
+
+// DefaultExpiryReminderOffsets sind die Standard-Vorlaufzeiten in Tagen vor dem Ablaufdatum
+var DefaultExpiryReminderOffsets = []int{60, 30, 7, 0}
+
+// ExpiryReminderSettings enthält die Konfiguration der Ablauferinnerungen
+type ExpiryReminderSettings struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Enabled        bool               `bson:"enabled" json:"enabled"`
+	OffsetsDays    []int              `bson:"offsetsDays" json:"offsetsDays"`       // Erinnerungsstufen, z.B. 60/30/7/0 Tage vorher
+	NotifyDrivers  bool               `bson:"notifyDrivers" json:"notifyDrivers"`   // Verantwortlichen Fahrer benachrichtigen
+	NotifyManagers bool               `bson:"notifyManagers" json:"notifyManagers"` // Manager und Administratoren benachrichtigen
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ExpiryReminder protokolliert eine versendete Ablauferinnerung, damit jede Stufe
+// pro Empfänger und Ablaufdatum nur einmal verschickt wird
+type ExpiryReminder struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SubjectType ExpirySubjectType  `bson:"subjectType" json:"subjectType"`
+	SubjectID   primitive.ObjectID `bson:"subjectId" json:"subjectId"` // Dokument bzw. Fahrzeug
+	Title       string             `bson:"title" json:"title"`
+	ExpiryDate  time.Time          `bson:"expiryDate" json:"expiryDate"`
+	OffsetDays  int                `bson:"offsetDays" json:"offsetDays"` // Erreichte Erinnerungsstufe
+	Recipient   string             `bson:"recipient" json:"recipient"`
+	SentAt      time.Time          `bson:"sentAt" json:"sentAt"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AmortizationEntry ist eine Rate im Tilgungsplan einer Fahrzeugfinanzierung
+type AmortizationEntry struct {
+	Number           int       `json:"number"`
+	Date             time.Time `json:"date"` // Fälligkeit, jeweils einen Monat nach der vorherigen Rate
+	Payment          float64   `json:"payment"`
+	Interest         float64   `json:"interest"`
+	Principal        float64   `json:"principal"`        // Tilgungsanteil
+	RemainingBalance float64   `json:"remainingBalance"` // Restschuld nach der Rate
+	FinalPayment     bool      `json:"finalPayment,omitempty"`
+}
+
+// AmortizationSchedule ist der Tilgungsplan einer Fahrzeugfinanzierung (Annuitätendarlehen).
+// Der Gesamtbetrag der Finanzierung gilt als Darlehensbetrag ohne Anzahlung.
+type AmortizationSchedule struct {
+	VehicleID        primitive.ObjectID  `json:"vehicleId"`
+	LicensePlate     string              `json:"licensePlate"`
+	Brand            string              `json:"brand"`
+	Model            string              `json:"model"`
+	Bank             string              `json:"bank"`
+	Principal        float64             `json:"principal"`
+	DownPayment      float64             `json:"downPayment"`
+	InterestRate     float64             `json:"interestRate"` // Sollzins p.a. in Prozent
+	MonthlyRate      float64             `json:"monthlyRate"`
+	RateCalculated   bool                `json:"rateCalculated"` // Rate aus Betrag, Zins und Laufzeit berechnet
+	StartDate        time.Time           `json:"startDate"`
+	EndDate          time.Time           `json:"endDate,omitempty"`
+	TermMonths       int                 `json:"termMonths"`
+	FinalPayment     float64             `json:"finalPayment"` // Schlussrate über die Monatsrate hinaus
+	TotalPayments    float64             `json:"totalPayments"`
+	TotalInterest    float64             `json:"totalInterest"`
+	PaidInstallments int                 `json:"paidInstallments"`
+	CurrentBalance   float64             `json:"currentBalance"` // Restschuld heute
+	NextPaymentDate  *time.Time          `json:"nextPaymentDate,omitempty"`
+	Entries          []AmortizationEntry `json:"entries"`
+	Issues           []FinancingIssue    `json:"issues"`
+}
+
+// FinancingIssueSeverity ist die Schwere einer Unstimmigkeit in den Finanzierungsdaten
+type FinancingIssueSeverity string
+
+const (
+	FinancingIssueError   FinancingIssueSeverity = "error"   // Tilgungsplan nicht oder nur falsch berechenbar
+	FinancingIssueWarning FinancingIssueSeverity = "warning" // Angaben passen nicht zusammen
+)
+
+// FinancingIssue ist eine Unstimmigkeit in den Finanzierungsdaten eines Fahrzeugs
+type FinancingIssue struct {
+	VehicleID    primitive.ObjectID     `json:"vehicleId"`
+	LicensePlate string                 `json:"licensePlate"`
+	Code         string                 `json:"code"`
+	Severity     FinancingIssueSeverity `json:"severity"`
+	Message      string                 `json:"message"`
+}
+
+// LiabilityForecastMonth sind die Zahlungsverpflichtungen der Flotte in einem Monat
+type LiabilityForecastMonth struct {
+	Month              string  `json:"month"` // YYYY-MM
+	FinancePayments    float64 `json:"financePayments"`
+	Interest           float64 `json:"interest"`
+	Principal          float64 `json:"principal"`
+	LeasePayments      float64 `json:"leasePayments"`
+	TotalPayments      float64 `json:"totalPayments"`
+	OutstandingBalance float64 `json:"outstandingBalance"` // Restschuld aller Finanzierungen am Monatsende
+	ActiveContracts    int     `json:"activeContracts"`
+}
+
+// LiabilityForecast ist die monatliche Vorschau auf Raten und Restschulden der Flotte
+type LiabilityForecast struct {
+	Months             []LiabilityForecastMonth `json:"months"`
+	OutstandingBalance float64                  `json:"outstandingBalance"` // Restschuld aller Finanzierungen heute
+	RemainingInterest  float64                  `json:"remainingInterest"`  // Noch zu zahlende Zinsen bis Vertragsende
+	TotalPayments      float64                  `json:"totalPayments"`      // Summe der Raten im Vorschauzeitraum
+	Issues             int                      `json:"issues"`             // Fahrzeuge mit Unstimmigkeiten
+}
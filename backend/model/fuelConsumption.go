@@ -0,0 +1,108 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FuelAnomalyType ist die Art einer Auffälligkeit beim Tanken
+type FuelAnomalyType string
+
+const (
+	FuelAnomalyHighConsumption  FuelAnomalyType = "high_consumption"   // Verbrauch deutlich über dem üblichen Wert des Fahrzeugs
+	FuelAnomalyTankOverfill     FuelAnomalyType = "tank_overfill"      // Tankmenge größer als der Tank
+	FuelAnomalyFuelTypeMismatch FuelAnomalyType = "fuel_type_mismatch" // Kraftstoffart passt nicht zum Fahrzeug
+	FuelAnomalyOdometerRollback FuelAnomalyType = "odometer_rollback"  // Kilometerstand niedriger als beim vorherigen Tanken
+)
+
+// FuelAnomalyTypeText gibt die deutsche Bezeichnung einer Auffälligkeit zurück
+func FuelAnomalyTypeText(anomalyType FuelAnomalyType) string {
+	switch anomalyType {
+	case FuelAnomalyHighConsumption:
+		return "Erhöhter Verbrauch"
+	case FuelAnomalyTankOverfill:
+		return "Tankmenge unplausibel"
+	case FuelAnomalyFuelTypeMismatch:
+		return "Falsche Kraftstoffart"
+	case FuelAnomalyOdometerRollback:
+		return "Kilometerstand rückläufig"
+	default:
+		return string(anomalyType)
+	}
+}
+
+// ConsumptionUnit gibt die Mengeneinheit einer Kraftstoffart zurück
+func ConsumptionUnit(fuelType FuelType) string {
+	switch fuelType {
+	case FuelTypeElectric:
+		return "kWh"
+	case FuelTypeHydrogen:
+		return "kg"
+	default:
+		return "l"
+	}
+}
+
+// FuelConsumptionInterval ist der Verbrauch zwischen zwei Tankvorgängen mit Kilometerstand
+type FuelConsumptionInterval struct {
+	FuelCostID  primitive.ObjectID `json:"fuelCostId"` // Tankvorgang am Ende des Intervalls
+	FromDate    time.Time          `json:"fromDate"`
+	ToDate      time.Time          `json:"toDate"`
+	FromMileage int                `json:"fromMileage"`
+	ToMileage   int                `json:"toMileage"`
+	Distance    int                `json:"distance"`
+	Amount      float64            `json:"amount"`      // Getankte Menge im Intervall
+	Consumption float64            `json:"consumption"` // Menge je 100 km
+}
+
+// FuelConsumptionTrendPoint ist der Durchschnittsverbrauch eines Monats
+type FuelConsumptionTrendPoint struct {
+	Month       string  `json:"month"` // YYYY-MM
+	Distance    int     `json:"distance"`
+	Amount      float64 `json:"amount"`
+	Consumption float64 `json:"consumption"`
+}
+
+// FuelAnomaly ist eine Auffälligkeit bei einem Tankvorgang
+type FuelAnomaly struct {
+	Type         FuelAnomalyType    `json:"type"`
+	TypeText     string             `json:"typeText"`
+	VehicleID    primitive.ObjectID `json:"vehicleId"`
+	LicensePlate string             `json:"licensePlate"`
+	FuelCostID   primitive.ObjectID `json:"fuelCostId"`
+	Date         time.Time          `json:"date"`
+	Message      string             `json:"message"`
+	Value        float64            `json:"value"`    // Beobachteter Wert (Verbrauch, Menge, Kilometerstand)
+	Expected     float64            `json:"expected"` // Erwarteter Wert bzw. Grenzwert
+}
+
+// VehicleFuelConsumption fasst den Verbrauch eines Fahrzeugs zusammen
+type VehicleFuelConsumption struct {
+	VehicleID           primitive.ObjectID          `json:"vehicleId"`
+	LicensePlate        string                      `json:"licensePlate"`
+	Brand               string                      `json:"brand"`
+	Model               string                      `json:"model"`
+	FuelType            FuelType                    `json:"fuelType"`
+	Unit                string                      `json:"unit"` // l, kWh oder kg je 100 km
+	Intervals           []FuelConsumptionInterval   `json:"intervals"`
+	Trend               []FuelConsumptionTrendPoint `json:"trend"`
+	TrendSlope          float64                     `json:"trendSlope"` // Veränderung des Monatsverbrauchs je Monat
+	TotalDistance       int                         `json:"totalDistance"`
+	TotalAmount         float64                     `json:"totalAmount"`
+	AverageConsumption  float64                     `json:"averageConsumption"`
+	BaselineConsumption float64                     `json:"baselineConsumption"` // Median der Intervalle
+	Anomalies           []FuelAnomaly               `json:"anomalies"`
+}
+
+// FleetConsumptionGroup vergleicht den Verbrauch einer Fahrzeuggruppe
+type FleetConsumptionGroup struct {
+	Key                string  `json:"key"` // Marke, Modell oder Kraftstoffart
+	Unit               string  `json:"unit"`
+	VehicleCount       int     `json:"vehicleCount"`
+	TotalDistance      int     `json:"totalDistance"`
+	TotalAmount        float64 `json:"totalAmount"`
+	AverageConsumption float64 `json:"averageConsumption"`
+	MinConsumption     float64 `json:"minConsumption"` // Sparsamstes Fahrzeug der Gruppe
+	MaxConsumption     float64 `json:"maxConsumption"` // Verbrauchsstärkstes Fahrzeug der Gruppe
+}
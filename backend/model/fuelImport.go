@@ -0,0 +1,107 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FuelImportColumns ordnet den Feldern eines Tankvorgangs die Spaltenüberschriften der Tankkarten-CSV zu.
+// Leere Felder werden nicht importiert.
+type FuelImportColumns struct {
+	CardNumber    string `bson:"cardNumber" json:"cardNumber"`
+	LicensePlate  string `bson:"licensePlate" json:"licensePlate"`
+	Date          string `bson:"date" json:"date"`
+	Time          string `bson:"time" json:"time"` // Optional, falls Uhrzeit in eigener Spalte
+	Product       string `bson:"product" json:"product"`
+	Amount        string `bson:"amount" json:"amount"`
+	PricePerUnit  string `bson:"pricePerUnit" json:"pricePerUnit"`
+	TotalCost     string `bson:"totalCost" json:"totalCost"`
+	Mileage       string `bson:"mileage" json:"mileage"`
+	Location      string `bson:"location" json:"location"`
+	ReceiptNumber string `bson:"receiptNumber" json:"receiptNumber"`
+}
+
+// FuelCardProvider beschreibt das CSV-Format eines Tankkartenanbieters
+type FuelCardProvider struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name         string              `bson:"name" json:"name"`
+	Delimiter    string              `bson:"delimiter" json:"delimiter"`       // z.B. ";" oder ","
+	DateFormat   string              `bson:"dateFormat" json:"dateFormat"`     // Go-Layout, z.B. "02.01.2006"
+	TimeFormat   string              `bson:"timeFormat" json:"timeFormat"`     // Go-Layout der Zeitspalte, z.B. "15:04"
+	DecimalComma bool                `bson:"decimalComma" json:"decimalComma"` // 1.234,56 statt 1,234.56
+	SkipRows     int                 `bson:"skipRows" json:"skipRows"`         // Zeilen vor der Kopfzeile
+	Columns      FuelImportColumns   `bson:"columns" json:"columns"`
+	Products     map[string]FuelType `bson:"products,omitempty" json:"products"` // Produktbezeichnung des Anbieters -> Kraftstoffart
+	CreatedAt    time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// FuelImportBatchStatus ist der Status eines Imports
+type FuelImportBatchStatus string
+
+const (
+	FuelImportBatchPreview    FuelImportBatchStatus = "preview"    // Probelauf, noch nichts übernommen
+	FuelImportBatchCommitting FuelImportBatchStatus = "committing" // Übernahme läuft
+	FuelImportBatchCommitted  FuelImportBatchStatus = "committed"  // Zugeordnete Zeilen als Tankkosten übernommen
+	FuelImportBatchDiscarded  FuelImportBatchStatus = "discarded"  // Verworfen
+)
+
+// FuelImportBatch ist ein hochgeladener Tankkartenauszug
+type FuelImportBatch struct {
+	ID          primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	ProviderID  primitive.ObjectID    `bson:"providerId" json:"providerId"`
+	FileName    string                `bson:"fileName" json:"fileName"`
+	Status      FuelImportBatchStatus `bson:"status" json:"status"`
+	Counts      FuelImportCounts      `bson:"counts" json:"counts"`
+	CreatedBy   primitive.ObjectID    `bson:"createdBy" json:"createdBy"`
+	CommittedAt *time.Time            `bson:"committedAt,omitempty" json:"committedAt,omitempty"`
+	CreatedAt   time.Time             `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time             `bson:"updatedAt" json:"updatedAt"`
+}
+
+// FuelImportCounts zählt die Zeilen eines Imports nach Status
+type FuelImportCounts struct {
+	Total     int `bson:"total" json:"total"`
+	Matched   int `bson:"matched" json:"matched"`
+	Duplicate int `bson:"duplicate" json:"duplicate"`
+	Unmatched int `bson:"unmatched" json:"unmatched"`
+	Invalid   int `bson:"invalid" json:"invalid"`
+	Imported  int `bson:"imported" json:"imported"`
+}
+
+// FuelImportRowStatus ist der Status einer importierten Zeile
+type FuelImportRowStatus string
+
+const (
+	FuelImportRowMatched   FuelImportRowStatus = "matched"   // Fahrzeug gefunden, wird übernommen
+	FuelImportRowDuplicate FuelImportRowStatus = "duplicate" // Bereits als Tankkosten erfasst
+	FuelImportRowUnmatched FuelImportRowStatus = "unmatched" // Kein Fahrzeug gefunden, wartet auf Prüfung
+	FuelImportRowInvalid   FuelImportRowStatus = "invalid"   // Nicht lesbar
+	FuelImportRowImported  FuelImportRowStatus = "imported"  // Als Tankkosten übernommen
+	FuelImportRowDiscarded FuelImportRowStatus = "discarded" // Bei der Prüfung verworfen
+)
+
+// FuelImportRow ist eine Zeile eines Tankkartenauszugs
+type FuelImportRow struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	BatchID       primitive.ObjectID  `bson:"batchId" json:"batchId"`
+	RowNumber     int                 `bson:"rowNumber" json:"rowNumber"` // Zeilennummer in der Datei
+	Status        FuelImportRowStatus `bson:"status" json:"status"`
+	Message       string              `bson:"message,omitempty" json:"message,omitempty"`
+	CardNumber    string              `bson:"cardNumber" json:"cardNumber"`
+	LicensePlate  string              `bson:"licensePlate" json:"licensePlate"`
+	Date          time.Time           `bson:"date" json:"date"`
+	Product       string              `bson:"product" json:"product"`
+	FuelType      FuelType            `bson:"fuelType" json:"fuelType"`
+	Amount        float64             `bson:"amount" json:"amount"`
+	PricePerUnit  float64             `bson:"pricePerUnit" json:"pricePerUnit"`
+	TotalCost     float64             `bson:"totalCost" json:"totalCost"`
+	Mileage       int                 `bson:"mileage" json:"mileage"`
+	Location      string              `bson:"location" json:"location"`
+	ReceiptNumber string              `bson:"receiptNumber" json:"receiptNumber"`
+	VehicleID     *primitive.ObjectID `bson:"vehicleId,omitempty" json:"vehicleId,omitempty"`
+	FuelCostID    *primitive.ObjectID `bson:"fuelCostId,omitempty" json:"fuelCostId,omitempty"`
+	CreatedAt     time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
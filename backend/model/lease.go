@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LeaseReturnLeadDays ist die Vorlaufzeit vor Leasingende, ab der die Rückgabe-Checkliste angelegt wird
+const LeaseReturnLeadDays = 90
+
+// LeaseForecast ist die Hochrechnung der Fahrleistung eines Leasingfahrzeugs bis Vertragsende
+type LeaseForecast struct {
+	VehicleID          primitive.ObjectID  `json:"vehicleId"`
+	LicensePlate       string              `json:"licensePlate"`
+	Brand              string              `json:"brand"`
+	Model              string              `json:"model"`
+	DriverID           *primitive.ObjectID `json:"driverId,omitempty"` // Fest zugewiesener Fahrer
+	DriverName         string              `json:"driverName,omitempty"`
+	LeaseStartDate     time.Time           `json:"leaseStartDate"`
+	LeaseEndDate       time.Time           `json:"leaseEndDate"`
+	DaysRemaining      int                 `json:"daysRemaining"`
+	CurrentMileage     int                 `json:"currentMileage"`
+	StartMileage       int                 `json:"startMileage"` // Stand bei Leasingbeginn, ohne Erfassung 0
+	DailyKm            float64             `json:"dailyKm"`      // Tagesfahrleistung aus dem Trend der letzten Monate
+	AllowedMileage     int                 `json:"allowedMileage"`
+	ProjectedMileage   int                 `json:"projectedMileage"`  // Erwartete Fahrleistung bei Vertragsende
+	ProjectedExcessKm  int                 `json:"projectedExcessKm"` // Negativ = Minderkilometer
+	ExpectedExcessCost float64             `json:"expectedExcessCost"`
+	ExcessMileageRate  float64             `json:"excessMileageRate"` // Preis je Mehrkilometer laut Vertrag
+	UsagePercent       float64             `json:"usagePercent"`      // Erwartete Fahrleistung in Prozent der vereinbarten
+	OnTrackToExceed    bool                `json:"onTrackToExceed"`
+	Warnings           []string            `json:"warnings"`
+}
+
+// LeaseSwapSuggestion schlägt vor, die Fahrer zweier Leasingfahrzeuge zu tauschen,
+// damit das Fahrzeug mit drohenden Mehrkilometern künftig weniger gefahren wird
+type LeaseSwapSuggestion struct {
+	HighMileage         LeaseForecast `json:"highMileage"` // Fahrzeug mit drohenden Mehrkilometern
+	LowMileage          LeaseForecast `json:"lowMileage"`  // Fahrzeug mit Reserve
+	CurrentExcessCost   float64       `json:"currentExcessCost"`
+	ExcessCostAfterSwap float64       `json:"excessCostAfterSwap"`
+	Savings             float64       `json:"savings"`
+}
+
+// LeaseReturnStatus ist der Bearbeitungsstand einer Leasingrückgabe
+type LeaseReturnStatus string
+
+const (
+	LeaseReturnStatusOpen      LeaseReturnStatus = "open"      // Checkliste in Bearbeitung
+	LeaseReturnStatusCompleted LeaseReturnStatus = "completed" // Fahrzeug zurückgegeben
+)
+
+// LeaseReturnItem ist ein Punkt der Rückgabe-Checkliste
+type LeaseReturnItem struct {
+	Key    string              `bson:"key" json:"key"`
+	Title  string              `bson:"title" json:"title"`
+	Done   bool                `bson:"done" json:"done"`
+	DoneAt *time.Time          `bson:"doneAt,omitempty" json:"doneAt,omitempty"`
+	DoneBy *primitive.ObjectID `bson:"doneBy,omitempty" json:"doneBy,omitempty"`
+	Notes  string              `bson:"notes,omitempty" json:"notes"`
+}
+
+// DefaultLeaseReturnItems sind die Punkte einer neuen Rückgabe-Checkliste
+var DefaultLeaseReturnItems = []LeaseReturnItem{
+	{Key: "appointment", Title: "Rückgabetermin mit der Leasinggesellschaft vereinbaren"},
+	{Key: "follow_up", Title: "Anschlussfahrzeug für den Fahrer klären"},
+	{Key: "pre_inspection", Title: "Vorabbegutachtung auf Schäden durchführen"},
+	{Key: "repairs", Title: "Schäden und Smart-Repair-Arbeiten beauftragen"},
+	{Key: "maintenance", Title: "Fällige Wartung und Hauptuntersuchung prüfen"},
+	{Key: "tires", Title: "Reifen auf Mindestprofil und vertragsgemäße Bereifung prüfen"},
+	{Key: "cleaning", Title: "Innen- und Außenreinigung"},
+	{Key: "personal_items", Title: "Persönliche Gegenstände, Tank- und Ladekarten entfernen"},
+	{Key: "documents", Title: "Fahrzeugschein, Serviceheft und alle Schlüssel bereitlegen"},
+	{Key: "mileage", Title: "Kilometerstand bei Rückgabe erfassen"},
+	{Key: "protocol", Title: "Rückgabeprotokoll unterschreiben und ablegen"},
+	{Key: "settlement", Title: "Mehr- und Minderkilometer sowie Schäden abrechnen"},
+}
+
+// LeaseReturn ist die Checkliste für die Rückgabe eines Leasingfahrzeugs
+type LeaseReturn struct {
+	ID               primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	VehicleID        primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
+	LeaseEndDate     time.Time           `bson:"leaseEndDate" json:"leaseEndDate"`
+	LeaseCompany     string              `bson:"leaseCompany" json:"leaseCompany"`
+	ContractNumber   string              `bson:"contractNumber" json:"contractNumber"`
+	AppointmentDate  *time.Time          `bson:"appointmentDate,omitempty" json:"appointmentDate,omitempty"` // Vereinbarter Rückgabetermin
+	Items            []LeaseReturnItem   `bson:"items" json:"items"`
+	ForecastExcessKm int                 `bson:"forecastExcessKm" json:"forecastExcessKm"` // Hochrechnung beim Anlegen
+	ReturnMileage    int                 `bson:"returnMileage,omitempty" json:"returnMileage,omitempty"`
+	ExcessKm         int                 `bson:"excessKm,omitempty" json:"excessKm,omitempty"` // Laut Rückgabe, negativ = Minderkilometer
+	SettlementAmount float64             `bson:"settlementAmount,omitempty" json:"settlementAmount,omitempty"`
+	Notes            string              `bson:"notes,omitempty" json:"notes"`
+	Status           LeaseReturnStatus   `bson:"status" json:"status"`
+	CompletedAt      *time.Time          `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	CompletedBy      *primitive.ObjectID `bson:"completedBy,omitempty" json:"completedBy,omitempty"`
+	CreatedBy        *primitive.ObjectID `bson:"createdBy,omitempty" json:"createdBy,omitempty"` // Leer, wenn automatisch angelegt
+	CreatedAt        time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// OpenItems liefert die Anzahl der noch offenen Checklistenpunkte
+func (r *LeaseReturn) OpenItems() int {
+	open := 0
+	for _, item := range r.Items {
+		if !item.Done {
+			open++
+		}
+	}
+	return open
+}
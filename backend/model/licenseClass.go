@@ -0,0 +1,87 @@
+package model
+
+import "strings"
+
+// licenseCoverage enthält für jede Führerscheinklasse alle Klassen, zu denen sie berechtigt
+// (Klasse C1 und höher setzen die Klasse B voraus)
+var licenseCoverage = map[LicenseClass][]LicenseClass{
+	LicenseClassAM:  {LicenseClassAM},
+	LicenseClassA1:  {LicenseClassA1, LicenseClassAM},
+	LicenseClassA2:  {LicenseClassA2, LicenseClassA1, LicenseClassAM},
+	LicenseClassA:   {LicenseClassA, LicenseClassA2, LicenseClassA1, LicenseClassAM},
+	LicenseClassB:   {LicenseClassB, LicenseClassAM},
+	LicenseClassBE:  {LicenseClassBE, LicenseClassB, LicenseClassAM},
+	LicenseClassC1:  {LicenseClassC1, LicenseClassB, LicenseClassAM},
+	LicenseClassC1E: {LicenseClassC1E, LicenseClassC1, LicenseClassBE, LicenseClassB, LicenseClassAM},
+	LicenseClassC:   {LicenseClassC, LicenseClassC1, LicenseClassB, LicenseClassAM},
+	LicenseClassCE:  {LicenseClassCE, LicenseClassC1E, LicenseClassC, LicenseClassC1, LicenseClassBE, LicenseClassB, LicenseClassAM},
+	LicenseClassD1:  {LicenseClassD1, LicenseClassB, LicenseClassAM},
+	LicenseClassD1E: {LicenseClassD1E, LicenseClassD1, LicenseClassBE, LicenseClassB, LicenseClassAM},
+	LicenseClassD:   {LicenseClassD, LicenseClassD1, LicenseClassB, LicenseClassAM},
+	LicenseClassDE:  {LicenseClassDE, LicenseClassD1E, LicenseClassD, LicenseClassD1, LicenseClassBE, LicenseClassB, LicenseClassAM},
+}
+
+// IsValidLicenseClass prüft, ob eine Führerscheinklasse bekannt ist
+func IsValidLicenseClass(class LicenseClass) bool {
+	_, ok := licenseCoverage[class]
+	return ok
+}
+
+// LicenseClassCovers prüft, ob die vorhandene Klasse zum Führen der geforderten Klasse berechtigt
+func LicenseClassCovers(held, required LicenseClass) bool {
+	for _, class := range licenseCoverage[held] {
+		if class == required {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLicenseFor prüft, ob der Fahrer eine Klasse besitzt, die zur geforderten Klasse berechtigt
+func (d *Driver) HasLicenseFor(required LicenseClass) bool {
+	for _, held := range d.LicenseClasses {
+		if LicenseClassCovers(held, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredLicense liefert die zum Führen des Fahrzeugs erforderliche Führerscheinklasse.
+// Eine explizit gesetzte Klasse hat Vorrang, sonst wird sie wie im Frontend aus
+// Fahrzeugart, Gesamtmasse und Leistung abgeleitet.
+func (v *Vehicle) RequiredLicense() LicenseClass {
+	if v.RequiredLicenseClass != "" {
+		return v.RequiredLicenseClass
+	}
+
+	vehicleType := strings.ToLower(v.VehicleType)
+	maxWeight := v.TechnicalMaxWeight
+	if maxWeight == 0 {
+		maxWeight = v.GrossWeight
+	}
+
+	switch {
+	case strings.Contains(vehicleType, "moped"):
+		return LicenseClassAM
+	case strings.Contains(vehicleType, "motorrad") || strings.Contains(vehicleType, "roller"):
+		if v.PowerRating > 0 && v.PowerRating <= 11 {
+			return LicenseClassA1
+		}
+		if v.PowerRating > 0 && v.PowerRating <= 35 {
+			return LicenseClassA2
+		}
+		return LicenseClassA
+	case strings.Contains(vehicleType, "bus"):
+		if maxWeight > 0 && maxWeight <= 7500 {
+			return LicenseClassD1
+		}
+		return LicenseClassD
+	case maxWeight > 7500 || strings.Contains(vehicleType, "lkw"):
+		return LicenseClassC
+	case maxWeight > 3500:
+		return LicenseClassC1
+	default:
+		return LicenseClassB
+	}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TripType beschreibt die steuerliche Einordnung einer Fahrt
+type TripType string
+
+const (
+	TripTypeBusiness TripType = "business" // Dienstliche Fahrt
+	TripTypeCommute  TripType = "commute"  // Fahrt zwischen Wohnung und erster Tätigkeitsstätte
+	TripTypePrivate  TripType = "private"  // Privatfahrt
+)
+
+// TripTypeText enthält die Anzeigenamen der Fahrtarten
+var TripTypeText = map[TripType]string{
+	TripTypeBusiness: "Dienstfahrt",
+	TripTypeCommute:  "Wohnung - Arbeitsstätte",
+	TripTypePrivate:  "Privatfahrt",
+}
+
+// LogbookEditGracePeriod ist die Zeit nach dem Erfassen, in der ein Eintrag noch direkt geändert werden darf.
+// Danach sind nur noch Korrekturen als neue Version mit Begründung möglich.
+const LogbookEditGracePeriod = 24 * time.Hour
+
+// LogbookEntry ist eine Version eines Fahrtenbucheintrags. Einträge werden nie überschrieben:
+// eine Korrektur legt eine neue Version mit derselben EntryID an und markiert die alte als nicht mehr aktuell.
+type LogbookEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EntryID   primitive.ObjectID `bson:"entryId" json:"entryId"` // Gemeinsame ID aller Versionen eines Eintrags
+	Version   int                `bson:"version" json:"version"`
+	Current   bool               `bson:"current" json:"current"`   // Nur die aktuelle Version zählt
+	Sequence  int                `bson:"sequence" json:"sequence"` // Laufende Nummer im Fahrtenbuch des Fahrzeugs
+	VehicleID primitive.ObjectID `bson:"vehicleId" json:"vehicleId"`
+	DriverID  primitive.ObjectID `bson:"driverId" json:"driverId"`
+
+	Date            time.Time `bson:"date" json:"date"`
+	StartMileage    int       `bson:"startMileage" json:"startMileage"`
+	EndMileage      int       `bson:"endMileage" json:"endMileage"`
+	Route           string    `bson:"route" json:"route"`                     // Reiseroute, z.B. "Berlin - Potsdam - Berlin"
+	BusinessPartner string    `bson:"businessPartner" json:"businessPartner"` // Aufgesuchte Kunden bzw. Geschäftspartner
+	Purpose         string    `bson:"purpose" json:"purpose"`
+	TripType        TripType  `bson:"tripType" json:"tripType"`
+
+	CorrectionReason string              `bson:"correctionReason,omitempty" json:"correctionReason,omitempty"`
+	RecordedBy       primitive.ObjectID  `bson:"recordedBy" json:"recordedBy"`
+	LockedAt         time.Time           `bson:"lockedAt" json:"lockedAt"` // Ab hier nur noch Korrekturversionen
+	SupersededAt     *time.Time          `bson:"supersededAt,omitempty" json:"supersededAt,omitempty"`
+	SupersededBy     *primitive.ObjectID `bson:"supersededBy,omitempty" json:"supersededBy,omitempty"`
+	CreatedAt        time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Distance liefert die gefahrenen Kilometer
+func (e *LogbookEntry) Distance() int {
+	return e.EndMileage - e.StartMileage
+}
+
+// IsLocked prüft, ob der Eintrag nur noch über eine Korrekturversion geändert werden kann
+func (e *LogbookEntry) IsLocked(now time.Time) bool {
+	return !now.Before(e.LockedAt)
+}
+
+// LogbookFilter schränkt die Abfrage aktueller Fahrtenbucheinträge ein
+type LogbookFilter struct {
+	VehicleID *primitive.ObjectID
+	DriverID  *primitive.ObjectID
+	From      *time.Time // Fahrtdatum einschließlich
+	To        *time.Time // Fahrtdatum ausschließlich
+}
@@ -19,6 +19,15 @@ const (
 	MaintenanceTypeOther      MaintenanceType = "other"
 )
 
+// MaintenanceTypeText enthält die Anzeigenamen der Wartungstypen
+var MaintenanceTypeText = map[MaintenanceType]string{
+	MaintenanceTypeInspection: "Inspektion",
+	MaintenanceTypeOilChange:  "Ölwechsel",
+	MaintenanceTypeTireChange: "Reifenwechsel",
+	MaintenanceTypeRepair:     "Reparatur",
+	MaintenanceTypeOther:      "Sonstiges",
+}
+
 // Maintenance repräsentiert einen Wartungseintrag im System
 type Maintenance struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaintenancePlan ist ein Wartungsplan mit Intervall nach Kilometern, nach Zeit oder
+// nach dem, was zuerst erreicht wird. Ein Plan gilt entweder für ein einzelnes Fahrzeug
+// oder für alle Fahrzeuge einer Marke bzw. eines Modells.
+type MaintenancePlan struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name           string              `bson:"name" json:"name"`
+	Type           MaintenanceType     `bson:"type" json:"type"`                               // Wartungstyp, dessen Erfassung den Plan zurücksetzt
+	VehicleID      *primitive.ObjectID `bson:"vehicleId,omitempty" json:"vehicleId,omitempty"` // Gesetzt bei Plänen für ein einzelnes Fahrzeug
+	Brand          string              `bson:"brand,omitempty" json:"brand,omitempty"`         // Marke bei Plänen je Modell
+	Model          string              `bson:"model,omitempty" json:"model,omitempty"`         // Optional: leer gilt für alle Modelle der Marke
+	IntervalKm     int                 `bson:"intervalKm" json:"intervalKm"`                   // 0 = kein Kilometerintervall
+	IntervalMonths int                 `bson:"intervalMonths" json:"intervalMonths"`           // 0 = kein Zeitintervall
+	LeadKm         int                 `bson:"leadKm" json:"leadKm"`                           // Ab dieser Restdistanz gilt die Wartung als fällig
+	LeadDays       int                 `bson:"leadDays" json:"leadDays"`                       // Ab dieser Restzeit gilt die Wartung als fällig
+	Active         bool                `bson:"active" json:"active"`
+	Notes          string              `bson:"notes" json:"notes"`
+	CreatedBy      primitive.ObjectID  `bson:"createdBy" json:"createdBy"`
+	CreatedAt      time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AppliesTo prüft, ob der Plan für das Fahrzeug gilt
+func (p *MaintenancePlan) AppliesTo(vehicle *Vehicle) bool {
+	if p.VehicleID != nil {
+		return *p.VehicleID == vehicle.ID
+	}
+	if p.Brand == "" || !strings.EqualFold(p.Brand, strings.TrimSpace(vehicle.Brand)) {
+		return false
+	}
+	return p.Model == "" || strings.EqualFold(p.Model, strings.TrimSpace(vehicle.Model))
+}
+
+// MaintenanceDueStatus ist der Status eines Wartungszyklus
+type MaintenanceDueStatus string
+
+const (
+	MaintenanceDueScheduled MaintenanceDueStatus = "scheduled" // Noch nicht im Vorlauf
+	MaintenanceDueDue       MaintenanceDueStatus = "due"       // Innerhalb des Vorlaufs
+	MaintenanceDueOverdue   MaintenanceDueStatus = "overdue"   // Kilometer- oder Zeitgrenze überschritten
+	MaintenanceDueCompleted MaintenanceDueStatus = "completed" // Durch einen Wartungseintrag erledigt
+)
+
+// MaintenanceDueStatusText enthält die Anzeigenamen der Status
+var MaintenanceDueStatusText = map[MaintenanceDueStatus]string{
+	MaintenanceDueScheduled: "Geplant",
+	MaintenanceDueDue:       "Fällig",
+	MaintenanceDueOverdue:   "Überfällig",
+	MaintenanceDueCompleted: "Erledigt",
+}
+
+// MaintenanceDue ist ein Wartungszyklus eines Plans für ein Fahrzeug. Er beginnt mit der
+// letzten passenden Wartung (bzw. der ersten Auswertung des Plans) und endet mit dem
+// nächsten Wartungseintrag des Plantyps, der sofort den folgenden Zyklus startet.
+type MaintenanceDue struct {
+	ID                     primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	PlanID                 primitive.ObjectID   `bson:"planId" json:"planId"`
+	VehicleID              primitive.ObjectID   `bson:"vehicleId" json:"vehicleId"`
+	Type                   MaintenanceType      `bson:"type" json:"type"`
+	BaselineDate           time.Time            `bson:"baselineDate" json:"baselineDate"`
+	BaselineMileage        int                  `bson:"baselineMileage" json:"baselineMileage"`
+	BaselineMaintenanceID  *primitive.ObjectID  `bson:"baselineMaintenanceId,omitempty" json:"baselineMaintenanceId,omitempty"`
+	DueMileage             int                  `bson:"dueMileage" json:"dueMileage"`                                 // 0 = kein Kilometerintervall
+	DueDateByTime          *time.Time           `bson:"dueDateByTime,omitempty" json:"dueDateByTime,omitempty"`       // Fälligkeit nach Zeitintervall
+	DueDateByMileage       *time.Time           `bson:"dueDateByMileage,omitempty" json:"dueDateByMileage,omitempty"` // Hochgerechnet aus der durchschnittlichen Tagesfahrleistung
+	DueDate                *time.Time           `bson:"dueDate,omitempty" json:"dueDate,omitempty"`                   // Was zuerst erreicht wird
+	CurrentMileage         int                  `bson:"currentMileage" json:"currentMileage"`
+	AverageDailyKm         float64              `bson:"averageDailyKm" json:"averageDailyKm"`
+	Status                 MaintenanceDueStatus `bson:"status" json:"status"`
+	CompletedMaintenanceID *primitive.ObjectID  `bson:"completedMaintenanceId,omitempty" json:"completedMaintenanceId,omitempty"`
+	CompletedAt            *time.Time           `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	EvaluatedAt            time.Time            `bson:"evaluatedAt" json:"evaluatedAt"`
+	CreatedAt              time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt              time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// IsOpen prüft, ob der Zyklus noch nicht erledigt ist
+func (d *MaintenanceDue) IsOpen() bool {
+	return d.Status != MaintenanceDueCompleted
+}
+
+// MaintenanceDueFilter schränkt die Abfrage von Wartungszyklen ein
+type MaintenanceDueFilter struct {
+	PlanID    *primitive.ObjectID
+	VehicleID *primitive.ObjectID
+	Type      MaintenanceType
+	Statuses  []MaintenanceDueStatus // Leer = alle Status
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HandoverType repräsentiert die Art eines Übergabeprotokolls
+type HandoverType string
+
+const (
+	HandoverTypeCheckOut HandoverType = "check_out" // Fahrzeugausgabe
+	HandoverTypeCheckIn  HandoverType = "check_in"  // Fahrzeugrückgabe
+)
+
+// DamageArea repräsentiert einen Prüfpunkt der Schadens-Checkliste
+type DamageArea string
+
+const (
+	DamageAreaFront      DamageArea = "front"      // Front / Stoßstange vorne
+	DamageAreaRear       DamageArea = "rear"       // Heck / Stoßstange hinten
+	DamageAreaLeft       DamageArea = "left"       // Linke Fahrzeugseite
+	DamageAreaRight      DamageArea = "right"      // Rechte Fahrzeugseite
+	DamageAreaRoof       DamageArea = "roof"       // Dach
+	DamageAreaWindshield DamageArea = "windshield" // Scheiben
+	DamageAreaWheels     DamageArea = "wheels"     // Reifen und Felgen
+	DamageAreaInterior   DamageArea = "interior"   // Innenraum
+	DamageAreaOther      DamageArea = "other"      // Sonstiges
+)
+
+// DamageAreas enthält alle Prüfpunkte der Schadens-Checkliste
+var DamageAreas = []DamageArea{
+	DamageAreaFront, DamageAreaRear, DamageAreaLeft, DamageAreaRight, DamageAreaRoof,
+	DamageAreaWindshield, DamageAreaWheels, DamageAreaInterior, DamageAreaOther,
+}
+
+// DamageAreaText gibt den deutschen Text für einen Prüfpunkt zurück
+func DamageAreaText(area DamageArea) string {
+	areas := map[DamageArea]string{
+		DamageAreaFront:      "Front",
+		DamageAreaRear:       "Heck",
+		DamageAreaLeft:       "Linke Seite",
+		DamageAreaRight:      "Rechte Seite",
+		DamageAreaRoof:       "Dach",
+		DamageAreaWindshield: "Scheiben",
+		DamageAreaWheels:     "Reifen/Felgen",
+		DamageAreaInterior:   "Innenraum",
+		DamageAreaOther:      "Sonstiges",
+	}
+
+	if text, ok := areas[area]; ok {
+		return text
+	}
+	return string(area)
+}
+
+// HandoverDamage repräsentiert einen Eintrag der Schadens-Checkliste
+type HandoverDamage struct {
+	Area        DamageArea `bson:"area" json:"area"`
+	Description string     `bson:"description" json:"description"`
+	IsNew       bool       `bson:"isNew" json:"isNew"` // Bei der Ausgabe noch nicht vorhanden
+}
+
+// ReservationHandover repräsentiert ein Übergabeprotokoll bei Ausgabe oder Rückgabe eines reservierten Fahrzeugs
+type ReservationHandover struct {
+	ID               primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	ReservationID    primitive.ObjectID   `bson:"reservationId" json:"reservationId"`
+	VehicleID        primitive.ObjectID   `bson:"vehicleId" json:"vehicleId"`
+	DriverID         primitive.ObjectID   `bson:"driverId" json:"driverId"`
+	Type             HandoverType         `bson:"type" json:"type"`
+	Mileage          int                  `bson:"mileage" json:"mileage"`                                       // Kilometerstand
+	EnergyLevel      int                  `bson:"energyLevel" json:"energyLevel"`                               // Tank- bzw. Ladestand in Prozent
+	Cleanliness      int                  `bson:"cleanliness" json:"cleanliness"`                               // Sauberkeit von 1 (schlecht) bis 5 (sehr gut)
+	Damages          []HandoverDamage     `bson:"damages" json:"damages"`                                       // Schadens-Checkliste
+	PhotoDocumentIDs []primitive.ObjectID `bson:"photoDocumentIds,omitempty" json:"photoDocumentIds,omitempty"` // Fotos als Fahrzeugdokumente
+	Notes            string               `bson:"notes,omitempty" json:"notes"`
+	UsageID          *primitive.ObjectID  `bson:"usageId,omitempty" json:"usageId,omitempty"`   // Erzeugte Fahrzeugnutzung (nur Rückgabe)
+	ReportID         *primitive.ObjectID  `bson:"reportId,omitempty" json:"reportId,omitempty"` // Erzeugte Schadensmeldung (nur Rückgabe)
+	RecordedBy       primitive.ObjectID   `bson:"recordedBy" json:"recordedBy"`
+	RecordedAt       time.Time            `bson:"recordedAt" json:"recordedAt"`
+	CreatedAt        time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// NewDamages liefert alle bei der Rückgabe neu festgestellten Schäden
+func (h *ReservationHandover) NewDamages() []HandoverDamage {
+	var damages []HandoverDamage
+	for _, damage := range h.Damages {
+		if damage.IsNew {
+			damages = append(damages, damage)
+		}
+	}
+	return damages
+}
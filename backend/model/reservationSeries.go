@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecurrenceFrequency repräsentiert die Wiederholungsart einer Reservierungsserie
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"   // Täglich
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"  // Wöchentlich an ausgewählten Wochentagen
+	RecurrenceMonthly RecurrenceFrequency = "monthly" // Monatlich am Tag der ersten Reservierung
+)
+
+// ReservationSeriesStatus repräsentiert den Status einer Reservierungsserie
+type ReservationSeriesStatus string
+
+const (
+	ReservationSeriesStatusActive    ReservationSeriesStatus = "active"    // Serie aktiv
+	ReservationSeriesStatusCancelled ReservationSeriesStatus = "cancelled" // Serie storniert
+)
+
+// MaxSeriesOccurrences begrenzt die Anzahl der Termine, die eine Serie erzeugen darf
+const MaxSeriesOccurrences = 366
+
+// RecurrenceRule beschreibt die Wiederholungsregel einer Serie.
+// Genau eines der Felder Until oder Count muss gesetzt sein.
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency `bson:"frequency" json:"frequency"`
+	Interval  int                 `bson:"interval" json:"interval"`                     // Jeden n-ten Tag/Woche/Monat (Standard 1)
+	Weekdays  []time.Weekday      `bson:"weekdays,omitempty" json:"weekdays,omitempty"` // Nur bei wöchentlich (0 = Sonntag)
+	Until     *time.Time          `bson:"until,omitempty" json:"until,omitempty"`       // Letzter möglicher Starttermin (inklusive)
+	Count     int                 `bson:"count,omitempty" json:"count,omitempty"`       // Anzahl der Termine
+}
+
+// ReservationSeries verknüpft die aus einer Wiederholungsregel erzeugten Reservierungen
+type ReservationSeries struct {
+	ID         primitive.ObjectID      `bson:"_id,omitempty" json:"id"`
+	VehicleID  primitive.ObjectID      `bson:"vehicleId" json:"vehicleId"`
+	DriverID   primitive.ObjectID      `bson:"driverId" json:"driverId"`
+	StartTime  time.Time               `bson:"startTime" json:"startTime"` // Beginn des ersten Termins
+	EndTime    time.Time               `bson:"endTime" json:"endTime"`     // Ende des ersten Termins
+	Recurrence RecurrenceRule          `bson:"recurrence" json:"recurrence"`
+	Status     ReservationSeriesStatus `bson:"status" json:"status"`
+	Purpose    string                  `bson:"purpose,omitempty" json:"purpose"`
+	Notes      string                  `bson:"notes,omitempty" json:"notes"`
+	CreatedBy  primitive.ObjectID      `bson:"createdBy" json:"createdBy"`
+	CreatedAt  time.Time               `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time               `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobTrigger gibt an, wodurch ein Joblauf ausgelöst wurde
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule" // Planmäßiger Lauf
+	JobTriggerManual   JobTrigger = "manual"   // Manuell durch einen Administrator gestartet
+)
+
+// JobRunStatus repräsentiert das Ergebnis eines Joblaufs
+type JobRunStatus string
+
+const (
+	JobRunStatusSuccess JobRunStatus = "success" // Erfolgreich abgeschlossen
+	JobRunStatusFailed  JobRunStatus = "failed"  // Mit Fehler abgebrochen
+)
+
+// ScheduledJob speichert Zustand und Sperre eines registrierten Hintergrundjobs.
+// Über LeaseOwner/LeaseUntil wird verhindert, dass mehrere FleetFlow-Instanzen
+// denselben Job gleichzeitig ausführen.
+type ScheduledJob struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name           string             `bson:"name" json:"name"` // Eindeutiger Name des Jobs
+	Description    string             `bson:"description" json:"description"`
+	Schedule       string             `bson:"schedule" json:"schedule"` // Cron-Ausdruck oder "@every <Dauer>"
+	Paused         bool               `bson:"paused" json:"paused"`
+	NextRunAt      time.Time          `bson:"nextRunAt" json:"nextRunAt"`
+	LastRunAt      *time.Time         `bson:"lastRunAt,omitempty" json:"lastRunAt"`
+	LastStatus     JobRunStatus       `bson:"lastStatus,omitempty" json:"lastStatus"`
+	LastError      string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	LastDurationMs int64              `bson:"lastDurationMs" json:"lastDurationMs"`
+	LeaseOwner     string             `bson:"leaseOwner,omitempty" json:"leaseOwner,omitempty"` // Instanz, die den Job gerade ausführt
+	LeaseUntil     *time.Time         `bson:"leaseUntil,omitempty" json:"leaseUntil,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// IsRunning prüft, ob der Job gerade von einer Instanz ausgeführt wird
+func (j *ScheduledJob) IsRunning() bool {
+	return j.LeaseUntil != nil && j.LeaseUntil.After(time.Now())
+}
+
+// JobRun protokolliert einen einzelnen Lauf eines Hintergrundjobs
+type JobRun struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobName    string             `bson:"jobName" json:"jobName"`
+	Trigger    JobTrigger         `bson:"trigger" json:"trigger"`
+	Instance   string             `bson:"instance" json:"instance"` // Ausführende FleetFlow-Instanz
+	Status     JobRunStatus       `bson:"status" json:"status"`
+	Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt  time.Time          `bson:"startedAt" json:"startedAt"`
+	FinishedAt time.Time          `bson:"finishedAt" json:"finishedAt"`
+	DurationMs int64              `bson:"durationMs" json:"durationMs"`
+}
@@ -48,6 +48,7 @@ const (
 	EmailTemplateWelcome         EmailTemplateType = "welcome"
 	EmailTemplateBookingReminder EmailTemplateType = "booking_reminder"
 	EmailTemplateMaintenanceAlert EmailTemplateType = "maintenance_alert"
+	EmailTemplateExpiryReminder   EmailTemplateType = "expiry_reminder"
 )
 
 // EmailLog repräsentiert ein E-Mail-Versand-Log
@@ -56,9 +57,16 @@ type EmailLog struct {
 	To        string             `bson:"to" json:"to"`
 	Subject   string             `bson:"subject" json:"subject"`
 	Body      string             `bson:"body" json:"body"`
+	BodyHTML  string             `bson:"bodyHtml,omitempty" json:"bodyHtml,omitempty"`
 	Status    EmailStatus        `bson:"status" json:"status"`
 	Error     string             `bson:"error,omitempty" json:"error,omitempty"`
 	SentAt    *time.Time         `bson:"sentAt,omitempty" json:"sentAt,omitempty"`
+
+	// Warteschlange: Zustellversuche mit exponentiellem Backoff
+	Attempts      int        `bson:"attempts" json:"attempts"`
+	MaxAttempts   int        `bson:"maxAttempts" json:"maxAttempts"`
+	NextAttemptAt time.Time  `bson:"nextAttemptAt" json:"nextAttemptAt"` // Frühester nächster Versuch bzw. Ende der Bearbeitungssperre
+	LastAttemptAt *time.Time `bson:"lastAttemptAt,omitempty" json:"lastAttemptAt,omitempty"`
 	
 	// Template info
 	TemplateType EmailTemplateType `bson:"templateType,omitempty" json:"templateType,omitempty"`
@@ -84,8 +92,103 @@ type NotificationSettings struct {
 	BookingReminders   bool               `bson:"bookingReminders" json:"bookingReminders"`
 	FuelReminders      bool               `bson:"fuelReminders" json:"fuelReminders"`
 	MaintenanceAlerts  bool               `bson:"maintenanceAlerts" json:"maintenanceAlerts"`
+
+	// Einzelne Ereignisarten
+	UrgentReports        bool `bson:"urgentReports" json:"urgentReports"`               // Dringende Fahrzeugmeldungen (Manager)
+	ReservationRequests  bool `bson:"reservationRequests" json:"reservationRequests"`   // Neue Reservierungsanfragen (Manager)
+	ReservationDecisions bool `bson:"reservationDecisions" json:"reservationDecisions"` // Genehmigung/Ablehnung eigener Reservierungen
+	ExpiryReminders      bool `bson:"expiryReminders" json:"expiryReminders"`           // Ablauf von Führerschein, Dokumenten, Versicherung, Leasing
+
+	// Ruhezeit im Format "HH:MM" (Europe/Berlin), darf über Mitternacht gehen
+	QuietHoursEnabled bool   `bson:"quietHoursEnabled" json:"quietHoursEnabled"`
+	QuietHoursStart   string `bson:"quietHoursStart" json:"quietHoursStart"`
+	QuietHoursEnd     string `bson:"quietHoursEnd" json:"quietHoursEnd"`
 	
 	// Audit fields
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
-}
\ No newline at end of file
+}
+
+// NotificationEvent beschreibt die Art einer Benachrichtigung
+type NotificationEvent string
+
+const (
+	NotificationUrgentReport        NotificationEvent = "urgent_report"
+	NotificationReservationRequest  NotificationEvent = "reservation_request"
+	NotificationReservationApproved NotificationEvent = "reservation_approved"
+	NotificationReservationRejected NotificationEvent = "reservation_rejected"
+	NotificationBookingReminder     NotificationEvent = "booking_reminder"
+	NotificationMaintenanceAlert    NotificationEvent = "maintenance_alert"
+	NotificationExpiryReminder      NotificationEvent = "expiry_reminder"
+	NotificationFuelReminder        NotificationEvent = "fuel_reminder"
+	NotificationTrafficFine         NotificationEvent = "traffic_fine" // Wegen der gesetzlichen Frist immer zugestellt
+)
+
+// Allows prüft, ob der Benutzer Benachrichtigungen dieser Art per E-Mail erhalten möchte
+func (n *NotificationSettings) Allows(event NotificationEvent) bool {
+	if !n.EmailNotifications {
+		return false
+	}
+
+	switch event {
+	case NotificationUrgentReport:
+		return n.UrgentReports
+	case NotificationReservationRequest:
+		return n.ReservationRequests
+	case NotificationReservationApproved, NotificationReservationRejected:
+		return n.ReservationDecisions
+	case NotificationBookingReminder:
+		return n.BookingReminders
+	case NotificationMaintenanceAlert:
+		return n.MaintenanceAlerts
+	case NotificationExpiryReminder:
+		return n.ExpiryReminders
+	case NotificationFuelReminder:
+		return n.FuelReminders
+	}
+	return true
+}
+
+// InQuietHours prüft, ob die Uhrzeit von t in die Ruhezeit fällt.
+// t muss bereits in der Zeitzone des Benutzers vorliegen.
+func (n *NotificationSettings) InQuietHours(t time.Time) bool {
+	if !n.QuietHoursEnabled {
+		return false
+	}
+
+	start, okStart := ParseClock(n.QuietHoursStart)
+	end, okEnd := ParseClock(n.QuietHoursEnd)
+	if !okStart || !okEnd || start == end {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// Ruhezeit über Mitternacht, z.B. 22:00 - 07:00
+	return minute >= start || minute < end
+}
+
+// QuietHoursEndAfter liefert das nächste Ende der Ruhezeit nach t (in der Zeitzone von t)
+func (n *NotificationSettings) QuietHoursEndAfter(t time.Time) time.Time {
+	end, ok := ParseClock(n.QuietHoursEnd)
+	if !ok {
+		return t
+	}
+
+	next := time.Date(t.Year(), t.Month(), t.Day(), end/60, end%60, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// ParseClock wandelt eine Uhrzeit "HH:MM" in Minuten seit Mitternacht um
+func ParseClock(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BenefitMethod beschreibt, wie der geldwerte Vorteil aus der Privatnutzung eines Dienstwagens ermittelt wird
+type BenefitMethod string
+
+const (
+	BenefitMethodFlatRate BenefitMethod = "flat_rate" // Pauschal nach der 1%-Regelung
+	BenefitMethodLogbook  BenefitMethod = "logbook"   // Tatsächliche Kosten nach Fahrtenbuch
+)
+
+// BenefitMethodText enthält die Anzeigenamen der Methoden
+var BenefitMethodText = map[BenefitMethod]string{
+	BenefitMethodFlatRate: "1%-Regelung",
+	BenefitMethodLogbook:  "Fahrtenbuchmethode",
+}
+
+// IsValidBenefitMethod prüft, ob die Methode bekannt ist. Leer steht für die 1%-Regelung.
+func IsValidBenefitMethod(method BenefitMethod) bool {
+	return method == "" || method == BenefitMethodFlatRate || method == BenefitMethodLogbook
+}
+
+// TaxableBenefitStatement ist die monatliche Abrechnung des geldwerten Vorteils eines Fahrers für die Lohnbuchhaltung
+type TaxableBenefitStatement struct {
+	Month        string             `json:"month"` // YYYY-MM
+	DriverID     primitive.ObjectID `json:"driverId"`
+	DriverName   string             `json:"driverName"`
+	DriverNumber string             `json:"driverNumber"`
+	VehicleID    primitive.ObjectID `json:"vehicleId"`
+	LicensePlate string             `json:"licensePlate"`
+	FuelType     FuelType           `json:"fuelType"`
+	Method       BenefitMethod      `json:"method"`
+
+	// 1%-Regelung
+	ListPrice       float64 `json:"listPrice"`
+	ListPriceFactor float64 `json:"listPriceFactor"` // 1, 0,5 oder 0,25 für Hybrid- und Elektrofahrzeuge
+	AssessmentBase  float64 `json:"assessmentBase"`  // Geminderter Listenpreis, auf volle 100 € abgerundet
+
+	// Fahrtenbuchmethode
+	FuelCosts        float64 `json:"fuelCosts"`
+	MaintenanceCosts float64 `json:"maintenanceCosts"`
+	InsuranceCosts   float64 `json:"insuranceCosts"`
+	FinancingCosts   float64 `json:"financingCosts"` // Abschreibung oder Leasingrate (gemindert), bei Finanzierung zzgl. Zinsanteil (ungemindert)
+	TotalCosts       float64 `json:"totalCosts"`
+	TotalKm          int     `json:"totalKm"`
+	PrivateKm        int     `json:"privateKm"`
+	CommuteKm        int     `json:"commuteKm"`
+	CostPerKm        float64 `json:"costPerKm"`
+
+	// Fahrten Wohnung - erste Tätigkeitsstätte (1%-Regelung)
+	CommuteDistanceKm int  `json:"commuteDistanceKm"`
+	CommuteDaily      bool `json:"commuteDaily"`
+	CommuteDays       int  `json:"commuteDays"`
+
+	PrivateUseBenefit float64  `json:"privateUseBenefit"`
+	CommuteBenefit    float64  `json:"commuteBenefit"`
+	TotalBenefit      float64  `json:"totalBenefit"`
+	Warnings          []string `json:"warnings,omitempty"`
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DepreciationMethod ist das Abschreibungsverfahren für gekaufte und finanzierte Fahrzeuge
+type DepreciationMethod string
+
+const (
+	DepreciationLinear           DepreciationMethod = "linear"            // Gleichmäßig über die Nutzungsdauer
+	DepreciationDecliningBalance DepreciationMethod = "declining_balance" // Fester Satz vom Restbuchwert, Wechsel zu linear sobald günstiger
+)
+
+const (
+	// DefaultDepreciationYears ist die Nutzungsdauer von Pkw laut AfA-Tabelle
+	DefaultDepreciationYears = 6
+	// DefaultDecliningBalanceRate ist der jährliche Satz der degressiven Abschreibung
+	DefaultDecliningBalanceRate = 0.25
+)
+
+// TCOOptions steuert die Berechnung der Gesamtkosten
+type TCOOptions struct {
+	From              *time.Time // Ohne Angabe ab Erwerb des Fahrzeugs
+	To                *time.Time // Ohne Angabe bis heute
+	Method            DepreciationMethod
+	DepreciationYears int
+	DecliningRate     float64 // Jährlicher Satz, z.B. 0.25
+}
+
+// TCOCosts schlüsselt die Gesamtkosten nach Kostenarten auf
+type TCOCosts struct {
+	Depreciation    float64 `json:"depreciation"`    // Wertverlust gekaufter und finanzierter Fahrzeuge
+	FinanceInterest float64 `json:"financeInterest"` // Zinsanteil der Finanzierungsraten
+	LeaseRates      float64 `json:"leaseRates"`
+	ExcessMileage   float64 `json:"excessMileage"` // Anteilige, hochgerechnete Mehrkilometer-Kosten
+	Insurance       float64 `json:"insurance"`
+	Fuel            float64 `json:"fuel"`
+	Charging        float64 `json:"charging"` // Ladekosten, zu Hause nur freigegebene Erstattungen
+	Maintenance     float64 `json:"maintenance"`
+	Claims          float64 `json:"claims"` // Schadenkosten nach Erstattungen, soweit nicht als Wartung erfasst
+	Fines           float64 `json:"fines"`  // Vom Unternehmen bezahlte Bußgelder
+	Total           float64 `json:"total"`
+}
+
+// Add addiert die Kosten eines weiteren Fahrzeugs
+func (c *TCOCosts) Add(other TCOCosts) {
+	c.Depreciation += other.Depreciation
+	c.FinanceInterest += other.FinanceInterest
+	c.LeaseRates += other.LeaseRates
+	c.ExcessMileage += other.ExcessMileage
+	c.Insurance += other.Insurance
+	c.Fuel += other.Fuel
+	c.Charging += other.Charging
+	c.Maintenance += other.Maintenance
+	c.Claims += other.Claims
+	c.Fines += other.Fines
+	c.Total += other.Total
+}
+
+// VehicleTCO sind die Gesamtkosten eines Fahrzeugs im Auswertungszeitraum
+type VehicleTCO struct {
+	VehicleID          primitive.ObjectID `json:"vehicleId"`
+	LicensePlate       string             `json:"licensePlate"`
+	Brand              string             `json:"brand"`
+	Model              string             `json:"model"`
+	AcquisitionType    AcquisitionType    `json:"acquisitionType"`
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"`
+	Months             float64            `json:"months"`
+	Kilometers         int                `json:"kilometers"`
+	Costs              TCOCosts           `json:"costs"`
+	CostPerKm          float64            `json:"costPerKm"`
+	CostPerMonth       float64            `json:"costPerMonth"`
+	DepreciationMethod DepreciationMethod `json:"depreciationMethod,omitempty"`
+	BookValue          *float64           `json:"bookValue,omitempty"`         // Restbuchwert am Ende des Zeitraums
+	ProjectedExcessKm  int                `json:"projectedExcessKm,omitempty"` // Hochgerechnete Mehrkilometer bis Leasingende
+	Warnings           []string           `json:"warnings"`
+}
+
+// FleetTCO sind die Gesamtkosten aller Fahrzeuge im Auswertungszeitraum
+type FleetTCO struct {
+	From         *time.Time   `json:"from,omitempty"`
+	To           time.Time    `json:"to"`
+	Vehicles     []VehicleTCO `json:"vehicles"`
+	Costs        TCOCosts     `json:"costs"`
+	Kilometers   int          `json:"kilometers"`
+	CostPerKm    float64      `json:"costPerKm"`
+	CostPerMonth float64      `json:"costPerMonth"` // Summe der monatlichen Kosten aller Fahrzeuge
+}
+
+// ModelTCO vergleicht die Gesamtkosten eines Fahrzeugmodells
+type ModelTCO struct {
+	Brand        string   `json:"brand"`
+	Model        string   `json:"model"`
+	Vehicles     int      `json:"vehicles"`
+	Months       float64  `json:"months"` // Summe der Fahrzeugmonate
+	Kilometers   int      `json:"kilometers"`
+	Costs        TCOCosts `json:"costs"`
+	CostPerKm    float64  `json:"costPerKm"`
+	CostPerMonth float64  `json:"costPerMonth"` // Je Fahrzeug und Monat
+}
@@ -0,0 +1,234 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LegalMinTreadDepth ist die gesetzliche Mindestprofiltiefe in mm (§ 36 StVZO)
+const LegalMinTreadDepth = 1.6
+
+// TireSeason ist die Saison, für die ein Reifensatz vorgesehen ist
+type TireSeason string
+
+const (
+	TireSeasonSummer    TireSeason = "summer"
+	TireSeasonWinter    TireSeason = "winter"
+	TireSeasonAllSeason TireSeason = "all_season"
+)
+
+// TireSeasonText enthält die Anzeigenamen der Saisons
+var TireSeasonText = map[TireSeason]string{
+	TireSeasonSummer:    "Sommerreifen",
+	TireSeasonWinter:    "Winterreifen",
+	TireSeasonAllSeason: "Ganzjahresreifen",
+}
+
+// TireLocation gibt an, wo sich ein Reifensatz befindet
+type TireLocation string
+
+const (
+	TireLocationVehicle TireLocation = "vehicle" // Am Fahrzeug montiert
+	TireLocationStorage TireLocation = "storage" // Eingelagert
+)
+
+// TireTreadMeasurement ist eine Messung der Profiltiefe in mm je Position
+type TireTreadMeasurement struct {
+	Date       time.Time `bson:"date" json:"date"`
+	FrontLeft  float64   `bson:"frontLeft" json:"frontLeft"`
+	FrontRight float64   `bson:"frontRight" json:"frontRight"`
+	RearLeft   float64   `bson:"rearLeft" json:"rearLeft"`
+	RearRight  float64   `bson:"rearRight" json:"rearRight"`
+	Mileage    int       `bson:"mileage,omitempty" json:"mileage,omitempty"`
+	Notes      string    `bson:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// MinDepth liefert die geringste gemessene Profiltiefe
+func (m TireTreadMeasurement) MinDepth() float64 {
+	return min(m.FrontLeft, m.FrontRight, m.RearLeft, m.RearRight)
+}
+
+// TireSeasonUsage ist die Laufleistung eines Reifensatzes in einer Saison
+type TireSeasonUsage struct {
+	Label            string             `bson:"label" json:"label"` // z.B. "Winter 2026/27"
+	VehicleID        primitive.ObjectID `bson:"vehicleId" json:"vehicleId"`
+	MountedAt        time.Time          `bson:"mountedAt" json:"mountedAt"`
+	MountedMileage   int                `bson:"mountedMileage" json:"mountedMileage"`
+	UnmountedAt      *time.Time         `bson:"unmountedAt,omitempty" json:"unmountedAt,omitempty"`
+	UnmountedMileage int                `bson:"unmountedMileage,omitempty" json:"unmountedMileage,omitempty"`
+	Distance         int                `bson:"distance" json:"distance"`
+}
+
+// TireSet ist ein Satz Reifen (in der Regel vier), der einem Fahrzeug zugeordnet ist
+// und entweder montiert oder eingelagert ist
+type TireSet struct {
+	ID             primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	VehicleID      *primitive.ObjectID    `bson:"vehicleId,omitempty" json:"vehicleId,omitempty"` // Zugeordnetes Fahrzeug (auch bei Einlagerung)
+	Season         TireSeason             `bson:"season" json:"season"`
+	Brand          string                 `bson:"brand" json:"brand"`
+	Model          string                 `bson:"model" json:"model"`
+	Size           string                 `bson:"size" json:"size"` // z.B. 205/55 R16 91H
+	RimType        string                 `bson:"rimType" json:"rimType"`
+	DOTCode        string                 `bson:"dotCode" json:"dotCode"` // Produktionswoche und -jahr, z.B. 2321
+	ProductionDate *time.Time             `bson:"productionDate,omitempty" json:"productionDate,omitempty"`
+	Location       TireLocation           `bson:"location" json:"location"`
+	StorageDetails string                 `bson:"storageDetails" json:"storageDetails"` // Reifenhotel, Regalplatz o.ä.
+	Measurements   []TireTreadMeasurement `bson:"measurements" json:"measurements"`
+	SeasonUsage    []TireSeasonUsage      `bson:"seasonUsage" json:"seasonUsage"`
+	TotalMileage   int                    `bson:"totalMileage" json:"totalMileage"`
+	Retired        bool                   `bson:"retired" json:"retired"` // Ausgemustert
+	Notes          string                 `bson:"notes" json:"notes"`
+	CreatedAt      time.Time              `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time              `bson:"updatedAt" json:"updatedAt"`
+}
+
+// LatestMeasurement liefert die jüngste Profilmessung oder nil
+func (t *TireSet) LatestMeasurement() *TireTreadMeasurement {
+	var latest *TireTreadMeasurement
+	for i := range t.Measurements {
+		if latest == nil || !t.Measurements[i].Date.Before(latest.Date) {
+			latest = &t.Measurements[i]
+		}
+	}
+	return latest
+}
+
+// AgeYears liefert das Alter der Reifen seit Produktion in Jahren
+func (t *TireSet) AgeYears(now time.Time) float64 {
+	if t.ProductionDate == nil {
+		return 0
+	}
+	return now.Sub(*t.ProductionDate).Hours() / 24 / 365.25
+}
+
+// ParseDOTDate wandelt die letzten vier Ziffern der DOT-Nummer (WWJJ) in den Montag der Produktionswoche um
+func ParseDOTDate(code string) (time.Time, error) {
+	if len(code) != 4 {
+		return time.Time{}, fmt.Errorf("dot-datum muss aus vier ziffern bestehen (wwjj)")
+	}
+	week, errWeek := strconv.Atoi(code[:2])
+	year, errYear := strconv.Atoi(code[2:])
+	if errWeek != nil || errYear != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("ungültiges dot-datum %s", code)
+	}
+	year += 2000
+
+	// Montag der ISO-Kalenderwoche: der 4. Januar liegt immer in Woche 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, -offset+(week-1)*7), nil
+}
+
+// TireSetFilter schränkt die Abfrage von Reifensätzen ein
+type TireSetFilter struct {
+	VehicleID      *primitive.ObjectID
+	Location       TireLocation
+	Season         TireSeason
+	IncludeRetired bool
+}
+
+// TireSettings enthält die Firmenvorgaben für Reifen und die Zeitfenster der Wechselaktionen
+type TireSettings struct {
+	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	MinTreadDepthSummer float64            `bson:"minTreadDepthSummer" json:"minTreadDepthSummer"` // mm, mindestens gesetzliche 1,6 mm
+	MinTreadDepthWinter float64            `bson:"minTreadDepthWinter" json:"minTreadDepthWinter"` // mm, gilt auch für Ganzjahresreifen
+	MaxAgeYears         int                `bson:"maxAgeYears" json:"maxAgeYears"`                 // 0 = keine Altersgrenze
+	SpringWindowStart   string             `bson:"springWindowStart" json:"springWindowStart"`     // MM-TT
+	SpringWindowEnd     string             `bson:"springWindowEnd" json:"springWindowEnd"`         // MM-TT
+	AutumnWindowStart   string             `bson:"autumnWindowStart" json:"autumnWindowStart"`     // MM-TT
+	AutumnWindowEnd     string             `bson:"autumnWindowEnd" json:"autumnWindowEnd"`         // MM-TT
+	SwapsPerDay         int                `bson:"swapsPerDay" json:"swapsPerDay"`                 // Werkstattkapazität je Arbeitstag
+	CreatedAt           time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt           time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// DefaultTireSettings liefert die Standardvorgaben (Wechsel nach der Faustregel "von O bis O")
+func DefaultTireSettings() *TireSettings {
+	return &TireSettings{
+		MinTreadDepthSummer: 3.0,
+		MinTreadDepthWinter: 4.0,
+		MaxAgeYears:         8,
+		SpringWindowStart:   "03-20",
+		SpringWindowEnd:     "04-30",
+		AutumnWindowStart:   "10-01",
+		AutumnWindowEnd:     "11-15",
+		SwapsPerDay:         8,
+	}
+}
+
+// TireWarningType ist die Art einer Reifenwarnung
+type TireWarningType string
+
+const (
+	TireWarningTreadLegal   TireWarningType = "tread_legal"    // Unter der gesetzlichen Mindestprofiltiefe
+	TireWarningTreadCompany TireWarningType = "tread_company"  // Unter der Firmenvorgabe
+	TireWarningAge          TireWarningType = "age"            // Älter als die Firmenvorgabe
+	TireWarningNoMeasure    TireWarningType = "no_measurement" // Noch keine Profilmessung
+)
+
+// TireWarning ist eine Warnung zu einem Reifensatz
+type TireWarning struct {
+	TireSetID    primitive.ObjectID  `json:"tireSetId"`
+	VehicleID    *primitive.ObjectID `json:"vehicleId,omitempty"`
+	LicensePlate string              `json:"licensePlate,omitempty"`
+	Season       TireSeason          `json:"season"`
+	Location     TireLocation        `json:"location"`
+	Type         TireWarningType     `json:"type"`
+	Message      string              `json:"message"`
+	Value        float64             `json:"value"` // Profiltiefe in mm bzw. Alter in Jahren
+	Limit        float64             `json:"limit"`
+}
+
+// TireSwapWindow ist die Wechselaktion im Frühjahr (auf Sommerreifen) oder Herbst (auf Winterreifen)
+type TireSwapWindow string
+
+const (
+	TireSwapSpring TireSwapWindow = "spring"
+	TireSwapAutumn TireSwapWindow = "autumn"
+)
+
+// TargetSeason liefert die Saison, auf die in der Wechselaktion umgerüstet wird
+func (w TireSwapWindow) TargetSeason() TireSeason {
+	if w == TireSwapAutumn {
+		return TireSeasonWinter
+	}
+	return TireSeasonSummer
+}
+
+// TireSwapItemStatus ist der Status eines geplanten Reifenwechsels
+type TireSwapItemStatus string
+
+const (
+	TireSwapPlanned TireSwapItemStatus = "planned"
+	TireSwapDone    TireSwapItemStatus = "done"
+	TireSwapSkipped TireSwapItemStatus = "skipped"
+)
+
+// TireSwapItem ist der geplante Reifenwechsel eines Fahrzeugs innerhalb einer Wechselaktion
+type TireSwapItem struct {
+	VehicleID     primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
+	LicensePlate  string              `bson:"licensePlate" json:"licensePlate"`
+	CurrentSetID  *primitive.ObjectID `bson:"currentSetId,omitempty" json:"currentSetId,omitempty"`
+	TargetSetID   *primitive.ObjectID `bson:"targetSetId,omitempty" json:"targetSetId,omitempty"`
+	ScheduledDate time.Time           `bson:"scheduledDate" json:"scheduledDate"`
+	Status        TireSwapItemStatus  `bson:"status" json:"status"`
+	Issues        []string            `bson:"issues" json:"issues"` // z.B. fehlender Satz oder zu geringe Profiltiefe
+	CompletedAt   *time.Time          `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
+
+// TireSwapCampaign ist eine saisonale Reifenwechselaktion über die Flotte
+type TireSwapCampaign struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Window       TireSwapWindow     `bson:"window" json:"window"`
+	Year         int                `bson:"year" json:"year"`
+	TargetSeason TireSeason         `bson:"targetSeason" json:"targetSeason"`
+	StartDate    time.Time          `bson:"startDate" json:"startDate"`
+	EndDate      time.Time          `bson:"endDate" json:"endDate"`
+	Items        []TireSwapItem     `bson:"items" json:"items"`
+	CreatedBy    primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
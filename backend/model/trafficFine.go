@@ -0,0 +1,115 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrafficFineStatus ist der Bearbeitungsstand eines Bußgeldbescheids bzw. Anhörungsbogens
+type TrafficFineStatus string
+
+const (
+	TrafficFineStatusOpen      TrafficFineStatus = "open"      // Eingegangen, Fahrer noch nicht informiert
+	TrafficFineStatusNotified  TrafficFineStatus = "notified"  // Fahrer informiert, Rückmeldung an die Behörde offen
+	TrafficFineStatusForwarded TrafficFineStatus = "forwarded" // Fahrer gegenüber der Behörde benannt
+	TrafficFineStatusPaid      TrafficFineStatus = "paid"      // Bezahlt
+	TrafficFineStatusCancelled TrafficFineStatus = "cancelled" // Verfahren eingestellt
+)
+
+// TrafficFineStatusText enthält die Anzeigenamen der Status
+var TrafficFineStatusText = map[TrafficFineStatus]string{
+	TrafficFineStatusOpen:      "Eingegangen",
+	TrafficFineStatusNotified:  "Fahrer informiert",
+	TrafficFineStatusForwarded: "Fahrer benannt",
+	TrafficFineStatusPaid:      "Bezahlt",
+	TrafficFineStatusCancelled: "Eingestellt",
+}
+
+// DriverSource gibt an, woraus der Fahrer zum Tatzeitpunkt ermittelt wurde
+type DriverSource string
+
+const (
+	DriverSourceUsage       DriverSource = "usage"       // Fahrzeugnutzung
+	DriverSourceReservation DriverSource = "reservation" // Reservierung
+	DriverSourceAssignment  DriverSource = "assignment"  // Feste Fahrzeugzuweisung
+	DriverSourceManual      DriverSource = "manual"      // Manuell festgelegt
+)
+
+// TrafficFinePayer gibt an, wer das Bußgeld trägt
+type TrafficFinePayer string
+
+const (
+	TrafficFinePayerDriver  TrafficFinePayer = "driver"
+	TrafficFinePayerCompany TrafficFinePayer = "company"
+)
+
+// DriverCandidate ist ein Fahrer, der laut Historie zum Tatzeitpunkt das Fahrzeug hatte
+type DriverCandidate struct {
+	DriverID   primitive.ObjectID `bson:"driverId" json:"driverId"`
+	DriverName string             `bson:"driverName" json:"driverName"`
+	Source     DriverSource       `bson:"source" json:"source"`
+	SourceID   primitive.ObjectID `bson:"sourceId" json:"sourceId"`
+	From       time.Time          `bson:"from" json:"from"`
+	To         *time.Time         `bson:"to,omitempty" json:"to,omitempty"` // nil = noch offen
+}
+
+// DriverResolution ist das Ergebnis der Fahrerermittlung zu einem Zeitpunkt
+type DriverResolution struct {
+	VehicleID  primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
+	At         time.Time           `bson:"at" json:"at"`
+	DriverID   *primitive.ObjectID `bson:"driverId,omitempty" json:"driverId,omitempty"` // Nur bei eindeutigem Ergebnis
+	Source     DriverSource        `bson:"source,omitempty" json:"source,omitempty"`
+	Candidates []DriverCandidate   `bson:"candidates" json:"candidates"`
+	Ambiguous  bool                `bson:"ambiguous" json:"ambiguous"`
+	Warnings   []string            `bson:"warnings" json:"warnings"`
+}
+
+// TrafficFine ist ein Bußgeldbescheid bzw. Anhörungs- oder Zeugenfragebogen zu einem Fahrzeug
+type TrafficFine struct {
+	ID               primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	VehicleID        primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
+	LicensePlate     string              `bson:"licensePlate" json:"licensePlate"`
+	OffenseTime      time.Time           `bson:"offenseTime" json:"offenseTime"`
+	Location         string              `bson:"location" json:"location"`
+	Offense          string              `bson:"offense" json:"offense"`                 // Tatvorwurf, z.B. Geschwindigkeitsüberschreitung 21 km/h innerorts
+	Authority        string              `bson:"authority" json:"authority"`             // Bußgeldstelle
+	ReferenceNumber  string              `bson:"referenceNumber" json:"referenceNumber"` // Aktenzeichen
+	Amount           float64             `bson:"amount" json:"amount"`
+	Points           int                 `bson:"points" json:"points"` // Punkte im Fahreignungsregister
+	ReceivedAt       time.Time           `bson:"receivedAt" json:"receivedAt"`
+	ResponseDeadline time.Time           `bson:"responseDeadline" json:"responseDeadline"` // Frist zur Benennung des Fahrers
+	Status           TrafficFineStatus   `bson:"status" json:"status"`
+	DriverID         *primitive.ObjectID `bson:"driverId,omitempty" json:"driverId,omitempty"`
+	DriverSource     DriverSource        `bson:"driverSource,omitempty" json:"driverSource,omitempty"`
+	Resolution       DriverResolution    `bson:"resolution" json:"resolution"` // Ergebnis der automatischen Ermittlung
+	NotifiedAt       *time.Time          `bson:"notifiedAt,omitempty" json:"notifiedAt,omitempty"`
+	ReminderSentAt   *time.Time          `bson:"reminderSentAt,omitempty" json:"reminderSentAt,omitempty"`
+	ForwardedAt      *time.Time          `bson:"forwardedAt,omitempty" json:"forwardedAt,omitempty"`
+	ForwardedTo      string              `bson:"forwardedTo,omitempty" json:"forwardedTo,omitempty"`
+	ResponseNotes    string              `bson:"responseNotes,omitempty" json:"responseNotes,omitempty"`
+	PaidAt           *time.Time          `bson:"paidAt,omitempty" json:"paidAt,omitempty"`
+	PaidAmount       float64             `bson:"paidAmount" json:"paidAmount"`
+	PaidBy           TrafficFinePayer    `bson:"paidBy,omitempty" json:"paidBy,omitempty"`
+	Notes            string              `bson:"notes" json:"notes"`
+	CreatedBy        primitive.ObjectID  `bson:"createdBy" json:"createdBy"`
+	CreatedAt        time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AwaitsResponse prüft, ob der Behörde noch kein Fahrer benannt wurde
+func (f *TrafficFine) AwaitsResponse() bool {
+	return f.Status == TrafficFineStatusOpen || f.Status == TrafficFineStatusNotified
+}
+
+// IsOverdue prüft, ob die Frist zur Benennung des Fahrers abgelaufen ist
+func (f *TrafficFine) IsOverdue(now time.Time) bool {
+	return f.AwaitsResponse() && now.After(f.ResponseDeadline)
+}
+
+// TrafficFineFilter schränkt die Abfrage von Bußgeldern ein
+type TrafficFineFilter struct {
+	VehicleID *primitive.ObjectID
+	DriverID  *primitive.ObjectID
+	Statuses  []TrafficFineStatus // Leer = alle Status
+}
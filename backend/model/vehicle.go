@@ -45,6 +45,21 @@ const (
 	AcquisitionTypeLeased    AcquisitionType = "leased"
 )
 
+// VehicleStatusText enthält die Anzeigenamen der Fahrzeugstatus
+var VehicleStatusText = map[VehicleStatus]string{
+	VehicleStatusAvailable:   "Verfügbar",
+	VehicleStatusInUse:       "In Nutzung",
+	VehicleStatusMaintenance: "In Wartung",
+	VehicleStatusReserved:    "Reserviert",
+}
+
+// AcquisitionTypeText enthält die Anzeigenamen der Erwerbsarten
+var AcquisitionTypeText = map[AcquisitionType]string{
+	AcquisitionTypePurchased: "Kauf",
+	AcquisitionTypeFinanced:  "Finanzierung",
+	AcquisitionTypeLeased:    "Leasing",
+}
+
 // Vehicle repräsentiert ein Fahrzeug im System
 type Vehicle struct {
 	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -84,10 +99,15 @@ type Vehicle struct {
 	CurbWeight         int     `bson:"curbWeight" json:"curbWeight"`                 // Leermasse in kg
 	MaxSpeed           int     `bson:"maxSpeed" json:"maxSpeed"`                     // Höchstgeschwindigkeit in km/h
 	TowingCapacity     int     `bson:"towingCapacity" json:"towingCapacity"`         // Zulässige Anhängelast in kg
+	TankCapacity       float64 `bson:"tankCapacity" json:"tankCapacity"`             // Tankinhalt in l bzw. nutzbare Batteriekapazität in kWh
 	SpecialFeatures    string  `bson:"specialFeatures" json:"specialFeatures"`       // Besonderheiten
 
+	// Explizit geforderte Führerscheinklasse (leer = aus Fahrzeugart und Gesamtmasse abgeleitet)
+	RequiredLicenseClass LicenseClass `bson:"requiredLicenseClass,omitempty" json:"requiredLicenseClass"`
+
 	// Finanzierungsinformationen
 	AcquisitionType AcquisitionType `bson:"acquisitionType" json:"acquisitionType"`
+	ListPrice       float64         `bson:"listPrice" json:"listPrice"` // Inländischer Bruttolistenpreis inkl. Sonderausstattung (1%-Regelung)
 
 	// Kauf-spezifische Felder
 	PurchaseDate   time.Time `bson:"purchaseDate" json:"purchaseDate"`
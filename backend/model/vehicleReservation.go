@@ -18,24 +18,36 @@ const (
 	ReservationStatusCancelled ReservationStatus = "cancelled" // Reservierung storniert
 )
 
+// ReservationStatusText enthält die Anzeigenamen der Reservierungsstatus
+var ReservationStatusText = map[ReservationStatus]string{
+	ReservationStatusPending:   "Ausstehend",
+	ReservationStatusApproved:  "Genehmigt",
+	ReservationStatusRejected:  "Abgelehnt",
+	ReservationStatusActive:    "Aktiv",
+	ReservationStatusCompleted: "Abgeschlossen",
+	ReservationStatusCancelled: "Storniert",
+}
+
 // VehicleReservation repräsentiert eine Fahrzeug-Reservierung
 type VehicleReservation struct {
-	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	VehicleID     primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
-	DriverID      primitive.ObjectID  `bson:"driverId" json:"driverId"`
-	StartTime     time.Time           `bson:"startTime" json:"startTime"`
-	EndTime       time.Time           `bson:"endTime" json:"endTime"`
-	Status        ReservationStatus   `bson:"status" json:"status"`
-	Purpose       string              `bson:"purpose,omitempty" json:"purpose"`             // Zweck der Reservierung
-	Notes         string              `bson:"notes,omitempty" json:"notes"`                 // Zusätzliche Notizen
-	CreatedBy     primitive.ObjectID  `bson:"createdBy" json:"createdBy"`                   // Wer die Reservierung erstellt hat
-	ApprovedBy    *primitive.ObjectID `bson:"approvedBy,omitempty" json:"approvedBy"`       // Wer die Reservierung genehmigt hat
-	ApprovedAt    *time.Time          `bson:"approvedAt,omitempty" json:"approvedAt"`       // Wann die Reservierung genehmigt wurde
-	RejectedBy    *primitive.ObjectID `bson:"rejectedBy,omitempty" json:"rejectedBy"`       // Wer die Reservierung abgelehnt hat
-	RejectedAt    *time.Time          `bson:"rejectedAt,omitempty" json:"rejectedAt"`       // Wann die Reservierung abgelehnt wurde
-	RejectionNote string              `bson:"rejectionNote,omitempty" json:"rejectionNote"` // Grund für Ablehnung
-	CreatedAt     time.Time           `bson:"createdAt" json:"createdAt"`
-	UpdatedAt     time.Time           `bson:"updatedAt" json:"updatedAt"`
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	VehicleID      primitive.ObjectID  `bson:"vehicleId" json:"vehicleId"`
+	DriverID       primitive.ObjectID  `bson:"driverId" json:"driverId"`
+	StartTime      time.Time           `bson:"startTime" json:"startTime"`
+	EndTime        time.Time           `bson:"endTime" json:"endTime"`
+	Status         ReservationStatus   `bson:"status" json:"status"`
+	Purpose        string              `bson:"purpose,omitempty" json:"purpose"`                         // Zweck der Reservierung
+	Notes          string              `bson:"notes,omitempty" json:"notes"`                             // Zusätzliche Notizen
+	CreatedBy      primitive.ObjectID  `bson:"createdBy" json:"createdBy"`                               // Wer die Reservierung erstellt hat
+	ApprovedBy     *primitive.ObjectID `bson:"approvedBy,omitempty" json:"approvedBy"`                   // Wer die Reservierung genehmigt hat
+	ApprovedAt     *time.Time          `bson:"approvedAt,omitempty" json:"approvedAt"`                   // Wann die Reservierung genehmigt wurde
+	RejectedBy     *primitive.ObjectID `bson:"rejectedBy,omitempty" json:"rejectedBy"`                   // Wer die Reservierung abgelehnt hat
+	RejectedAt     *time.Time          `bson:"rejectedAt,omitempty" json:"rejectedAt"`                   // Wann die Reservierung abgelehnt wurde
+	RejectionNote  string              `bson:"rejectionNote,omitempty" json:"rejectionNote"`             // Grund für Ablehnung
+	SeriesID       *primitive.ObjectID `bson:"seriesId,omitempty" json:"seriesId,omitempty"`             // Zugehörige Serie bei wiederkehrenden Reservierungen
+	ReminderSentAt *time.Time          `bson:"reminderSentAt,omitempty" json:"reminderSentAt,omitempty"` // Wann die Buchungserinnerung versendet wurde
+	CreatedAt      time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time           `bson:"updatedAt" json:"updatedAt"`
 }
 
 // IsActive prüft ob die Reservierung aktuell aktiv ist
@@ -54,4 +66,4 @@ func (r *VehicleReservation) IsPending() bool {
 func (r *VehicleReservation) IsOverdue() bool {
 	now := time.Now()
 	return (r.Status == ReservationStatusPending || r.Status == ReservationStatusActive) && now.After(r.EndTime)
-}
\ No newline at end of file
+}
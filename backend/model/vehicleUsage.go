@@ -17,6 +17,13 @@ const (
 	UsageStatusCancelled UsageStatus = "cancelled"
 )
 
+// UsageStatusText enthält die Anzeigenamen der Nutzungsstatus
+var UsageStatusText = map[UsageStatus]string{
+	UsageStatusActive:    "Aktiv",
+	UsageStatusCompleted: "Abgeschlossen",
+	UsageStatusCancelled: "Storniert",
+}
+
 // VehicleUsage repräsentiert eine Fahrzeugnutzung im System
 type VehicleUsage struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -17,6 +17,8 @@ const (
 	DocumentTypeInvoice             DocumentType = "invoice"              // Rechnungen
 	DocumentTypeWarranty            DocumentType = "warranty"             // Garantieunterlagen
 	DocumentTypeVehicleImage        DocumentType = "vehicle_image"        // Fahrzeugbilder
+	DocumentTypeHandoverPhoto       DocumentType = "handover_photo"       // Fotos aus Übergabeprotokollen
+	DocumentTypeClaimDocument       DocumentType = "claim_document"       // Unterlagen zu Schadenfällen
 	DocumentTypeOther               DocumentType = "other"                // Sonstige
 )
 
@@ -48,6 +50,8 @@ func DocumentTypeText(docType DocumentType) string {
 		DocumentTypeInvoice:             "Rechnung",
 		DocumentTypeWarranty:            "Garantie",
 		DocumentTypeVehicleImage:        "Fahrzeugbild",
+		DocumentTypeHandoverPhoto:       "Übergabefoto",
+		DocumentTypeClaimDocument:       "Schadenunterlagen",
 		DocumentTypeOther:               "Sonstiges",
 	}
 
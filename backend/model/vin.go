@@ -0,0 +1,18 @@
+package model
+
+// VINInfo ist das Ergebnis der Prüfung und Entschlüsselung einer Fahrzeug-Identifizierungsnummer (FIN)
+type VINInfo struct {
+	VIN                string `json:"vin"`                    // Normalisiert: Großbuchstaben ohne Leer- und Bindestriche
+	WMI                string `json:"wmi"`                    // Herstellercode, Stellen 1-3
+	Region             string `json:"region"`                 // Herkunftsregion nach der ersten Stelle
+	Manufacturer       string `json:"manufacturer,omitempty"` // Hersteller laut WMI-Tabelle, leer wenn unbekannt
+	Brand              string `json:"brand,omitempty"`        // Marke zum Vorbelegen von Vehicle.Brand
+	ModelYearCode      string `json:"modelYearCode"`          // Stelle 10
+	ModelYear          int    `json:"modelYear,omitempty"`    // 0, wenn die Stelle kein Modelljahr kodiert
+	PlantCode          string `json:"plantCode"`              // Stelle 11
+	Plant              string `json:"plant,omitempty"`        // Werk laut Tabelle, leer wenn unbekannt
+	SerialNumber       string `json:"serialNumber"`           // Stellen 12-17
+	CheckDigit         string `json:"checkDigit"`             // Stelle 9
+	CheckDigitRequired bool   `json:"checkDigitRequired"`     // Pflicht für Nordamerika und China
+	CheckDigitValid    bool   `json:"checkDigitValid"`
+}
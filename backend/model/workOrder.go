@@ -0,0 +1,109 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WorkOrderStatus ist der Status eines Werkstattauftrags
+type WorkOrderStatus string
+
+const (
+	WorkOrderStatusOpen       WorkOrderStatus = "open"        // Angelegt, Fahrzeug noch nicht in der Werkstatt
+	WorkOrderStatusInProgress WorkOrderStatus = "in_progress" // Fahrzeug steht in der Werkstatt
+	WorkOrderStatusClosed     WorkOrderStatus = "closed"      // Abgeschlossen, Wartungseintrag erstellt
+	WorkOrderStatusCancelled  WorkOrderStatus = "cancelled"   // Storniert
+)
+
+// WorkOrderStatusText enthält die Anzeigenamen der Status
+var WorkOrderStatusText = map[WorkOrderStatus]string{
+	WorkOrderStatusOpen:       "Offen",
+	WorkOrderStatusInProgress: "In der Werkstatt",
+	WorkOrderStatusClosed:     "Abgeschlossen",
+	WorkOrderStatusCancelled:  "Storniert",
+}
+
+// WorkOrderLineType unterscheidet Teile und Arbeitszeit
+type WorkOrderLineType string
+
+const (
+	WorkOrderLinePart   WorkOrderLineType = "part"   // Ersatzteil oder Material
+	WorkOrderLineLabour WorkOrderLineType = "labour" // Arbeitszeit, Menge in Stunden
+)
+
+// WorkOrderLineItem ist eine Position eines Werkstattauftrags
+type WorkOrderLineItem struct {
+	Type        WorkOrderLineType `bson:"type" json:"type"`
+	Description string            `bson:"description" json:"description"`
+	PartNumber  string            `bson:"partNumber,omitempty" json:"partNumber,omitempty"`
+	Quantity    float64           `bson:"quantity" json:"quantity"`
+	UnitPrice   float64           `bson:"unitPrice" json:"unitPrice"`
+	Total       float64           `bson:"total" json:"total"`
+}
+
+// WorkOrder ist ein Werkstattauftrag zu einer oder mehreren Fahrzeugmeldungen
+type WorkOrder struct {
+	ID                    primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	VehicleID             primitive.ObjectID   `bson:"vehicleId" json:"vehicleId"`
+	ReportIDs             []primitive.ObjectID `bson:"reportIds" json:"reportIds"`
+	Workshop              string               `bson:"workshop" json:"workshop"`
+	Description           string               `bson:"description" json:"description"`
+	MaintenanceType       MaintenanceType      `bson:"maintenanceType" json:"maintenanceType"` // Typ des Wartungseintrags beim Abschluss
+	LineItems             []WorkOrderLineItem  `bson:"lineItems" json:"lineItems"`
+	EstimatedCost         float64              `bson:"estimatedCost" json:"estimatedCost"`
+	ActualCost            float64              `bson:"actualCost" json:"actualCost"` // Beim Abschluss, ohne Angabe Summe der Positionen
+	DowntimeStart         *time.Time           `bson:"downtimeStart,omitempty" json:"downtimeStart,omitempty"`
+	DowntimeEnd           *time.Time           `bson:"downtimeEnd,omitempty" json:"downtimeEnd,omitempty"`
+	Status                WorkOrderStatus      `bson:"status" json:"status"`
+	PreviousVehicleStatus VehicleStatus        `bson:"previousVehicleStatus,omitempty" json:"previousVehicleStatus,omitempty"` // Status vor dem Werkstattaufenthalt
+	Mileage               int                  `bson:"mileage" json:"mileage"`                                                 // Kilometerstand bei Abschluss
+	Resolution            string               `bson:"resolution" json:"resolution"`
+	MaintenanceID         *primitive.ObjectID  `bson:"maintenanceId,omitempty" json:"maintenanceId,omitempty"`
+	CreatedBy             primitive.ObjectID   `bson:"createdBy" json:"createdBy"`
+	ClosedBy              *primitive.ObjectID  `bson:"closedBy,omitempty" json:"closedBy,omitempty"`
+	ClosedAt              *time.Time           `bson:"closedAt,omitempty" json:"closedAt,omitempty"`
+	CreatedAt             time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt             time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// LineItemTotal summiert die Positionen des Auftrags
+func (w *WorkOrder) LineItemTotal() float64 {
+	var total float64
+	for _, item := range w.LineItems {
+		total += item.Total
+	}
+	return total
+}
+
+// DowntimeDays liefert die Standzeit in angefangenen Tagen (bei laufendem Auftrag bis jetzt)
+func (w *WorkOrder) DowntimeDays() int {
+	if w.DowntimeStart == nil {
+		return 0
+	}
+	end := time.Now()
+	if w.DowntimeEnd != nil {
+		end = *w.DowntimeEnd
+	}
+	hours := end.Sub(*w.DowntimeStart).Hours()
+	if hours <= 0 {
+		return 0
+	}
+	days := int(hours / 24)
+	if float64(days)*24 < hours {
+		days++
+	}
+	return days
+}
+
+// IsActive prüft, ob der Auftrag weder abgeschlossen noch storniert ist
+func (w *WorkOrder) IsActive() bool {
+	return w.Status == WorkOrderStatusOpen || w.Status == WorkOrderStatusInProgress
+}
+
+// WorkOrderFilter schränkt die Abfrage von Werkstattaufträgen ein
+type WorkOrderFilter struct {
+	VehicleID *primitive.ObjectID
+	ReportID  *primitive.ObjectID
+	Statuses  []WorkOrderStatus // Leer = alle Status
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoAccidentClaimRepository enthält alle Datenbankoperationen für Schadenfälle
+type MongoAccidentClaimRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAccidentClaimRepository erstellt ein neues MongoAccidentClaimRepository
+func NewMongoAccidentClaimRepository() *MongoAccidentClaimRepository {
+	r := &MongoAccidentClaimRepository{
+		collection: db.GetCollection("accident_claims"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "reportId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "incidentDate", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für accident_claims konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt einen neuen Schadenfall an
+func (r *MongoAccidentClaimRepository) Create(claim *model.AccidentClaim) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	claim.ID = primitive.NewObjectID()
+	claim.CreatedAt = now
+	claim.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, claim)
+	return err
+}
+
+// Update aktualisiert einen Schadenfall
+func (r *MongoAccidentClaimRepository) Update(claim *model.AccidentClaim) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	claim.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"driverId":              claim.DriverID,
+			"incidentDate":          claim.IncidentDate,
+			"location":              claim.Location,
+			"description":           claim.Description,
+			"parties":               claim.Parties,
+			"policeReportNumber":    claim.PoliceReportNumber,
+			"fault":                 claim.Fault,
+			"faultShare":            claim.FaultShare,
+			"insuranceCompany":      claim.InsuranceCompany,
+			"insuranceNumber":       claim.InsuranceNumber,
+			"insuranceType":         claim.InsuranceType,
+			"claimNumber":           claim.ClaimNumber,
+			"workOrderId":           claim.WorkOrderID,
+			"repairCost":            claim.RepairCost,
+			"deductible":            claim.Deductible,
+			"reimbursementReceived": claim.ReimbursementReceived,
+			"reimbursedAt":          claim.ReimbursedAt,
+			"downtimeStart":         claim.DowntimeStart,
+			"downtimeEnd":           claim.DowntimeEnd,
+			"documentIds":           claim.DocumentIDs,
+			"timeline":              claim.Timeline,
+			"status":                claim.Status,
+			"closedAt":              claim.ClosedAt,
+			"updatedAt":             claim.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": claim.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht einen Schadenfall
+func (r *MongoAccidentClaimRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindByID findet einen Schadenfall anhand seiner ID
+func (r *MongoAccidentClaimRepository) FindByID(id string) (*model.AccidentClaim, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var claim model.AccidentClaim
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&claim); err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// FindByReport findet den Schadenfall zu einer Unfallmeldung
+func (r *MongoAccidentClaimRepository) FindByReport(reportID primitive.ObjectID) (*model.AccidentClaim, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var claim model.AccidentClaim
+	if err := r.collection.FindOne(ctx, bson.M{"reportId": reportID}).Decode(&claim); err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Find findet alle Schadenfälle zum Filter, die jüngsten Unfälle zuerst
+func (r *MongoAccidentClaimRepository) Find(filter model.AccidentClaimFilter) ([]*model.AccidentClaim, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.DriverID != nil {
+		query["driverId"] = *filter.DriverID
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+	if filter.From != nil || filter.To != nil {
+		dateQuery := bson.M{}
+		if filter.From != nil {
+			dateQuery["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			dateQuery["$lte"] = *filter.To
+		}
+		query["incidentDate"] = dateQuery
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "incidentDate", Value: -1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var claims []*model.AccidentClaim
+	if err = cursor.All(ctx, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
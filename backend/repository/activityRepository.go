@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// ActivityRepository enthält alle Datenbankoperationen für das Activity-Modell
-type ActivityRepository struct {
+// MongoActivityRepository enthält alle Datenbankoperationen für das Activity-Modell
+type MongoActivityRepository struct {
 	collection *mongo.Collection
 }
 
-// NewActivityRepository erstellt ein neues ActivityRepository
-func NewActivityRepository() *ActivityRepository {
-	return &ActivityRepository{
+// NewMongoActivityRepository erstellt ein neues MongoActivityRepository
+func NewMongoActivityRepository() *MongoActivityRepository {
+	return &MongoActivityRepository{
 		collection: db.GetCollection("activities"),
 	}
 }
 
 // Create erstellt eine neue Aktivität
-func (r *ActivityRepository) Create(activity *model.Activity) error {
+func (r *MongoActivityRepository) Create(activity *model.Activity) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -45,7 +45,7 @@ func (r *ActivityRepository) Create(activity *model.Activity) error {
 }
 
 // FindByID findet eine Aktivität anhand ihrer ID
-func (r *ActivityRepository) FindByID(id string) (*model.Activity, error) {
+func (r *MongoActivityRepository) FindByID(id string) (*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -64,7 +64,7 @@ func (r *ActivityRepository) FindByID(id string) (*model.Activity, error) {
 }
 
 // FindAll findet alle Aktivitäten
-func (r *ActivityRepository) FindAll(limit int, skip int) ([]*model.Activity, error) {
+func (r *MongoActivityRepository) FindAll(limit int, skip int) ([]*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -96,7 +96,7 @@ func (r *ActivityRepository) FindAll(limit int, skip int) ([]*model.Activity, er
 }
 
 // FindByVehicle findet alle Aktivitäten für ein bestimmtes Fahrzeug
-func (r *ActivityRepository) FindByVehicle(vehicleID string, limit int, skip int) ([]*model.Activity, error) {
+func (r *MongoActivityRepository) FindByVehicle(vehicleID string, limit int, skip int) ([]*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -133,7 +133,7 @@ func (r *ActivityRepository) FindByVehicle(vehicleID string, limit int, skip int
 }
 
 // FindByDriver findet alle Aktivitäten für einen bestimmten Fahrer
-func (r *ActivityRepository) FindByDriver(driverID string, limit int, skip int) ([]*model.Activity, error) {
+func (r *MongoActivityRepository) FindByDriver(driverID string, limit int, skip int) ([]*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -170,7 +170,7 @@ func (r *ActivityRepository) FindByDriver(driverID string, limit int, skip int)
 }
 
 // FindByType findet alle Aktivitäten eines bestimmten Typs
-func (r *ActivityRepository) FindByType(activityType model.ActivityType, limit int, skip int) ([]*model.Activity, error) {
+func (r *MongoActivityRepository) FindByType(activityType model.ActivityType, limit int, skip int) ([]*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -202,7 +202,7 @@ func (r *ActivityRepository) FindByType(activityType model.ActivityType, limit i
 }
 
 // FindByDateRange findet alle Aktivitäten in einem bestimmten Zeitraum
-func (r *ActivityRepository) FindByDateRange(start time.Time, end time.Time, limit int, skip int) ([]*model.Activity, error) {
+func (r *MongoActivityRepository) FindByDateRange(start time.Time, end time.Time, limit int, skip int) ([]*model.Activity, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoBulkImportRepository enthält alle Datenbankoperationen für den Massenimport von Fahrzeugen und Fahrern
+type MongoBulkImportRepository struct {
+	batchCollection *mongo.Collection
+	rowCollection   *mongo.Collection
+}
+
+// NewMongoBulkImportRepository erstellt ein neues MongoBulkImportRepository
+func NewMongoBulkImportRepository() *MongoBulkImportRepository {
+	r := &MongoBulkImportRepository{
+		batchCollection: db.GetCollection("bulk_import_batches"),
+		rowCollection:   db.GetCollection("bulk_import_rows"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.batchCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für bulk_import_batches konnten nicht erstellt werden: %v", err)
+	}
+	_, err = r.rowCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "batchId", Value: 1}, {Key: "rowNumber", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für bulk_import_rows konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// CreateBatch legt einen Import mit allen Zeilen an
+func (r *MongoBulkImportRepository) CreateBatch(batch *model.BulkImportBatch, rows []*model.BulkImportRow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	batch.ID = primitive.NewObjectID()
+	batch.CreatedAt = now
+	batch.UpdatedAt = now
+
+	if _, err := r.batchCollection.InsertOne(ctx, batch); err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		row.ID = primitive.NewObjectID()
+		row.BatchID = batch.ID
+		row.CreatedAt = now
+		row.UpdatedAt = now
+		docs = append(docs, row)
+	}
+
+	if _, err := r.rowCollection.InsertMany(ctx, docs); err != nil {
+		// Import ohne Zeilen nicht stehen lassen
+		_, _ = r.batchCollection.DeleteOne(ctx, bson.M{"_id": batch.ID})
+		_, _ = r.rowCollection.DeleteMany(ctx, bson.M{"batchId": batch.ID})
+		return err
+	}
+	return nil
+}
+
+// UpdateBatch aktualisiert Zuordnung, Status und Zähler eines Imports
+func (r *MongoBulkImportRepository) UpdateBatch(batch *model.BulkImportBatch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	batch.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"mapping":     batch.Mapping,
+			"status":      batch.Status,
+			"counts":      batch.Counts,
+			"queuedAt":    batch.QueuedAt,
+			"completedAt": batch.CompletedAt,
+			"updatedAt":   batch.UpdatedAt,
+		},
+	}
+
+	result, err := r.batchCollection.UpdateOne(ctx, bson.M{"_id": batch.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindBatchByID findet einen Import anhand seiner ID
+func (r *MongoBulkImportRepository) FindBatchByID(id string) (*model.BulkImportBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch model.BulkImportBatch
+	if err := r.batchCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// FindRecentBatches findet die neuesten Importe
+func (r *MongoBulkImportRepository) FindRecentBatches(limit int) ([]*model.BulkImportBatch, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit))
+	return r.findBatches(bson.M{}, opts)
+}
+
+// FindBatchesByStatus findet alle Importe mit einem der Status, älteste zuerst
+func (r *MongoBulkImportRepository) FindBatchesByStatus(statuses ...model.BulkImportStatus) ([]*model.BulkImportBatch, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	return r.findBatches(bson.M{"status": bson.M{"$in": statuses}}, opts)
+}
+
+// findBatches führt eine Importabfrage aus und dekodiert alle Treffer
+func (r *MongoBulkImportRepository) findBatches(query bson.M, opts *options.FindOptions) ([]*model.BulkImportBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.batchCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var batches []*model.BulkImportBatch
+	if err = cursor.All(ctx, &batches); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// UpdateRow aktualisiert Werte, Prüfergebnis und angelegten Datensatz einer Zeile
+func (r *MongoBulkImportRepository) UpdateRow(row *model.BulkImportRow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	row.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"values":    row.Values,
+			"status":    row.Status,
+			"errors":    row.Errors,
+			"message":   row.Message,
+			"recordId":  row.RecordID,
+			"updatedAt": row.UpdatedAt,
+		},
+	}
+
+	result, err := r.rowCollection.UpdateOne(ctx, bson.M{"_id": row.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindRowsByBatch findet alle Zeilen eines Imports in Dateireihenfolge
+func (r *MongoBulkImportRepository) FindRowsByBatch(batchID primitive.ObjectID) ([]*model.BulkImportRow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "rowNumber", Value: 1}})
+	cursor, err := r.rowCollection.Find(ctx, bson.M{"batchId": batchID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []*model.BulkImportRow
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCalendarFeedTokenRepository enthält alle Datenbankoperationen für Kalender-Abonnements
+type MongoCalendarFeedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCalendarFeedTokenRepository erstellt ein neues MongoCalendarFeedTokenRepository
+func NewMongoCalendarFeedTokenRepository() *MongoCalendarFeedTokenRepository {
+	return &MongoCalendarFeedTokenRepository{
+		collection: db.GetCollection("calendar_feed_tokens"),
+	}
+}
+
+// Create erstellt ein neues Kalender-Abonnement
+func (r *MongoCalendarFeedTokenRepository) Create(token *model.CalendarFeedToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	token.CreatedAt = time.Now()
+	token.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	token.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID findet ein Kalender-Abonnement anhand seiner ID
+func (r *MongoCalendarFeedTokenRepository) FindByID(id string) (*model.CalendarFeedToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var token model.CalendarFeedToken
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// FindByToken findet ein Kalender-Abonnement anhand des geheimen Tokens
+func (r *MongoCalendarFeedTokenRepository) FindByToken(token string) (*model.CalendarFeedToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var feedToken model.CalendarFeedToken
+	err := r.collection.FindOne(ctx, bson.M{"token": token}).Decode(&feedToken)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Unbekannter Token
+		}
+		return nil, err
+	}
+
+	return &feedToken, nil
+}
+
+// FindByUser findet alle Kalender-Abonnements eines Benutzers, neueste zuerst
+func (r *MongoCalendarFeedTokenRepository) FindByUser(userID primitive.ObjectID) ([]*model.CalendarFeedToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []*model.CalendarFeedToken
+	if err = cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Revoke widerruft ein Kalender-Abonnement
+func (r *MongoCalendarFeedTokenRepository) Revoke(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"revokedAt": now, "updatedAt": now}}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// MarkAccessed speichert den Zeitpunkt des letzten Feed-Abrufs
+func (r *MongoCalendarFeedTokenRepository) MarkAccessed(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"lastAccessedAt": time.Now()}}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoChargingSessionRepository enthält alle Datenbankoperationen für Ladevorgänge
+type MongoChargingSessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoChargingSessionRepository erstellt ein neues MongoChargingSessionRepository
+func NewMongoChargingSessionRepository() *MongoChargingSessionRepository {
+	r := &MongoChargingSessionRepository{
+		collection: db.GetCollection("charging_sessions"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "startTime", Value: 1}}},
+		{Keys: bson.D{{Key: "reimbursement.status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für charging_sessions konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt einen neuen Ladevorgang an
+func (r *MongoChargingSessionRepository) Create(session *model.ChargingSession) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	session.ID = primitive.NewObjectID()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, session)
+	return err
+}
+
+// Update aktualisiert einen Ladevorgang einschließlich des Erstattungsantrags
+func (r *MongoChargingSessionRepository) Update(session *model.ChargingSession) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	session.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"vehicleId":     session.VehicleID,
+			"driverId":      session.DriverID,
+			"startTime":     session.StartTime,
+			"endTime":       session.EndTime,
+			"energyKWh":     session.EnergyKWh,
+			"chargePointId": session.ChargePointID,
+			"locationType":  session.LocationType,
+			"location":      session.Location,
+			"tariff":        session.Tariff,
+			"pricePerKWh":   session.PricePerKWh,
+			"cost":          session.Cost,
+			"mileage":       session.Mileage,
+			"notes":         session.Notes,
+			"reimbursement": session.Reimbursement,
+			"updatedAt":     session.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": session.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht einen Ladevorgang
+func (r *MongoChargingSessionRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindByID findet einen Ladevorgang anhand seiner ID
+func (r *MongoChargingSessionRepository) FindByID(id string) (*model.ChargingSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var session model.ChargingSession
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByVehicle findet alle Ladevorgänge eines Fahrzeugs
+func (r *MongoChargingSessionRepository) FindByVehicle(vehicleID string) ([]*model.ChargingSession, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Find(model.ChargingSessionFilter{VehicleID: &objID})
+}
+
+// Find findet alle Ladevorgänge zum Filter, sortiert nach Beginn
+func (r *MongoChargingSessionRepository) Find(filter model.ChargingSessionFilter) ([]*model.ChargingSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.DriverID != nil {
+		query["driverId"] = *filter.DriverID
+	}
+	if filter.From != nil || filter.To != nil {
+		timeRange := bson.M{}
+		if filter.From != nil {
+			timeRange["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			timeRange["$lt"] = *filter.To
+		}
+		query["startTime"] = timeRange
+	}
+	if filter.LocationType != "" {
+		query["locationType"] = filter.LocationType
+	}
+	if filter.ReimbursementStatus != model.ReimbursementNone {
+		query["reimbursement.status"] = filter.ReimbursementStatus
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*model.ChargingSession
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
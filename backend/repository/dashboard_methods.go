@@ -10,9 +10,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// Add these methods to the VehicleRepository
+// Add these methods to the MongoVehicleRepository
 
-func (r *VehicleRepository) CountByStatus(status model.VehicleStatus) (int64, error) {
+func (r *MongoVehicleRepository) CountByStatus(status model.VehicleStatus) (int64, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -20,7 +20,7 @@ func (r *VehicleRepository) CountByStatus(status model.VehicleStatus) (int64, er
 	return count, err
 }
 
-func (r *VehicleRepository) FindAllWithLimit(limit int) ([]*model.Vehicle, error) {
+func (r *MongoVehicleRepository) FindAllWithLimit(limit int) ([]*model.Vehicle, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -49,7 +49,7 @@ func (r *VehicleRepository) FindAllWithLimit(limit int) ([]*model.Vehicle, error
 }
 
 // Zusätzliche Methode um zu prüfen ob Fahrzeuge existieren
-func (r *VehicleRepository) HasAnyVehicles() (bool, error) {
+func (r *MongoVehicleRepository) HasAnyVehicles() (bool, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -60,9 +60,9 @@ func (r *VehicleRepository) HasAnyVehicles() (bool, error) {
 	return count > 0, nil
 }
 
-// Add these methods to the DriverRepository
+// Add these methods to the MongoDriverRepository
 
-func (r *DriverRepository) FindAllWithLimit(limit int) ([]*model.Driver, error) {
+func (r *MongoDriverRepository) FindAllWithLimit(limit int) ([]*model.Driver, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -91,7 +91,7 @@ func (r *DriverRepository) FindAllWithLimit(limit int) ([]*model.Driver, error)
 }
 
 // Zusätzliche Methode um zu prüfen ob Fahrer existieren
-func (r *DriverRepository) HasAnyDrivers() (bool, error) {
+func (r *MongoDriverRepository) HasAnyDrivers() (bool, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -102,9 +102,9 @@ func (r *DriverRepository) HasAnyDrivers() (bool, error) {
 	return count > 0, nil
 }
 
-// Add these methods to the MaintenanceRepository
+// Add these methods to the MongoMaintenanceRepository
 
-func (r *MaintenanceRepository) FindUpcomingWithLimit(endDate time.Time, limit int) ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindUpcomingWithLimit(endDate time.Time, limit int) ([]*model.Maintenance, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -140,7 +140,7 @@ func (r *MaintenanceRepository) FindUpcomingWithLimit(endDate time.Time, limit i
 	return maintenanceEntries, nil
 }
 
-func (r *MaintenanceRepository) FindRecentWithLimit(limit int) ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindRecentWithLimit(limit int) ([]*model.Maintenance, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -170,7 +170,7 @@ func (r *MaintenanceRepository) FindRecentWithLimit(limit int) ([]*model.Mainten
 }
 
 // Zusätzliche Methode um zu prüfen ob Wartungseinträge existieren
-func (r *MaintenanceRepository) HasAnyMaintenance() (bool, error) {
+func (r *MongoMaintenanceRepository) HasAnyMaintenance() (bool, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -181,9 +181,9 @@ func (r *MaintenanceRepository) HasAnyMaintenance() (bool, error) {
 	return count > 0, nil
 }
 
-// Add these methods to the VehicleUsageRepository
+// Add these methods to the MongoVehicleUsageRepository
 
-func (r *VehicleUsageRepository) FindRecentWithLimit(limit int) ([]*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindRecentWithLimit(limit int) ([]*model.VehicleUsage, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -213,7 +213,7 @@ func (r *VehicleUsageRepository) FindRecentWithLimit(limit int) ([]*model.Vehicl
 }
 
 // Zusätzliche Methode um zu prüfen ob Nutzungseinträge existieren
-func (r *VehicleUsageRepository) HasAnyUsage() (bool, error) {
+func (r *MongoVehicleUsageRepository) HasAnyUsage() (bool, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -225,7 +225,7 @@ func (r *VehicleUsageRepository) HasAnyUsage() (bool, error) {
 }
 
 // Methode um aktive Nutzungen zu zählen
-func (r *VehicleUsageRepository) CountActiveUsage() (int64, error) {
+func (r *MongoVehicleUsageRepository) CountActiveUsage() (int64, error) {
 	ctx, cancel := r.getContext()
 	defer cancel()
 
@@ -236,18 +236,18 @@ func (r *VehicleUsageRepository) CountActiveUsage() (int64, error) {
 }
 
 // Add to existing repositories a helper method for context creation
-func (r *VehicleRepository) getContext() (context.Context, context.CancelFunc) {
+func (r *MongoVehicleRepository) getContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), 10*time.Second)
 }
 
-func (r *DriverRepository) getContext() (context.Context, context.CancelFunc) {
+func (r *MongoDriverRepository) getContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), 10*time.Second)
 }
 
-func (r *MaintenanceRepository) getContext() (context.Context, context.CancelFunc) {
+func (r *MongoMaintenanceRepository) getContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), 10*time.Second)
 }
 
-func (r *VehicleUsageRepository) getContext() (context.Context, context.CancelFunc) {
+func (r *MongoVehicleUsageRepository) getContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), 10*time.Second)
 }
@@ -0,0 +1,8 @@
+package repository
+
+import "time"
+
+// startOfDay liefert den Beginn des Kalendertags in UTC, in der Datumsfelder ohne Uhrzeit gespeichert werden
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// DriverDocumentRepository enthält alle Datenbankoperationen für Fahrerdokumente
-type DriverDocumentRepository struct {
+// MongoDriverDocumentRepository enthält alle Datenbankoperationen für Fahrerdokumente
+type MongoDriverDocumentRepository struct {
 	collection *mongo.Collection
 }
 
-// NewDriverDocumentRepository erstellt ein neues DriverDocumentRepository
-func NewDriverDocumentRepository() *DriverDocumentRepository {
-	return &DriverDocumentRepository{
+// NewMongoDriverDocumentRepository erstellt ein neues MongoDriverDocumentRepository
+func NewMongoDriverDocumentRepository() *MongoDriverDocumentRepository {
+	return &MongoDriverDocumentRepository{
 		collection: db.GetCollection("driver_documents"),
 	}
 }
 
 // Create erstellt ein neues Fahrerdokument
-func (r *DriverDocumentRepository) Create(document *model.DriverDocument) error {
+func (r *MongoDriverDocumentRepository) Create(document *model.DriverDocument) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *DriverDocumentRepository) Create(document *model.DriverDocument) error
 }
 
 // FindByID findet ein Dokument anhand seiner ID
-func (r *DriverDocumentRepository) FindByID(id string) (*model.DriverDocument, error) {
+func (r *MongoDriverDocumentRepository) FindByID(id string) (*model.DriverDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *DriverDocumentRepository) FindByID(id string) (*model.DriverDocument, e
 }
 
 // FindByDriver findet alle Dokumente für einen Fahrer
-func (r *DriverDocumentRepository) FindByDriver(driverID string) ([]*model.DriverDocument, error) {
+func (r *MongoDriverDocumentRepository) FindByDriver(driverID string) ([]*model.DriverDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -92,7 +92,7 @@ func (r *DriverDocumentRepository) FindByDriver(driverID string) ([]*model.Drive
 }
 
 // FindByDriverAndType findet alle Dokumente eines bestimmten Typs für einen Fahrer
-func (r *DriverDocumentRepository) FindByDriverAndType(driverID string, docType model.DriverDocumentType) ([]*model.DriverDocument, error) {
+func (r *MongoDriverDocumentRepository) FindByDriverAndType(driverID string, docType model.DriverDocumentType) ([]*model.DriverDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -126,7 +126,7 @@ func (r *DriverDocumentRepository) FindByDriverAndType(driverID string, docType
 }
 
 // Update aktualisiert ein Dokument (ohne Datei-Daten)
-func (r *DriverDocumentRepository) Update(document *model.DriverDocument) error {
+func (r *MongoDriverDocumentRepository) Update(document *model.DriverDocument) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -155,7 +155,7 @@ func (r *DriverDocumentRepository) Update(document *model.DriverDocument) error
 }
 
 // Delete löscht ein Dokument
-func (r *DriverDocumentRepository) Delete(id string) error {
+func (r *MongoDriverDocumentRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -169,7 +169,7 @@ func (r *DriverDocumentRepository) Delete(id string) error {
 }
 
 // CountByDriver zählt die Anzahl der Dokumente für einen Fahrer
-func (r *DriverDocumentRepository) CountByDriver(driverID string) (int64, error) {
+func (r *MongoDriverDocumentRepository) CountByDriver(driverID string) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -186,17 +186,18 @@ func (r *DriverDocumentRepository) CountByDriver(driverID string) (int64, error)
 	return count, nil
 }
 
-// FindExpiringLicenses findet alle Führerscheine, die in den nächsten 30 Tagen ablaufen
-func (r *DriverDocumentRepository) FindExpiringLicenses(days int) ([]*model.DriverDocument, error) {
+// FindExpiringLicenses findet alle Führerscheine, die ab heute in den nächsten Tagen ablaufen
+func (r *MongoDriverDocumentRepository) FindExpiringLicenses(days int) ([]*model.DriverDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	expiryThreshold := time.Now().AddDate(0, 0, days)
+	today := startOfDay(time.Now())
+	expiryThreshold := today.AddDate(0, 0, days)
 
 	filter := bson.M{
 		"type": model.DriverDocumentTypeLicense,
 		"expiryDate": bson.M{
-			"$gte": time.Now(),
+			"$gte": today,
 			"$lte": expiryThreshold,
 		},
 	}
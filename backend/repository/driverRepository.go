@@ -12,22 +12,23 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// DriverRepository enthält alle Datenbankoperationen für das Driver-Modell
-type DriverRepository struct {
+// MongoDriverRepository enthält alle Datenbankoperationen für das Driver-Modell
+type MongoDriverRepository struct {
 	collection *mongo.Collection
 }
 
-// NewDriverRepository erstellt ein neues DriverRepository
-func NewDriverRepository() *DriverRepository {
-	return &DriverRepository{
+// NewMongoDriverRepository erstellt ein neues MongoDriverRepository
+func NewMongoDriverRepository() *MongoDriverRepository {
+	return &MongoDriverRepository{
 		collection: db.GetCollection("drivers"),
 	}
 }
 
 // Create erstellt einen neuen Fahrer
-func (r *DriverRepository) Create(driver *model.Driver) error {
+func (r *MongoDriverRepository) Create(driver *model.Driver) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +45,7 @@ func (r *DriverRepository) Create(driver *model.Driver) error {
 }
 
 // FindByID findet einen Fahrer anhand seiner ID
-func (r *DriverRepository) FindByID(id string) (*model.Driver, error) {
+func (r *MongoDriverRepository) FindByID(id string) (*model.Driver, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +64,7 @@ func (r *DriverRepository) FindByID(id string) (*model.Driver, error) {
 }
 
 // FindByEmail findet einen Fahrer anhand seiner E-Mail
-func (r *DriverRepository) FindByEmail(email string) (*model.Driver, error) {
+func (r *MongoDriverRepository) FindByEmail(email string) (*model.Driver, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -77,7 +78,7 @@ func (r *DriverRepository) FindByEmail(email string) (*model.Driver, error) {
 }
 
 // FindAll findet alle Fahrer
-func (r *DriverRepository) FindAll() ([]*model.Driver, error) {
+func (r *MongoDriverRepository) FindAll() ([]*model.Driver, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -104,7 +105,7 @@ func (r *DriverRepository) FindAll() ([]*model.Driver, error) {
 }
 
 // FindByStatus findet alle Fahrer mit einem bestimmten Status
-func (r *DriverRepository) FindByStatus(status model.DriverStatus) ([]*model.Driver, error) {
+func (r *MongoDriverRepository) FindByStatus(status model.DriverStatus) ([]*model.Driver, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -130,8 +131,40 @@ func (r *DriverRepository) FindByStatus(status model.DriverStatus) ([]*model.Dri
 	return drivers, nil
 }
 
+// FindPage findet eine Seite Fahrer (leerer Status = alle), sortiert nach Anlage
+func (r *MongoDriverRepository) FindPage(status model.DriverStatus, limit int, skip int) ([]*model.Driver, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var drivers []*model.Driver
+	for cursor.Next(ctx) {
+		var driver model.Driver
+		if err := cursor.Decode(&driver); err != nil {
+			return nil, err
+		}
+		drivers = append(drivers, &driver)
+	}
+
+	return drivers, cursor.Err()
+}
+
 // FindByVehicle findet den Fahrer, dem ein bestimmtes Fahrzeug zugewiesen ist
-func (r *DriverRepository) FindByVehicle(vehicleID primitive.ObjectID) (*model.Driver, error) {
+func (r *MongoDriverRepository) FindByVehicle(vehicleID primitive.ObjectID) (*model.Driver, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -145,14 +178,14 @@ func (r *DriverRepository) FindByVehicle(vehicleID primitive.ObjectID) (*model.D
 }
 
 // Update aktualisiert einen Fahrer
-func (r *DriverRepository) Update(driver *model.Driver) error {
+func (r *MongoDriverRepository) Update(driver *model.Driver) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	driver.UpdatedAt = time.Now()
 
 	// Debug-Ausgabe vor Update
-	fmt.Printf("=== DriverRepository.Update DEBUG ===\n")
+	fmt.Printf("=== MongoDriverRepository.Update DEBUG ===\n")
 	fmt.Printf("Updating driver ID: %s\n", driver.ID.Hex())
 	fmt.Printf("AssignedVehicleID: %s\n", driver.AssignedVehicleID.Hex())
 	fmt.Printf("Status: %s\n", driver.Status)
@@ -161,14 +194,17 @@ func (r *DriverRepository) Update(driver *model.Driver) error {
 	// SCHRITT 1: Alle Felder außer assignedVehicleId
 	updateDoc := bson.M{
 		"$set": bson.M{
-			"firstName":      driver.FirstName,
-			"lastName":       driver.LastName,
-			"email":          driver.Email,
-			"phone":          driver.Phone,
-			"status":         driver.Status,
-			"licenseClasses": driver.LicenseClasses,
-			"notes":          driver.Notes,
-			"updatedAt":      driver.UpdatedAt,
+			"firstName":             driver.FirstName,
+			"lastName":              driver.LastName,
+			"email":                 driver.Email,
+			"phone":                 driver.Phone,
+			"status":                driver.Status,
+			"licenseClasses":        driver.LicenseClasses,
+			"notes":                 driver.Notes,
+			"benefitMethod":         driver.BenefitMethod,
+			"commuteDistanceKm":     driver.CommuteDistanceKm,
+			"commuteDailyValuation": driver.CommuteDailyValuation,
+			"updatedAt":             driver.UpdatedAt,
 		},
 	}
 
@@ -259,7 +295,7 @@ func (r *DriverRepository) Update(driver *model.Driver) error {
 }
 
 // Delete löscht einen Fahrer
-func (r *DriverRepository) Delete(id string) error {
+func (r *MongoDriverRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoExpiryReminderRepository verwaltet versendete Ablauferinnerungen und deren Einstellungen
+type MongoExpiryReminderRepository struct {
+	collection         *mongo.Collection
+	settingsCollection *mongo.Collection
+}
+
+// NewMongoExpiryReminderRepository erstellt ein neues MongoExpiryReminderRepository
+func NewMongoExpiryReminderRepository() *MongoExpiryReminderRepository {
+	return &MongoExpiryReminderRepository{
+		collection:         db.GetCollection("expiry_reminders"),
+		settingsCollection: db.GetCollection("expiry_reminder_settings"),
+	}
+}
+
+// Create speichert eine versendete Erinnerung
+func (r *MongoExpiryReminderRepository) Create(reminder *model.ExpiryReminder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, reminder)
+	if err != nil {
+		return err
+	}
+
+	reminder.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// HasBeenSent prüft, ob die Erinnerungsstufe für dieses Ablaufdatum bereits an den Empfänger ging
+func (r *MongoExpiryReminderRepository) HasBeenSent(subjectType model.ExpirySubjectType, subjectID primitive.ObjectID, expiryDate time.Time, offsetDays int, recipient string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"subjectType": subjectType,
+		"subjectId":   subjectID,
+		"expiryDate":  expiryDate,
+		"offsetDays":  offsetDays,
+		"recipient":   recipient,
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// FindRecent findet die zuletzt versendeten Erinnerungen
+func (r *MongoExpiryReminderRepository) FindRecent(limit int) ([]*model.ExpiryReminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "sentAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reminders []*model.ExpiryReminder
+	if err = cursor.All(ctx, &reminders); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// GetSettings holt die Einstellungen der Ablauferinnerungen
+func (r *MongoExpiryReminderRepository) GetSettings() (*model.ExpiryReminderSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var settings model.ExpiryReminderSettings
+	err := r.settingsCollection.FindOne(ctx, bson.M{}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Standardeinstellungen zurückgeben
+			return defaultExpiryReminderSettings(), nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SaveSettings speichert oder aktualisiert die Einstellungen der Ablauferinnerungen
+func (r *MongoExpiryReminderRepository) SaveSettings(settings *model.ExpiryReminderSettings) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	// Es gibt nur einen Einstellungsdatensatz
+	update := bson.M{
+		"$set": bson.M{
+			"enabled":        settings.Enabled,
+			"offsetsDays":    settings.OffsetsDays,
+			"notifyDrivers":  settings.NotifyDrivers,
+			"notifyManagers": settings.NotifyManagers,
+			"updatedAt":      now,
+		},
+		"$setOnInsert": bson.M{
+			"createdAt": now,
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	result, err := r.settingsCollection.UpdateOne(ctx, bson.M{}, update, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.UpsertedID != nil {
+		settings.ID = result.UpsertedID.(primitive.ObjectID)
+	}
+
+	return nil
+}
+
+// defaultExpiryReminderSettings liefert die Standardeinstellungen, solange nichts gespeichert wurde
+func defaultExpiryReminderSettings() *model.ExpiryReminderSettings {
+	return &model.ExpiryReminderSettings{
+		Enabled:        true,
+		OffsetsDays:    append([]int(nil), model.DefaultExpiryReminderOffsets...),
+		NotifyDrivers:  true,
+		NotifyManagers: true,
+	}
+}
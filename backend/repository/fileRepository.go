@@ -0,0 +1,54 @@
+// backend/repository/fileRepository.go
+package repository
+
+import (
+	"io"
+
+	"FleetFlow/backend/db"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// MongoFileRepository legt Binärdateien in GridFS ab
+type MongoFileRepository struct{}
+
+// NewMongoFileRepository erstellt ein neues MongoFileRepository
+func NewMongoFileRepository() *MongoFileRepository {
+	return &MongoFileRepository{}
+}
+
+// Upload speichert den Inhalt von source unter dem angegebenen Namen
+func (r *MongoFileRepository) Upload(name string, source io.Reader) (primitive.ObjectID, error) {
+	bucket, err := r.bucket()
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return bucket.UploadFromStream(name, source)
+}
+
+// Open öffnet eine gespeicherte Datei zum Lesen
+func (r *MongoFileRepository) Open(id primitive.ObjectID) (io.ReadCloser, error) {
+	bucket, err := r.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.OpenDownloadStream(id)
+}
+
+// Delete löscht eine gespeicherte Datei
+func (r *MongoFileRepository) Delete(id primitive.ObjectID) error {
+	bucket, err := r.bucket()
+	if err != nil {
+		return err
+	}
+
+	return bucket.Delete(id)
+}
+
+// bucket liefert den GridFS-Bucket der FleetFlow-Datenbank
+func (r *MongoFileRepository) bucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(db.DBClient.Database("FleetFlow"))
+}
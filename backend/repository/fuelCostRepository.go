@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// FuelCostRepository enthält alle Datenbankoperationen für das FuelCost-Modell
-type FuelCostRepository struct {
+// MongoFuelCostRepository enthält alle Datenbankoperationen für das FuelCost-Modell
+type MongoFuelCostRepository struct {
 	collection *mongo.Collection
 }
 
-// NewFuelCostRepository erstellt ein neues FuelCostRepository
-func NewFuelCostRepository() *FuelCostRepository {
-	return &FuelCostRepository{
+// NewMongoFuelCostRepository erstellt ein neues MongoFuelCostRepository
+func NewMongoFuelCostRepository() *MongoFuelCostRepository {
+	return &MongoFuelCostRepository{
 		collection: db.GetCollection("fuelCosts"),
 	}
 }
 
 // Create erstellt einen neuen Tankkosteneintrag
-func (r *FuelCostRepository) Create(fuelCost *model.FuelCost) error {
+func (r *MongoFuelCostRepository) Create(fuelCost *model.FuelCost) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *FuelCostRepository) Create(fuelCost *model.FuelCost) error {
 }
 
 // FindByID findet einen Tankkosteneintrag anhand seiner ID
-func (r *FuelCostRepository) FindByID(id string) (*model.FuelCost, error) {
+func (r *MongoFuelCostRepository) FindByID(id string) (*model.FuelCost, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *FuelCostRepository) FindByID(id string) (*model.FuelCost, error) {
 }
 
 // FindAll findet alle Tankkosteneinträge
-func (r *FuelCostRepository) FindAll() ([]*model.FuelCost, error) {
+func (r *MongoFuelCostRepository) FindAll() ([]*model.FuelCost, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -93,8 +93,37 @@ func (r *FuelCostRepository) FindAll() ([]*model.FuelCost, error) {
 	return fuelCosts, nil
 }
 
+// FindPage findet eine Seite Tankkosteneinträge, neueste zuerst
+func (r *MongoFuelCostRepository) FindPage(limit int, skip int) ([]*model.FuelCost, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var fuelCosts []*model.FuelCost
+	for cursor.Next(ctx) {
+		var fuelCost model.FuelCost
+		if err := cursor.Decode(&fuelCost); err != nil {
+			return nil, err
+		}
+		fuelCosts = append(fuelCosts, &fuelCost)
+	}
+
+	return fuelCosts, cursor.Err()
+}
+
 // FindByVehicle findet alle Tankkosteneinträge für ein bestimmtes Fahrzeug
-func (r *FuelCostRepository) FindByVehicle(vehicleID string) ([]*model.FuelCost, error) {
+func (r *MongoFuelCostRepository) FindByVehicle(vehicleID string) ([]*model.FuelCost, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -129,7 +158,7 @@ func (r *FuelCostRepository) FindByVehicle(vehicleID string) ([]*model.FuelCost,
 }
 
 // Update aktualisiert einen Tankkosteneintrag
-func (r *FuelCostRepository) Update(fuelCost *model.FuelCost) error {
+func (r *MongoFuelCostRepository) Update(fuelCost *model.FuelCost) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -144,7 +173,7 @@ func (r *FuelCostRepository) Update(fuelCost *model.FuelCost) error {
 }
 
 // Delete löscht einen Tankkosteneintrag
-func (r *FuelCostRepository) Delete(id string) error {
+func (r *MongoFuelCostRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -159,7 +188,7 @@ func (r *FuelCostRepository) Delete(id string) error {
 
 // HasAnyFuelCosts prüft ob überhaupt Tankkosten-Einträge vorhanden sind
 
-func (r *FuelCostRepository) HasAnyFuelCosts() (bool, error) {
+func (r *MongoFuelCostRepository) HasAnyFuelCosts() (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -171,7 +200,7 @@ func (r *FuelCostRepository) HasAnyFuelCosts() (bool, error) {
 }
 
 // FindByDateRange findet Tankkosten in einem bestimmten Zeitraum
-func (r *FuelCostRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.FuelCost, error) {
+func (r *MongoFuelCostRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.FuelCost, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
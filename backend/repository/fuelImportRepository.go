@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoFuelImportRepository enthält alle Datenbankoperationen für den Tankkartenimport
+type MongoFuelImportRepository struct {
+	providerCollection *mongo.Collection
+	batchCollection    *mongo.Collection
+	rowCollection      *mongo.Collection
+}
+
+// NewMongoFuelImportRepository erstellt ein neues MongoFuelImportRepository
+func NewMongoFuelImportRepository() *MongoFuelImportRepository {
+	r := &MongoFuelImportRepository{
+		providerCollection: db.GetCollection("fuel_card_providers"),
+		batchCollection:    db.GetCollection("fuel_import_batches"),
+		rowCollection:      db.GetCollection("fuel_import_rows"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.rowCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "batchId", Value: 1}, {Key: "rowNumber", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für fuel_import_rows konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// CreateProvider legt ein neues Anbieterformat an
+func (r *MongoFuelImportRepository) CreateProvider(provider *model.FuelCardProvider) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	provider.ID = primitive.NewObjectID()
+	provider.CreatedAt = now
+	provider.UpdatedAt = now
+
+	_, err := r.providerCollection.InsertOne(ctx, provider)
+	return err
+}
+
+// UpdateProvider aktualisiert ein Anbieterformat
+func (r *MongoFuelImportRepository) UpdateProvider(provider *model.FuelCardProvider) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	provider.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         provider.Name,
+			"delimiter":    provider.Delimiter,
+			"dateFormat":   provider.DateFormat,
+			"timeFormat":   provider.TimeFormat,
+			"decimalComma": provider.DecimalComma,
+			"skipRows":     provider.SkipRows,
+			"columns":      provider.Columns,
+			"products":     provider.Products,
+			"updatedAt":    provider.UpdatedAt,
+		},
+	}
+
+	result, err := r.providerCollection.UpdateOne(ctx, bson.M{"_id": provider.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteProvider löscht ein Anbieterformat
+func (r *MongoFuelImportRepository) DeleteProvider(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.providerCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindProviderByID findet ein Anbieterformat anhand seiner ID
+func (r *MongoFuelImportRepository) FindProviderByID(id string) (*model.FuelCardProvider, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var provider model.FuelCardProvider
+	if err := r.providerCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// FindAllProviders findet alle Anbieterformate, sortiert nach Name
+func (r *MongoFuelImportRepository) FindAllProviders() ([]*model.FuelCardProvider, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.providerCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var providers []*model.FuelCardProvider
+	if err = cursor.All(ctx, &providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// CreateBatch legt einen Import mit allen Zeilen an
+func (r *MongoFuelImportRepository) CreateBatch(batch *model.FuelImportBatch, rows []*model.FuelImportRow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	batch.ID = primitive.NewObjectID()
+	batch.CreatedAt = now
+	batch.UpdatedAt = now
+
+	if _, err := r.batchCollection.InsertOne(ctx, batch); err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		row.ID = primitive.NewObjectID()
+		row.BatchID = batch.ID
+		row.CreatedAt = now
+		row.UpdatedAt = now
+		docs = append(docs, row)
+	}
+
+	if _, err := r.rowCollection.InsertMany(ctx, docs); err != nil {
+		// Import ohne Zeilen nicht stehen lassen
+		_, _ = r.batchCollection.DeleteOne(ctx, bson.M{"_id": batch.ID})
+		_, _ = r.rowCollection.DeleteMany(ctx, bson.M{"batchId": batch.ID})
+		return err
+	}
+	return nil
+}
+
+// UpdateBatch aktualisiert Status und Zähler eines Imports
+func (r *MongoFuelImportRepository) UpdateBatch(batch *model.FuelImportBatch) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	batch.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":      batch.Status,
+			"counts":      batch.Counts,
+			"committedAt": batch.CommittedAt,
+			"updatedAt":   batch.UpdatedAt,
+		},
+	}
+
+	result, err := r.batchCollection.UpdateOne(ctx, bson.M{"_id": batch.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// TransitionBatch setzt den Status eines Imports nur, wenn er noch den Status from hat,
+// und meldet, ob der Wechsel stattgefunden hat
+func (r *MongoFuelImportRepository) TransitionBatch(id primitive.ObjectID, from, to model.FuelImportBatchStatus) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"status": to, "updatedAt": time.Now()}}
+
+	result, err := r.batchCollection.UpdateOne(ctx, bson.M{"_id": id, "status": from}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// FindBatchByID findet einen Import anhand seiner ID
+func (r *MongoFuelImportRepository) FindBatchByID(id string) (*model.FuelImportBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch model.FuelImportBatch
+	if err := r.batchCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// FindRecentBatches findet die neuesten Importe
+func (r *MongoFuelImportRepository) FindRecentBatches(limit int) ([]*model.FuelImportBatch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.batchCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var batches []*model.FuelImportBatch
+	if err = cursor.All(ctx, &batches); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// UpdateRow aktualisiert Status und Zuordnung einer Zeile
+func (r *MongoFuelImportRepository) UpdateRow(row *model.FuelImportRow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	row.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     row.Status,
+			"message":    row.Message,
+			"fuelType":   row.FuelType,
+			"vehicleId":  row.VehicleID,
+			"fuelCostId": row.FuelCostID,
+			"updatedAt":  row.UpdatedAt,
+		},
+	}
+
+	result, err := r.rowCollection.UpdateOne(ctx, bson.M{"_id": row.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindRowByID findet eine Zeile anhand ihrer ID
+func (r *MongoFuelImportRepository) FindRowByID(id string) (*model.FuelImportRow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var row model.FuelImportRow
+	if err := r.rowCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// FindRowsByBatch findet alle Zeilen eines Imports in Dateireihenfolge
+func (r *MongoFuelImportRepository) FindRowsByBatch(batchID primitive.ObjectID) ([]*model.FuelImportRow, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "rowNumber", Value: 1}})
+	return r.findRows(bson.M{"batchId": batchID}, opts)
+}
+
+// FindRowsByStatus findet alle Zeilen eines Status, älteste zuerst
+func (r *MongoFuelImportRepository) FindRowsByStatus(status model.FuelImportRowStatus) ([]*model.FuelImportRow, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "rowNumber", Value: 1}})
+	return r.findRows(bson.M{"status": status}, opts)
+}
+
+// findRows führt eine Zeilenabfrage aus und dekodiert alle Treffer
+func (r *MongoFuelImportRepository) findRows(query bson.M, opts *options.FindOptions) ([]*model.FuelImportRow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.rowCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []*model.FuelImportRow
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
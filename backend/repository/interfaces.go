@@ -0,0 +1,404 @@
+// backend/repository/interfaces.go
+package repository
+
+import (
+	"io"
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VehicleRepository beschreibt alle Datenbankoperationen für Fahrzeuge
+type VehicleRepository interface {
+	Create(vehicle *model.Vehicle) error
+	FindByID(id string) (*model.Vehicle, error)
+	FindByLicensePlate(licensePlate string) (*model.Vehicle, error)
+	FindAll() ([]*model.Vehicle, error)
+	FindAllWithLimit(limit int) ([]*model.Vehicle, error)
+	FindByStatus(status model.VehicleStatus) ([]*model.Vehicle, error)
+	FindPage(status model.VehicleStatus, limit int, skip int) ([]*model.Vehicle, error)
+	Update(vehicle *model.Vehicle) error
+	Delete(id string) error
+	CountByStatus(status model.VehicleStatus) (int64, error)
+	CountByStatusAndDate(status model.VehicleStatus, date time.Time) (int64, error)
+	HasAnyVehicles() (bool, error)
+}
+
+// DriverRepository beschreibt alle Datenbankoperationen für Fahrer
+type DriverRepository interface {
+	Create(driver *model.Driver) error
+	FindByID(id string) (*model.Driver, error)
+	FindByEmail(email string) (*model.Driver, error)
+	FindAll() ([]*model.Driver, error)
+	FindAllWithLimit(limit int) ([]*model.Driver, error)
+	FindByStatus(status model.DriverStatus) ([]*model.Driver, error)
+	FindPage(status model.DriverStatus, limit int, skip int) ([]*model.Driver, error)
+	FindByVehicle(vehicleID primitive.ObjectID) (*model.Driver, error)
+	Update(driver *model.Driver) error
+	Delete(id string) error
+	HasAnyDrivers() (bool, error)
+}
+
+// DriverDocumentRepository beschreibt alle Datenbankoperationen für Fahrerdokumente
+type DriverDocumentRepository interface {
+	Create(document *model.DriverDocument) error
+	FindByID(id string) (*model.DriverDocument, error)
+	FindByDriver(driverID string) ([]*model.DriverDocument, error)
+	FindByDriverAndType(driverID string, docType model.DriverDocumentType) ([]*model.DriverDocument, error)
+	Update(document *model.DriverDocument) error
+	Delete(id string) error
+	CountByDriver(driverID string) (int64, error)
+	FindExpiringLicenses(days int) ([]*model.DriverDocument, error)
+}
+
+// VehicleDocumentRepository beschreibt alle Datenbankoperationen für Fahrzeugdokumente
+type VehicleDocumentRepository interface {
+	Create(document *model.VehicleDocument) error
+	FindByID(id string) (*model.VehicleDocument, error)
+	FindByVehicle(vehicleID string) ([]*model.VehicleDocument, error)
+	FindByVehicleAndType(vehicleID string, docType model.DocumentType) ([]*model.VehicleDocument, error)
+	Update(document *model.VehicleDocument) error
+	Delete(id string) error
+	FindExpiring(days int) ([]*model.VehicleDocument, error)
+	CountByVehicle(vehicleID string) (int64, error)
+}
+
+// FuelCostRepository beschreibt alle Datenbankoperationen für Tankkosten
+type FuelCostRepository interface {
+	Create(fuelCost *model.FuelCost) error
+	FindByID(id string) (*model.FuelCost, error)
+	FindAll() ([]*model.FuelCost, error)
+	FindPage(limit int, skip int) ([]*model.FuelCost, error)
+	FindByVehicle(vehicleID string) ([]*model.FuelCost, error)
+	FindByDateRange(startDate, endDate time.Time) ([]*model.FuelCost, error)
+	Update(fuelCost *model.FuelCost) error
+	Delete(id string) error
+	HasAnyFuelCosts() (bool, error)
+}
+
+// MaintenancePlanRepository beschreibt alle Datenbankoperationen für Wartungspläne und ihre Zyklen
+type MaintenancePlanRepository interface {
+	CreatePlan(plan *model.MaintenancePlan) error
+	UpdatePlan(plan *model.MaintenancePlan) error
+	DeletePlan(id string) error
+	FindPlanByID(id string) (*model.MaintenancePlan, error)
+	FindAllPlans() ([]*model.MaintenancePlan, error)
+	CreateDue(due *model.MaintenanceDue) error
+	UpdateDue(due *model.MaintenanceDue) error
+	FindDueByID(id string) (*model.MaintenanceDue, error)
+	FindDues(filter model.MaintenanceDueFilter) ([]*model.MaintenanceDue, error)
+	DeleteDuesByPlan(planID primitive.ObjectID) error
+}
+
+// MaintenanceRepository beschreibt alle Datenbankoperationen für Wartungseinträge
+type MaintenanceRepository interface {
+	Create(maintenance *model.Maintenance) error
+	FindByID(id string) (*model.Maintenance, error)
+	FindAll() ([]*model.Maintenance, error)
+	FindPage(limit int, skip int) ([]*model.Maintenance, error)
+	FindByVehicle(vehicleID string) ([]*model.Maintenance, error)
+	FindByDateRange(startDate, endDate time.Time) ([]*model.Maintenance, error)
+	FindUpcoming(fromDate time.Time, toDate time.Time) ([]*model.Maintenance, error)
+	FindUpcomingWithLimit(endDate time.Time, limit int) ([]*model.Maintenance, error)
+	FindRecentWithLimit(limit int) ([]*model.Maintenance, error)
+	Update(maintenance *model.Maintenance) error
+	Delete(id string) error
+	HasAnyMaintenance() (bool, error)
+}
+
+// VehicleUsageRepository beschreibt alle Datenbankoperationen für Fahrzeugnutzungen
+type VehicleUsageRepository interface {
+	Create(usage *model.VehicleUsage) error
+	FindByID(id string) (*model.VehicleUsage, error)
+	FindAll() ([]*model.VehicleUsage, error)
+	FindPage(limit int, skip int) ([]*model.VehicleUsage, error)
+	FindByVehicle(vehicleID string) ([]*model.VehicleUsage, error)
+	FindByDriver(driverID string) ([]*model.VehicleUsage, error)
+	FindActiveUsage(vehicleID string) (*model.VehicleUsage, error)
+	FindRecentWithLimit(limit int) ([]*model.VehicleUsage, error)
+	Update(usage *model.VehicleUsage) error
+	Delete(id string) error
+	CountActiveUsage() (int64, error)
+	CountActiveUsagesByDateRange(startDate, endDate time.Time) (int64, error)
+	HasAnyUsage() (bool, error)
+}
+
+// FuelImportRepository beschreibt alle Datenbankoperationen für den Tankkartenimport
+type FuelImportRepository interface {
+	CreateProvider(provider *model.FuelCardProvider) error
+	UpdateProvider(provider *model.FuelCardProvider) error
+	DeleteProvider(id string) error
+	FindProviderByID(id string) (*model.FuelCardProvider, error)
+	FindAllProviders() ([]*model.FuelCardProvider, error)
+	CreateBatch(batch *model.FuelImportBatch, rows []*model.FuelImportRow) error
+	UpdateBatch(batch *model.FuelImportBatch) error
+	TransitionBatch(id primitive.ObjectID, from, to model.FuelImportBatchStatus) (bool, error)
+	FindBatchByID(id string) (*model.FuelImportBatch, error)
+	FindRecentBatches(limit int) ([]*model.FuelImportBatch, error)
+	UpdateRow(row *model.FuelImportRow) error
+	FindRowByID(id string) (*model.FuelImportRow, error)
+	FindRowsByBatch(batchID primitive.ObjectID) ([]*model.FuelImportRow, error)
+	FindRowsByStatus(status model.FuelImportRowStatus) ([]*model.FuelImportRow, error)
+}
+
+// BulkImportRepository beschreibt alle Datenbankoperationen für den Massenimport von Fahrzeugen und Fahrern
+type BulkImportRepository interface {
+	CreateBatch(batch *model.BulkImportBatch, rows []*model.BulkImportRow) error
+	UpdateBatch(batch *model.BulkImportBatch) error
+	FindBatchByID(id string) (*model.BulkImportBatch, error)
+	FindRecentBatches(limit int) ([]*model.BulkImportBatch, error)
+	FindBatchesByStatus(statuses ...model.BulkImportStatus) ([]*model.BulkImportBatch, error)
+	UpdateRow(row *model.BulkImportRow) error
+	FindRowsByBatch(batchID primitive.ObjectID) ([]*model.BulkImportRow, error)
+}
+
+// ChargingSessionRepository beschreibt alle Datenbankoperationen für Ladevorgänge
+type ChargingSessionRepository interface {
+	Create(session *model.ChargingSession) error
+	Update(session *model.ChargingSession) error
+	Delete(id string) error
+	FindByID(id string) (*model.ChargingSession, error)
+	FindByVehicle(vehicleID string) ([]*model.ChargingSession, error)
+	Find(filter model.ChargingSessionFilter) ([]*model.ChargingSession, error)
+}
+
+// LogbookRepository beschreibt alle Datenbankoperationen für das Fahrtenbuch
+type LogbookRepository interface {
+	Create(entry *model.LogbookEntry) error
+	Update(entry *model.LogbookEntry) error
+	Supersede(current *model.LogbookEntry, correction *model.LogbookEntry) error
+	FindCurrent(entryID primitive.ObjectID) (*model.LogbookEntry, error)
+	FindVersions(entryID primitive.ObjectID) ([]*model.LogbookEntry, error)
+	FindBySequence(vehicleID primitive.ObjectID, sequence int) (*model.LogbookEntry, error)
+	FindLast(vehicleID primitive.ObjectID) (*model.LogbookEntry, error)
+	Find(filter model.LogbookFilter) ([]*model.LogbookEntry, error)
+}
+
+// VehicleAssignmentRepository beschreibt alle Datenbankoperationen für die Zuweisungshistorie
+type VehicleAssignmentRepository interface {
+	Create(assignment *model.VehicleAssignment) error
+	Update(assignment *model.VehicleAssignment) error
+	FindByDriverID(driverID string) ([]*model.VehicleAssignment, error)
+	FindByVehicleID(vehicleID string) ([]*model.VehicleAssignment, error)
+	FindActiveAssignmentByDriver(driverID string) (*model.VehicleAssignment, error)
+	CloseAssignment(assignmentID primitive.ObjectID, unassignedAt time.Time) error
+	FindRecentAssignments(limit int) ([]*model.VehicleAssignment, error)
+}
+
+// VehicleReservationRepository beschreibt alle Datenbankoperationen für Reservierungen
+type VehicleReservationRepository interface {
+	Create(reservation *model.VehicleReservation) error
+	FindByID(id string) (*model.VehicleReservation, error)
+	FindAll() ([]model.VehicleReservation, error)
+	FindPage(includeCompleted bool, limit int, skip int) ([]model.VehicleReservation, error)
+	FindByVehicleID(vehicleID string) ([]model.VehicleReservation, error)
+	FindByDriverID(driverID string) ([]model.VehicleReservation, error)
+	FindBySeriesID(seriesID string) ([]model.VehicleReservation, error)
+	FindActiveReservations() ([]model.VehicleReservation, error)
+	FindUpcomingReservations(hours int) ([]model.VehicleReservation, error)
+	CheckConflict(vehicleID string, startTime, endTime time.Time, excludeID *string) (bool, error)
+	CheckConflictDetails(vehicleID string, startTime, endTime time.Time, excludeID *string) (*ConflictDetails, error)
+	Update(reservation *model.VehicleReservation) error
+	Delete(id string) error
+}
+
+// ReservationSeriesRepository beschreibt alle Datenbankoperationen für Reservierungsserien
+type ReservationSeriesRepository interface {
+	Create(series *model.ReservationSeries) error
+	FindByID(id string) (*model.ReservationSeries, error)
+	FindAll() ([]model.ReservationSeries, error)
+	Update(series *model.ReservationSeries) error
+	Delete(id string) error
+}
+
+// ReservationHandoverRepository beschreibt alle Datenbankoperationen für Übergabeprotokolle
+type ReservationHandoverRepository interface {
+	Create(handover *model.ReservationHandover) error
+	FindByID(id string) (*model.ReservationHandover, error)
+	FindByReservation(reservationID string) ([]*model.ReservationHandover, error)
+	FindByReservationAndType(reservationID string, handoverType model.HandoverType) (*model.ReservationHandover, error)
+	FindByVehicle(vehicleID string) ([]*model.ReservationHandover, error)
+	Update(handover *model.ReservationHandover) error
+}
+
+// CalendarFeedTokenRepository beschreibt alle Datenbankoperationen für Kalender-Abonnements
+type CalendarFeedTokenRepository interface {
+	Create(token *model.CalendarFeedToken) error
+	FindByID(id string) (*model.CalendarFeedToken, error)
+	FindByToken(token string) (*model.CalendarFeedToken, error)
+	FindByUser(userID primitive.ObjectID) ([]*model.CalendarFeedToken, error)
+	Revoke(id primitive.ObjectID) error
+	MarkAccessed(id primitive.ObjectID) error
+}
+
+// TireRepository beschreibt alle Datenbankoperationen für Reifensätze, Reifenvorgaben und Wechselaktionen
+type TireRepository interface {
+	CreateSet(set *model.TireSet) error
+	UpdateSet(set *model.TireSet) error
+	DeleteSet(id string) error
+	FindSetByID(id string) (*model.TireSet, error)
+	FindSets(filter model.TireSetFilter) ([]*model.TireSet, error)
+	GetSettings() (*model.TireSettings, error)
+	SaveSettings(settings *model.TireSettings) error
+	CreateCampaign(campaign *model.TireSwapCampaign) error
+	UpdateCampaign(campaign *model.TireSwapCampaign) error
+	FindCampaignByID(id string) (*model.TireSwapCampaign, error)
+	FindCampaigns() ([]*model.TireSwapCampaign, error)
+}
+
+// TrafficFineRepository beschreibt alle Datenbankoperationen für Bußgelder
+type TrafficFineRepository interface {
+	Create(fine *model.TrafficFine) error
+	Update(fine *model.TrafficFine) error
+	Delete(id string) error
+	FindByID(id string) (*model.TrafficFine, error)
+	Find(filter model.TrafficFineFilter) ([]*model.TrafficFine, error)
+}
+
+// AccidentClaimRepository beschreibt alle Datenbankoperationen für Schadenfälle
+type AccidentClaimRepository interface {
+	Create(claim *model.AccidentClaim) error
+	Update(claim *model.AccidentClaim) error
+	Delete(id string) error
+	FindByID(id string) (*model.AccidentClaim, error)
+	FindByReport(reportID primitive.ObjectID) (*model.AccidentClaim, error)
+	Find(filter model.AccidentClaimFilter) ([]*model.AccidentClaim, error)
+}
+
+// LeaseReturnRepository beschreibt alle Datenbankoperationen für Leasingrückgaben
+type LeaseReturnRepository interface {
+	Create(leaseReturn *model.LeaseReturn) error
+	Update(leaseReturn *model.LeaseReturn) error
+	Delete(id string) error
+	FindByID(id string) (*model.LeaseReturn, error)
+	FindByVehicleAndEndDate(vehicleID primitive.ObjectID, leaseEndDate time.Time) (*model.LeaseReturn, error)
+	FindByStatus(status model.LeaseReturnStatus) ([]*model.LeaseReturn, error)
+}
+
+// WorkOrderRepository beschreibt alle Datenbankoperationen für Werkstattaufträge
+type WorkOrderRepository interface {
+	Create(order *model.WorkOrder) error
+	Update(order *model.WorkOrder) error
+	FindByID(id string) (*model.WorkOrder, error)
+	Find(filter model.WorkOrderFilter) ([]*model.WorkOrder, error)
+}
+
+// VehicleReportRepository beschreibt alle Datenbankoperationen für Fahrzeugmeldungen
+type VehicleReportRepository interface {
+	Create(report *model.VehicleReport) error
+	FindByID(id primitive.ObjectID) (*model.VehicleReport, error)
+	FindByReporter(reporterID primitive.ObjectID, limit int) ([]*model.VehicleReport, error)
+	FindByVehicle(vehicleID primitive.ObjectID) ([]*model.VehicleReport, error)
+	FindByStatus(status model.ReportStatus) ([]*model.VehicleReport, error)
+	FindAll(page, limit int) ([]*model.VehicleReport, error)
+	FindPage(status model.ReportStatus, limit int, skip int) ([]*model.VehicleReport, error)
+	FindUrgent() ([]*model.VehicleReport, error)
+	Update(id primitive.ObjectID, update bson.M) error
+	UpdateStatus(id primitive.ObjectID, status model.ReportStatus, updatedBy primitive.ObjectID) error
+	AssignTo(id primitive.ObjectID, assignedTo primitive.ObjectID) error
+	Resolve(id primitive.ObjectID, resolvedBy primitive.ObjectID, resolution string) error
+	Delete(id primitive.ObjectID) error
+	CountByStatus(status model.ReportStatus) (int64, error)
+	CountByReporter(reporterID primitive.ObjectID) (int64, error)
+	GetStatistics() (map[string]interface{}, error)
+}
+
+// ActivityRepository beschreibt alle Datenbankoperationen für Aktivitäten
+type ActivityRepository interface {
+	Create(activity *model.Activity) error
+	FindByID(id string) (*model.Activity, error)
+	FindAll(limit int, skip int) ([]*model.Activity, error)
+	FindByVehicle(vehicleID string, limit int, skip int) ([]*model.Activity, error)
+	FindByDriver(driverID string, limit int, skip int) ([]*model.Activity, error)
+	FindByType(activityType model.ActivityType, limit int, skip int) ([]*model.Activity, error)
+	FindByDateRange(start time.Time, end time.Time, limit int, skip int) ([]*model.Activity, error)
+}
+
+// UserRepository beschreibt alle Datenbankoperationen für Benutzer
+type UserRepository interface {
+	Create(user *model.User) error
+	FindByID(id string) (*model.User, error)
+	FindByEmail(email string) (*model.User, error)
+	FindByUsername(username string) (*model.User, error)
+	FindAll() ([]*model.User, error)
+	GetAll() ([]*model.User, error)
+	GetUsersWithFilter(filter bson.M) ([]*model.User, error)
+	GetUserByEmail(email string) (*model.User, error)
+	Update(user *model.User) error
+	Delete(id string) error
+	Count() (int64, error)
+	CreateAdminUserIfNotExists() error
+}
+
+// SMTPRepository beschreibt alle Datenbankoperationen für SMTP-Konfiguration, Vorlagen, Logs und Benachrichtigungseinstellungen
+type SMTPRepository interface {
+	SaveSMTPConfig(config *model.SMTPConfig) error
+	GetSMTPConfig() (*model.SMTPConfig, error)
+	TestSMTPConnection(config *model.SMTPConfig) error
+	SaveEmailTemplate(template *model.EmailTemplate) error
+	GetEmailTemplate(templateType model.EmailTemplateType) (*model.EmailTemplate, error)
+	GetAllEmailTemplates() ([]*model.EmailTemplate, error)
+	CreateDefaultEmailTemplates() error
+	LogEmail(log *model.EmailLog) error
+	GetEmailLogs(limit, offset int) ([]*model.EmailLog, error)
+	GetEmailLogsByStatus(status model.EmailStatus, limit, offset int) ([]*model.EmailLog, error)
+	GetEmailLog(id string) (*model.EmailLog, error)
+	ClaimNextEmail(now, lockUntil time.Time) (*model.EmailLog, error)
+	MarkEmailSent(id primitive.ObjectID, sentAt time.Time) error
+	MarkEmailFailed(id primitive.ObjectID, errorMessage string, retryAt *time.Time) error
+	RequeueEmail(id primitive.ObjectID, at time.Time) (bool, error)
+	SaveNotificationSettings(settings *model.NotificationSettings) error
+	GetNotificationSettings(userID primitive.ObjectID) (*model.NotificationSettings, error)
+}
+
+// ExpiryReminderRepository beschreibt alle Datenbankoperationen für Ablauferinnerungen und deren Einstellungen
+type ExpiryReminderRepository interface {
+	Create(reminder *model.ExpiryReminder) error
+	HasBeenSent(subjectType model.ExpirySubjectType, subjectID primitive.ObjectID, expiryDate time.Time, offsetDays int, recipient string) (bool, error)
+	FindRecent(limit int) ([]*model.ExpiryReminder, error)
+	GetSettings() (*model.ExpiryReminderSettings, error)
+	SaveSettings(settings *model.ExpiryReminderSettings) error
+}
+
+// PeopleFlowRepository beschreibt alle Datenbankoperationen für die PeopleFlow-Integration
+type PeopleFlowRepository interface {
+	GetIntegration() (*model.PeopleFlowIntegration, error)
+	SaveIntegration(integration *model.PeopleFlowIntegration) error
+	UpdateIntegrationStatus(isActive bool, lastSync time.Time, status string, syncedEmployees int) error
+	SaveEmployee(employee *model.PeopleFlowEmployee) error
+	FindEmployeeByEmail(email string) (*model.PeopleFlowEmployee, error)
+	FindEmployeeByPeopleFlowID(peopleFlowID string) (*model.PeopleFlowEmployee, error)
+	FindAllEmployees() ([]*model.PeopleFlowEmployee, error)
+	FindDriverEligibleEmployees() ([]*model.PeopleFlowEmployee, error)
+	UpdateEmployeeSyncStatus(email string, status string) error
+	DeleteEmployee(email string) error
+	GetEmployeeCount() (int64, error)
+	CreateSyncLog(syncLog *model.PeopleFlowSyncLog) error
+	UpdateSyncLog(syncLog *model.PeopleFlowSyncLog) error
+	FindRecentSyncLogs(limit int) ([]*model.PeopleFlowSyncLog, error)
+}
+
+// ScheduledJobRepository beschreibt alle Datenbankoperationen für Hintergrundjobs und deren Laufhistorie
+type ScheduledJobRepository interface {
+	Create(job *model.ScheduledJob) error
+	FindByName(name string) (*model.ScheduledJob, error)
+	FindAll() ([]*model.ScheduledJob, error)
+	UpdateDefinition(name, description, schedule string, nextRunAt time.Time) error
+	SetPaused(name string, paused bool) error
+	AcquireLease(name, owner string, until time.Time, dueBefore *time.Time) (bool, error)
+	RenewLease(name, owner string, until time.Time) (bool, error)
+	ReleaseLease(name, owner string, nextRunAt time.Time) error
+	CompleteRun(name, owner string, run *model.JobRun, nextRunAt time.Time) error
+	CreateRun(run *model.JobRun) error
+	FindRuns(name string, limit int) ([]*model.JobRun, error)
+}
+
+// FileRepository beschreibt die Ablage von Binärdateien wie Profilbildern
+type FileRepository interface {
+	Upload(name string, source io.Reader) (primitive.ObjectID, error)
+	Open(id primitive.ObjectID) (io.ReadCloser, error)
+	Delete(id primitive.ObjectID) error
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoLeaseReturnRepository enthält alle Datenbankoperationen für Leasingrückgaben
+type MongoLeaseReturnRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLeaseReturnRepository erstellt ein neues MongoLeaseReturnRepository
+func NewMongoLeaseReturnRepository() *MongoLeaseReturnRepository {
+	r := &MongoLeaseReturnRepository{
+		collection: db.GetCollection("lease_returns"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "leaseEndDate", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "leaseEndDate", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für lease_returns konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt eine neue Leasingrückgabe an
+func (r *MongoLeaseReturnRepository) Create(leaseReturn *model.LeaseReturn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	leaseReturn.ID = primitive.NewObjectID()
+	leaseReturn.CreatedAt = now
+	leaseReturn.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, leaseReturn)
+	return err
+}
+
+// Update aktualisiert eine Leasingrückgabe
+func (r *MongoLeaseReturnRepository) Update(leaseReturn *model.LeaseReturn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	leaseReturn.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"leaseCompany":     leaseReturn.LeaseCompany,
+			"contractNumber":   leaseReturn.ContractNumber,
+			"appointmentDate":  leaseReturn.AppointmentDate,
+			"items":            leaseReturn.Items,
+			"forecastExcessKm": leaseReturn.ForecastExcessKm,
+			"returnMileage":    leaseReturn.ReturnMileage,
+			"excessKm":         leaseReturn.ExcessKm,
+			"settlementAmount": leaseReturn.SettlementAmount,
+			"notes":            leaseReturn.Notes,
+			"status":           leaseReturn.Status,
+			"completedAt":      leaseReturn.CompletedAt,
+			"completedBy":      leaseReturn.CompletedBy,
+			"updatedAt":        leaseReturn.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": leaseReturn.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht eine Leasingrückgabe
+func (r *MongoLeaseReturnRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindByID findet eine Leasingrückgabe anhand ihrer ID
+func (r *MongoLeaseReturnRepository) FindByID(id string) (*model.LeaseReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaseReturn model.LeaseReturn
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&leaseReturn); err != nil {
+		return nil, err
+	}
+	return &leaseReturn, nil
+}
+
+// FindByVehicleAndEndDate findet die Rückgabe zu einem Leasingvertrag eines Fahrzeugs
+func (r *MongoLeaseReturnRepository) FindByVehicleAndEndDate(vehicleID primitive.ObjectID, leaseEndDate time.Time) (*model.LeaseReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var leaseReturn model.LeaseReturn
+	if err := r.collection.FindOne(ctx, bson.M{"vehicleId": vehicleID, "leaseEndDate": leaseEndDate}).Decode(&leaseReturn); err != nil {
+		return nil, err
+	}
+	return &leaseReturn, nil
+}
+
+// FindByStatus findet alle Leasingrückgaben mit dem Status (leer = alle), die nächsten Rückgaben zuerst
+func (r *MongoLeaseReturnRepository) FindByStatus(status model.LeaseReturnStatus) ([]*model.LeaseReturn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if status != "" {
+		query["status"] = status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "leaseEndDate", Value: 1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var leaseReturns []*model.LeaseReturn
+	if err = cursor.All(ctx, &leaseReturns); err != nil {
+		return nil, err
+	}
+	return leaseReturns, nil
+}
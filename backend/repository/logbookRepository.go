@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoLogbookRepository enthält alle Datenbankoperationen für das Fahrtenbuch
+type MongoLogbookRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLogbookRepository erstellt ein neues MongoLogbookRepository
+func NewMongoLogbookRepository() *MongoLogbookRepository {
+	r := &MongoLogbookRepository{
+		collection: db.GetCollection("logbook_entries"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// Jede Position der Kilometerkette eines Fahrzeugs darf nur einen aktuellen Eintrag haben
+			Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "sequence", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"current": true}),
+		},
+		{
+			Keys:    bson.D{{Key: "entryId", Value: 1}, {Key: "version", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für logbook_entries konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt die erste Version eines Fahrtenbucheintrags an
+func (r *MongoLogbookRepository) Create(entry *model.LogbookEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	entry.ID = primitive.NewObjectID()
+	entry.EntryID = entry.ID
+	entry.Version = 1
+	entry.Current = true
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// Update ändert die aktuelle Version eines Eintrags innerhalb der Bearbeitungsfrist
+func (r *MongoLogbookRepository) Update(entry *model.LogbookEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	entry.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"driverId":        entry.DriverID,
+			"date":            entry.Date,
+			"startMileage":    entry.StartMileage,
+			"endMileage":      entry.EndMileage,
+			"route":           entry.Route,
+			"businessPartner": entry.BusinessPartner,
+			"purpose":         entry.Purpose,
+			"tripType":        entry.TripType,
+			"updatedAt":       entry.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": entry.ID, "current": true}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Supersede ersetzt die aktuelle Version durch eine Korrekturversion; die alte Version bleibt erhalten
+func (r *MongoLogbookRepository) Supersede(current *model.LogbookEntry, correction *model.LogbookEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	correction.ID = primitive.NewObjectID()
+	correction.EntryID = current.EntryID
+	correction.Version = current.Version + 1
+	correction.Current = true
+	correction.CreatedAt = now
+	correction.UpdatedAt = now
+
+	// Alte Version zuerst ablösen, damit der eindeutige Index auf die Kettenposition greift
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": current.ID, "current": true},
+		bson.M{"$set": bson.M{"current": false, "supersededAt": now, "supersededBy": correction.ID}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	if _, err := r.collection.InsertOne(ctx, correction); err != nil {
+		// Ablösung rückgängig machen, damit der Eintrag nicht verloren geht
+		_, _ = r.collection.UpdateOne(ctx,
+			bson.M{"_id": current.ID},
+			bson.M{"$set": bson.M{"current": true}, "$unset": bson.M{"supersededAt": "", "supersededBy": ""}},
+		)
+		return err
+	}
+
+	current.Current = false
+	current.SupersededAt = &now
+	current.SupersededBy = &correction.ID
+	return nil
+}
+
+// FindCurrent findet die aktuelle Version eines Eintrags
+func (r *MongoLogbookRepository) FindCurrent(entryID primitive.ObjectID) (*model.LogbookEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var entry model.LogbookEntry
+	err := r.collection.FindOne(ctx, bson.M{"entryId": entryID, "current": true}).Decode(&entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// FindVersions findet alle Versionen eines Eintrags, älteste zuerst
+func (r *MongoLogbookRepository) FindVersions(entryID primitive.ObjectID) ([]*model.LogbookEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	return r.find(bson.M{"entryId": entryID}, opts)
+}
+
+// FindBySequence findet den aktuellen Eintrag an einer Position der Kilometerkette
+func (r *MongoLogbookRepository) FindBySequence(vehicleID primitive.ObjectID, sequence int) (*model.LogbookEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var entry model.LogbookEntry
+	err := r.collection.FindOne(ctx, bson.M{"vehicleId": vehicleID, "sequence": sequence, "current": true}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// FindLast findet den letzten aktuellen Eintrag der Kilometerkette eines Fahrzeugs
+func (r *MongoLogbookRepository) FindLast(vehicleID primitive.ObjectID) (*model.LogbookEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}})
+
+	var entry model.LogbookEntry
+	err := r.collection.FindOne(ctx, bson.M{"vehicleId": vehicleID, "current": true}, opts).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Find findet aktuelle Einträge nach Filter, sortiert nach Datum und Kettenposition
+func (r *MongoLogbookRepository) Find(filter model.LogbookFilter) ([]*model.LogbookEntry, error) {
+	query := bson.M{"current": true}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.DriverID != nil {
+		query["driverId"] = *filter.DriverID
+	}
+	if filter.From != nil || filter.To != nil {
+		dateRange := bson.M{}
+		if filter.From != nil {
+			dateRange["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			dateRange["$lt"] = *filter.To
+		}
+		query["date"] = dateRange
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "sequence", Value: 1}})
+	return r.find(query, opts)
+}
+
+// find führt eine Abfrage aus und dekodiert alle Treffer
+func (r *MongoLogbookRepository) find(query bson.M, opts *options.FindOptions) ([]*model.LogbookEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.LogbookEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMaintenancePlanRepository enthält alle Datenbankoperationen für Wartungspläne und ihre Zyklen
+type MongoMaintenancePlanRepository struct {
+	planCollection *mongo.Collection
+	dueCollection  *mongo.Collection
+}
+
+// NewMongoMaintenancePlanRepository erstellt ein neues MongoMaintenancePlanRepository
+func NewMongoMaintenancePlanRepository() *MongoMaintenancePlanRepository {
+	r := &MongoMaintenancePlanRepository{
+		planCollection: db.GetCollection("maintenance_plans"),
+		dueCollection:  db.GetCollection("maintenance_dues"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.dueCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "planId", Value: 1}, {Key: "vehicleId", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "type", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für maintenance_dues konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// CreatePlan legt einen neuen Wartungsplan an
+func (r *MongoMaintenancePlanRepository) CreatePlan(plan *model.MaintenancePlan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	plan.ID = primitive.NewObjectID()
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+
+	_, err := r.planCollection.InsertOne(ctx, plan)
+	return err
+}
+
+// UpdatePlan aktualisiert einen Wartungsplan
+func (r *MongoMaintenancePlanRepository) UpdatePlan(plan *model.MaintenancePlan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	plan.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":           plan.Name,
+			"type":           plan.Type,
+			"vehicleId":      plan.VehicleID,
+			"brand":          plan.Brand,
+			"model":          plan.Model,
+			"intervalKm":     plan.IntervalKm,
+			"intervalMonths": plan.IntervalMonths,
+			"leadKm":         plan.LeadKm,
+			"leadDays":       plan.LeadDays,
+			"active":         plan.Active,
+			"notes":          plan.Notes,
+			"updatedAt":      plan.UpdatedAt,
+		},
+	}
+
+	result, err := r.planCollection.UpdateOne(ctx, bson.M{"_id": plan.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeletePlan löscht einen Wartungsplan
+func (r *MongoMaintenancePlanRepository) DeletePlan(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.planCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindPlanByID findet einen Wartungsplan anhand seiner ID
+func (r *MongoMaintenancePlanRepository) FindPlanByID(id string) (*model.MaintenancePlan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan model.MaintenancePlan
+	if err := r.planCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// FindAllPlans findet alle Wartungspläne, sortiert nach Name
+func (r *MongoMaintenancePlanRepository) FindAllPlans() ([]*model.MaintenancePlan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.planCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plans []*model.MaintenancePlan
+	if err = cursor.All(ctx, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// CreateDue legt einen neuen Wartungszyklus an
+func (r *MongoMaintenancePlanRepository) CreateDue(due *model.MaintenanceDue) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	due.ID = primitive.NewObjectID()
+	due.CreatedAt = now
+	due.UpdatedAt = now
+
+	_, err := r.dueCollection.InsertOne(ctx, due)
+	return err
+}
+
+// UpdateDue aktualisiert Hochrechnung und Status eines Wartungszyklus
+func (r *MongoMaintenancePlanRepository) UpdateDue(due *model.MaintenanceDue) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	due.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"type":                   due.Type,
+			"baselineDate":           due.BaselineDate,
+			"baselineMileage":        due.BaselineMileage,
+			"baselineMaintenanceId":  due.BaselineMaintenanceID,
+			"dueMileage":             due.DueMileage,
+			"dueDateByTime":          due.DueDateByTime,
+			"dueDateByMileage":       due.DueDateByMileage,
+			"dueDate":                due.DueDate,
+			"currentMileage":         due.CurrentMileage,
+			"averageDailyKm":         due.AverageDailyKm,
+			"status":                 due.Status,
+			"completedMaintenanceId": due.CompletedMaintenanceID,
+			"completedAt":            due.CompletedAt,
+			"evaluatedAt":            due.EvaluatedAt,
+			"updatedAt":              due.UpdatedAt,
+		},
+	}
+
+	result, err := r.dueCollection.UpdateOne(ctx, bson.M{"_id": due.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindDueByID findet einen Wartungszyklus anhand seiner ID
+func (r *MongoMaintenancePlanRepository) FindDueByID(id string) (*model.MaintenanceDue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var due model.MaintenanceDue
+	if err := r.dueCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&due); err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+// FindDues findet alle Wartungszyklen zum Filter, sortiert nach Fälligkeit
+func (r *MongoMaintenancePlanRepository) FindDues(filter model.MaintenanceDueFilter) ([]*model.MaintenanceDue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.PlanID != nil {
+		query["planId"] = *filter.PlanID
+	}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "dueDate", Value: 1}, {Key: "createdAt", Value: 1}})
+	cursor, err := r.dueCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dues []*model.MaintenanceDue
+	if err = cursor.All(ctx, &dues); err != nil {
+		return nil, err
+	}
+	return dues, nil
+}
+
+// DeleteDuesByPlan löscht alle Wartungszyklen eines Plans
+func (r *MongoMaintenancePlanRepository) DeleteDuesByPlan(planID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.dueCollection.DeleteMany(ctx, bson.M{"planId": planID})
+	return err
+}
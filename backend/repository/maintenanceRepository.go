@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// MaintenanceRepository enthält alle Datenbankoperationen für das Maintenance-Modell
-type MaintenanceRepository struct {
+// MongoMaintenanceRepository enthält alle Datenbankoperationen für das Maintenance-Modell
+type MongoMaintenanceRepository struct {
 	collection *mongo.Collection
 }
 
-// NewMaintenanceRepository erstellt ein neues MaintenanceRepository
-func NewMaintenanceRepository() *MaintenanceRepository {
-	return &MaintenanceRepository{
+// NewMongoMaintenanceRepository erstellt ein neues MongoMaintenanceRepository
+func NewMongoMaintenanceRepository() *MongoMaintenanceRepository {
+	return &MongoMaintenanceRepository{
 		collection: db.GetCollection("maintenance"),
 	}
 }
 
 // Create erstellt einen neuen Wartungseintrag
-func (r *MaintenanceRepository) Create(maintenance *model.Maintenance) error {
+func (r *MongoMaintenanceRepository) Create(maintenance *model.Maintenance) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *MaintenanceRepository) Create(maintenance *model.Maintenance) error {
 }
 
 // FindByID findet einen Wartungseintrag anhand seiner ID
-func (r *MaintenanceRepository) FindByID(id string) (*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindByID(id string) (*model.Maintenance, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *MaintenanceRepository) FindByID(id string) (*model.Maintenance, error)
 }
 
 // FindAll findet alle Wartungseinträge
-func (r *MaintenanceRepository) FindAll() ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindAll() ([]*model.Maintenance, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -89,8 +89,37 @@ func (r *MaintenanceRepository) FindAll() ([]*model.Maintenance, error) {
 	return maintenances, nil
 }
 
+// FindPage findet eine Seite Wartungseinträge, sortiert nach Anlage
+func (r *MongoMaintenanceRepository) FindPage(limit int, skip int) ([]*model.Maintenance, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*model.Maintenance
+	for cursor.Next(ctx) {
+		var entry model.Maintenance
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, cursor.Err()
+}
+
 // FindByVehicle findet alle Wartungseinträge für ein bestimmtes Fahrzeug
-func (r *MaintenanceRepository) FindByVehicle(vehicleID string) ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindByVehicle(vehicleID string) ([]*model.Maintenance, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -122,7 +151,7 @@ func (r *MaintenanceRepository) FindByVehicle(vehicleID string) ([]*model.Mainte
 }
 
 // Update aktualisiert einen Wartungseintrag
-func (r *MaintenanceRepository) Update(maintenance *model.Maintenance) error {
+func (r *MongoMaintenanceRepository) Update(maintenance *model.Maintenance) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -137,7 +166,7 @@ func (r *MaintenanceRepository) Update(maintenance *model.Maintenance) error {
 }
 
 // Delete löscht einen Wartungseintrag
-func (r *MaintenanceRepository) Delete(id string) error {
+func (r *MongoMaintenanceRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -150,7 +179,7 @@ func (r *MaintenanceRepository) Delete(id string) error {
 	return err
 }
 
-func (r *MaintenanceRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.Maintenance, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -187,7 +216,7 @@ func (r *MaintenanceRepository) FindByDateRange(startDate, endDate time.Time) ([
 }
 
 // FindUpcoming findet alle geplanten Wartungseinträge ab einem bestimmten Datum
-func (r *MaintenanceRepository) FindUpcoming(fromDate time.Time, toDate time.Time) ([]*model.Maintenance, error) {
+func (r *MongoMaintenanceRepository) FindUpcoming(fromDate time.Time, toDate time.Time) ([]*model.Maintenance, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,100 @@
+// backend/repository/memoryAccidentClaimRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryAccidentClaimRepository hält Schadenfälle im Arbeitsspeicher
+type MemoryAccidentClaimRepository struct {
+	store *memoryStore[model.AccidentClaim]
+}
+
+// NewMemoryAccidentClaimRepository erstellt ein neues MemoryAccidentClaimRepository
+func NewMemoryAccidentClaimRepository() *MemoryAccidentClaimRepository {
+	return &MemoryAccidentClaimRepository{
+		store: newMemoryStore(
+			func(c *model.AccidentClaim) primitive.ObjectID { return c.ID },
+			func(c *model.AccidentClaim, id primitive.ObjectID) { c.ID = id },
+		),
+	}
+}
+
+// Create legt einen neuen Schadenfall an; je Meldung ist nur ein Schadenfall erlaubt
+func (r *MemoryAccidentClaimRepository) Create(claim *model.AccidentClaim) error {
+	if r.store.count(func(c *model.AccidentClaim) bool { return c.ReportID == claim.ReportID }) > 0 {
+		return duplicateKeyError()
+	}
+	now := time.Now()
+	claim.ID = primitive.NewObjectID()
+	claim.CreatedAt = now
+	claim.UpdatedAt = now
+	return r.store.insert(claim)
+}
+
+// Update aktualisiert einen Schadenfall
+func (r *MemoryAccidentClaimRepository) Update(claim *model.AccidentClaim) error {
+	claim.UpdatedAt = time.Now()
+	found := r.store.modify(claim.ID, func(stored *model.AccidentClaim) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *claim
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht einen Schadenfall
+func (r *MemoryAccidentClaimRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindByID findet einen Schadenfall anhand seiner ID
+func (r *MemoryAccidentClaimRepository) FindByID(id string) (*model.AccidentClaim, error) {
+	return r.store.getHex(id)
+}
+
+// FindByReport findet den Schadenfall zu einer Unfallmeldung
+func (r *MemoryAccidentClaimRepository) FindByReport(reportID primitive.ObjectID) (*model.AccidentClaim, error) {
+	return r.store.first(func(c *model.AccidentClaim) bool { return c.ReportID == reportID })
+}
+
+// Find findet alle Schadenfälle zum Filter, die jüngsten Unfälle zuerst
+func (r *MemoryAccidentClaimRepository) Find(filter model.AccidentClaimFilter) ([]*model.AccidentClaim, error) {
+	claims := r.store.filter(func(c *model.AccidentClaim) bool {
+		if filter.VehicleID != nil && c.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.DriverID != nil && (c.DriverID == nil || *c.DriverID != *filter.DriverID) {
+			return false
+		}
+		if filter.From != nil && c.IncidentDate.Before(*filter.From) {
+			return false
+		}
+		if filter.To != nil && c.IncidentDate.After(*filter.To) {
+			return false
+		}
+		if len(filter.Statuses) > 0 {
+			matched := false
+			for _, status := range filter.Statuses {
+				if c.Status == status {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	})
+	return sortItems(claims, func(a, b *model.AccidentClaim) bool { return a.IncidentDate.After(b.IncidentDate) }), nil
+}
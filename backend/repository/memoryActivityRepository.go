@@ -0,0 +1,81 @@
+// backend/repository/memoryActivityRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryActivityRepository hält Aktivitäten im Arbeitsspeicher
+type MemoryActivityRepository struct {
+	store *memoryStore[model.Activity]
+}
+
+// NewMemoryActivityRepository erstellt ein neues MemoryActivityRepository
+func NewMemoryActivityRepository() *MemoryActivityRepository {
+	return &MemoryActivityRepository{
+		store: newMemoryStore(
+			func(a *model.Activity) primitive.ObjectID { return a.ID },
+			func(a *model.Activity, id primitive.ObjectID) { a.ID = id },
+		),
+	}
+}
+
+// Create erstellt eine neue Aktivität
+func (r *MemoryActivityRepository) Create(activity *model.Activity) error {
+	if activity.Timestamp.IsZero() {
+		activity.Timestamp = time.Now()
+	}
+	return r.store.insert(activity)
+}
+
+// FindByID findet eine Aktivität anhand ihrer ID
+func (r *MemoryActivityRepository) FindByID(id string) (*model.Activity, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Aktivitäten
+func (r *MemoryActivityRepository) FindAll(limit int, skip int) ([]*model.Activity, error) {
+	return r.find(nil, limit, skip), nil
+}
+
+// FindByVehicle findet alle Aktivitäten für ein bestimmtes Fahrzeug
+func (r *MemoryActivityRepository) FindByVehicle(vehicleID string, limit int, skip int) ([]*model.Activity, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(a *model.Activity) bool { return a.VehicleID == objID }, limit, skip), nil
+}
+
+// FindByDriver findet alle Aktivitäten für einen bestimmten Fahrer
+func (r *MemoryActivityRepository) FindByDriver(driverID string, limit int, skip int) ([]*model.Activity, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(a *model.Activity) bool { return a.DriverID == objID }, limit, skip), nil
+}
+
+// FindByType findet alle Aktivitäten eines bestimmten Typs
+func (r *MemoryActivityRepository) FindByType(activityType model.ActivityType, limit int, skip int) ([]*model.Activity, error) {
+	return r.find(func(a *model.Activity) bool { return a.Type == activityType }, limit, skip), nil
+}
+
+// FindByDateRange findet alle Aktivitäten in einem bestimmten Zeitraum
+func (r *MemoryActivityRepository) FindByDateRange(start time.Time, end time.Time, limit int, skip int) ([]*model.Activity, error) {
+	return r.find(func(a *model.Activity) bool {
+		return !a.Timestamp.Before(start) && !a.Timestamp.After(end)
+	}, limit, skip), nil
+}
+
+// find liefert passende Aktivitäten absteigend nach Zeitstempel mit Paginierung
+func (r *MemoryActivityRepository) find(match func(*model.Activity) bool, limit, skip int) []*model.Activity {
+	activities := sortItems(r.store.filter(match), func(a, b *model.Activity) bool {
+		return a.Timestamp.After(b.Timestamp)
+	})
+	return pageItems(activities, skip, limit)
+}
@@ -0,0 +1,119 @@
+// backend/repository/memoryBulkImportRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryBulkImportRepository hält Massenimporte und deren Zeilen im Arbeitsspeicher
+type MemoryBulkImportRepository struct {
+	batches *memoryStore[model.BulkImportBatch]
+	rows    *memoryStore[model.BulkImportRow]
+}
+
+// NewMemoryBulkImportRepository erstellt ein neues MemoryBulkImportRepository
+func NewMemoryBulkImportRepository() *MemoryBulkImportRepository {
+	return &MemoryBulkImportRepository{
+		batches: newMemoryStore(
+			func(b *model.BulkImportBatch) primitive.ObjectID { return b.ID },
+			func(b *model.BulkImportBatch, id primitive.ObjectID) { b.ID = id },
+		),
+		rows: newMemoryStore(
+			func(r *model.BulkImportRow) primitive.ObjectID { return r.ID },
+			func(r *model.BulkImportRow, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// CreateBatch legt einen Import mit allen Zeilen an
+func (r *MemoryBulkImportRepository) CreateBatch(batch *model.BulkImportBatch, rows []*model.BulkImportRow) error {
+	now := time.Now()
+	batch.ID = primitive.NewObjectID()
+	batch.CreatedAt = now
+	batch.UpdatedAt = now
+	if err := r.batches.insert(batch); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		row.ID = primitive.NewObjectID()
+		row.BatchID = batch.ID
+		row.CreatedAt = now
+		row.UpdatedAt = now
+		if err := r.rows.insert(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateBatch aktualisiert Zuordnung, Status und Zähler eines Imports
+func (r *MemoryBulkImportRepository) UpdateBatch(batch *model.BulkImportBatch) error {
+	batch.UpdatedAt = time.Now()
+	found := r.batches.modify(batch.ID, func(stored *model.BulkImportBatch) {
+		stored.Mapping = batch.Mapping
+		stored.Status = batch.Status
+		stored.Counts = batch.Counts
+		stored.QueuedAt = batch.QueuedAt
+		stored.CompletedAt = batch.CompletedAt
+		stored.UpdatedAt = batch.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindBatchByID findet einen Import anhand seiner ID
+func (r *MemoryBulkImportRepository) FindBatchByID(id string) (*model.BulkImportBatch, error) {
+	return r.batches.getHex(id)
+}
+
+// FindRecentBatches findet die neuesten Importe
+func (r *MemoryBulkImportRepository) FindRecentBatches(limit int) ([]*model.BulkImportBatch, error) {
+	batches := sortItems(r.batches.filter(nil), func(a, b *model.BulkImportBatch) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return pageItems(batches, 0, limit), nil
+}
+
+// FindBatchesByStatus findet alle Importe mit einem der Status, älteste zuerst
+func (r *MemoryBulkImportRepository) FindBatchesByStatus(statuses ...model.BulkImportStatus) ([]*model.BulkImportBatch, error) {
+	batches := r.batches.filter(func(batch *model.BulkImportBatch) bool {
+		for _, status := range statuses {
+			if batch.Status == status {
+				return true
+			}
+		}
+		return false
+	})
+	return sortItems(batches, func(a, b *model.BulkImportBatch) bool { return a.CreatedAt.Before(b.CreatedAt) }), nil
+}
+
+// UpdateRow aktualisiert Werte, Prüfergebnis und angelegten Datensatz einer Zeile
+func (r *MemoryBulkImportRepository) UpdateRow(row *model.BulkImportRow) error {
+	row.UpdatedAt = time.Now()
+	found := r.rows.modify(row.ID, func(stored *model.BulkImportRow) {
+		stored.Values = row.Values
+		stored.Status = row.Status
+		stored.Errors = row.Errors
+		stored.Message = row.Message
+		stored.RecordID = row.RecordID
+		stored.UpdatedAt = row.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindRowsByBatch findet alle Zeilen eines Imports in Dateireihenfolge
+func (r *MemoryBulkImportRepository) FindRowsByBatch(batchID primitive.ObjectID) ([]*model.BulkImportRow, error) {
+	rows := r.rows.filter(func(row *model.BulkImportRow) bool { return row.BatchID == batchID })
+	return sortItems(rows, func(a, b *model.BulkImportRow) bool { return a.RowNumber < b.RowNumber }), nil
+}
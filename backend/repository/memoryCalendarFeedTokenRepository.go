@@ -0,0 +1,69 @@
+// backend/repository/memoryCalendarFeedTokenRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryCalendarFeedTokenRepository hält Kalender-Abonnements im Arbeitsspeicher
+type MemoryCalendarFeedTokenRepository struct {
+	store *memoryStore[model.CalendarFeedToken]
+}
+
+// NewMemoryCalendarFeedTokenRepository erstellt ein neues MemoryCalendarFeedTokenRepository
+func NewMemoryCalendarFeedTokenRepository() *MemoryCalendarFeedTokenRepository {
+	return &MemoryCalendarFeedTokenRepository{
+		store: newMemoryStore(
+			func(t *model.CalendarFeedToken) primitive.ObjectID { return t.ID },
+			func(t *model.CalendarFeedToken, id primitive.ObjectID) { t.ID = id },
+		),
+	}
+}
+
+// Create erstellt ein neues Kalender-Abonnement
+func (r *MemoryCalendarFeedTokenRepository) Create(token *model.CalendarFeedToken) error {
+	token.CreatedAt = time.Now()
+	token.UpdatedAt = time.Now()
+	return r.store.insert(token)
+}
+
+// FindByID findet ein Kalender-Abonnement anhand seiner ID
+func (r *MemoryCalendarFeedTokenRepository) FindByID(id string) (*model.CalendarFeedToken, error) {
+	return r.store.getHex(id)
+}
+
+// FindByToken findet ein Kalender-Abonnement anhand des geheimen Tokens
+func (r *MemoryCalendarFeedTokenRepository) FindByToken(token string) (*model.CalendarFeedToken, error) {
+	feedToken, err := r.store.first(func(t *model.CalendarFeedToken) bool { return t.Token == token })
+	if err != nil {
+		return nil, nil // Unbekannter Token
+	}
+	return feedToken, nil
+}
+
+// FindByUser findet alle Kalender-Abonnements eines Benutzers, neueste zuerst
+func (r *MemoryCalendarFeedTokenRepository) FindByUser(userID primitive.ObjectID) ([]*model.CalendarFeedToken, error) {
+	tokens := r.store.filter(func(t *model.CalendarFeedToken) bool { return t.UserID == userID })
+	return sortItems(tokens, func(a, b *model.CalendarFeedToken) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+// Revoke widerruft ein Kalender-Abonnement
+func (r *MemoryCalendarFeedTokenRepository) Revoke(id primitive.ObjectID) error {
+	now := time.Now()
+	r.store.modify(id, func(t *model.CalendarFeedToken) {
+		t.RevokedAt = &now
+		t.UpdatedAt = now
+	})
+	return nil
+}
+
+// MarkAccessed speichert den Zeitpunkt des letzten Feed-Abrufs
+func (r *MemoryCalendarFeedTokenRepository) MarkAccessed(id primitive.ObjectID) error {
+	now := time.Now()
+	r.store.modify(id, func(t *model.CalendarFeedToken) { t.LastAccessedAt = &now })
+	return nil
+}
@@ -0,0 +1,95 @@
+// backend/repository/memoryChargingSessionRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryChargingSessionRepository hält Ladevorgänge im Arbeitsspeicher
+type MemoryChargingSessionRepository struct {
+	store *memoryStore[model.ChargingSession]
+}
+
+// NewMemoryChargingSessionRepository erstellt ein neues MemoryChargingSessionRepository
+func NewMemoryChargingSessionRepository() *MemoryChargingSessionRepository {
+	return &MemoryChargingSessionRepository{
+		store: newMemoryStore(
+			func(s *model.ChargingSession) primitive.ObjectID { return s.ID },
+			func(s *model.ChargingSession, id primitive.ObjectID) { s.ID = id },
+		),
+	}
+}
+
+// Create legt einen neuen Ladevorgang an
+func (r *MemoryChargingSessionRepository) Create(session *model.ChargingSession) error {
+	now := time.Now()
+	session.ID = primitive.NewObjectID()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+	return r.store.insert(session)
+}
+
+// Update aktualisiert einen Ladevorgang einschließlich des Erstattungsantrags
+func (r *MemoryChargingSessionRepository) Update(session *model.ChargingSession) error {
+	session.UpdatedAt = time.Now()
+	found := r.store.modify(session.ID, func(stored *model.ChargingSession) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *session
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht einen Ladevorgang
+func (r *MemoryChargingSessionRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindByID findet einen Ladevorgang anhand seiner ID
+func (r *MemoryChargingSessionRepository) FindByID(id string) (*model.ChargingSession, error) {
+	return r.store.getHex(id)
+}
+
+// FindByVehicle findet alle Ladevorgänge eines Fahrzeugs
+func (r *MemoryChargingSessionRepository) FindByVehicle(vehicleID string) ([]*model.ChargingSession, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Find(model.ChargingSessionFilter{VehicleID: &objID})
+}
+
+// Find findet alle Ladevorgänge zum Filter, sortiert nach Beginn
+func (r *MemoryChargingSessionRepository) Find(filter model.ChargingSessionFilter) ([]*model.ChargingSession, error) {
+	sessions := r.store.filter(func(s *model.ChargingSession) bool {
+		if filter.VehicleID != nil && s.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.DriverID != nil && s.DriverID != *filter.DriverID {
+			return false
+		}
+		if filter.From != nil && s.StartTime.Before(*filter.From) {
+			return false
+		}
+		if filter.To != nil && !s.StartTime.Before(*filter.To) {
+			return false
+		}
+		if filter.LocationType != "" && s.LocationType != filter.LocationType {
+			return false
+		}
+		if filter.ReimbursementStatus != model.ReimbursementNone && s.Reimbursement.Status != filter.ReimbursementStatus {
+			return false
+		}
+		return true
+	})
+	return sortItems(sessions, func(a, b *model.ChargingSession) bool { return a.StartTime.Before(b.StartTime) }), nil
+}
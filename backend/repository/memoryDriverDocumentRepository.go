@@ -0,0 +1,107 @@
+// backend/repository/memoryDriverDocumentRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryDriverDocumentRepository hält Fahrerdokumente im Arbeitsspeicher
+type MemoryDriverDocumentRepository struct {
+	store *memoryStore[model.DriverDocument]
+}
+
+// NewMemoryDriverDocumentRepository erstellt ein neues MemoryDriverDocumentRepository
+func NewMemoryDriverDocumentRepository() *MemoryDriverDocumentRepository {
+	return &MemoryDriverDocumentRepository{
+		store: newMemoryStore(
+			func(d *model.DriverDocument) primitive.ObjectID { return d.ID },
+			func(d *model.DriverDocument, id primitive.ObjectID) { d.ID = id },
+		),
+	}
+}
+
+// Create erstellt ein neues Fahrerdokument
+func (r *MemoryDriverDocumentRepository) Create(document *model.DriverDocument) error {
+	document.UploadedAt = time.Now()
+	document.UpdatedAt = time.Now()
+	return r.store.insert(document)
+}
+
+// FindByID findet ein Dokument anhand seiner ID
+func (r *MemoryDriverDocumentRepository) FindByID(id string) (*model.DriverDocument, error) {
+	return r.store.getHex(id)
+}
+
+// FindByDriver findet alle Dokumente für einen Fahrer
+func (r *MemoryDriverDocumentRepository) FindByDriver(driverID string) ([]*model.DriverDocument, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := r.store.filter(func(d *model.DriverDocument) bool { return d.DriverID == objID })
+	return sortItems(documents, newestDriverDocumentFirst), nil
+}
+
+// FindByDriverAndType findet alle Dokumente eines bestimmten Typs für einen Fahrer
+func (r *MemoryDriverDocumentRepository) FindByDriverAndType(driverID string, docType model.DriverDocumentType) ([]*model.DriverDocument, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := r.store.filter(func(d *model.DriverDocument) bool {
+		return d.DriverID == objID && d.Type == docType
+	})
+	return sortItems(documents, newestDriverDocumentFirst), nil
+}
+
+// Update aktualisiert ein Dokument (ohne Datei-Daten)
+func (r *MemoryDriverDocumentRepository) Update(document *model.DriverDocument) error {
+	document.UpdatedAt = time.Now()
+	r.store.modify(document.ID, func(stored *model.DriverDocument) {
+		stored.Type = document.Type
+		stored.Name = document.Name
+		stored.ExpiryDate = document.ExpiryDate
+		stored.IssueDate = document.IssueDate
+		stored.LicenseNumber = document.LicenseNumber
+		stored.IssuingAuthority = document.IssuingAuthority
+		stored.Notes = document.Notes
+		stored.UpdatedAt = document.UpdatedAt
+	})
+	return nil
+}
+
+// Delete löscht ein Dokument
+func (r *MemoryDriverDocumentRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// CountByDriver zählt die Anzahl der Dokumente für einen Fahrer
+func (r *MemoryDriverDocumentRepository) CountByDriver(driverID string) (int64, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return 0, err
+	}
+	return r.store.count(func(d *model.DriverDocument) bool { return d.DriverID == objID }), nil
+}
+
+// FindExpiringLicenses findet alle Führerscheine, die ab heute in den nächsten Tagen ablaufen
+func (r *MemoryDriverDocumentRepository) FindExpiringLicenses(days int) ([]*model.DriverDocument, error) {
+	today := startOfDay(time.Now())
+	expiryThreshold := today.AddDate(0, 0, days)
+
+	return r.store.filter(func(d *model.DriverDocument) bool {
+		return d.Type == model.DriverDocumentTypeLicense && d.ExpiryDate != nil &&
+			!d.ExpiryDate.Before(today) && !d.ExpiryDate.After(expiryThreshold)
+	}), nil
+}
+
+// newestDriverDocumentFirst sortiert Dokumente absteigend nach Upload-Datum
+func newestDriverDocumentFirst(a, b *model.DriverDocument) bool {
+	return a.UploadedAt.After(b.UploadedAt)
+}
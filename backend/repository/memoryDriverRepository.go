@@ -0,0 +1,104 @@
+// backend/repository/memoryDriverRepository.go
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryDriverRepository hält Fahrer im Arbeitsspeicher
+type MemoryDriverRepository struct {
+	store *memoryStore[model.Driver]
+}
+
+// NewMemoryDriverRepository erstellt ein neues MemoryDriverRepository
+func NewMemoryDriverRepository() *MemoryDriverRepository {
+	return &MemoryDriverRepository{
+		store: newMemoryStore(
+			func(d *model.Driver) primitive.ObjectID { return d.ID },
+			func(d *model.Driver, id primitive.ObjectID) { d.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Fahrer
+func (r *MemoryDriverRepository) Create(driver *model.Driver) error {
+	driver.CreatedAt = time.Now()
+	driver.UpdatedAt = time.Now()
+	return r.store.insert(driver)
+}
+
+// FindByID findet einen Fahrer anhand seiner ID
+func (r *MemoryDriverRepository) FindByID(id string) (*model.Driver, error) {
+	return r.store.getHex(id)
+}
+
+// FindByEmail findet einen Fahrer anhand seiner E-Mail
+func (r *MemoryDriverRepository) FindByEmail(email string) (*model.Driver, error) {
+	return r.store.first(func(d *model.Driver) bool { return d.Email == email })
+}
+
+// FindAll findet alle Fahrer
+func (r *MemoryDriverRepository) FindAll() ([]*model.Driver, error) {
+	return r.store.all(), nil
+}
+
+// FindAllWithLimit findet die zuletzt angelegten Fahrer
+func (r *MemoryDriverRepository) FindAllWithLimit(limit int) ([]*model.Driver, error) {
+	drivers := sortItems(r.store.all(), func(a, b *model.Driver) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return pageItems(drivers, 0, limit), nil
+}
+
+// FindByStatus findet alle Fahrer mit einem bestimmten Status
+func (r *MemoryDriverRepository) FindByStatus(status model.DriverStatus) ([]*model.Driver, error) {
+	return r.store.filter(func(d *model.Driver) bool { return d.Status == status }), nil
+}
+
+// FindPage findet eine Seite Fahrer (leerer Status = alle), sortiert nach Anlage
+func (r *MemoryDriverRepository) FindPage(status model.DriverStatus, limit int, skip int) ([]*model.Driver, error) {
+	drivers := r.store.filter(func(d *model.Driver) bool { return status == "" || d.Status == status })
+	return pageItems(drivers, skip, limit), nil
+}
+
+// FindByVehicle findet den Fahrer, dem ein bestimmtes Fahrzeug zugewiesen ist
+func (r *MemoryDriverRepository) FindByVehicle(vehicleID primitive.ObjectID) (*model.Driver, error) {
+	return r.store.first(func(d *model.Driver) bool { return d.AssignedVehicleID == vehicleID })
+}
+
+// Update aktualisiert einen Fahrer
+func (r *MemoryDriverRepository) Update(driver *model.Driver) error {
+	driver.UpdatedAt = time.Now()
+
+	found := r.store.modify(driver.ID, func(stored *model.Driver) {
+		stored.FirstName = driver.FirstName
+		stored.LastName = driver.LastName
+		stored.Email = driver.Email
+		stored.Phone = driver.Phone
+		stored.Status = driver.Status
+		stored.LicenseClasses = driver.LicenseClasses
+		stored.Notes = driver.Notes
+		stored.BenefitMethod = driver.BenefitMethod
+		stored.CommuteDistanceKm = driver.CommuteDistanceKm
+		stored.CommuteDailyValuation = driver.CommuteDailyValuation
+		stored.AssignedVehicleID = driver.AssignedVehicleID
+		stored.UpdatedAt = driver.UpdatedAt
+	})
+	if !found {
+		return fmt.Errorf("no document found with ID %s", driver.ID.Hex())
+	}
+	return nil
+}
+
+// Delete löscht einen Fahrer
+func (r *MemoryDriverRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// HasAnyDrivers prüft, ob Fahrer vorhanden sind
+func (r *MemoryDriverRepository) HasAnyDrivers() (bool, error) {
+	return r.store.count(nil) > 0, nil
+}
@@ -0,0 +1,85 @@
+// backend/repository/memoryExpiryReminderRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryExpiryReminderRepository hält Ablauferinnerungen und deren Einstellungen im Arbeitsspeicher
+type MemoryExpiryReminderRepository struct {
+	reminders *memoryStore[model.ExpiryReminder]
+	settings  *memoryStore[model.ExpiryReminderSettings]
+}
+
+// NewMemoryExpiryReminderRepository erstellt ein neues MemoryExpiryReminderRepository
+func NewMemoryExpiryReminderRepository() *MemoryExpiryReminderRepository {
+	return &MemoryExpiryReminderRepository{
+		reminders: newMemoryStore(
+			func(r *model.ExpiryReminder) primitive.ObjectID { return r.ID },
+			func(r *model.ExpiryReminder, id primitive.ObjectID) { r.ID = id },
+		),
+		settings: newMemoryStore(
+			func(s *model.ExpiryReminderSettings) primitive.ObjectID { return s.ID },
+			func(s *model.ExpiryReminderSettings, id primitive.ObjectID) { s.ID = id },
+		),
+	}
+}
+
+// Create speichert eine versendete Erinnerung
+func (r *MemoryExpiryReminderRepository) Create(reminder *model.ExpiryReminder) error {
+	return r.reminders.insert(reminder)
+}
+
+// HasBeenSent prüft, ob die Erinnerungsstufe für dieses Ablaufdatum bereits an den Empfänger ging
+func (r *MemoryExpiryReminderRepository) HasBeenSent(subjectType model.ExpirySubjectType, subjectID primitive.ObjectID, expiryDate time.Time, offsetDays int, recipient string) (bool, error) {
+	return r.reminders.count(func(rem *model.ExpiryReminder) bool {
+		return rem.SubjectType == subjectType && rem.SubjectID == subjectID && rem.ExpiryDate.Equal(expiryDate) &&
+			rem.OffsetDays == offsetDays && rem.Recipient == recipient
+	}) > 0, nil
+}
+
+// FindRecent findet die zuletzt versendeten Erinnerungen
+func (r *MemoryExpiryReminderRepository) FindRecent(limit int) ([]*model.ExpiryReminder, error) {
+	reminders := sortItems(r.reminders.all(), func(a, b *model.ExpiryReminder) bool { return a.SentAt.After(b.SentAt) })
+	return pageItems(reminders, 0, limit), nil
+}
+
+// GetSettings holt die Einstellungen der Ablauferinnerungen
+func (r *MemoryExpiryReminderRepository) GetSettings() (*model.ExpiryReminderSettings, error) {
+	all := r.settings.all()
+	if len(all) == 0 {
+		// Standardeinstellungen zurückgeben
+		return defaultExpiryReminderSettings(), nil
+	}
+	return all[0], nil
+}
+
+// SaveSettings speichert oder aktualisiert die Einstellungen der Ablauferinnerungen
+func (r *MemoryExpiryReminderRepository) SaveSettings(settings *model.ExpiryReminderSettings) error {
+	now := time.Now()
+
+	updated := r.settings.modifyAll(func(*model.ExpiryReminderSettings) bool { return true }, func(stored *model.ExpiryReminderSettings) {
+		stored.Enabled = settings.Enabled
+		stored.OffsetsDays = append([]int(nil), settings.OffsetsDays...)
+		stored.NotifyDrivers = settings.NotifyDrivers
+		stored.NotifyManagers = settings.NotifyManagers
+		stored.UpdatedAt = now
+	})
+	if updated > 0 {
+		return nil
+	}
+
+	stored := *settings
+	stored.ID = primitive.NilObjectID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	if err := r.settings.insert(&stored); err != nil {
+		return err
+	}
+	settings.ID = stored.ID
+	return nil
+}
@@ -0,0 +1,63 @@
+// backend/repository/memoryFileRepository.go
+package repository
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// MemoryFileRepository hält Binärdateien im Arbeitsspeicher
+type MemoryFileRepository struct {
+	mu    sync.RWMutex
+	files map[primitive.ObjectID][]byte
+}
+
+// NewMemoryFileRepository erstellt ein neues MemoryFileRepository
+func NewMemoryFileRepository() *MemoryFileRepository {
+	return &MemoryFileRepository{
+		files: make(map[primitive.ObjectID][]byte),
+	}
+}
+
+// Upload speichert den Inhalt von source unter dem angegebenen Namen
+func (r *MemoryFileRepository) Upload(name string, source io.Reader) (primitive.ObjectID, error) {
+	data, err := io.ReadAll(source)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := primitive.NewObjectID()
+	r.files[id] = data
+	return id, nil
+}
+
+// Open öffnet eine gespeicherte Datei zum Lesen
+func (r *MemoryFileRepository) Open(id primitive.ObjectID) (io.ReadCloser, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data, ok := r.files[id]
+	if !ok {
+		return nil, gridfs.ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Delete löscht eine gespeicherte Datei
+func (r *MemoryFileRepository) Delete(id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.files[id]; !ok {
+		return gridfs.ErrFileNotFound
+	}
+	delete(r.files, id)
+	return nil
+}
@@ -0,0 +1,87 @@
+// backend/repository/memoryFuelCostRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryFuelCostRepository hält Tankkosten im Arbeitsspeicher
+type MemoryFuelCostRepository struct {
+	store *memoryStore[model.FuelCost]
+}
+
+// NewMemoryFuelCostRepository erstellt ein neues MemoryFuelCostRepository
+func NewMemoryFuelCostRepository() *MemoryFuelCostRepository {
+	return &MemoryFuelCostRepository{
+		store: newMemoryStore(
+			func(f *model.FuelCost) primitive.ObjectID { return f.ID },
+			func(f *model.FuelCost, id primitive.ObjectID) { f.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Tankkosteneintrag
+func (r *MemoryFuelCostRepository) Create(fuelCost *model.FuelCost) error {
+	fuelCost.CreatedAt = time.Now()
+	fuelCost.UpdatedAt = time.Now()
+	return r.store.insert(fuelCost)
+}
+
+// FindByID findet einen Tankkosteneintrag anhand seiner ID
+func (r *MemoryFuelCostRepository) FindByID(id string) (*model.FuelCost, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Tankkosteneinträge (neueste zuerst)
+func (r *MemoryFuelCostRepository) FindAll() ([]*model.FuelCost, error) {
+	return sortItems(r.store.all(), newestFuelCostFirst), nil
+}
+
+// FindPage findet eine Seite Tankkosteneinträge, neueste zuerst
+func (r *MemoryFuelCostRepository) FindPage(limit int, skip int) ([]*model.FuelCost, error) {
+	return pageItems(sortItems(r.store.all(), newestFuelCostFirst), skip, limit), nil
+}
+
+// FindByVehicle findet alle Tankkosteneinträge für ein bestimmtes Fahrzeug (neueste zuerst)
+func (r *MemoryFuelCostRepository) FindByVehicle(vehicleID string) ([]*model.FuelCost, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	fuelCosts := r.store.filter(func(f *model.FuelCost) bool { return f.VehicleID == objID })
+	return sortItems(fuelCosts, newestFuelCostFirst), nil
+}
+
+// FindByDateRange findet Tankkosten in einem bestimmten Zeitraum
+func (r *MemoryFuelCostRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.FuelCost, error) {
+	return r.store.filter(func(f *model.FuelCost) bool {
+		return !f.Date.Before(startDate) && !f.Date.After(endDate)
+	}), nil
+}
+
+// Update aktualisiert einen Tankkosteneintrag
+func (r *MemoryFuelCostRepository) Update(fuelCost *model.FuelCost) error {
+	fuelCost.UpdatedAt = time.Now()
+	r.store.modify(fuelCost.ID, func(stored *model.FuelCost) { *stored = *fuelCost })
+	return nil
+}
+
+// Delete löscht einen Tankkosteneintrag
+func (r *MemoryFuelCostRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// HasAnyFuelCosts prüft ob überhaupt Tankkosten-Einträge vorhanden sind
+func (r *MemoryFuelCostRepository) HasAnyFuelCosts() (bool, error) {
+	return r.store.count(nil) > 0, nil
+}
+
+// newestFuelCostFirst sortiert Tankkosten absteigend nach Datum
+func newestFuelCostFirst(a, b *model.FuelCost) bool {
+	return a.Date.After(b.Date)
+}
@@ -0,0 +1,176 @@
+// backend/repository/memoryFuelImportRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryFuelImportRepository hält Anbieterformate, Importe und Importzeilen im Arbeitsspeicher
+type MemoryFuelImportRepository struct {
+	providers *memoryStore[model.FuelCardProvider]
+	batches   *memoryStore[model.FuelImportBatch]
+	rows      *memoryStore[model.FuelImportRow]
+}
+
+// NewMemoryFuelImportRepository erstellt ein neues MemoryFuelImportRepository
+func NewMemoryFuelImportRepository() *MemoryFuelImportRepository {
+	return &MemoryFuelImportRepository{
+		providers: newMemoryStore(
+			func(p *model.FuelCardProvider) primitive.ObjectID { return p.ID },
+			func(p *model.FuelCardProvider, id primitive.ObjectID) { p.ID = id },
+		),
+		batches: newMemoryStore(
+			func(b *model.FuelImportBatch) primitive.ObjectID { return b.ID },
+			func(b *model.FuelImportBatch, id primitive.ObjectID) { b.ID = id },
+		),
+		rows: newMemoryStore(
+			func(r *model.FuelImportRow) primitive.ObjectID { return r.ID },
+			func(r *model.FuelImportRow, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// CreateProvider legt ein neues Anbieterformat an
+func (r *MemoryFuelImportRepository) CreateProvider(provider *model.FuelCardProvider) error {
+	now := time.Now()
+	provider.ID = primitive.NewObjectID()
+	provider.CreatedAt = now
+	provider.UpdatedAt = now
+	return r.providers.insert(provider)
+}
+
+// UpdateProvider aktualisiert ein Anbieterformat
+func (r *MemoryFuelImportRepository) UpdateProvider(provider *model.FuelCardProvider) error {
+	provider.UpdatedAt = time.Now()
+	found := r.providers.modify(provider.ID, func(stored *model.FuelCardProvider) {
+		createdAt := stored.CreatedAt
+		*stored = *provider
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteProvider löscht ein Anbieterformat
+func (r *MemoryFuelImportRepository) DeleteProvider(id string) error {
+	return r.providers.removeHex(id)
+}
+
+// FindProviderByID findet ein Anbieterformat anhand seiner ID
+func (r *MemoryFuelImportRepository) FindProviderByID(id string) (*model.FuelCardProvider, error) {
+	return r.providers.getHex(id)
+}
+
+// FindAllProviders findet alle Anbieterformate, sortiert nach Name
+func (r *MemoryFuelImportRepository) FindAllProviders() ([]*model.FuelCardProvider, error) {
+	providers := r.providers.filter(nil)
+	return sortItems(providers, func(a, b *model.FuelCardProvider) bool { return a.Name < b.Name }), nil
+}
+
+// CreateBatch legt einen Import mit allen Zeilen an
+func (r *MemoryFuelImportRepository) CreateBatch(batch *model.FuelImportBatch, rows []*model.FuelImportRow) error {
+	now := time.Now()
+	batch.ID = primitive.NewObjectID()
+	batch.CreatedAt = now
+	batch.UpdatedAt = now
+	if err := r.batches.insert(batch); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		row.ID = primitive.NewObjectID()
+		row.BatchID = batch.ID
+		row.CreatedAt = now
+		row.UpdatedAt = now
+		if err := r.rows.insert(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateBatch aktualisiert Status und Zähler eines Imports
+func (r *MemoryFuelImportRepository) UpdateBatch(batch *model.FuelImportBatch) error {
+	batch.UpdatedAt = time.Now()
+	found := r.batches.modify(batch.ID, func(stored *model.FuelImportBatch) {
+		stored.Status = batch.Status
+		stored.Counts = batch.Counts
+		stored.CommittedAt = batch.CommittedAt
+		stored.UpdatedAt = batch.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// TransitionBatch setzt den Status eines Imports nur, wenn er noch den Status from hat,
+// und meldet, ob der Wechsel stattgefunden hat
+func (r *MemoryFuelImportRepository) TransitionBatch(id primitive.ObjectID, from, to model.FuelImportBatchStatus) (bool, error) {
+	changed := r.batches.modifyAll(func(b *model.FuelImportBatch) bool {
+		return b.ID == id && b.Status == from
+	}, func(b *model.FuelImportBatch) {
+		b.Status = to
+		b.UpdatedAt = time.Now()
+	})
+	return changed == 1, nil
+}
+
+// FindBatchByID findet einen Import anhand seiner ID
+func (r *MemoryFuelImportRepository) FindBatchByID(id string) (*model.FuelImportBatch, error) {
+	return r.batches.getHex(id)
+}
+
+// FindRecentBatches findet die neuesten Importe
+func (r *MemoryFuelImportRepository) FindRecentBatches(limit int) ([]*model.FuelImportBatch, error) {
+	batches := sortItems(r.batches.filter(nil), func(a, b *model.FuelImportBatch) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return pageItems(batches, 0, limit), nil
+}
+
+// UpdateRow aktualisiert Status und Zuordnung einer Zeile
+func (r *MemoryFuelImportRepository) UpdateRow(row *model.FuelImportRow) error {
+	row.UpdatedAt = time.Now()
+	found := r.rows.modify(row.ID, func(stored *model.FuelImportRow) {
+		stored.Status = row.Status
+		stored.Message = row.Message
+		stored.FuelType = row.FuelType
+		stored.VehicleID = row.VehicleID
+		stored.FuelCostID = row.FuelCostID
+		stored.UpdatedAt = row.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindRowByID findet eine Zeile anhand ihrer ID
+func (r *MemoryFuelImportRepository) FindRowByID(id string) (*model.FuelImportRow, error) {
+	return r.rows.getHex(id)
+}
+
+// FindRowsByBatch findet alle Zeilen eines Imports in Dateireihenfolge
+func (r *MemoryFuelImportRepository) FindRowsByBatch(batchID primitive.ObjectID) ([]*model.FuelImportRow, error) {
+	rows := r.rows.filter(func(row *model.FuelImportRow) bool { return row.BatchID == batchID })
+	return sortItems(rows, func(a, b *model.FuelImportRow) bool { return a.RowNumber < b.RowNumber }), nil
+}
+
+// FindRowsByStatus findet alle Zeilen eines Status, älteste zuerst
+func (r *MemoryFuelImportRepository) FindRowsByStatus(status model.FuelImportRowStatus) ([]*model.FuelImportRow, error) {
+	rows := r.rows.filter(func(row *model.FuelImportRow) bool { return row.Status == status })
+	return sortItems(rows, func(a, b *model.FuelImportRow) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.RowNumber < b.RowNumber
+	}), nil
+}
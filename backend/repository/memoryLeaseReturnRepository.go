@@ -0,0 +1,80 @@
+// backend/repository/memoryLeaseReturnRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryLeaseReturnRepository hält Leasingrückgaben im Arbeitsspeicher
+type MemoryLeaseReturnRepository struct {
+	store *memoryStore[model.LeaseReturn]
+}
+
+// NewMemoryLeaseReturnRepository erstellt ein neues MemoryLeaseReturnRepository
+func NewMemoryLeaseReturnRepository() *MemoryLeaseReturnRepository {
+	return &MemoryLeaseReturnRepository{
+		store: newMemoryStore(
+			func(r *model.LeaseReturn) primitive.ObjectID { return r.ID },
+			func(r *model.LeaseReturn, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// Create legt eine neue Leasingrückgabe an; je Fahrzeug und Leasingende ist nur eine Rückgabe erlaubt
+func (r *MemoryLeaseReturnRepository) Create(leaseReturn *model.LeaseReturn) error {
+	if r.store.count(func(existing *model.LeaseReturn) bool {
+		return existing.VehicleID == leaseReturn.VehicleID && existing.LeaseEndDate.Equal(leaseReturn.LeaseEndDate)
+	}) > 0 {
+		return duplicateKeyError()
+	}
+	now := time.Now()
+	leaseReturn.ID = primitive.NewObjectID()
+	leaseReturn.CreatedAt = now
+	leaseReturn.UpdatedAt = now
+	return r.store.insert(leaseReturn)
+}
+
+// Update aktualisiert eine Leasingrückgabe
+func (r *MemoryLeaseReturnRepository) Update(leaseReturn *model.LeaseReturn) error {
+	leaseReturn.UpdatedAt = time.Now()
+	found := r.store.modify(leaseReturn.ID, func(stored *model.LeaseReturn) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *leaseReturn
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht eine Leasingrückgabe
+func (r *MemoryLeaseReturnRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindByID findet eine Leasingrückgabe anhand ihrer ID
+func (r *MemoryLeaseReturnRepository) FindByID(id string) (*model.LeaseReturn, error) {
+	return r.store.getHex(id)
+}
+
+// FindByVehicleAndEndDate findet die Rückgabe zu einem Leasingvertrag eines Fahrzeugs
+func (r *MemoryLeaseReturnRepository) FindByVehicleAndEndDate(vehicleID primitive.ObjectID, leaseEndDate time.Time) (*model.LeaseReturn, error) {
+	return r.store.first(func(existing *model.LeaseReturn) bool {
+		return existing.VehicleID == vehicleID && existing.LeaseEndDate.Equal(leaseEndDate)
+	})
+}
+
+// FindByStatus findet alle Leasingrückgaben mit dem Status (leer = alle), die nächsten Rückgaben zuerst
+func (r *MemoryLeaseReturnRepository) FindByStatus(status model.LeaseReturnStatus) ([]*model.LeaseReturn, error) {
+	leaseReturns := r.store.filter(func(existing *model.LeaseReturn) bool {
+		return status == "" || existing.Status == status
+	})
+	return sortItems(leaseReturns, func(a, b *model.LeaseReturn) bool { return a.LeaseEndDate.Before(b.LeaseEndDate) }), nil
+}
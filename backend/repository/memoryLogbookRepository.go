@@ -0,0 +1,167 @@
+// backend/repository/memoryLogbookRepository.go
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryLogbookRepository hält Fahrtenbucheinträge im Arbeitsspeicher
+type MemoryLogbookRepository struct {
+	mu    sync.Mutex // Sichert Prüfung und Schreiben der Kettenposition gemeinsam ab
+	store *memoryStore[model.LogbookEntry]
+}
+
+// NewMemoryLogbookRepository erstellt ein neues MemoryLogbookRepository
+func NewMemoryLogbookRepository() *MemoryLogbookRepository {
+	return &MemoryLogbookRepository{
+		store: newMemoryStore(
+			func(e *model.LogbookEntry) primitive.ObjectID { return e.ID },
+			func(e *model.LogbookEntry, id primitive.ObjectID) { e.ID = id },
+		),
+	}
+}
+
+// Create legt die erste Version eines Fahrtenbucheintrags an
+func (r *MemoryLogbookRepository) Create(entry *model.LogbookEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sequenceTaken(entry.VehicleID, entry.Sequence) {
+		return duplicateKeyError()
+	}
+
+	now := time.Now()
+	entry.ID = primitive.NewObjectID()
+	entry.EntryID = entry.ID
+	entry.Version = 1
+	entry.Current = true
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	return r.store.insert(entry)
+}
+
+// Update ändert die aktuelle Version eines Eintrags innerhalb der Bearbeitungsfrist
+func (r *MemoryLogbookRepository) Update(entry *model.LogbookEntry) error {
+	entry.UpdatedAt = time.Now()
+	updated := r.store.modifyAll(func(e *model.LogbookEntry) bool { return e.ID == entry.ID && e.Current }, func(e *model.LogbookEntry) {
+		e.DriverID = entry.DriverID
+		e.Date = entry.Date
+		e.StartMileage = entry.StartMileage
+		e.EndMileage = entry.EndMileage
+		e.Route = entry.Route
+		e.BusinessPartner = entry.BusinessPartner
+		e.Purpose = entry.Purpose
+		e.TripType = entry.TripType
+		e.UpdatedAt = entry.UpdatedAt
+	})
+	if updated == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Supersede ersetzt die aktuelle Version durch eine Korrekturversion; die alte Version bleibt erhalten
+func (r *MemoryLogbookRepository) Supersede(current *model.LogbookEntry, correction *model.LogbookEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	correctionID := primitive.NewObjectID()
+	superseded := r.store.modifyAll(func(e *model.LogbookEntry) bool { return e.ID == current.ID && e.Current }, func(e *model.LogbookEntry) {
+		e.Current = false
+		e.SupersededAt = &now
+		e.SupersededBy = &correctionID
+	})
+	if superseded == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	correction.ID = correctionID
+	correction.EntryID = current.EntryID
+	correction.Version = current.Version + 1
+	correction.Current = true
+	correction.CreatedAt = now
+	correction.UpdatedAt = now
+	if err := r.store.insert(correction); err != nil {
+		return err
+	}
+
+	current.Current = false
+	current.SupersededAt = &now
+	current.SupersededBy = &correctionID
+	return nil
+}
+
+// FindCurrent findet die aktuelle Version eines Eintrags
+func (r *MemoryLogbookRepository) FindCurrent(entryID primitive.ObjectID) (*model.LogbookEntry, error) {
+	return r.store.first(func(e *model.LogbookEntry) bool { return e.EntryID == entryID && e.Current })
+}
+
+// FindVersions findet alle Versionen eines Eintrags, älteste zuerst
+func (r *MemoryLogbookRepository) FindVersions(entryID primitive.ObjectID) ([]*model.LogbookEntry, error) {
+	versions := r.store.filter(func(e *model.LogbookEntry) bool { return e.EntryID == entryID })
+	return sortItems(versions, func(a, b *model.LogbookEntry) bool { return a.Version < b.Version }), nil
+}
+
+// FindBySequence findet den aktuellen Eintrag an einer Position der Kilometerkette
+func (r *MemoryLogbookRepository) FindBySequence(vehicleID primitive.ObjectID, sequence int) (*model.LogbookEntry, error) {
+	entry, err := r.store.first(func(e *model.LogbookEntry) bool {
+		return e.VehicleID == vehicleID && e.Sequence == sequence && e.Current
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// FindLast findet den letzten aktuellen Eintrag der Kilometerkette eines Fahrzeugs
+func (r *MemoryLogbookRepository) FindLast(vehicleID primitive.ObjectID) (*model.LogbookEntry, error) {
+	var last *model.LogbookEntry
+	for _, entry := range r.store.filter(func(e *model.LogbookEntry) bool { return e.VehicleID == vehicleID && e.Current }) {
+		if last == nil || entry.Sequence > last.Sequence {
+			last = entry
+		}
+	}
+	return last, nil
+}
+
+// Find findet aktuelle Einträge nach Filter, sortiert nach Datum und Kettenposition
+func (r *MemoryLogbookRepository) Find(filter model.LogbookFilter) ([]*model.LogbookEntry, error) {
+	entries := r.store.filter(func(e *model.LogbookEntry) bool {
+		if !e.Current {
+			return false
+		}
+		if filter.VehicleID != nil && e.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.DriverID != nil && e.DriverID != *filter.DriverID {
+			return false
+		}
+		if filter.From != nil && e.Date.Before(*filter.From) {
+			return false
+		}
+		if filter.To != nil && !e.Date.Before(*filter.To) {
+			return false
+		}
+		return true
+	})
+	return sortItems(entries, func(a, b *model.LogbookEntry) bool {
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Sequence < b.Sequence
+	}), nil
+}
+
+// sequenceTaken prüft, ob die Kettenposition bereits durch einen aktuellen Eintrag belegt ist
+func (r *MemoryLogbookRepository) sequenceTaken(vehicleID primitive.ObjectID, sequence int) bool {
+	return r.store.count(func(e *model.LogbookEntry) bool {
+		return e.VehicleID == vehicleID && e.Sequence == sequence && e.Current
+	}) > 0
+}
@@ -0,0 +1,141 @@
+// backend/repository/memoryMaintenancePlanRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryMaintenancePlanRepository hält Wartungspläne und ihre Zyklen im Arbeitsspeicher
+type MemoryMaintenancePlanRepository struct {
+	plans *memoryStore[model.MaintenancePlan]
+	dues  *memoryStore[model.MaintenanceDue]
+}
+
+// NewMemoryMaintenancePlanRepository erstellt ein neues MemoryMaintenancePlanRepository
+func NewMemoryMaintenancePlanRepository() *MemoryMaintenancePlanRepository {
+	return &MemoryMaintenancePlanRepository{
+		plans: newMemoryStore(
+			func(p *model.MaintenancePlan) primitive.ObjectID { return p.ID },
+			func(p *model.MaintenancePlan, id primitive.ObjectID) { p.ID = id },
+		),
+		dues: newMemoryStore(
+			func(d *model.MaintenanceDue) primitive.ObjectID { return d.ID },
+			func(d *model.MaintenanceDue, id primitive.ObjectID) { d.ID = id },
+		),
+	}
+}
+
+// CreatePlan legt einen neuen Wartungsplan an
+func (r *MemoryMaintenancePlanRepository) CreatePlan(plan *model.MaintenancePlan) error {
+	now := time.Now()
+	plan.ID = primitive.NewObjectID()
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+	return r.plans.insert(plan)
+}
+
+// UpdatePlan aktualisiert einen Wartungsplan
+func (r *MemoryMaintenancePlanRepository) UpdatePlan(plan *model.MaintenancePlan) error {
+	plan.UpdatedAt = time.Now()
+	found := r.plans.modify(plan.ID, func(stored *model.MaintenancePlan) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *plan
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeletePlan löscht einen Wartungsplan
+func (r *MemoryMaintenancePlanRepository) DeletePlan(id string) error {
+	return r.plans.removeHex(id)
+}
+
+// FindPlanByID findet einen Wartungsplan anhand seiner ID
+func (r *MemoryMaintenancePlanRepository) FindPlanByID(id string) (*model.MaintenancePlan, error) {
+	return r.plans.getHex(id)
+}
+
+// FindAllPlans findet alle Wartungspläne, sortiert nach Name
+func (r *MemoryMaintenancePlanRepository) FindAllPlans() ([]*model.MaintenancePlan, error) {
+	plans := r.plans.filter(nil)
+	return sortItems(plans, func(a, b *model.MaintenancePlan) bool { return a.Name < b.Name }), nil
+}
+
+// CreateDue legt einen neuen Wartungszyklus an
+func (r *MemoryMaintenancePlanRepository) CreateDue(due *model.MaintenanceDue) error {
+	now := time.Now()
+	due.ID = primitive.NewObjectID()
+	due.CreatedAt = now
+	due.UpdatedAt = now
+	return r.dues.insert(due)
+}
+
+// UpdateDue aktualisiert Hochrechnung und Status eines Wartungszyklus
+func (r *MemoryMaintenancePlanRepository) UpdateDue(due *model.MaintenanceDue) error {
+	due.UpdatedAt = time.Now()
+	found := r.dues.modify(due.ID, func(stored *model.MaintenanceDue) {
+		planID, vehicleID, createdAt := stored.PlanID, stored.VehicleID, stored.CreatedAt
+		*stored = *due
+		stored.PlanID = planID
+		stored.VehicleID = vehicleID
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindDueByID findet einen Wartungszyklus anhand seiner ID
+func (r *MemoryMaintenancePlanRepository) FindDueByID(id string) (*model.MaintenanceDue, error) {
+	return r.dues.getHex(id)
+}
+
+// FindDues findet alle Wartungszyklen zum Filter, sortiert nach Fälligkeit (ohne Fälligkeit zuerst wie in MongoDB)
+func (r *MemoryMaintenancePlanRepository) FindDues(filter model.MaintenanceDueFilter) ([]*model.MaintenanceDue, error) {
+	dues := r.dues.filter(func(d *model.MaintenanceDue) bool {
+		if filter.PlanID != nil && d.PlanID != *filter.PlanID {
+			return false
+		}
+		if filter.VehicleID != nil && d.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.Type != "" && d.Type != filter.Type {
+			return false
+		}
+		if len(filter.Statuses) > 0 {
+			matched := false
+			for _, status := range filter.Statuses {
+				if d.Status == status {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	})
+	return sortItems(dues, func(a, b *model.MaintenanceDue) bool {
+		if a.DueDate == nil || b.DueDate == nil {
+			return a.DueDate == nil && b.DueDate != nil
+		}
+		return a.DueDate.Before(*b.DueDate)
+	}), nil
+}
+
+// DeleteDuesByPlan löscht alle Wartungszyklen eines Plans
+func (r *MemoryMaintenancePlanRepository) DeleteDuesByPlan(planID primitive.ObjectID) error {
+	r.dues.removeAll(func(d *model.MaintenanceDue) bool { return d.PlanID == planID })
+	return nil
+}
@@ -0,0 +1,104 @@
+// backend/repository/memoryMaintenanceRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryMaintenanceRepository hält Wartungseinträge im Arbeitsspeicher
+type MemoryMaintenanceRepository struct {
+	store *memoryStore[model.Maintenance]
+}
+
+// NewMemoryMaintenanceRepository erstellt ein neues MemoryMaintenanceRepository
+func NewMemoryMaintenanceRepository() *MemoryMaintenanceRepository {
+	return &MemoryMaintenanceRepository{
+		store: newMemoryStore(
+			func(m *model.Maintenance) primitive.ObjectID { return m.ID },
+			func(m *model.Maintenance, id primitive.ObjectID) { m.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Wartungseintrag
+func (r *MemoryMaintenanceRepository) Create(maintenance *model.Maintenance) error {
+	maintenance.CreatedAt = time.Now()
+	maintenance.UpdatedAt = time.Now()
+	return r.store.insert(maintenance)
+}
+
+// FindByID findet einen Wartungseintrag anhand seiner ID
+func (r *MemoryMaintenanceRepository) FindByID(id string) (*model.Maintenance, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Wartungseinträge
+func (r *MemoryMaintenanceRepository) FindAll() ([]*model.Maintenance, error) {
+	return r.store.all(), nil
+}
+
+// FindPage findet eine Seite Wartungseinträge, sortiert nach Anlage
+func (r *MemoryMaintenanceRepository) FindPage(limit int, skip int) ([]*model.Maintenance, error) {
+	return pageItems(r.store.all(), skip, limit), nil
+}
+
+// FindByVehicle findet alle Wartungseinträge für ein bestimmtes Fahrzeug
+func (r *MemoryMaintenanceRepository) FindByVehicle(vehicleID string) ([]*model.Maintenance, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.store.filter(func(m *model.Maintenance) bool { return m.VehicleID == objID }), nil
+}
+
+// FindByDateRange findet Wartungseinträge in einem Zeitraum (neueste zuerst)
+func (r *MemoryMaintenanceRepository) FindByDateRange(startDate, endDate time.Time) ([]*model.Maintenance, error) {
+	maintenances := r.store.filter(maintenanceBetween(startDate, endDate))
+	return sortItems(maintenances, func(a, b *model.Maintenance) bool { return a.Date.After(b.Date) }), nil
+}
+
+// FindUpcoming findet alle geplanten Wartungseinträge in einem Zeitraum (nächste zuerst)
+func (r *MemoryMaintenanceRepository) FindUpcoming(fromDate time.Time, toDate time.Time) ([]*model.Maintenance, error) {
+	maintenances := r.store.filter(maintenanceBetween(fromDate, toDate))
+	return sortItems(maintenances, func(a, b *model.Maintenance) bool { return a.Date.Before(b.Date) }), nil
+}
+
+// FindUpcomingWithLimit findet die nächsten anstehenden Wartungen bis zum Enddatum
+func (r *MemoryMaintenanceRepository) FindUpcomingWithLimit(endDate time.Time, limit int) ([]*model.Maintenance, error) {
+	maintenances, _ := r.FindUpcoming(time.Now(), endDate)
+	return pageItems(maintenances, 0, limit), nil
+}
+
+// FindRecentWithLimit findet die zuletzt durchgeführten Wartungen
+func (r *MemoryMaintenanceRepository) FindRecentWithLimit(limit int) ([]*model.Maintenance, error) {
+	maintenances := sortItems(r.store.all(), func(a, b *model.Maintenance) bool { return a.Date.After(b.Date) })
+	return pageItems(maintenances, 0, limit), nil
+}
+
+// Update aktualisiert einen Wartungseintrag
+func (r *MemoryMaintenanceRepository) Update(maintenance *model.Maintenance) error {
+	maintenance.UpdatedAt = time.Now()
+	r.store.modify(maintenance.ID, func(stored *model.Maintenance) { *stored = *maintenance })
+	return nil
+}
+
+// Delete löscht einen Wartungseintrag
+func (r *MemoryMaintenanceRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// HasAnyMaintenance prüft, ob Wartungseinträge vorhanden sind
+func (r *MemoryMaintenanceRepository) HasAnyMaintenance() (bool, error) {
+	return r.store.count(nil) > 0, nil
+}
+
+// maintenanceBetween liefert einen Filter für Wartungen im angegebenen Zeitraum
+func maintenanceBetween(from, to time.Time) func(*model.Maintenance) bool {
+	return func(m *model.Maintenance) bool {
+		return !m.Date.Before(from) && !m.Date.After(to)
+	}
+}
@@ -0,0 +1,183 @@
+// backend/repository/memoryPeopleFlowRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryPeopleFlowRepository hält die PeopleFlow-Integration im Arbeitsspeicher
+type MemoryPeopleFlowRepository struct {
+	integrations *memoryStore[model.PeopleFlowIntegration]
+	employees    *memoryStore[model.PeopleFlowEmployee]
+	syncLogs     *memoryStore[model.PeopleFlowSyncLog]
+}
+
+// NewMemoryPeopleFlowRepository erstellt ein neues MemoryPeopleFlowRepository
+func NewMemoryPeopleFlowRepository() *MemoryPeopleFlowRepository {
+	return &MemoryPeopleFlowRepository{
+		integrations: newMemoryStore(
+			func(i *model.PeopleFlowIntegration) primitive.ObjectID { return i.ID },
+			func(i *model.PeopleFlowIntegration, id primitive.ObjectID) { i.ID = id },
+		),
+		employees: newMemoryStore(
+			func(e *model.PeopleFlowEmployee) primitive.ObjectID { return e.ID },
+			func(e *model.PeopleFlowEmployee, id primitive.ObjectID) { e.ID = id },
+		),
+		syncLogs: newMemoryStore(
+			func(l *model.PeopleFlowSyncLog) primitive.ObjectID { return l.ID },
+			func(l *model.PeopleFlowSyncLog, id primitive.ObjectID) { l.ID = id },
+		),
+	}
+}
+
+// === Integration Configuration Methods ===
+
+// GetIntegration holt die aktuelle PeopleFlow-Integration-Konfiguration
+func (r *MemoryPeopleFlowRepository) GetIntegration() (*model.PeopleFlowIntegration, error) {
+	integrations := r.integrations.all()
+	if len(integrations) == 0 {
+		return nil, nil // Keine Integration konfiguriert
+	}
+	return integrations[0], nil
+}
+
+// SaveIntegration speichert oder aktualisiert die PeopleFlow-Integration-Konfiguration
+func (r *MemoryPeopleFlowRepository) SaveIntegration(integration *model.PeopleFlowIntegration) error {
+	integration.UpdatedAt = time.Now()
+
+	if integration.ID.IsZero() {
+		integration.CreatedAt = time.Now()
+		return r.integrations.insert(integration)
+	}
+
+	r.integrations.modify(integration.ID, func(stored *model.PeopleFlowIntegration) { *stored = *integration })
+	return nil
+}
+
+// UpdateIntegrationStatus aktualisiert den Status der Integration
+func (r *MemoryPeopleFlowRepository) UpdateIntegrationStatus(isActive bool, lastSync time.Time, status string, syncedEmployees int) error {
+	integration, _ := r.GetIntegration()
+	if integration == nil {
+		return nil
+	}
+
+	r.integrations.modify(integration.ID, func(stored *model.PeopleFlowIntegration) {
+		stored.IsActive = isActive
+		stored.LastSync = lastSync
+		stored.LastSyncStatus = status
+		stored.SyncedEmployees = syncedEmployees
+		stored.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// === Employee Methods ===
+
+// SaveEmployee speichert oder aktualisiert einen PeopleFlow-Mitarbeiter
+func (r *MemoryPeopleFlowRepository) SaveEmployee(employee *model.PeopleFlowEmployee) error {
+	employee.UpdatedAt = time.Now()
+
+	// Prüfen, ob Mitarbeiter bereits existiert (nach E-Mail)
+	existingEmployee, err := r.FindEmployeeByEmail(employee.Email)
+	if err == nil && existingEmployee != nil {
+		employee.ID = existingEmployee.ID
+		employee.CreatedAt = existingEmployee.CreatedAt
+		r.employees.modify(employee.ID, func(stored *model.PeopleFlowEmployee) { *stored = *employee })
+		return nil
+	}
+
+	employee.CreatedAt = time.Now()
+	return r.employees.insert(employee)
+}
+
+// FindEmployeeByEmail findet einen PeopleFlow-Mitarbeiter anhand der E-Mail
+func (r *MemoryPeopleFlowRepository) FindEmployeeByEmail(email string) (*model.PeopleFlowEmployee, error) {
+	return r.employees.first(func(e *model.PeopleFlowEmployee) bool { return e.Email == email })
+}
+
+// FindEmployeeByPeopleFlowID findet einen Mitarbeiter anhand der PeopleFlow-ID
+func (r *MemoryPeopleFlowRepository) FindEmployeeByPeopleFlowID(peopleFlowID string) (*model.PeopleFlowEmployee, error) {
+	return r.employees.first(func(e *model.PeopleFlowEmployee) bool { return e.PeopleFlowID == peopleFlowID })
+}
+
+// FindAllEmployees findet alle PeopleFlow-Mitarbeiter
+func (r *MemoryPeopleFlowRepository) FindAllEmployees() ([]*model.PeopleFlowEmployee, error) {
+	return sortItems(r.employees.all(), employeesByName), nil
+}
+
+// FindDriverEligibleEmployees findet alle Mitarbeiter, die als Fahrer geeignet sind
+func (r *MemoryPeopleFlowRepository) FindDriverEligibleEmployees() ([]*model.PeopleFlowEmployee, error) {
+	employees := r.employees.filter(func(e *model.PeopleFlowEmployee) bool {
+		return e.IsDriverEligible && e.HasValidLicense && e.Status == "active"
+	})
+	return sortItems(employees, employeesByName), nil
+}
+
+// UpdateEmployeeSyncStatus aktualisiert den Sync-Status eines Mitarbeiters
+func (r *MemoryPeopleFlowRepository) UpdateEmployeeSyncStatus(email string, status string) error {
+	employee, err := r.FindEmployeeByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	r.employees.modify(employee.ID, func(stored *model.PeopleFlowEmployee) {
+		stored.SyncStatus = status
+		stored.LastSyncedAt = time.Now()
+		stored.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// DeleteEmployee löscht einen PeopleFlow-Mitarbeiter
+func (r *MemoryPeopleFlowRepository) DeleteEmployee(email string) error {
+	employee, err := r.FindEmployeeByEmail(email)
+	if err == nil {
+		r.employees.remove(employee.ID)
+	}
+	return nil
+}
+
+// GetEmployeeCount gibt die Anzahl der synchronisierten Mitarbeiter zurück
+func (r *MemoryPeopleFlowRepository) GetEmployeeCount() (int64, error) {
+	return r.employees.count(nil), nil
+}
+
+// === Sync Log Methods ===
+
+// CreateSyncLog erstellt einen neuen Sync-Log-Eintrag
+func (r *MemoryPeopleFlowRepository) CreateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
+	syncLog.CreatedAt = time.Now()
+	if syncLog.StartTime.IsZero() {
+		syncLog.StartTime = time.Now()
+	}
+	return r.syncLogs.insert(syncLog)
+}
+
+// UpdateSyncLog aktualisiert einen bestehenden Sync-Log-Eintrag
+func (r *MemoryPeopleFlowRepository) UpdateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
+	if syncLog.EndTime.IsZero() {
+		syncLog.EndTime = time.Now()
+	}
+	r.syncLogs.modify(syncLog.ID, func(stored *model.PeopleFlowSyncLog) { *stored = *syncLog })
+	return nil
+}
+
+// FindRecentSyncLogs findet die letzten Sync-Logs
+func (r *MemoryPeopleFlowRepository) FindRecentSyncLogs(limit int) ([]*model.PeopleFlowSyncLog, error) {
+	logs := sortItems(r.syncLogs.all(), func(a, b *model.PeopleFlowSyncLog) bool {
+		return a.StartTime.After(b.StartTime)
+	})
+	return pageItems(logs, 0, limit), nil
+}
+
+// employeesByName sortiert Mitarbeiter nach Nachname und Vorname
+func employeesByName(a, b *model.PeopleFlowEmployee) bool {
+	if a.LastName != b.LastName {
+		return a.LastName < b.LastName
+	}
+	return a.FirstName < b.FirstName
+}
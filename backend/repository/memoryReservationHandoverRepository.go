@@ -0,0 +1,82 @@
+// backend/repository/memoryReservationHandoverRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryReservationHandoverRepository hält Übergabeprotokolle im Arbeitsspeicher
+type MemoryReservationHandoverRepository struct {
+	store *memoryStore[model.ReservationHandover]
+}
+
+// NewMemoryReservationHandoverRepository erstellt ein neues MemoryReservationHandoverRepository
+func NewMemoryReservationHandoverRepository() *MemoryReservationHandoverRepository {
+	return &MemoryReservationHandoverRepository{
+		store: newMemoryStore(
+			func(h *model.ReservationHandover) primitive.ObjectID { return h.ID },
+			func(h *model.ReservationHandover, id primitive.ObjectID) { h.ID = id },
+		),
+	}
+}
+
+// Create erstellt ein neues Übergabeprotokoll
+func (r *MemoryReservationHandoverRepository) Create(handover *model.ReservationHandover) error {
+	handover.CreatedAt = time.Now()
+	handover.UpdatedAt = time.Now()
+	return r.store.insert(handover)
+}
+
+// FindByID findet ein Übergabeprotokoll anhand seiner ID
+func (r *MemoryReservationHandoverRepository) FindByID(id string) (*model.ReservationHandover, error) {
+	return r.store.getHex(id)
+}
+
+// FindByReservation findet alle Übergabeprotokolle einer Reservierung in zeitlicher Reihenfolge
+func (r *MemoryReservationHandoverRepository) FindByReservation(reservationID string) ([]*model.ReservationHandover, error) {
+	objectID, err := primitive.ObjectIDFromHex(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	handovers := r.store.filter(func(h *model.ReservationHandover) bool { return h.ReservationID == objectID })
+	return sortItems(handovers, func(a, b *model.ReservationHandover) bool { return a.RecordedAt.Before(b.RecordedAt) }), nil
+}
+
+// FindByReservationAndType findet das Ausgabe- oder Rückgabeprotokoll einer Reservierung
+func (r *MemoryReservationHandoverRepository) FindByReservationAndType(reservationID string, handoverType model.HandoverType) (*model.ReservationHandover, error) {
+	objectID, err := primitive.ObjectIDFromHex(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	handover, err := r.store.first(func(h *model.ReservationHandover) bool {
+		return h.ReservationID == objectID && h.Type == handoverType
+	})
+	if err != nil {
+		return nil, nil // Noch kein Protokoll vorhanden
+	}
+	return handover, nil
+}
+
+// FindByVehicle findet alle Übergabeprotokolle eines Fahrzeugs, neueste zuerst
+func (r *MemoryReservationHandoverRepository) FindByVehicle(vehicleID string) ([]*model.ReservationHandover, error) {
+	objectID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	handovers := r.store.filter(func(h *model.ReservationHandover) bool { return h.VehicleID == objectID })
+	return sortItems(handovers, func(a, b *model.ReservationHandover) bool { return a.RecordedAt.After(b.RecordedAt) }), nil
+}
+
+// Update aktualisiert ein Übergabeprotokoll
+func (r *MemoryReservationHandoverRepository) Update(handover *model.ReservationHandover) error {
+	handover.UpdatedAt = time.Now()
+	r.store.modify(handover.ID, func(stored *model.ReservationHandover) { *stored = *handover })
+	return nil
+}
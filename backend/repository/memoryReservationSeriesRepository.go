@@ -0,0 +1,60 @@
+// backend/repository/memoryReservationSeriesRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryReservationSeriesRepository hält Reservierungsserien im Arbeitsspeicher
+type MemoryReservationSeriesRepository struct {
+	store *memoryStore[model.ReservationSeries]
+}
+
+// NewMemoryReservationSeriesRepository erstellt ein neues MemoryReservationSeriesRepository
+func NewMemoryReservationSeriesRepository() *MemoryReservationSeriesRepository {
+	return &MemoryReservationSeriesRepository{
+		store: newMemoryStore(
+			func(s *model.ReservationSeries) primitive.ObjectID { return s.ID },
+			func(s *model.ReservationSeries, id primitive.ObjectID) { s.ID = id },
+		),
+	}
+}
+
+// Create erstellt eine neue Reservierungsserie
+func (r *MemoryReservationSeriesRepository) Create(series *model.ReservationSeries) error {
+	series.CreatedAt = time.Now()
+	series.UpdatedAt = time.Now()
+	return r.store.insert(series)
+}
+
+// FindByID findet eine Reservierungsserie anhand ihrer ID
+func (r *MemoryReservationSeriesRepository) FindByID(id string) (*model.ReservationSeries, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Reservierungsserien, sortiert nach Beginn
+func (r *MemoryReservationSeriesRepository) FindAll() ([]model.ReservationSeries, error) {
+	items := sortItems(r.store.all(), func(a, b *model.ReservationSeries) bool { return a.StartTime.Before(b.StartTime) })
+
+	var series []model.ReservationSeries
+	for _, item := range items {
+		series = append(series, *item)
+	}
+	return series, nil
+}
+
+// Update aktualisiert eine Reservierungsserie
+func (r *MemoryReservationSeriesRepository) Update(series *model.ReservationSeries) error {
+	series.UpdatedAt = time.Now()
+	r.store.modify(series.ID, func(stored *model.ReservationSeries) { *stored = *series })
+	return nil
+}
+
+// Delete löscht eine Reservierungsserie
+func (r *MemoryReservationSeriesRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
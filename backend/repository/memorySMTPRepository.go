@@ -0,0 +1,242 @@
+// backend/repository/memorySMTPRepository.go
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemorySMTPRepository hält SMTP-Konfiguration, Vorlagen, Logs und Benachrichtigungseinstellungen im Arbeitsspeicher
+type MemorySMTPRepository struct {
+	mu        sync.RWMutex
+	config    *model.SMTPConfig
+	templates *memoryStore[model.EmailTemplate]
+	logs      *memoryStore[model.EmailLog]
+	settings  *memoryStore[model.NotificationSettings]
+}
+
+// NewMemorySMTPRepository erstellt ein neues MemorySMTPRepository
+func NewMemorySMTPRepository() *MemorySMTPRepository {
+	return &MemorySMTPRepository{
+		templates: newMemoryStore(
+			func(t *model.EmailTemplate) primitive.ObjectID { return t.ID },
+			func(t *model.EmailTemplate, id primitive.ObjectID) { t.ID = id },
+		),
+		logs: newMemoryStore(
+			func(l *model.EmailLog) primitive.ObjectID { return l.ID },
+			func(l *model.EmailLog, id primitive.ObjectID) { l.ID = id },
+		),
+		settings: newMemoryStore(
+			func(s *model.NotificationSettings) primitive.ObjectID { return s.ID },
+			func(s *model.NotificationSettings, id primitive.ObjectID) { s.ID = id },
+		),
+	}
+}
+
+// SaveSMTPConfig speichert oder aktualisiert die SMTP-Konfiguration
+func (r *MemorySMTPRepository) SaveSMTPConfig(config *model.SMTPConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	stored := *config
+	stored.UpdatedAt = now
+
+	// Es sollte nur eine SMTP-Konfiguration geben
+	if r.config == nil {
+		stored.ID = primitive.NewObjectID()
+		stored.CreatedAt = now
+		config.ID = stored.ID
+	} else {
+		stored.ID = r.config.ID
+		stored.CreatedAt = r.config.CreatedAt
+	}
+
+	r.config = &stored
+	return nil
+}
+
+// GetSMTPConfig holt die aktuelle SMTP-Konfiguration
+func (r *MemorySMTPRepository) GetSMTPConfig() (*model.SMTPConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.config == nil {
+		return nil, nil // Keine Konfiguration gefunden
+	}
+	config := *r.config
+	return &config, nil
+}
+
+// TestSMTPConnection testet die SMTP-Verbindung
+func (r *MemorySMTPRepository) TestSMTPConnection(config *model.SMTPConfig) error {
+	return nil
+}
+
+// SaveEmailTemplate speichert oder aktualisiert eine E-Mail-Vorlage
+func (r *MemorySMTPRepository) SaveEmailTemplate(template *model.EmailTemplate) error {
+	now := time.Now()
+	template.UpdatedAt = now
+
+	if template.ID.IsZero() {
+		template.CreatedAt = now
+		return r.templates.insert(template)
+	}
+
+	r.templates.modify(template.ID, func(stored *model.EmailTemplate) { *stored = *template })
+	return nil
+}
+
+// GetEmailTemplate holt eine aktive E-Mail-Vorlage nach Typ
+func (r *MemorySMTPRepository) GetEmailTemplate(templateType model.EmailTemplateType) (*model.EmailTemplate, error) {
+	template, err := r.templates.first(func(t *model.EmailTemplate) bool {
+		return t.Type == templateType && t.IsActive
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return template, nil
+}
+
+// GetAllEmailTemplates holt alle E-Mail-Vorlagen
+func (r *MemorySMTPRepository) GetAllEmailTemplates() ([]*model.EmailTemplate, error) {
+	return r.templates.all(), nil
+}
+
+// CreateDefaultEmailTemplates erstellt Standard-E-Mail-Vorlagen
+func (r *MemorySMTPRepository) CreateDefaultEmailTemplates() error {
+	for _, template := range defaultEmailTemplates() {
+		existing, _ := r.GetEmailTemplate(template.Type)
+		if existing == nil {
+			if err := r.SaveEmailTemplate(template); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LogEmail protokolliert einen E-Mail-Versand
+func (r *MemorySMTPRepository) LogEmail(log *model.EmailLog) error {
+	log.ID = primitive.NewObjectID()
+	log.CreatedAt = time.Now()
+	return r.logs.insert(log)
+}
+
+// GetEmailLogs holt E-Mail-Logs mit Paginierung
+func (r *MemorySMTPRepository) GetEmailLogs(limit, offset int) ([]*model.EmailLog, error) {
+	logs := sortItems(r.logs.all(), func(a, b *model.EmailLog) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return pageItems(logs, offset, limit), nil
+}
+
+// GetEmailLogsByStatus holt E-Mail-Logs eines Status mit Paginierung
+func (r *MemorySMTPRepository) GetEmailLogsByStatus(status model.EmailStatus, limit, offset int) ([]*model.EmailLog, error) {
+	logs := r.logs.filter(func(l *model.EmailLog) bool { return l.Status == status })
+	logs = sortItems(logs, func(a, b *model.EmailLog) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return pageItems(logs, offset, limit), nil
+}
+
+// GetEmailLog holt einen einzelnen E-Mail-Log-Eintrag
+func (r *MemorySMTPRepository) GetEmailLog(id string) (*model.EmailLog, error) {
+	return r.logs.getHex(id)
+}
+
+// ClaimNextEmail reserviert atomar die älteste fällige E-Mail der Warteschlange
+func (r *MemorySMTPRepository) ClaimNextEmail(now, lockUntil time.Time) (*model.EmailLog, error) {
+	var claimed *model.EmailLog
+	r.logs.modifyAll(func(l *model.EmailLog) bool {
+		return claimed == nil && l.Status == model.EmailStatusPending && !l.NextAttemptAt.After(now)
+	}, func(l *model.EmailLog) {
+		attemptAt := now
+		l.Attempts++
+		l.NextAttemptAt = lockUntil
+		l.LastAttemptAt = &attemptAt
+		copied := *l
+		claimed = &copied
+	})
+	return claimed, nil
+}
+
+// MarkEmailSent markiert eine E-Mail als zugestellt
+func (r *MemorySMTPRepository) MarkEmailSent(id primitive.ObjectID, sentAt time.Time) error {
+	r.logs.modify(id, func(l *model.EmailLog) {
+		l.Status = model.EmailStatusSent
+		l.SentAt = &sentAt
+		l.Error = ""
+	})
+	return nil
+}
+
+// MarkEmailFailed hält einen fehlgeschlagenen Versuch fest
+func (r *MemorySMTPRepository) MarkEmailFailed(id primitive.ObjectID, errorMessage string, retryAt *time.Time) error {
+	r.logs.modify(id, func(l *model.EmailLog) {
+		l.Error = errorMessage
+		if retryAt != nil {
+			l.NextAttemptAt = *retryAt
+		} else {
+			l.Status = model.EmailStatusFailed
+		}
+	})
+	return nil
+}
+
+// RequeueEmail stellt eine endgültig fehlgeschlagene E-Mail mit zurückgesetzten Versuchen erneut ein
+func (r *MemorySMTPRepository) RequeueEmail(id primitive.ObjectID, at time.Time) (bool, error) {
+	requeued := r.logs.modifyAll(func(l *model.EmailLog) bool {
+		return l.ID == id && l.Status == model.EmailStatusFailed
+	}, func(l *model.EmailLog) {
+		l.Status = model.EmailStatusPending
+		l.Attempts = 0
+		l.NextAttemptAt = at
+		l.Error = ""
+	})
+	return requeued > 0, nil
+}
+
+// SaveNotificationSettings speichert oder aktualisiert Benachrichtigungseinstellungen
+func (r *MemorySMTPRepository) SaveNotificationSettings(settings *model.NotificationSettings) error {
+	now := time.Now()
+	match := func(s *model.NotificationSettings) bool { return s.UserID == settings.UserID }
+
+	updated := r.settings.modifyAll(match, func(stored *model.NotificationSettings) {
+		stored.EmailNotifications = settings.EmailNotifications
+		stored.BookingReminders = settings.BookingReminders
+		stored.FuelReminders = settings.FuelReminders
+		stored.MaintenanceAlerts = settings.MaintenanceAlerts
+		stored.UrgentReports = settings.UrgentReports
+		stored.ReservationRequests = settings.ReservationRequests
+		stored.ReservationDecisions = settings.ReservationDecisions
+		stored.ExpiryReminders = settings.ExpiryReminders
+		stored.QuietHoursEnabled = settings.QuietHoursEnabled
+		stored.QuietHoursStart = settings.QuietHoursStart
+		stored.QuietHoursEnd = settings.QuietHoursEnd
+		stored.UpdatedAt = now
+	})
+	if updated > 0 {
+		return nil
+	}
+
+	stored := *settings
+	stored.ID = primitive.NilObjectID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	if err := r.settings.insert(&stored); err != nil {
+		return err
+	}
+	settings.ID = stored.ID
+	return nil
+}
+
+// GetNotificationSettings holt Benachrichtigungseinstellungen für einen Benutzer
+func (r *MemorySMTPRepository) GetNotificationSettings(userID primitive.ObjectID) (*model.NotificationSettings, error) {
+	settings, err := r.settings.first(func(s *model.NotificationSettings) bool { return s.UserID == userID })
+	if err != nil {
+		// Standardeinstellungen zurückgeben
+		return defaultNotificationSettings(userID), nil
+	}
+	return settings, nil
+}
@@ -0,0 +1,135 @@
+// backend/repository/memoryScheduledJobRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryScheduledJobRepository hält Hintergrundjobs und deren Laufhistorie im Arbeitsspeicher
+type MemoryScheduledJobRepository struct {
+	jobs *memoryStore[model.ScheduledJob]
+	runs *memoryStore[model.JobRun]
+}
+
+// NewMemoryScheduledJobRepository erstellt ein neues MemoryScheduledJobRepository
+func NewMemoryScheduledJobRepository() *MemoryScheduledJobRepository {
+	return &MemoryScheduledJobRepository{
+		jobs: newMemoryStore(
+			func(j *model.ScheduledJob) primitive.ObjectID { return j.ID },
+			func(j *model.ScheduledJob, id primitive.ObjectID) { j.ID = id },
+		),
+		runs: newMemoryStore(
+			func(r *model.JobRun) primitive.ObjectID { return r.ID },
+			func(r *model.JobRun, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// Create legt einen neuen Job an
+func (r *MemoryScheduledJobRepository) Create(job *model.ScheduledJob) error {
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+	return r.jobs.insert(job)
+}
+
+// FindByName findet einen Job anhand seines Namens
+func (r *MemoryScheduledJobRepository) FindByName(name string) (*model.ScheduledJob, error) {
+	job, err := r.jobs.first(func(j *model.ScheduledJob) bool { return j.Name == name })
+	if err != nil {
+		return nil, nil // Job noch nicht angelegt
+	}
+	return job, nil
+}
+
+// FindAll findet alle Jobs sortiert nach Namen
+func (r *MemoryScheduledJobRepository) FindAll() ([]*model.ScheduledJob, error) {
+	return sortItems(r.jobs.all(), func(a, b *model.ScheduledJob) bool { return a.Name < b.Name }), nil
+}
+
+// UpdateDefinition aktualisiert Beschreibung und Zeitplan eines Jobs
+func (r *MemoryScheduledJobRepository) UpdateDefinition(name, description, schedule string, nextRunAt time.Time) error {
+	r.jobs.modifyAll(func(j *model.ScheduledJob) bool { return j.Name == name }, func(j *model.ScheduledJob) {
+		j.Description = description
+		j.Schedule = schedule
+		j.NextRunAt = nextRunAt
+		j.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// SetPaused pausiert einen Job oder setzt ihn fort
+func (r *MemoryScheduledJobRepository) SetPaused(name string, paused bool) error {
+	r.jobs.modifyAll(func(j *model.ScheduledJob) bool { return j.Name == name }, func(j *model.ScheduledJob) {
+		j.Paused = paused
+		j.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// AcquireLease sperrt einen Job atomar für eine Instanz. Ist dueBefore gesetzt,
+// gelingt die Sperre nur, wenn der nächste Lauf bis zu diesem Zeitpunkt fällig ist.
+func (r *MemoryScheduledJobRepository) AcquireLease(name, owner string, until time.Time, dueBefore *time.Time) (bool, error) {
+	now := time.Now()
+	acquired := r.jobs.modifyAll(func(j *model.ScheduledJob) bool {
+		if j.Name != name || (j.LeaseUntil != nil && !j.LeaseUntil.Before(now)) {
+			return false
+		}
+		return dueBefore == nil || !j.NextRunAt.After(*dueBefore)
+	}, func(j *model.ScheduledJob) {
+		j.LeaseOwner = owner
+		j.LeaseUntil = &until
+	})
+	return acquired == 1, nil
+}
+
+// RenewLease verlängert die Sperre eines laufenden Jobs, solange sie noch dieser Instanz gehört
+func (r *MemoryScheduledJobRepository) RenewLease(name, owner string, until time.Time) (bool, error) {
+	renewed := r.jobs.modifyAll(func(j *model.ScheduledJob) bool { return j.Name == name && j.LeaseOwner == owner }, func(j *model.ScheduledJob) {
+		j.LeaseUntil = &until
+	})
+	return renewed == 1, nil
+}
+
+// ReleaseLease gibt die Sperre eines Jobs frei, ohne einen Lauf zu protokollieren
+func (r *MemoryScheduledJobRepository) ReleaseLease(name, owner string, nextRunAt time.Time) error {
+	r.jobs.modifyAll(func(j *model.ScheduledJob) bool { return j.Name == name && j.LeaseOwner == owner }, func(j *model.ScheduledJob) {
+		j.NextRunAt = nextRunAt
+		j.LeaseOwner = ""
+		j.LeaseUntil = nil
+	})
+	return nil
+}
+
+// CompleteRun übernimmt das Ergebnis eines Laufs in den Job und gibt die Sperre frei
+func (r *MemoryScheduledJobRepository) CompleteRun(name, owner string, run *model.JobRun, nextRunAt time.Time) error {
+	r.jobs.modifyAll(func(j *model.ScheduledJob) bool { return j.Name == name && j.LeaseOwner == owner }, func(j *model.ScheduledJob) {
+		startedAt := run.StartedAt
+		j.NextRunAt = nextRunAt
+		j.LastRunAt = &startedAt
+		j.LastStatus = run.Status
+		j.LastError = run.Error
+		j.LastDurationMs = run.DurationMs
+		j.LeaseOwner = ""
+		j.LeaseUntil = nil
+		j.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// CreateRun speichert einen abgeschlossenen Joblauf und entfernt Läufe außerhalb der Aufbewahrungsfrist
+func (r *MemoryScheduledJobRepository) CreateRun(run *model.JobRun) error {
+	cutoff := time.Now().Add(-jobRunRetention)
+	r.runs.removeAll(func(existing *model.JobRun) bool { return existing.FinishedAt.Before(cutoff) })
+	return r.runs.insert(run)
+}
+
+// FindRuns findet die letzten Läufe eines Jobs, neueste zuerst
+func (r *MemoryScheduledJobRepository) FindRuns(name string, limit int) ([]*model.JobRun, error) {
+	runs := r.runs.filter(func(run *model.JobRun) bool { return run.JobName == name })
+	sortItems(runs, func(a, b *model.JobRun) bool { return a.StartedAt.After(b.StartedAt) })
+	return pageItems(runs, 0, limit), nil
+}
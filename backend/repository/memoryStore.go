@@ -0,0 +1,254 @@
+// backend/repository/memoryStore.go
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryStore hält Dokumente eines Typs threadsicher im Arbeitsspeicher.
+// Gespeichert und herausgegeben werden immer Kopien, damit Aufrufer den
+// Zustand nicht an den Repository-Methoden vorbei verändern können.
+type memoryStore[T any] struct {
+	mu    sync.RWMutex
+	items map[primitive.ObjectID]T
+	order []primitive.ObjectID
+	getID func(*T) primitive.ObjectID
+	setID func(*T, primitive.ObjectID)
+}
+
+// newMemoryStore erstellt einen neuen memoryStore
+func newMemoryStore[T any](getID func(*T) primitive.ObjectID, setID func(*T, primitive.ObjectID)) *memoryStore[T] {
+	return &memoryStore[T]{
+		items: make(map[primitive.ObjectID]T),
+		getID: getID,
+		setID: setID,
+	}
+}
+
+// insert legt ein Dokument an und vergibt bei Bedarf eine neue ID
+func (s *memoryStore[T]) insert(item *T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.getID(item)
+	if id.IsZero() {
+		id = primitive.NewObjectID()
+		s.setID(item, id)
+	}
+	if _, exists := s.items[id]; exists {
+		return duplicateKeyError()
+	}
+
+	s.items[id] = *item
+	s.order = append(s.order, id)
+	return nil
+}
+
+// duplicateKeyError bildet den Fehler von MongoDB bei verletzten eindeutigen Indizes nach
+func duplicateKeyError() error {
+	return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+}
+
+// get liefert eine Kopie des Dokuments mit der angegebenen ID
+func (s *memoryStore[T]) get(id primitive.ObjectID) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &item, nil
+}
+
+// getHex liefert eine Kopie des Dokuments mit der angegebenen Hex-ID
+func (s *memoryStore[T]) getHex(id string) (*T, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(objID)
+}
+
+// modify wendet fn auf das gespeicherte Dokument an und meldet, ob es existierte
+func (s *memoryStore[T]) modify(id primitive.ObjectID, fn func(*T)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[id]
+	if !ok {
+		return false
+	}
+	fn(&item)
+	s.items[id] = item
+	return true
+}
+
+// modifyAll wendet fn auf alle passenden Dokumente an und liefert deren Anzahl
+func (s *memoryStore[T]) modifyAll(match func(*T) bool, fn func(*T)) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modified := 0
+	for _, id := range s.order {
+		item := s.items[id]
+		if match(&item) {
+			fn(&item)
+			s.items[id] = item
+			modified++
+		}
+	}
+	return modified
+}
+
+// remove löscht das Dokument mit der angegebenen ID
+func (s *memoryStore[T]) remove(id primitive.ObjectID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return false
+	}
+	delete(s.items, id)
+	for i, existing := range s.order {
+		if existing == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// removeHex löscht das Dokument mit der angegebenen Hex-ID
+func (s *memoryStore[T]) removeHex(id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	s.remove(objID)
+	return nil
+}
+
+// removeAll löscht alle passenden Dokumente und liefert deren Anzahl
+func (s *memoryStore[T]) removeAll(match func(*T) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	kept := s.order[:0]
+	for _, id := range s.order {
+		item := s.items[id]
+		if match(&item) {
+			delete(s.items, id)
+			removed++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.order = kept
+	return removed
+}
+
+// filter liefert Kopien aller passenden Dokumente in Einfügereihenfolge
+func (s *memoryStore[T]) filter(match func(*T) bool) []*T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*T
+	for _, id := range s.order {
+		item := s.items[id]
+		if match == nil || match(&item) {
+			result = append(result, &item)
+		}
+	}
+	return result
+}
+
+// first liefert das erste passende Dokument oder mongo.ErrNoDocuments
+func (s *memoryStore[T]) first(match func(*T) bool) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, id := range s.order {
+		item := s.items[id]
+		if match(&item) {
+			return &item, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// count zählt alle passenden Dokumente
+func (s *memoryStore[T]) count(match func(*T) bool) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var n int64
+	for _, id := range s.order {
+		item := s.items[id]
+		if match == nil || match(&item) {
+			n++
+		}
+	}
+	return n
+}
+
+// all liefert alle Dokumente in Einfügereihenfolge
+func (s *memoryStore[T]) all() []*T {
+	return s.filter(nil)
+}
+
+// sortItems sortiert Dokumente stabil nach der angegebenen Vergleichsfunktion
+func sortItems[T any](items []*T, less func(a, b *T) bool) []*T {
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	return items
+}
+
+// pageItems wendet skip und limit wie bei einer MongoDB-Abfrage an (limit <= 0 bedeutet unbegrenzt)
+func pageItems[T any](items []*T, skip, limit int) []*T {
+	if skip > 0 {
+		if skip >= len(items) {
+			return nil
+		}
+		items = items[skip:]
+	}
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// applySet überträgt ein $set-Dokument über die BSON-Darstellung auf ein Modell
+func applySet[T any](item *T, set bson.M) error {
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	for key, value := range set {
+		doc[key] = value
+	}
+
+	raw, err = bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	var updated T
+	if err := bson.Unmarshal(raw, &updated); err != nil {
+		return err
+	}
+	*item = updated
+	return nil
+}
@@ -0,0 +1,159 @@
+// backend/repository/memoryTireRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryTireRepository hält Reifensätze, Reifenvorgaben und Wechselaktionen im Arbeitsspeicher
+type MemoryTireRepository struct {
+	sets      *memoryStore[model.TireSet]
+	settings  *memoryStore[model.TireSettings]
+	campaigns *memoryStore[model.TireSwapCampaign]
+}
+
+// NewMemoryTireRepository erstellt ein neues MemoryTireRepository
+func NewMemoryTireRepository() *MemoryTireRepository {
+	return &MemoryTireRepository{
+		sets: newMemoryStore(
+			func(s *model.TireSet) primitive.ObjectID { return s.ID },
+			func(s *model.TireSet, id primitive.ObjectID) { s.ID = id },
+		),
+		settings: newMemoryStore(
+			func(s *model.TireSettings) primitive.ObjectID { return s.ID },
+			func(s *model.TireSettings, id primitive.ObjectID) { s.ID = id },
+		),
+		campaigns: newMemoryStore(
+			func(c *model.TireSwapCampaign) primitive.ObjectID { return c.ID },
+			func(c *model.TireSwapCampaign, id primitive.ObjectID) { c.ID = id },
+		),
+	}
+}
+
+// CreateSet legt einen neuen Reifensatz an
+func (r *MemoryTireRepository) CreateSet(set *model.TireSet) error {
+	now := time.Now()
+	set.ID = primitive.NewObjectID()
+	set.CreatedAt = now
+	set.UpdatedAt = now
+	return r.sets.insert(set)
+}
+
+// UpdateSet aktualisiert einen Reifensatz samt Messungen und Saisonlaufleistung
+func (r *MemoryTireRepository) UpdateSet(set *model.TireSet) error {
+	set.UpdatedAt = time.Now()
+	found := r.sets.modify(set.ID, func(stored *model.TireSet) {
+		createdAt := stored.CreatedAt
+		*stored = *set
+		stored.Measurements = append([]model.TireTreadMeasurement(nil), set.Measurements...)
+		stored.SeasonUsage = append([]model.TireSeasonUsage(nil), set.SeasonUsage...)
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteSet löscht einen Reifensatz
+func (r *MemoryTireRepository) DeleteSet(id string) error {
+	return r.sets.removeHex(id)
+}
+
+// FindSetByID findet einen Reifensatz anhand seiner ID
+func (r *MemoryTireRepository) FindSetByID(id string) (*model.TireSet, error) {
+	return r.sets.getHex(id)
+}
+
+// FindSets findet alle Reifensätze zum Filter, sortiert nach Anlage
+func (r *MemoryTireRepository) FindSets(filter model.TireSetFilter) ([]*model.TireSet, error) {
+	sets := r.sets.filter(func(s *model.TireSet) bool {
+		if filter.VehicleID != nil && (s.VehicleID == nil || *s.VehicleID != *filter.VehicleID) {
+			return false
+		}
+		if filter.Location != "" && s.Location != filter.Location {
+			return false
+		}
+		if filter.Season != "" && s.Season != filter.Season {
+			return false
+		}
+		return filter.IncludeRetired || !s.Retired
+	})
+	return sortItems(sets, func(a, b *model.TireSet) bool { return a.CreatedAt.Before(b.CreatedAt) }), nil
+}
+
+// GetSettings holt die Reifenvorgaben
+func (r *MemoryTireRepository) GetSettings() (*model.TireSettings, error) {
+	all := r.settings.all()
+	if len(all) == 0 {
+		// Standardvorgaben zurückgeben
+		return model.DefaultTireSettings(), nil
+	}
+	return all[0], nil
+}
+
+// SaveSettings speichert oder aktualisiert die Reifenvorgaben
+func (r *MemoryTireRepository) SaveSettings(settings *model.TireSettings) error {
+	now := time.Now()
+
+	updated := r.settings.modifyAll(func(*model.TireSettings) bool { return true }, func(stored *model.TireSettings) {
+		id, createdAt := stored.ID, stored.CreatedAt
+		*stored = *settings
+		stored.ID = id
+		stored.CreatedAt = createdAt
+		stored.UpdatedAt = now
+	})
+	if updated > 0 {
+		return nil
+	}
+
+	stored := *settings
+	stored.ID = primitive.NilObjectID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	if err := r.settings.insert(&stored); err != nil {
+		return err
+	}
+	settings.ID = stored.ID
+	return nil
+}
+
+// CreateCampaign legt eine neue Wechselaktion an
+func (r *MemoryTireRepository) CreateCampaign(campaign *model.TireSwapCampaign) error {
+	now := time.Now()
+	campaign.ID = primitive.NewObjectID()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+	return r.campaigns.insert(campaign)
+}
+
+// UpdateCampaign aktualisiert die geplanten Wechsel einer Wechselaktion
+func (r *MemoryTireRepository) UpdateCampaign(campaign *model.TireSwapCampaign) error {
+	campaign.UpdatedAt = time.Now()
+	found := r.campaigns.modify(campaign.ID, func(stored *model.TireSwapCampaign) {
+		stored.StartDate = campaign.StartDate
+		stored.EndDate = campaign.EndDate
+		stored.Items = append([]model.TireSwapItem(nil), campaign.Items...)
+		stored.UpdatedAt = campaign.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindCampaignByID findet eine Wechselaktion anhand ihrer ID
+func (r *MemoryTireRepository) FindCampaignByID(id string) (*model.TireSwapCampaign, error) {
+	return r.campaigns.getHex(id)
+}
+
+// FindCampaigns findet alle Wechselaktionen, die jüngste zuerst
+func (r *MemoryTireRepository) FindCampaigns() ([]*model.TireSwapCampaign, error) {
+	campaigns := r.campaigns.filter(nil)
+	return sortItems(campaigns, func(a, b *model.TireSwapCampaign) bool { return a.StartDate.After(b.StartDate) }), nil
+}
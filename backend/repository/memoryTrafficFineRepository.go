@@ -0,0 +1,86 @@
+// backend/repository/memoryTrafficFineRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryTrafficFineRepository hält Bußgelder im Arbeitsspeicher
+type MemoryTrafficFineRepository struct {
+	store *memoryStore[model.TrafficFine]
+}
+
+// NewMemoryTrafficFineRepository erstellt ein neues MemoryTrafficFineRepository
+func NewMemoryTrafficFineRepository() *MemoryTrafficFineRepository {
+	return &MemoryTrafficFineRepository{
+		store: newMemoryStore(
+			func(f *model.TrafficFine) primitive.ObjectID { return f.ID },
+			func(f *model.TrafficFine, id primitive.ObjectID) { f.ID = id },
+		),
+	}
+}
+
+// Create legt ein neues Bußgeld an
+func (r *MemoryTrafficFineRepository) Create(fine *model.TrafficFine) error {
+	now := time.Now()
+	fine.ID = primitive.NewObjectID()
+	fine.CreatedAt = now
+	fine.UpdatedAt = now
+	return r.store.insert(fine)
+}
+
+// Update aktualisiert ein Bußgeld
+func (r *MemoryTrafficFineRepository) Update(fine *model.TrafficFine) error {
+	fine.UpdatedAt = time.Now()
+	found := r.store.modify(fine.ID, func(stored *model.TrafficFine) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *fine
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht ein Bußgeld
+func (r *MemoryTrafficFineRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindByID findet ein Bußgeld anhand seiner ID
+func (r *MemoryTrafficFineRepository) FindByID(id string) (*model.TrafficFine, error) {
+	return r.store.getHex(id)
+}
+
+// Find findet alle Bußgelder zum Filter, die jüngsten Tatzeitpunkte zuerst
+func (r *MemoryTrafficFineRepository) Find(filter model.TrafficFineFilter) ([]*model.TrafficFine, error) {
+	fines := r.store.filter(func(f *model.TrafficFine) bool {
+		if filter.VehicleID != nil && f.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.DriverID != nil && (f.DriverID == nil || *f.DriverID != *filter.DriverID) {
+			return false
+		}
+		if len(filter.Statuses) > 0 {
+			matched := false
+			for _, status := range filter.Statuses {
+				if f.Status == status {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	})
+	return sortItems(fines, func(a, b *model.TrafficFine) bool { return a.OffenseTime.After(b.OffenseTime) }), nil
+}
@@ -0,0 +1,167 @@
+// backend/repository/memoryUserRepository.go
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryUserRepository hält Benutzer im Arbeitsspeicher
+type MemoryUserRepository struct {
+	store *memoryStore[model.User]
+}
+
+// NewMemoryUserRepository erstellt ein neues MemoryUserRepository
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		store: newMemoryStore(
+			func(u *model.User) primitive.ObjectID { return u.ID },
+			func(u *model.User, id primitive.ObjectID) { u.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Benutzer
+func (r *MemoryUserRepository) Create(user *model.User) error {
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	// Passwort hashen
+	if err := user.HashPassword(); err != nil {
+		return err
+	}
+
+	return r.store.insert(user)
+}
+
+// FindByID findet einen Benutzer anhand seiner ID
+func (r *MemoryUserRepository) FindByID(id string) (*model.User, error) {
+	return r.store.getHex(id)
+}
+
+// FindByEmail findet einen Benutzer anhand seiner E-Mail
+func (r *MemoryUserRepository) FindByEmail(email string) (*model.User, error) {
+	return r.store.first(func(u *model.User) bool { return u.Email == email })
+}
+
+// FindByUsername findet einen Benutzer anhand seines Benutzernamens.
+// Das User-Modell kennt kein Benutzernamen-Feld, daher gibt es wie in MongoDB nie einen Treffer.
+func (r *MemoryUserRepository) FindByUsername(username string) (*model.User, error) {
+	return nil, mongo.ErrNoDocuments
+}
+
+// FindAll findet alle Benutzer
+func (r *MemoryUserRepository) FindAll() ([]*model.User, error) {
+	return r.store.all(), nil
+}
+
+// GetAll gibt alle Benutzer zurück
+func (r *MemoryUserRepository) GetAll() ([]*model.User, error) {
+	return r.GetUsersWithFilter(bson.M{})
+}
+
+// GetUsersWithFilter gibt Benutzer basierend auf einem Filter zurück.
+// Unterstützt werden einfache Gleichheitsbedingungen auf BSON-Feldnamen.
+func (r *MemoryUserRepository) GetUsersWithFilter(filter bson.M) ([]*model.User, error) {
+	var users []*model.User
+	for _, user := range r.store.all() {
+		matches, err := matchesEqualityFilter(user, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			users = append(users, user)
+		}
+	}
+
+	return sortItems(users, func(a, b *model.User) bool {
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		return a.FirstName < b.FirstName
+	}), nil
+}
+
+// GetUserByEmail findet einen Benutzer anhand seiner E-Mail-Adresse
+func (r *MemoryUserRepository) GetUserByEmail(email string) (*model.User, error) {
+	user, err := r.FindByEmail(email)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil // Kein Benutzer gefunden, aber kein Fehler
+	}
+	return user, err
+}
+
+// Update aktualisiert einen bestehenden Benutzer
+func (r *MemoryUserRepository) Update(user *model.User) error {
+	user.UpdatedAt = time.Now()
+
+	found := r.store.modify(user.ID, func(stored *model.User) {
+		stored.FirstName = user.FirstName
+		stored.LastName = user.LastName
+		stored.Email = user.Email
+		stored.Password = user.Password
+		stored.Role = user.Role
+		stored.Status = user.Status
+		stored.UpdatedAt = user.UpdatedAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht einen Benutzer
+func (r *MemoryUserRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// Count gibt die Anzahl der Benutzer zurück
+func (r *MemoryUserRepository) Count() (int64, error) {
+	return r.store.count(nil), nil
+}
+
+// CreateAdminUserIfNotExists erstellt einen Admin-Benutzer, falls keiner existiert
+func (r *MemoryUserRepository) CreateAdminUserIfNotExists() error {
+	if r.store.count(func(u *model.User) bool { return u.Role == model.RoleAdmin }) > 0 {
+		return nil
+	}
+
+	admin, err := newDefaultAdminUser()
+	if err != nil {
+		return err
+	}
+	return r.store.insert(admin)
+}
+
+// matchesEqualityFilter prüft, ob ein Dokument alle Gleichheitsbedingungen eines Filters erfüllt
+func matchesEqualityFilter(item interface{}, filter bson.M) (bool, error) {
+	if len(filter) == 0 {
+		return true, nil
+	}
+
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return false, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return false, err
+	}
+
+	for key, expected := range filter {
+		if strings.HasPrefix(key, "$") {
+			return false, fmt.Errorf("operator %s wird im Speicher-Backend nicht unterstützt", key)
+		}
+		if fmt.Sprint(doc[key]) != fmt.Sprint(expected) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
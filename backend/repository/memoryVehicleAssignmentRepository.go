@@ -0,0 +1,98 @@
+// backend/repository/memoryVehicleAssignmentRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleAssignmentRepository hält die Zuweisungshistorie im Arbeitsspeicher
+type MemoryVehicleAssignmentRepository struct {
+	store *memoryStore[model.VehicleAssignment]
+}
+
+// NewMemoryVehicleAssignmentRepository erstellt ein neues MemoryVehicleAssignmentRepository
+func NewMemoryVehicleAssignmentRepository() *MemoryVehicleAssignmentRepository {
+	return &MemoryVehicleAssignmentRepository{
+		store: newMemoryStore(
+			func(a *model.VehicleAssignment) primitive.ObjectID { return a.ID },
+			func(a *model.VehicleAssignment, id primitive.ObjectID) { a.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Zuweisungseintrag
+func (r *MemoryVehicleAssignmentRepository) Create(assignment *model.VehicleAssignment) error {
+	assignment.CreatedAt = time.Now()
+	assignment.UpdatedAt = time.Now()
+	return r.store.insert(assignment)
+}
+
+// Update aktualisiert einen Zuweisungseintrag
+func (r *MemoryVehicleAssignmentRepository) Update(assignment *model.VehicleAssignment) error {
+	assignment.UpdatedAt = time.Now()
+	r.store.modify(assignment.ID, func(stored *model.VehicleAssignment) { *stored = *assignment })
+	return nil
+}
+
+// FindByDriverID findet alle Zuweisungen für einen Fahrer
+func (r *MemoryVehicleAssignmentRepository) FindByDriverID(driverID string) ([]*model.VehicleAssignment, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := r.store.filter(func(a *model.VehicleAssignment) bool { return a.DriverID == objID })
+	return sortItems(assignments, newestAssignmentFirst), nil
+}
+
+// FindByVehicleID findet alle Zuweisungen für ein Fahrzeug
+func (r *MemoryVehicleAssignmentRepository) FindByVehicleID(vehicleID string) ([]*model.VehicleAssignment, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := r.store.filter(func(a *model.VehicleAssignment) bool { return a.VehicleID == objID })
+	return sortItems(assignments, newestAssignmentFirst), nil
+}
+
+// FindActiveAssignmentByDriver findet die aktuelle aktive Zuweisung eines Fahrers
+func (r *MemoryVehicleAssignmentRepository) FindActiveAssignmentByDriver(driverID string) (*model.VehicleAssignment, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.store.first(func(a *model.VehicleAssignment) bool {
+		return a.DriverID == objID && a.UnassignedAt == nil
+	})
+}
+
+// CloseAssignment schließt eine aktive Zuweisung ab
+func (r *MemoryVehicleAssignmentRepository) CloseAssignment(assignmentID primitive.ObjectID, unassignedAt time.Time) error {
+	if _, err := r.store.get(assignmentID); err != nil {
+		return err
+	}
+
+	r.store.modify(assignmentID, func(stored *model.VehicleAssignment) {
+		duration := unassignedAt.Sub(stored.AssignedAt)
+		stored.UnassignedAt = &unassignedAt
+		stored.Duration = &duration
+		stored.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// FindRecentAssignments findet die letzten Zuweisungen systemweit
+func (r *MemoryVehicleAssignmentRepository) FindRecentAssignments(limit int) ([]*model.VehicleAssignment, error) {
+	return pageItems(sortItems(r.store.all(), newestAssignmentFirst), 0, limit), nil
+}
+
+// newestAssignmentFirst sortiert Zuweisungen absteigend nach Zuweisungsdatum
+func newestAssignmentFirst(a, b *model.VehicleAssignment) bool {
+	return a.AssignedAt.After(b.AssignedAt)
+}
@@ -0,0 +1,104 @@
+// backend/repository/memoryVehicleDocumentRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleDocumentRepository hält Fahrzeugdokumente im Arbeitsspeicher
+type MemoryVehicleDocumentRepository struct {
+	store *memoryStore[model.VehicleDocument]
+}
+
+// NewMemoryVehicleDocumentRepository erstellt ein neues MemoryVehicleDocumentRepository
+func NewMemoryVehicleDocumentRepository() *MemoryVehicleDocumentRepository {
+	return &MemoryVehicleDocumentRepository{
+		store: newMemoryStore(
+			func(d *model.VehicleDocument) primitive.ObjectID { return d.ID },
+			func(d *model.VehicleDocument, id primitive.ObjectID) { d.ID = id },
+		),
+	}
+}
+
+// Create erstellt ein neues Fahrzeugdokument
+func (r *MemoryVehicleDocumentRepository) Create(document *model.VehicleDocument) error {
+	document.CreatedAt = time.Now()
+	document.UpdatedAt = time.Now()
+	document.UploadedAt = time.Now()
+	return r.store.insert(document)
+}
+
+// FindByID findet ein Dokument anhand seiner ID
+func (r *MemoryVehicleDocumentRepository) FindByID(id string) (*model.VehicleDocument, error) {
+	return r.store.getHex(id)
+}
+
+// FindByVehicle findet alle Dokumente für ein bestimmtes Fahrzeug
+func (r *MemoryVehicleDocumentRepository) FindByVehicle(vehicleID string) ([]*model.VehicleDocument, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := r.store.filter(func(d *model.VehicleDocument) bool { return d.VehicleID == objID })
+	return sortItems(documents, newestVehicleDocumentFirst), nil
+}
+
+// FindByVehicleAndType findet Dokumente für ein Fahrzeug nach Typ
+func (r *MemoryVehicleDocumentRepository) FindByVehicleAndType(vehicleID string, docType model.DocumentType) ([]*model.VehicleDocument, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := r.store.filter(func(d *model.VehicleDocument) bool {
+		return d.VehicleID == objID && d.Type == docType
+	})
+	return sortItems(documents, newestVehicleDocumentFirst), nil
+}
+
+// Update aktualisiert ein Dokument
+func (r *MemoryVehicleDocumentRepository) Update(document *model.VehicleDocument) error {
+	document.UpdatedAt = time.Now()
+	r.store.modify(document.ID, func(stored *model.VehicleDocument) {
+		stored.Type = document.Type
+		stored.Name = document.Name
+		stored.ExpiryDate = document.ExpiryDate
+		stored.Notes = document.Notes
+		stored.UpdatedAt = document.UpdatedAt
+	})
+	return nil
+}
+
+// Delete löscht ein Dokument
+func (r *MemoryVehicleDocumentRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindExpiring findet alle Dokumente, die ab heute in den nächsten Tagen ablaufen
+func (r *MemoryVehicleDocumentRepository) FindExpiring(days int) ([]*model.VehicleDocument, error) {
+	today := startOfDay(time.Now())
+	expiryDate := today.AddDate(0, 0, days)
+
+	return r.store.filter(func(d *model.VehicleDocument) bool {
+		return d.ExpiryDate != nil && !d.ExpiryDate.Before(today) && !d.ExpiryDate.After(expiryDate)
+	}), nil
+}
+
+// CountByVehicle zählt die Dokumente für ein Fahrzeug
+func (r *MemoryVehicleDocumentRepository) CountByVehicle(vehicleID string) (int64, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return 0, err
+	}
+	return r.store.count(func(d *model.VehicleDocument) bool { return d.VehicleID == objID }), nil
+}
+
+// newestVehicleDocumentFirst sortiert Dokumente absteigend nach Upload-Datum
+func newestVehicleDocumentFirst(a, b *model.VehicleDocument) bool {
+	return a.UploadedAt.After(b.UploadedAt)
+}
@@ -0,0 +1,172 @@
+// backend/repository/memoryVehicleReportRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleReportRepository hält Fahrzeugmeldungen im Arbeitsspeicher
+type MemoryVehicleReportRepository struct {
+	store *memoryStore[model.VehicleReport]
+}
+
+// NewMemoryVehicleReportRepository erstellt ein neues MemoryVehicleReportRepository
+func NewMemoryVehicleReportRepository() *MemoryVehicleReportRepository {
+	return &MemoryVehicleReportRepository{
+		store: newMemoryStore(
+			func(r *model.VehicleReport) primitive.ObjectID { return r.ID },
+			func(r *model.VehicleReport, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// Create erstellt eine neue Fahrzeugmeldung
+func (r *MemoryVehicleReportRepository) Create(report *model.VehicleReport) error {
+	report.CreatedAt = time.Now()
+	report.UpdatedAt = time.Now()
+
+	if report.Status == "" {
+		report.Status = model.ReportStatusOpen
+	}
+
+	return r.store.insert(report)
+}
+
+// FindByID findet eine Meldung anhand der ID
+func (r *MemoryVehicleReportRepository) FindByID(id primitive.ObjectID) (*model.VehicleReport, error) {
+	return r.store.get(id)
+}
+
+// FindByReporter findet alle Meldungen eines Fahrers
+func (r *MemoryVehicleReportRepository) FindByReporter(reporterID primitive.ObjectID, limit int) ([]*model.VehicleReport, error) {
+	reports := r.newestFirst(func(rep *model.VehicleReport) bool { return rep.ReporterID == reporterID })
+	return pageItems(reports, 0, limit), nil
+}
+
+// FindByVehicle findet alle Meldungen für ein Fahrzeug
+func (r *MemoryVehicleReportRepository) FindByVehicle(vehicleID primitive.ObjectID) ([]*model.VehicleReport, error) {
+	return r.newestFirst(func(rep *model.VehicleReport) bool { return rep.VehicleID == vehicleID }), nil
+}
+
+// FindByStatus findet alle Meldungen mit einem bestimmten Status
+func (r *MemoryVehicleReportRepository) FindByStatus(status model.ReportStatus) ([]*model.VehicleReport, error) {
+	return r.newestFirst(func(rep *model.VehicleReport) bool { return rep.Status == status }), nil
+}
+
+// FindAll findet alle Meldungen mit Paginierung
+func (r *MemoryVehicleReportRepository) FindAll(page, limit int) ([]*model.VehicleReport, error) {
+	skip := (page - 1) * limit
+	return pageItems(r.newestFirst(nil), skip, limit), nil
+}
+
+// FindPage findet eine Seite Meldungen (leerer Status = alle), neueste zuerst
+func (r *MemoryVehicleReportRepository) FindPage(status model.ReportStatus, limit int, skip int) ([]*model.VehicleReport, error) {
+	reports := r.newestFirst(func(rep *model.VehicleReport) bool { return status == "" || rep.Status == status })
+	return pageItems(reports, skip, limit), nil
+}
+
+// FindUrgent findet alle dringenden Meldungen
+func (r *MemoryVehicleReportRepository) FindUrgent() ([]*model.VehicleReport, error) {
+	return r.newestFirst(func(rep *model.VehicleReport) bool {
+		urgent := rep.Priority == model.ReportPriorityUrgent ||
+			rep.Type == model.ReportTypeAccident ||
+			rep.Type == model.ReportTypeBrakeIssue
+		return urgent && (rep.Status == model.ReportStatusOpen || rep.Status == model.ReportStatusInProgress)
+	}), nil
+}
+
+// Update aktualisiert eine Meldung
+func (r *MemoryVehicleReportRepository) Update(id primitive.ObjectID, update bson.M) error {
+	update["updatedAt"] = time.Now()
+
+	var err error
+	r.store.modify(id, func(stored *model.VehicleReport) {
+		err = applySet(stored, update)
+	})
+	return err
+}
+
+// UpdateStatus ändert den Status einer Meldung
+func (r *MemoryVehicleReportRepository) UpdateStatus(id primitive.ObjectID, status model.ReportStatus, updatedBy primitive.ObjectID) error {
+	r.store.modify(id, func(stored *model.VehicleReport) {
+		now := time.Now()
+		stored.Status = status
+		stored.UpdatedAt = now
+
+		// Spezielle Felder je nach Status setzen
+		switch status {
+		case model.ReportStatusResolved:
+			stored.ResolvedBy = &updatedBy
+			stored.ResolvedAt = &now
+		case model.ReportStatusInProgress:
+			stored.AssignedTo = &updatedBy
+		}
+	})
+	return nil
+}
+
+// AssignTo weist eine Meldung einem Bearbeiter zu
+func (r *MemoryVehicleReportRepository) AssignTo(id primitive.ObjectID, assignedTo primitive.ObjectID) error {
+	r.store.modify(id, func(stored *model.VehicleReport) {
+		stored.AssignedTo = &assignedTo
+		stored.Status = model.ReportStatusInProgress
+		stored.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+// Resolve markiert eine Meldung als behoben
+func (r *MemoryVehicleReportRepository) Resolve(id primitive.ObjectID, resolvedBy primitive.ObjectID, resolution string) error {
+	r.store.modify(id, func(stored *model.VehicleReport) {
+		now := time.Now()
+		stored.Status = model.ReportStatusResolved
+		stored.ResolvedBy = &resolvedBy
+		stored.ResolvedAt = &now
+		stored.Resolution = resolution
+		stored.UpdatedAt = now
+	})
+	return nil
+}
+
+// Delete löscht eine Meldung
+func (r *MemoryVehicleReportRepository) Delete(id primitive.ObjectID) error {
+	r.store.remove(id)
+	return nil
+}
+
+// CountByStatus zählt Meldungen nach Status
+func (r *MemoryVehicleReportRepository) CountByStatus(status model.ReportStatus) (int64, error) {
+	return r.store.count(func(rep *model.VehicleReport) bool { return rep.Status == status }), nil
+}
+
+// CountByReporter zählt Meldungen eines Fahrers
+func (r *MemoryVehicleReportRepository) CountByReporter(reporterID primitive.ObjectID) (int64, error) {
+	return r.store.count(func(rep *model.VehicleReport) bool { return rep.ReporterID == reporterID }), nil
+}
+
+// GetStatistics liefert Statistiken über Meldungen
+func (r *MemoryVehicleReportRepository) GetStatistics() (map[string]interface{}, error) {
+	stats := make(map[string]interface{})
+	total := int64(0)
+
+	for _, report := range r.store.all() {
+		count, _ := stats[string(report.Status)].(int64)
+		stats[string(report.Status)] = count + 1
+		total++
+	}
+
+	stats["total"] = total
+	return stats, nil
+}
+
+// newestFirst liefert passende Meldungen absteigend nach Erstellungsdatum
+func (r *MemoryVehicleReportRepository) newestFirst(match func(*model.VehicleReport) bool) []*model.VehicleReport {
+	return sortItems(r.store.filter(match), func(a, b *model.VehicleReport) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+}
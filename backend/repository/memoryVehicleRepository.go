@@ -0,0 +1,97 @@
+// backend/repository/memoryVehicleRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleRepository hält Fahrzeuge im Arbeitsspeicher
+type MemoryVehicleRepository struct {
+	store *memoryStore[model.Vehicle]
+}
+
+// NewMemoryVehicleRepository erstellt ein neues MemoryVehicleRepository
+func NewMemoryVehicleRepository() *MemoryVehicleRepository {
+	return &MemoryVehicleRepository{
+		store: newMemoryStore(
+			func(v *model.Vehicle) primitive.ObjectID { return v.ID },
+			func(v *model.Vehicle, id primitive.ObjectID) { v.ID = id },
+		),
+	}
+}
+
+// Create erstellt ein neues Fahrzeug
+func (r *MemoryVehicleRepository) Create(vehicle *model.Vehicle) error {
+	vehicle.CreatedAt = time.Now()
+	vehicle.UpdatedAt = time.Now()
+	return r.store.insert(vehicle)
+}
+
+// FindByID findet ein Fahrzeug anhand seiner ID
+func (r *MemoryVehicleRepository) FindByID(id string) (*model.Vehicle, error) {
+	return r.store.getHex(id)
+}
+
+// FindByLicensePlate findet ein Fahrzeug anhand seines Kennzeichens
+func (r *MemoryVehicleRepository) FindByLicensePlate(licensePlate string) (*model.Vehicle, error) {
+	return r.store.first(func(v *model.Vehicle) bool { return v.LicensePlate == licensePlate })
+}
+
+// FindAll findet alle Fahrzeuge
+func (r *MemoryVehicleRepository) FindAll() ([]*model.Vehicle, error) {
+	return r.store.all(), nil
+}
+
+// FindAllWithLimit findet die zuletzt angelegten Fahrzeuge
+func (r *MemoryVehicleRepository) FindAllWithLimit(limit int) ([]*model.Vehicle, error) {
+	vehicles := sortItems(r.store.all(), func(a, b *model.Vehicle) bool { return a.CreatedAt.After(b.CreatedAt) })
+	return pageItems(vehicles, 0, limit), nil
+}
+
+// FindByStatus findet alle Fahrzeuge mit einem bestimmten Status
+func (r *MemoryVehicleRepository) FindByStatus(status model.VehicleStatus) ([]*model.Vehicle, error) {
+	return r.store.filter(func(v *model.Vehicle) bool { return v.Status == status }), nil
+}
+
+// FindPage findet eine Seite Fahrzeuge (leerer Status = alle), sortiert nach Anlage
+func (r *MemoryVehicleRepository) FindPage(status model.VehicleStatus, limit int, skip int) ([]*model.Vehicle, error) {
+	vehicles := r.store.filter(func(v *model.Vehicle) bool { return status == "" || v.Status == status })
+	return pageItems(vehicles, skip, limit), nil
+}
+
+// Update aktualisiert ein Fahrzeug
+func (r *MemoryVehicleRepository) Update(vehicle *model.Vehicle) error {
+	vehicle.UpdatedAt = time.Now()
+	r.store.modify(vehicle.ID, func(stored *model.Vehicle) {
+		createdAt := stored.CreatedAt
+		*stored = *vehicle
+		stored.CreatedAt = createdAt
+	})
+	return nil
+}
+
+// Delete löscht ein Fahrzeug
+func (r *MemoryVehicleRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// CountByStatus zählt Fahrzeuge mit einem bestimmten Status
+func (r *MemoryVehicleRepository) CountByStatus(status model.VehicleStatus) (int64, error) {
+	return r.store.count(func(v *model.Vehicle) bool { return v.Status == status }), nil
+}
+
+// CountByStatusAndDate zählt Fahrzeuge mit einem bestimmten Status an einem bestimmten Datum
+func (r *MemoryVehicleRepository) CountByStatusAndDate(status model.VehicleStatus, date time.Time) (int64, error) {
+	return r.store.count(func(v *model.Vehicle) bool {
+		return v.Status == status && !v.UpdatedAt.After(date)
+	}), nil
+}
+
+// HasAnyVehicles prüft, ob Fahrzeuge vorhanden sind
+func (r *MemoryVehicleRepository) HasAnyVehicles() (bool, error) {
+	return r.store.count(nil) > 0, nil
+}
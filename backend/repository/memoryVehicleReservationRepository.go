@@ -0,0 +1,173 @@
+// backend/repository/memoryVehicleReservationRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleReservationRepository hält Reservierungen im Arbeitsspeicher
+type MemoryVehicleReservationRepository struct {
+	store *memoryStore[model.VehicleReservation]
+}
+
+// NewMemoryVehicleReservationRepository erstellt ein neues MemoryVehicleReservationRepository
+func NewMemoryVehicleReservationRepository() *MemoryVehicleReservationRepository {
+	return &MemoryVehicleReservationRepository{
+		store: newMemoryStore(
+			func(r *model.VehicleReservation) primitive.ObjectID { return r.ID },
+			func(r *model.VehicleReservation, id primitive.ObjectID) { r.ID = id },
+		),
+	}
+}
+
+// Create erstellt eine neue Reservierung
+func (r *MemoryVehicleReservationRepository) Create(reservation *model.VehicleReservation) error {
+	reservation.CreatedAt = time.Now()
+	reservation.UpdatedAt = time.Now()
+	return r.store.insert(reservation)
+}
+
+// FindByID findet eine Reservierung anhand ihrer ID
+func (r *MemoryVehicleReservationRepository) FindByID(id string) (*model.VehicleReservation, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Reservierungen
+func (r *MemoryVehicleReservationRepository) FindAll() ([]model.VehicleReservation, error) {
+	return reservationValues(r.store.all()), nil
+}
+
+// FindPage findet eine Seite Reservierungen (ohne includeCompleted nur nicht abgeschlossene), sortiert nach Anlage
+func (r *MemoryVehicleReservationRepository) FindPage(includeCompleted bool, limit int, skip int) ([]model.VehicleReservation, error) {
+	reservations := r.store.filter(func(res *model.VehicleReservation) bool {
+		return includeCompleted || res.Status != model.ReservationStatusCompleted
+	})
+	return reservationValues(pageItems(reservations, skip, limit)), nil
+}
+
+// FindByVehicleID findet alle Reservierungen für ein bestimmtes Fahrzeug
+func (r *MemoryVehicleReservationRepository) FindByVehicleID(vehicleID string) ([]model.VehicleReservation, error) {
+	objectID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return reservationValues(r.store.filter(func(res *model.VehicleReservation) bool {
+		return res.VehicleID == objectID
+	})), nil
+}
+
+// FindByDriverID findet alle Reservierungen für einen bestimmten Fahrer
+func (r *MemoryVehicleReservationRepository) FindByDriverID(driverID string) ([]model.VehicleReservation, error) {
+	objectID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+	return reservationValues(r.store.filter(func(res *model.VehicleReservation) bool {
+		return res.DriverID == objectID
+	})), nil
+}
+
+// FindBySeriesID findet alle Reservierungen einer Serie, sortiert nach Startzeit
+func (r *MemoryVehicleReservationRepository) FindBySeriesID(seriesID string) ([]model.VehicleReservation, error) {
+	objectID, err := primitive.ObjectIDFromHex(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := r.store.filter(func(res *model.VehicleReservation) bool {
+		return res.SeriesID != nil && *res.SeriesID == objectID
+	})
+	sortItems(reservations, func(a, b *model.VehicleReservation) bool { return a.StartTime.Before(b.StartTime) })
+	return reservationValues(reservations), nil
+}
+
+// CheckConflict prüft ob es einen Terminkonflikt gibt
+func (r *MemoryVehicleReservationRepository) CheckConflict(vehicleID string, startTime, endTime time.Time, excludeID *string) (bool, error) {
+	conflicts, err := r.findConflicts(vehicleID, startTime, endTime, excludeID)
+	if err != nil {
+		return false, err
+	}
+	return len(conflicts) > 0, nil
+}
+
+// CheckConflictDetails prüft auf Konflikte und liefert detaillierte Informationen
+func (r *MemoryVehicleReservationRepository) CheckConflictDetails(vehicleID string, startTime, endTime time.Time, excludeID *string) (*ConflictDetails, error) {
+	conflicts, err := r.findConflicts(vehicleID, startTime, endTime, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	return NewConflictDetails(conflicts), nil
+}
+
+// FindActiveReservations findet alle aktiven Reservierungen
+func (r *MemoryVehicleReservationRepository) FindActiveReservations() ([]model.VehicleReservation, error) {
+	now := time.Now()
+	return reservationValues(r.store.filter(func(res *model.VehicleReservation) bool {
+		return res.Status == model.ReservationStatusActive &&
+			!res.StartTime.After(now) && !res.EndTime.Before(now)
+	})), nil
+}
+
+// Update aktualisiert eine Reservierung
+func (r *MemoryVehicleReservationRepository) Update(reservation *model.VehicleReservation) error {
+	reservation.UpdatedAt = time.Now()
+	r.store.modify(reservation.ID, func(stored *model.VehicleReservation) { *stored = *reservation })
+	return nil
+}
+
+// Delete löscht eine Reservierung
+func (r *MemoryVehicleReservationRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// FindUpcomingReservations findet anstehende Reservierungen (für Benachrichtigungen)
+func (r *MemoryVehicleReservationRepository) FindUpcomingReservations(hours int) ([]model.VehicleReservation, error) {
+	now := time.Now()
+	upcoming := now.Add(time.Duration(hours) * time.Hour)
+
+	reservations := r.store.filter(func(res *model.VehicleReservation) bool {
+		return (res.Status == model.ReservationStatusPending || res.Status == model.ReservationStatusApproved) &&
+			!res.StartTime.Before(now) && !res.StartTime.After(upcoming)
+	})
+	sortItems(reservations, func(a, b *model.VehicleReservation) bool { return a.StartTime.Before(b.StartTime) })
+	return reservationValues(reservations), nil
+}
+
+// findConflicts sucht überschneidende anstehende oder aktive Reservierungen eines Fahrzeugs
+func (r *MemoryVehicleReservationRepository) findConflicts(vehicleID string, startTime, endTime time.Time, excludeID *string) ([]model.VehicleReservation, error) {
+	objectID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var excludeObjectID primitive.ObjectID
+	if excludeID != nil {
+		excludeObjectID, err = primitive.ObjectIDFromHex(*excludeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reservationValues(r.store.filter(func(res *model.VehicleReservation) bool {
+		if res.VehicleID != objectID || (excludeID != nil && res.ID == excludeObjectID) {
+			return false
+		}
+		if res.Status != model.ReservationStatusPending && res.Status != model.ReservationStatusActive {
+			return false
+		}
+		return res.StartTime.Before(endTime) && res.EndTime.After(startTime)
+	})), nil
+}
+
+// reservationValues wandelt Zeiger in die vom Interface erwarteten Werte um
+func reservationValues(items []*model.VehicleReservation) []model.VehicleReservation {
+	var reservations []model.VehicleReservation
+	for _, item := range items {
+		reservations = append(reservations, *item)
+	}
+	return reservations
+}
@@ -0,0 +1,126 @@
+// backend/repository/memoryVehicleUsageRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryVehicleUsageRepository hält Fahrzeugnutzungen im Arbeitsspeicher
+type MemoryVehicleUsageRepository struct {
+	store *memoryStore[model.VehicleUsage]
+}
+
+// NewMemoryVehicleUsageRepository erstellt ein neues MemoryVehicleUsageRepository
+func NewMemoryVehicleUsageRepository() *MemoryVehicleUsageRepository {
+	return &MemoryVehicleUsageRepository{
+		store: newMemoryStore(
+			func(u *model.VehicleUsage) primitive.ObjectID { return u.ID },
+			func(u *model.VehicleUsage, id primitive.ObjectID) { u.ID = id },
+		),
+	}
+}
+
+// Create erstellt einen neuen Fahrzeugnutzungseintrag
+func (r *MemoryVehicleUsageRepository) Create(usage *model.VehicleUsage) error {
+	usage.CreatedAt = time.Now()
+	usage.UpdatedAt = time.Now()
+	return r.store.insert(usage)
+}
+
+// FindByID findet einen Fahrzeugnutzungseintrag anhand seiner ID
+func (r *MemoryVehicleUsageRepository) FindByID(id string) (*model.VehicleUsage, error) {
+	return r.store.getHex(id)
+}
+
+// FindAll findet alle Fahrzeugnutzungseinträge (neueste zuerst)
+func (r *MemoryVehicleUsageRepository) FindAll() ([]*model.VehicleUsage, error) {
+	return sortItems(r.store.all(), newestUsageFirst), nil
+}
+
+// FindPage findet eine Seite Nutzungseinträge, neueste zuerst
+func (r *MemoryVehicleUsageRepository) FindPage(limit int, skip int) ([]*model.VehicleUsage, error) {
+	return pageItems(sortItems(r.store.all(), newestUsageFirst), skip, limit), nil
+}
+
+// FindByVehicle findet alle Nutzungseinträge für ein bestimmtes Fahrzeug (neueste zuerst)
+func (r *MemoryVehicleUsageRepository) FindByVehicle(vehicleID string) ([]*model.VehicleUsage, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := r.store.filter(func(u *model.VehicleUsage) bool { return u.VehicleID == objID })
+	return sortItems(usages, newestUsageFirst), nil
+}
+
+// FindByDriver findet alle Nutzungseinträge für einen bestimmten Fahrer (neueste zuerst)
+func (r *MemoryVehicleUsageRepository) FindByDriver(driverID string) ([]*model.VehicleUsage, error) {
+	objID, err := primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := r.store.filter(func(u *model.VehicleUsage) bool { return u.DriverID == objID })
+	return sortItems(usages, newestUsageFirst), nil
+}
+
+// FindActiveUsage findet die aktive Nutzung eines Fahrzeugs
+func (r *MemoryVehicleUsageRepository) FindActiveUsage(vehicleID string) (*model.VehicleUsage, error) {
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.store.first(func(u *model.VehicleUsage) bool {
+		return u.VehicleID == objID && u.Status == model.UsageStatusActive
+	})
+}
+
+// FindRecentWithLimit findet die zuletzt begonnenen Nutzungen
+func (r *MemoryVehicleUsageRepository) FindRecentWithLimit(limit int) ([]*model.VehicleUsage, error) {
+	return pageItems(sortItems(r.store.all(), newestUsageFirst), 0, limit), nil
+}
+
+// Update aktualisiert einen Fahrzeugnutzungseintrag
+func (r *MemoryVehicleUsageRepository) Update(usage *model.VehicleUsage) error {
+	usage.UpdatedAt = time.Now()
+	r.store.modify(usage.ID, func(stored *model.VehicleUsage) { *stored = *usage })
+	return nil
+}
+
+// Delete löscht einen Fahrzeugnutzungseintrag
+func (r *MemoryVehicleUsageRepository) Delete(id string) error {
+	return r.store.removeHex(id)
+}
+
+// CountActiveUsage zählt die aktiven Nutzungen
+func (r *MemoryVehicleUsageRepository) CountActiveUsage() (int64, error) {
+	return r.store.count(func(u *model.VehicleUsage) bool { return u.Status == model.UsageStatusActive }), nil
+}
+
+// CountActiveUsagesByDateRange zählt aktive Fahrzeugnutzungen in einem Zeitraum
+func (r *MemoryVehicleUsageRepository) CountActiveUsagesByDateRange(startDate, endDate time.Time) (int64, error) {
+	return r.store.count(func(u *model.VehicleUsage) bool {
+		// Nutzungen, die im Zeitraum begonnen haben
+		if !u.StartDate.Before(startDate) && u.StartDate.Before(endDate) {
+			return true
+		}
+		// Nutzungen, die vor dem Zeitraum begonnen haben und noch aktiv sind
+		return u.StartDate.Before(startDate) &&
+			(!u.EndDate.Before(startDate) || u.Status == model.UsageStatusActive)
+	}), nil
+}
+
+// HasAnyUsage prüft, ob Nutzungseinträge vorhanden sind
+func (r *MemoryVehicleUsageRepository) HasAnyUsage() (bool, error) {
+	return r.store.count(nil) > 0, nil
+}
+
+// newestUsageFirst sortiert Nutzungen absteigend nach Startdatum
+func newestUsageFirst(a, b *model.VehicleUsage) bool {
+	return a.StartDate.After(b.StartDate)
+}
@@ -0,0 +1,90 @@
+// backend/repository/memoryWorkOrderRepository.go
+package repository
+
+import (
+	"time"
+
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryWorkOrderRepository hält Werkstattaufträge im Arbeitsspeicher
+type MemoryWorkOrderRepository struct {
+	store *memoryStore[model.WorkOrder]
+}
+
+// NewMemoryWorkOrderRepository erstellt ein neues MemoryWorkOrderRepository
+func NewMemoryWorkOrderRepository() *MemoryWorkOrderRepository {
+	return &MemoryWorkOrderRepository{
+		store: newMemoryStore(
+			func(o *model.WorkOrder) primitive.ObjectID { return o.ID },
+			func(o *model.WorkOrder, id primitive.ObjectID) { o.ID = id },
+		),
+	}
+}
+
+// Create legt einen neuen Werkstattauftrag an
+func (r *MemoryWorkOrderRepository) Create(order *model.WorkOrder) error {
+	now := time.Now()
+	order.ID = primitive.NewObjectID()
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	return r.store.insert(order)
+}
+
+// Update aktualisiert einen Werkstattauftrag
+func (r *MemoryWorkOrderRepository) Update(order *model.WorkOrder) error {
+	order.UpdatedAt = time.Now()
+	found := r.store.modify(order.ID, func(stored *model.WorkOrder) {
+		createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+		*stored = *order
+		stored.CreatedBy = createdBy
+		stored.CreatedAt = createdAt
+	})
+	if !found {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindByID findet einen Werkstattauftrag anhand seiner ID
+func (r *MemoryWorkOrderRepository) FindByID(id string) (*model.WorkOrder, error) {
+	return r.store.getHex(id)
+}
+
+// Find findet alle Werkstattaufträge zum Filter, die neuesten zuerst
+func (r *MemoryWorkOrderRepository) Find(filter model.WorkOrderFilter) ([]*model.WorkOrder, error) {
+	orders := r.store.filter(func(o *model.WorkOrder) bool {
+		if filter.VehicleID != nil && o.VehicleID != *filter.VehicleID {
+			return false
+		}
+		if filter.ReportID != nil {
+			linked := false
+			for _, reportID := range o.ReportIDs {
+				if reportID == *filter.ReportID {
+					linked = true
+					break
+				}
+			}
+			if !linked {
+				return false
+			}
+		}
+		if len(filter.Statuses) > 0 {
+			matched := false
+			for _, status := range filter.Statuses {
+				if o.Status == status {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	})
+	return sortItems(orders, func(a, b *model.WorkOrder) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
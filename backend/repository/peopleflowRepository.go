@@ -14,16 +14,16 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// PeopleFlowRepository enthält alle Datenbankoperationen für PeopleFlow-Integration
-type PeopleFlowRepository struct {
+// MongoPeopleFlowRepository enthält alle Datenbankoperationen für PeopleFlow-Integration
+type MongoPeopleFlowRepository struct {
 	integrationCollection *mongo.Collection
 	employeeCollection    *mongo.Collection
 	syncLogCollection     *mongo.Collection
 }
 
-// NewPeopleFlowRepository erstellt ein neues PeopleFlowRepository
-func NewPeopleFlowRepository() *PeopleFlowRepository {
-	return &PeopleFlowRepository{
+// NewMongoPeopleFlowRepository erstellt ein neues MongoPeopleFlowRepository
+func NewMongoPeopleFlowRepository() *MongoPeopleFlowRepository {
+	return &MongoPeopleFlowRepository{
 		integrationCollection: db.GetCollection("peopleflow_integration"),
 		employeeCollection:    db.GetCollection("peopleflow_employees"),
 		syncLogCollection:     db.GetCollection("peopleflow_sync_logs"),
@@ -33,7 +33,7 @@ func NewPeopleFlowRepository() *PeopleFlowRepository {
 // === Integration Configuration Methods ===
 
 // GetIntegration holt die aktuelle PeopleFlow-Integration-Konfiguration
-func (r *PeopleFlowRepository) GetIntegration() (*model.PeopleFlowIntegration, error) {
+func (r *MongoPeopleFlowRepository) GetIntegration() (*model.PeopleFlowIntegration, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -50,7 +50,7 @@ func (r *PeopleFlowRepository) GetIntegration() (*model.PeopleFlowIntegration, e
 }
 
 // SaveIntegration speichert oder aktualisiert die PeopleFlow-Integration-Konfiguration
-func (r *PeopleFlowRepository) SaveIntegration(integration *model.PeopleFlowIntegration) error {
+func (r *MongoPeopleFlowRepository) SaveIntegration(integration *model.PeopleFlowIntegration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -78,7 +78,7 @@ func (r *PeopleFlowRepository) SaveIntegration(integration *model.PeopleFlowInte
 }
 
 // UpdateIntegrationStatus aktualisiert den Status der Integration
-func (r *PeopleFlowRepository) UpdateIntegrationStatus(isActive bool, lastSync time.Time, status string, syncedEmployees int) error {
+func (r *MongoPeopleFlowRepository) UpdateIntegrationStatus(isActive bool, lastSync time.Time, status string, syncedEmployees int) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -102,7 +102,7 @@ func (r *PeopleFlowRepository) UpdateIntegrationStatus(isActive bool, lastSync t
 // === Employee Methods ===
 
 // SaveEmployee speichert oder aktualisiert einen PeopleFlow-Mitarbeiter
-func (r *PeopleFlowRepository) SaveEmployee(employee *model.PeopleFlowEmployee) error {
+func (r *MongoPeopleFlowRepository) SaveEmployee(employee *model.PeopleFlowEmployee) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -135,7 +135,7 @@ func (r *PeopleFlowRepository) SaveEmployee(employee *model.PeopleFlowEmployee)
 }
 
 // FindEmployeeByEmail findet einen PeopleFlow-Mitarbeiter anhand der E-Mail
-func (r *PeopleFlowRepository) FindEmployeeByEmail(email string) (*model.PeopleFlowEmployee, error) {
+func (r *MongoPeopleFlowRepository) FindEmployeeByEmail(email string) (*model.PeopleFlowEmployee, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -149,7 +149,7 @@ func (r *PeopleFlowRepository) FindEmployeeByEmail(email string) (*model.PeopleF
 }
 
 // FindEmployeeByPeopleFlowID findet einen Mitarbeiter anhand der PeopleFlow-ID
-func (r *PeopleFlowRepository) FindEmployeeByPeopleFlowID(peopleFlowID string) (*model.PeopleFlowEmployee, error) {
+func (r *MongoPeopleFlowRepository) FindEmployeeByPeopleFlowID(peopleFlowID string) (*model.PeopleFlowEmployee, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -163,7 +163,7 @@ func (r *PeopleFlowRepository) FindEmployeeByPeopleFlowID(peopleFlowID string) (
 }
 
 // FindAllEmployees findet alle PeopleFlow-Mitarbeiter
-func (r *PeopleFlowRepository) FindAllEmployees() ([]*model.PeopleFlowEmployee, error) {
+func (r *MongoPeopleFlowRepository) FindAllEmployees() ([]*model.PeopleFlowEmployee, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -188,7 +188,7 @@ func (r *PeopleFlowRepository) FindAllEmployees() ([]*model.PeopleFlowEmployee,
 }
 
 // FindDriverEligibleEmployees findet alle Mitarbeiter, die als Fahrer geeignet sind
-func (r *PeopleFlowRepository) FindDriverEligibleEmployees() ([]*model.PeopleFlowEmployee, error) {
+func (r *MongoPeopleFlowRepository) FindDriverEligibleEmployees() ([]*model.PeopleFlowEmployee, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -217,7 +217,7 @@ func (r *PeopleFlowRepository) FindDriverEligibleEmployees() ([]*model.PeopleFlo
 }
 
 // UpdateEmployeeSyncStatus aktualisiert den Sync-Status eines Mitarbeiters
-func (r *PeopleFlowRepository) UpdateEmployeeSyncStatus(email string, status string) error {
+func (r *MongoPeopleFlowRepository) UpdateEmployeeSyncStatus(email string, status string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -239,7 +239,7 @@ func (r *PeopleFlowRepository) UpdateEmployeeSyncStatus(email string, status str
 // === Sync Log Methods ===
 
 // CreateSyncLog erstellt einen neuen Sync-Log-Eintrag
-func (r *PeopleFlowRepository) CreateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
+func (r *MongoPeopleFlowRepository) CreateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -259,7 +259,7 @@ func (r *PeopleFlowRepository) CreateSyncLog(syncLog *model.PeopleFlowSyncLog) e
 }
 
 // UpdateSyncLog aktualisiert einen bestehenden Sync-Log-Eintrag
-func (r *PeopleFlowRepository) UpdateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
+func (r *MongoPeopleFlowRepository) UpdateSyncLog(syncLog *model.PeopleFlowSyncLog) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -277,7 +277,7 @@ func (r *PeopleFlowRepository) UpdateSyncLog(syncLog *model.PeopleFlowSyncLog) e
 }
 
 // FindRecentSyncLogs findet die letzten Sync-Logs
-func (r *PeopleFlowRepository) FindRecentSyncLogs(limit int) ([]*model.PeopleFlowSyncLog, error) {
+func (r *MongoPeopleFlowRepository) FindRecentSyncLogs(limit int) ([]*model.PeopleFlowSyncLog, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -304,7 +304,7 @@ func (r *PeopleFlowRepository) FindRecentSyncLogs(limit int) ([]*model.PeopleFlo
 }
 
 // DeleteEmployee löscht einen PeopleFlow-Mitarbeiter
-func (r *PeopleFlowRepository) DeleteEmployee(email string) error {
+func (r *MongoPeopleFlowRepository) DeleteEmployee(email string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -313,7 +313,7 @@ func (r *PeopleFlowRepository) DeleteEmployee(email string) error {
 }
 
 // GetEmployeeCount gibt die Anzahl der synchronisierten Mitarbeiter zurück
-func (r *PeopleFlowRepository) GetEmployeeCount() (int64, error) {
+func (r *MongoPeopleFlowRepository) GetEmployeeCount() (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,110 @@
+// backend/repository/repositories.go
+package repository
+
+// Repositories bündelt alle Repositories einer Speicher-Implementierung,
+// damit Services und Handler sie per Konstruktor übergeben bekommen
+type Repositories struct {
+	Vehicle            VehicleRepository
+	Driver             DriverRepository
+	DriverDocument     DriverDocumentRepository
+	VehicleDocument    VehicleDocumentRepository
+	FuelCost           FuelCostRepository
+	FuelImport         FuelImportRepository
+	BulkImport         BulkImportRepository
+	ChargingSession    ChargingSessionRepository
+	Maintenance        MaintenanceRepository
+	MaintenancePlan    MaintenancePlanRepository
+	VehicleUsage       VehicleUsageRepository
+	Logbook            LogbookRepository
+	VehicleAssignment  VehicleAssignmentRepository
+	VehicleReservation VehicleReservationRepository
+	ReservationSeries  ReservationSeriesRepository
+	Handover           ReservationHandoverRepository
+	CalendarFeed       CalendarFeedTokenRepository
+	VehicleReport      VehicleReportRepository
+	WorkOrder          WorkOrderRepository
+	Tire               TireRepository
+	TrafficFine        TrafficFineRepository
+	AccidentClaim      AccidentClaimRepository
+	LeaseReturn        LeaseReturnRepository
+	Activity           ActivityRepository
+	User               UserRepository
+	SMTP               SMTPRepository
+	ExpiryReminder     ExpiryReminderRepository
+	PeopleFlow         PeopleFlowRepository
+	ScheduledJob       ScheduledJobRepository
+	Files              FileRepository
+}
+
+// NewMongoRepositories erstellt alle Repositories für MongoDB (erfordert db.ConnectDB)
+func NewMongoRepositories() *Repositories {
+	return &Repositories{
+		Vehicle:            NewMongoVehicleRepository(),
+		Driver:             NewMongoDriverRepository(),
+		DriverDocument:     NewMongoDriverDocumentRepository(),
+		VehicleDocument:    NewMongoVehicleDocumentRepository(),
+		FuelCost:           NewMongoFuelCostRepository(),
+		FuelImport:         NewMongoFuelImportRepository(),
+		BulkImport:         NewMongoBulkImportRepository(),
+		ChargingSession:    NewMongoChargingSessionRepository(),
+		Maintenance:        NewMongoMaintenanceRepository(),
+		MaintenancePlan:    NewMongoMaintenancePlanRepository(),
+		VehicleUsage:       NewMongoVehicleUsageRepository(),
+		Logbook:            NewMongoLogbookRepository(),
+		VehicleAssignment:  NewMongoVehicleAssignmentRepository(),
+		VehicleReservation: NewMongoVehicleReservationRepository(),
+		ReservationSeries:  NewMongoReservationSeriesRepository(),
+		Handover:           NewMongoReservationHandoverRepository(),
+		CalendarFeed:       NewMongoCalendarFeedTokenRepository(),
+		VehicleReport:      NewMongoVehicleReportRepository(),
+		WorkOrder:          NewMongoWorkOrderRepository(),
+		Tire:               NewMongoTireRepository(),
+		TrafficFine:        NewMongoTrafficFineRepository(),
+		AccidentClaim:      NewMongoAccidentClaimRepository(),
+		LeaseReturn:        NewMongoLeaseReturnRepository(),
+		Activity:           NewMongoActivityRepository(),
+		User:               NewMongoUserRepository(),
+		SMTP:               NewMongoSMTPRepository(),
+		ExpiryReminder:     NewMongoExpiryReminderRepository(),
+		PeopleFlow:         NewMongoPeopleFlowRepository(),
+		ScheduledJob:       NewMongoScheduledJobRepository(),
+		Files:              NewMongoFileRepository(),
+	}
+}
+
+// NewMemoryRepositories erstellt alle Repositories als In-Memory-Implementierung
+// (für lokale Entwicklung und Tests ohne MongoDB)
+func NewMemoryRepositories() *Repositories {
+	return &Repositories{
+		Vehicle:            NewMemoryVehicleRepository(),
+		Driver:             NewMemoryDriverRepository(),
+		DriverDocument:     NewMemoryDriverDocumentRepository(),
+		VehicleDocument:    NewMemoryVehicleDocumentRepository(),
+		FuelCost:           NewMemoryFuelCostRepository(),
+		FuelImport:         NewMemoryFuelImportRepository(),
+		BulkImport:         NewMemoryBulkImportRepository(),
+		ChargingSession:    NewMemoryChargingSessionRepository(),
+		Maintenance:        NewMemoryMaintenanceRepository(),
+		MaintenancePlan:    NewMemoryMaintenancePlanRepository(),
+		VehicleUsage:       NewMemoryVehicleUsageRepository(),
+		Logbook:            NewMemoryLogbookRepository(),
+		VehicleAssignment:  NewMemoryVehicleAssignmentRepository(),
+		VehicleReservation: NewMemoryVehicleReservationRepository(),
+		ReservationSeries:  NewMemoryReservationSeriesRepository(),
+		Handover:           NewMemoryReservationHandoverRepository(),
+		CalendarFeed:       NewMemoryCalendarFeedTokenRepository(),
+		VehicleReport:      NewMemoryVehicleReportRepository(),
+		WorkOrder:          NewMemoryWorkOrderRepository(),
+		Tire:               NewMemoryTireRepository(),
+		TrafficFine:        NewMemoryTrafficFineRepository(),
+		AccidentClaim:      NewMemoryAccidentClaimRepository(),
+		LeaseReturn:        NewMemoryLeaseReturnRepository(),
+		Activity:           NewMemoryActivityRepository(),
+		User:               NewMemoryUserRepository(),
+		SMTP:               NewMemorySMTPRepository(),
+		ExpiryReminder:     NewMemoryExpiryReminderRepository(),
+		PeopleFlow:         NewMemoryPeopleFlowRepository(),
+		ScheduledJob:       NewMemoryScheduledJobRepository(),
+		Files:              NewMemoryFileRepository(),
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoReservationHandoverRepository enthält alle Datenbankoperationen für Übergabeprotokolle
+type MongoReservationHandoverRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReservationHandoverRepository erstellt ein neues MongoReservationHandoverRepository
+func NewMongoReservationHandoverRepository() *MongoReservationHandoverRepository {
+	return &MongoReservationHandoverRepository{
+		collection: db.GetCollection("reservation_handovers"),
+	}
+}
+
+// Create erstellt ein neues Übergabeprotokoll
+func (r *MongoReservationHandoverRepository) Create(handover *model.ReservationHandover) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	handover.CreatedAt = time.Now()
+	handover.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, handover)
+	if err != nil {
+		return err
+	}
+
+	handover.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID findet ein Übergabeprotokoll anhand seiner ID
+func (r *MongoReservationHandoverRepository) FindByID(id string) (*model.ReservationHandover, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var handover model.ReservationHandover
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&handover)
+	if err != nil {
+		return nil, err
+	}
+
+	return &handover, nil
+}
+
+// FindByReservation findet alle Übergabeprotokolle einer Reservierung in zeitlicher Reihenfolge
+func (r *MongoReservationHandoverRepository) FindByReservation(reservationID string) ([]*model.ReservationHandover, error) {
+	objectID, err := primitive.ObjectIDFromHex(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.find(bson.M{"reservationId": objectID}, bson.D{{Key: "recordedAt", Value: 1}})
+}
+
+// FindByReservationAndType findet das Ausgabe- oder Rückgabeprotokoll einer Reservierung
+func (r *MongoReservationHandoverRepository) FindByReservationAndType(reservationID string, handoverType model.HandoverType) (*model.ReservationHandover, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var handover model.ReservationHandover
+	filter := bson.M{"reservationId": objectID, "type": handoverType}
+	err = r.collection.FindOne(ctx, filter).Decode(&handover)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Noch kein Protokoll vorhanden
+		}
+		return nil, err
+	}
+
+	return &handover, nil
+}
+
+// FindByVehicle findet alle Übergabeprotokolle eines Fahrzeugs, neueste zuerst
+func (r *MongoReservationHandoverRepository) FindByVehicle(vehicleID string) ([]*model.ReservationHandover, error) {
+	objectID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.find(bson.M{"vehicleId": objectID}, bson.D{{Key: "recordedAt", Value: -1}})
+}
+
+// Update aktualisiert ein Übergabeprotokoll
+func (r *MongoReservationHandoverRepository) Update(handover *model.ReservationHandover) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	handover.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": handover.ID}
+	update := bson.M{"$set": handover}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// find führt eine sortierte Suche nach Übergabeprotokollen aus
+func (r *MongoReservationHandoverRepository) find(filter bson.M, sort bson.D) ([]*model.ReservationHandover, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var handovers []*model.ReservationHandover
+	if err = cursor.All(ctx, &handovers); err != nil {
+		return nil, err
+	}
+
+	return handovers, nil
+}
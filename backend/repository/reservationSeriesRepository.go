@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoReservationSeriesRepository enthält alle Datenbankoperationen für das ReservationSeries-Modell
+type MongoReservationSeriesRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReservationSeriesRepository erstellt ein neues MongoReservationSeriesRepository
+func NewMongoReservationSeriesRepository() *MongoReservationSeriesRepository {
+	return &MongoReservationSeriesRepository{
+		collection: db.GetCollection("reservation_series"),
+	}
+}
+
+// Create erstellt eine neue Reservierungsserie
+func (r *MongoReservationSeriesRepository) Create(series *model.ReservationSeries) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series.CreatedAt = time.Now()
+	series.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, series)
+	if err != nil {
+		return err
+	}
+
+	series.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID findet eine Reservierungsserie anhand ihrer ID
+func (r *MongoReservationSeriesRepository) FindByID(id string) (*model.ReservationSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var series model.ReservationSeries
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&series)
+	if err != nil {
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+// FindAll findet alle Reservierungsserien, sortiert nach Beginn
+func (r *MongoReservationSeriesRepository) FindAll() ([]model.ReservationSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var series []model.ReservationSeries
+	if err = cursor.All(ctx, &series); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// Update aktualisiert eine Reservierungsserie
+func (r *MongoReservationSeriesRepository) Update(series *model.ReservationSeries) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": series.ID}
+	update := bson.M{"$set": series}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// Delete löscht eine Reservierungsserie
+func (r *MongoReservationSeriesRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// jobRunRetention legt fest, wie lange abgeschlossene Jobläufe in der Historie bleiben
+const jobRunRetention = 30 * 24 * time.Hour
+
+// MongoScheduledJobRepository verwaltet Hintergrundjobs und deren Laufhistorie
+type MongoScheduledJobRepository struct {
+	collection    *mongo.Collection
+	runCollection *mongo.Collection
+}
+
+// NewMongoScheduledJobRepository erstellt ein neues MongoScheduledJobRepository
+func NewMongoScheduledJobRepository() *MongoScheduledJobRepository {
+	r := &MongoScheduledJobRepository{
+		collection:    db.GetCollection("scheduled_jobs"),
+		runCollection: db.GetCollection("job_runs"),
+	}
+
+	// Eindeutiger Jobname, damit parallel startende Instanzen keine Duplikate anlegen
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("⚠️  Index für scheduled_jobs konnte nicht erstellt werden: %v", err)
+	}
+
+	// Alte Läufe verfallen automatisch, sonst wächst die Historie minütlicher Jobs unbegrenzt
+	_, err = r.runCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "finishedAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(jobRunRetention.Seconds())),
+	})
+	if err != nil {
+		log.Printf("⚠️  Index für job_runs konnte nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt einen neuen Job an
+func (r *MongoScheduledJobRepository) Create(job *model.ScheduledJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByName findet einen Job anhand seines Namens
+func (r *MongoScheduledJobRepository) FindByName(name string) (*model.ScheduledJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var job model.ScheduledJob
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Job noch nicht angelegt
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// FindAll findet alle Jobs sortiert nach Namen
+func (r *MongoScheduledJobRepository) FindAll() ([]*model.ScheduledJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*model.ScheduledJob
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// UpdateDefinition aktualisiert Beschreibung und Zeitplan eines Jobs
+func (r *MongoScheduledJobRepository) UpdateDefinition(name, description, schedule string, nextRunAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": description,
+			"schedule":    schedule,
+			"nextRunAt":   nextRunAt,
+			"updatedAt":   time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"name": name}, update)
+	return err
+}
+
+// SetPaused pausiert einen Job oder setzt ihn fort
+func (r *MongoScheduledJobRepository) SetPaused(name string, paused bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"paused": paused, "updatedAt": time.Now()}}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"name": name}, update)
+	return err
+}
+
+// AcquireLease sperrt einen Job atomar für eine Instanz. Ist dueBefore gesetzt,
+// gelingt die Sperre nur, wenn der nächste Lauf bis zu diesem Zeitpunkt fällig ist.
+func (r *MongoScheduledJobRepository) AcquireLease(name, owner string, until time.Time, dueBefore *time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"name": name,
+		"$or": []bson.M{
+			{"leaseUntil": nil},
+			{"leaseUntil": bson.M{"$lt": now}},
+		},
+	}
+	if dueBefore != nil {
+		filter["nextRunAt"] = bson.M{"$lte": *dueBefore}
+	}
+
+	update := bson.M{"$set": bson.M{"leaseOwner": owner, "leaseUntil": until}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// RenewLease verlängert die Sperre eines laufenden Jobs, solange sie noch dieser Instanz gehört
+func (r *MongoScheduledJobRepository) RenewLease(name, owner string, until time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"name": name, "leaseOwner": owner}, bson.M{"$set": bson.M{"leaseUntil": until}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// ReleaseLease gibt die Sperre eines Jobs frei, ohne einen Lauf zu protokollieren
+func (r *MongoScheduledJobRepository) ReleaseLease(name, owner string, nextRunAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set":   bson.M{"nextRunAt": nextRunAt},
+		"$unset": bson.M{"leaseOwner": "", "leaseUntil": ""},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"name": name, "leaseOwner": owner}, update)
+	return err
+}
+
+// CompleteRun übernimmt das Ergebnis eines Laufs in den Job und gibt die Sperre frei
+func (r *MongoScheduledJobRepository) CompleteRun(name, owner string, run *model.JobRun, nextRunAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"nextRunAt":      nextRunAt,
+			"lastRunAt":      run.StartedAt,
+			"lastStatus":     run.Status,
+			"lastError":      run.Error,
+			"lastDurationMs": run.DurationMs,
+			"updatedAt":      time.Now(),
+		},
+		"$unset": bson.M{"leaseOwner": "", "leaseUntil": ""},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"name": name, "leaseOwner": owner}, update)
+	return err
+}
+
+// CreateRun speichert einen abgeschlossenen Joblauf
+func (r *MongoScheduledJobRepository) CreateRun(run *model.JobRun) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.runCollection.InsertOne(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	run.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindRuns findet die letzten Läufe eines Jobs, neueste zuerst
+func (r *MongoScheduledJobRepository) FindRuns(name string, limit int) ([]*model.JobRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "startedAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.runCollection.Find(ctx, bson.M{"jobName": name}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []*model.JobRun
+	if err = cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
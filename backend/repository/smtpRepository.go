@@ -4,6 +4,7 @@ import (
 	"FleetFlow/backend/db"
 	"FleetFlow/backend/model"
 	"context"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -12,33 +13,45 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// SMTPRepository verwaltet SMTP-Konfigurationsdaten
-type SMTPRepository struct {
+// MongoSMTPRepository verwaltet SMTP-Konfigurationsdaten
+type MongoSMTPRepository struct {
 	collection         *mongo.Collection
 	templateCollection *mongo.Collection
 	logCollection      *mongo.Collection
 	settingsCollection *mongo.Collection
 }
 
-// NewSMTPRepository erstellt ein neues SMTPRepository
-func NewSMTPRepository() *SMTPRepository {
-	return &SMTPRepository{
+// NewMongoSMTPRepository erstellt ein neues MongoSMTPRepository
+func NewMongoSMTPRepository() *MongoSMTPRepository {
+	r := &MongoSMTPRepository{
 		collection:         db.GetCollection("smtp_config"),
 		templateCollection: db.GetCollection("email_templates"),
 		logCollection:      db.GetCollection("email_logs"),
 		settingsCollection: db.GetCollection("notification_settings"),
 	}
+
+	// Index für die Abfrage fälliger E-Mails der Warteschlange
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.logCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Index für email_logs konnte nicht erstellt werden: %v", err)
+	}
+
+	return r
 }
 
 // SMTP Config Methods
 
 // SaveSMTPConfig speichert oder aktualisiert die SMTP-Konfiguration
-func (r *SMTPRepository) SaveSMTPConfig(config *model.SMTPConfig) error {
+func (r *MongoSMTPRepository) SaveSMTPConfig(config *model.SMTPConfig) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	now := time.Now()
-	
+
 	// Es sollte nur eine SMTP-Konfiguration geben
 	filter := bson.M{}
 	update := bson.M{
@@ -73,7 +86,7 @@ func (r *SMTPRepository) SaveSMTPConfig(config *model.SMTPConfig) error {
 }
 
 // GetSMTPConfig holt die aktuelle SMTP-Konfiguration
-func (r *SMTPRepository) GetSMTPConfig() (*model.SMTPConfig, error) {
+func (r *MongoSMTPRepository) GetSMTPConfig() (*model.SMTPConfig, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -90,7 +103,7 @@ func (r *SMTPRepository) GetSMTPConfig() (*model.SMTPConfig, error) {
 }
 
 // TestSMTPConnection testet die SMTP-Verbindung
-func (r *SMTPRepository) TestSMTPConnection(config *model.SMTPConfig) error {
+func (r *MongoSMTPRepository) TestSMTPConnection(config *model.SMTPConfig) error {
 	// TODO: Implementiere SMTP-Verbindungstest
 	return nil
 }
@@ -98,7 +111,7 @@ func (r *SMTPRepository) TestSMTPConnection(config *model.SMTPConfig) error {
 // Email Template Methods
 
 // SaveEmailTemplate speichert oder aktualisiert eine E-Mail-Vorlage
-func (r *SMTPRepository) SaveEmailTemplate(template *model.EmailTemplate) error {
+func (r *MongoSMTPRepository) SaveEmailTemplate(template *model.EmailTemplate) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -119,7 +132,7 @@ func (r *SMTPRepository) SaveEmailTemplate(template *model.EmailTemplate) error
 }
 
 // GetEmailTemplate holt eine E-Mail-Vorlage nach Typ
-func (r *SMTPRepository) GetEmailTemplate(templateType model.EmailTemplateType) (*model.EmailTemplate, error) {
+func (r *MongoSMTPRepository) GetEmailTemplate(templateType model.EmailTemplateType) (*model.EmailTemplate, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -137,7 +150,7 @@ func (r *SMTPRepository) GetEmailTemplate(templateType model.EmailTemplateType)
 }
 
 // GetAllEmailTemplates holt alle E-Mail-Vorlagen
-func (r *SMTPRepository) GetAllEmailTemplates() ([]*model.EmailTemplate, error) {
+func (r *MongoSMTPRepository) GetAllEmailTemplates() ([]*model.EmailTemplate, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -162,7 +175,7 @@ func (r *SMTPRepository) GetAllEmailTemplates() ([]*model.EmailTemplate, error)
 // Email Log Methods
 
 // LogEmail protokolliert einen E-Mail-Versand
-func (r *SMTPRepository) LogEmail(log *model.EmailLog) error {
+func (r *MongoSMTPRepository) LogEmail(log *model.EmailLog) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -174,7 +187,7 @@ func (r *SMTPRepository) LogEmail(log *model.EmailLog) error {
 }
 
 // GetEmailLogs holt E-Mail-Logs mit Paginierung
-func (r *SMTPRepository) GetEmailLogs(limit, offset int) ([]*model.EmailLog, error) {
+func (r *MongoSMTPRepository) GetEmailLogs(limit, offset int) ([]*model.EmailLog, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -201,23 +214,158 @@ func (r *SMTPRepository) GetEmailLogs(limit, offset int) ([]*model.EmailLog, err
 	return logs, nil
 }
 
+// GetEmailLogsByStatus holt E-Mail-Logs eines Status mit Paginierung
+func (r *MongoSMTPRepository) GetEmailLogsByStatus(status model.EmailStatus, limit, offset int) ([]*model.EmailLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := r.logCollection.Find(ctx, bson.M{"status": status}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*model.EmailLog
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// GetEmailLog holt einen einzelnen E-Mail-Log-Eintrag
+func (r *MongoSMTPRepository) GetEmailLog(id string) (*model.EmailLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var log model.EmailLog
+	if err := r.logCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&log); err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// ClaimNextEmail reserviert atomar die älteste fällige E-Mail der Warteschlange.
+// Bis lockUntil ist sie für andere Instanzen gesperrt; bricht der Versand ab, wird sie danach erneut versucht.
+func (r *MongoSMTPRepository) ClaimNextEmail(now, lockUntil time.Time) (*model.EmailLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":        model.EmailStatusPending,
+		"nextAttemptAt": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"nextAttemptAt": lockUntil,
+			"lastAttemptAt": now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var log model.EmailLog
+	err := r.logCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&log)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// MarkEmailSent markiert eine E-Mail als zugestellt
+func (r *MongoSMTPRepository) MarkEmailSent(id primitive.ObjectID, sentAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set":   bson.M{"status": model.EmailStatusSent, "sentAt": sentAt},
+		"$unset": bson.M{"error": ""},
+	}
+	_, err := r.logCollection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// MarkEmailFailed hält einen fehlgeschlagenen Versuch fest. Mit retryAt bleibt die E-Mail
+// in der Warteschlange, ohne gilt sie endgültig als fehlgeschlagen.
+func (r *MongoSMTPRepository) MarkEmailFailed(id primitive.ObjectID, errorMessage string, retryAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set := bson.M{"error": errorMessage}
+	if retryAt != nil {
+		set["nextAttemptAt"] = *retryAt
+	} else {
+		set["status"] = model.EmailStatusFailed
+	}
+
+	_, err := r.logCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+// RequeueEmail stellt eine endgültig fehlgeschlagene E-Mail mit zurückgesetzten Versuchen erneut ein
+func (r *MongoSMTPRepository) RequeueEmail(id primitive.ObjectID, at time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "status": model.EmailStatusFailed}
+	update := bson.M{
+		"$set": bson.M{
+			"status":        model.EmailStatusPending,
+			"attempts":      0,
+			"nextAttemptAt": at,
+		},
+		"$unset": bson.M{"error": ""},
+	}
+
+	result, err := r.logCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
 // Notification Settings Methods
 
 // SaveNotificationSettings speichert oder aktualisiert Benachrichtigungseinstellungen
-func (r *SMTPRepository) SaveNotificationSettings(settings *model.NotificationSettings) error {
+func (r *MongoSMTPRepository) SaveNotificationSettings(settings *model.NotificationSettings) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	now := time.Now()
-	
+
 	filter := bson.M{"userId": settings.UserID}
 	update := bson.M{
 		"$set": bson.M{
-			"emailNotifications": settings.EmailNotifications,
-			"bookingReminders":   settings.BookingReminders,
-			"fuelReminders":      settings.FuelReminders,
-			"maintenanceAlerts":  settings.MaintenanceAlerts,
-			"updatedAt":          now,
+			"emailNotifications":   settings.EmailNotifications,
+			"bookingReminders":     settings.BookingReminders,
+			"fuelReminders":        settings.FuelReminders,
+			"maintenanceAlerts":    settings.MaintenanceAlerts,
+			"urgentReports":        settings.UrgentReports,
+			"reservationRequests":  settings.ReservationRequests,
+			"reservationDecisions": settings.ReservationDecisions,
+			"expiryReminders":      settings.ExpiryReminders,
+			"quietHoursEnabled":    settings.QuietHoursEnabled,
+			"quietHoursStart":      settings.QuietHoursStart,
+			"quietHoursEnd":        settings.QuietHoursEnd,
+			"updatedAt":            now,
 		},
 		"$setOnInsert": bson.M{
 			"userId":    settings.UserID,
@@ -239,33 +387,63 @@ func (r *SMTPRepository) SaveNotificationSettings(settings *model.NotificationSe
 }
 
 // GetNotificationSettings holt Benachrichtigungseinstellungen für einen Benutzer
-func (r *SMTPRepository) GetNotificationSettings(userID primitive.ObjectID) (*model.NotificationSettings, error) {
+func (r *MongoSMTPRepository) GetNotificationSettings(userID primitive.ObjectID) (*model.NotificationSettings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	var settings model.NotificationSettings
+	// Mit Standardwerten vorbelegen, damit ältere Datensätze ohne neue Felder diese nicht abschalten
+	settings := defaultNotificationSettings(userID)
 	filter := bson.M{"userId": userID}
-	err := r.settingsCollection.FindOne(ctx, filter).Decode(&settings)
+	err := r.settingsCollection.FindOne(ctx, filter).Decode(settings)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			// Standardeinstellungen zurückgeben
-			return &model.NotificationSettings{
-				UserID:             userID,
-				EmailNotifications: true,
-				BookingReminders:   true,
-				FuelReminders:      true,
-				MaintenanceAlerts:  false,
-			}, nil
+			return defaultNotificationSettings(userID), nil
 		}
 		return nil, err
 	}
 
-	return &settings, nil
+	return settings, nil
+}
+
+// defaultNotificationSettings liefert die Standardeinstellungen für Benutzer ohne gespeicherte Einstellungen
+func defaultNotificationSettings(userID primitive.ObjectID) *model.NotificationSettings {
+	return &model.NotificationSettings{
+		UserID:               userID,
+		EmailNotifications:   true,
+		BookingReminders:     true,
+		FuelReminders:        true,
+		MaintenanceAlerts:    false,
+		UrgentReports:        true,
+		ReservationRequests:  true,
+		ReservationDecisions: true,
+		ExpiryReminders:      true,
+		QuietHoursEnabled:    false,
+		QuietHoursStart:      "22:00",
+		QuietHoursEnd:        "07:00",
+	}
 }
 
 // CreateDefaultEmailTemplates erstellt Standard-E-Mail-Vorlagen
-func (r *SMTPRepository) CreateDefaultEmailTemplates() error {
-	templates := []*model.EmailTemplate{
+func (r *MongoSMTPRepository) CreateDefaultEmailTemplates() error {
+	templates := defaultEmailTemplates()
+
+	for _, template := range templates {
+		// Prüfen, ob Template bereits existiert
+		existing, _ := r.GetEmailTemplate(template.Type)
+		if existing == nil {
+			if err := r.SaveEmailTemplate(template); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultEmailTemplates liefert die Standard-E-Mail-Vorlagen
+func defaultEmailTemplates() []*model.EmailTemplate {
+	return []*model.EmailTemplate{
 		{
 			Name:     "Neuer Benutzer erstellt",
 			Subject:  "Willkommen bei FleetFlow - Ihr Zugang wurde erstellt",
@@ -282,17 +460,13 @@ func (r *SMTPRepository) CreateDefaultEmailTemplates() error {
 			Type:     model.EmailTemplatePasswordReset,
 			IsActive: true,
 		},
+		{
+			Name:     "Ablauferinnerung",
+			Subject:  "FleetFlow - {{.Headline}}",
+			Body:     "Hallo {{.RecipientName}},\n\n{{.Headline}}.\n\n{{.Kind}}: {{.Title}}\nAblaufdatum: {{.ExpiryDate}}\n\nBitte kümmern Sie sich rechtzeitig um die Verlängerung.\n\nMit freundlichen Grüßen\nIhr FleetFlow-Team",
+			BodyHTML: `<h2>{{.Kind}} läuft ab</h2><p>Hallo {{.RecipientName}},</p><p>{{.Headline}}.</p><p><strong>{{.Kind}}:</strong> {{.Title}}<br><strong>Ablaufdatum:</strong> {{.ExpiryDate}}</p><p>Bitte kümmern Sie sich rechtzeitig um die Verlängerung.</p><p>Mit freundlichen Grüßen<br>Ihr FleetFlow-Team</p>`,
+			Type:     model.EmailTemplateExpiryReminder,
+			IsActive: true,
+		},
 	}
-
-	for _, template := range templates {
-		// Prüfen, ob Template bereits existiert
-		existing, _ := r.GetEmailTemplate(template.Type)
-		if existing == nil {
-			if err := r.SaveEmailTemplate(template); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
\ No newline at end of file
+}
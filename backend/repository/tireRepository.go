@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoTireRepository enthält alle Datenbankoperationen für Reifensätze, Reifenvorgaben und Wechselaktionen
+type MongoTireRepository struct {
+	setCollection      *mongo.Collection
+	settingsCollection *mongo.Collection
+	campaignCollection *mongo.Collection
+}
+
+// NewMongoTireRepository erstellt ein neues MongoTireRepository
+func NewMongoTireRepository() *MongoTireRepository {
+	r := &MongoTireRepository{
+		setCollection:      db.GetCollection("tire_sets"),
+		settingsCollection: db.GetCollection("tire_settings"),
+		campaignCollection: db.GetCollection("tire_swap_campaigns"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.setCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "location", Value: 1}}},
+		{Keys: bson.D{{Key: "season", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für tire_sets konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// CreateSet legt einen neuen Reifensatz an
+func (r *MongoTireRepository) CreateSet(set *model.TireSet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	set.ID = primitive.NewObjectID()
+	set.CreatedAt = now
+	set.UpdatedAt = now
+
+	_, err := r.setCollection.InsertOne(ctx, set)
+	return err
+}
+
+// UpdateSet aktualisiert einen Reifensatz samt Messungen und Saisonlaufleistung
+func (r *MongoTireRepository) UpdateSet(set *model.TireSet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	set.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"vehicleId":      set.VehicleID,
+			"season":         set.Season,
+			"brand":          set.Brand,
+			"model":          set.Model,
+			"size":           set.Size,
+			"rimType":        set.RimType,
+			"dotCode":        set.DOTCode,
+			"productionDate": set.ProductionDate,
+			"location":       set.Location,
+			"storageDetails": set.StorageDetails,
+			"measurements":   set.Measurements,
+			"seasonUsage":    set.SeasonUsage,
+			"totalMileage":   set.TotalMileage,
+			"retired":        set.Retired,
+			"notes":          set.Notes,
+			"updatedAt":      set.UpdatedAt,
+		},
+	}
+
+	result, err := r.setCollection.UpdateOne(ctx, bson.M{"_id": set.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteSet löscht einen Reifensatz
+func (r *MongoTireRepository) DeleteSet(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.setCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindSetByID findet einen Reifensatz anhand seiner ID
+func (r *MongoTireRepository) FindSetByID(id string) (*model.TireSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var set model.TireSet
+	if err := r.setCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&set); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// FindSets findet alle Reifensätze zum Filter, sortiert nach Anlage
+func (r *MongoTireRepository) FindSets(filter model.TireSetFilter) ([]*model.TireSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.Location != "" {
+		query["location"] = filter.Location
+	}
+	if filter.Season != "" {
+		query["season"] = filter.Season
+	}
+	if !filter.IncludeRetired {
+		query["retired"] = bson.M{"$ne": true}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.setCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sets []*model.TireSet
+	if err = cursor.All(ctx, &sets); err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+// GetSettings holt die Reifenvorgaben
+func (r *MongoTireRepository) GetSettings() (*model.TireSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var settings model.TireSettings
+	err := r.settingsCollection.FindOne(ctx, bson.M{}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// Standardvorgaben zurückgeben
+			return model.DefaultTireSettings(), nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SaveSettings speichert oder aktualisiert die Reifenvorgaben
+func (r *MongoTireRepository) SaveSettings(settings *model.TireSettings) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	// Es gibt nur einen Einstellungsdatensatz
+	update := bson.M{
+		"$set": bson.M{
+			"minTreadDepthSummer": settings.MinTreadDepthSummer,
+			"minTreadDepthWinter": settings.MinTreadDepthWinter,
+			"maxAgeYears":         settings.MaxAgeYears,
+			"springWindowStart":   settings.SpringWindowStart,
+			"springWindowEnd":     settings.SpringWindowEnd,
+			"autumnWindowStart":   settings.AutumnWindowStart,
+			"autumnWindowEnd":     settings.AutumnWindowEnd,
+			"swapsPerDay":         settings.SwapsPerDay,
+			"updatedAt":           now,
+		},
+		"$setOnInsert": bson.M{
+			"createdAt": now,
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	result, err := r.settingsCollection.UpdateOne(ctx, bson.M{}, update, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.UpsertedID != nil {
+		settings.ID = result.UpsertedID.(primitive.ObjectID)
+	}
+
+	return nil
+}
+
+// CreateCampaign legt eine neue Wechselaktion an
+func (r *MongoTireRepository) CreateCampaign(campaign *model.TireSwapCampaign) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	campaign.ID = primitive.NewObjectID()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	_, err := r.campaignCollection.InsertOne(ctx, campaign)
+	return err
+}
+
+// UpdateCampaign aktualisiert die geplanten Wechsel einer Wechselaktion
+func (r *MongoTireRepository) UpdateCampaign(campaign *model.TireSwapCampaign) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	campaign.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"startDate": campaign.StartDate,
+			"endDate":   campaign.EndDate,
+			"items":     campaign.Items,
+			"updatedAt": campaign.UpdatedAt,
+		},
+	}
+
+	result, err := r.campaignCollection.UpdateOne(ctx, bson.M{"_id": campaign.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindCampaignByID findet eine Wechselaktion anhand ihrer ID
+func (r *MongoTireRepository) FindCampaignByID(id string) (*model.TireSwapCampaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaign model.TireSwapCampaign
+	if err := r.campaignCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&campaign); err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// FindCampaigns findet alle Wechselaktionen, die jüngste zuerst
+func (r *MongoTireRepository) FindCampaigns() ([]*model.TireSwapCampaign, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "startDate", Value: -1}})
+	cursor, err := r.campaignCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var campaigns []*model.TireSwapCampaign
+	if err = cursor.All(ctx, &campaigns); err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoTrafficFineRepository enthält alle Datenbankoperationen für Bußgelder
+type MongoTrafficFineRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoTrafficFineRepository erstellt ein neues MongoTrafficFineRepository
+func NewMongoTrafficFineRepository() *MongoTrafficFineRepository {
+	r := &MongoTrafficFineRepository{
+		collection: db.GetCollection("traffic_fines"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "offenseTime", Value: -1}}},
+		{Keys: bson.D{{Key: "driverId", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "responseDeadline", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für traffic_fines konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt ein neues Bußgeld an
+func (r *MongoTrafficFineRepository) Create(fine *model.TrafficFine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	fine.ID = primitive.NewObjectID()
+	fine.CreatedAt = now
+	fine.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, fine)
+	return err
+}
+
+// Update aktualisiert ein Bußgeld
+func (r *MongoTrafficFineRepository) Update(fine *model.TrafficFine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	fine.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"vehicleId":        fine.VehicleID,
+			"licensePlate":     fine.LicensePlate,
+			"offenseTime":      fine.OffenseTime,
+			"location":         fine.Location,
+			"offense":          fine.Offense,
+			"authority":        fine.Authority,
+			"referenceNumber":  fine.ReferenceNumber,
+			"amount":           fine.Amount,
+			"points":           fine.Points,
+			"receivedAt":       fine.ReceivedAt,
+			"responseDeadline": fine.ResponseDeadline,
+			"status":           fine.Status,
+			"driverId":         fine.DriverID,
+			"driverSource":     fine.DriverSource,
+			"resolution":       fine.Resolution,
+			"notifiedAt":       fine.NotifiedAt,
+			"reminderSentAt":   fine.ReminderSentAt,
+			"forwardedAt":      fine.ForwardedAt,
+			"forwardedTo":      fine.ForwardedTo,
+			"responseNotes":    fine.ResponseNotes,
+			"paidAt":           fine.PaidAt,
+			"paidAmount":       fine.PaidAmount,
+			"paidBy":           fine.PaidBy,
+			"notes":            fine.Notes,
+			"updatedAt":        fine.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": fine.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete löscht ein Bußgeld
+func (r *MongoTrafficFineRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// FindByID findet ein Bußgeld anhand seiner ID
+func (r *MongoTrafficFineRepository) FindByID(id string) (*model.TrafficFine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var fine model.TrafficFine
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&fine); err != nil {
+		return nil, err
+	}
+	return &fine, nil
+}
+
+// Find findet alle Bußgelder zum Filter, die jüngsten Tatzeitpunkte zuerst
+func (r *MongoTrafficFineRepository) Find(filter model.TrafficFineFilter) ([]*model.TrafficFine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.DriverID != nil {
+		query["driverId"] = *filter.DriverID
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "offenseTime", Value: -1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var fines []*model.TrafficFine
+	if err = cursor.All(ctx, &fines); err != nil {
+		return nil, err
+	}
+	return fines, nil
+}
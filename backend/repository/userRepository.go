@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// UserRepository enthält alle Datenbankoperationen für das User-Modell
-type UserRepository struct {
+// MongoUserRepository enthält alle Datenbankoperationen für das User-Modell
+type MongoUserRepository struct {
 	collection *mongo.Collection
 }
 
-// NewUserRepository erstellt ein neues UserRepository
-func NewUserRepository() *UserRepository {
-	return &UserRepository{
+// NewMongoUserRepository erstellt ein neues MongoUserRepository
+func NewMongoUserRepository() *MongoUserRepository {
+	return &MongoUserRepository{
 		collection: db.GetCollection("users"),
 	}
 }
 
 // Create erstellt einen neuen Benutzer
-func (r *UserRepository) Create(user *model.User) error {
+func (r *MongoUserRepository) Create(user *model.User) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -49,7 +49,7 @@ func (r *UserRepository) Create(user *model.User) error {
 }
 
 // FindByID findet einen Benutzer anhand seiner ID
-func (r *UserRepository) FindByID(id string) (*model.User, error) {
+func (r *MongoUserRepository) FindByID(id string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -68,7 +68,7 @@ func (r *UserRepository) FindByID(id string) (*model.User, error) {
 }
 
 // FindByEmail findet einen Benutzer anhand seiner E-Mail
-func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
+func (r *MongoUserRepository) FindByEmail(email string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -82,7 +82,7 @@ func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
 }
 
 // FindAll findet alle Benutzer
-func (r *UserRepository) FindAll() ([]*model.User, error) {
+func (r *MongoUserRepository) FindAll() ([]*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -110,7 +110,7 @@ func (r *UserRepository) FindAll() ([]*model.User, error) {
 
 // Update aktualisiert einen Benutzer
 // Update aktualisiert einen bestehenden Benutzer in der Datenbank
-func (r *UserRepository) Update(user *model.User) error {
+func (r *MongoUserRepository) Update(user *model.User) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -146,7 +146,7 @@ func (r *UserRepository) Update(user *model.User) error {
 }
 
 // Delete löscht einen Benutzer
-func (r *UserRepository) Delete(id string) error {
+func (r *MongoUserRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -160,7 +160,7 @@ func (r *UserRepository) Delete(id string) error {
 }
 
 // CreateAdminUserIfNotExists erstellt einen Admin-Benutzer, falls keiner existiert
-func (r *UserRepository) CreateAdminUserIfNotExists() error {
+func (r *MongoUserRepository) CreateAdminUserIfNotExists() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -176,6 +176,18 @@ func (r *UserRepository) CreateAdminUserIfNotExists() error {
 	}
 
 	// Admin-Benutzer erstellen
+	admin, err := newDefaultAdminUser()
+	if err != nil {
+		return err
+	}
+
+	// Admin in der Datenbank speichern
+	_, err = r.collection.InsertOne(ctx, admin)
+	return err
+}
+
+// newDefaultAdminUser erstellt den Standard-Admin mit gehashtem Passwort
+func newDefaultAdminUser() (*model.User, error) {
 	admin := &model.User{
 		FirstName: "Admin",
 		LastName:  "User",
@@ -189,15 +201,13 @@ func (r *UserRepository) CreateAdminUserIfNotExists() error {
 
 	// Passwort hashen
 	if err := admin.HashPassword(); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Admin in der Datenbank speichern
-	_, err = r.collection.InsertOne(ctx, admin)
-	return err
+	return admin, nil
 }
 
-func (r *UserRepository) FindByUsername(username string) (*model.User, error) {
+func (r *MongoUserRepository) FindByUsername(username string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -211,19 +221,19 @@ func (r *UserRepository) FindByUsername(username string) (*model.User, error) {
 }
 
 // GetAll gibt alle Benutzer aus der Datenbank zurück
-func (r *UserRepository) GetAll() ([]*model.User, error) {
+func (r *MongoUserRepository) GetAll() ([]*model.User, error) {
 	return r.GetUsersWithFilter(bson.M{})
 }
 
 // GetUsersWithFilter gibt Benutzer basierend auf einem Filter zurück
-func (r *UserRepository) GetUsersWithFilter(filter bson.M) ([]*model.User, error) {
+func (r *MongoUserRepository) GetUsersWithFilter(filter bson.M) ([]*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	var users []*model.User
 
 	// Optionen für Sortierung und Felder
-	opts := options.Find().SetSort(bson.D{{"lastName", 1}, {"firstName", 1}})
+	opts := options.Find().SetSort(bson.D{{Key: "lastName", Value: 1}, {Key: "firstName", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -247,7 +257,7 @@ func (r *UserRepository) GetUsersWithFilter(filter bson.M) ([]*model.User, error
 }
 
 // GetUserByEmail findet einen Benutzer anhand seiner E-Mail-Adresse
-func (r *UserRepository) GetUserByEmail(email string) (*model.User, error) {
+func (r *MongoUserRepository) GetUserByEmail(email string) (*model.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -264,7 +274,7 @@ func (r *UserRepository) GetUserByEmail(email string) (*model.User, error) {
 }
 
 // Count gibt die Anzahl der Benutzer in der Datenbank zurück
-func (r *UserRepository) Count() (int64, error) {
+func (r *MongoUserRepository) Count() (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleAssignmentRepository enthält alle Datenbankoperationen für VehicleAssignment
-type VehicleAssignmentRepository struct {
+// MongoVehicleAssignmentRepository enthält alle Datenbankoperationen für VehicleAssignment
+type MongoVehicleAssignmentRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleAssignmentRepository erstellt ein neues VehicleAssignmentRepository
-func NewVehicleAssignmentRepository() *VehicleAssignmentRepository {
-	return &VehicleAssignmentRepository{
+// NewMongoVehicleAssignmentRepository erstellt ein neues MongoVehicleAssignmentRepository
+func NewMongoVehicleAssignmentRepository() *MongoVehicleAssignmentRepository {
+	return &MongoVehicleAssignmentRepository{
 		collection: db.GetCollection("vehicleAssignments"),
 	}
 }
 
 // Create erstellt einen neuen Zuweisungseintrag
-func (r *VehicleAssignmentRepository) Create(assignment *model.VehicleAssignment) error {
+func (r *MongoVehicleAssignmentRepository) Create(assignment *model.VehicleAssignment) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *VehicleAssignmentRepository) Create(assignment *model.VehicleAssignment
 }
 
 // Update aktualisiert einen Zuweisungseintrag
-func (r *VehicleAssignmentRepository) Update(assignment *model.VehicleAssignment) error {
+func (r *MongoVehicleAssignmentRepository) Update(assignment *model.VehicleAssignment) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -59,7 +59,7 @@ func (r *VehicleAssignmentRepository) Update(assignment *model.VehicleAssignment
 }
 
 // FindByDriverID findet alle Zuweisungen für einen Fahrer
-func (r *VehicleAssignmentRepository) FindByDriverID(driverID string) ([]*model.VehicleAssignment, error) {
+func (r *MongoVehicleAssignmentRepository) FindByDriverID(driverID string) ([]*model.VehicleAssignment, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -89,7 +89,7 @@ func (r *VehicleAssignmentRepository) FindByDriverID(driverID string) ([]*model.
 }
 
 // FindByVehicleID findet alle Zuweisungen für ein Fahrzeug
-func (r *VehicleAssignmentRepository) FindByVehicleID(vehicleID string) ([]*model.VehicleAssignment, error) {
+func (r *MongoVehicleAssignmentRepository) FindByVehicleID(vehicleID string) ([]*model.VehicleAssignment, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -119,7 +119,7 @@ func (r *VehicleAssignmentRepository) FindByVehicleID(vehicleID string) ([]*mode
 }
 
 // FindActiveAssignmentByDriver findet die aktuelle aktive Zuweisung eines Fahrers
-func (r *VehicleAssignmentRepository) FindActiveAssignmentByDriver(driverID string) (*model.VehicleAssignment, error) {
+func (r *MongoVehicleAssignmentRepository) FindActiveAssignmentByDriver(driverID string) (*model.VehicleAssignment, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -142,7 +142,7 @@ func (r *VehicleAssignmentRepository) FindActiveAssignmentByDriver(driverID stri
 }
 
 // CloseAssignment schließt eine aktive Zuweisung ab
-func (r *VehicleAssignmentRepository) CloseAssignment(assignmentID primitive.ObjectID, unassignedAt time.Time) error {
+func (r *MongoVehicleAssignmentRepository) CloseAssignment(assignmentID primitive.ObjectID, unassignedAt time.Time) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -173,7 +173,7 @@ func (r *VehicleAssignmentRepository) CloseAssignment(assignmentID primitive.Obj
 }
 
 // FindRecentAssignments findet die letzten Zuweisungen systemweit
-func (r *VehicleAssignmentRepository) FindRecentAssignments(limit int) ([]*model.VehicleAssignment, error) {
+func (r *MongoVehicleAssignmentRepository) FindRecentAssignments(limit int) ([]*model.VehicleAssignment, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -13,20 +13,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleDocumentRepository enthält alle Datenbankoperationen für Fahrzeugdokumente
-type VehicleDocumentRepository struct {
+// MongoVehicleDocumentRepository enthält alle Datenbankoperationen für Fahrzeugdokumente
+type MongoVehicleDocumentRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleDocumentRepository erstellt ein neues VehicleDocumentRepository
-func NewVehicleDocumentRepository() *VehicleDocumentRepository {
-	return &VehicleDocumentRepository{
+// NewMongoVehicleDocumentRepository erstellt ein neues MongoVehicleDocumentRepository
+func NewMongoVehicleDocumentRepository() *MongoVehicleDocumentRepository {
+	return &MongoVehicleDocumentRepository{
 		collection: db.GetCollection("vehicle_documents"),
 	}
 }
 
 // Create erstellt ein neues Fahrzeugdokument
-func (r *VehicleDocumentRepository) Create(document *model.VehicleDocument) error {
+func (r *MongoVehicleDocumentRepository) Create(document *model.VehicleDocument) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *VehicleDocumentRepository) Create(document *model.VehicleDocument) erro
 }
 
 // FindByID findet ein Dokument anhand seiner ID
-func (r *VehicleDocumentRepository) FindByID(id string) (*model.VehicleDocument, error) {
+func (r *MongoVehicleDocumentRepository) FindByID(id string) (*model.VehicleDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *VehicleDocumentRepository) FindByID(id string) (*model.VehicleDocument,
 }
 
 // FindByVehicle findet alle Dokumente für ein bestimmtes Fahrzeug
-func (r *VehicleDocumentRepository) FindByVehicle(vehicleID string) ([]*model.VehicleDocument, error) {
+func (r *MongoVehicleDocumentRepository) FindByVehicle(vehicleID string) ([]*model.VehicleDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -97,7 +97,7 @@ func (r *VehicleDocumentRepository) FindByVehicle(vehicleID string) ([]*model.Ve
 }
 
 // FindByVehicleAndType findet Dokumente für ein Fahrzeug nach Typ
-func (r *VehicleDocumentRepository) FindByVehicleAndType(vehicleID string, docType model.DocumentType) ([]*model.VehicleDocument, error) {
+func (r *MongoVehicleDocumentRepository) FindByVehicleAndType(vehicleID string, docType model.DocumentType) ([]*model.VehicleDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -134,7 +134,7 @@ func (r *VehicleDocumentRepository) FindByVehicleAndType(vehicleID string, docTy
 }
 
 // Update aktualisiert ein Dokument
-func (r *VehicleDocumentRepository) Update(document *model.VehicleDocument) error {
+func (r *MongoVehicleDocumentRepository) Update(document *model.VehicleDocument) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -160,7 +160,7 @@ func (r *VehicleDocumentRepository) Update(document *model.VehicleDocument) erro
 }
 
 // Delete löscht ein Dokument
-func (r *VehicleDocumentRepository) Delete(id string) error {
+func (r *MongoVehicleDocumentRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -173,17 +173,18 @@ func (r *VehicleDocumentRepository) Delete(id string) error {
 	return err
 }
 
-// FindExpiring findet alle Dokumente, die in den nächsten Tagen ablaufen
-func (r *VehicleDocumentRepository) FindExpiring(days int) ([]*model.VehicleDocument, error) {
+// FindExpiring findet alle Dokumente, die ab heute in den nächsten Tagen ablaufen
+func (r *MongoVehicleDocumentRepository) FindExpiring(days int) ([]*model.VehicleDocument, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	expiryDate := time.Now().AddDate(0, 0, days)
+	today := startOfDay(time.Now())
+	expiryDate := today.AddDate(0, 0, days)
 
 	var documents []*model.VehicleDocument
 	cursor, err := r.collection.Find(ctx, bson.M{
 		"expiryDate": bson.M{
-			"$gte": time.Now(),
+			"$gte": today,
 			"$lte": expiryDate,
 		},
 	})
@@ -208,7 +209,7 @@ func (r *VehicleDocumentRepository) FindExpiring(days int) ([]*model.VehicleDocu
 }
 
 // CountByVehicle zählt die Dokumente für ein Fahrzeug
-func (r *VehicleDocumentRepository) CountByVehicle(vehicleID string) (int64, error) {
+func (r *MongoVehicleDocumentRepository) CountByVehicle(vehicleID string) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -13,20 +13,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleReportRepository verwaltet Fahrzeugmeldungen
-type VehicleReportRepository struct {
+// MongoVehicleReportRepository verwaltet Fahrzeugmeldungen
+type MongoVehicleReportRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleReportRepository erstellt eine neue Repository-Instanz
-func NewVehicleReportRepository() *VehicleReportRepository {
-	return &VehicleReportRepository{
+// NewMongoVehicleReportRepository erstellt eine neue Repository-Instanz
+func NewMongoVehicleReportRepository() *MongoVehicleReportRepository {
+	return &MongoVehicleReportRepository{
 		collection: db.GetCollection("vehicleReports"),
 	}
 }
 
 // Create erstellt eine neue Fahrzeugmeldung
-func (r *VehicleReportRepository) Create(report *model.VehicleReport) error {
+func (r *MongoVehicleReportRepository) Create(report *model.VehicleReport) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -47,7 +47,7 @@ func (r *VehicleReportRepository) Create(report *model.VehicleReport) error {
 }
 
 // FindByID findet eine Meldung anhand der ID
-func (r *VehicleReportRepository) FindByID(id primitive.ObjectID) (*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindByID(id primitive.ObjectID) (*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -61,7 +61,7 @@ func (r *VehicleReportRepository) FindByID(id primitive.ObjectID) (*model.Vehicl
 }
 
 // FindByReporter findet alle Meldungen eines Fahrers
-func (r *VehicleReportRepository) FindByReporter(reporterID primitive.ObjectID, limit int) ([]*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindByReporter(reporterID primitive.ObjectID, limit int) ([]*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -89,7 +89,7 @@ func (r *VehicleReportRepository) FindByReporter(reporterID primitive.ObjectID,
 }
 
 // FindByVehicle findet alle Meldungen für ein Fahrzeug
-func (r *VehicleReportRepository) FindByVehicle(vehicleID primitive.ObjectID) ([]*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindByVehicle(vehicleID primitive.ObjectID) ([]*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -113,7 +113,7 @@ func (r *VehicleReportRepository) FindByVehicle(vehicleID primitive.ObjectID) ([
 }
 
 // FindByStatus findet alle Meldungen mit einem bestimmten Status
-func (r *VehicleReportRepository) FindByStatus(status model.ReportStatus) ([]*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindByStatus(status model.ReportStatus) ([]*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -137,7 +137,7 @@ func (r *VehicleReportRepository) FindByStatus(status model.ReportStatus) ([]*mo
 }
 
 // FindAll findet alle Meldungen mit Paginierung
-func (r *VehicleReportRepository) FindAll(page, limit int) ([]*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindAll(page, limit int) ([]*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -165,8 +165,40 @@ func (r *VehicleReportRepository) FindAll(page, limit int) ([]*model.VehicleRepo
 	return reports, cursor.Err()
 }
 
+// FindPage findet eine Seite Meldungen (leerer Status = alle), neueste zuerst
+func (r *MongoVehicleReportRepository) FindPage(status model.ReportStatus, limit int, skip int) ([]*model.VehicleReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []*model.VehicleReport
+	for cursor.Next(ctx) {
+		var report model.VehicleReport
+		if err := cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+
+	return reports, cursor.Err()
+}
+
 // FindUrgent findet alle dringenden Meldungen
-func (r *VehicleReportRepository) FindUrgent() ([]*model.VehicleReport, error) {
+func (r *MongoVehicleReportRepository) FindUrgent() ([]*model.VehicleReport, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -199,7 +231,7 @@ func (r *VehicleReportRepository) FindUrgent() ([]*model.VehicleReport, error) {
 }
 
 // Update aktualisiert eine Meldung
-func (r *VehicleReportRepository) Update(id primitive.ObjectID, update bson.M) error {
+func (r *MongoVehicleReportRepository) Update(id primitive.ObjectID, update bson.M) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -215,7 +247,7 @@ func (r *VehicleReportRepository) Update(id primitive.ObjectID, update bson.M) e
 }
 
 // UpdateStatus ändert den Status einer Meldung
-func (r *VehicleReportRepository) UpdateStatus(id primitive.ObjectID, status model.ReportStatus, updatedBy primitive.ObjectID) error {
+func (r *MongoVehicleReportRepository) UpdateStatus(id primitive.ObjectID, status model.ReportStatus, updatedBy primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -243,7 +275,7 @@ func (r *VehicleReportRepository) UpdateStatus(id primitive.ObjectID, status mod
 }
 
 // AssignTo weist eine Meldung einem Bearbeiter zu
-func (r *VehicleReportRepository) AssignTo(id primitive.ObjectID, assignedTo primitive.ObjectID) error {
+func (r *MongoVehicleReportRepository) AssignTo(id primitive.ObjectID, assignedTo primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -263,7 +295,7 @@ func (r *VehicleReportRepository) AssignTo(id primitive.ObjectID, assignedTo pri
 }
 
 // Resolve markiert eine Meldung als behoben
-func (r *VehicleReportRepository) Resolve(id primitive.ObjectID, resolvedBy primitive.ObjectID, resolution string) error {
+func (r *MongoVehicleReportRepository) Resolve(id primitive.ObjectID, resolvedBy primitive.ObjectID, resolution string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -285,7 +317,7 @@ func (r *VehicleReportRepository) Resolve(id primitive.ObjectID, resolvedBy prim
 }
 
 // Delete löscht eine Meldung
-func (r *VehicleReportRepository) Delete(id primitive.ObjectID) error {
+func (r *MongoVehicleReportRepository) Delete(id primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -294,7 +326,7 @@ func (r *VehicleReportRepository) Delete(id primitive.ObjectID) error {
 }
 
 // CountByStatus zählt Meldungen nach Status
-func (r *VehicleReportRepository) CountByStatus(status model.ReportStatus) (int64, error) {
+func (r *MongoVehicleReportRepository) CountByStatus(status model.ReportStatus) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -303,7 +335,7 @@ func (r *VehicleReportRepository) CountByStatus(status model.ReportStatus) (int6
 }
 
 // CountByReporter zählt Meldungen eines Fahrers
-func (r *VehicleReportRepository) CountByReporter(reporterID primitive.ObjectID) (int64, error) {
+func (r *MongoVehicleReportRepository) CountByReporter(reporterID primitive.ObjectID) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -312,14 +344,14 @@ func (r *VehicleReportRepository) CountByReporter(reporterID primitive.ObjectID)
 }
 
 // GetStatistics liefert Statistiken über Meldungen
-func (r *VehicleReportRepository) GetStatistics() (map[string]interface{}, error) {
+func (r *MongoVehicleReportRepository) GetStatistics() (map[string]interface{}, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	pipeline := []bson.M{
 		{
 			"$group": bson.M{
-				"_id": "$status",
+				"_id":   "$status",
 				"count": bson.M{"$sum": 1},
 			},
 		},
@@ -348,4 +380,4 @@ func (r *VehicleReportRepository) GetStatistics() (map[string]interface{}, error
 	}
 
 	return stats, cursor.Err()
-}
\ No newline at end of file
+}
@@ -12,22 +12,23 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleRepository enthält alle Datenbankoperationen für das Vehicle-Modell
-type VehicleRepository struct {
+// MongoVehicleRepository enthält alle Datenbankoperationen für das Vehicle-Modell
+type MongoVehicleRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleRepository erstellt ein neues VehicleRepository
-func NewVehicleRepository() *VehicleRepository {
-	return &VehicleRepository{
+// NewMongoVehicleRepository erstellt ein neues MongoVehicleRepository
+func NewMongoVehicleRepository() *MongoVehicleRepository {
+	return &MongoVehicleRepository{
 		collection: db.GetCollection("vehicles"),
 	}
 }
 
 // Create erstellt ein neues Fahrzeug
-func (r *VehicleRepository) Create(vehicle *model.Vehicle) error {
+func (r *MongoVehicleRepository) Create(vehicle *model.Vehicle) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +45,7 @@ func (r *VehicleRepository) Create(vehicle *model.Vehicle) error {
 }
 
 // FindByID findet ein Fahrzeug anhand seiner ID
-func (r *VehicleRepository) FindByID(id string) (*model.Vehicle, error) {
+func (r *MongoVehicleRepository) FindByID(id string) (*model.Vehicle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +64,7 @@ func (r *VehicleRepository) FindByID(id string) (*model.Vehicle, error) {
 }
 
 // FindByLicensePlate findet ein Fahrzeug anhand seines Kennzeichens
-func (r *VehicleRepository) FindByLicensePlate(licensePlate string) (*model.Vehicle, error) {
+func (r *MongoVehicleRepository) FindByLicensePlate(licensePlate string) (*model.Vehicle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -77,7 +78,7 @@ func (r *VehicleRepository) FindByLicensePlate(licensePlate string) (*model.Vehi
 }
 
 // FindAll findet alle Fahrzeuge
-func (r *VehicleRepository) FindAll() ([]*model.Vehicle, error) {
+func (r *MongoVehicleRepository) FindAll() ([]*model.Vehicle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -104,14 +105,14 @@ func (r *VehicleRepository) FindAll() ([]*model.Vehicle, error) {
 }
 
 // Update aktualisiert ein Fahrzeug
-func (r *VehicleRepository) Update(vehicle *model.Vehicle) error {
+func (r *MongoVehicleRepository) Update(vehicle *model.Vehicle) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	vehicle.UpdatedAt = time.Now()
 
 	// Debug-Ausgabe
-	fmt.Printf("=== VehicleRepository.Update DEBUG ===\n")
+	fmt.Printf("=== MongoVehicleRepository.Update DEBUG ===\n")
 	fmt.Printf("Updating vehicle ID: %s\n", vehicle.ID.Hex())
 	fmt.Printf("Brand/Model: %s %s (%s)\n", vehicle.Brand, vehicle.Model, vehicle.LicensePlate)
 	fmt.Printf("CardNumber: '%s'\n", vehicle.CardNumber)
@@ -157,8 +158,11 @@ func (r *VehicleRepository) Update(vehicle *model.Vehicle) error {
 			"curbWeight":             vehicle.CurbWeight,
 			"maxSpeed":               vehicle.MaxSpeed,
 			"towingCapacity":         vehicle.TowingCapacity,
+			"tankCapacity":           vehicle.TankCapacity,
 			"specialFeatures":        vehicle.SpecialFeatures,
+			"requiredLicenseClass":   vehicle.RequiredLicenseClass,
 			"acquisitionType":        vehicle.AcquisitionType,
+			"listPrice":              vehicle.ListPrice,
 			"purchaseDate":           vehicle.PurchaseDate,
 			"purchasePrice":          vehicle.PurchasePrice,
 			"purchaseVendor":         vehicle.PurchaseVendor,
@@ -272,7 +276,7 @@ func getMapKeys(m bson.M) []string {
 }
 
 // Delete löscht ein Fahrzeug
-func (r *VehicleRepository) Delete(id string) error {
+func (r *MongoVehicleRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -286,7 +290,7 @@ func (r *VehicleRepository) Delete(id string) error {
 }
 
 // FindByStatus findet alle Fahrzeuge mit einem bestimmten Status
-func (r *VehicleRepository) FindByStatus(status model.VehicleStatus) ([]*model.Vehicle, error) {
+func (r *MongoVehicleRepository) FindByStatus(status model.VehicleStatus) ([]*model.Vehicle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -312,8 +316,40 @@ func (r *VehicleRepository) FindByStatus(status model.VehicleStatus) ([]*model.V
 	return vehicles, nil
 }
 
+// FindPage findet eine Seite Fahrzeuge (leerer Status = alle), sortiert nach Anlage
+func (r *MongoVehicleRepository) FindPage(status model.VehicleStatus, limit int, skip int) ([]*model.Vehicle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var vehicles []*model.Vehicle
+	for cursor.Next(ctx) {
+		var vehicle model.Vehicle
+		if err := cursor.Decode(&vehicle); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, &vehicle)
+	}
+
+	return vehicles, cursor.Err()
+}
+
 // CountByStatusAndDate zählt Fahrzeuge mit einem bestimmten Status an einem bestimmten Datum
-func (r *VehicleRepository) CountByStatusAndDate(status model.VehicleStatus, date time.Time) (int64, error) {
+func (r *MongoVehicleRepository) CountByStatusAndDate(status model.VehicleStatus, date time.Time) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
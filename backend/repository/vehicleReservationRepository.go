@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleReservationRepository enthält alle Datenbankoperationen für das VehicleReservation-Modell
-type VehicleReservationRepository struct {
+// MongoVehicleReservationRepository enthält alle Datenbankoperationen für das VehicleReservation-Modell
+type MongoVehicleReservationRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleReservationRepository erstellt ein neues VehicleReservationRepository
-func NewVehicleReservationRepository() *VehicleReservationRepository {
-	return &VehicleReservationRepository{
+// NewMongoVehicleReservationRepository erstellt ein neues MongoVehicleReservationRepository
+func NewMongoVehicleReservationRepository() *MongoVehicleReservationRepository {
+	return &MongoVehicleReservationRepository{
 		collection: db.GetCollection("vehicle_reservations"),
 	}
 }
 
 // Create erstellt eine neue Reservierung
-func (r *VehicleReservationRepository) Create(reservation *model.VehicleReservation) error {
+func (r *MongoVehicleReservationRepository) Create(reservation *model.VehicleReservation) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *VehicleReservationRepository) Create(reservation *model.VehicleReservat
 }
 
 // FindByID findet eine Reservierung anhand ihrer ID
-func (r *VehicleReservationRepository) FindByID(id string) (*model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindByID(id string) (*model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *VehicleReservationRepository) FindByID(id string) (*model.VehicleReserv
 }
 
 // FindAll findet alle Reservierungen
-func (r *VehicleReservationRepository) FindAll() ([]model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindAll() ([]model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -81,8 +81,40 @@ func (r *VehicleReservationRepository) FindAll() ([]model.VehicleReservation, er
 	return reservations, nil
 }
 
+// FindPage findet eine Seite Reservierungen (ohne includeCompleted nur nicht abgeschlossene), sortiert nach Anlage
+func (r *MongoVehicleReservationRepository) FindPage(includeCompleted bool, limit int, skip int) ([]model.VehicleReservation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if !includeCompleted {
+		filter["status"] = bson.M{"$ne": model.ReservationStatusCompleted}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []model.VehicleReservation
+	for cursor.Next(ctx) {
+		var reservation model.VehicleReservation
+		if err := cursor.Decode(&reservation); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, cursor.Err()
+}
+
 // FindByVehicleID findet alle Reservierungen für ein bestimmtes Fahrzeug
-func (r *VehicleReservationRepository) FindByVehicleID(vehicleID string) ([]model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindByVehicleID(vehicleID string) ([]model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -106,7 +138,7 @@ func (r *VehicleReservationRepository) FindByVehicleID(vehicleID string) ([]mode
 }
 
 // FindByDriverID findet alle Reservierungen für einen bestimmten Fahrer
-func (r *VehicleReservationRepository) FindByDriverID(driverID string) ([]model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindByDriverID(driverID string) ([]model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -129,8 +161,33 @@ func (r *VehicleReservationRepository) FindByDriverID(driverID string) ([]model.
 	return reservations, nil
 }
 
+// FindBySeriesID findet alle Reservierungen einer Serie, sortiert nach Startzeit
+func (r *MongoVehicleReservationRepository) FindBySeriesID(seriesID string) ([]model.VehicleReservation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"seriesId": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []model.VehicleReservation
+	if err = cursor.All(ctx, &reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
 // CheckConflict prüft ob es einen Terminkonflikt gibt
-func (r *VehicleReservationRepository) CheckConflict(vehicleID string, startTime, endTime time.Time, excludeID *string) (bool, error) {
+func (r *MongoVehicleReservationRepository) CheckConflict(vehicleID string, startTime, endTime time.Time, excludeID *string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -171,13 +228,13 @@ func (r *VehicleReservationRepository) CheckConflict(vehicleID string, startTime
 
 // ConflictDetails enthält detaillierte Informationen über Reservierungskonflikte
 type ConflictDetails struct {
-	HasConflict         bool                         `json:"hasConflict"`
+	HasConflict             bool                       `json:"hasConflict"`
 	ConflictingReservations []model.VehicleReservation `json:"conflictingReservations"`
-	Message             string                       `json:"message"`
+	Message                 string                     `json:"message"`
 }
 
 // CheckConflictDetails prüft auf Konflikte und liefert detaillierte Informationen
-func (r *VehicleReservationRepository) CheckConflictDetails(vehicleID string, startTime, endTime time.Time, excludeID *string) (*ConflictDetails, error) {
+func (r *MongoVehicleReservationRepository) CheckConflictDetails(vehicleID string, startTime, endTime time.Time, excludeID *string) (*ConflictDetails, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -219,6 +276,11 @@ func (r *VehicleReservationRepository) CheckConflictDetails(vehicleID string, st
 		return nil, err
 	}
 
+	return NewConflictDetails(conflicts), nil
+}
+
+// NewConflictDetails baut die Konfliktbeschreibung für gefundene Überschneidungen auf
+func NewConflictDetails(conflicts []model.VehicleReservation) *ConflictDetails {
 	result := &ConflictDetails{
 		HasConflict:             len(conflicts) > 0,
 		ConflictingReservations: conflicts,
@@ -235,11 +297,11 @@ func (r *VehicleReservationRepository) CheckConflictDetails(vehicleID string, st
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // FindActiveReservations findet alle aktiven Reservierungen
-func (r *VehicleReservationRepository) FindActiveReservations() ([]model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindActiveReservations() ([]model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -265,7 +327,7 @@ func (r *VehicleReservationRepository) FindActiveReservations() ([]model.Vehicle
 }
 
 // Update aktualisiert eine Reservierung
-func (r *VehicleReservationRepository) Update(reservation *model.VehicleReservation) error {
+func (r *MongoVehicleReservationRepository) Update(reservation *model.VehicleReservation) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -279,7 +341,7 @@ func (r *VehicleReservationRepository) Update(reservation *model.VehicleReservat
 }
 
 // Delete löscht eine Reservierung
-func (r *VehicleReservationRepository) Delete(id string) error {
+func (r *MongoVehicleReservationRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -293,7 +355,7 @@ func (r *VehicleReservationRepository) Delete(id string) error {
 }
 
 // FindUpcomingReservations findet anstehende Reservierungen (für Benachrichtigungen)
-func (r *VehicleReservationRepository) FindUpcomingReservations(hours int) ([]model.VehicleReservation, error) {
+func (r *MongoVehicleReservationRepository) FindUpcomingReservations(hours int) ([]model.VehicleReservation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -301,11 +363,11 @@ func (r *VehicleReservationRepository) FindUpcomingReservations(hours int) ([]mo
 	upcoming := now.Add(time.Duration(hours) * time.Hour)
 
 	filter := bson.M{
-		"status":    string(model.ReservationStatusPending),
+		"status":    bson.M{"$in": []model.ReservationStatus{model.ReservationStatusPending, model.ReservationStatusApproved}},
 		"startTime": bson.M{"$gte": now, "$lte": upcoming},
 	}
 
-	opts := options.Find().SetSort(bson.D{{"startTime", 1}})
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}})
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
@@ -318,4 +380,4 @@ func (r *VehicleReservationRepository) FindUpcomingReservations(hours int) ([]mo
 	}
 
 	return reservations, nil
-}
\ No newline at end of file
+}
@@ -14,20 +14,20 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VehicleUsageRepository enthält alle Datenbankoperationen für das VehicleUsage-Modell
-type VehicleUsageRepository struct {
+// MongoVehicleUsageRepository enthält alle Datenbankoperationen für das VehicleUsage-Modell
+type MongoVehicleUsageRepository struct {
 	collection *mongo.Collection
 }
 
-// NewVehicleUsageRepository erstellt ein neues VehicleUsageRepository
-func NewVehicleUsageRepository() *VehicleUsageRepository {
-	return &VehicleUsageRepository{
+// NewMongoVehicleUsageRepository erstellt ein neues MongoVehicleUsageRepository
+func NewMongoVehicleUsageRepository() *MongoVehicleUsageRepository {
+	return &MongoVehicleUsageRepository{
 		collection: db.GetCollection("vehicleUsage"),
 	}
 }
 
 // Create erstellt einen neuen Fahrzeugnutzungseintrag
-func (r *VehicleUsageRepository) Create(usage *model.VehicleUsage) error {
+func (r *MongoVehicleUsageRepository) Create(usage *model.VehicleUsage) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (r *VehicleUsageRepository) Create(usage *model.VehicleUsage) error {
 }
 
 // FindByID findet einen Fahrzeugnutzungseintrag anhand seiner ID
-func (r *VehicleUsageRepository) FindByID(id string) (*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindByID(id string) (*model.VehicleUsage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -63,7 +63,7 @@ func (r *VehicleUsageRepository) FindByID(id string) (*model.VehicleUsage, error
 }
 
 // FindAll findet alle Fahrzeugnutzungseinträge
-func (r *VehicleUsageRepository) FindAll() ([]*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindAll() ([]*model.VehicleUsage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -92,8 +92,37 @@ func (r *VehicleUsageRepository) FindAll() ([]*model.VehicleUsage, error) {
 	return usages, nil
 }
 
+// FindPage findet eine Seite Nutzungseinträge, neueste zuerst
+func (r *MongoVehicleUsageRepository) FindPage(limit int, skip int) ([]*model.VehicleUsage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "startDate", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var usages []*model.VehicleUsage
+	for cursor.Next(ctx) {
+		var usage model.VehicleUsage
+		if err := cursor.Decode(&usage); err != nil {
+			return nil, err
+		}
+		usages = append(usages, &usage)
+	}
+
+	return usages, cursor.Err()
+}
+
 // FindByVehicle findet alle Nutzungseinträge für ein bestimmtes Fahrzeug
-func (r *VehicleUsageRepository) FindByVehicle(vehicleID string) ([]*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindByVehicle(vehicleID string) ([]*model.VehicleUsage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -128,7 +157,7 @@ func (r *VehicleUsageRepository) FindByVehicle(vehicleID string) ([]*model.Vehic
 }
 
 // FindByDriver findet alle Nutzungseinträge für einen bestimmten Fahrer
-func (r *VehicleUsageRepository) FindByDriver(driverID string) ([]*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindByDriver(driverID string) ([]*model.VehicleUsage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -163,7 +192,7 @@ func (r *VehicleUsageRepository) FindByDriver(driverID string) ([]*model.Vehicle
 }
 
 // FindActiveUsage findet die aktive Nutzung eines Fahrzeugs
-func (r *VehicleUsageRepository) FindActiveUsage(vehicleID string) (*model.VehicleUsage, error) {
+func (r *MongoVehicleUsageRepository) FindActiveUsage(vehicleID string) (*model.VehicleUsage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -189,7 +218,7 @@ func (r *VehicleUsageRepository) FindActiveUsage(vehicleID string) (*model.Vehic
 }
 
 // Update aktualisiert einen Fahrzeugnutzungseintrag
-func (r *VehicleUsageRepository) Update(usage *model.VehicleUsage) error {
+func (r *MongoVehicleUsageRepository) Update(usage *model.VehicleUsage) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -204,7 +233,7 @@ func (r *VehicleUsageRepository) Update(usage *model.VehicleUsage) error {
 }
 
 // Delete löscht einen Fahrzeugnutzungseintrag
-func (r *VehicleUsageRepository) Delete(id string) error {
+func (r *MongoVehicleUsageRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -218,7 +247,7 @@ func (r *VehicleUsageRepository) Delete(id string) error {
 }
 
 // CountActiveUsagesByDateRange zählt aktive Fahrzeugnutzungen in einem Zeitraum
-func (r *VehicleUsageRepository) CountActiveUsagesByDateRange(startDate, endDate time.Time) (int64, error) {
+func (r *MongoVehicleUsageRepository) CountActiveUsagesByDateRange(startDate, endDate time.Time) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"FleetFlow/backend/db"
+	"FleetFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWorkOrderRepository enthält alle Datenbankoperationen für Werkstattaufträge
+type MongoWorkOrderRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoWorkOrderRepository erstellt ein neues MongoWorkOrderRepository
+func NewMongoWorkOrderRepository() *MongoWorkOrderRepository {
+	r := &MongoWorkOrderRepository{
+		collection: db.GetCollection("work_orders"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vehicleId", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "reportIds", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Indizes für work_orders konnten nicht erstellt werden: %v", err)
+	}
+
+	return r
+}
+
+// Create legt einen neuen Werkstattauftrag an
+func (r *MongoWorkOrderRepository) Create(order *model.WorkOrder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	order.ID = primitive.NewObjectID()
+	order.CreatedAt = now
+	order.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, order)
+	return err
+}
+
+// Update aktualisiert einen Werkstattauftrag
+func (r *MongoWorkOrderRepository) Update(order *model.WorkOrder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	order.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"vehicleId":             order.VehicleID,
+			"reportIds":             order.ReportIDs,
+			"workshop":              order.Workshop,
+			"description":           order.Description,
+			"maintenanceType":       order.MaintenanceType,
+			"lineItems":             order.LineItems,
+			"estimatedCost":         order.EstimatedCost,
+			"actualCost":            order.ActualCost,
+			"downtimeStart":         order.DowntimeStart,
+			"downtimeEnd":           order.DowntimeEnd,
+			"status":                order.Status,
+			"previousVehicleStatus": order.PreviousVehicleStatus,
+			"mileage":               order.Mileage,
+			"resolution":            order.Resolution,
+			"maintenanceId":         order.MaintenanceID,
+			"closedBy":              order.ClosedBy,
+			"closedAt":              order.ClosedAt,
+			"updatedAt":             order.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": order.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindByID findet einen Werkstattauftrag anhand seiner ID
+func (r *MongoWorkOrderRepository) FindByID(id string) (*model.WorkOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var order model.WorkOrder
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Find findet alle Werkstattaufträge zum Filter, die neuesten zuerst
+func (r *MongoWorkOrderRepository) Find(filter model.WorkOrderFilter) ([]*model.WorkOrder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.VehicleID != nil {
+		query["vehicleId"] = *filter.VehicleID
+	}
+	if filter.ReportID != nil {
+		query["reportIds"] = *filter.ReportID
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var orders []*model.WorkOrder
+	if err = cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
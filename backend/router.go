@@ -5,6 +5,7 @@ import (
 	"FleetFlow/backend/middleware"
 	"FleetFlow/backend/model"
 	"FleetFlow/backend/repository"
+	"FleetFlow/backend/service"
 	"FleetFlow/backend/utils"
 	"net/http"
 	"time"
@@ -13,13 +14,13 @@ import (
 )
 
 // InitializeRoutes setzt alle Routen für die Anwendung auf
-func InitializeRoutes(router *gin.Engine) {
+func InitializeRoutes(router *gin.Engine, repos *repository.Repositories, services *service.Services) {
 	// Public routes (keine Authentifizierung erforderlich)
-	setupPublicRoutes(router)
+	setupPublicRoutes(router, repos, services)
 
 	// Auth middleware für geschützte Routen
 	authorized := router.Group("/")
-	authorized.Use(middleware.AuthMiddleware())
+	authorized.Use(middleware.AuthMiddleware(repos.User))
 	{
 		// Dashboard-Route mit rollenbasierter Weiterleitung (muss vor anderen Routen stehen)
 		authorized.GET("/dashboard", func(c *gin.Context) {
@@ -36,20 +37,20 @@ func InitializeRoutes(router *gin.Engine) {
 			}
 			
 			// Für Manager und Admins: normale Dashboard-Handler aufrufen
-			dashboardHandler := handler.NewDashboardHandler()
+			dashboardHandler := handler.NewDashboardHandler(repos, services)
 			dashboardHandler.GetCompleteDashboardData(c)
 		})
 		
 		// Fahrer-spezifische Routen
-		setupDriverRoutes(authorized.Group("/driver"))
+		setupDriverRoutes(authorized.Group("/driver"), repos, services)
 		
 		// Manager/Admin Routen (schützen vor Fahrer-Zugriff)
 		managerRoutes := authorized.Group("/")
 		managerRoutes.Use(middleware.ManagerOrAdminMiddleware())
-		setupAuthorizedRoutes(managerRoutes)
+		setupAuthorizedRoutes(managerRoutes, repos, services)
 		
 		// API Routen mit individueller Berechtigung
-		setupAPIRoutes(authorized.Group("/api"))
+		setupAPIRoutes(authorized.Group("/api"), repos, services)
 	}
 
 	// Catch-All für alle anderen Routen: Fahrer zu ihrem Dashboard weiterleiten
@@ -78,7 +79,7 @@ func InitializeRoutes(router *gin.Engine) {
 }
 
 // setupPublicRoutes konfiguriert die öffentlichen Routen
-func setupPublicRoutes(router *gin.Engine) {
+func setupPublicRoutes(router *gin.Engine, repos *repository.Repositories, services *service.Services) {
 	router.GET("/login", func(c *gin.Context) {
 		// Token aus dem Cookie extrahieren
 		tokenString, err := c.Cookie("token")
@@ -99,10 +100,14 @@ func setupPublicRoutes(router *gin.Engine) {
 	})
 
 	// Auth-Handler erstellen
-	authHandler := handler.NewAuthHandler()
+	authHandler := handler.NewAuthHandler(repos)
 	router.POST("/auth", authHandler.Login)
 	router.GET("/logout", authHandler.Logout)
 
+	// iCalendar-Feeds für Kalender-Abonnements (Zugriff über den Token in der URL)
+	calendarHandler := handler.NewCalendarHandler(services)
+	router.GET("/calendar/:token", calendarHandler.GetFeed)
+
 	// Root-Pfad zum Dashboard umleiten (rollenbasiert)
 	router.GET("/", func(c *gin.Context) {
 		// Token aus dem Cookie extrahieren
@@ -120,8 +125,7 @@ func setupPublicRoutes(router *gin.Engine) {
 		}
 
 		// Benutzer aus der Datenbank abrufen
-		userRepo := repository.NewUserRepository()
-		user, err := userRepo.FindByID(claims.UserID)
+		user, err := repos.User.FindByID(claims.UserID)
 		if err != nil {
 			c.Redirect(http.StatusFound, "/login")
 			return
@@ -138,7 +142,7 @@ func setupPublicRoutes(router *gin.Engine) {
 }
 
 // setupAuthorizedRoutes konfiguriert die geschützten Seitenrouten
-func setupAuthorizedRoutes(group *gin.RouterGroup) {
+func setupAuthorizedRoutes(group *gin.RouterGroup, repos *repository.Repositories, services *service.Services) {
 	currentYear := time.Now().Year()
 
 	// Dashboard-Route ist bereits oben definiert mit rollenbasierter Weiterleitung
@@ -163,8 +167,7 @@ func setupAuthorizedRoutes(group *gin.RouterGroup) {
 
 		// Nur für Admins: Benutzer laden und Statistiken berechnen
 		if currentUser.Role == model.RoleAdmin {
-			userRepo := repository.NewUserRepository()
-			users, err := userRepo.FindAll()
+			users, err := repos.User.FindAll()
 			if err == nil {
 				templateData["users"] = users
 
@@ -235,13 +238,13 @@ func setupAuthorizedRoutes(group *gin.RouterGroup) {
 		user, _ := c.Get("user")
 		vehicleID := c.Param("id")
 
-		// Repository initialisieren
-		vehicleRepo := repository.NewVehicleRepository()
-		driverRepo := repository.NewDriverRepository()
-		maintenanceRepo := repository.NewMaintenanceRepository()
-		usageRepo := repository.NewVehicleUsageRepository()
-		fuelCostRepo := repository.NewFuelCostRepository()
-		documentRepo := repository.NewVehicleDocumentRepository() // NEU
+		// Repositories übernehmen
+		vehicleRepo := repos.Vehicle
+		driverRepo := repos.Driver
+		maintenanceRepo := repos.Maintenance
+		usageRepo := repos.VehicleUsage
+		fuelCostRepo := repos.FuelCost
+		documentRepo := repos.VehicleDocument
 
 		// Fahrzeugdaten laden
 		vehicle, err := vehicleRepo.FindByID(vehicleID)
@@ -362,6 +365,7 @@ func setupAuthorizedRoutes(group *gin.RouterGroup) {
 			"emissionClass":      vehicle.EmissionClass,
 			"maxSpeed":           vehicle.MaxSpeed,
 			"towingCapacity":     vehicle.TowingCapacity,
+			"tankCapacity":       vehicle.TankCapacity,
 			// Abmessungen
 			"length":             vehicle.Length,
 			"width":              vehicle.Width,
@@ -372,6 +376,7 @@ func setupAuthorizedRoutes(group *gin.RouterGroup) {
 			"specialFeatures":    vehicle.SpecialFeatures,
 			// Finanzierungsinformationen
 			"acquisitionType":        vehicle.AcquisitionType,
+			"listPrice":              vehicle.ListPrice,
 			"purchaseDate":           vehicle.PurchaseDate,
 			"purchasePrice":          vehicle.PurchasePrice,
 			"purchaseVendor":         vehicle.PurchaseVendor,
@@ -475,30 +480,48 @@ func setupAuthorizedRoutes(group *gin.RouterGroup) {
 	})
 
 	// Reservierungen (mit integriertem Kalender)
-	reservationHandler := handler.NewReservationHandler()
+	reservationHandler := handler.NewReservationHandler(repos, services)
 	group.GET("/reservations", reservationHandler.ShowReservationsPage)
 	
 	// Manager-Genehmigungsseite (nur für Manager und Admins)
-	managerApprovalHandler := handler.NewManagerApprovalHandler()
+	managerApprovalHandler := handler.NewManagerApprovalHandler(repos, services)
 	group.GET("/manager/approvals", middleware.ManagerOrAdminMiddleware(), managerApprovalHandler.ShowManagerApprovalPage)
 }
 
 // setupAPIRoutes konfiguriert die API-Routen
-func setupAPIRoutes(api *gin.RouterGroup) {
+func setupAPIRoutes(api *gin.RouterGroup, repos *repository.Repositories, services *service.Services) {
 	// Handler initialisieren
-	userHandler := handler.NewUserHandler()
-	vehicleHandler := handler.NewVehicleHandler()
-	driverHandler := handler.NewDriverHandler()
-	maintenanceHandler := handler.NewMaintenanceHandler()
-	usageHandler := handler.NewVehicleUsageHandler()
-	fuelCostHandler := handler.NewFuelCostHandler()
-	activityHandler := handler.NewActivityHandler()
-	profileHandler := handler.NewProfileHandler()
-	dashboardHandler := handler.NewDashboardHandler()
-	reportsHandler := handler.NewReportsHandler()
-	documentHandler := handler.NewVehicleDocumentHandler()
-	driverDocumentHandler := handler.NewDriverDocumentHandler()
-	reservationHandler := handler.NewReservationHandler()
+	userHandler := handler.NewUserHandler(repos, services)
+	vehicleHandler := handler.NewVehicleHandler(repos, services)
+	driverHandler := handler.NewDriverHandler(repos, services)
+	maintenanceHandler := handler.NewMaintenanceHandler(repos, services)
+	usageHandler := handler.NewVehicleUsageHandler(repos, services)
+	fuelCostHandler := handler.NewFuelCostHandler(repos, services)
+	activityHandler := handler.NewActivityHandler(repos)
+	profileHandler := handler.NewProfileHandler(repos)
+	dashboardHandler := handler.NewDashboardHandler(repos, services)
+	reportsHandler := handler.NewReportsHandler(repos)
+	documentHandler := handler.NewVehicleDocumentHandler(repos, services)
+	driverDocumentHandler := handler.NewDriverDocumentHandler(repos, services)
+	reservationHandler := handler.NewReservationHandler(repos, services)
+	handoverHandler := handler.NewHandoverHandler(services)
+	calendarHandler := handler.NewCalendarHandler(services)
+	jobHandler := handler.NewJobHandler(services)
+	expiryReminderHandler := handler.NewExpiryReminderHandler(services)
+	logbookHandler := handler.NewLogbookHandler(services)
+	taxableBenefitHandler := handler.NewTaxableBenefitHandler(services)
+	fuelImportHandler := handler.NewFuelImportHandler(services)
+	bulkImportHandler := handler.NewBulkImportHandler(services)
+	fuelConsumptionHandler := handler.NewFuelConsumptionHandler(services)
+	chargingSessionHandler := handler.NewChargingSessionHandler(services)
+	maintenancePlanHandler := handler.NewMaintenancePlanHandler(services)
+	workOrderHandler := handler.NewWorkOrderHandler(services)
+	tireHandler := handler.NewTireHandler(services)
+	trafficFineHandler := handler.NewTrafficFineHandler(services)
+	accidentClaimHandler := handler.NewAccidentClaimHandler(services)
+	tcoHandler := handler.NewTCOHandler(services)
+	leaseHandler := handler.NewLeaseHandler(services)
+	financingHandler := handler.NewFinancingHandler(services)
 
 	// Benutzer-API
 	users := api.Group("/users")
@@ -522,12 +545,16 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		profile.POST("/picture", profileHandler.UploadProfilePicture)
 		profile.GET("/picture", profileHandler.GetProfilePicture)
 		profile.DELETE("/picture", profileHandler.DeleteProfilePicture)
+		profile.GET("/calendar-feeds", calendarHandler.GetFeedTokens)
+		profile.POST("/calendar-feeds", calendarHandler.CreateFeedToken)
+		profile.DELETE("/calendar-feeds/:id", calendarHandler.RevokeFeedToken)
 	}
 
 	// Fahrzeug-API (KORRIGIERT)
 	vehicles := api.Group("/vehicles")
 	{
 		vehicles.GET("", vehicleHandler.GetVehicles)
+		vehicles.GET("/vin/:vin", vehicleHandler.DecodeVIN)
 		vehicles.GET("/:id", vehicleHandler.GetVehicle)
 		vehicles.POST("", vehicleHandler.CreateVehicle)
 		vehicles.PUT("/:id", vehicleHandler.UpdateVehicle)
@@ -577,6 +604,117 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		maintenance.GET("/upcoming", dashboardHandler.GetUpcomingMaintenance)
 	}
 
+	// Wartungspläne nach Kilometern und/oder Zeit mit hochgerechneter Fälligkeit
+	maintenancePlans := api.Group("/maintenance-plans")
+	{
+		maintenancePlans.GET("", maintenancePlanHandler.GetPlans)
+		maintenancePlans.GET("/due", maintenancePlanHandler.GetDues) // ?vehicleId=&type=&status=due,overdue
+		maintenancePlans.GET("/:id", maintenancePlanHandler.GetPlan)
+		maintenancePlans.POST("", middleware.ManagerOrAdminMiddleware(), maintenancePlanHandler.CreatePlan)
+		maintenancePlans.PUT("/:id", middleware.ManagerOrAdminMiddleware(), maintenancePlanHandler.UpdatePlan)
+		maintenancePlans.DELETE("/:id", middleware.ManagerOrAdminMiddleware(), maintenancePlanHandler.DeletePlan)
+	}
+
+	// Werkstattaufträge zu Fahrzeugmeldungen (Fahrzeug steht währenddessen auf "maintenance")
+	workOrders := api.Group("/work-orders")
+	workOrders.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		workOrders.GET("", workOrderHandler.GetWorkOrders) // ?vehicleId=&reportId=&status=
+		workOrders.POST("", workOrderHandler.CreateWorkOrder)
+		workOrders.GET("/:id", workOrderHandler.GetWorkOrder)
+		workOrders.PUT("/:id", workOrderHandler.UpdateWorkOrder)
+		workOrders.POST("/:id/start", workOrderHandler.StartWorkOrder)
+		workOrders.POST("/:id/close", workOrderHandler.CloseWorkOrder) // Erstellt den Wartungseintrag und behebt die Meldungen
+		workOrders.POST("/:id/cancel", workOrderHandler.CancelWorkOrder)
+	}
+
+	// Reifensätze, Reifenwechsel und saisonale Wechselaktionen
+	tires := api.Group("/tires")
+	tires.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		tires.GET("/sets", tireHandler.GetSets) // ?vehicleId=&location=&season=&retired=true
+		tires.POST("/sets", tireHandler.CreateSet)
+		tires.GET("/sets/:id", tireHandler.GetSet)
+		tires.PUT("/sets/:id", tireHandler.UpdateSet)
+		tires.DELETE("/sets/:id", tireHandler.DeleteSet)
+		tires.POST("/sets/:id/measurements", tireHandler.AddMeasurement)
+		tires.POST("/swap", tireHandler.Swap) // Erstellt einen Wartungseintrag "tire-change"
+		tires.GET("/warnings", tireHandler.GetWarnings)
+		tires.GET("/settings", tireHandler.GetSettings)
+		tires.PUT("/settings", middleware.AdminMiddleware(), tireHandler.SaveSettings)
+		tires.GET("/campaigns", tireHandler.GetCampaigns)
+		tires.POST("/campaigns", tireHandler.PlanCampaign)
+		tires.GET("/campaigns/:id", tireHandler.GetCampaign)
+		tires.PUT("/campaigns/:id/items/:vehicleId", tireHandler.UpdateCampaignItem)
+	}
+
+	// Bußgeld-API
+	trafficFines := api.Group("/traffic-fines")
+	trafficFines.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		trafficFines.GET("", trafficFineHandler.GetFines) // ?vehicleId=&driverId=&status=&overdue=true
+		trafficFines.POST("", trafficFineHandler.CreateFine)
+		trafficFines.GET("/resolve", trafficFineHandler.ResolveDriver) // ?vehicleId=|licensePlate=&at=YYYY-MM-DDTHH:MM
+		trafficFines.GET("/:id", trafficFineHandler.GetFine)
+		trafficFines.PUT("/:id", trafficFineHandler.UpdateFine)
+		trafficFines.DELETE("/:id", trafficFineHandler.DeleteFine)
+		trafficFines.PUT("/:id/driver", trafficFineHandler.AssignDriver)
+		trafficFines.POST("/:id/notify", trafficFineHandler.NotifyDriver)
+		trafficFines.POST("/:id/forward", trafficFineHandler.Forward)
+		trafficFines.POST("/:id/payment", trafficFineHandler.RecordPayment)
+		trafficFines.POST("/:id/cancel", trafficFineHandler.Cancel)
+	}
+
+	// Schadenfall-API zu Unfallmeldungen
+	claims := api.Group("/claims")
+	claims.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		claims.GET("", accidentClaimHandler.GetClaims) // ?vehicleId=&driverId=&status=
+		claims.POST("", accidentClaimHandler.CreateClaim)
+		claims.GET("/:id", accidentClaimHandler.GetClaim)
+		claims.PUT("/:id", accidentClaimHandler.UpdateClaim)
+		claims.DELETE("/:id", accidentClaimHandler.DeleteClaim)
+		claims.POST("/:id/status", accidentClaimHandler.ChangeStatus)
+		claims.POST("/:id/notes", accidentClaimHandler.AddNote)
+		claims.POST("/:id/reimbursements", accidentClaimHandler.RecordReimbursement)
+		claims.GET("/:id/documents", accidentClaimHandler.GetDocuments)
+		claims.POST("/:id/documents", accidentClaimHandler.UploadDocument) // Download über /api/documents/:id/download
+	}
+
+	// Gesamtkosten-API (?from=&to=&method=linear|declining_balance&years=&rate=)
+	tco := api.Group("/tco")
+	tco.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		tco.GET("/vehicles/:id", tcoHandler.GetVehicleTCO)
+		tco.GET("/fleet", tcoHandler.GetFleetTCO)
+		tco.GET("/models", tcoHandler.GetModelComparison)
+	}
+
+	// Leasing-API: Mehrkilometer-Hochrechnung, Tauschvorschläge und Rückgabe-Checklisten
+	leases := api.Group("/leases")
+	leases.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		leases.GET("/forecasts", leaseHandler.GetForecasts)
+		leases.GET("/forecasts/:vehicleId", leaseHandler.GetForecast)
+		leases.GET("/swap-suggestions", leaseHandler.GetSwapSuggestions)
+		leases.GET("/returns", leaseHandler.GetReturns) // ?status=open|completed
+		leases.POST("/returns", leaseHandler.StartReturn)
+		leases.GET("/returns/:id", leaseHandler.GetReturn)
+		leases.PUT("/returns/:id", leaseHandler.UpdateReturn)
+		leases.DELETE("/returns/:id", leaseHandler.DeleteReturn)
+		leases.PUT("/returns/:id/items/:key", leaseHandler.UpdateItem)
+		leases.POST("/returns/:id/complete", leaseHandler.CompleteReturn)
+	}
+
+	// Finanzierungs-API (Tilgungspläne und Verbindlichkeiten)
+	financing := api.Group("/financing")
+	financing.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		financing.GET("/vehicles/:id/schedule", financingHandler.GetSchedule)
+		financing.GET("/forecast", financingHandler.GetLiabilityForecast) // ?months=
+		financing.GET("/issues", financingHandler.GetIssues)
+	}
+
 	// Fahrzeugnutzungs-API
 	usage := api.Group("/usage")
 	{
@@ -600,6 +738,45 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		fuelCosts.DELETE("/:id", fuelCostHandler.DeleteFuelCost)
 	}
 
+	// Tankkartenimport mit Vorschau und Prüfliste für nicht zugeordnete Tankvorgänge
+	fuelImports := api.Group("/fuel-imports")
+	fuelImports.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		fuelImports.GET("/providers", fuelImportHandler.GetProviders)
+		fuelImports.POST("/providers", middleware.AdminMiddleware(), fuelImportHandler.CreateProvider)
+		fuelImports.PUT("/providers/:id", middleware.AdminMiddleware(), fuelImportHandler.UpdateProvider)
+		fuelImports.DELETE("/providers/:id", middleware.AdminMiddleware(), fuelImportHandler.DeleteProvider)
+		fuelImports.GET("/review", fuelImportHandler.GetReviewQueue)
+		fuelImports.POST("/review/:rowId/assign", fuelImportHandler.AssignRow)
+		fuelImports.POST("/review/:rowId/discard", fuelImportHandler.DiscardRow)
+		fuelImports.GET("", fuelImportHandler.GetBatches)
+		fuelImports.POST("", fuelImportHandler.Preview) // multipart: file, providerId
+		fuelImports.GET("/:id", fuelImportHandler.GetBatch)
+		fuelImports.POST("/:id/commit", fuelImportHandler.CommitBatch)
+		fuelImports.POST("/:id/discard", fuelImportHandler.DiscardBatch)
+	}
+
+	// Massenimport von Fahrzeugen und Fahrern mit Spaltenzuordnung und Probelauf
+	bulkImports := api.Group("/bulk-imports")
+	bulkImports.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		bulkImports.GET("", bulkImportHandler.GetBatches)
+		bulkImports.POST("", bulkImportHandler.Upload) // multipart: file, entity, mapping (optional)
+		bulkImports.GET("/:id", bulkImportHandler.GetBatch)
+		bulkImports.PUT("/:id/mapping", bulkImportHandler.UpdateMapping)
+		bulkImports.POST("/:id/commit", bulkImportHandler.CommitBatch)
+		bulkImports.POST("/:id/discard", bulkImportHandler.DiscardBatch)
+	}
+
+	// Verbrauchsauswertung und Auffälligkeiten beim Tanken
+	fuelConsumption := api.Group("/fuel-consumption")
+	fuelConsumption.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		fuelConsumption.GET("/vehicles/:vehicleId", fuelConsumptionHandler.GetVehicleConsumption)
+		fuelConsumption.GET("/fleet", fuelConsumptionHandler.GetFleetComparison) // ?groupBy=brand|model|fuelType
+		fuelConsumption.GET("/anomalies", fuelConsumptionHandler.GetAnomalies)   // ?from=&to=&vehicleId=&type=
+	}
+
 	// Aktivitäts-API
 	activities := api.Group("/activities")
 	{
@@ -628,7 +805,7 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 	// PeopleFlow Integration
 	peopleflow := api.Group("/integrations/peopleflow")
 	{
-		peopleflowHandler := handler.NewPeopleFlowHandler()
+		peopleflowHandler := handler.NewPeopleFlowHandler(services)
 
 		peopleflow.POST("/save", middleware.AdminMiddleware(), peopleflowHandler.SavePeopleFlowCredentials)
 		peopleflow.GET("/test", middleware.AdminMiddleware(), peopleflowHandler.TestPeopleFlowConnection)
@@ -641,6 +818,60 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		peopleflow.PUT("/auto-sync", middleware.AdminMiddleware(), peopleflowHandler.UpdatePeopleFlowAutoSync)
 	}
 
+	// Hintergrundjobs (nur Administratoren)
+	jobs := api.Group("/jobs")
+	jobs.Use(middleware.AdminMiddleware())
+	{
+		jobs.GET("", jobHandler.GetJobs)
+		jobs.GET("/:name/runs", jobHandler.GetJobRuns)
+		jobs.POST("/:name/pause", jobHandler.PauseJob)
+		jobs.POST("/:name/resume", jobHandler.ResumeJob)
+		jobs.POST("/:name/run", jobHandler.TriggerJob)
+	}
+
+	// Ablauferinnerungen (Führerscheine, Dokumente, Versicherung, HU, Leasing)
+	reminders := api.Group("/reminders")
+	reminders.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		reminders.GET("", expiryReminderHandler.GetReminders)
+		reminders.GET("/settings", expiryReminderHandler.GetSettings)
+		reminders.PUT("/settings", middleware.AdminMiddleware(), expiryReminderHandler.SaveSettings)
+	}
+
+	// Elektronisches Fahrtenbuch (Fahrer sehen nur ihre eigenen Fahrten)
+	logbook := api.Group("/logbook")
+	{
+		logbook.GET("", logbookHandler.GetEntries)
+		logbook.POST("", logbookHandler.CreateEntry)
+		logbook.GET("/export", logbookHandler.ExportYear) // ?year=&vehicleId=&driverId=&format=csv|json
+		logbook.GET("/:id", logbookHandler.GetEntry)
+		logbook.PUT("/:id", logbookHandler.UpdateEntry) // Nur innerhalb der Bearbeitungsfrist
+		logbook.POST("/:id/corrections", logbookHandler.CorrectEntry)
+		logbook.GET("/:id/versions", logbookHandler.GetVersions)
+	}
+
+	// Ladevorgänge von Elektrofahrzeugen (Fahrer sehen nur ihre eigenen, Laden zu Hause wird erstattet)
+	charging := api.Group("/charging-sessions")
+	{
+		charging.GET("", chargingSessionHandler.GetSessions) // ?vehicleId=&driverId=&from=&to=&locationType=&status=
+		charging.POST("", chargingSessionHandler.CreateSession)
+		charging.GET("/reimbursements", middleware.ManagerOrAdminMiddleware(), chargingSessionHandler.GetReimbursements) // ?status=
+		charging.GET("/vehicles/:vehicleId/report", middleware.ManagerOrAdminMiddleware(), chargingSessionHandler.GetEnergyReport) // ?from=&to=&format=csv|json
+		charging.GET("/:id", chargingSessionHandler.GetSession)
+		charging.PUT("/:id", chargingSessionHandler.UpdateSession)
+		charging.DELETE("/:id", chargingSessionHandler.DeleteSession)
+		charging.POST("/:id/reimbursement/approve", middleware.ManagerOrAdminMiddleware(), chargingSessionHandler.ApproveReimbursement)
+		charging.POST("/:id/reimbursement/reject", middleware.ManagerOrAdminMiddleware(), chargingSessionHandler.RejectReimbursement)
+	}
+
+	// Geldwerter Vorteil aus der Privatnutzung von Dienstwagen (Lohnbuchhaltung)
+	taxableBenefits := api.Group("/taxable-benefits")
+	taxableBenefits.Use(middleware.ManagerOrAdminMiddleware())
+	{
+		taxableBenefits.GET("", taxableBenefitHandler.GetStatements)                        // ?month=YYYY-MM&method=&format=csv|json
+		taxableBenefits.GET("/drivers/:driverId", taxableBenefitHandler.GetDriverStatement) // ?month=YYYY-MM&method=&format=csv|json
+	}
+
 	// Reports API
 	reports := api.Group("/reports")
 	{
@@ -655,13 +886,20 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 	{
 		reservations.GET("", reservationHandler.GetReservations)
 		reservations.POST("", reservationHandler.CreateReservation)
-		reservations.PUT("/:id", reservationHandler.UpdateReservation)
-		reservations.DELETE("/:id", reservationHandler.CancelReservation)
+		reservations.POST("/series", reservationHandler.CreateReservationSeries)
+		reservations.GET("/series/:seriesId", reservationHandler.GetReservationSeries)
+		reservations.PUT("/:id", reservationHandler.UpdateReservation)    // ?scope=series für die ganze Serie
+		reservations.DELETE("/:id", reservationHandler.CancelReservation) // ?scope=series für die ganze Serie
 		reservations.POST("/:id/complete", reservationHandler.CompleteReservation)
 		reservations.GET("/vehicle/:vehicleId", reservationHandler.GetReservationsByVehicle)
 		reservations.GET("/driver/:driverId", reservationHandler.GetReservationsByDriver)
 		reservations.GET("/available-vehicles", reservationHandler.GetAvailableVehicles)
 		reservations.GET("/check-conflict", reservationHandler.CheckReservationConflict)
+
+		// Übergabeprotokolle bei Ausgabe und Rückgabe
+		reservations.POST("/:id/check-out", handoverHandler.CheckOut)
+		reservations.POST("/:id/check-in", handoverHandler.CheckIn)
+		reservations.GET("/:id/handovers", handoverHandler.GetHandovers)
 		
 		// Genehmigungsrouten für Manager/Admins
 		reservations.POST("/:id/approve", middleware.ManagerOrAdminMiddleware(), reservationHandler.ApproveReservation)
@@ -669,8 +907,14 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		reservations.GET("/pending", middleware.ManagerOrAdminMiddleware(), reservationHandler.GetPendingReservations)
 	}
 
+	// Fotos zu Übergabeprotokollen
+	handovers := api.Group("/handovers")
+	{
+		handovers.POST("/:handoverId/photos", handoverHandler.UploadPhoto)
+	}
+
 	// Vehicle Reports API
-	vehicleReportHandler := handler.NewVehicleReportHandler()
+	vehicleReportHandler := handler.NewVehicleReportHandler(repos, services)
 	reportsAPI := api.Group("/vehicle-reports")
 	{
 		reportsAPI.GET("", middleware.ManagerOrAdminMiddleware(), vehicleReportHandler.GetReports)
@@ -681,7 +925,7 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 	}
 
 	// SMTP API
-	smtpHandler := handler.NewSMTPHandler()
+	smtpHandler := handler.NewSMTPHandler(services)
 	smtp := api.Group("/smtp")
 	{
 		smtp.GET("/config", middleware.AdminMiddleware(), smtpHandler.GetSMTPConfig)
@@ -690,16 +934,18 @@ func setupAPIRoutes(api *gin.RouterGroup) {
 		smtp.GET("/templates", middleware.AdminMiddleware(), smtpHandler.GetEmailTemplates)
 		smtp.POST("/templates", middleware.AdminMiddleware(), smtpHandler.SaveEmailTemplate)
 		smtp.GET("/logs", middleware.AdminMiddleware(), smtpHandler.GetEmailLogs)
+		smtp.POST("/logs/:id/resend", middleware.AdminMiddleware(), smtpHandler.ResendEmail)
 		smtp.POST("/send", middleware.AdminMiddleware(), smtpHandler.SendTestEmail)
 	}
 
 }
 
 // setupDriverRoutes konfiguriert die Fahrer-spezifischen Routen
-func setupDriverRoutes(group *gin.RouterGroup) {
+func setupDriverRoutes(group *gin.RouterGroup, repos *repository.Repositories, services *service.Services) {
 	// Handler initialisieren
-	driverDashboardHandler := handler.NewDriverDashboardHandler()
-	vehicleReportHandler := handler.NewVehicleReportHandler()
+	driverDashboardHandler := handler.NewDriverDashboardHandler(repos, services)
+	vehicleReportHandler := handler.NewVehicleReportHandler(repos, services)
+	handoverHandler := handler.NewHandoverHandler(services)
 
 	// Fahrer-Middleware hinzufügen - nur Fahrer dürfen diese Routen verwenden
 	group.Use(middleware.DriverOrHigherMiddleware())
@@ -722,6 +968,8 @@ func setupDriverRoutes(group *gin.RouterGroup) {
 			reservations.GET("", vehicleReportHandler.GetReportsByDriver) // Eigene Reservierungen
 			// reservations.POST("", driverReservationHandler.CreateReservation) // Neue Reservierung erstellen
 			// reservations.GET("/:id", driverReservationHandler.GetReservation) // Reservierung anzeigen
+			reservations.POST("/:id/check-out", handoverHandler.CheckOut) // Fahrzeug abholen
+			reservations.POST("/:id/check-in", handoverHandler.CheckIn)   // Fahrzeug zurückgeben
 		}
 
 		// Fahrzeugmeldungs-API für Fahrer
@@ -0,0 +1,567 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrClaimNotFound wird für unbekannte Schadenfälle zurückgegeben
+	ErrClaimNotFound = errors.New("schadenfall nicht gefunden")
+	// ErrClaimExists wird zurückgegeben, wenn zur Meldung bereits ein Schadenfall existiert
+	ErrClaimExists = errors.New("zu dieser meldung existiert bereits ein schadenfall")
+	// ErrClaimState wird zurückgegeben, wenn der Schadenfall im aktuellen Status nicht geändert werden kann
+	ErrClaimState = errors.New("der schadenfall kann im aktuellen status nicht geändert werden")
+)
+
+// claimTransitions enthält die erlaubten Statuswechsel eines Schadenfalls
+var claimTransitions = map[model.ClaimStatus][]model.ClaimStatus{
+	model.ClaimStatusOpen:     {model.ClaimStatusReported, model.ClaimStatusClosed},
+	model.ClaimStatusReported: {model.ClaimStatusSettled, model.ClaimStatusRejected, model.ClaimStatusClosed},
+	model.ClaimStatusSettled:  {model.ClaimStatusClosed},
+	model.ClaimStatusRejected: {model.ClaimStatusReported, model.ClaimStatusClosed}, // Erneute Meldung nach Widerspruch
+}
+
+// AccidentClaimInput enthält die Angaben eines Schadenfalls
+type AccidentClaimInput struct {
+	ReportID           string // Nur beim Anlegen: Fahrzeugmeldung vom Typ "accident"
+	DriverID           string // Ohne Angabe der Fahrer, der den Unfall gemeldet hat
+	IncidentDate       *time.Time
+	Location           string
+	Description        string
+	Parties            []model.ClaimParty
+	PoliceReportNumber string
+	Fault              model.ClaimFault
+	FaultShare         int
+	InsuranceCompany   string // Ohne Angabe aus dem Fahrzeug
+	InsuranceNumber    string // Ohne Angabe aus dem Fahrzeug
+	ClaimNumber        string
+	WorkOrderID        string
+	RepairCost         float64
+	Deductible         float64
+	DowntimeStart      *time.Time // Ohne Angabe aus dem Werkstattauftrag
+	DowntimeEnd        *time.Time
+}
+
+// AccidentClaimService verwaltet Schadenfälle zu Unfallmeldungen. Jede Änderung wird im Verlauf
+// des Schadenfalls festgehalten, Unterlagen werden als Fahrzeugdokumente abgelegt.
+type AccidentClaimService struct {
+	claimRepo       repository.AccidentClaimRepository
+	reportRepo      repository.VehicleReportRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	userRepo        repository.UserRepository
+	workOrderRepo   repository.WorkOrderRepository
+	documentRepo    repository.VehicleDocumentRepository
+	activityService *ActivityService
+}
+
+// NewAccidentClaimService erstellt einen neuen AccidentClaimService
+func NewAccidentClaimService(claimRepo repository.AccidentClaimRepository, reportRepo repository.VehicleReportRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, userRepo repository.UserRepository, workOrderRepo repository.WorkOrderRepository, documentRepo repository.VehicleDocumentRepository, activityService *ActivityService) *AccidentClaimService {
+	return &AccidentClaimService{
+		claimRepo:       claimRepo,
+		reportRepo:      reportRepo,
+		vehicleRepo:     vehicleRepo,
+		driverRepo:      driverRepo,
+		userRepo:        userRepo,
+		workOrderRepo:   workOrderRepo,
+		documentRepo:    documentRepo,
+		activityService: activityService,
+	}
+}
+
+// GetClaims liefert alle Schadenfälle zum Filter
+func (s *AccidentClaimService) GetClaims(filter model.AccidentClaimFilter) ([]*model.AccidentClaim, error) {
+	return s.claimRepo.Find(filter)
+}
+
+// GetClaim liefert einen Schadenfall
+func (s *AccidentClaimService) GetClaim(id string) (*model.AccidentClaim, error) {
+	claim, err := s.claimRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrClaimNotFound
+	}
+	return claim, nil
+}
+
+// CreateClaim legt den Schadenfall zu einer Unfallmeldung an
+func (s *AccidentClaimService) CreateClaim(user *model.User, input AccidentClaimInput) (*model.AccidentClaim, error) {
+	reportID, err := primitive.ObjectIDFromHex(input.ReportID)
+	if err != nil {
+		return nil, fmt.Errorf("ungültige meldungs-id")
+	}
+	report, err := s.reportRepo.FindByID(reportID)
+	if err != nil || report == nil {
+		return nil, fmt.Errorf("meldung nicht gefunden")
+	}
+	if report.Type != model.ReportTypeAccident {
+		return nil, fmt.Errorf("schadenfälle können nur zu unfallmeldungen angelegt werden")
+	}
+	if _, err := s.claimRepo.FindByReport(report.ID); err == nil {
+		return nil, ErrClaimExists
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(report.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	claim := &model.AccidentClaim{
+		ReportID:         report.ID,
+		VehicleID:        report.VehicleID,
+		IncidentDate:     report.CreatedAt,
+		Location:         report.Location,
+		Description:      report.Description,
+		Fault:            model.ClaimFaultUnknown,
+		InsuranceCompany: vehicle.InsuranceCompany,
+		InsuranceNumber:  vehicle.InsuranceNumber,
+		InsuranceType:    vehicle.InsuranceType,
+		Parties:          []model.ClaimParty{},
+		DocumentIDs:      []primitive.ObjectID{},
+		Timeline:         []model.ClaimTimelineEntry{},
+		Status:           model.ClaimStatusOpen,
+		CreatedBy:        user.ID,
+	}
+	// Der meldende Benutzer ist in der Regel der Fahrer
+	if reporter, err := s.userRepo.FindByID(report.ReporterID.Hex()); err == nil && reporter != nil {
+		if driver, err := driverForUser(s.driverRepo, reporter); err == nil && driver != nil {
+			claim.DriverID = &driver.ID
+		}
+	}
+
+	if err := s.applyInput(claim, input); err != nil {
+		return nil, err
+	}
+	addClaimTimeline(claim, user, model.ClaimTimelineCreated,
+		fmt.Sprintf("Schadenfall zur Meldung \"%s\" angelegt", report.Title))
+
+	if err := s.claimRepo.Create(claim); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrClaimExists
+		}
+		return nil, fmt.Errorf("fehler beim anlegen des schadenfalls: %v", err)
+	}
+
+	// Die Meldung ist mit dem Schadenfall in Bearbeitung
+	if report.Status == model.ReportStatusOpen {
+		if err := s.reportRepo.UpdateStatus(report.ID, model.ReportStatusInProgress, user.ID); err != nil {
+			return nil, fmt.Errorf("fehler beim aktualisieren der meldung: %v", err)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"accident_claim_created",
+		fmt.Sprintf("Schadenfall für %s vom %s angelegt", vehicle.LicensePlate, claim.IncidentDate.Format("02.01.2006")),
+		user.ID,
+		&claim.VehicleID,
+	)
+
+	return claim, nil
+}
+
+// UpdateClaim ändert die Angaben eines Schadenfalls und vermerkt die Änderungen im Verlauf
+func (s *AccidentClaimService) UpdateClaim(user *model.User, id string, input AccidentClaimInput) (*model.AccidentClaim, error) {
+	claim, err := s.activeClaim(id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *claim
+	if err := s.applyInput(claim, input); err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	if claim.ClaimNumber != before.ClaimNumber {
+		changes = append(changes, fmt.Sprintf("Schadennummer %s", valueOrDash(claim.ClaimNumber)))
+	}
+	if claim.PoliceReportNumber != before.PoliceReportNumber {
+		changes = append(changes, fmt.Sprintf("Polizeiliches Aktenzeichen %s", valueOrDash(claim.PoliceReportNumber)))
+	}
+	if claim.Fault != before.Fault || claim.FaultShare != before.FaultShare {
+		changes = append(changes, fmt.Sprintf("Schuldfrage %s", claimFaultLabel(claim)))
+	}
+	if claim.RepairCost != before.RepairCost {
+		changes = append(changes, fmt.Sprintf("Reparaturkosten %.2f €", claim.RepairCost))
+	}
+	if claim.Deductible != before.Deductible {
+		changes = append(changes, fmt.Sprintf("Selbstbeteiligung %.2f €", claim.Deductible))
+	}
+	if !sameObjectID(claim.WorkOrderID, before.WorkOrderID) {
+		changes = append(changes, "Werkstattauftrag verknüpft")
+	}
+	if len(claim.Parties) != len(before.Parties) {
+		changes = append(changes, fmt.Sprintf("%d Beteiligte", len(claim.Parties)))
+	}
+	if !sameTime(claim.DowntimeStart, before.DowntimeStart) || !sameTime(claim.DowntimeEnd, before.DowntimeEnd) {
+		changes = append(changes, fmt.Sprintf("Ausfallzeit %d Tage", claim.DowntimeDays()))
+	}
+	text := "Angaben geändert"
+	if len(changes) > 0 {
+		text += ": " + strings.Join(changes, ", ")
+	}
+	addClaimTimeline(claim, user, model.ClaimTimelineUpdate, text)
+
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des schadenfalls: %v", err)
+	}
+
+	return claim, nil
+}
+
+// ChangeStatus setzt den Status eines Schadenfalls. Beim Abschluss wird auch die Unfallmeldung geschlossen.
+func (s *AccidentClaimService) ChangeStatus(user *model.User, id string, status model.ClaimStatus, note string) (*model.AccidentClaim, error) {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, known := model.ClaimStatusText[status]; !known {
+		return nil, fmt.Errorf("ungültiger status: %s", status)
+	}
+
+	allowed := false
+	for _, next := range claimTransitions[claim.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s → %s", ErrClaimState, model.ClaimStatusText[claim.Status], model.ClaimStatusText[status])
+	}
+	if status == model.ClaimStatusReported && claim.InsuranceCompany == "" {
+		return nil, fmt.Errorf("für die meldung an die versicherung muss ein versicherer hinterlegt sein")
+	}
+
+	text := fmt.Sprintf("Status %s → %s", model.ClaimStatusText[claim.Status], model.ClaimStatusText[status])
+	if note = strings.TrimSpace(note); note != "" {
+		text += ": " + note
+	}
+	claim.Status = status
+	if status == model.ClaimStatusClosed {
+		now := time.Now()
+		claim.ClosedAt = &now
+		if claim.DowntimeStart != nil && claim.DowntimeEnd == nil {
+			claim.DowntimeEnd = &now
+		}
+	}
+	addClaimTimeline(claim, user, model.ClaimTimelineStatus, text)
+
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des schadenfalls: %v", err)
+	}
+
+	if status == model.ClaimStatusClosed {
+		if err := s.reportRepo.UpdateStatus(claim.ReportID, model.ReportStatusClosed, user.ID); err != nil {
+			return nil, fmt.Errorf("fehler beim schließen der meldung: %v", err)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"accident_claim_status",
+		fmt.Sprintf("Schadenfall %s: %s", valueOrDash(claim.ClaimNumber), text),
+		user.ID,
+		&claim.VehicleID,
+	)
+
+	return claim, nil
+}
+
+// AddNote ergänzt den Verlauf um eine Notiz, z.B. zu Telefonaten mit der Versicherung
+func (s *AccidentClaimService) AddNote(user *model.User, id, text string) (*model.AccidentClaim, error) {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+	if text = strings.TrimSpace(text); text == "" {
+		return nil, fmt.Errorf("die notiz darf nicht leer sein")
+	}
+
+	addClaimTimeline(claim, user, model.ClaimTimelineNote, text)
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des schadenfalls: %v", err)
+	}
+	return claim, nil
+}
+
+// RecordReimbursement verbucht eine Zahlung der Versicherung oder des Unfallgegners.
+// Regulierungen erfolgen oft in Teilbeträgen, die Zahlungen werden daher aufsummiert.
+func (s *AccidentClaimService) RecordReimbursement(user *model.User, id string, amount float64, date *time.Time, payer string) (*model.AccidentClaim, error) {
+	claim, err := s.activeClaim(id)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("der erstattungsbetrag muss größer als 0 sein")
+	}
+
+	paidAt := time.Now()
+	if date != nil {
+		paidAt = *date
+	}
+	claim.ReimbursementReceived = roundCents(claim.ReimbursementReceived + amount)
+	claim.ReimbursedAt = &paidAt
+
+	text := fmt.Sprintf("Erstattung %.2f € am %s erhalten", amount, paidAt.Format("02.01.2006"))
+	if payer = strings.TrimSpace(payer); payer != "" {
+		text += " von " + payer
+	}
+	addClaimTimeline(claim, user, model.ClaimTimelineReimbursement, text)
+
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des schadenfalls: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"accident_claim_reimbursement",
+		fmt.Sprintf("Schadenfall %s: %s", valueOrDash(claim.ClaimNumber), text),
+		user.ID,
+		&claim.VehicleID,
+	)
+
+	return claim, nil
+}
+
+// AddDocument speichert eine Unterlage als Fahrzeugdokument und hängt sie an den Schadenfall
+func (s *AccidentClaimService) AddDocument(user *model.User, id, name, fileName, contentType string, data []byte) (*model.VehicleDocument, error) {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = fileName
+	}
+	document := &model.VehicleDocument{
+		VehicleID:   claim.VehicleID,
+		Type:        model.DocumentTypeClaimDocument,
+		Name:        name,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Data:        data,
+		UploadedBy:  user.ID,
+		Notes:       fmt.Sprintf("Schadenfall vom %s", claim.IncidentDate.Format("02.01.2006")),
+	}
+	if err := s.documentRepo.Create(document); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des dokuments: %v", err)
+	}
+
+	claim.DocumentIDs = append(claim.DocumentIDs, document.ID)
+	addClaimTimeline(claim, user, model.ClaimTimelineDocument, fmt.Sprintf("Dokument \"%s\" hinzugefügt", name))
+	claim.Timeline[len(claim.Timeline)-1].DocumentID = &document.ID
+	if err := s.claimRepo.Update(claim); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des schadenfalls: %v", err)
+	}
+
+	return document, nil
+}
+
+// GetDocuments liefert die Unterlagen eines Schadenfalls
+func (s *AccidentClaimService) GetDocuments(id string) ([]*model.VehicleDocument, error) {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []*model.VehicleDocument{}
+	for _, documentID := range claim.DocumentIDs {
+		document, err := s.documentRepo.FindByID(documentID.Hex())
+		if err != nil {
+			// Das Dokument wurde inzwischen in der Fahrzeugakte gelöscht
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// DeleteClaim löscht einen versehentlich angelegten Schadenfall, solange er nicht gemeldet wurde
+func (s *AccidentClaimService) DeleteClaim(user *model.User, id string) error {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return err
+	}
+	if claim.Status != model.ClaimStatusOpen {
+		return ErrClaimState
+	}
+	if err := s.claimRepo.Delete(id); err != nil {
+		return fmt.Errorf("fehler beim löschen des schadenfalls: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"accident_claim_deleted",
+		fmt.Sprintf("Schadenfall vom %s gelöscht", claim.IncidentDate.Format("02.01.2006")),
+		user.ID,
+		&claim.VehicleID,
+	)
+	return nil
+}
+
+// activeClaim lädt einen Schadenfall, der noch nicht abgeschlossen ist
+func (s *AccidentClaimService) activeClaim(id string) (*model.AccidentClaim, error) {
+	claim, err := s.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+	if !claim.IsActive() {
+		return nil, ErrClaimState
+	}
+	return claim, nil
+}
+
+// applyInput prüft die Angaben und übernimmt sie in den Schadenfall
+func (s *AccidentClaimService) applyInput(claim *model.AccidentClaim, input AccidentClaimInput) error {
+	if input.RepairCost < 0 || input.Deductible < 0 {
+		return fmt.Errorf("kosten dürfen nicht negativ sein")
+	}
+	if input.FaultShare < 0 || input.FaultShare > 100 {
+		return fmt.Errorf("der haftungsanteil muss zwischen 0 und 100 prozent liegen")
+	}
+
+	fault := input.Fault
+	switch fault {
+	case "":
+		fault = claim.Fault
+		if fault == "" {
+			fault = model.ClaimFaultUnknown
+		}
+	case model.ClaimFaultUnknown, model.ClaimFaultOwn, model.ClaimFaultThirdParty, model.ClaimFaultShared:
+	default:
+		return fmt.Errorf("ungültige schuldfrage: %s", fault)
+	}
+	faultShare := input.FaultShare
+	switch fault {
+	case model.ClaimFaultOwn:
+		faultShare = 100
+	case model.ClaimFaultThirdParty:
+		faultShare = 0
+	case model.ClaimFaultShared:
+		if faultShare == 0 || faultShare == 100 {
+			return fmt.Errorf("bei teilschuld muss der eigene haftungsanteil zwischen 1 und 99 prozent liegen")
+		}
+	}
+
+	parties := make([]model.ClaimParty, 0, len(input.Parties))
+	for _, party := range input.Parties {
+		party.Name = strings.TrimSpace(party.Name)
+		if party.Name == "" {
+			return fmt.Errorf("jeder beteiligte benötigt einen namen")
+		}
+		switch party.Role {
+		case "":
+			party.Role = model.ClaimPartyOther
+		case model.ClaimPartyOwnDriver, model.ClaimPartyOpponent, model.ClaimPartyWitness, model.ClaimPartyOther:
+		default:
+			return fmt.Errorf("ungültige rolle für %s: %s", party.Name, party.Role)
+		}
+		party.LicensePlate = strings.ToUpper(strings.TrimSpace(party.LicensePlate))
+		parties = append(parties, party)
+	}
+
+	if input.DriverID != "" {
+		driver, err := s.driverRepo.FindByID(input.DriverID)
+		if err != nil {
+			return fmt.Errorf("fahrer nicht gefunden")
+		}
+		claim.DriverID = &driver.ID
+	}
+
+	downtimeStart, downtimeEnd := input.DowntimeStart, input.DowntimeEnd
+	claim.WorkOrderID = nil
+	if input.WorkOrderID != "" {
+		order, err := s.workOrderRepo.FindByID(input.WorkOrderID)
+		if err != nil {
+			return fmt.Errorf("werkstattauftrag nicht gefunden")
+		}
+		if order.VehicleID != claim.VehicleID {
+			return fmt.Errorf("der werkstattauftrag gehört zu einem anderen fahrzeug")
+		}
+		claim.WorkOrderID = &order.ID
+		// Die Standzeit in der Werkstatt ist die Ausfallzeit, sofern nicht abweichend angegeben
+		if downtimeStart == nil {
+			downtimeStart, downtimeEnd = order.DowntimeStart, order.DowntimeEnd
+		}
+	}
+	if downtimeStart != nil && downtimeEnd != nil && downtimeEnd.Before(*downtimeStart) {
+		return fmt.Errorf("das ende der ausfallzeit muss nach dem beginn liegen")
+	}
+
+	if input.IncidentDate != nil {
+		if input.IncidentDate.After(time.Now()) {
+			return fmt.Errorf("das unfalldatum darf nicht in der zukunft liegen")
+		}
+		claim.IncidentDate = *input.IncidentDate
+	}
+	if location := strings.TrimSpace(input.Location); location != "" {
+		claim.Location = location
+	}
+	if description := strings.TrimSpace(input.Description); description != "" {
+		claim.Description = description
+	}
+	if company := strings.TrimSpace(input.InsuranceCompany); company != "" {
+		claim.InsuranceCompany = company
+	}
+	if number := strings.TrimSpace(input.InsuranceNumber); number != "" {
+		claim.InsuranceNumber = number
+	}
+	claim.Parties = parties
+	claim.PoliceReportNumber = strings.TrimSpace(input.PoliceReportNumber)
+	claim.Fault = fault
+	claim.FaultShare = faultShare
+	claim.ClaimNumber = strings.TrimSpace(input.ClaimNumber)
+	claim.RepairCost = roundCents(input.RepairCost)
+	claim.Deductible = roundCents(input.Deductible)
+	claim.DowntimeStart = downtimeStart
+	claim.DowntimeEnd = downtimeEnd
+	return nil
+}
+
+// addClaimTimeline hängt einen Eintrag an den Verlauf des Schadenfalls
+func addClaimTimeline(claim *model.AccidentClaim, user *model.User, entryType model.ClaimTimelineType, text string) {
+	claim.Timeline = append(claim.Timeline, model.ClaimTimelineEntry{
+		Date:   time.Now(),
+		Type:   entryType,
+		Text:   text,
+		UserID: user.ID,
+	})
+}
+
+// claimFaultLabel beschreibt die Schuldfrage mit Haftungsanteil
+func claimFaultLabel(claim *model.AccidentClaim) string {
+	if claim.Fault == model.ClaimFaultShared {
+		return fmt.Sprintf("%s (%d %%)", model.ClaimFaultText[claim.Fault], claim.FaultShare)
+	}
+	return model.ClaimFaultText[claim.Fault]
+}
+
+// valueOrDash liefert den Wert oder einen Gedankenstrich für leere Angaben
+func valueOrDash(value string) string {
+	if value == "" {
+		return "–"
+	}
+	return value
+}
+
+// sameObjectID vergleicht zwei optionale IDs
+func sameObjectID(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// sameTime vergleicht zwei optionale Zeitpunkte
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
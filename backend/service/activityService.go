@@ -11,13 +11,13 @@ import (
 
 // ActivityService ist ein zentraler Service für die Protokollierung von Aktivitäten
 type ActivityService struct {
-	activityRepo *repository.ActivityRepository
+	activityRepo repository.ActivityRepository
 }
 
 // NewActivityService erstellt einen neuen ActivityService
-func NewActivityService() *ActivityService {
+func NewActivityService(activityRepo repository.ActivityRepository) *ActivityService {
 	return &ActivityService{
-		activityRepo: repository.NewActivityRepository(),
+		activityRepo: activityRepo,
 	}
 }
 
@@ -10,16 +10,18 @@ import (
 )
 
 type AssignmentService struct {
-	vehicleRepo           *repository.VehicleRepository
-	driverRepo            *repository.DriverRepository
-	assignmentHistoryRepo *repository.VehicleAssignmentRepository
+	vehicleRepo           repository.VehicleRepository
+	driverRepo            repository.DriverRepository
+	assignmentHistoryRepo repository.VehicleAssignmentRepository
+	eligibility           *EligibilityService
 }
 
-func NewAssignmentService() *AssignmentService {
+func NewAssignmentService(vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, assignmentHistoryRepo repository.VehicleAssignmentRepository, eligibility *EligibilityService) *AssignmentService {
 	return &AssignmentService{
-		vehicleRepo:           repository.NewVehicleRepository(),
-		driverRepo:            repository.NewDriverRepository(),
-		assignmentHistoryRepo: repository.NewVehicleAssignmentRepository(),
+		vehicleRepo:           vehicleRepo,
+		driverRepo:            driverRepo,
+		assignmentHistoryRepo: assignmentHistoryRepo,
+		eligibility:           eligibility,
 	}
 }
 
@@ -56,6 +58,11 @@ func (s *AssignmentService) AssignVehicleToDriver(driverID, vehicleID string, as
 		}
 	}
 
+	// Führerscheinklasse und Gültigkeit des Führerscheins prüfen
+	if err := s.eligibility.CheckDriverEligibility(driver, vehicle, time.Now()); err != nil {
+		return err
+	}
+
 	// Wenn der Fahrer bereits dieses Fahrzeug hat, nichts tun
 	if !driver.AssignedVehicleID.IsZero() && driver.AssignedVehicleID.Hex() == vehicleID {
 		fmt.Printf("Driver already has this vehicle assigned\n")
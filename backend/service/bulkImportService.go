@@ -0,0 +1,808 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrBulkImportNotFound wird für unbekannte Massenimporte zurückgegeben
+	ErrBulkImportNotFound = errors.New("import nicht gefunden")
+	// ErrBulkImportState wird zurückgegeben, wenn ein Import im aktuellen Status nicht bearbeitet werden kann
+	ErrBulkImportState = errors.New("der import kann in diesem status nicht bearbeitet werden")
+)
+
+// bulkImportMaxRows begrenzt die Anzahl der Datenzeilen je Datei
+const bulkImportMaxRows = 5000
+
+// licensePlatePattern prüft deutsche Kennzeichen: Unterscheidungszeichen, Erkennungsbuchstaben
+// und -nummer, optional mit E (Elektro) oder H (historisch) am Ende
+var licensePlatePattern = regexp.MustCompile(`^[A-ZÄÖÜ]{1,3}[- ][A-Z]{1,2}[- ]?[1-9][0-9]{0,3}[EH]?$`)
+
+// fuelTypeAliases ordnet gängige Schreibweisen aus Fremdsystemen den Kraftstoffarten zu
+var fuelTypeAliases = map[string]model.FuelType{
+	"super":    model.FuelTypeGasoline,
+	"e10":      model.FuelTypeGasoline,
+	"petrol":   model.FuelTypeGasoline,
+	"gasoline": model.FuelTypeGasoline,
+	"electric": model.FuelTypeElectric,
+	"bev":      model.FuelTypeElectric,
+	"strom":    model.FuelTypeElectric,
+	"hydrogen": model.FuelTypeHydrogen,
+	"h2":       model.FuelTypeHydrogen,
+}
+
+// BulkImportResult ist ein Massenimport mit allen Zeilen, z.B. als Probelauf vor der Übernahme
+type BulkImportResult struct {
+	Batch         *model.BulkImportBatch  `json:"batch"`
+	Fields        []model.BulkImportField `json:"fields"`
+	MissingFields []string                `json:"missingFields"` // Pflichtfelder ohne zugeordnete Spalte
+	Rows          []*model.BulkImportRow  `json:"rows"`
+}
+
+// BulkImportService importiert Fahrzeug- und Fahrerlisten aus CSV- oder XLSX-Dateien. Ein Upload
+// erzeugt einen Probelauf mit Fehlern je Zeile; nach der Übernahme legt der Hintergrundjob
+// die gültigen Zeilen an.
+type BulkImportService struct {
+	importRepo      repository.BulkImportRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	activityService *ActivityService
+}
+
+// NewBulkImportService erstellt einen neuen BulkImportService
+func NewBulkImportService(importRepo repository.BulkImportRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, activityService *ActivityService) *BulkImportService {
+	return &BulkImportService{
+		importRepo:      importRepo,
+		vehicleRepo:     vehicleRepo,
+		driverRepo:      driverRepo,
+		activityService: activityService,
+	}
+}
+
+// Upload liest eine Datei ein und prüft alle Zeilen. Ohne Zuordnung werden die Spalten anhand
+// ihrer Überschriften den Feldern zugeordnet. Es werden noch keine Datensätze angelegt.
+func (s *BulkImportService) Upload(userID primitive.ObjectID, entity model.BulkImportEntity, fileName string, data []byte, mapping map[string]string) (*BulkImportResult, error) {
+	fields := model.BulkImportFields(entity)
+	if fields == nil {
+		return nil, fmt.Errorf("unbekannte importart: %s", entity)
+	}
+
+	headers, records, err := readSpreadsheet(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("die datei enthält keine datenzeilen")
+	}
+	if len(records) > bulkImportMaxRows {
+		return nil, fmt.Errorf("die datei enthält %d zeilen, erlaubt sind höchstens %d", len(records), bulkImportMaxRows)
+	}
+
+	if mapping == nil {
+		mapping = suggestBulkImportMapping(fields, headers)
+	} else if mapping, err = validateBulkImportMapping(fields, headers, mapping); err != nil {
+		return nil, err
+	}
+
+	rows := make([]*model.BulkImportRow, len(records))
+	for i, record := range records {
+		raw := make([]string, len(headers))
+		copy(raw, record.values)
+		rows[i] = &model.BulkImportRow{RowNumber: record.line, Raw: raw}
+	}
+
+	batch := &model.BulkImportBatch{
+		Entity:    entity,
+		FileName:  fileName,
+		Headers:   headers,
+		Mapping:   mapping,
+		Status:    model.BulkImportPreview,
+		CreatedBy: userID,
+	}
+	if err := s.validateRows(batch, rows); err != nil {
+		return nil, err
+	}
+	batch.Counts = countBulkImportRows(rows)
+
+	if err := s.importRepo.CreateBatch(batch, rows); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des probelaufs: %v", err)
+	}
+	return newBulkImportResult(batch, rows), nil
+}
+
+// GetBatch liefert einen Import mit allen Zeilen
+func (s *BulkImportService) GetBatch(id string) (*BulkImportResult, error) {
+	batch, err := s.importRepo.FindBatchByID(id)
+	if err != nil {
+		return nil, ErrBulkImportNotFound
+	}
+	rows, err := s.importRepo.FindRowsByBatch(batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkImportResult(batch, rows), nil
+}
+
+// GetRecentBatches liefert die letzten Importe
+func (s *BulkImportService) GetRecentBatches(limit int) ([]*model.BulkImportBatch, error) {
+	return s.importRepo.FindRecentBatches(limit)
+}
+
+// UpdateMapping ändert die Spaltenzuordnung eines Probelaufs und prüft alle Zeilen erneut
+func (s *BulkImportService) UpdateMapping(id string, mapping map[string]string) (*BulkImportResult, error) {
+	result, err := s.GetBatch(id)
+	if err != nil {
+		return nil, err
+	}
+	batch := result.Batch
+	if batch.Status != model.BulkImportPreview {
+		return nil, ErrBulkImportState
+	}
+
+	if batch.Mapping, err = validateBulkImportMapping(model.BulkImportFields(batch.Entity), batch.Headers, mapping); err != nil {
+		return nil, err
+	}
+	if err := s.validateRows(batch, result.Rows); err != nil {
+		return nil, err
+	}
+	for _, row := range result.Rows {
+		if err := s.importRepo.UpdateRow(row); err != nil {
+			return nil, fmt.Errorf("fehler beim aktualisieren der importzeile %d: %v", row.RowNumber, err)
+		}
+	}
+
+	batch.Counts = countBulkImportRows(result.Rows)
+	if err := s.importRepo.UpdateBatch(batch); err != nil {
+		return nil, err
+	}
+	return newBulkImportResult(batch, result.Rows), nil
+}
+
+// Commit beauftragt die Übernahme der gültigen Zeilen eines Probelaufs. Angelegt werden die
+// Datensätze vom Hintergrundjob; ungültige Zeilen werden übersprungen.
+func (s *BulkImportService) Commit(id string) (*model.BulkImportBatch, error) {
+	batch, err := s.importRepo.FindBatchByID(id)
+	if err != nil {
+		return nil, ErrBulkImportNotFound
+	}
+	if batch.Status != model.BulkImportPreview {
+		return nil, ErrBulkImportState
+	}
+	if batch.Counts.Valid == 0 {
+		return nil, fmt.Errorf("der import enthält keine gültigen zeilen")
+	}
+
+	now := time.Now()
+	batch.Status = model.BulkImportQueued
+	batch.QueuedAt = &now
+	if err := s.importRepo.UpdateBatch(batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// Discard verwirft einen Probelauf
+func (s *BulkImportService) Discard(id string) error {
+	batch, err := s.importRepo.FindBatchByID(id)
+	if err != nil {
+		return ErrBulkImportNotFound
+	}
+	if batch.Status != model.BulkImportPreview {
+		return ErrBulkImportState
+	}
+	batch.Status = model.BulkImportDiscarded
+	return s.importRepo.UpdateBatch(batch)
+}
+
+// RunQueuedImports übernimmt alle beauftragten Importe. Importe, deren Lauf abgebrochen wurde,
+// werden fortgesetzt; bereits angelegte Zeilen bleiben dabei unverändert.
+func (s *BulkImportService) RunQueuedImports(run JobRunContext) error {
+	batches, err := s.importRepo.FindBatchesByStatus(model.BulkImportRunning, model.BulkImportQueued)
+	if err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return ErrJobSkipped
+	}
+
+	failed := 0
+	for _, batch := range batches {
+		if err := s.runImport(batch); err != nil {
+			log.Printf("Fehler beim Massenimport %s: %v", batch.ID.Hex(), err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d massenimporte konnten nicht abgeschlossen werden", failed)
+	}
+	return nil
+}
+
+// runImport legt die gültigen Zeilen eines Imports an. Die Zeilen werden zuvor erneut gegen den
+// Bestand geprüft, da seit dem Probelauf Fahrzeuge oder Fahrer hinzugekommen sein können.
+func (s *BulkImportService) runImport(batch *model.BulkImportBatch) error {
+	rows, err := s.importRepo.FindRowsByBatch(batch.ID)
+	if err != nil {
+		return err
+	}
+
+	batch.Status = model.BulkImportRunning
+	if err := s.importRepo.UpdateBatch(batch); err != nil {
+		return err
+	}
+
+	check, err := s.newBulkImportCheck(batch.Entity)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if row.Status != model.BulkImportRowValid {
+			continue
+		}
+
+		record := check.validate(batch, row)
+		if record != nil {
+			recordID, err := s.createRecord(record)
+			if err != nil {
+				row.Status = model.BulkImportRowFailed
+				row.Message = fmt.Sprintf("fehler beim speichern: %v", err)
+			} else {
+				row.Status = model.BulkImportRowImported
+				row.RecordID = &recordID
+			}
+		}
+		if err := s.importRepo.UpdateRow(row); err != nil {
+			return fmt.Errorf("fehler beim aktualisieren der importzeile %d: %v", row.RowNumber, err)
+		}
+	}
+
+	now := time.Now()
+	batch.Status = model.BulkImportCommitted
+	batch.CompletedAt = &now
+	batch.Counts = countBulkImportRows(rows)
+	if err := s.importRepo.UpdateBatch(batch); err != nil {
+		return err
+	}
+
+	s.activityService.LogActivity(
+		"bulk_import_committed",
+		fmt.Sprintf("Massenimport %s übernommen: %d %s angelegt, %d fehlgeschlagen, %d ungültige Zeilen übersprungen",
+			batch.FileName, batch.Counts.Imported, model.BulkImportEntityText[batch.Entity], batch.Counts.Failed, batch.Counts.Invalid),
+		batch.CreatedBy,
+		nil,
+	)
+	return nil
+}
+
+// createRecord speichert ein geprüftes Fahrzeug bzw. einen geprüften Fahrer
+func (s *BulkImportService) createRecord(record interface{}) (primitive.ObjectID, error) {
+	switch r := record.(type) {
+	case *model.Vehicle:
+		if err := s.vehicleRepo.Create(r); err != nil {
+			return primitive.NilObjectID, err
+		}
+		return r.ID, nil
+	case *model.Driver:
+		if err := s.driverRepo.Create(r); err != nil {
+			return primitive.NilObjectID, err
+		}
+		return r.ID, nil
+	}
+	return primitive.NilObjectID, fmt.Errorf("unbekannter datensatz")
+}
+
+// validateRows prüft alle Zeilen eines Probelaufs in Dateireihenfolge gegen den Bestand
+func (s *BulkImportService) validateRows(batch *model.BulkImportBatch, rows []*model.BulkImportRow) error {
+	check, err := s.newBulkImportCheck(batch.Entity)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		check.validate(batch, row)
+	}
+	return nil
+}
+
+// bulkImportCheck gleicht eindeutige Werte mit dem Bestand und den vorherigen Zeilen der Datei ab.
+// Die Maps enthalten den normalisierten Wert und die Zeilennummer, 0 für vorhandene Datensätze.
+type bulkImportCheck struct {
+	plates        map[string]int
+	vins          map[string]int
+	vehicleIDs    map[string]int
+	emails        map[string]int
+	driverNumbers map[string]int
+}
+
+// newBulkImportCheck lädt die eindeutigen Werte aller vorhandenen Fahrzeuge bzw. Fahrer
+func (s *BulkImportService) newBulkImportCheck(entity model.BulkImportEntity) (*bulkImportCheck, error) {
+	check := &bulkImportCheck{
+		plates:        make(map[string]int),
+		vins:          make(map[string]int),
+		vehicleIDs:    make(map[string]int),
+		emails:        make(map[string]int),
+		driverNumbers: make(map[string]int),
+	}
+
+	switch entity {
+	case model.BulkImportVehicles:
+		vehicles, err := s.vehicleRepo.FindAll()
+		if err != nil {
+			return nil, fmt.Errorf("fehler beim laden der fahrzeuge: %v", err)
+		}
+		for _, vehicle := range vehicles {
+			check.add(check.plates, normalizePlate(vehicle.LicensePlate), 0)
+			check.add(check.vins, strings.ToUpper(strings.TrimSpace(vehicle.VIN)), 0)
+			check.add(check.vehicleIDs, strings.ToUpper(strings.TrimSpace(vehicle.VehicleID)), 0)
+		}
+	case model.BulkImportDrivers:
+		drivers, err := s.driverRepo.FindAll()
+		if err != nil {
+			return nil, fmt.Errorf("fehler beim laden der fahrer: %v", err)
+		}
+		for _, driver := range drivers {
+			check.add(check.emails, strings.ToLower(strings.TrimSpace(driver.Email)), 0)
+			check.add(check.driverNumbers, strings.ToUpper(strings.TrimSpace(driver.DriverNumber)), 0)
+		}
+	}
+	return check, nil
+}
+
+func (c *bulkImportCheck) add(index map[string]int, key string, rowNumber int) {
+	if key == "" {
+		return
+	}
+	if _, exists := index[key]; !exists {
+		index[key] = rowNumber
+	}
+}
+
+// unique meldet einen Fehler, wenn der Wert bereits vergeben ist
+func (c *bulkImportCheck) unique(v *bulkRowValidator, index map[string]int, field, key string) {
+	if key == "" {
+		return
+	}
+	rowNumber, exists := index[key]
+	switch {
+	case !exists:
+	case rowNumber == 0:
+		v.fail(field, "%s existiert bereits", v.values[field])
+	default:
+		v.fail(field, "%s kommt bereits in zeile %d vor", v.values[field], rowNumber)
+	}
+}
+
+// validate überträgt die zugeordneten Werte in die Zeile und prüft sie. Gültige Zeilen werden
+// vorgemerkt, damit spätere Zeilen mit demselben Kennzeichen usw. als doppelt erkannt werden.
+// Zurückgegeben wird das anzulegende Fahrzeug bzw. der anzulegende Fahrer, für ungültige Zeilen nil.
+func (c *bulkImportCheck) validate(batch *model.BulkImportBatch, row *model.BulkImportRow) interface{} {
+	row.Values = mapBulkImportValues(batch, row.Raw)
+	v := &bulkRowValidator{values: row.Values}
+
+	var record interface{}
+	switch batch.Entity {
+	case model.BulkImportVehicles:
+		if vehicle := c.vehicle(v, row.RowNumber); vehicle != nil {
+			record = vehicle
+		}
+	case model.BulkImportDrivers:
+		if driver := c.driver(v, row.RowNumber); driver != nil {
+			record = driver
+		}
+	}
+
+	row.Errors = v.errors
+	row.Message = ""
+	row.Status = model.BulkImportRowValid
+	if record == nil {
+		row.Status = model.BulkImportRowInvalid
+	}
+	return record
+}
+
+// vehicle prüft eine Fahrzeugzeile wie beim manuellen Anlegen eines Fahrzeugs
+func (c *bulkImportCheck) vehicle(v *bulkRowValidator, rowNumber int) *model.Vehicle {
+	plate := strings.ToUpper(strings.Join(strings.Fields(v.required("licensePlate")), " "))
+	if plate != "" {
+		if !licensePlatePattern.MatchString(plate) {
+			v.fail("licensePlate", "ungültiges kennzeichen: %s (erwartet z.B. B-AB 1234)", plate)
+		}
+		c.unique(v, c.plates, "licensePlate", normalizePlate(plate))
+	}
+
+	// Leere Marke und leeres Baujahr werden wie beim manuellen Anlegen aus der FIN übernommen
+	brand, year := v.text("brand"), 0
+	vin := NormalizeVIN(v.text("vin"))
+	if vin != "" {
+		if info, err := DecodeVIN(vin); err != nil {
+			v.fail("vin", "%v", err)
+		} else {
+			if brand == "" {
+				brand = info.Brand
+			}
+			year = info.ModelYear
+		}
+		c.unique(v, c.vins, "vin", vin)
+	}
+	if brand == "" {
+		v.fail("brand", "pflichtfeld fehlt")
+	}
+	if v.text("year") != "" || year == 0 {
+		year = v.integer("year", true, 1900, time.Now().Year()+1)
+	}
+
+	vehicleID := v.text("vehicleId")
+	c.unique(v, c.vehicleIDs, "vehicleId", strings.ToUpper(vehicleID))
+
+	vehicle := &model.Vehicle{
+		LicensePlate:         plate,
+		Brand:                brand,
+		Model:                v.required("model"),
+		Year:                 year,
+		Color:                v.text("color"),
+		VehicleID:            vehicleID,
+		VIN:                  vin,
+		CardNumber:           v.text("cardNumber"),
+		FuelType:             v.fuelType("fuelType"),
+		Mileage:              v.integer("mileage", false, 0, 9999999),
+		RegistrationDate:     v.date("registrationDate"),
+		VehicleType:          v.text("vehicleType"),
+		RequiredLicenseClass: v.licenseClass("requiredLicenseClass"),
+		InsuranceCompany:     v.text("insuranceCompany"),
+		InsuranceNumber:      v.text("insuranceNumber"),
+		InsuranceType:        v.insuranceType("insuranceType"),
+		InsuranceExpiry:      v.date("insuranceExpiry"),
+		InsuranceCost:        v.amount("insuranceCost"),
+		NextInspectionDate:   v.date("nextInspectionDate"),
+		AcquisitionType:      v.acquisitionType("acquisitionType"),
+		ListPrice:            v.amount("listPrice"),
+		Status:               model.VehicleStatusAvailable,
+	}
+	if len(v.errors) > 0 {
+		return nil
+	}
+
+	// Fahrzeugnummer wie beim manuellen Anlegen erzeugen (z.B. FD-2025-12345)
+	for vehicle.VehicleID == "" {
+		candidate := fmt.Sprintf("FD-%d-%05d", time.Now().Year(), rand.Intn(90000)+10000)
+		if _, exists := c.vehicleIDs[candidate]; !exists {
+			vehicle.VehicleID = candidate
+		}
+	}
+
+	c.add(c.plates, normalizePlate(vehicle.LicensePlate), rowNumber)
+	c.add(c.vins, vehicle.VIN, rowNumber)
+	c.add(c.vehicleIDs, strings.ToUpper(vehicle.VehicleID), rowNumber)
+	return vehicle
+}
+
+// driver prüft eine Fahrerzeile wie beim manuellen Anlegen eines Fahrers
+func (c *bulkImportCheck) driver(v *bulkRowValidator, rowNumber int) *model.Driver {
+	email := v.required("email")
+	if email != "" {
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			v.fail("email", "ungültige e-mail-adresse: %s", email)
+		}
+		c.unique(v, c.emails, "email", strings.ToLower(email))
+	}
+
+	driverNumber := v.text("driverNumber")
+	c.unique(v, c.driverNumbers, "driverNumber", strings.ToUpper(driverNumber))
+
+	driver := &model.Driver{
+		FirstName:         v.required("firstName"),
+		LastName:          v.required("lastName"),
+		DriverNumber:      driverNumber,
+		Email:             email,
+		Phone:             v.text("phone"),
+		Status:            model.DriverStatusAvailable,
+		LicenseClasses:    v.licenseClasses("licenseClasses"),
+		Notes:             v.text("notes"),
+		CommuteDistanceKm: v.integer("commuteDistanceKm", false, 0, 1000),
+	}
+	if len(v.errors) > 0 {
+		return nil
+	}
+
+	c.add(c.emails, strings.ToLower(driver.Email), rowNumber)
+	c.add(c.driverNumbers, strings.ToUpper(driver.DriverNumber), rowNumber)
+	return driver
+}
+
+// bulkRowValidator liest die Werte einer Importzeile und sammelt Fehler je Feld
+type bulkRowValidator struct {
+	values map[string]string
+	errors []model.BulkImportRowError
+}
+
+func (v *bulkRowValidator) fail(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, model.BulkImportRowError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *bulkRowValidator) text(field string) string {
+	return v.values[field]
+}
+
+func (v *bulkRowValidator) required(field string) string {
+	value := v.values[field]
+	if value == "" {
+		v.fail(field, "pflichtfeld fehlt")
+	}
+	return value
+}
+
+// integer liest eine Ganzzahl; Tausenderpunkte sind erlaubt
+func (v *bulkRowValidator) integer(field string, required bool, min, max int) int {
+	raw := v.values[field]
+	if raw == "" {
+		if required {
+			v.fail(field, "pflichtfeld fehlt")
+		}
+		return 0
+	}
+	value, err := parseLocalizedNumber(raw, true)
+	if err != nil || value != math.Trunc(value) {
+		v.fail(field, "keine ganze zahl: %s", raw)
+		return 0
+	}
+	if value < float64(min) || value > float64(max) {
+		v.fail(field, "%s liegt nicht zwischen %d und %d", raw, min, max)
+		return 0
+	}
+	return int(value)
+}
+
+// amount liest einen nicht negativen Betrag mit Dezimalkomma oder -punkt
+func (v *bulkRowValidator) amount(field string) float64 {
+	raw := v.values[field]
+	if raw == "" {
+		return 0
+	}
+	value, err := parseLocalizedNumber(raw, strings.LastIndex(raw, ",") > strings.LastIndex(raw, "."))
+	if err != nil {
+		v.fail(field, "ungültiger betrag: %s", raw)
+		return 0
+	}
+	if value < 0 {
+		v.fail(field, "betrag darf nicht negativ sein: %s", raw)
+		return 0
+	}
+	return roundCents(value)
+}
+
+func (v *bulkRowValidator) date(field string) time.Time {
+	raw := v.values[field]
+	if raw == "" {
+		return time.Time{}
+	}
+	date, err := parseImportDate(raw)
+	if err != nil {
+		v.fail(field, "ungültiges datum: %s (erwartet TT.MM.JJJJ oder JJJJ-MM-TT)", raw)
+	}
+	return date
+}
+
+func (v *bulkRowValidator) fuelType(field string) model.FuelType {
+	raw := v.values[field]
+	if raw == "" {
+		return ""
+	}
+	key := strings.ToLower(raw)
+	for _, fuelType := range []model.FuelType{model.FuelTypeGasoline, model.FuelTypeDiesel, model.FuelTypeElectric,
+		model.FuelTypeHybridGas, model.FuelTypeHybridDiesel, model.FuelTypeHydrogen} {
+		if strings.ToLower(string(fuelType)) == key {
+			return fuelType
+		}
+	}
+	if fuelType, ok := fuelTypeAliases[key]; ok {
+		return fuelType
+	}
+	v.fail(field, "unbekannte kraftstoffart: %s", raw)
+	return ""
+}
+
+func (v *bulkRowValidator) insuranceType(field string) model.InsuranceType {
+	raw := v.values[field]
+	if raw == "" {
+		return ""
+	}
+	for _, insuranceType := range []model.InsuranceType{model.InsuranceTypeLiability, model.InsuranceTypePartial, model.InsuranceTypeComprehensive} {
+		if strings.EqualFold(string(insuranceType), raw) {
+			return insuranceType
+		}
+	}
+	v.fail(field, "unbekannte versicherungsart: %s", raw)
+	return ""
+}
+
+func (v *bulkRowValidator) acquisitionType(field string) model.AcquisitionType {
+	raw := v.values[field]
+	if raw == "" {
+		return ""
+	}
+	for acquisitionType, text := range model.AcquisitionTypeText {
+		if strings.EqualFold(string(acquisitionType), raw) || strings.EqualFold(text, raw) {
+			return acquisitionType
+		}
+	}
+	v.fail(field, "unbekannte erwerbsart: %s", raw)
+	return ""
+}
+
+func (v *bulkRowValidator) licenseClass(field string) model.LicenseClass {
+	raw := v.values[field]
+	if raw == "" {
+		return ""
+	}
+	class := model.LicenseClass(strings.ToUpper(raw))
+	if !model.IsValidLicenseClass(class) {
+		v.fail(field, "unbekannte führerscheinklasse: %s", raw)
+		return ""
+	}
+	return class
+}
+
+// licenseClasses liest eine durch Komma, Semikolon, Schrägstrich oder Leerzeichen getrennte Liste
+func (v *bulkRowValidator) licenseClasses(field string) []model.LicenseClass {
+	parts := strings.FieldsFunc(strings.ToUpper(v.values[field]), func(r rune) bool {
+		return r == ',' || r == ';' || r == '/' || unicode.IsSpace(r)
+	})
+
+	var classes []model.LicenseClass
+	var unknown []string
+	seen := make(map[model.LicenseClass]bool)
+	for _, part := range parts {
+		class := model.LicenseClass(part)
+		switch {
+		case !model.IsValidLicenseClass(class):
+			unknown = append(unknown, part)
+		case !seen[class]:
+			seen[class] = true
+			classes = append(classes, class)
+		}
+	}
+	if len(unknown) > 0 {
+		v.fail(field, "unbekannte führerscheinklassen: %s", strings.Join(unknown, ", "))
+	}
+	return classes
+}
+
+// parseImportDate liest ein Datum im deutschen oder ISO-Format. Aus XLSX-Dateien kommen
+// Datumszellen als Excel-Seriennummer; eine Uhrzeit nach dem Datum wird ignoriert.
+func parseImportDate(raw string) (time.Time, error) {
+	value := strings.Fields(raw)[0]
+	for _, layout := range []string{"02.01.2006", "2.1.2006", "2006-01-02", "02.01.06"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	// Seriennummern 10000-80000 entsprechen den Jahren 1927-2119
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 10000 && serial <= 80000 {
+		return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("ungültiges datum: %s", raw)
+}
+
+// suggestBulkImportMapping ordnet jedem Feld die erste Spalte zu, deren Überschrift dem Feldnamen,
+// der Bezeichnung oder einem bekannten Alias entspricht
+func suggestBulkImportMapping(fields []model.BulkImportField, headers []string) map[string]string {
+	mapping := make(map[string]string)
+	used := make(map[string]bool)
+	for _, field := range fields {
+		candidates := map[string]bool{bulkImportHeaderKey(field.Key): true, bulkImportHeaderKey(field.Label): true}
+		for _, alias := range field.Aliases {
+			candidates[bulkImportHeaderKey(alias)] = true
+		}
+		for _, header := range headers {
+			if !used[header] && candidates[bulkImportHeaderKey(header)] {
+				mapping[field.Key] = header
+				used[header] = true
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// validateBulkImportMapping prüft eine Zuordnung Feld -> Spaltenüberschrift; leere Spalten entfallen
+func validateBulkImportMapping(fields []model.BulkImportField, headers []string, mapping map[string]string) (map[string]string, error) {
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+	columns := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		columns[header] = true
+	}
+
+	cleaned := make(map[string]string, len(mapping))
+	for field, column := range mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("unbekanntes feld: %s", field)
+		}
+		if column == "" {
+			continue
+		}
+		if !columns[column] {
+			return nil, fmt.Errorf("spalte nicht in der datei gefunden: %s", column)
+		}
+		cleaned[field] = column
+	}
+	return cleaned, nil
+}
+
+// mapBulkImportValues liefert die Werte einer Zeile je zugeordnetem Feld
+func mapBulkImportValues(batch *model.BulkImportBatch, raw []string) map[string]string {
+	positions := make(map[string]int, len(batch.Headers))
+	for i, header := range batch.Headers {
+		positions[header] = i
+	}
+
+	values := make(map[string]string, len(batch.Mapping))
+	for field, column := range batch.Mapping {
+		if i, ok := positions[column]; ok && i < len(raw) {
+			if value := strings.TrimSpace(raw[i]); value != "" {
+				values[field] = value
+			}
+		}
+	}
+	return values
+}
+
+// bulkImportHeaderKey vereinheitlicht Spaltenüberschriften für den Vergleich
+func bulkImportHeaderKey(header string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(header) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// countBulkImportRows zählt die Zeilen eines Imports nach Status
+func countBulkImportRows(rows []*model.BulkImportRow) model.BulkImportCounts {
+	counts := model.BulkImportCounts{Total: len(rows)}
+	for _, row := range rows {
+		switch row.Status {
+		case model.BulkImportRowValid:
+			counts.Valid++
+		case model.BulkImportRowInvalid:
+			counts.Invalid++
+		case model.BulkImportRowImported:
+			counts.Imported++
+		case model.BulkImportRowFailed:
+			counts.Failed++
+		}
+	}
+	return counts
+}
+
+// newBulkImportResult ergänzt einen Import um Felder und fehlende Pflichtfelder
+func newBulkImportResult(batch *model.BulkImportBatch, rows []*model.BulkImportRow) *BulkImportResult {
+	fields := model.BulkImportFields(batch.Entity)
+	missing := []string{}
+	for _, field := range fields {
+		if field.Required && batch.Mapping[field.Key] == "" {
+			missing = append(missing, field.Label)
+		}
+	}
+	if rows == nil {
+		rows = []*model.BulkImportRow{}
+	}
+	return &BulkImportResult{Batch: batch, Fields: fields, MissingFields: missing, Rows: rows}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// calendarFeedPastDays begrenzt, wie weit vergangene Reservierungen in einem Feed zurückreichen
+const calendarFeedPastDays = 90
+
+// ErrCalendarFeedNotFound wird für unbekannte oder widerrufene Feed-Tokens zurückgegeben
+var ErrCalendarFeedNotFound = errors.New("kalender-feed nicht gefunden")
+
+// CalendarService stellt Reservierungen als abonnierbare iCalendar-Feeds (RFC 5545) bereit
+type CalendarService struct {
+	tokenRepo          repository.CalendarFeedTokenRepository
+	userRepo           repository.UserRepository
+	vehicleRepo        repository.VehicleRepository
+	driverRepo         repository.DriverRepository
+	reservationService *ReservationService
+}
+
+// NewCalendarService erstellt einen neuen CalendarService
+func NewCalendarService(tokenRepo repository.CalendarFeedTokenRepository, userRepo repository.UserRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, reservationService *ReservationService) *CalendarService {
+	return &CalendarService{
+		tokenRepo:          tokenRepo,
+		userRepo:           userRepo,
+		vehicleRepo:        vehicleRepo,
+		driverRepo:         driverRepo,
+		reservationService: reservationService,
+	}
+}
+
+// CreateFeedToken legt ein neues Kalender-Abonnement für den Benutzer an.
+// Fahrer dürfen nur ihre eigenen Reservierungen abonnieren, Fahrzeug- und
+// Flottenkalender sind Managern und Administratoren vorbehalten.
+func (s *CalendarService) CreateFeedToken(user *model.User, scope model.CalendarFeedScope, targetID, name string) (*model.CalendarFeedToken, error) {
+	isManager := isManagerOrAdmin(user)
+
+	feedToken := &model.CalendarFeedToken{
+		UserID: user.ID,
+		Scope:  scope,
+		Name:   strings.TrimSpace(name),
+	}
+
+	var defaultName string
+	switch scope {
+	case model.CalendarFeedScopeFleet:
+		if !isManager {
+			return nil, errors.New("nur manager und administratoren dürfen den flottenkalender abonnieren")
+		}
+		defaultName = "FleetFlow Flotte"
+
+	case model.CalendarFeedScopeVehicle:
+		if !isManager {
+			return nil, errors.New("nur manager und administratoren dürfen fahrzeugkalender abonnieren")
+		}
+		vehicle, err := s.vehicleRepo.FindByID(targetID)
+		if err != nil {
+			return nil, errors.New("fahrzeug nicht gefunden")
+		}
+		feedToken.TargetID = &vehicle.ID
+		defaultName = "FleetFlow " + vehicle.LicensePlate
+
+	case model.CalendarFeedScopeDriver:
+		var driver *model.Driver
+		var err error
+		if isManager && targetID != "" {
+			driver, err = s.driverRepo.FindByID(targetID)
+		} else {
+			driver, err = driverForUser(s.driverRepo, user)
+		}
+		if err != nil {
+			return nil, errors.New("fahrer nicht gefunden")
+		}
+		if !isManager && targetID != "" && targetID != driver.ID.Hex() {
+			return nil, errors.New("fahrer dürfen nur ihre eigenen reservierungen abonnieren")
+		}
+		feedToken.TargetID = &driver.ID
+		defaultName = fmt.Sprintf("FleetFlow %s %s", driver.FirstName, driver.LastName)
+
+	default:
+		return nil, errors.New("ungültiger kalender-typ")
+	}
+
+	if feedToken.Name == "" {
+		feedToken.Name = defaultName
+	}
+
+	token, err := generateFeedToken()
+	if err != nil {
+		return nil, fmt.Errorf("token konnte nicht erzeugt werden: %v", err)
+	}
+	feedToken.Token = token
+
+	if err := s.tokenRepo.Create(feedToken); err != nil {
+		return nil, err
+	}
+	return feedToken, nil
+}
+
+// GetFeedTokens liefert alle Kalender-Abonnements eines Benutzers
+func (s *CalendarService) GetFeedTokens(userID primitive.ObjectID) ([]*model.CalendarFeedToken, error) {
+	return s.tokenRepo.FindByUser(userID)
+}
+
+// RevokeFeedToken widerruft ein Kalender-Abonnement des Benutzers
+func (s *CalendarService) RevokeFeedToken(userID primitive.ObjectID, id string) error {
+	feedToken, err := s.tokenRepo.FindByID(id)
+	if err != nil || feedToken.UserID != userID {
+		return ErrCalendarFeedNotFound
+	}
+	if feedToken.IsRevoked() {
+		return nil
+	}
+	return s.tokenRepo.Revoke(feedToken.ID)
+}
+
+// RenderFeed erzeugt den iCalendar-Feed für einen Token
+func (s *CalendarService) RenderFeed(token string) (string, error) {
+	feedToken, err := s.tokenRepo.FindByToken(token)
+	if err != nil {
+		return "", err
+	}
+	if feedToken == nil || feedToken.IsRevoked() {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	// Abonnements deaktivierter oder gelöschter Benutzer liefern nichts mehr aus
+	owner, err := s.userRepo.FindByID(feedToken.UserID.Hex())
+	if err != nil || owner.Status == model.StatusInactive {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	// Fahrzeug- und Flottenkalender nur, solange der Besitzer noch Manager oder Administrator ist
+	if (feedToken.Scope == model.CalendarFeedScopeFleet || feedToken.Scope == model.CalendarFeedScopeVehicle) && !isManagerOrAdmin(owner) {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	var reservations []model.VehicleReservation
+	switch feedToken.Scope {
+	case model.CalendarFeedScopeFleet:
+		reservations, err = s.reservationService.GetAllReservations()
+	case model.CalendarFeedScopeVehicle:
+		reservations, err = s.reservationService.GetReservationsByVehicle(feedToken.TargetID.Hex())
+	case model.CalendarFeedScopeDriver:
+		reservations, err = s.reservationService.GetReservationsByDriver(feedToken.TargetID.Hex())
+	default:
+		return "", ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.MarkAccessed(feedToken.ID); err != nil {
+		log.Printf("Warnung: Letzter Abruf des Kalender-Feeds %s konnte nicht gespeichert werden: %v", feedToken.ID.Hex(), err)
+	}
+
+	return s.buildCalendar(feedToken, reservations), nil
+}
+
+// buildCalendar wandelt die Reservierungen in ein VCALENDAR-Dokument um
+func (s *CalendarService) buildCalendar(feedToken *model.CalendarFeedToken, reservations []model.VehicleReservation) string {
+	cutoff := time.Now().AddDate(0, 0, -calendarFeedPastDays)
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].StartTime.Before(reservations[j].StartTime) })
+
+	vehicles := make(map[primitive.ObjectID]*model.Vehicle)
+	drivers := make(map[primitive.ObjectID]*model.Driver)
+
+	var w icsWriter
+	w.property("BEGIN", "VCALENDAR")
+	w.property("VERSION", "2.0")
+	w.property("PRODID", "-//FleetFlow//Fahrzeugreservierungen//DE")
+	w.property("CALSCALE", "GREGORIAN")
+	w.property("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", feedToken.Name)
+	w.property("X-WR-TIMEZONE", "Europe/Berlin")
+	w.property("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.property("X-PUBLISHED-TTL", "PT1H")
+
+	for i := range reservations {
+		reservation := &reservations[i]
+		if reservation.EndTime.Before(cutoff) {
+			continue
+		}
+
+		vehicle, ok := vehicles[reservation.VehicleID]
+		if !ok {
+			vehicle, _ = s.vehicleRepo.FindByID(reservation.VehicleID.Hex())
+			vehicles[reservation.VehicleID] = vehicle
+		}
+		driver, ok := drivers[reservation.DriverID]
+		if !ok {
+			driver, _ = s.driverRepo.FindByID(reservation.DriverID.Hex())
+			drivers[reservation.DriverID] = driver
+		}
+
+		writeReservationEvent(&w, reservation, vehicle, driver)
+	}
+
+	w.property("END", "VCALENDAR")
+	return w.String()
+}
+
+// writeReservationEvent schreibt eine Reservierung als VEVENT
+func writeReservationEvent(w *icsWriter, reservation *model.VehicleReservation, vehicle *model.Vehicle, driver *model.Driver) {
+	plate := "Unbekanntes Fahrzeug"
+	vehicleText := plate
+	if vehicle != nil {
+		plate = vehicle.LicensePlate
+		vehicleText = strings.TrimSpace(fmt.Sprintf("%s (%s %s)", vehicle.LicensePlate, vehicle.Brand, vehicle.Model))
+	}
+	driverName := "Unbekannter Fahrer"
+	if driver != nil {
+		driverName = driver.FirstName + " " + driver.LastName
+	}
+
+	summary := plate + " – " + driverName
+	if reservation.Purpose != "" {
+		summary += ": " + reservation.Purpose
+	}
+	if reservation.Status == model.ReservationStatusPending {
+		summary = "[Angefragt] " + summary
+	}
+
+	description := []string{
+		"Fahrzeug: " + vehicleText,
+		"Fahrer: " + driverName,
+		"Status: " + model.ReservationStatusText[reservation.Status],
+	}
+	if reservation.Purpose != "" {
+		description = append(description, "Zweck: "+reservation.Purpose)
+	}
+	if reservation.Notes != "" {
+		description = append(description, "Notizen: "+reservation.Notes)
+	}
+
+	lastModified := reservation.UpdatedAt
+	if lastModified.IsZero() {
+		lastModified = reservation.CreatedAt
+	}
+
+	w.property("BEGIN", "VEVENT")
+	w.property("UID", reservation.ID.Hex()+"@fleetflow")
+	w.timestamp("DTSTAMP", lastModified)
+	w.timestamp("CREATED", reservation.CreatedAt)
+	w.timestamp("LAST-MODIFIED", lastModified)
+	w.timestamp("DTSTART", reservation.StartTime)
+	w.timestamp("DTEND", reservation.EndTime)
+	w.text("SUMMARY", summary)
+	w.text("DESCRIPTION", strings.Join(description, "\n"))
+	w.text("LOCATION", plate)
+	w.property("STATUS", reservationEventStatus(reservation.Status))
+	w.property("TRANSP", "OPAQUE")
+	w.text("CATEGORIES", "Fahrzeugreservierung")
+	w.property("END", "VEVENT")
+}
+
+// reservationEventStatus bildet den Reservierungsstatus auf den VEVENT-Status ab
+func reservationEventStatus(status model.ReservationStatus) string {
+	switch status {
+	case model.ReservationStatusPending:
+		return "TENTATIVE"
+	case model.ReservationStatusRejected, model.ReservationStatusCancelled:
+		return "CANCELLED"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// driverForUser ermittelt das Fahrerprofil eines Benutzers über die E-Mail-Adresse
+// (Fallback: gleiche ID, wie im Fahrer-Dashboard)
+func driverForUser(driverRepo repository.DriverRepository, user *model.User) (*model.Driver, error) {
+	if driver, err := driverRepo.FindByEmail(user.Email); err == nil && driver != nil {
+		return driver, nil
+	}
+	return driverRepo.FindByID(user.ID.Hex())
+}
+
+// isManagerOrAdmin prüft, ob der Benutzer fuhrparkweite Rechte hat
+func isManagerOrAdmin(user *model.User) bool {
+	return user.Role == model.RoleAdmin || user.Role == model.RoleManager
+}
+
+// generateFeedToken erzeugt einen zufälligen, URL-sicheren Token
+func generateFeedToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrChargingSessionNotFound wird für unbekannte oder nicht sichtbare Ladevorgänge zurückgegeben
+	ErrChargingSessionNotFound = errors.New("ladevorgang nicht gefunden")
+	// ErrChargingForbidden wird zurückgegeben, wenn der Benutzer fremde Ladevorgänge erfassen oder ändern will
+	ErrChargingForbidden = errors.New("keine berechtigung für diesen ladevorgang")
+	// ErrChargingSessionLocked wird zurückgegeben, wenn über die Erstattung eines Ladevorgangs bereits entschieden wurde
+	ErrChargingSessionLocked = errors.New("über die erstattung wurde bereits entschieden, der ladevorgang kann nicht mehr geändert werden")
+)
+
+// ChargingSessionInput enthält die Angaben eines Ladevorgangs
+type ChargingSessionInput struct {
+	VehicleID     string
+	DriverID      string // Optional; ohne Angabe der Fahrer des Benutzers bzw. der aktuelle Fahrer des Fahrzeugs
+	StartTime     time.Time
+	EndTime       time.Time
+	EnergyKWh     float64
+	ChargePointID string
+	LocationType  model.ChargingLocationType
+	Location      string
+	Tariff        string
+	PricePerKWh   float64
+	Cost          float64 // Optional; ohne Angabe aus Energiemenge und Tarifpreis berechnet
+	Mileage       int
+	Notes         string
+}
+
+// ChargingService verwaltet Ladevorgänge von Elektrofahrzeugen und die Erstattung des Ladens zu Hause
+type ChargingService struct {
+	chargingRepo    repository.ChargingSessionRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	mileageService  *VehicleMileageService
+	activityService *ActivityService
+}
+
+// NewChargingService erstellt einen neuen ChargingService
+func NewChargingService(chargingRepo repository.ChargingSessionRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, mileageService *VehicleMileageService, activityService *ActivityService) *ChargingService {
+	return &ChargingService{
+		chargingRepo:    chargingRepo,
+		vehicleRepo:     vehicleRepo,
+		driverRepo:      driverRepo,
+		mileageService:  mileageService,
+		activityService: activityService,
+	}
+}
+
+// CreateSession erfasst einen Ladevorgang. Beim Laden zu Hause wird automatisch ein Erstattungsantrag gestellt.
+func (s *ChargingService) CreateSession(user *model.User, input ChargingSessionInput) (*model.ChargingSession, error) {
+	if err := validateChargingInput(&input); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.chargeableVehicle(input.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	driverID, err := s.resolveDriver(user, input.DriverID, vehicle)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.ChargingSession{CreatedBy: user.ID}
+	if err := applyChargingInput(session, input, vehicle.ID, driverID); err != nil {
+		return nil, err
+	}
+
+	if err := s.chargingRepo.Create(session); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des ladevorgangs: %v", err)
+	}
+
+	s.updateMileage(vehicle)
+
+	description := fmt.Sprintf("Ladevorgang für %s erfasst: %.1f kWh (%s)",
+		vehicle.LicensePlate, session.EnergyKWh, model.ChargingLocationTypeText(session.LocationType))
+	if session.Reimbursement.Status == model.ReimbursementPending {
+		description += fmt.Sprintf(", Erstattung über %.2f € beantragt", session.Reimbursement.Amount)
+	}
+	s.activityService.LogActivity("charging_session_created", description, user.ID, &vehicle.ID)
+
+	return session, nil
+}
+
+// UpdateSession ändert einen Ladevorgang, solange über eine Erstattung noch nicht entschieden wurde
+func (s *ChargingService) UpdateSession(user *model.User, id string, input ChargingSessionInput) (*model.ChargingSession, error) {
+	session, err := s.editableSession(user, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateChargingInput(&input); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.chargeableVehicle(input.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	driverID := session.DriverID
+	if input.DriverID != "" || vehicle.ID != session.VehicleID {
+		if driverID, err = s.resolveDriver(user, input.DriverID, vehicle); err != nil {
+			return nil, err
+		}
+	}
+
+	previousVehicleID := session.VehicleID
+	if err := applyChargingInput(session, input, vehicle.ID, driverID); err != nil {
+		return nil, err
+	}
+
+	if err := s.chargingRepo.Update(session); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des ladevorgangs: %v", err)
+	}
+
+	s.updateMileage(vehicle)
+	if previousVehicleID != vehicle.ID {
+		if previous, err := s.vehicleRepo.FindByID(previousVehicleID.Hex()); err == nil {
+			s.updateMileage(previous)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"charging_session_updated",
+		fmt.Sprintf("Ladevorgang für %s vom %s geändert", vehicle.LicensePlate, session.StartTime.Format("02.01.2006")),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return session, nil
+}
+
+// DeleteSession löscht einen Ladevorgang, solange über eine Erstattung noch nicht entschieden wurde
+func (s *ChargingService) DeleteSession(user *model.User, id string) error {
+	session, err := s.editableSession(user, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.chargingRepo.Delete(id); err != nil {
+		return fmt.Errorf("fehler beim löschen des ladevorgangs: %v", err)
+	}
+
+	if vehicle, err := s.vehicleRepo.FindByID(session.VehicleID.Hex()); err == nil {
+		s.updateMileage(vehicle)
+	}
+	return nil
+}
+
+// GetSession liefert einen Ladevorgang; Fahrer sehen nur ihre eigenen
+func (s *ChargingService) GetSession(user *model.User, id string) (*model.ChargingSession, error) {
+	session, err := s.chargingRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrChargingSessionNotFound
+	}
+	if !s.canAccess(user, session) {
+		return nil, ErrChargingSessionNotFound
+	}
+	return session, nil
+}
+
+// GetSessions liefert alle Ladevorgänge zum Filter; Fahrer sehen nur ihre eigenen
+func (s *ChargingService) GetSessions(user *model.User, filter model.ChargingSessionFilter) ([]*model.ChargingSession, error) {
+	if !isManagerOrAdmin(user) {
+		driver, err := driverForUser(s.driverRepo, user)
+		if err != nil {
+			return nil, ErrChargingForbidden
+		}
+		filter.DriverID = &driver.ID
+	}
+	return s.chargingRepo.Find(filter)
+}
+
+// ApproveReimbursement gibt den Erstattungsantrag eines Ladevorgangs zu Hause frei
+func (s *ChargingService) ApproveReimbursement(user *model.User, id string) (*model.ChargingSession, error) {
+	return s.reviewReimbursement(user, id, model.ReimbursementApproved, "")
+}
+
+// RejectReimbursement lehnt den Erstattungsantrag eines Ladevorgangs mit Begründung ab
+func (s *ChargingService) RejectReimbursement(user *model.User, id, comment string) (*model.ChargingSession, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, fmt.Errorf("eine begründung für die ablehnung ist erforderlich")
+	}
+	return s.reviewReimbursement(user, id, model.ReimbursementRejected, comment)
+}
+
+// reviewReimbursement entscheidet über einen offenen Erstattungsantrag
+func (s *ChargingService) reviewReimbursement(user *model.User, id string, status model.ReimbursementStatus, comment string) (*model.ChargingSession, error) {
+	if !isManagerOrAdmin(user) {
+		return nil, ErrChargingForbidden
+	}
+
+	session, err := s.chargingRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrChargingSessionNotFound
+	}
+	if session.Reimbursement.Status != model.ReimbursementPending {
+		return nil, fmt.Errorf("für diesen ladevorgang liegt kein offener erstattungsantrag vor")
+	}
+
+	now := time.Now()
+	session.Reimbursement.Status = status
+	session.Reimbursement.ReviewedBy = &user.ID
+	session.Reimbursement.ReviewedAt = &now
+	session.Reimbursement.Comment = comment
+	if err := s.chargingRepo.Update(session); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern der entscheidung: %v", err)
+	}
+
+	licensePlate := session.VehicleID.Hex()
+	if vehicle, err := s.vehicleRepo.FindByID(session.VehicleID.Hex()); err == nil {
+		licensePlate = vehicle.LicensePlate
+	}
+	activityType, verb := "charging_reimbursement_approved", "freigegeben"
+	if status == model.ReimbursementRejected {
+		activityType, verb = "charging_reimbursement_rejected", "abgelehnt"
+	}
+	s.activityService.LogActivity(
+		activityType,
+		fmt.Sprintf("Erstattung für Laden zu Hause (%s, %s) über %.2f € %s",
+			licensePlate, session.StartTime.Format("02.01.2006"), session.Reimbursement.Amount, verb),
+		user.ID,
+		&session.VehicleID,
+	)
+
+	return session, nil
+}
+
+// GetEnergyReport erstellt den Energiebericht eines Fahrzeugs für den Zeitraum [from, to)
+func (s *ChargingService) GetEnergyReport(vehicleID string, from, to time.Time) (*model.VehicleEnergyReport, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	sessions, err := s.chargingRepo.Find(model.ChargingSessionFilter{VehicleID: &vehicle.ID, From: &from, To: &to})
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der ladevorgänge: %v", err)
+	}
+
+	report := &model.VehicleEnergyReport{
+		VehicleID:    vehicle.ID,
+		LicensePlate: vehicle.LicensePlate,
+		From:         from,
+		To:           to,
+		Sessions:     len(sessions),
+		ByLocation:   []model.ChargingLocationSummary{},
+		ByMonth:      []model.ChargingMonthSummary{},
+	}
+
+	byLocation := make(map[model.ChargingLocationType]*model.ChargingLocationSummary)
+	byMonth := make(map[string]*model.ChargingMonthSummary)
+	var first, last *model.ChargingSession
+	var energySinceFirst float64
+
+	for _, session := range sessions {
+		report.EnergyKWh += session.EnergyKWh
+		report.Cost += session.Cost
+		report.ChargingHours += session.EndTime.Sub(session.StartTime).Hours()
+
+		switch session.Reimbursement.Status {
+		case model.ReimbursementApproved:
+			report.Reimbursed += session.Reimbursement.Amount
+		case model.ReimbursementPending:
+			report.PendingReimbursement += session.Reimbursement.Amount
+		}
+
+		location, exists := byLocation[session.LocationType]
+		if !exists {
+			location = &model.ChargingLocationSummary{LocationType: session.LocationType}
+			byLocation[session.LocationType] = location
+		}
+		location.Sessions++
+		location.EnergyKWh += session.EnergyKWh
+		location.Cost += session.Cost
+
+		monthKey := session.StartTime.Format("2006-01")
+		month, exists := byMonth[monthKey]
+		if !exists {
+			month = &model.ChargingMonthSummary{Month: monthKey}
+			byMonth[monthKey] = month
+		}
+		month.Sessions++
+		month.EnergyKWh += session.EnergyKWh
+		month.Cost += session.Cost
+
+		// Verbrauch nach der Volltankmethode: die Energie des ersten Ladevorgangs zählt zur Strecke davor
+		if session.Mileage > 0 {
+			if first == nil {
+				first = session
+			} else if session.Mileage > last.Mileage {
+				energySinceFirst += session.EnergyKWh
+			}
+			if last == nil || session.Mileage > last.Mileage {
+				last = session
+			}
+		}
+	}
+
+	if first != nil && last.Mileage > first.Mileage {
+		report.Distance = last.Mileage - first.Mileage
+		report.ConsumptionPer100 = roundCents(energySinceFirst / float64(report.Distance) * 100)
+	}
+	if report.EnergyKWh > 0 {
+		report.AveragePricePerKWh = roundCents(report.Cost / report.EnergyKWh)
+	}
+	report.EnergyKWh = roundCents(report.EnergyKWh)
+	report.Cost = roundCents(report.Cost)
+	report.ChargingHours = roundCents(report.ChargingHours)
+	report.Reimbursed = roundCents(report.Reimbursed)
+	report.PendingReimbursement = roundCents(report.PendingReimbursement)
+
+	for _, location := range byLocation {
+		location.EnergyKWh = roundCents(location.EnergyKWh)
+		location.Cost = roundCents(location.Cost)
+		report.ByLocation = append(report.ByLocation, *location)
+	}
+	sort.Slice(report.ByLocation, func(i, j int) bool { return report.ByLocation[i].LocationType < report.ByLocation[j].LocationType })
+
+	for _, month := range byMonth {
+		month.EnergyKWh = roundCents(month.EnergyKWh)
+		month.Cost = roundCents(month.Cost)
+		report.ByMonth = append(report.ByMonth, *month)
+	}
+	sort.Slice(report.ByMonth, func(i, j int) bool { return report.ByMonth[i].Month < report.ByMonth[j].Month })
+
+	return report, nil
+}
+
+// editableSession lädt einen Ladevorgang zum Ändern oder Löschen und prüft Berechtigung und Erstattungsstatus
+func (s *ChargingService) editableSession(user *model.User, id string) (*model.ChargingSession, error) {
+	session, err := s.GetSession(user, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Reimbursement.Status == model.ReimbursementApproved || session.Reimbursement.Status == model.ReimbursementRejected {
+		return nil, ErrChargingSessionLocked
+	}
+	return session, nil
+}
+
+// canAccess prüft, ob der Benutzer den Ladevorgang sehen darf
+func (s *ChargingService) canAccess(user *model.User, session *model.ChargingSession) bool {
+	if isManagerOrAdmin(user) || session.CreatedBy == user.ID {
+		return true
+	}
+	driver, err := driverForUser(s.driverRepo, user)
+	return err == nil && driver.ID == session.DriverID
+}
+
+// chargeableVehicle lädt ein Fahrzeug und prüft, ob es geladen werden kann
+func (s *ChargingService) chargeableVehicle(vehicleID string) (*model.Vehicle, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	switch vehicle.FuelType {
+	case model.FuelTypeElectric, model.FuelTypeHybridGas, model.FuelTypeHybridDiesel, "":
+		return vehicle, nil
+	default:
+		return nil, fmt.Errorf("ladevorgänge können nur für elektro- und hybridfahrzeuge erfasst werden")
+	}
+}
+
+// resolveDriver ermittelt den Fahrer eines Ladevorgangs. Fahrer erfassen nur für sich selbst;
+// Manager können einen Fahrer angeben, sonst gilt der aktuelle Fahrer des Fahrzeugs.
+func (s *ChargingService) resolveDriver(user *model.User, driverID string, vehicle *model.Vehicle) (primitive.ObjectID, error) {
+	if isManagerOrAdmin(user) {
+		if driverID == "" {
+			return vehicle.CurrentDriverID, nil
+		}
+		driver, err := s.driverRepo.FindByID(driverID)
+		if err != nil {
+			return primitive.NilObjectID, fmt.Errorf("fahrer nicht gefunden")
+		}
+		return driver.ID, nil
+	}
+
+	driver, err := driverForUser(s.driverRepo, user)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("für ihr benutzerkonto ist kein fahrerprofil hinterlegt")
+	}
+	if driverID != "" && driverID != driver.ID.Hex() {
+		return primitive.NilObjectID, ErrChargingForbidden
+	}
+	return driver.ID, nil
+}
+
+// updateMileage übernimmt den Kilometerstand des Ladevorgangs in das Fahrzeug
+func (s *ChargingService) updateMileage(vehicle *model.Vehicle) {
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicle.ID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Ladevorgang für %s: %v", vehicle.LicensePlate, err)
+	}
+}
+
+// applyChargingInput überträgt die Eingabe auf den Ladevorgang und führt den Erstattungsantrag nach
+func applyChargingInput(session *model.ChargingSession, input ChargingSessionInput, vehicleID, driverID primitive.ObjectID) error {
+	session.VehicleID = vehicleID
+	session.DriverID = driverID
+	session.StartTime = input.StartTime
+	session.EndTime = input.EndTime
+	session.EnergyKWh = input.EnergyKWh
+	session.ChargePointID = input.ChargePointID
+	session.LocationType = input.LocationType
+	session.Location = input.Location
+	session.Tariff = input.Tariff
+	session.PricePerKWh = input.PricePerKWh
+	session.Cost = input.Cost
+	session.Mileage = input.Mileage
+	session.Notes = input.Notes
+
+	if session.Cost == 0 {
+		session.Cost = roundCents(session.EnergyKWh * session.PricePerKWh)
+	}
+	if session.PricePerKWh == 0 && session.Cost > 0 {
+		session.PricePerKWh = roundCents(session.Cost / session.EnergyKWh)
+	}
+
+	if session.LocationType != model.ChargingLocationHome {
+		session.Reimbursement = model.ChargingReimbursement{}
+		return nil
+	}
+	if session.DriverID.IsZero() {
+		return fmt.Errorf("für die erstattung beim laden zu hause ist ein fahrer erforderlich")
+	}
+	if session.Cost <= 0 {
+		return fmt.Errorf("für die erstattung beim laden zu hause sind kosten oder ein preis pro kwh erforderlich")
+	}
+	session.Reimbursement = model.ChargingReimbursement{
+		Status: model.ReimbursementPending,
+		Amount: session.Cost,
+	}
+	return nil
+}
+
+// validateChargingInput prüft die Pflichtangaben eines Ladevorgangs
+func validateChargingInput(input *ChargingSessionInput) error {
+	input.ChargePointID = strings.TrimSpace(input.ChargePointID)
+	input.Location = strings.TrimSpace(input.Location)
+	input.Tariff = strings.TrimSpace(input.Tariff)
+
+	if input.StartTime.IsZero() || input.EndTime.IsZero() {
+		return fmt.Errorf("beginn und ende des ladevorgangs sind erforderlich")
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return fmt.Errorf("das ende muss nach dem beginn des ladevorgangs liegen")
+	}
+	if input.EndTime.After(time.Now().Add(time.Hour)) {
+		return fmt.Errorf("der ladevorgang darf nicht in der zukunft liegen")
+	}
+	if input.EnergyKWh <= 0 {
+		return fmt.Errorf("die geladene energiemenge muss größer als 0 sein")
+	}
+	if !model.IsValidChargingLocationType(input.LocationType) {
+		return fmt.Errorf("ungültiger ladeort, erlaubt sind depot, public und home")
+	}
+	if input.PricePerKWh < 0 || input.Cost < 0 || input.Mileage < 0 {
+		return fmt.Errorf("preis, kosten und kilometerstand dürfen nicht negativ sein")
+	}
+	return nil
+}
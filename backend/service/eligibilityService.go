@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+)
+
+// EligibilityService prüft, ob ein Fahrer ein Fahrzeug führen darf
+type EligibilityService struct {
+	driverDocRepo repository.DriverDocumentRepository
+}
+
+// NewEligibilityService erstellt einen neuen EligibilityService
+func NewEligibilityService(driverDocRepo repository.DriverDocumentRepository) *EligibilityService {
+	return &EligibilityService{
+		driverDocRepo: driverDocRepo,
+	}
+}
+
+// CheckDriverEligibility prüft, ob der Fahrer die erforderliche Führerscheinklasse besitzt und
+// sein hinterlegter Führerschein mindestens bis validUntil gültig ist. Fahrer ohne hochgeladenen
+// Führerschein werden nur anhand der Klassen geprüft; Fahrer ohne hinterlegte Klassen werden abgelehnt.
+func (s *EligibilityService) CheckDriverEligibility(driver *model.Driver, vehicle *model.Vehicle, validUntil time.Time) error {
+	required := vehicle.RequiredLicense()
+	if len(driver.LicenseClasses) == 0 {
+		return fmt.Errorf("für fahrer %s %s sind keine führerscheinklassen hinterlegt, fahrzeug %s erfordert klasse %s",
+			driver.FirstName, driver.LastName, vehicle.LicensePlate, required)
+	}
+	if !driver.HasLicenseFor(required) {
+		classes := make([]string, 0, len(driver.LicenseClasses))
+		for _, class := range driver.LicenseClasses {
+			classes = append(classes, string(class))
+		}
+		return fmt.Errorf("fahrer %s %s fehlt die führerscheinklasse %s für fahrzeug %s (vorhanden: %s)",
+			driver.FirstName, driver.LastName, required, vehicle.LicensePlate, strings.Join(classes, ", "))
+	}
+
+	expiry, err := s.LicenseExpiry(driver)
+	if err != nil {
+		return err
+	}
+	if expiry != nil && expiry.Before(validUntil) {
+		return licenseExpiryError(driver, *expiry)
+	}
+	return nil
+}
+
+// LicenseExpiry liefert das späteste Ablaufdatum der hochgeladenen Führerscheine. nil bedeutet,
+// dass kein Führerschein hochgeladen ist oder einer unbefristet gilt.
+func (s *EligibilityService) LicenseExpiry(driver *model.Driver) (*time.Time, error) {
+	licenses, err := s.driverDocRepo.FindByDriverAndType(driver.ID.Hex(), model.DriverDocumentTypeLicense)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim prüfen des führerscheins: %v", err)
+	}
+
+	var latestExpiry *time.Time
+	for _, license := range licenses {
+		if license.ExpiryDate == nil {
+			return nil, nil
+		}
+		if latestExpiry == nil || license.ExpiryDate.After(*latestExpiry) {
+			latestExpiry = license.ExpiryDate
+		}
+	}
+	return latestExpiry, nil
+}
+
+// licenseExpiryError meldet einen Führerschein, der vor dem Ende der Nutzung abläuft
+func licenseExpiryError(driver *model.Driver, expiry time.Time) error {
+	return fmt.Errorf("führerschein von fahrer %s %s ist nur bis %s gültig",
+		driver.FirstName, driver.LastName, expiry.Format("02.01.2006"))
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// emailQueuePollInterval legt fest, wie oft die Warteschlange ohne neuen Auftrag geprüft wird
+	emailQueuePollInterval = 10 * time.Second
+	// emailSendLockDuration sperrt eine E-Mail während des Versands für andere Instanzen
+	emailSendLockDuration = 5 * time.Minute
+	// emailRetryBaseDelay ist die Wartezeit nach dem ersten Fehlversuch, sie verdoppelt sich danach
+	emailRetryBaseDelay = time.Minute
+	// emailRetryMaxDelay begrenzt die Wartezeit zwischen zwei Versuchen
+	emailRetryMaxDelay = 6 * time.Hour
+	// DefaultEmailMaxAttempts ist die Anzahl der Zustellversuche, bevor eine E-Mail als fehlgeschlagen gilt
+	DefaultEmailMaxAttempts = 8
+)
+
+var (
+	// ErrEmailNotFound wird für unbekannte E-Mail-Log-Einträge zurückgegeben
+	ErrEmailNotFound = errors.New("e-mail nicht gefunden")
+	// ErrEmailNotFailed wird zurückgegeben, wenn nur fehlgeschlagene E-Mails erneut gesendet werden dürfen
+	ErrEmailNotFailed = errors.New("nur fehlgeschlagene e-mails können erneut gesendet werden")
+)
+
+// enqueueEmail legt eine E-Mail in der Warteschlange ab und weckt den Versand-Worker.
+// Ohne aktive SMTP-Konfiguration bleibt die E-Mail eingereiht, bis SMTP aktiviert wird.
+func (s *EmailService) enqueueEmail(to, subject, body, bodyHTML string, notBefore time.Time) error {
+	emailLog := &model.EmailLog{
+		To:            to,
+		Subject:       subject,
+		Body:          body,
+		BodyHTML:      bodyHTML,
+		Status:        model.EmailStatusPending,
+		MaxAttempts:   DefaultEmailMaxAttempts,
+		NextAttemptAt: notBefore,
+	}
+
+	if err := s.smtpRepo.LogEmail(emailLog); err != nil {
+		return fmt.Errorf("fehler beim Einreihen der E-Mail: %v", err)
+	}
+
+	s.wakeQueue()
+	return nil
+}
+
+// StartQueue startet den Worker, der die E-Mail-Warteschlange abarbeitet
+func (s *EmailService) StartQueue() {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+
+	if s.queueRunning {
+		return
+	}
+	s.queueRunning = true
+	s.queueStop = make(chan struct{})
+
+	s.queueWG.Add(1)
+	go func(stop <-chan struct{}) {
+		defer s.queueWG.Done()
+
+		ticker := time.NewTicker(emailQueuePollInterval)
+		defer ticker.Stop()
+
+		for {
+			s.processQueue(stop)
+			select {
+			case <-ticker.C:
+			case <-s.queueWake:
+			case <-stop:
+				return
+			}
+		}
+	}(s.queueStop)
+}
+
+// StopQueue beendet den Worker nach dem aktuellen Versand. Nicht zugestellte E-Mails
+// bleiben in der Warteschlange und werden beim nächsten Start verschickt.
+func (s *EmailService) StopQueue() {
+	s.queueMu.Lock()
+	if !s.queueRunning {
+		s.queueMu.Unlock()
+		return
+	}
+	s.queueRunning = false
+	close(s.queueStop)
+	s.queueMu.Unlock()
+
+	s.queueWG.Wait()
+}
+
+// wakeQueue weckt den Worker, ohne zu blockieren
+func (s *EmailService) wakeQueue() {
+	select {
+	case s.queueWake <- struct{}{}:
+	default:
+	}
+}
+
+// processQueue verschickt alle fälligen E-Mails. Solange SMTP inaktiv ist, bleiben
+// die E-Mails unverändert in der Warteschlange, damit keine Versuche verbraucht werden.
+func (s *EmailService) processQueue(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		config, err := s.smtpRepo.GetSMTPConfig()
+		if err != nil {
+			log.Printf("Fehler beim Abrufen der SMTP-Konfiguration: %v", err)
+			return
+		}
+		if config == nil || !config.IsActive {
+			return
+		}
+
+		now := time.Now()
+		emailLog, err := s.smtpRepo.ClaimNextEmail(now, now.Add(emailSendLockDuration))
+		if err != nil {
+			log.Printf("Fehler beim Lesen der E-Mail-Warteschlange: %v", err)
+			return
+		}
+		if emailLog == nil {
+			return
+		}
+
+		s.deliver(emailLog)
+	}
+}
+
+// deliver versucht eine reservierte E-Mail zuzustellen und plant bei Fehlern den nächsten Versuch
+func (s *EmailService) deliver(emailLog *model.EmailLog) {
+	err := s.sendQueuedEmail(emailLog)
+	if err == nil {
+		if err := s.smtpRepo.MarkEmailSent(emailLog.ID, time.Now()); err != nil {
+			log.Printf("Fehler beim Aktualisieren des E-Mail-Logs %s: %v", emailLog.ID.Hex(), err)
+		}
+		return
+	}
+
+	maxAttempts := emailLog.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultEmailMaxAttempts
+	}
+
+	var retryAt *time.Time
+	if emailLog.Attempts < maxAttempts {
+		next := time.Now().Add(emailRetryDelay(emailLog.Attempts))
+		retryAt = &next
+		log.Printf("⚠️  E-Mail an %s fehlgeschlagen (Versuch %d/%d), nächster Versuch %s: %v",
+			emailLog.To, emailLog.Attempts, maxAttempts, next.Format("02.01.2006 15:04"), err)
+	} else {
+		log.Printf("❌ E-Mail an %s nach %d Versuchen endgültig fehlgeschlagen: %v", emailLog.To, emailLog.Attempts, err)
+	}
+
+	if err := s.smtpRepo.MarkEmailFailed(emailLog.ID, err.Error(), retryAt); err != nil {
+		log.Printf("Fehler beim Aktualisieren des E-Mail-Logs %s: %v", emailLog.ID.Hex(), err)
+	}
+}
+
+// sendQueuedEmail stellt eine E-Mail mit der aktuellen SMTP-Konfiguration zu
+func (s *EmailService) sendQueuedEmail(emailLog *model.EmailLog) error {
+	config, err := s.smtpRepo.GetSMTPConfig()
+	if err != nil {
+		return fmt.Errorf("fehler beim Abrufen der SMTP-Konfiguration: %v", err)
+	}
+
+	if config == nil || !config.IsActive {
+		return fmt.Errorf("keine aktive SMTP-Konfiguration gefunden")
+	}
+
+	return s.sendSMTPEmail(config, emailLog.To, emailLog.Subject, emailLog.Body, emailLog.BodyHTML)
+}
+
+// emailRetryDelay liefert die Wartezeit nach dem n-ten Fehlversuch (1 min, 2 min, 4 min, ... max. 6 h)
+func emailRetryDelay(attempt int) time.Duration {
+	delay := emailRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= emailRetryMaxDelay {
+			return emailRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// GetEmailLogsByStatus holt E-Mail-Logs eines Status
+func (s *EmailService) GetEmailLogsByStatus(status model.EmailStatus, limit, offset int) ([]*model.EmailLog, error) {
+	return s.smtpRepo.GetEmailLogsByStatus(status, limit, offset)
+}
+
+// ResendEmail stellt eine endgültig fehlgeschlagene E-Mail erneut in die Warteschlange
+func (s *EmailService) ResendEmail(id string) (*model.EmailLog, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrEmailNotFound
+	}
+
+	requeued, err := s.smtpRepo.RequeueEmail(objectID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	emailLog, err := s.smtpRepo.GetEmailLog(id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if !requeued {
+		return nil, ErrEmailNotFailed
+	}
+
+	s.wakeQueue()
+	return emailLog, nil
+}
@@ -8,26 +8,45 @@ import (
 	"fmt"
 	"net/smtp"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// EmailService verwaltet E-Mail-Funktionen
+// EmailService verwaltet E-Mail-Funktionen. Ausgehende E-Mails laufen über eine
+// persistente Warteschlange, die ein Hintergrund-Worker mit Wiederholungen abarbeitet.
 type EmailService struct {
-	smtpRepo *repository.SMTPRepository
+	smtpRepo repository.SMTPRepository
+
+	queueMu      sync.Mutex
+	queueRunning bool
+	queueStop    chan struct{}
+	queueWake    chan struct{}
+	queueWG      sync.WaitGroup
 }
 
 // NewEmailService erstellt einen neuen EmailService
-func NewEmailService() *EmailService {
+func NewEmailService(smtpRepo repository.SMTPRepository) *EmailService {
 	return &EmailService{
-		smtpRepo: repository.NewSMTPRepository(),
+		smtpRepo:  smtpRepo,
+		queueWake: make(chan struct{}, 1),
 	}
 }
 
-// SendEmail sendet eine E-Mail
+// SendEmail reiht eine E-Mail zum sofortigen Versand in die Warteschlange ein
 func (s *EmailService) SendEmail(to, subject, body, bodyHTML string) error {
+	return s.enqueueEmail(to, subject, body, bodyHTML, time.Now())
+}
+
+// SendEmailAt reiht eine E-Mail ein, die frühestens zum angegebenen Zeitpunkt versendet wird
+func (s *EmailService) SendEmailAt(to, subject, body, bodyHTML string, notBefore time.Time) error {
+	return s.enqueueEmail(to, subject, body, bodyHTML, notBefore)
+}
+
+// SendEmailNow sendet eine E-Mail sofort und ohne Warteschlange, z.B. für Test-E-Mails
+func (s *EmailService) SendEmailNow(to, subject, body, bodyHTML string) error {
 	config, err := s.smtpRepo.GetSMTPConfig()
 	if err != nil {
 		return fmt.Errorf("fehler beim Abrufen der SMTP-Konfiguration: %v", err)
@@ -39,20 +58,25 @@ func (s *EmailService) SendEmail(to, subject, body, bodyHTML string) error {
 
 	// E-Mail-Log erstellen
 	emailLog := &model.EmailLog{
-		To:      to,
-		Subject: subject,
-		Body:    body,
-		Status:  model.EmailStatusPending,
+		To:          to,
+		Subject:     subject,
+		Body:        body,
+		BodyHTML:    bodyHTML,
+		Status:      model.EmailStatusPending,
+		Attempts:    1,
+		MaxAttempts: 1,
 	}
 
 	// Versuche E-Mail zu senden
+	now := time.Now()
+	emailLog.LastAttemptAt = &now
+	emailLog.NextAttemptAt = now
 	err = s.sendSMTPEmail(config, to, subject, body, bodyHTML)
 	if err != nil {
 		emailLog.Status = model.EmailStatusFailed
 		emailLog.Error = err.Error()
 	} else {
 		emailLog.Status = model.EmailStatusSent
-		now := time.Now()
 		emailLog.SentAt = &now
 	}
 
@@ -240,9 +264,15 @@ func (s *EmailService) GetSMTPConfig() (*model.SMTPConfig, error) {
 	return s.smtpRepo.GetSMTPConfig()
 }
 
-// SaveSMTPConfig speichert die SMTP-Konfiguration
+// SaveSMTPConfig speichert die SMTP-Konfiguration und weckt den Versand-Worker,
+// damit zurückgehaltene E-Mails nach dem Aktivieren sofort verschickt werden
 func (s *EmailService) SaveSMTPConfig(config *model.SMTPConfig) error {
-	return s.smtpRepo.SaveSMTPConfig(config)
+	if err := s.smtpRepo.SaveSMTPConfig(config); err != nil {
+		return err
+	}
+
+	s.wakeQueue()
+	return nil
 }
 
 // GetEmailTemplates holt alle E-Mail-Vorlagen
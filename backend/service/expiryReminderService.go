@@ -0,0 +1,358 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxExpiryReminderOffset begrenzt die Vorlaufzeit einer Erinnerungsstufe
+const maxExpiryReminderOffset = 365
+
+// expiryItem ist ein ablaufendes Dokument oder Fahrzeugdatum mit seinen Empfängern
+type expiryItem struct {
+	subjectType model.ExpirySubjectType
+	subjectID   primitive.ObjectID
+	title       string
+	expiryDate  time.Time
+	driver      *model.Driver // Verantwortlicher Fahrer (optional)
+}
+
+// expiryRecipient ist ein Empfänger einer Ablauferinnerung
+type expiryRecipient struct {
+	email string
+	name  string
+}
+
+// ExpiryReminderService verschickt gestaffelte Erinnerungen vor dem Ablauf von
+// Führerscheinen, Fahrzeugdokumenten, Versicherung, Hauptuntersuchung und Leasingvertrag
+type ExpiryReminderService struct {
+	reminderRepo   repository.ExpiryReminderRepository
+	driverDocRepo  repository.DriverDocumentRepository
+	vehicleDocRepo repository.VehicleDocumentRepository
+	vehicleRepo    repository.VehicleRepository
+	driverRepo     repository.DriverRepository
+	userRepo       repository.UserRepository
+	emailService   *EmailService
+	notifications  *NotificationService
+}
+
+// NewExpiryReminderService erstellt einen neuen ExpiryReminderService
+func NewExpiryReminderService(reminderRepo repository.ExpiryReminderRepository, driverDocRepo repository.DriverDocumentRepository, vehicleDocRepo repository.VehicleDocumentRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, userRepo repository.UserRepository, emailService *EmailService, notifications *NotificationService) *ExpiryReminderService {
+	return &ExpiryReminderService{
+		reminderRepo:   reminderRepo,
+		driverDocRepo:  driverDocRepo,
+		vehicleDocRepo: vehicleDocRepo,
+		vehicleRepo:    vehicleRepo,
+		driverRepo:     driverRepo,
+		userRepo:       userRepo,
+		emailService:   emailService,
+		notifications:  notifications,
+	}
+}
+
+// GetSettings liefert die Einstellungen der Ablauferinnerungen
+func (s *ExpiryReminderService) GetSettings() (*model.ExpiryReminderSettings, error) {
+	return s.reminderRepo.GetSettings()
+}
+
+// SaveSettings prüft und speichert die Einstellungen der Ablauferinnerungen
+func (s *ExpiryReminderService) SaveSettings(settings *model.ExpiryReminderSettings) error {
+	if len(settings.OffsetsDays) == 0 {
+		return errors.New("mindestens eine erinnerungsstufe ist erforderlich")
+	}
+
+	seen := make(map[int]bool)
+	var offsets []int
+	for _, offset := range settings.OffsetsDays {
+		if offset < 0 || offset > maxExpiryReminderOffset {
+			return fmt.Errorf("erinnerungsstufe %d muss zwischen 0 und %d tagen liegen", offset, maxExpiryReminderOffset)
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+	settings.OffsetsDays = offsets
+
+	return s.reminderRepo.SaveSettings(settings)
+}
+
+// GetRecentReminders liefert die zuletzt versendeten Erinnerungen
+func (s *ExpiryReminderService) GetRecentReminders(limit int) ([]*model.ExpiryReminder, error) {
+	return s.reminderRepo.FindRecent(limit)
+}
+
+// RunReminders sucht ablaufende Daten und verschickt für jede neu erreichte Stufe eine Erinnerung.
+// Wird ein Lauf verpasst, geht nur die aktuell dringendste Stufe raus, nicht alle übersprungenen.
+func (s *ExpiryReminderService) RunReminders(run JobRunContext) error {
+	settings, err := s.reminderRepo.GetSettings()
+	if err != nil {
+		return err
+	}
+	// Ein manueller Start läuft auch bei deaktivierten Erinnerungen
+	if !settings.Enabled && run.Trigger != model.JobTriggerManual {
+		return ErrJobSkipped
+	}
+	if len(settings.OffsetsDays) == 0 || (!settings.NotifyDrivers && !settings.NotifyManagers) {
+		return ErrJobSkipped
+	}
+
+	maxOffset := 0
+	for _, offset := range settings.OffsetsDays {
+		if offset > maxOffset {
+			maxOffset = offset
+		}
+	}
+
+	items, err := s.collectExpiringItems(maxOffset)
+	if err != nil {
+		return err
+	}
+
+	var managers []*model.User
+	if settings.NotifyManagers {
+		if managers, err = s.getActiveManagers(); err != nil {
+			return err
+		}
+	}
+
+	today := calendarDay(time.Now().In(berlinLocation()))
+	sent, failed := 0, 0
+	for _, item := range items {
+		daysLeft := int(calendarDay(item.expiryDate).Sub(today).Hours() / 24)
+		offset, ok := reminderStage(settings.OffsetsDays, daysLeft)
+		if !ok {
+			continue
+		}
+
+		for _, recipient := range s.recipientsFor(item, settings, managers) {
+			alreadySent, err := s.reminderRepo.HasBeenSent(item.subjectType, item.subjectID, calendarDay(item.expiryDate), offset, recipient.email)
+			if err != nil {
+				return err
+			}
+			if alreadySent {
+				continue
+			}
+
+			if err := s.sendReminder(item, recipient, daysLeft); err != nil {
+				log.Printf("Fehler beim Senden der Ablauferinnerung an %s: %v", recipient.email, err)
+				failed++
+				continue
+			}
+
+			reminder := &model.ExpiryReminder{
+				SubjectType: item.subjectType,
+				SubjectID:   item.subjectID,
+				Title:       item.title,
+				ExpiryDate:  calendarDay(item.expiryDate),
+				OffsetDays:  offset,
+				Recipient:   recipient.email,
+				SentAt:      time.Now(),
+			}
+			if err := s.reminderRepo.Create(reminder); err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d von %d ablauferinnerungen konnten nicht gesendet werden", failed, failed+sent)
+	}
+	return nil
+}
+
+// collectExpiringItems sammelt alle Daten, die innerhalb der größten Vorlaufzeit ablaufen
+func (s *ExpiryReminderService) collectExpiringItems(withinDays int) ([]expiryItem, error) {
+	var items []expiryItem
+	drivers := make(map[primitive.ObjectID]*model.Driver)
+	driverByID := func(id primitive.ObjectID) *model.Driver {
+		if id.IsZero() {
+			return nil
+		}
+		if driver, ok := drivers[id]; ok {
+			return driver
+		}
+		driver, err := s.driverRepo.FindByID(id.Hex())
+		if err != nil {
+			driver = nil
+		}
+		drivers[id] = driver
+		return driver
+	}
+
+	// Führerscheine
+	licenses, err := s.driverDocRepo.FindExpiringLicenses(withinDays)
+	if err != nil {
+		return nil, err
+	}
+	for _, license := range licenses {
+		driver := driverByID(license.DriverID)
+		title := "Führerschein"
+		if driver != nil {
+			title = fmt.Sprintf("Führerschein von %s %s", driver.FirstName, driver.LastName)
+		}
+		items = append(items, expiryItem{
+			subjectType: model.ExpirySubjectDriverLicense,
+			subjectID:   license.ID,
+			title:       title,
+			expiryDate:  *license.ExpiryDate,
+			driver:      driver,
+		})
+	}
+
+	// Fahrzeugdokumente und Fahrzeugdaten
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	vehicleByID := make(map[primitive.ObjectID]*model.Vehicle, len(vehicles))
+	for _, vehicle := range vehicles {
+		vehicleByID[vehicle.ID] = vehicle
+	}
+
+	documents, err := s.vehicleDocRepo.FindExpiring(withinDays)
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		vehicle := vehicleByID[document.VehicleID]
+		if vehicle == nil {
+			continue
+		}
+		items = append(items, expiryItem{
+			subjectType: model.ExpirySubjectVehicleDocument,
+			subjectID:   document.ID,
+			title:       fmt.Sprintf("%s für %s", document.Name, vehicle.LicensePlate),
+			expiryDate:  *document.ExpiryDate,
+			driver:      driverByID(vehicle.CurrentDriverID),
+		})
+	}
+
+	today := calendarDay(time.Now().In(berlinLocation()))
+	limit := today.AddDate(0, 0, withinDays)
+	for _, vehicle := range vehicles {
+		dates := []struct {
+			subjectType model.ExpirySubjectType
+			date        time.Time
+		}{
+			{model.ExpirySubjectVehicleInsurance, vehicle.InsuranceExpiry},
+			{model.ExpirySubjectVehicleInspection, vehicle.NextInspectionDate},
+		}
+		if vehicle.AcquisitionType == model.AcquisitionTypeLeased {
+			dates = append(dates, struct {
+				subjectType model.ExpirySubjectType
+				date        time.Time
+			}{model.ExpirySubjectVehicleLease, vehicle.LeaseEndDate})
+		}
+
+		for _, d := range dates {
+			if d.date.IsZero() {
+				continue
+			}
+			day := calendarDay(d.date)
+			if day.Before(today) || day.After(limit) {
+				continue
+			}
+			items = append(items, expiryItem{
+				subjectType: d.subjectType,
+				subjectID:   vehicle.ID,
+				title:       fmt.Sprintf("%s für %s", model.ExpirySubjectText[d.subjectType], vehicle.LicensePlate),
+				expiryDate:  d.date,
+				driver:      driverByID(vehicle.CurrentDriverID),
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// recipientsFor ermittelt die Empfänger einer Erinnerung ohne doppelte Adressen
+func (s *ExpiryReminderService) recipientsFor(item expiryItem, settings *model.ExpiryReminderSettings, managers []*model.User) []expiryRecipient {
+	var recipients []expiryRecipient
+	seen := make(map[string]bool)
+	add := func(email, name string) {
+		email = strings.TrimSpace(email)
+		key := strings.ToLower(email)
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		recipients = append(recipients, expiryRecipient{email: email, name: name})
+	}
+
+	// Persönliche Benachrichtigungseinstellungen und Ruhezeiten der Empfänger beachten
+	if settings.NotifyDrivers && item.driver != nil && s.notifications.ShouldNotifyDriver(item.driver, model.NotificationExpiryReminder) {
+		add(item.driver.Email, item.driver.FirstName+" "+item.driver.LastName)
+	}
+	for _, manager := range managers {
+		if s.notifications.ShouldNotifyUser(manager, model.NotificationExpiryReminder) {
+			add(manager.Email, manager.FirstName+" "+manager.LastName)
+		}
+	}
+	return recipients
+}
+
+// sendReminder verschickt eine Erinnerung über die E-Mail-Vorlage
+func (s *ExpiryReminderService) sendReminder(item expiryItem, recipient expiryRecipient, daysLeft int) error {
+	headline := fmt.Sprintf("%s läuft in %d Tagen ab", item.title, daysLeft)
+	switch daysLeft {
+	case 0:
+		headline = item.title + " läuft heute ab"
+	case 1:
+		headline = item.title + " läuft morgen ab"
+	}
+
+	data := map[string]interface{}{
+		"RecipientName": recipient.name,
+		"Kind":          model.ExpirySubjectText[item.subjectType],
+		"Title":         item.title,
+		"Headline":      headline,
+		"ExpiryDate":    calendarDay(item.expiryDate).Format("02.01.2006"),
+		"DaysLeft":      daysLeft,
+	}
+
+	return s.emailService.SendTemplateEmail(recipient.email, model.EmailTemplateExpiryReminder, data)
+}
+
+// getActiveManagers liefert alle aktiven Manager und Administratoren
+func (s *ExpiryReminderService) getActiveManagers() ([]*model.User, error) {
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var managers []*model.User
+	for _, user := range users {
+		if (user.Role == model.RoleAdmin || user.Role == model.RoleManager) && user.Status != model.StatusInactive {
+			managers = append(managers, user)
+		}
+	}
+	return managers, nil
+}
+
+// reminderStage liefert die dringendste erreichte Erinnerungsstufe für die verbleibenden Tage
+func reminderStage(offsets []int, daysLeft int) (int, bool) {
+	stage, found := 0, false
+	for _, offset := range offsets {
+		if daysLeft <= offset && (!found || offset < stage) {
+			stage, found = offset, true
+		}
+	}
+	return stage, found
+}
+
+// calendarDay reduziert einen Zeitpunkt auf sein Kalenderdatum (Mitternacht UTC),
+// so wie Datumsfelder ohne Uhrzeit gespeichert werden
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// maxInstallments begrenzt Tilgungspläne ohne Vertragsende auf 50 Jahre
+	maxInstallments = 600
+	// financeRateTolerance ist die erlaubte Abweichung der Monatsrate von der rechnerischen Annuität
+	financeRateTolerance = 0.02
+	// maxPlausibleInterestRate ist der Sollzins in Prozent, ab dem ein Tippfehler vermutet wird
+	maxPlausibleInterestRate = 15.0
+	// defaultForecastMonths ist der Zeitraum der Verbindlichkeiten-Vorschau ohne Angabe
+	defaultForecastMonths = 24
+)
+
+// ErrFinancingVehicleNotFound wird für unbekannte Fahrzeuge zurückgegeben
+var ErrFinancingVehicleNotFound = errors.New("fahrzeug nicht gefunden")
+
+// FinancingService erstellt Tilgungspläne für finanzierte Fahrzeuge, eine monatliche Vorschau
+// der Zahlungsverpflichtungen der Flotte und prüft die Finanzierungsdaten auf Widersprüche
+type FinancingService struct {
+	vehicleRepo repository.VehicleRepository
+}
+
+// NewFinancingService erstellt einen neuen FinancingService
+func NewFinancingService(vehicleRepo repository.VehicleRepository) *FinancingService {
+	return &FinancingService{
+		vehicleRepo: vehicleRepo,
+	}
+}
+
+// GetSchedule erstellt den Tilgungsplan eines finanzierten Fahrzeugs
+func (s *FinancingService) GetSchedule(vehicleID string) (*model.AmortizationSchedule, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, ErrFinancingVehicleNotFound
+	}
+	if vehicle.AcquisitionType != model.AcquisitionTypeFinanced {
+		return nil, fmt.Errorf("das fahrzeug ist nicht finanziert")
+	}
+	return BuildAmortizationSchedule(vehicle, time.Now()), nil
+}
+
+// GetIssues prüft die Finanzierungsdaten aller Fahrzeuge, Fehler zuerst
+func (s *FinancingService) GetIssues() ([]model.FinancingIssue, error) {
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []model.FinancingIssue{}
+	now := time.Now()
+	for _, vehicle := range vehicles {
+		if vehicle.AcquisitionType == model.AcquisitionTypeFinanced {
+			issues = append(issues, BuildAmortizationSchedule(vehicle, now).Issues...)
+			continue
+		}
+		if vehicle.FinanceTotalAmount > 0 || vehicle.FinanceMonthlyRate > 0 {
+			issues = append(issues, model.FinancingIssue{
+				VehicleID:    vehicle.ID,
+				LicensePlate: vehicle.LicensePlate,
+				Code:         "not_financed",
+				Severity:     model.FinancingIssueWarning,
+				Message:      "Finanzierungsdaten hinterlegt, das Fahrzeug ist aber nicht als finanziert erfasst",
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Severity != issues[j].Severity {
+			return issues[i].Severity == model.FinancingIssueError
+		}
+		return issues[i].LicensePlate < issues[j].LicensePlate
+	})
+	return issues, nil
+}
+
+// GetLiabilityForecast summiert Finanzierungs- und Leasingraten der Flotte je Monat,
+// beginnend mit dem aktuellen Monat
+func (s *FinancingService) GetLiabilityForecast(months int) (*model.LiabilityForecast, error) {
+	if months == 0 {
+		months = defaultForecastMonths
+	}
+	if months < 1 || months > 120 {
+		return nil, fmt.Errorf("die vorschau muss zwischen 1 und 120 monaten liegen")
+	}
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	firstMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	forecast := &model.LiabilityForecast{Months: make([]model.LiabilityForecastMonth, months)}
+	for i := range forecast.Months {
+		forecast.Months[i].Month = firstMonth.AddDate(0, i, 0).Format("2006-01")
+	}
+	monthIndex := func(t time.Time) int {
+		return (t.Year()-firstMonth.Year())*12 + int(t.Month()-firstMonth.Month())
+	}
+
+	for _, vehicle := range vehicles {
+		switch vehicle.AcquisitionType {
+		case model.AcquisitionTypeFinanced:
+			schedule := BuildAmortizationSchedule(vehicle, now)
+			if len(schedule.Issues) > 0 {
+				forecast.Issues++
+			}
+			if len(schedule.Entries) == 0 {
+				continue
+			}
+			forecast.OutstandingBalance += schedule.CurrentBalance
+
+			for _, entry := range schedule.Entries {
+				if !entry.Date.After(now) {
+					continue
+				}
+				forecast.RemainingInterest += entry.Interest
+				index := monthIndex(entry.Date)
+				if index < 0 || index >= months {
+					continue
+				}
+				month := &forecast.Months[index]
+				month.FinancePayments += entry.Payment
+				month.Interest += entry.Interest
+				month.Principal += entry.Principal
+			}
+			// Restschuld am Monatsende: letzte Rate bis dahin, sonst die heutige Restschuld
+			for i := range forecast.Months {
+				monthEnd := firstMonth.AddDate(0, i+1, 0)
+				balance := schedule.CurrentBalance
+				for _, entry := range schedule.Entries {
+					if entry.Date.After(now) && entry.Date.Before(monthEnd) {
+						balance = entry.RemainingBalance
+					}
+				}
+				forecast.Months[i].OutstandingBalance += balance
+				if balance > 0 {
+					forecast.Months[i].ActiveContracts++
+				}
+			}
+
+		case model.AcquisitionTypeLeased:
+			if vehicle.LeaseMonthlyRate <= 0 || vehicle.LeaseStartDate.IsZero() || vehicle.LeaseEndDate.IsZero() {
+				continue
+			}
+			first := monthIndex(vehicle.LeaseStartDate)
+			for i := range forecast.Months {
+				if i < first || !firstMonth.AddDate(0, i, 0).Before(vehicle.LeaseEndDate) {
+					continue
+				}
+				forecast.Months[i].LeasePayments += vehicle.LeaseMonthlyRate
+				forecast.Months[i].ActiveContracts++
+			}
+		}
+	}
+
+	for i := range forecast.Months {
+		month := &forecast.Months[i]
+		month.FinancePayments = roundCents(month.FinancePayments)
+		month.Interest = roundCents(month.Interest)
+		month.Principal = roundCents(month.Principal)
+		month.LeasePayments = roundCents(month.LeasePayments)
+		month.OutstandingBalance = roundCents(month.OutstandingBalance)
+		month.TotalPayments = roundCents(month.FinancePayments + month.LeasePayments)
+		forecast.TotalPayments += month.TotalPayments
+	}
+	forecast.TotalPayments = roundCents(forecast.TotalPayments)
+	forecast.OutstandingBalance = roundCents(forecast.OutstandingBalance)
+	forecast.RemainingInterest = roundCents(forecast.RemainingInterest)
+	return forecast, nil
+}
+
+// BuildAmortizationSchedule erstellt den Tilgungsplan aus den Finanzierungsdaten eines Fahrzeugs.
+// Fehlt die Monatsrate, wird sie aus Betrag, Zins und Laufzeit berechnet; deckt die Rate den Betrag
+// bis Vertragsende nicht, bleibt eine Schlussrate. Unstimmigkeiten werden im Plan vermerkt.
+func BuildAmortizationSchedule(vehicle *model.Vehicle, now time.Time) *model.AmortizationSchedule {
+	schedule := &model.AmortizationSchedule{
+		VehicleID:    vehicle.ID,
+		LicensePlate: vehicle.LicensePlate,
+		Brand:        vehicle.Brand,
+		Model:        vehicle.Model,
+		Bank:         vehicle.FinanceBank,
+		Principal:    vehicle.FinanceTotalAmount,
+		DownPayment:  vehicle.FinanceDownPayment,
+		InterestRate: vehicle.FinanceInterestRate,
+		MonthlyRate:  vehicle.FinanceMonthlyRate,
+		StartDate:    vehicle.FinanceStartDate,
+		EndDate:      vehicle.FinanceEndDate,
+		Entries:      []model.AmortizationEntry{},
+		Issues:       []model.FinancingIssue{},
+	}
+	addIssue := func(code string, severity model.FinancingIssueSeverity, format string, args ...interface{}) {
+		schedule.Issues = append(schedule.Issues, model.FinancingIssue{
+			VehicleID:    vehicle.ID,
+			LicensePlate: vehicle.LicensePlate,
+			Code:         code,
+			Severity:     severity,
+			Message:      fmt.Sprintf(format, args...),
+		})
+	}
+
+	principal := vehicle.FinanceTotalAmount
+	start := vehicle.FinanceStartDate
+	if principal <= 0 {
+		addIssue("missing_amount", model.FinancingIssueError, "Kein Finanzierungsbetrag hinterlegt")
+	}
+	if start.IsZero() {
+		addIssue("missing_start", model.FinancingIssueError, "Kein Finanzierungsbeginn hinterlegt")
+	}
+	term := 0
+	if !vehicle.FinanceEndDate.IsZero() && !start.IsZero() {
+		if vehicle.FinanceEndDate.After(start) {
+			term = monthsBetween(start, vehicle.FinanceEndDate)
+		} else {
+			addIssue("invalid_term", model.FinancingIssueError, "Das Vertragsende liegt vor dem Finanzierungsbeginn")
+		}
+	}
+	if vehicle.FinanceInterestRate < 0 {
+		addIssue("invalid_interest_rate", model.FinancingIssueError, "Der Zinssatz ist negativ")
+	} else if vehicle.FinanceInterestRate > maxPlausibleInterestRate {
+		addIssue("high_interest_rate", model.FinancingIssueWarning, "Ungewöhnlich hoher Zinssatz von %.2f %%", vehicle.FinanceInterestRate)
+	}
+
+	monthlyInterest := vehicle.FinanceInterestRate / 100 / 12
+	rate := vehicle.FinanceMonthlyRate
+	if rate <= 0 && term > 0 && principal > 0 {
+		rate = roundCents(annuityRate(principal, monthlyInterest, term))
+		schedule.MonthlyRate = rate
+		schedule.RateCalculated = true
+	}
+	if rate <= 0 && principal > 0 && !start.IsZero() {
+		addIssue("missing_rate", model.FinancingIssueError, "Weder Monatsrate noch Vertragsende hinterlegt")
+	}
+	if rate > 0 && principal > 0 && rate <= roundCents(principal*monthlyInterest) {
+		addIssue("rate_below_interest", model.FinancingIssueError, "Die Monatsrate von %.2f € deckt nicht einmal die Zinsen von %.2f €", rate, principal*monthlyInterest)
+	}
+	for _, issue := range schedule.Issues {
+		if issue.Severity == model.FinancingIssueError {
+			return schedule
+		}
+	}
+
+	// Plausibilität von Rate, Betrag und Laufzeit
+	if term > 0 && !schedule.RateCalculated {
+		expected := annuityRate(principal, monthlyInterest, term)
+		sumOfRates := rate * float64(term)
+		switch {
+		case monthlyInterest == 0 && sumOfRates > principal*(1+financeRateTolerance):
+			addIssue("missing_interest_rate", model.FinancingIssueWarning,
+				"Kein Zinssatz hinterlegt, die Raten übersteigen den Finanzierungsbetrag um %.2f €", sumOfRates-principal)
+		case monthlyInterest > 0 && math.Abs(sumOfRates-principal) <= principal*financeRateTolerance:
+			addIssue("amount_is_sum_of_rates", model.FinancingIssueWarning,
+				"Der Gesamtbetrag entspricht der Summe aller Raten; erwartet wird der Darlehensbetrag ohne Zinsen")
+		case math.Abs(rate-expected) > math.Max(1, expected*financeRateTolerance):
+			if rate > expected {
+				addIssue("rate_mismatch", model.FinancingIssueWarning,
+					"Die Rate von %.2f € tilgt den Betrag vor Vertragsende; bei %d Monaten Laufzeit wären %.2f € zu erwarten", rate, term, expected)
+			} else {
+				addIssue("final_payment", model.FinancingIssueWarning,
+					"Die Rate von %.2f € tilgt den Betrag bis Vertragsende nicht, es bleibt eine Schlussrate; für volle Tilgung wären %.2f € zu erwarten", rate, expected)
+			}
+		}
+	}
+
+	balance := principal
+	for number := 1; balance > 0.005 && number <= maxInstallments; number++ {
+		entry := model.AmortizationEntry{
+			Number:   number,
+			Date:     start.AddDate(0, number, 0),
+			Payment:  rate,
+			Interest: roundCents(balance * monthlyInterest),
+		}
+		entry.Principal = roundCents(entry.Payment - entry.Interest)
+		lastByTerm := term > 0 && number == term
+		if entry.Principal >= balance || lastByTerm {
+			entry.Principal = roundCents(balance)
+			entry.Payment = roundCents(entry.Principal + entry.Interest)
+			// Rundungsreste der letzten Rate gelten nicht als Schlussrate
+			entry.FinalPayment = lastByTerm && entry.Payment-rate > math.Max(1, rate*financeRateTolerance)
+		}
+		balance = roundCents(balance - entry.Principal)
+		entry.RemainingBalance = balance
+		schedule.Entries = append(schedule.Entries, entry)
+	}
+
+	schedule.TermMonths = len(schedule.Entries)
+	schedule.CurrentBalance = principal
+	for _, entry := range schedule.Entries {
+		schedule.TotalPayments += entry.Payment
+		schedule.TotalInterest += entry.Interest
+		if entry.FinalPayment {
+			schedule.FinalPayment = roundCents(entry.Payment - rate)
+		}
+		if !entry.Date.After(now) {
+			schedule.PaidInstallments++
+			schedule.CurrentBalance = entry.RemainingBalance
+		} else if schedule.NextPaymentDate == nil {
+			date := entry.Date
+			schedule.NextPaymentDate = &date
+		}
+	}
+	schedule.TotalPayments = roundCents(schedule.TotalPayments)
+	schedule.TotalInterest = roundCents(schedule.TotalInterest)
+	if schedule.EndDate.IsZero() && len(schedule.Entries) > 0 {
+		schedule.EndDate = schedule.Entries[len(schedule.Entries)-1].Date
+	}
+	return schedule
+}
+
+// monthsBetween liefert die Anzahl voller Kalendermonate zwischen zwei Daten, mindestens 1
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	if months < 1 {
+		return 1
+	}
+	return months
+}
+
+// annuityRate berechnet die Monatsrate eines Annuitätendarlehens
+func annuityRate(principal, monthlyInterest float64, months int) float64 {
+	if monthlyInterest == 0 {
+		return principal / float64(months)
+	}
+	factor := math.Pow(1+monthlyInterest, float64(months))
+	return principal * monthlyInterest * factor / (factor - 1)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAnnuityRate(t *testing.T) {
+	tests := []struct {
+		name            string
+		principal       float64
+		monthlyInterest float64
+		months          int
+		want            float64
+	}{
+		{"zinsfrei", 12000, 0, 12, 1000},
+		{"zinsfrei mit rest", 20000, 0, 48, 416.67},
+		{"sechs prozent ein jahr", 10000, 0.06 / 12, 12, 860.66},
+		{"vier prozent fünf jahre", 30000, 0.04 / 12, 60, 552.50},
+		{"ein monat", 5000, 0.01, 1, 5050},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundCents(annuityRate(tt.principal, tt.monthlyInterest, tt.months)); got != tt.want {
+				t.Errorf("annuityRate = %.2f, erwartet %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       int
+	}{
+		{"ein jahr", date(2024, 1, 15), date(2025, 1, 15), 12},
+		{"angebrochener monat zählt nicht", date(2024, 1, 15), date(2024, 3, 14), 1},
+		{"über den jahreswechsel", date(2024, 11, 1), date(2025, 2, 1), 3},
+		{"mindestens ein monat", date(2024, 1, 15), date(2024, 1, 20), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := monthsBetween(tt.start, tt.end); got != tt.want {
+				t.Errorf("monthsBetween = %d, erwartet %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAmortizationSchedule(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		vehicle           model.Vehicle
+		wantIssues        []string
+		wantRate          float64
+		wantCalculated    bool
+		wantEntries       int
+		wantTotalInterest float64
+		wantFinalPayment  bool
+		wantPaid          int
+		wantBalance       float64
+	}{
+		{
+			name: "zinsfrei, rate aus der laufzeit",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 12000,
+				FinanceStartDate:   start,
+				FinanceEndDate:     start.AddDate(1, 0, 0),
+			},
+			wantIssues:     []string{},
+			wantRate:       1000,
+			wantCalculated: true,
+			wantEntries:    12,
+			wantPaid:       5,
+			wantBalance:    7000,
+		},
+		{
+			name: "annuität mit zins, rate aus der laufzeit",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount:  10000,
+				FinanceInterestRate: 6,
+				FinanceStartDate:    start,
+				FinanceEndDate:      start.AddDate(1, 0, 0),
+			},
+			wantIssues:        []string{},
+			wantRate:          860.66,
+			wantCalculated:    true,
+			wantEntries:       12,
+			wantTotalInterest: 327.96,
+			wantPaid:          5,
+			wantBalance:       5905.96,
+		},
+		{
+			name: "rate ohne vertragsende läuft bis zur tilgung",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 1000,
+				FinanceMonthlyRate: 300,
+				FinanceStartDate:   start,
+			},
+			wantIssues:  []string{},
+			wantRate:    300,
+			wantEntries: 4,
+			wantPaid:    4,
+			wantBalance: 0,
+		},
+		{
+			name: "zu niedrige rate ergibt eine schlussrate",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount:  20000,
+				FinanceInterestRate: 3.6,
+				FinanceMonthlyRate:  300,
+				FinanceStartDate:    start,
+				FinanceEndDate:      start.AddDate(3, 0, 0),
+			},
+			wantIssues:        []string{"final_payment"},
+			wantRate:          300,
+			wantEntries:       36,
+			wantTotalInterest: 1690.58,
+			wantFinalPayment:  true,
+			wantPaid:          5,
+			wantBalance:       18792.78,
+		},
+		{
+			name: "zinsfreie raten über dem betrag",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 12000,
+				FinanceMonthlyRate: 1100,
+				FinanceStartDate:   start,
+				FinanceEndDate:     start.AddDate(1, 0, 0),
+			},
+			wantIssues:  []string{"missing_interest_rate"},
+			wantRate:    1100,
+			wantEntries: 11,
+			wantPaid:    5,
+			wantBalance: 6500,
+		},
+		{
+			name: "gesamtbetrag ist die summe der raten",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount:  12000,
+				FinanceInterestRate: 5,
+				FinanceMonthlyRate:  1000,
+				FinanceStartDate:    start,
+				FinanceEndDate:      start.AddDate(1, 0, 0),
+			},
+			wantIssues:        []string{"amount_is_sum_of_rates"},
+			wantRate:          1000,
+			wantEntries:       12,
+			wantTotalInterest: 335.10,
+			wantFinalPayment:  true,
+			wantPaid:          5,
+			wantBalance:       7210.26,
+		},
+		{
+			name:       "ohne betrag und beginn",
+			vehicle:    model.Vehicle{FinanceMonthlyRate: 300},
+			wantIssues: []string{"missing_amount", "missing_start"},
+			wantRate:   300,
+		},
+		{
+			name: "vertragsende vor beginn",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 12000,
+				FinanceMonthlyRate: 1000,
+				FinanceStartDate:   start,
+				FinanceEndDate:     start.AddDate(0, -1, 0),
+			},
+			wantIssues: []string{"invalid_term"},
+			wantRate:   1000,
+		},
+		{
+			name: "weder rate noch vertragsende",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 12000,
+				FinanceStartDate:   start,
+			},
+			wantIssues: []string{"missing_rate"},
+		},
+		{
+			name: "rate deckt die zinsen nicht",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount:  12000,
+				FinanceInterestRate: 12,
+				FinanceMonthlyRate:  100,
+				FinanceStartDate:    start,
+			},
+			wantIssues: []string{"rate_below_interest"},
+			wantRate:   100,
+		},
+		{
+			name: "negativer zinssatz",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount:  12000,
+				FinanceInterestRate: -1,
+				FinanceMonthlyRate:  1000,
+				FinanceStartDate:    start,
+			},
+			wantIssues: []string{"invalid_interest_rate"},
+			wantRate:   1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := BuildAmortizationSchedule(&tt.vehicle, now)
+
+			codes := make([]string, 0, len(schedule.Issues))
+			for _, issue := range schedule.Issues {
+				codes = append(codes, issue.Code)
+			}
+			if len(codes) != len(tt.wantIssues) {
+				t.Fatalf("hinweise %v, erwartet %v", codes, tt.wantIssues)
+			}
+			for i := range codes {
+				if codes[i] != tt.wantIssues[i] {
+					t.Fatalf("hinweise %v, erwartet %v", codes, tt.wantIssues)
+				}
+			}
+
+			if schedule.MonthlyRate != tt.wantRate {
+				t.Errorf("MonthlyRate = %.2f, erwartet %.2f", schedule.MonthlyRate, tt.wantRate)
+			}
+			if schedule.RateCalculated != tt.wantCalculated {
+				t.Errorf("RateCalculated = %v, erwartet %v", schedule.RateCalculated, tt.wantCalculated)
+			}
+			if len(schedule.Entries) != tt.wantEntries {
+				t.Fatalf("%d raten, erwartet %d", len(schedule.Entries), tt.wantEntries)
+			}
+			if tt.wantEntries == 0 {
+				return
+			}
+
+			last := schedule.Entries[len(schedule.Entries)-1]
+			if last.RemainingBalance != 0 {
+				t.Errorf("restschuld nach der letzten rate %.2f, erwartet 0", last.RemainingBalance)
+			}
+			if last.FinalPayment != tt.wantFinalPayment {
+				t.Errorf("FinalPayment der letzten rate = %v, erwartet %v", last.FinalPayment, tt.wantFinalPayment)
+			}
+			if tt.wantFinalPayment && schedule.FinalPayment <= 0 {
+				t.Errorf("schlussrate %.2f, erwartet einen positiven betrag", schedule.FinalPayment)
+			}
+
+			principal := 0.0
+			for _, entry := range schedule.Entries {
+				principal += entry.Principal
+			}
+			if math.Abs(principal-tt.vehicle.FinanceTotalAmount) > 0.005 {
+				t.Errorf("summe der tilgung %.2f, erwartet %.2f", principal, tt.vehicle.FinanceTotalAmount)
+			}
+			if schedule.TotalInterest != tt.wantTotalInterest {
+				t.Errorf("TotalInterest = %.2f, erwartet %.2f", schedule.TotalInterest, tt.wantTotalInterest)
+			}
+			if want := roundCents(tt.vehicle.FinanceTotalAmount + tt.wantTotalInterest); schedule.TotalPayments != want {
+				t.Errorf("TotalPayments = %.2f, erwartet %.2f", schedule.TotalPayments, want)
+			}
+			if schedule.PaidInstallments != tt.wantPaid {
+				t.Errorf("PaidInstallments = %d, erwartet %d", schedule.PaidInstallments, tt.wantPaid)
+			}
+			if schedule.CurrentBalance != tt.wantBalance {
+				t.Errorf("CurrentBalance = %.2f, erwartet %.2f", schedule.CurrentBalance, tt.wantBalance)
+			}
+		})
+	}
+}
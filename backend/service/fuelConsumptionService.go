@@ -0,0 +1,412 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// highConsumptionFactor ist der Faktor über dem üblichen Verbrauch, ab dem ein Intervall auffällig ist
+	highConsumptionFactor = 1.3
+	// minBaselineIntervals ist die Mindestanzahl an Intervallen für einen belastbaren Vergleichswert
+	minBaselineIntervals = 3
+	// minAnomalyDistance ist die Mindeststrecke eines Intervalls für die Verbrauchsprüfung, kürzere Strecken schwanken zu stark
+	minAnomalyDistance = 50
+	// tankCapacityTolerance berücksichtigt Messtoleranzen der Zapfsäule und Einfüllstutzen
+	tankCapacityTolerance = 1.05
+)
+
+// FuelConsumptionService berechnet Verbräuche aus den Tankkosten und erkennt Auffälligkeiten.
+// Der Verbrauch wird von Tankvorgang zu Tankvorgang ermittelt (Volltankmethode): die Menge
+// eines Tankvorgangs wird der Strecke seit dem vorherigen Tankvorgang mit Kilometerstand zugerechnet.
+type FuelConsumptionService struct {
+	vehicleRepo  repository.VehicleRepository
+	fuelCostRepo repository.FuelCostRepository
+}
+
+// NewFuelConsumptionService erstellt einen neuen FuelConsumptionService
+func NewFuelConsumptionService(vehicleRepo repository.VehicleRepository, fuelCostRepo repository.FuelCostRepository) *FuelConsumptionService {
+	return &FuelConsumptionService{
+		vehicleRepo:  vehicleRepo,
+		fuelCostRepo: fuelCostRepo,
+	}
+}
+
+// GetVehicleConsumption liefert Verbrauchsintervalle, Monatstrend und Auffälligkeiten eines Fahrzeugs
+func (s *FuelConsumptionService) GetVehicleConsumption(vehicleID string) (*model.VehicleFuelConsumption, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	fuelCosts, err := s.fuelCostRepo.FindByVehicle(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der tankkosten: %v", err)
+	}
+
+	return analyzeFuelConsumption(vehicle, fuelCosts), nil
+}
+
+// GetFleetConsumption liefert die Verbrauchsauswertung aller Fahrzeuge
+func (s *FuelConsumptionService) GetFleetConsumption() ([]*model.VehicleFuelConsumption, error) {
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der fahrzeuge: %v", err)
+	}
+
+	fuelCosts, err := s.fuelCostRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der tankkosten: %v", err)
+	}
+
+	byVehicle := make(map[primitive.ObjectID][]*model.FuelCost)
+	for _, fuelCost := range fuelCosts {
+		byVehicle[fuelCost.VehicleID] = append(byVehicle[fuelCost.VehicleID], fuelCost)
+	}
+
+	results := make([]*model.VehicleFuelConsumption, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		results = append(results, analyzeFuelConsumption(vehicle, byVehicle[vehicle.ID]))
+	}
+	return results, nil
+}
+
+// CompareFleet vergleicht den Durchschnittsverbrauch nach Marke, Modell oder Kraftstoffart.
+// Fahrzeuge ohne auswertbare Tankvorgänge werden nicht berücksichtigt.
+func (s *FuelConsumptionService) CompareFleet(groupBy string) ([]*model.FleetConsumptionGroup, error) {
+	if groupBy != "brand" && groupBy != "model" && groupBy != "fuelType" {
+		return nil, fmt.Errorf("ungültige gruppierung, erlaubt sind brand, model und fuelType")
+	}
+
+	results, err := s.GetFleetConsumption()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*model.FleetConsumptionGroup)
+	for _, result := range results {
+		if result.TotalDistance == 0 {
+			continue
+		}
+
+		var key string
+		switch groupBy {
+		case "brand":
+			key = result.Brand
+		case "model":
+			key = strings.TrimSpace(result.Brand + " " + result.Model)
+		case "fuelType":
+			key = string(result.FuelType)
+		}
+		if key == "" {
+			key = "Unbekannt"
+		}
+
+		// Liter und kWh lassen sich nicht gemeinsam mitteln
+		groupKey := key + "|" + result.Unit
+		group, exists := groups[groupKey]
+		if !exists {
+			group = &model.FleetConsumptionGroup{Key: key, Unit: result.Unit, MinConsumption: result.AverageConsumption}
+			groups[groupKey] = group
+		}
+
+		group.VehicleCount++
+		group.TotalDistance += result.TotalDistance
+		group.TotalAmount += result.TotalAmount
+		if result.AverageConsumption < group.MinConsumption {
+			group.MinConsumption = result.AverageConsumption
+		}
+		if result.AverageConsumption > group.MaxConsumption {
+			group.MaxConsumption = result.AverageConsumption
+		}
+	}
+
+	comparison := make([]*model.FleetConsumptionGroup, 0, len(groups))
+	for _, group := range groups {
+		group.TotalAmount = roundCents(group.TotalAmount)
+		group.AverageConsumption = roundCents(group.TotalAmount / float64(group.TotalDistance) * 100)
+		comparison = append(comparison, group)
+	}
+	sort.Slice(comparison, func(i, j int) bool {
+		if comparison[i].Key != comparison[j].Key {
+			return comparison[i].Key < comparison[j].Key
+		}
+		return comparison[i].Unit < comparison[j].Unit
+	})
+	return comparison, nil
+}
+
+// GetAnomalies liefert alle Auffälligkeiten im Zeitraum, neueste zuerst. Leere Grenzen werden nicht geprüft.
+func (s *FuelConsumptionService) GetAnomalies(from, to *time.Time) ([]model.FuelAnomaly, error) {
+	results, err := s.GetFleetConsumption()
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := []model.FuelAnomaly{}
+	for _, result := range results {
+		for _, anomaly := range result.Anomalies {
+			if from != nil && anomaly.Date.Before(*from) {
+				continue
+			}
+			if to != nil && !anomaly.Date.Before(*to) {
+				continue
+			}
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool { return anomalies[i].Date.After(anomalies[j].Date) })
+	return anomalies, nil
+}
+
+// analyzeFuelConsumption wertet die Tankvorgänge eines Fahrzeugs aus
+func analyzeFuelConsumption(vehicle *model.Vehicle, fuelCosts []*model.FuelCost) *model.VehicleFuelConsumption {
+	result := &model.VehicleFuelConsumption{
+		VehicleID:    vehicle.ID,
+		LicensePlate: vehicle.LicensePlate,
+		Brand:        vehicle.Brand,
+		Model:        vehicle.Model,
+		FuelType:     vehicle.FuelType,
+		Unit:         model.ConsumptionUnit(consumptionFuelType(vehicle.FuelType)),
+		Intervals:    []model.FuelConsumptionInterval{},
+		Trend:        []model.FuelConsumptionTrendPoint{},
+		Anomalies:    []model.FuelAnomaly{},
+	}
+
+	sorted := make([]*model.FuelCost, len(fuelCosts))
+	copy(sorted, fuelCosts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].Mileage < sorted[j].Mileage
+	})
+
+	anomaly := func(anomalyType model.FuelAnomalyType, fuelCost *model.FuelCost, message string, value, expected float64) {
+		result.Anomalies = append(result.Anomalies, model.FuelAnomaly{
+			Type:         anomalyType,
+			TypeText:     model.FuelAnomalyTypeText(anomalyType),
+			VehicleID:    vehicle.ID,
+			LicensePlate: vehicle.LicensePlate,
+			FuelCostID:   fuelCost.ID,
+			Date:         fuelCost.Date,
+			Message:      message,
+			Value:        value,
+			Expected:     expected,
+		})
+	}
+
+	capacity := plausibleTankCapacity(vehicle)
+	var lastMileage int          // Höchster bisher gesehener Kilometerstand
+	var previous *model.FuelCost // Letzter Tankvorgang mit Kilometerstand, der in den Verbrauch eingeht
+	var pending float64          // Menge von Tankvorgängen ohne Kilometerstand seit previous
+
+	for _, fuelCost := range sorted {
+		rolledBack := fuelCost.Mileage > 0 && fuelCost.Mileage < lastMileage
+		if rolledBack {
+			anomaly(model.FuelAnomalyOdometerRollback, fuelCost,
+				fmt.Sprintf("Kilometerstand %d km liegt unter dem vorherigen Stand von %d km", fuelCost.Mileage, lastMileage),
+				float64(fuelCost.Mileage), float64(lastMileage))
+		} else if fuelCost.Mileage > lastMileage {
+			lastMileage = fuelCost.Mileage
+		}
+
+		if fuelCost.FuelType != "" && vehicle.FuelType != "" && !fuelTypeFitsVehicle(vehicle.FuelType, fuelCost.FuelType) {
+			anomaly(model.FuelAnomalyFuelTypeMismatch, fuelCost,
+				fmt.Sprintf("%s getankt, Fahrzeug fährt mit %s", fuelCost.FuelType, vehicle.FuelType), 0, 0)
+			continue
+		}
+
+		if !countsTowardsConsumption(vehicle.FuelType, fuelCost.FuelType) {
+			continue // z.B. Ladevorgänge eines Plug-in-Hybrids
+		}
+
+		if capacity > 0 && fuelCost.Amount > capacity*tankCapacityTolerance {
+			anomaly(model.FuelAnomalyTankOverfill, fuelCost,
+				fmt.Sprintf("%.2f %s getankt, Tankinhalt %.0f %s", fuelCost.Amount, result.Unit, capacity, result.Unit),
+				fuelCost.Amount, capacity)
+		}
+
+		// Ohne verwertbaren Kilometerstand zählt die Menge zum nächsten Intervall
+		if rolledBack || fuelCost.Mileage == 0 || (previous != nil && fuelCost.Mileage == previous.Mileage) {
+			pending += fuelCost.Amount
+			continue
+		}
+		if previous != nil {
+			distance := fuelCost.Mileage - previous.Mileage
+			amount := pending + fuelCost.Amount
+			result.Intervals = append(result.Intervals, model.FuelConsumptionInterval{
+				FuelCostID:  fuelCost.ID,
+				FromDate:    previous.Date,
+				ToDate:      fuelCost.Date,
+				FromMileage: previous.Mileage,
+				ToMileage:   fuelCost.Mileage,
+				Distance:    distance,
+				Amount:      roundCents(amount),
+				Consumption: roundCents(amount / float64(distance) * 100),
+			})
+			result.TotalDistance += distance
+			result.TotalAmount += amount
+		}
+		previous = fuelCost
+		pending = 0
+	}
+
+	if result.TotalDistance > 0 {
+		result.TotalAmount = roundCents(result.TotalAmount)
+		result.AverageConsumption = roundCents(result.TotalAmount / float64(result.TotalDistance) * 100)
+	}
+
+	if len(result.Intervals) >= minBaselineIntervals {
+		result.BaselineConsumption = medianConsumption(result.Intervals)
+		limit := result.BaselineConsumption * highConsumptionFactor
+		byID := make(map[primitive.ObjectID]*model.FuelCost, len(sorted))
+		for _, fuelCost := range sorted {
+			byID[fuelCost.ID] = fuelCost
+		}
+		for _, interval := range result.Intervals {
+			if interval.Distance < minAnomalyDistance || interval.Consumption <= limit {
+				continue
+			}
+			anomaly(model.FuelAnomalyHighConsumption, byID[interval.FuelCostID],
+				fmt.Sprintf("%.2f %s/100 km auf %d km, üblich sind %.2f %s/100 km",
+					interval.Consumption, result.Unit, interval.Distance, result.BaselineConsumption, result.Unit),
+				interval.Consumption, result.BaselineConsumption)
+		}
+	}
+
+	result.Trend = consumptionTrend(result.Intervals)
+	result.TrendSlope = trendSlope(result.Trend)
+
+	sort.SliceStable(result.Anomalies, func(i, j int) bool { return result.Anomalies[i].Date.Before(result.Anomalies[j].Date) })
+	return result
+}
+
+// consumptionFuelType liefert die Kraftstoffart, deren Verbrauch für ein Fahrzeug ausgewertet wird
+func consumptionFuelType(vehicleFuelType model.FuelType) model.FuelType {
+	switch vehicleFuelType {
+	case model.FuelTypeHybridGas:
+		return model.FuelTypeGasoline
+	case model.FuelTypeHybridDiesel:
+		return model.FuelTypeDiesel
+	default:
+		return vehicleFuelType
+	}
+}
+
+// countsTowardsConsumption prüft, ob ein Tankvorgang in den Verbrauch des Fahrzeugs eingeht.
+// Bei Hybriden zählt nur der Kraftstoff, Ladevorgänge bleiben außen vor.
+func countsTowardsConsumption(vehicleFuelType, fuelType model.FuelType) bool {
+	if vehicleFuelType == "" || fuelType == "" {
+		return true
+	}
+	return fuelType == vehicleFuelType || fuelType == consumptionFuelType(vehicleFuelType)
+}
+
+// fuelTypeFitsVehicle prüft, ob eine Kraftstoffart für ein Fahrzeug zulässig ist
+func fuelTypeFitsVehicle(vehicleFuelType, fuelType model.FuelType) bool {
+	if fuelType == vehicleFuelType {
+		return true
+	}
+	switch vehicleFuelType {
+	case model.FuelTypeHybridGas:
+		return fuelType == model.FuelTypeGasoline || fuelType == model.FuelTypeElectric
+	case model.FuelTypeHybridDiesel:
+		return fuelType == model.FuelTypeDiesel || fuelType == model.FuelTypeElectric
+	default:
+		return false
+	}
+}
+
+// plausibleTankCapacity liefert die größte plausible Tankmenge eines Fahrzeugs.
+// Ohne hinterlegten Tankinhalt wird für Pkw ein großzügiger Richtwert angenommen,
+// für schwere Fahrzeuge wird nicht geprüft (0).
+func plausibleTankCapacity(vehicle *model.Vehicle) float64 {
+	if vehicle.TankCapacity > 0 {
+		return vehicle.TankCapacity
+	}
+	if vehicle.GrossWeight > 3500 {
+		return 0
+	}
+	switch consumptionFuelType(vehicle.FuelType) {
+	case model.FuelTypeGasoline, model.FuelTypeDiesel:
+		return 100
+	case model.FuelTypeElectric:
+		return 120
+	case model.FuelTypeHydrogen:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// medianConsumption liefert den Median der Intervallverbräuche als robusten Vergleichswert
+func medianConsumption(intervals []model.FuelConsumptionInterval) float64 {
+	values := make([]float64, len(intervals))
+	for i, interval := range intervals {
+		values[i] = interval.Consumption
+	}
+	sort.Float64s(values)
+
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+	return roundCents((values[middle-1] + values[middle]) / 2)
+}
+
+// consumptionTrend fasst die Intervalle nach dem Monat ihres Endes zusammen
+func consumptionTrend(intervals []model.FuelConsumptionInterval) []model.FuelConsumptionTrendPoint {
+	trend := []model.FuelConsumptionTrendPoint{}
+	index := make(map[string]int)
+	for _, interval := range intervals {
+		month := interval.ToDate.Format("2006-01")
+		i, exists := index[month]
+		if !exists {
+			i = len(trend)
+			index[month] = i
+			trend = append(trend, model.FuelConsumptionTrendPoint{Month: month})
+		}
+		trend[i].Distance += interval.Distance
+		trend[i].Amount += interval.Amount
+	}
+
+	for i := range trend {
+		trend[i].Amount = roundCents(trend[i].Amount)
+		trend[i].Consumption = roundCents(trend[i].Amount / float64(trend[i].Distance) * 100)
+	}
+	sort.Slice(trend, func(i, j int) bool { return trend[i].Month < trend[j].Month })
+	return trend
+}
+
+// trendSlope berechnet die Steigung der Regressionsgeraden des Monatsverbrauchs (Veränderung je Monat)
+func trendSlope(trend []model.FuelConsumptionTrendPoint) float64 {
+	if len(trend) < 2 {
+		return 0
+	}
+
+	first, _ := time.Parse("2006-01", trend[0].Month)
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range trend {
+		month, _ := time.Parse("2006-01", point.Month)
+		x := float64((month.Year()-first.Year())*12 + int(month.Month()) - int(first.Month()))
+		sumX += x
+		sumY += point.Consumption
+		sumXY += x * point.Consumption
+		sumXX += x * x
+	}
+
+	n := float64(len(trend))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return roundCents((n*sumXY - sumX*sumY) / denominator)
+}
@@ -0,0 +1,802 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrFuelImportNotFound wird für unbekannte Importe, Zeilen oder Anbieterformate zurückgegeben
+	ErrFuelImportNotFound = errors.New("import nicht gefunden")
+	// ErrFuelImportState wird zurückgegeben, wenn ein Import oder eine Zeile im aktuellen Status nicht bearbeitet werden kann
+	ErrFuelImportState = errors.New("der import bzw. die zeile kann in diesem status nicht bearbeitet werden")
+)
+
+// FuelImportResult ist ein Import mit allen Zeilen, z.B. als Vorschau vor der Übernahme
+type FuelImportResult struct {
+	Batch *model.FuelImportBatch `json:"batch"`
+	Rows  []*model.FuelImportRow `json:"rows"`
+}
+
+// FuelImportService importiert Tankkartenauszüge als Tankkosten. Ein Upload erzeugt zunächst eine Vorschau;
+// erst die Übernahme legt Tankkosten an. Zeilen ohne Fahrzeug landen in einer Prüfliste.
+type FuelImportService struct {
+	importRepo          repository.FuelImportRepository
+	fuelCostRepo        repository.FuelCostRepository
+	vehicleRepo         repository.VehicleRepository
+	mileageService      *VehicleMileageService
+	notificationService *NotificationService
+	activityService     *ActivityService
+}
+
+// NewFuelImportService erstellt einen neuen FuelImportService
+func NewFuelImportService(importRepo repository.FuelImportRepository, fuelCostRepo repository.FuelCostRepository, vehicleRepo repository.VehicleRepository, mileageService *VehicleMileageService, notificationService *NotificationService, activityService *ActivityService) *FuelImportService {
+	return &FuelImportService{
+		importRepo:          importRepo,
+		fuelCostRepo:        fuelCostRepo,
+		vehicleRepo:         vehicleRepo,
+		mileageService:      mileageService,
+		notificationService: notificationService,
+		activityService:     activityService,
+	}
+}
+
+// GetProviders liefert alle Anbieterformate
+func (s *FuelImportService) GetProviders() ([]*model.FuelCardProvider, error) {
+	return s.importRepo.FindAllProviders()
+}
+
+// CreateProvider legt ein neues Anbieterformat an
+func (s *FuelImportService) CreateProvider(provider *model.FuelCardProvider) error {
+	if err := validateFuelCardProvider(provider); err != nil {
+		return err
+	}
+	return s.importRepo.CreateProvider(provider)
+}
+
+// UpdateProvider aktualisiert ein Anbieterformat
+func (s *FuelImportService) UpdateProvider(id string, provider *model.FuelCardProvider) error {
+	existing, err := s.importRepo.FindProviderByID(id)
+	if err != nil {
+		return ErrFuelImportNotFound
+	}
+	if err := validateFuelCardProvider(provider); err != nil {
+		return err
+	}
+	provider.ID = existing.ID
+	provider.CreatedAt = existing.CreatedAt
+	return s.importRepo.UpdateProvider(provider)
+}
+
+// DeleteProvider löscht ein Anbieterformat
+func (s *FuelImportService) DeleteProvider(id string) error {
+	if _, err := s.importRepo.FindProviderByID(id); err != nil {
+		return ErrFuelImportNotFound
+	}
+	return s.importRepo.DeleteProvider(id)
+}
+
+// Preview liest einen Tankkartenauszug ein, ordnet die Zeilen Fahrzeugen zu und erkennt Duplikate.
+// Es werden noch keine Tankkosten angelegt.
+func (s *FuelImportService) Preview(userID primitive.ObjectID, providerID, fileName string, file io.Reader) (*FuelImportResult, error) {
+	provider, err := s.importRepo.FindProviderByID(providerID)
+	if err != nil {
+		return nil, fmt.Errorf("anbieterformat nicht gefunden")
+	}
+
+	rows, err := parseFuelCardCSV(provider, file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("die datei enthält keine tankvorgänge")
+	}
+
+	if err := s.matchRows(rows); err != nil {
+		return nil, err
+	}
+
+	batch := &model.FuelImportBatch{
+		ProviderID: provider.ID,
+		FileName:   fileName,
+		Status:     model.FuelImportBatchPreview,
+		Counts:     countFuelImportRows(rows),
+		CreatedBy:  userID,
+	}
+	if err := s.importRepo.CreateBatch(batch, rows); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern der vorschau: %v", err)
+	}
+
+	return &FuelImportResult{Batch: batch, Rows: rows}, nil
+}
+
+// GetBatch liefert einen Import mit allen Zeilen
+func (s *FuelImportService) GetBatch(id string) (*FuelImportResult, error) {
+	batch, err := s.importRepo.FindBatchByID(id)
+	if err != nil {
+		return nil, ErrFuelImportNotFound
+	}
+	rows, err := s.importRepo.FindRowsByBatch(batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &FuelImportResult{Batch: batch, Rows: rows}, nil
+}
+
+// GetRecentBatches liefert die letzten Importe
+func (s *FuelImportService) GetRecentBatches(limit int) ([]*model.FuelImportBatch, error) {
+	return s.importRepo.FindRecentBatches(limit)
+}
+
+// Commit übernimmt alle zugeordneten Zeilen einer Vorschau als Tankkosten.
+// Nicht zugeordnete Zeilen werden in die Prüfliste gestellt.
+func (s *FuelImportService) Commit(userID primitive.ObjectID, batchID string) (*FuelImportResult, error) {
+	result, err := s.GetBatch(batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status vor der Übernahme umstellen, damit gleichzeitige Aufrufe die Zeilen nicht doppelt übernehmen
+	started, err := s.importRepo.TransitionBatch(result.Batch.ID, model.FuelImportBatchPreview, model.FuelImportBatchCommitting)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim starten der übernahme: %v", err)
+	}
+	if !started {
+		return nil, ErrFuelImportState
+	}
+	result.Batch.Status = model.FuelImportBatchCommitting
+
+	// Zwischen Vorschau und Übernahme können Fahrzeuge oder Tankkosten hinzugekommen sein
+	if err := s.matchRows(result.Rows); err != nil {
+		s.abortCommit(result.Batch)
+		return nil, err
+	}
+
+	vehicles := make(map[primitive.ObjectID]bool)
+	for _, row := range result.Rows {
+		if row.Status == model.FuelImportRowMatched {
+			if err := s.importRow(row); err != nil {
+				log.Printf("Fehler beim Übernehmen der Importzeile %d: %v", row.RowNumber, err)
+				row.Status = model.FuelImportRowInvalid
+				row.Message = fmt.Sprintf("fehler beim speichern: %v", err)
+			} else {
+				vehicles[*row.VehicleID] = true
+			}
+		}
+		if err := s.importRepo.UpdateRow(row); err != nil {
+			s.abortCommit(result.Batch)
+			return nil, fmt.Errorf("fehler beim aktualisieren der importzeile %d: %v", row.RowNumber, err)
+		}
+	}
+
+	now := time.Now()
+	result.Batch.Status = model.FuelImportBatchCommitted
+	result.Batch.CommittedAt = &now
+	result.Batch.Counts = countFuelImportRows(result.Rows)
+	if err := s.importRepo.UpdateBatch(result.Batch); err != nil {
+		s.abortCommit(result.Batch)
+		return nil, fmt.Errorf("fehler beim abschließen des imports: %v", err)
+	}
+
+	for vehicleID := range vehicles {
+		if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicleID.Hex()); err != nil {
+			log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Tankkartenimport: %v", err)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"fuel_import_committed",
+		fmt.Sprintf("Tankkartenimport %s übernommen: %d Tankvorgänge importiert, %d zur Prüfung, %d Duplikate",
+			result.Batch.FileName, result.Batch.Counts.Imported, result.Batch.Counts.Unmatched, result.Batch.Counts.Duplicate),
+		userID,
+		nil,
+	)
+
+	return result, nil
+}
+
+// abortCommit stellt einen Import nach einer abgebrochenen Übernahme wieder auf Vorschau.
+// Bereits übernommene Zeilen behalten ihren Status und werden beim nächsten Versuch übersprungen.
+func (s *FuelImportService) abortCommit(batch *model.FuelImportBatch) {
+	if _, err := s.importRepo.TransitionBatch(batch.ID, model.FuelImportBatchCommitting, model.FuelImportBatchPreview); err != nil {
+		log.Printf("Fehler beim Zurücksetzen des Tankkartenimports %s: %v", batch.ID.Hex(), err)
+	}
+}
+
+// Discard verwirft eine Vorschau
+func (s *FuelImportService) Discard(batchID string) error {
+	batch, err := s.importRepo.FindBatchByID(batchID)
+	if err != nil {
+		return ErrFuelImportNotFound
+	}
+	discarded, err := s.importRepo.TransitionBatch(batch.ID, model.FuelImportBatchPreview, model.FuelImportBatchDiscarded)
+	if err != nil {
+		return err
+	}
+	if !discarded {
+		return ErrFuelImportState
+	}
+	return nil
+}
+
+// GetReviewQueue liefert alle übernommenen Zeilen, denen noch kein Fahrzeug zugeordnet ist
+func (s *FuelImportService) GetReviewQueue() ([]*model.FuelImportRow, error) {
+	rows, err := s.importRepo.FindRowsByStatus(model.FuelImportRowUnmatched)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make(map[primitive.ObjectID]bool)
+	queue := make([]*model.FuelImportRow, 0, len(rows))
+	for _, row := range rows {
+		isCommitted, known := committed[row.BatchID]
+		if !known {
+			batch, err := s.importRepo.FindBatchByID(row.BatchID.Hex())
+			isCommitted = err == nil && batch.Status == model.FuelImportBatchCommitted
+			committed[row.BatchID] = isCommitted
+		}
+		if isCommitted {
+			queue = append(queue, row)
+		}
+	}
+	return queue, nil
+}
+
+// RunReviewReminders ist der Hintergrundjob, der die Manager an offene Zeilen der Prüfliste erinnert
+func (s *FuelImportService) RunReviewReminders(run JobRunContext) error {
+	queue, err := s.GetReviewQueue()
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		return nil
+	}
+	return s.notificationService.NotifyFuelReviewQueue(queue)
+}
+
+// ResolveRow ordnet eine Zeile der Prüfliste manuell einem Fahrzeug zu und übernimmt sie als Tankkosten
+func (s *FuelImportService) ResolveRow(userID primitive.ObjectID, rowID, vehicleID string) (*model.FuelImportRow, error) {
+	row, err := s.reviewRow(rowID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	existing, err := s.existingFuelCosts([]*model.FuelImportRow{row})
+	if err != nil {
+		return nil, err
+	}
+	if isDuplicateFuelCost(row, vehicle.ID, existing) {
+		row.VehicleID = &vehicle.ID
+		row.Status = model.FuelImportRowDuplicate
+		row.Message = "bereits als tankkosten erfasst"
+		if err := s.importRepo.UpdateRow(row); err != nil {
+			return nil, err
+		}
+		return row, nil
+	}
+
+	row.VehicleID = &vehicle.ID
+	if row.FuelType == "" {
+		row.FuelType = vehicle.FuelType
+	}
+	if err := s.importRow(row); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern der tankkosten: %v", err)
+	}
+	if err := s.importRepo.UpdateRow(row); err != nil {
+		return nil, err
+	}
+	s.refreshBatchCounts(row.BatchID)
+
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicle.ID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Tankkartenimport: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"fuel_import_resolved",
+		fmt.Sprintf("Tankvorgang vom %s (%.2f €) manuell %s zugeordnet", row.Date.Format("02.01.2006"), row.TotalCost, vehicle.LicensePlate),
+		userID,
+		&vehicle.ID,
+	)
+
+	return row, nil
+}
+
+// DiscardRow entfernt eine Zeile aus der Prüfliste, ohne Tankkosten anzulegen
+func (s *FuelImportService) DiscardRow(rowID string) (*model.FuelImportRow, error) {
+	row, err := s.reviewRow(rowID)
+	if err != nil {
+		return nil, err
+	}
+
+	row.Status = model.FuelImportRowDiscarded
+	if err := s.importRepo.UpdateRow(row); err != nil {
+		return nil, err
+	}
+	s.refreshBatchCounts(row.BatchID)
+	return row, nil
+}
+
+// reviewRow lädt eine Zeile der Prüfliste
+func (s *FuelImportService) reviewRow(rowID string) (*model.FuelImportRow, error) {
+	row, err := s.importRepo.FindRowByID(rowID)
+	if err != nil {
+		return nil, ErrFuelImportNotFound
+	}
+	batch, err := s.importRepo.FindBatchByID(row.BatchID.Hex())
+	if err != nil {
+		return nil, ErrFuelImportNotFound
+	}
+	if row.Status != model.FuelImportRowUnmatched || batch.Status != model.FuelImportBatchCommitted {
+		return nil, ErrFuelImportState
+	}
+	return row, nil
+}
+
+// refreshBatchCounts aktualisiert die Zähler eines Imports nach Bearbeitung der Prüfliste
+func (s *FuelImportService) refreshBatchCounts(batchID primitive.ObjectID) {
+	result, err := s.GetBatch(batchID.Hex())
+	if err != nil {
+		return
+	}
+	result.Batch.Counts = countFuelImportRows(result.Rows)
+	if err := s.importRepo.UpdateBatch(result.Batch); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Imports %s: %v", batchID.Hex(), err)
+	}
+}
+
+// importRow legt die Tankkosten einer zugeordneten Zeile an
+func (s *FuelImportService) importRow(row *model.FuelImportRow) error {
+	vehicle, err := s.vehicleRepo.FindByID(row.VehicleID.Hex())
+	if err != nil {
+		return err
+	}
+
+	notes := "Tankkartenimport"
+	if row.Product != "" {
+		notes += ": " + row.Product
+	}
+
+	fuelCost := &model.FuelCost{
+		VehicleID:     vehicle.ID,
+		DriverID:      vehicle.CurrentDriverID,
+		Date:          row.Date,
+		FuelType:      row.FuelType,
+		Amount:        row.Amount,
+		PricePerUnit:  row.PricePerUnit,
+		TotalCost:     row.TotalCost,
+		Mileage:       row.Mileage,
+		Location:      row.Location,
+		ReceiptNumber: row.ReceiptNumber,
+		Notes:         notes,
+	}
+	if err := s.fuelCostRepo.Create(fuelCost); err != nil {
+		return err
+	}
+
+	row.Status = model.FuelImportRowImported
+	row.Message = ""
+	row.FuelCostID = &fuelCost.ID
+	return nil
+}
+
+// matchRows ordnet lesbare Zeilen über Tankkartennummer oder Kennzeichen einem Fahrzeug zu
+// und markiert Tankvorgänge, die bereits erfasst sind oder in der Datei doppelt vorkommen
+func (s *FuelImportService) matchRows(rows []*model.FuelImportRow) error {
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return fmt.Errorf("fehler beim laden der fahrzeuge: %v", err)
+	}
+
+	byCard := make(map[string]*model.Vehicle)
+	byPlate := make(map[string]*model.Vehicle)
+	for _, vehicle := range vehicles {
+		if card := normalizeFuelCardKey(vehicle.CardNumber); card != "" {
+			byCard[card] = vehicle
+		}
+		if plate := normalizeFuelCardKey(vehicle.LicensePlate); plate != "" {
+			byPlate[plate] = vehicle
+		}
+	}
+
+	existing, err := s.existingFuelCosts(rows)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		switch row.Status {
+		case model.FuelImportRowMatched, model.FuelImportRowUnmatched, model.FuelImportRowDuplicate:
+		default:
+			continue // Ungültige oder bereits bearbeitete Zeilen bleiben unverändert
+		}
+
+		vehicle := byCard[normalizeFuelCardKey(row.CardNumber)]
+		if vehicle == nil {
+			vehicle = byPlate[normalizeFuelCardKey(row.LicensePlate)]
+		}
+
+		if vehicle == nil {
+			row.VehicleID = nil
+			row.Status = model.FuelImportRowUnmatched
+			row.Message = "kein fahrzeug mit dieser tankkarte oder diesem kennzeichen gefunden"
+			if isDuplicateFuelCost(row, primitive.NilObjectID, existing) {
+				row.Status = model.FuelImportRowDuplicate
+				row.Message = "beleg bereits als tankkosten erfasst"
+			}
+			continue
+		}
+
+		vehicleID := vehicle.ID
+		row.VehicleID = &vehicleID
+		if row.FuelType == "" {
+			row.FuelType = vehicle.FuelType
+		}
+
+		key := fuelImportRowKey(row, vehicleID)
+		switch {
+		case isDuplicateFuelCost(row, vehicleID, existing):
+			row.Status = model.FuelImportRowDuplicate
+			row.Message = "bereits als tankkosten erfasst"
+		case seen[key]:
+			row.Status = model.FuelImportRowDuplicate
+			row.Message = "doppelt in der datei"
+		default:
+			row.Status = model.FuelImportRowMatched
+			row.Message = ""
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// existingFuelCosts lädt alle Tankkosten im Zeitraum der Zeilen
+func (s *FuelImportService) existingFuelCosts(rows []*model.FuelImportRow) ([]*model.FuelCost, error) {
+	var from, to time.Time
+	for _, row := range rows {
+		if row.Date.IsZero() {
+			continue
+		}
+		if from.IsZero() || row.Date.Before(from) {
+			from = row.Date
+		}
+		if to.IsZero() || row.Date.After(to) {
+			to = row.Date
+		}
+	}
+	if from.IsZero() {
+		return nil, nil
+	}
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 23, 59, 59, 0, to.Location())
+	existing, err := s.fuelCostRepo.FindByDateRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der tankkosten: %v", err)
+	}
+	return existing, nil
+}
+
+// isDuplicateFuelCost prüft, ob ein Tankvorgang bereits erfasst ist: gleiche Belegnummer am selben Tag
+// oder – ohne Belegnummer – gleiches Fahrzeug, gleicher Tag und gleicher Betrag
+func isDuplicateFuelCost(row *model.FuelImportRow, vehicleID primitive.ObjectID, existing []*model.FuelCost) bool {
+	day := row.Date.Format("2006-01-02")
+	for _, fuelCost := range existing {
+		if fuelCost.Date.Format("2006-01-02") != day {
+			continue
+		}
+		if row.ReceiptNumber != "" && fuelCost.ReceiptNumber != "" {
+			if strings.EqualFold(strings.TrimSpace(fuelCost.ReceiptNumber), row.ReceiptNumber) {
+				return true
+			}
+			continue
+		}
+		if vehicleID.IsZero() || fuelCost.VehicleID != vehicleID {
+			continue
+		}
+		if math.Abs(fuelCost.TotalCost-row.TotalCost) < 0.005 &&
+			(row.Amount == 0 || math.Abs(fuelCost.Amount-row.Amount) < 0.005) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuelImportRowKey bildet den Schlüssel zur Erkennung doppelter Zeilen innerhalb einer Datei
+func fuelImportRowKey(row *model.FuelImportRow, vehicleID primitive.ObjectID) string {
+	if row.ReceiptNumber != "" {
+		return "receipt|" + strings.ToUpper(row.ReceiptNumber) + "|" + row.Date.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s|%s|%.2f|%.2f", vehicleID.Hex(), row.Date.Format(time.RFC3339), row.TotalCost, row.Amount)
+}
+
+// countFuelImportRows zählt die Zeilen eines Imports nach Status
+func countFuelImportRows(rows []*model.FuelImportRow) model.FuelImportCounts {
+	counts := model.FuelImportCounts{Total: len(rows)}
+	for _, row := range rows {
+		switch row.Status {
+		case model.FuelImportRowMatched:
+			counts.Matched++
+		case model.FuelImportRowDuplicate:
+			counts.Duplicate++
+		case model.FuelImportRowUnmatched:
+			counts.Unmatched++
+		case model.FuelImportRowInvalid:
+			counts.Invalid++
+		case model.FuelImportRowImported:
+			counts.Imported++
+		}
+	}
+	return counts
+}
+
+// normalizeFuelCardKey vereinheitlicht Tankkartennummern und Kennzeichen für den Vergleich
+func normalizeFuelCardKey(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// validateFuelCardProvider prüft ein Anbieterformat und setzt Standardwerte
+func validateFuelCardProvider(provider *model.FuelCardProvider) error {
+	provider.Name = strings.TrimSpace(provider.Name)
+	if provider.Name == "" {
+		return fmt.Errorf("name ist erforderlich")
+	}
+	if provider.Delimiter == "" {
+		provider.Delimiter = ";"
+	}
+	if provider.Delimiter == `\t` {
+		provider.Delimiter = "\t"
+	}
+	if utf8.RuneCountInString(provider.Delimiter) != 1 {
+		return fmt.Errorf("das trennzeichen muss genau ein zeichen sein")
+	}
+	if provider.DateFormat == "" {
+		provider.DateFormat = "02.01.2006"
+	}
+	if provider.Columns.Time != "" && provider.TimeFormat == "" {
+		provider.TimeFormat = "15:04"
+	}
+	if provider.SkipRows < 0 {
+		return fmt.Errorf("zu überspringende zeilen dürfen nicht negativ sein")
+	}
+
+	columns := provider.Columns
+	if columns.Date == "" {
+		return fmt.Errorf("die spalte für das datum ist erforderlich")
+	}
+	if columns.CardNumber == "" && columns.LicensePlate == "" {
+		return fmt.Errorf("eine spalte für tankkartennummer oder kennzeichen ist erforderlich")
+	}
+	if columns.TotalCost == "" && (columns.Amount == "" || columns.PricePerUnit == "") {
+		return fmt.Errorf("eine spalte für den gesamtbetrag oder für menge und preis ist erforderlich")
+	}
+
+	for product, fuelType := range provider.Products {
+		if !isKnownFuelType(fuelType) {
+			return fmt.Errorf("unbekannte kraftstoffart für produkt %s: %s", product, fuelType)
+		}
+	}
+	return nil
+}
+
+// isKnownFuelType prüft, ob eine Kraftstoffart bekannt ist
+func isKnownFuelType(fuelType model.FuelType) bool {
+	switch fuelType {
+	case model.FuelTypeGasoline, model.FuelTypeDiesel, model.FuelTypeElectric,
+		model.FuelTypeHybridGas, model.FuelTypeHybridDiesel, model.FuelTypeHydrogen:
+		return true
+	}
+	return false
+}
+
+// parseFuelCardCSV liest einen Tankkartenauszug im Format des Anbieters
+func parseFuelCardCSV(provider *model.FuelCardProvider, file io.Reader) ([]*model.FuelImportRow, error) {
+	delimiter, _ := utf8.DecodeRuneInString(provider.Delimiter)
+
+	reader := csv.NewReader(file)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for i := 0; i < provider.SkipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("die datei enthält weniger als %d vorspannzeilen", provider.SkipRows)
+		}
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("kopfzeile konnte nicht gelesen werden: %v", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\uFEFF")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := provider.Columns
+	column := func(name string) int {
+		if name == "" {
+			return -1
+		}
+		if i, ok := index[strings.ToLower(strings.TrimSpace(name))]; ok {
+			return i
+		}
+		return -2
+	}
+
+	mapped := map[string]string{
+		"cardNumber": columns.CardNumber, "licensePlate": columns.LicensePlate, "date": columns.Date,
+		"time": columns.Time, "product": columns.Product, "amount": columns.Amount,
+		"pricePerUnit": columns.PricePerUnit, "totalCost": columns.TotalCost, "mileage": columns.Mileage,
+		"location": columns.Location, "receiptNumber": columns.ReceiptNumber,
+	}
+	positions := make(map[string]int, len(mapped))
+	var missing []string
+	for field, name := range mapped {
+		positions[field] = column(name)
+		if positions[field] == -2 {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("spalten nicht in der datei gefunden: %s", strings.Join(missing, ", "))
+	}
+
+	products := make(map[string]model.FuelType, len(provider.Products))
+	for product, fuelType := range provider.Products {
+		products[strings.ToLower(strings.TrimSpace(product))] = fuelType
+	}
+
+	var rows []*model.FuelImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Zeilennummern aus der Datei, da Leerzeilen und mehrzeilige Felder das Zählen verfälschen
+			line := 0
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.StartLine
+			}
+			rows = append(rows, &model.FuelImportRow{RowNumber: line, Status: model.FuelImportRowInvalid, Message: err.Error()})
+			continue
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		value := func(field string) string {
+			i := positions[field]
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := &model.FuelImportRow{
+			RowNumber:     line,
+			Status:        model.FuelImportRowUnmatched,
+			CardNumber:    value("cardNumber"),
+			LicensePlate:  value("licensePlate"),
+			Product:       value("product"),
+			Location:      value("location"),
+			ReceiptNumber: value("receiptNumber"),
+		}
+		row.FuelType = products[strings.ToLower(row.Product)]
+
+		if err := parseFuelImportValues(provider, row, value); err != nil {
+			row.Status = model.FuelImportRowInvalid
+			row.Message = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseFuelImportValues liest Datum, Mengen und Beträge einer Zeile
+func parseFuelImportValues(provider *model.FuelCardProvider, row *model.FuelImportRow, value func(string) string) error {
+	dateValue, layout := value("date"), provider.DateFormat
+	if timeValue := value("time"); timeValue != "" {
+		dateValue, layout = dateValue+" "+timeValue, layout+" "+provider.TimeFormat
+	}
+	date, err := time.Parse(layout, dateValue)
+	if err != nil {
+		return fmt.Errorf("ungültiges datum: %s", dateValue)
+	}
+	row.Date = date
+
+	number := func(field string) (float64, error) {
+		raw := value(field)
+		if raw == "" {
+			return 0, nil
+		}
+		parsed, err := parseLocalizedNumber(raw, provider.DecimalComma)
+		if err != nil {
+			return 0, fmt.Errorf("ungültige zahl in spalte %s: %s", field, raw)
+		}
+		return parsed, nil
+	}
+
+	if row.Amount, err = number("amount"); err != nil {
+		return err
+	}
+	if row.PricePerUnit, err = number("pricePerUnit"); err != nil {
+		return err
+	}
+	if row.TotalCost, err = number("totalCost"); err != nil {
+		return err
+	}
+	mileage, err := number("mileage")
+	if err != nil {
+		return err
+	}
+	row.Mileage = int(math.Round(mileage))
+
+	if row.TotalCost == 0 {
+		row.TotalCost = roundCents(row.Amount * row.PricePerUnit)
+	}
+	if row.PricePerUnit == 0 && row.Amount > 0 {
+		row.PricePerUnit = math.Round(row.TotalCost/row.Amount*1000) / 1000
+	}
+
+	if row.TotalCost <= 0 {
+		return fmt.Errorf("betrag fehlt oder ist keine belastung")
+	}
+	if row.CardNumber == "" && row.LicensePlate == "" {
+		return fmt.Errorf("weder tankkartennummer noch kennzeichen angegeben")
+	}
+	return nil
+}
+
+// parseLocalizedNumber liest Zahlen wie "1.234,56" (Dezimalkomma) oder "1,234.56"
+func parseLocalizedNumber(raw string, decimalComma bool) (float64, error) {
+	cleaned := strings.NewReplacer("€", "", "EUR", "", " ", "", "\u00a0", "").Replace(raw)
+	if decimalComma {
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	} else {
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	}
+	return strconv.ParseFloat(cleaned, 64)
+}
+
+// isEmptyRecord prüft, ob eine CSV-Zeile nur leere Felder enthält
+func isEmptyRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
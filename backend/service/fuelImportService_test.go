@@ -0,0 +1,215 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testFuelCardProvider ist ein typisches Format eines deutschen Tankkartenanbieters
+func testFuelCardProvider() *model.FuelCardProvider {
+	provider := &model.FuelCardProvider{
+		Name:         "Testkarte",
+		DecimalComma: true,
+		Columns: model.FuelImportColumns{
+			CardNumber:    "Kartennummer",
+			LicensePlate:  "Kennzeichen",
+			Date:          "Datum",
+			Time:          "Uhrzeit",
+			Product:       "Produkt",
+			Amount:        "Menge",
+			PricePerUnit:  "Einzelpreis",
+			TotalCost:     "Betrag",
+			Mileage:       "Km-Stand",
+			Location:      "Station",
+			ReceiptNumber: "Beleg",
+		},
+		Products: map[string]model.FuelType{"Diesel": model.FuelTypeDiesel, "Super E10": model.FuelTypeGasoline},
+	}
+	if err := validateFuelCardProvider(provider); err != nil {
+		panic(err)
+	}
+	return provider
+}
+
+const testFuelCardHeader = "Kartennummer;Kennzeichen;Datum;Uhrzeit;Produkt;Menge;Einzelpreis;Betrag;Km-Stand;Station;Beleg\n"
+
+func TestParseFuelCardCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider func() *model.FuelCardProvider
+		data     string
+		want     []model.FuelImportRow
+		wantErr  string
+	}{
+		{
+			name:     "vollständige zeile",
+			provider: testFuelCardProvider,
+			data:     testFuelCardHeader + "7001 2345;B-FF 100;03.02.2025;14:35;Diesel;45,20;1,659;74,99;12.345;Berlin Mitte;R-1\n",
+			want: []model.FuelImportRow{{
+				RowNumber: 2, Status: model.FuelImportRowUnmatched,
+				CardNumber: "7001 2345", LicensePlate: "B-FF 100",
+				Date:    time.Date(2025, time.February, 3, 14, 35, 0, 0, time.UTC),
+				Product: "Diesel", FuelType: model.FuelTypeDiesel,
+				Amount: 45.2, PricePerUnit: 1.659, TotalCost: 74.99, Mileage: 12345,
+				Location: "Berlin Mitte", ReceiptNumber: "R-1",
+			}},
+		},
+		{
+			name:     "betrag aus menge und preis, unbekanntes produkt",
+			provider: testFuelCardProvider,
+			data:     testFuelCardHeader + "7001;;03.02.2025;;AdBlue;10,00;0,999 €;;;;\n",
+			want: []model.FuelImportRow{{
+				RowNumber: 2, Status: model.FuelImportRowUnmatched, CardNumber: "7001",
+				Date:    time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC),
+				Product: "AdBlue", Amount: 10, PricePerUnit: 0.999, TotalCost: 9.99,
+			}},
+		},
+		{
+			name:     "preis aus betrag und menge, produkt ohne groß- und kleinschreibung",
+			provider: testFuelCardProvider,
+			data:     testFuelCardHeader + ";B-FF 100;03.02.2025;08:00;super e10;40;;70,00;;;\n",
+			want: []model.FuelImportRow{{
+				RowNumber: 2, Status: model.FuelImportRowUnmatched, LicensePlate: "B-FF 100",
+				Date:    time.Date(2025, time.February, 3, 8, 0, 0, 0, time.UTC),
+				Product: "super e10", FuelType: model.FuelTypeGasoline,
+				Amount: 40, PricePerUnit: 1.75, TotalCost: 70,
+			}},
+		},
+		{
+			name: "dezimalpunkt, komma als trenner und vorspann",
+			provider: func() *model.FuelCardProvider {
+				return &model.FuelCardProvider{
+					Delimiter:  ",",
+					DateFormat: "2006-01-02",
+					SkipRows:   2,
+					Columns:    model.FuelImportColumns{CardNumber: "card", Date: "date", TotalCost: "total", Amount: "liters"},
+				}
+			},
+			data: "Statement January\nCustomer 4711\n\uFEFF card , date,liters,total\n700,2025-01-31,\"1,000.5\",\"1,234.56\"\n",
+			want: []model.FuelImportRow{{
+				RowNumber: 4, Status: model.FuelImportRowUnmatched, CardNumber: "700",
+				Date:   time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+				Amount: 1000.5, PricePerUnit: 1.234, TotalCost: 1234.56,
+			}},
+		},
+		{
+			name:     "ungültige zeilen werden markiert, leere übersprungen",
+			provider: testFuelCardProvider,
+			data: testFuelCardHeader +
+				"7001;;31.02.2025;;Diesel;;;50,00;;;\n" +
+				";;;;;;;;;;\n" +
+				"7001;;03.02.2025;;Diesel;viel;;50,00;;;\n" +
+				"7001;;03.02.2025;;Diesel;;;-5,00;;;\n" +
+				";;03.02.2025;;Diesel;;;50,00;;;\n",
+			want: []model.FuelImportRow{
+				{RowNumber: 2, Status: model.FuelImportRowInvalid, Message: "ungültiges datum: 31.02.2025"},
+				{RowNumber: 4, Status: model.FuelImportRowInvalid, Message: "ungültige zahl in spalte amount: viel"},
+				{RowNumber: 5, Status: model.FuelImportRowInvalid, Message: "betrag fehlt oder ist keine belastung"},
+				{RowNumber: 6, Status: model.FuelImportRowInvalid, Message: "weder tankkartennummer noch kennzeichen angegeben"},
+			},
+		},
+		{
+			name:     "zeilennummern nach leerzeilen und mehrzeiligen feldern",
+			provider: testFuelCardProvider,
+			data: testFuelCardHeader + "\n" +
+				"7001;;03.02.2025;;Diesel;;;50,00;;\"Berlin\nMitte\";\n" +
+				"7002;;04.02.2025;;Diesel;;;60,00;;;\n",
+			want: []model.FuelImportRow{
+				{RowNumber: 3, Status: model.FuelImportRowUnmatched, CardNumber: "7001", Location: "Berlin\nMitte"},
+				{RowNumber: 5, Status: model.FuelImportRowUnmatched, CardNumber: "7002"},
+			},
+		},
+		{
+			name:     "fehlende spalte",
+			provider: testFuelCardProvider,
+			data:     "Kartennummer;Datum;Betrag\n7001;03.02.2025;50,00\n",
+			wantErr:  "spalten nicht in der datei gefunden",
+		},
+		{
+			name:     "zu wenige vorspannzeilen",
+			provider: func() *model.FuelCardProvider { p := testFuelCardProvider(); p.SkipRows = 3; return p },
+			data:     "Vorspann\n",
+			wantErr:  "weniger als 3 vorspannzeilen",
+		},
+		{
+			name:     "leere datei",
+			provider: testFuelCardProvider,
+			data:     "",
+			wantErr:  "kopfzeile konnte nicht gelesen werden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseFuelCardCSV(tt.provider(), strings.NewReader(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("fehler mit %q erwartet, erhalten %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("%d zeilen erhalten, erwartet %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := rows[i]
+				if got.RowNumber != want.RowNumber || got.Status != want.Status {
+					t.Errorf("zeile %d: nummer %d / status %s, erwartet %d / %s (%s)",
+						i, got.RowNumber, got.Status, want.RowNumber, want.Status, got.Message)
+				}
+				if want.Status == model.FuelImportRowInvalid {
+					if got.Message != want.Message {
+						t.Errorf("zeile %d: meldung %q, erwartet %q", i, got.Message, want.Message)
+					}
+					continue
+				}
+				if want.Date.IsZero() {
+					// Nur Zeilennummer und Zuordnungsfelder prüfen
+					if got.CardNumber != want.CardNumber || got.Location != want.Location {
+						t.Errorf("zeile %d: %+v, erwartet %+v", i, *got, want)
+					}
+					continue
+				}
+				if *got != want {
+					t.Errorf("zeile %d:\n  erhalten %+v\n  erwartet %+v", i, *got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLocalizedNumber(t *testing.T) {
+	tests := []struct {
+		raw          string
+		decimalComma bool
+		want         float64
+		wantErr      bool
+	}{
+		{"1.234,56", true, 1234.56, false},
+		{"74,99 €", true, 74.99, false},
+		{"-5,00", true, -5, false},
+		{"12\u00a0345", true, 12345, false},
+		{"1,234.56", false, 1234.56, false},
+		{"EUR 9.99", false, 9.99, false},
+		{"1,5", false, 15, false},
+		{"abc", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLocalizedNumber(tt.raw, tt.decimalComma)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLocalizedNumber(%q): fehler erwartet", tt.raw)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseLocalizedNumber(%q, %v) = %v, %v; erwartet %v", tt.raw, tt.decimalComma, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrHandoverForbidden wird zurückgegeben, wenn ein Fahrer die Reservierung eines anderen Fahrers übergeben will
+var ErrHandoverForbidden = errors.New("keine berechtigung für diese reservierung")
+
+// HandoverService verwaltet die Übergabeprotokolle bei Ausgabe und Rückgabe reservierter Fahrzeuge
+type HandoverService struct {
+	handoverRepo       repository.ReservationHandoverRepository
+	reservationRepo    repository.VehicleReservationRepository
+	vehicleRepo        repository.VehicleRepository
+	driverRepo         repository.DriverRepository
+	usageRepo          repository.VehicleUsageRepository
+	reportRepo         repository.VehicleReportRepository
+	documentRepo       repository.VehicleDocumentRepository
+	reservationService *ReservationService
+	mileageService     *VehicleMileageService
+	activityService    *ActivityService
+}
+
+// NewHandoverService erstellt einen neuen HandoverService
+func NewHandoverService(
+	handoverRepo repository.ReservationHandoverRepository,
+	reservationRepo repository.VehicleReservationRepository,
+	vehicleRepo repository.VehicleRepository,
+	driverRepo repository.DriverRepository,
+	usageRepo repository.VehicleUsageRepository,
+	reportRepo repository.VehicleReportRepository,
+	documentRepo repository.VehicleDocumentRepository,
+	reservationService *ReservationService,
+	mileageService *VehicleMileageService,
+	activityService *ActivityService,
+) *HandoverService {
+	return &HandoverService{
+		handoverRepo:       handoverRepo,
+		reservationRepo:    reservationRepo,
+		vehicleRepo:        vehicleRepo,
+		driverRepo:         driverRepo,
+		usageRepo:          usageRepo,
+		reportRepo:         reportRepo,
+		documentRepo:       documentRepo,
+		reservationService: reservationService,
+		mileageService:     mileageService,
+		activityService:    activityService,
+	}
+}
+
+// HandoverInput enthält die bei einer Übergabe erfassten Werte
+type HandoverInput struct {
+	Mileage     int
+	EnergyLevel int
+	Cleanliness int
+	Damages     []model.HandoverDamage
+	Notes       string
+}
+
+// CheckOut erfasst das Ausgabeprotokoll und aktiviert die Reservierung
+func (s *HandoverService) CheckOut(user *model.User, reservationID string, input HandoverInput) (*model.ReservationHandover, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+	if !s.canAccess(user, reservation.DriverID) {
+		return nil, ErrHandoverForbidden
+	}
+	recordedBy := user.ID
+
+	existing, err := s.handoverRepo.FindByReservationAndType(reservationID, model.HandoverTypeCheckOut)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden des ausgabeprotokolls: %v", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("für diese reservierung wurde bereits ein ausgabeprotokoll erfasst")
+	}
+
+	if err := validateHandoverInput(input); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(reservation.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden: %v", err)
+	}
+	if input.Mileage < vehicle.Mileage {
+		return nil, fmt.Errorf("kilometerstand darf nicht unter dem aktuellen stand von %d km liegen", vehicle.Mileage)
+	}
+
+	// Reservierung aktivieren, bevor das Protokoll gespeichert wird
+	if err := s.reservationService.CheckOutReservation(reservationID); err != nil {
+		return nil, err
+	}
+
+	// Bei der Ausgabe vorhandene Schäden gelten als bekannt
+	damages := make([]model.HandoverDamage, 0, len(input.Damages))
+	for _, damage := range input.Damages {
+		damage.IsNew = false
+		damages = append(damages, damage)
+	}
+
+	handover := &model.ReservationHandover{
+		ReservationID: reservation.ID,
+		VehicleID:     reservation.VehicleID,
+		DriverID:      reservation.DriverID,
+		Type:          model.HandoverTypeCheckOut,
+		Mileage:       input.Mileage,
+		EnergyLevel:   input.EnergyLevel,
+		Cleanliness:   input.Cleanliness,
+		Damages:       damages,
+		Notes:         input.Notes,
+		RecordedBy:    recordedBy,
+		RecordedAt:    time.Now(),
+	}
+	if err := s.handoverRepo.Create(handover); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des ausgabeprotokolls: %v", err)
+	}
+
+	// Aktivität protokollieren
+	s.activityService.LogActivity(
+		"vehicle_checked_out",
+		fmt.Sprintf("Fahrzeug %s ausgegeben (Kilometerstand %d km, Tank/Akku %d%%)", vehicle.LicensePlate, input.Mileage, input.EnergyLevel),
+		recordedBy,
+		&reservation.VehicleID,
+	)
+
+	return handover, nil
+}
+
+// CheckIn erfasst das Rückgabeprotokoll, legt die Fahrzeugnutzung an, meldet neue Schäden
+// und schließt die Reservierung ab
+func (s *HandoverService) CheckIn(user *model.User, reservationID string, input HandoverInput) (*model.ReservationHandover, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+	if !s.canAccess(user, reservation.DriverID) {
+		return nil, ErrHandoverForbidden
+	}
+	recordedBy := user.ID
+	if reservation.Status != model.ReservationStatusActive {
+		return nil, fmt.Errorf("nur aktive reservierungen können zurückgegeben werden")
+	}
+
+	checkOut, err := s.handoverRepo.FindByReservationAndType(reservationID, model.HandoverTypeCheckOut)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden des ausgabeprotokolls: %v", err)
+	}
+	if checkOut == nil {
+		return nil, fmt.Errorf("für diese reservierung wurde noch kein ausgabeprotokoll erfasst")
+	}
+
+	existing, err := s.handoverRepo.FindByReservationAndType(reservationID, model.HandoverTypeCheckIn)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden des rückgabeprotokolls: %v", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("für diese reservierung wurde bereits ein rückgabeprotokoll erfasst")
+	}
+
+	if err := validateHandoverInput(input); err != nil {
+		return nil, err
+	}
+	if input.Mileage < checkOut.Mileage {
+		return nil, fmt.Errorf("kilometerstand darf nicht unter dem stand bei ausgabe von %d km liegen", checkOut.Mileage)
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(reservation.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden: %v", err)
+	}
+
+	now := time.Now()
+	handover := &model.ReservationHandover{
+		ReservationID: reservation.ID,
+		VehicleID:     reservation.VehicleID,
+		DriverID:      reservation.DriverID,
+		Type:          model.HandoverTypeCheckIn,
+		Mileage:       input.Mileage,
+		EnergyLevel:   input.EnergyLevel,
+		Cleanliness:   input.Cleanliness,
+		Damages:       markNewDamages(checkOut.Damages, input.Damages),
+		Notes:         input.Notes,
+		RecordedBy:    recordedBy,
+		RecordedAt:    now,
+	}
+	if err := s.handoverRepo.Create(handover); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des rückgabeprotokolls: %v", err)
+	}
+
+	// Fahrzeugnutzung aus Aus- und Rückgabe erzeugen
+	usage := &model.VehicleUsage{
+		VehicleID:    reservation.VehicleID,
+		DriverID:     reservation.DriverID,
+		StartDate:    checkOut.RecordedAt,
+		EndDate:      now,
+		StartMileage: checkOut.Mileage,
+		EndMileage:   input.Mileage,
+		Purpose:      reservation.Purpose,
+		Status:       model.UsageStatusCompleted,
+		Notes:        fmt.Sprintf("Automatisch aus Rückgabeprotokoll der Reservierung %s erstellt", reservationID),
+	}
+	if err := s.usageRepo.Create(usage); err != nil {
+		return nil, fmt.Errorf("fehler beim erstellen der fahrzeugnutzung: %v", err)
+	}
+	handover.UsageID = &usage.ID
+
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(reservation.VehicleID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Rückgabe von Fahrzeug %s: %v", vehicle.LicensePlate, err)
+	}
+
+	// Neue Schäden als Fahrzeugmeldung erfassen
+	if newDamages := handover.NewDamages(); len(newDamages) > 0 {
+		report, err := s.createDamageReport(handover, newDamages, recordedBy)
+		if err != nil {
+			return nil, err
+		}
+		handover.ReportID = &report.ID
+
+		s.activityService.LogActivity(
+			"vehicle_report_created",
+			fmt.Sprintf("Schadensmeldung bei Rückgabe erstellt für %s %s (%s)", vehicle.Brand, vehicle.Model, vehicle.LicensePlate),
+			recordedBy,
+			&reservation.VehicleID,
+		)
+	}
+
+	if err := s.handoverRepo.Update(handover); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des rückgabeprotokolls: %v", err)
+	}
+
+	if err := s.reservationService.CompleteReservation(reservationID, recordedBy); err != nil {
+		return nil, err
+	}
+
+	// Aktivität protokollieren
+	s.activityService.LogActivity(
+		"vehicle_checked_in",
+		fmt.Sprintf("Fahrzeug %s zurückgegeben (%d km gefahren, %d neue Schäden)",
+			vehicle.LicensePlate, input.Mileage-checkOut.Mileage, len(handover.NewDamages())),
+		recordedBy,
+		&reservation.VehicleID,
+	)
+
+	return handover, nil
+}
+
+// AddPhoto speichert ein Foto als Fahrzeugdokument und hängt es an das Übergabeprotokoll
+func (s *HandoverService) AddPhoto(user *model.User, handoverID, fileName, contentType string, data []byte) (*model.VehicleDocument, error) {
+	handover, err := s.handoverRepo.FindByID(handoverID)
+	if err != nil {
+		return nil, fmt.Errorf("übergabeprotokoll nicht gefunden: %v", err)
+	}
+	if !s.canAccess(user, handover.DriverID) {
+		return nil, ErrHandoverForbidden
+	}
+
+	name := "Übergabefoto Ausgabe"
+	if handover.Type == model.HandoverTypeCheckIn {
+		name = "Übergabefoto Rückgabe"
+	}
+
+	document := &model.VehicleDocument{
+		VehicleID:   handover.VehicleID,
+		Type:        model.DocumentTypeHandoverPhoto,
+		Name:        fmt.Sprintf("%s %s", name, handover.RecordedAt.Format("02.01.2006 15:04")),
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Data:        data,
+		UploadedBy:  user.ID,
+		Notes:       fmt.Sprintf("Reservierung %s", handover.ReservationID.Hex()),
+	}
+	if err := s.documentRepo.Create(document); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des fotos: %v", err)
+	}
+
+	handover.PhotoDocumentIDs = append(handover.PhotoDocumentIDs, document.ID)
+	if err := s.handoverRepo.Update(handover); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des übergabeprotokolls: %v", err)
+	}
+
+	return document, nil
+}
+
+// GetHandovers liefert Aus- und Rückgabeprotokoll einer Reservierung
+func (s *HandoverService) GetHandovers(user *model.User, reservationID string) ([]*model.ReservationHandover, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+	if !s.canAccess(user, reservation.DriverID) {
+		return nil, ErrHandoverForbidden
+	}
+	return s.handoverRepo.FindByReservation(reservationID)
+}
+
+// canAccess prüft, ob der Benutzer Übergaben für den Fahrer der Reservierung erfassen und einsehen darf
+func (s *HandoverService) canAccess(user *model.User, driverID primitive.ObjectID) bool {
+	if isManagerOrAdmin(user) {
+		return true
+	}
+	driver, err := driverForUser(s.driverRepo, user)
+	return err == nil && driver.ID == driverID
+}
+
+// createDamageReport legt eine Fahrzeugmeldung für neu festgestellte Schäden an
+func (s *HandoverService) createDamageReport(handover *model.ReservationHandover, damages []model.HandoverDamage, reporterID primitive.ObjectID) (*model.VehicleReport, error) {
+	var lines []string
+	for _, damage := range damages {
+		lines = append(lines, fmt.Sprintf("- %s: %s", model.DamageAreaText(damage.Area), damage.Description))
+	}
+
+	mileage := handover.Mileage
+	report := &model.VehicleReport{
+		VehicleID:   handover.VehicleID,
+		ReporterID:  reporterID,
+		Type:        model.ReportTypeRepair,
+		Priority:    model.ReportPriorityMedium,
+		Status:      model.ReportStatusOpen,
+		Title:       "Neuer Schaden bei Fahrzeugrückgabe",
+		Description: "Bei der Rückgabe festgestellte Schäden:\n" + strings.Join(lines, "\n"),
+		Mileage:     &mileage,
+	}
+	if err := s.reportRepo.Create(report); err != nil {
+		return nil, fmt.Errorf("fehler beim erstellen der schadensmeldung: %v", err)
+	}
+
+	return report, nil
+}
+
+// markNewDamages markiert alle Schäden, die im Ausgabeprotokoll nicht mit gleichem Bereich
+// und gleicher Beschreibung erfasst wurden, als neu
+func markNewDamages(known, reported []model.HandoverDamage) []model.HandoverDamage {
+	key := func(damage model.HandoverDamage) string {
+		return string(damage.Area) + "|" + strings.ToLower(strings.TrimSpace(damage.Description))
+	}
+
+	existing := make(map[string]bool)
+	for _, damage := range known {
+		existing[key(damage)] = true
+	}
+
+	damages := make([]model.HandoverDamage, 0, len(reported))
+	for _, damage := range reported {
+		damage.IsNew = !existing[key(damage)]
+		damages = append(damages, damage)
+	}
+	return damages
+}
+
+// validateHandoverInput prüft die erfassten Werte eines Übergabeprotokolls
+func validateHandoverInput(input HandoverInput) error {
+	if input.Mileage <= 0 {
+		return fmt.Errorf("kilometerstand ist erforderlich")
+	}
+	if input.EnergyLevel < 0 || input.EnergyLevel > 100 {
+		return fmt.Errorf("tank- bzw. ladestand muss zwischen 0 und 100 prozent liegen")
+	}
+	if input.Cleanliness < 1 || input.Cleanliness > 5 {
+		return fmt.Errorf("sauberkeit muss zwischen 1 und 5 liegen")
+	}
+
+	for _, damage := range input.Damages {
+		valid := false
+		for _, area := range model.DamageAreas {
+			if damage.Area == area {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("ungültiger schadensbereich: %s", damage.Area)
+		}
+		if strings.TrimSpace(damage.Description) == "" {
+			return fmt.Errorf("beschreibung für schaden im bereich %s ist erforderlich", model.DamageAreaText(damage.Area))
+		}
+	}
+
+	return nil
+}
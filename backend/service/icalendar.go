@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsMaxLineOctets ist die maximale Zeilenlänge nach RFC 5545 (ohne CRLF)
+const icsMaxLineOctets = 75
+
+// icsEscaper maskiert Sonderzeichen in TEXT-Werten nach RFC 5545, Abschnitt 3.3.11
+var icsEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// icsWriter baut ein iCalendar-Dokument mit CRLF-Zeilenenden und gefalteten Zeilen auf
+type icsWriter struct {
+	sb strings.Builder
+}
+
+// property schreibt eine Eigenschaft mit bereits formatiertem Wert
+func (w *icsWriter) property(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// text schreibt eine Eigenschaft mit maskiertem Textwert
+func (w *icsWriter) text(name, value string) {
+	w.property(name, icsEscaper.Replace(value))
+}
+
+// timestamp schreibt einen Zeitpunkt im UTC-Format (z.B. 20250101T080000Z)
+func (w *icsWriter) timestamp(name string, t time.Time) {
+	w.property(name, t.UTC().Format("20060102T150405Z"))
+}
+
+// line schreibt eine Inhaltszeile und faltet sie nach 75 Oktetts,
+// ohne dabei ein UTF-8-Zeichen zu zerteilen
+func (w *icsWriter) line(content string) {
+	limit := icsMaxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.sb.WriteString(content[:cut])
+		w.sb.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icsMaxLineOctets - 1 // Folgezeilen beginnen mit einem Leerzeichen
+	}
+	w.sb.WriteString(content)
+	w.sb.WriteString("\r\n")
+}
+
+// String liefert das fertige Dokument
+func (w *icsWriter) String() string {
+	return w.sb.String()
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobSchedule berechnet den nächsten Ausführungszeitpunkt eines Jobs
+type JobSchedule interface {
+	Next(after time.Time) time.Time
+}
+
+// scheduleAliases bildet die üblichen Cron-Kurzformen auf vollständige Ausdrücke ab
+var scheduleAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// ParseJobSchedule liest einen Zeitplan. Unterstützt werden "@every <Dauer>" (z.B. "@every 5m"),
+// die Kurzformen @hourly/@daily/@weekly/@monthly sowie fünfteilige Cron-Ausdrücke
+// (Minute Stunde Tag Monat Wochentag), die in der Zeitzone Europe/Berlin ausgewertet werden.
+func ParseJobSchedule(spec string) (JobSchedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("ungültiges intervall in %q: %v", spec, err)
+		}
+		if interval < time.Minute {
+			return nil, fmt.Errorf("intervall in %q muss mindestens eine minute betragen", spec)
+		}
+		return intervalSchedule(interval), nil
+	}
+
+	if alias, ok := scheduleAliases[spec]; ok {
+		spec = alias
+	}
+	return parseCronSchedule(spec)
+}
+
+// intervalSchedule führt einen Job in festen Abständen aus
+type intervalSchedule time.Duration
+
+// Next liefert den Zeitpunkt ein Intervall nach after
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(s))
+}
+
+// cronSchedule enthält die erlaubten Werte je Cron-Feld als Bitmaske
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+	location                      *time.Location
+}
+
+// cronField beschreibt Name und Wertebereich eines Cron-Felds
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"stunde", 0, 23},
+	{"tag", 1, 31},
+	{"monat", 1, 12},
+	{"wochentag", 0, 7},
+}
+
+// parseCronSchedule liest einen fünfteiligen Cron-Ausdruck
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron-ausdruck %q muss aus %d feldern bestehen", spec, len(cronFields))
+	}
+
+	var masks [5]uint64
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron-ausdruck %q: %v", spec, err)
+		}
+		masks[i] = mask
+	}
+
+	// Wochentag 7 ist wie in Cron üblich ein Synonym für Sonntag
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	location := berlinLocation()
+
+	return &cronSchedule{
+		minute:   masks[0],
+		hour:     masks[1],
+		dom:      masks[2],
+		month:    masks[3],
+		dow:      masks[4],
+		domAny:   parts[2] == "*",
+		dowAny:   parts[4] == "*",
+		location: location,
+	}, nil
+}
+
+// parseCronField liest ein Feld mit Listen ("1,15"), Bereichen ("1-5") und Schritten ("*/10")
+func parseCronField(value string, field cronField) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(item[idx+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("ungültige schrittweite %q im feld %s", item, field.name)
+			}
+			rangePart = item[:idx]
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("ungültiger wert %q im feld %s", item, field.name)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("ungültiger wert %q im feld %s", item, field.name)
+				}
+			} else if step > 1 {
+				end = field.max // "5/15" bedeutet ab 5 alle 15
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("wert %q liegt außerhalb von %d-%d im feld %s", item, field.min, field.max, field.name)
+		}
+		for v := start; v <= end; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// Next liefert die erste passende Minute nach after
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0) // Unerfüllbare Ausdrücke wie "0 0 30 2 *" beenden die Suche
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches prüft Tag und Wochentag; sind beide eingeschränkt, genügt wie bei Cron eines davon
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// berlinLocation liefert die Zeitzone Europe/Berlin (Fallback: lokale Zeitzone)
+func berlinLocation() *time.Location {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+// cronBits baut die erwartete Bitmaske aus einzelnen Werten
+func cronBits(values ...int) uint64 {
+	var mask uint64
+	for _, v := range values {
+		mask |= 1 << uint(v)
+	}
+	return mask
+}
+
+func TestParseCronField(t *testing.T) {
+	minute := cronField{"minute", 0, 59}
+	hour := cronField{"stunde", 0, 23}
+	month := cronField{"monat", 1, 12}
+
+	tests := []struct {
+		name    string
+		value   string
+		field   cronField
+		want    uint64
+		wantErr bool
+	}{
+		{name: "einzelwert", value: "5", field: minute, want: cronBits(5)},
+		{name: "liste", value: "1,15,30", field: minute, want: cronBits(1, 15, 30)},
+		{name: "bereich", value: "9-12", field: hour, want: cronBits(9, 10, 11, 12)},
+		{name: "stern", value: "*", field: month, want: cronBits(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)},
+		{name: "stern mit schritt", value: "*/15", field: minute, want: cronBits(0, 15, 30, 45)},
+		{name: "bereich mit schritt", value: "8-18/4", field: hour, want: cronBits(8, 12, 16)},
+		{name: "startwert mit schritt läuft bis zum maximum", value: "5/20", field: minute, want: cronBits(5, 25, 45)},
+		{name: "liste aus bereichen", value: "1-2,11-12", field: month, want: cronBits(1, 2, 11, 12)},
+		{name: "unter dem minimum", value: "0", field: month, wantErr: true},
+		{name: "über dem maximum", value: "60", field: minute, wantErr: true},
+		{name: "umgekehrter bereich", value: "12-9", field: hour, wantErr: true},
+		{name: "schrittweite null", value: "*/0", field: minute, wantErr: true},
+		{name: "ungültige schrittweite", value: "*/x", field: minute, wantErr: true},
+		{name: "kein zahlenwert", value: "mon", field: minute, wantErr: true},
+		{name: "leerer listeneintrag", value: "1,", field: minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCronField(tt.value, tt.field)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fehler erwartet für %q, maske %b erhalten", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseCronField(%q) = %b, erwartet %b", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJobSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("zeitzonendaten nicht verfügbar: %v", err)
+	}
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, berlin)
+	}
+	// Mittwoch, 15. Januar 2025, 10:30:20 Uhr
+	after := time.Date(2025, time.January, 15, 10, 30, 20, 0, berlin)
+
+	tests := []struct {
+		name    string
+		spec    string
+		after   time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "intervall", spec: "@every 90m", after: after, want: after.Add(90 * time.Minute)},
+		{name: "intervall mit leerzeichen", spec: "  @every 1h  ", after: after, want: after.Add(time.Hour)},
+		{name: "stündlich", spec: "@hourly", after: after, want: at(2025, 1, 15, 11, 0)},
+		{name: "täglich", spec: "@daily", after: after, want: at(2025, 1, 16, 0, 0)},
+		{name: "wöchentlich am sonntag", spec: "@weekly", after: after, want: at(2025, 1, 19, 0, 0)},
+		{name: "monatlich", spec: "@monthly", after: after, want: at(2025, 2, 1, 0, 0)},
+		{name: "nächste volle minute", spec: "* * * * *", after: after, want: at(2025, 1, 15, 10, 31)},
+		{name: "exakt zur passenden minute zählt nicht", spec: "30 10 * * *", after: at(2025, 1, 15, 10, 30), want: at(2025, 1, 16, 10, 30)},
+		{name: "werktags um sieben", spec: "0 7 * * 1-5", after: at(2025, 1, 17, 8, 0), want: at(2025, 1, 20, 7, 0)},
+		{name: "wochentag 7 ist sonntag", spec: "0 6 * * 7", after: after, want: at(2025, 1, 19, 6, 0)},
+		{name: "tag oder wochentag", spec: "0 0 20 * 5", after: after, want: at(2025, 1, 17, 0, 0)},
+		{name: "monatsende wird übersprungen", spec: "0 12 31 * *", after: at(2025, 2, 1, 0, 0), want: at(2025, 3, 31, 12, 0)},
+		{name: "schaltjahr", spec: "0 0 29 2 *", after: after, want: at(2028, 2, 29, 0, 0)},
+		{name: "zeitumstellung im frühjahr", spec: "30 2 * * *", after: at(2025, 3, 29, 12, 0), want: at(2025, 3, 31, 2, 30)},
+		{name: "unerfüllbarer ausdruck", spec: "0 0 30 2 *", after: after, want: time.Time{}},
+		{name: "intervall unter einer minute", spec: "@every 30s", wantErr: true},
+		{name: "ungültiges intervall", spec: "@every bald", wantErr: true},
+		{name: "zu wenige felder", spec: "0 7 * *", wantErr: true},
+		{name: "zu viele felder", spec: "0 0 7 * * *", wantErr: true},
+		{name: "unbekannte kurzform", spec: "@yearly", wantErr: true},
+		{name: "ungültige stunde", spec: "0 24 * * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseJobSchedule(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fehler erwartet für %q", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if got := schedule.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, erwartet %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
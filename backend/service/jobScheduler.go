@@ -0,0 +1,385 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// jobTickInterval legt fest, wie oft der Scheduler nach fälligen Jobs sucht
+	jobTickInterval = 15 * time.Second
+	// jobLeaseDuration ist die Dauer einer Sperre; ohne Verlängerung darf danach eine andere Instanz den Job übernehmen
+	jobLeaseDuration = 30 * time.Minute
+	// jobLeaseRenewInterval legt fest, wie oft die Sperre eines laufenden Jobs verlängert wird
+	jobLeaseRenewInterval = 10 * time.Minute
+)
+
+var (
+	// ErrJobNotFound wird für unbekannte Jobnamen zurückgegeben
+	ErrJobNotFound = errors.New("job nicht gefunden")
+	// ErrJobRunning wird zurückgegeben, wenn der Job bereits von einer Instanz ausgeführt wird
+	ErrJobRunning = errors.New("job wird bereits ausgeführt")
+	// ErrJobSkipped kann von einer JobFunc zurückgegeben werden, wenn nichts zu tun war;
+	// solche Läufe werden nicht in der Historie gespeichert
+	ErrJobSkipped = errors.New("job übersprungen")
+)
+
+// JobRunContext enthält Informationen zum aktuellen Lauf eines Jobs
+type JobRunContext struct {
+	Trigger   model.JobTrigger
+	LastRunAt *time.Time // Start des letzten nicht übersprungenen Laufs
+}
+
+// JobFunc ist die Arbeitsfunktion eines Hintergrundjobs
+type JobFunc func(run JobRunContext) error
+
+// registeredJob ist ein im Scheduler registrierter Job
+type registeredJob struct {
+	name        string
+	description string
+	spec        string
+	schedule    JobSchedule
+	fn          JobFunc
+}
+
+// JobScheduler führt registrierte Hintergrundjobs nach Zeitplan aus. Zustand, Sperren und
+// Laufhistorie liegen im Repository, sodass mehrere Instanzen sich einen Datenbestand teilen können.
+type JobScheduler struct {
+	jobRepo    repository.ScheduledJobRepository
+	instanceID string
+
+	mu       sync.Mutex
+	jobs     map[string]*registeredJob
+	order    []string
+	running  bool
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewJobScheduler erstellt einen neuen JobScheduler
+func NewJobScheduler(jobRepo repository.ScheduledJobRepository) *JobScheduler {
+	return &JobScheduler{
+		jobRepo:    jobRepo,
+		instanceID: newInstanceID(),
+		jobs:       make(map[string]*registeredJob),
+	}
+}
+
+// Register meldet einen Job beim Scheduler an und legt ihn bei Bedarf im Repository an. Der erste
+// Lauf eines neuen Jobs richtet sich nach dem Zeitplan; bei bestehenden Jobs bleibt der gespeicherte
+// nächste Lauf erhalten und wird nur neu berechnet, wenn sich der Zeitplan geändert hat.
+func (s *JobScheduler) Register(name, description, spec string, fn JobFunc) error {
+	schedule, err := ParseJobSchedule(spec)
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("zeitplan %q von job %s wird nie fällig", spec, name)
+	}
+
+	stored, err := s.jobRepo.FindByName(name)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		job := &model.ScheduledJob{
+			Name:        name,
+			Description: description,
+			Schedule:    spec,
+			NextRunAt:   schedule.Next(time.Now()),
+		}
+		if err := s.jobRepo.Create(job); err != nil {
+			// Eine andere Instanz kann den Job parallel angelegt haben
+			if existing, _ := s.jobRepo.FindByName(name); existing == nil {
+				return err
+			}
+		}
+	} else if stored.Schedule != spec || stored.Description != description {
+		nextRunAt := stored.NextRunAt
+		if stored.Schedule != spec {
+			nextRunAt = schedule.Next(time.Now())
+		}
+		if err := s.jobRepo.UpdateDefinition(name, description, spec, nextRunAt); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[name]; !exists {
+		s.order = append(s.order, name)
+	}
+	s.jobs[name] = &registeredJob{name: name, description: description, spec: spec, schedule: schedule, fn: fn}
+	return nil
+}
+
+// Start startet die Ausführung der registrierten Jobs
+func (s *JobScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+
+	s.wg.Add(1)
+	go func(stop <-chan struct{}) {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(jobTickInterval)
+		defer ticker.Stop()
+
+		s.runDueJobs()
+		for {
+			select {
+			case <-ticker.C:
+				s.runDueJobs()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stopChan)
+}
+
+// Stop beendet den Scheduler und wartet auf laufende Jobs
+func (s *JobScheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stopChan)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// IsRunning gibt zurück, ob der Scheduler läuft
+func (s *JobScheduler) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Jobs liefert den gespeicherten Zustand aller registrierten Jobs
+func (s *JobScheduler) Jobs() ([]*model.ScheduledJob, error) {
+	stored, err := s.jobRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*model.ScheduledJob
+	for _, job := range stored {
+		if s.lookup(job.Name) != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// Runs liefert die letzten Läufe eines Jobs
+func (s *JobScheduler) Runs(name string, limit int) ([]*model.JobRun, error) {
+	if s.lookup(name) == nil {
+		return nil, ErrJobNotFound
+	}
+	return s.jobRepo.FindRuns(name, limit)
+}
+
+// Pause pausiert einen Job; laufende Ausführungen werden nicht abgebrochen
+func (s *JobScheduler) Pause(name string) error {
+	if s.lookup(name) == nil {
+		return ErrJobNotFound
+	}
+	return s.jobRepo.SetPaused(name, true)
+}
+
+// Resume setzt einen pausierten Job fort
+func (s *JobScheduler) Resume(name string) error {
+	if s.lookup(name) == nil {
+		return ErrJobNotFound
+	}
+	return s.jobRepo.SetPaused(name, false)
+}
+
+// Trigger startet einen Job sofort im Hintergrund, auch wenn er pausiert ist.
+// Der planmäßige nächste Lauf bleibt unverändert.
+func (s *JobScheduler) Trigger(name string) error {
+	job := s.lookup(name)
+	if job == nil {
+		return ErrJobNotFound
+	}
+
+	state, err := s.jobRepo.FindByName(name)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return ErrJobNotFound
+	}
+
+	acquired, err := s.jobRepo.AcquireLease(name, s.instanceID, time.Now().Add(jobLeaseDuration), nil)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrJobRunning
+	}
+
+	s.wg.Add(1)
+	go s.execute(job, state, model.JobTriggerManual)
+	return nil
+}
+
+// runDueJobs startet alle fälligen, nicht pausierten Jobs, für die diese Instanz die Sperre erhält
+func (s *JobScheduler) runDueJobs() {
+	s.mu.Lock()
+	jobs := make([]*registeredJob, 0, len(s.order))
+	for _, name := range s.order {
+		jobs = append(jobs, s.jobs[name])
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	for _, job := range jobs {
+		state, err := s.jobRepo.FindByName(job.name)
+		if err != nil || state == nil {
+			log.Printf("⚠️  Job %s konnte nicht geladen werden: %v", job.name, err)
+			continue
+		}
+		if state.Paused || state.NextRunAt.After(now) || state.IsRunning() {
+			continue
+		}
+
+		acquired, err := s.jobRepo.AcquireLease(job.name, s.instanceID, now.Add(jobLeaseDuration), &now)
+		if err != nil {
+			log.Printf("⚠️  Sperre für Job %s fehlgeschlagen: %v", job.name, err)
+			continue
+		}
+		if !acquired {
+			continue // Andere Instanz war schneller
+		}
+
+		s.wg.Add(1)
+		go s.execute(job, state, model.JobTriggerSchedule)
+	}
+}
+
+// execute führt einen Job aus, protokolliert den Lauf und gibt die Sperre frei
+func (s *JobScheduler) execute(job *registeredJob, state *model.ScheduledJob, trigger model.JobTrigger) {
+	defer s.wg.Done()
+
+	startedAt := time.Now()
+	stopRenew := s.renewLease(job.name)
+	err := runJobFunc(job.fn, JobRunContext{Trigger: trigger, LastRunAt: state.LastRunAt})
+	stopRenew()
+	finishedAt := time.Now()
+
+	// Manuelle Läufe verschieben den Zeitplan nicht
+	nextRunAt := state.NextRunAt
+	if trigger == model.JobTriggerSchedule {
+		nextRunAt = job.schedule.Next(startedAt)
+	}
+
+	if errors.Is(err, ErrJobSkipped) {
+		if err := s.jobRepo.ReleaseLease(job.name, s.instanceID, nextRunAt); err != nil {
+			log.Printf("⚠️  Sperre für Job %s konnte nicht freigegeben werden: %v", job.name, err)
+		}
+		return
+	}
+
+	run := &model.JobRun{
+		JobName:    job.name,
+		Trigger:    trigger,
+		Instance:   s.instanceID,
+		Status:     model.JobRunStatusSuccess,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		DurationMs: finishedAt.Sub(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		run.Status = model.JobRunStatusFailed
+		run.Error = err.Error()
+		log.Printf("⚠️  Job %s fehlgeschlagen: %v", job.name, err)
+	}
+
+	if err := s.jobRepo.CreateRun(run); err != nil {
+		log.Printf("⚠️  Lauf von Job %s konnte nicht gespeichert werden: %v", job.name, err)
+	}
+	if err := s.jobRepo.CompleteRun(job.name, s.instanceID, run, nextRunAt); err != nil {
+		log.Printf("⚠️  Sperre für Job %s konnte nicht freigegeben werden: %v", job.name, err)
+	}
+}
+
+// renewLease verlängert die Sperre eines Jobs regelmäßig, bis die zurückgegebene Funktion aufgerufen wird,
+// damit lange Läufe nicht nach jobLeaseDuration von einer anderen Instanz erneut gestartet werden
+func (s *JobScheduler) renewLease(name string) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		ticker := time.NewTicker(jobLeaseRenewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				renewed, err := s.jobRepo.RenewLease(name, s.instanceID, time.Now().Add(jobLeaseDuration))
+				if err != nil {
+					log.Printf("⚠️  Sperre für Job %s konnte nicht verlängert werden: %v", name, err)
+				} else if !renewed {
+					log.Printf("⚠️  Sperre für Job %s gehört nicht mehr dieser Instanz", name)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// lookup liefert einen registrierten Job oder nil
+func (s *JobScheduler) lookup(name string) *registeredJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[name]
+}
+
+// runJobFunc führt die Arbeitsfunktion aus und wandelt Panics in Fehler um
+func runJobFunc(fn JobFunc, run JobRunContext) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(run)
+}
+
+// newInstanceID erzeugt eine eindeutige Kennung dieser FleetFlow-Instanz für die Job-Sperren
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "fleetflow"
+	}
+
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(buf))
+}
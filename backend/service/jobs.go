@@ -0,0 +1,77 @@
+package service
+
+// Namen der registrierten Hintergrundjobs
+const (
+	JobReservationProcessing = "reservation-processing"
+	JobPeopleFlowAutoSync    = "peopleflow-auto-sync"
+	JobExpiryReminders       = "expiry-reminders"
+	JobMaintenancePlans      = "maintenance-plans"
+	JobTrafficFineDeadlines  = "traffic-fine-deadlines"
+	JobLeaseMonitoring       = "lease-monitoring"
+	JobBulkImport            = "bulk-import"
+	JobFuelReviewReminders   = "fuel-review-reminders"
+)
+
+// registerJobs meldet alle Hintergrundjobs beim Scheduler an
+func registerJobs(scheduler *JobScheduler, services *Services) error {
+	if err := scheduler.Register(JobReservationProcessing,
+		"Aktiviert fällige und schließt abgelaufene Reservierungen ab, erinnert Fahrer an anstehende Buchungen", "@every 1m",
+		func(JobRunContext) error { return services.Reservation.ProcessScheduledReservations() },
+	); err != nil {
+		return err
+	}
+
+	if err := scheduler.Register(JobPeopleFlowAutoSync,
+		"Synchronisiert Mitarbeiter aus PeopleFlow gemäß dem eingestellten Sync-Intervall", "@every 1m",
+		services.PeopleFlow.RunAutoSync,
+	); err != nil {
+		return err
+	}
+
+	// Stündlich, damit während einer Ruhezeit zurückgehaltene Erinnerungen danach zugestellt werden;
+	// jede Erinnerungsstufe geht trotzdem nur einmal pro Empfänger raus
+	if err := scheduler.Register(JobExpiryReminders,
+		"Erinnert an ablaufende Führerscheine, Dokumente, Versicherungen, HU-Termine und Leasingverträge", "0 * * * *",
+		services.ExpiryReminder.RunReminders,
+	); err != nil {
+		return err
+	}
+
+	// Täglich vor Arbeitsbeginn, damit die Tagesfahrleistung des Vortags eingerechnet ist
+	if err := scheduler.Register(JobMaintenancePlans,
+		"Rechnet Wartungspläne auf den aktuellen Kilometerstand hoch und erzeugt fällige Wartungen", "0 5 * * *",
+		services.MaintenancePlan.RunPlans,
+	); err != nil {
+		return err
+	}
+
+	// Täglich, die Erinnerung geht je Bußgeld nur einmal raus
+	if err := scheduler.Register(JobTrafficFineDeadlines,
+		"Erinnert an Bußgelder, deren Frist zur Fahrerbenennung bald abläuft", "0 7 * * *",
+		services.TrafficFine.RunDeadlineReminders,
+	); err != nil {
+		return err
+	}
+
+	// Täglich nach den Wartungsplänen; Checklisten und Mehrkilometer-Warnungen entstehen je Vertrag nur einmal
+	if err := scheduler.Register(JobLeaseMonitoring,
+		"Legt Checklisten für Leasingrückgaben an und warnt vor drohenden Mehrkilometern", "30 6 * * *",
+		services.Lease.RunLeaseMonitoring,
+	); err != nil {
+		return err
+	}
+
+	// Die Übernahme startet den Job sofort; der Intervall-Lauf greift, wenn er gerade beschäftigt war
+	if err := scheduler.Register(JobBulkImport,
+		"Legt die Fahrzeuge und Fahrer übernommener Massenimporte an", "@every 1m",
+		services.BulkImport.RunQueuedImports,
+	); err != nil {
+		return err
+	}
+
+	// Werktäglich, solange Tankvorgänge aus Tankkartenimporten auf eine Zuordnung warten
+	return scheduler.Register(JobFuelReviewReminders,
+		"Erinnert Manager an Tankvorgänge, die noch keinem Fahrzeug zugeordnet sind", "0 8 * * 1-5",
+		services.FuelImport.RunReviewReminders,
+	)
+}
@@ -0,0 +1,544 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// leaseSwapMinDays ist die Restlaufzeit, ab der sich ein Fahrzeugtausch noch lohnt
+	leaseSwapMinDays = 90
+	// leaseSwapMinSavings ist die Mindestersparnis in Euro für einen Tauschvorschlag
+	leaseSwapMinSavings = 100.0
+)
+
+var (
+	// ErrLeaseReturnNotFound wird für unbekannte Leasingrückgaben zurückgegeben
+	ErrLeaseReturnNotFound = errors.New("leasingrückgabe nicht gefunden")
+	// ErrLeaseReturnExists wird zurückgegeben, wenn für den Leasingvertrag bereits eine Rückgabe angelegt ist
+	ErrLeaseReturnExists = errors.New("für diesen leasingvertrag existiert bereits eine rückgabe")
+	// ErrLeaseReturnState wird zurückgegeben, wenn die Rückgabe bereits abgeschlossen ist
+	ErrLeaseReturnState = errors.New("die leasingrückgabe ist bereits abgeschlossen")
+)
+
+// LeaseReturnInput enthält die änderbaren Angaben einer Leasingrückgabe
+type LeaseReturnInput struct {
+	AppointmentDate *time.Time
+	Notes           string
+}
+
+// LeaseReturnCompletion enthält die Angaben bei Rückgabe des Fahrzeugs
+type LeaseReturnCompletion struct {
+	ReturnMileage    int
+	SettlementAmount float64 // Laut Schlussabrechnung, ohne Angabe aus den Mehrkilometern berechnet
+	Notes            string
+}
+
+// LeaseService rechnet die Fahrleistung von Leasingfahrzeugen bis Vertragsende hoch, schlägt
+// Fahrzeugtausche zur Vermeidung von Mehrkilometern vor und führt die Checklisten zur Rückgabe
+type LeaseService struct {
+	leaseReturnRepo     repository.LeaseReturnRepository
+	vehicleRepo         repository.VehicleRepository
+	driverRepo          repository.DriverRepository
+	reminderRepo        repository.ExpiryReminderRepository
+	mileageService      *VehicleMileageService
+	eligibilityService  *EligibilityService
+	notificationService *NotificationService
+	activityService     *ActivityService
+}
+
+// NewLeaseService erstellt einen neuen LeaseService
+func NewLeaseService(leaseReturnRepo repository.LeaseReturnRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, reminderRepo repository.ExpiryReminderRepository, mileageService *VehicleMileageService, eligibilityService *EligibilityService, notificationService *NotificationService, activityService *ActivityService) *LeaseService {
+	return &LeaseService{
+		leaseReturnRepo:     leaseReturnRepo,
+		vehicleRepo:         vehicleRepo,
+		driverRepo:          driverRepo,
+		reminderRepo:        reminderRepo,
+		mileageService:      mileageService,
+		eligibilityService:  eligibilityService,
+		notificationService: notificationService,
+		activityService:     activityService,
+	}
+}
+
+// GetForecasts rechnet alle laufenden Leasingverträge hoch, die höchsten erwarteten Mehrkosten zuerst
+func (s *LeaseService) GetForecasts() ([]model.LeaseForecast, error) {
+	vehicles, err := s.activeLeases()
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := make([]model.LeaseForecast, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		forecasts = append(forecasts, s.forecast(vehicle))
+	}
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		if forecasts[i].ExpectedExcessCost != forecasts[j].ExpectedExcessCost {
+			return forecasts[i].ExpectedExcessCost > forecasts[j].ExpectedExcessCost
+		}
+		return forecasts[i].UsagePercent > forecasts[j].UsagePercent
+	})
+	return forecasts, nil
+}
+
+// GetForecast rechnet den Leasingvertrag eines Fahrzeugs hoch
+func (s *LeaseService) GetForecast(vehicleID string) (*model.LeaseForecast, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	if vehicle.AcquisitionType != model.AcquisitionTypeLeased || vehicle.LeaseEndDate.IsZero() {
+		return nil, fmt.Errorf("das fahrzeug hat keinen leasingvertrag mit enddatum")
+	}
+	forecast := s.forecast(vehicle)
+	return &forecast, nil
+}
+
+// GetSwapSuggestions schlägt vor, die festen Fahrer von Fahrzeugen mit drohenden Mehrkilometern
+// und Fahrzeugen mit Reserve zu tauschen. Grundlage ist die bisherige Tagesfahrleistung der Fahrzeuge,
+// jedes Fahrzeug erscheint höchstens in einem Vorschlag.
+func (s *LeaseService) GetSwapSuggestions() ([]model.LeaseSwapSuggestion, error) {
+	forecasts, err := s.GetForecasts()
+	if err != nil {
+		return nil, err
+	}
+
+	var high, low []model.LeaseForecast
+	for _, forecast := range forecasts {
+		if forecast.DriverID == nil || forecast.DaysRemaining < leaseSwapMinDays || forecast.DailyKm <= 0 || forecast.AllowedMileage <= 0 {
+			continue
+		}
+		if forecast.ProjectedExcessKm > 0 {
+			high = append(high, forecast)
+		} else {
+			low = append(low, forecast)
+		}
+	}
+
+	var candidates []model.LeaseSwapSuggestion
+	for _, h := range high {
+		for _, l := range low {
+			if h.DailyKm <= l.DailyKm {
+				continue
+			}
+			// Nach dem Tausch fährt jedes Fahrzeug für die Restlaufzeit mit der Tagesfahrleistung des anderen Fahrers
+			highAfter := h.ProjectedMileage + int(math.Round((l.DailyKm-h.DailyKm)*float64(h.DaysRemaining)))
+			lowAfter := l.ProjectedMileage + int(math.Round((h.DailyKm-l.DailyKm)*float64(l.DaysRemaining)))
+			current := h.ExpectedExcessCost + l.ExpectedExcessCost
+			after := excessCost(highAfter, h) + excessCost(lowAfter, l)
+			if current-after < leaseSwapMinSavings {
+				continue
+			}
+			if !s.canSwap(h, l) {
+				continue
+			}
+			candidates = append(candidates, model.LeaseSwapSuggestion{
+				HighMileage:         h,
+				LowMileage:          l,
+				CurrentExcessCost:   roundCents(current),
+				ExcessCostAfterSwap: roundCents(after),
+				Savings:             roundCents(current - after),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Savings > candidates[j].Savings })
+	used := make(map[primitive.ObjectID]bool)
+	suggestions := []model.LeaseSwapSuggestion{}
+	for _, candidate := range candidates {
+		if used[candidate.HighMileage.VehicleID] || used[candidate.LowMileage.VehicleID] {
+			continue
+		}
+		used[candidate.HighMileage.VehicleID] = true
+		used[candidate.LowMileage.VehicleID] = true
+		suggestions = append(suggestions, candidate)
+	}
+	return suggestions, nil
+}
+
+// GetReturns gibt die Leasingrückgaben mit dem Status zurück (leer = alle)
+func (s *LeaseService) GetReturns(status model.LeaseReturnStatus) ([]*model.LeaseReturn, error) {
+	return s.leaseReturnRepo.FindByStatus(status)
+}
+
+// GetReturn gibt eine Leasingrückgabe zurück
+func (s *LeaseService) GetReturn(id string) (*model.LeaseReturn, error) {
+	leaseReturn, err := s.leaseReturnRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrLeaseReturnNotFound
+	}
+	return leaseReturn, nil
+}
+
+// StartReturn legt die Rückgabe-Checkliste vorzeitig von Hand an
+func (s *LeaseService) StartReturn(user *model.User, vehicleID string) (*model.LeaseReturn, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	if vehicle.AcquisitionType != model.AcquisitionTypeLeased || vehicle.LeaseEndDate.IsZero() {
+		return nil, fmt.Errorf("das fahrzeug hat keinen leasingvertrag mit enddatum")
+	}
+
+	leaseReturn, err := s.createReturn(vehicle, &user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.activityService.LogActivity(
+		"lease_return_started",
+		fmt.Sprintf("Leasingrückgabe für %s zum %s angelegt", vehicle.LicensePlate, vehicle.LeaseEndDate.Format("02.01.2006")),
+		user.ID,
+		&vehicle.ID,
+	)
+	return leaseReturn, nil
+}
+
+// UpdateReturn ändert Rückgabetermin und Notizen
+func (s *LeaseService) UpdateReturn(id string, input LeaseReturnInput) (*model.LeaseReturn, error) {
+	leaseReturn, err := s.openReturn(id)
+	if err != nil {
+		return nil, err
+	}
+	leaseReturn.AppointmentDate = input.AppointmentDate
+	leaseReturn.Notes = input.Notes
+	if err := s.leaseReturnRepo.Update(leaseReturn); err != nil {
+		return nil, err
+	}
+	return leaseReturn, nil
+}
+
+// UpdateItem hakt einen Punkt der Checkliste ab oder öffnet ihn wieder
+func (s *LeaseService) UpdateItem(user *model.User, id, key string, done bool, notes string) (*model.LeaseReturn, error) {
+	leaseReturn, err := s.openReturn(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *model.LeaseReturnItem
+	for i := range leaseReturn.Items {
+		if leaseReturn.Items[i].Key == key {
+			item = &leaseReturn.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf("unbekannter checklistenpunkt: %s", key)
+	}
+
+	if done && !item.Done {
+		now := time.Now()
+		item.DoneAt = &now
+		item.DoneBy = &user.ID
+	} else if !done {
+		item.DoneAt = nil
+		item.DoneBy = nil
+	}
+	item.Done = done
+	item.Notes = notes
+
+	if err := s.leaseReturnRepo.Update(leaseReturn); err != nil {
+		return nil, err
+	}
+	return leaseReturn, nil
+}
+
+// CompleteReturn schließt die Rückgabe ab, sobald alle Punkte der Checkliste erledigt sind,
+// und rechnet die Mehr- oder Minderkilometer laut Kilometerstand bei Rückgabe ab
+func (s *LeaseService) CompleteReturn(user *model.User, id string, completion LeaseReturnCompletion) (*model.LeaseReturn, error) {
+	leaseReturn, err := s.openReturn(id)
+	if err != nil {
+		return nil, err
+	}
+	if open := leaseReturn.OpenItems(); open > 0 {
+		return nil, fmt.Errorf("%d punkte der checkliste sind noch offen", open)
+	}
+	vehicle, err := s.vehicleRepo.FindByID(leaseReturn.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	if completion.ReturnMileage < vehicle.Mileage {
+		return nil, fmt.Errorf("der kilometerstand bei rückgabe liegt unter dem aktuellen stand von %d km", vehicle.Mileage)
+	}
+
+	forecast := s.forecast(vehicle)
+	leaseReturn.ReturnMileage = completion.ReturnMileage
+	leaseReturn.ExcessKm = completion.ReturnMileage - forecast.StartMileage - forecast.AllowedMileage
+	leaseReturn.SettlementAmount = completion.SettlementAmount
+	if leaseReturn.SettlementAmount == 0 && leaseReturn.ExcessKm > 0 {
+		leaseReturn.SettlementAmount = roundCents(float64(leaseReturn.ExcessKm) * vehicle.LeaseExcessMileageCost)
+	}
+	if completion.Notes != "" {
+		leaseReturn.Notes = completion.Notes
+	}
+	now := time.Now()
+	leaseReturn.Status = model.LeaseReturnStatusCompleted
+	leaseReturn.CompletedAt = &now
+	leaseReturn.CompletedBy = &user.ID
+
+	if err := s.leaseReturnRepo.Update(leaseReturn); err != nil {
+		return nil, err
+	}
+
+	s.activityService.LogActivity(
+		"lease_return_completed",
+		fmt.Sprintf("Leasingfahrzeug %s mit %d km zurückgegeben", vehicle.LicensePlate, completion.ReturnMileage),
+		user.ID,
+		&vehicle.ID,
+	)
+	return leaseReturn, nil
+}
+
+// DeleteReturn löscht eine offene Leasingrückgabe, z.B. nach Verlängerung des Vertrags
+func (s *LeaseService) DeleteReturn(id string) error {
+	if _, err := s.openReturn(id); err != nil {
+		return err
+	}
+	return s.leaseReturnRepo.Delete(id)
+}
+
+// RunLeaseMonitoring legt Rückgabe-Checklisten ab 90 Tagen vor Leasingende an und warnt Manager
+// einmal je Vertrag, sobald ein Fahrzeug auf Mehrkilometer zusteuert
+func (s *LeaseService) RunLeaseMonitoring(run JobRunContext) error {
+	vehicles, err := s.activeLeases()
+	if err != nil {
+		return err
+	}
+	managers, err := s.notificationService.getManagersAndAdmins()
+	if err != nil {
+		return err
+	}
+
+	today := calendarDay(time.Now().In(berlinLocation()))
+	returnFrom := today.AddDate(0, 0, model.LeaseReturnLeadDays)
+	created, alerted, failed := 0, 0, 0
+	for _, vehicle := range vehicles {
+		if !calendarDay(vehicle.LeaseEndDate).After(returnFrom) {
+			leaseReturn, err := s.createReturn(vehicle, nil)
+			switch {
+			case errors.Is(err, ErrLeaseReturnExists):
+			case err != nil:
+				log.Printf("Fehler beim Anlegen der Leasingrückgabe für %s: %v", vehicle.LicensePlate, err)
+				failed++
+			default:
+				created++
+				if err := s.notificationService.NotifyLeaseReturnStarted(leaseReturn, vehicle); err != nil {
+					log.Printf("Fehler bei der Benachrichtigung zur Leasingrückgabe %s: %v", leaseReturn.ID.Hex(), err)
+				}
+			}
+		}
+
+		forecast := s.forecast(vehicle)
+		if !forecast.OnTrackToExceed || forecast.DaysRemaining == 0 {
+			continue
+		}
+		expiryDate := calendarDay(vehicle.LeaseEndDate)
+		for _, manager := range managers {
+			sent, err := s.reminderRepo.HasBeenSent(model.ExpirySubjectLeaseMileage, vehicle.ID, expiryDate, 0, manager.Email)
+			if err != nil {
+				return err
+			}
+			if sent {
+				continue
+			}
+			notified, err := s.notificationService.NotifyLeaseMileageOverage(manager, vehicle, &forecast)
+			if err != nil {
+				log.Printf("Fehler beim Senden der Mehrkilometer-Warnung an %s: %v", manager.Email, err)
+				failed++
+				continue
+			}
+			if !notified {
+				continue
+			}
+			reminder := &model.ExpiryReminder{
+				SubjectType: model.ExpirySubjectLeaseMileage,
+				SubjectID:   vehicle.ID,
+				Title:       fmt.Sprintf("%s für %s", model.ExpirySubjectText[model.ExpirySubjectLeaseMileage], vehicle.LicensePlate),
+				ExpiryDate:  expiryDate,
+				Recipient:   manager.Email,
+				SentAt:      time.Now(),
+			}
+			if err := s.reminderRepo.Create(reminder); err != nil {
+				return err
+			}
+			alerted++
+		}
+	}
+
+	log.Printf("Leasingüberwachung: %d Rückgaben angelegt, %d Mehrkilometer-Warnungen versendet", created, alerted)
+	if failed > 0 {
+		return fmt.Errorf("%d vorgänge der leasingüberwachung sind fehlgeschlagen", failed)
+	}
+	return nil
+}
+
+// activeLeases liefert alle Leasingfahrzeuge, deren Vertrag heute oder später endet
+func (s *LeaseService) activeLeases() ([]*model.Vehicle, error) {
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	today := calendarDay(time.Now().In(berlinLocation()))
+	var leased []*model.Vehicle
+	for _, vehicle := range vehicles {
+		if vehicle.AcquisitionType != model.AcquisitionTypeLeased || vehicle.LeaseEndDate.IsZero() {
+			continue
+		}
+		if calendarDay(vehicle.LeaseEndDate).Before(today) {
+			continue
+		}
+		leased = append(leased, vehicle)
+	}
+	return leased, nil
+}
+
+// forecast rechnet den Leasingvertrag eines Fahrzeugs hoch und ergänzt den festen Fahrer
+func (s *LeaseService) forecast(vehicle *model.Vehicle) model.LeaseForecast {
+	forecast := forecastLease(vehicle, s.mileageService.datedReadings(vehicle.ID.Hex()), time.Now())
+	if !vehicle.CurrentDriverID.IsZero() {
+		driverID := vehicle.CurrentDriverID
+		forecast.DriverID = &driverID
+		if driver, err := s.driverRepo.FindByID(driverID.Hex()); err == nil {
+			forecast.DriverName = driver.FirstName + " " + driver.LastName
+		}
+	}
+	return forecast
+}
+
+// canSwap prüft, ob beide Fahrer das jeweils andere Fahrzeug fahren dürfen
+func (s *LeaseService) canSwap(high, low model.LeaseForecast) bool {
+	highVehicle, err := s.vehicleRepo.FindByID(high.VehicleID.Hex())
+	if err != nil {
+		return false
+	}
+	lowVehicle, err := s.vehicleRepo.FindByID(low.VehicleID.Hex())
+	if err != nil {
+		return false
+	}
+	highDriver, err := s.driverRepo.FindByID(high.DriverID.Hex())
+	if err != nil {
+		return false
+	}
+	lowDriver, err := s.driverRepo.FindByID(low.DriverID.Hex())
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	return s.eligibilityService.CheckDriverEligibility(highDriver, lowVehicle, now) == nil &&
+		s.eligibilityService.CheckDriverEligibility(lowDriver, highVehicle, now) == nil
+}
+
+// createReturn legt die Rückgabe-Checkliste für den aktuellen Leasingvertrag an
+func (s *LeaseService) createReturn(vehicle *model.Vehicle, createdBy *primitive.ObjectID) (*model.LeaseReturn, error) {
+	if _, err := s.leaseReturnRepo.FindByVehicleAndEndDate(vehicle.ID, vehicle.LeaseEndDate); err == nil {
+		return nil, ErrLeaseReturnExists
+	}
+
+	leaseReturn := &model.LeaseReturn{
+		VehicleID:        vehicle.ID,
+		LeaseEndDate:     vehicle.LeaseEndDate,
+		LeaseCompany:     vehicle.LeaseCompany,
+		ContractNumber:   vehicle.LeaseContractNumber,
+		Items:            append([]model.LeaseReturnItem(nil), model.DefaultLeaseReturnItems...),
+		ForecastExcessKm: s.forecast(vehicle).ProjectedExcessKm,
+		Status:           model.LeaseReturnStatusOpen,
+		CreatedBy:        createdBy,
+	}
+	if err := s.leaseReturnRepo.Create(leaseReturn); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrLeaseReturnExists
+		}
+		return nil, err
+	}
+	return leaseReturn, nil
+}
+
+// openReturn lädt eine noch nicht abgeschlossene Leasingrückgabe
+func (s *LeaseService) openReturn(id string) (*model.LeaseReturn, error) {
+	leaseReturn, err := s.GetReturn(id)
+	if err != nil {
+		return nil, err
+	}
+	if leaseReturn.Status != model.LeaseReturnStatusOpen {
+		return nil, ErrLeaseReturnState
+	}
+	return leaseReturn, nil
+}
+
+// forecastLease rechnet die Fahrleistung eines Leasingvertrags mit der Tagesfahrleistung der letzten
+// Monate bis zum Vertragsende hoch. Die vereinbarte Laufleistung gilt je Vertragsjahr.
+func forecastLease(vehicle *model.Vehicle, readings []mileageReading, now time.Time) model.LeaseForecast {
+	forecast := model.LeaseForecast{
+		VehicleID:         vehicle.ID,
+		LicensePlate:      vehicle.LicensePlate,
+		Brand:             vehicle.Brand,
+		Model:             vehicle.Model,
+		LeaseStartDate:    vehicle.LeaseStartDate,
+		LeaseEndDate:      vehicle.LeaseEndDate,
+		CurrentMileage:    vehicle.Mileage,
+		ExcessMileageRate: vehicle.LeaseExcessMileageCost,
+		Warnings:          []string{},
+	}
+
+	// Ausgangsstand ist der letzte Kilometerstand bis Leasingbeginn, sonst ein Neufahrzeug
+	baseline := mileageReading{date: vehicle.LeaseStartDate}
+	for _, reading := range readings {
+		if !reading.date.After(vehicle.LeaseStartDate) && reading.mileage > baseline.mileage {
+			baseline.mileage = reading.mileage
+		}
+		if reading.mileage > forecast.CurrentMileage && !reading.date.After(now) {
+			forecast.CurrentMileage = reading.mileage
+		}
+	}
+	forecast.StartMileage = baseline.mileage
+
+	if vehicle.LeaseStartDate.IsZero() || !vehicle.LeaseEndDate.After(vehicle.LeaseStartDate) {
+		forecast.Warnings = append(forecast.Warnings, "Leasingbeginn fehlt, keine Hochrechnung möglich")
+		return forecast
+	}
+
+	today := calendarDay(now)
+	if remaining := calendarDay(vehicle.LeaseEndDate).Sub(today).Hours() / 24; remaining > 0 {
+		forecast.DaysRemaining = int(remaining)
+	}
+	forecast.DailyKm = dailyKmTrend(readings, baseline, forecast.CurrentMileage, now)
+	if forecast.DailyKm == 0 && forecast.DaysRemaining > 0 {
+		forecast.Warnings = append(forecast.Warnings, "Zu wenige Kilometerstände für eine Hochrechnung")
+	}
+
+	driven := forecast.CurrentMileage - forecast.StartMileage
+	forecast.ProjectedMileage = driven + int(math.Round(forecast.DailyKm*float64(forecast.DaysRemaining)))
+
+	if vehicle.LeaseMileageLimit <= 0 {
+		forecast.Warnings = append(forecast.Warnings, "Keine vereinbarte Laufleistung hinterlegt")
+		return forecast
+	}
+	contractYears := vehicle.LeaseEndDate.Sub(vehicle.LeaseStartDate).Hours() / 24 / 365.25
+	forecast.AllowedMileage = int(math.Round(float64(vehicle.LeaseMileageLimit) * contractYears))
+	forecast.ProjectedExcessKm = forecast.ProjectedMileage - forecast.AllowedMileage
+	forecast.ExpectedExcessCost = excessCost(forecast.ProjectedMileage, forecast)
+	forecast.UsagePercent = math.Round(float64(forecast.ProjectedMileage)/float64(forecast.AllowedMileage)*1000) / 10
+	forecast.OnTrackToExceed = forecast.ProjectedExcessKm > 0
+	if forecast.OnTrackToExceed && vehicle.LeaseExcessMileageCost <= 0 {
+		forecast.Warnings = append(forecast.Warnings, "Kein Preis je Mehrkilometer hinterlegt")
+	}
+	return forecast
+}
+
+// excessCost berechnet die Mehrkilometer-Kosten für eine Fahrleistung bei Vertragsende
+func excessCost(projectedMileage int, forecast model.LeaseForecast) float64 {
+	excess := projectedMileage - forecast.AllowedMileage
+	if excess <= 0 {
+		return 0
+	}
+	return roundCents(float64(excess) * forecast.ExcessMileageRate)
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrLogbookEntryNotFound wird für unbekannte oder nicht sichtbare Fahrtenbucheinträge zurückgegeben
+	ErrLogbookEntryNotFound = errors.New("fahrtenbucheintrag nicht gefunden")
+	// ErrLogbookEntryLocked wird zurückgegeben, wenn ein Eintrag nach Ablauf der Frist direkt geändert werden soll
+	ErrLogbookEntryLocked = errors.New("der eintrag ist festgeschrieben und kann nur noch über eine korrektur geändert werden")
+	// ErrLogbookForbidden wird zurückgegeben, wenn der Benutzer fremde Fahrten erfassen oder ändern will
+	ErrLogbookForbidden = errors.New("keine berechtigung für diesen fahrtenbucheintrag")
+)
+
+// LogbookInput enthält die Angaben einer Fahrt
+type LogbookInput struct {
+	VehicleID        string
+	DriverID         string // Optional; ohne Angabe der Fahrer des angemeldeten Benutzers
+	Date             time.Time
+	StartMileage     int
+	EndMileage       int
+	Route            string
+	BusinessPartner  string
+	Purpose          string
+	TripType         model.TripType
+	CorrectionReason string // Nur für Korrekturen
+}
+
+// LogbookSummary fasst die gefahrenen Kilometer nach Fahrtart zusammen
+type LogbookSummary struct {
+	Trips      int `json:"trips"`
+	BusinessKm int `json:"businessKm"`
+	CommuteKm  int `json:"commuteKm"`
+	PrivateKm  int `json:"privateKm"`
+	TotalKm    int `json:"totalKm"`
+}
+
+// LogbookService führt das elektronische Fahrtenbuch. Die Einträge eines Fahrzeugs bilden eine
+// lückenlose Kilometerkette; nach Ablauf der Bearbeitungsfrist sind sie nur noch über Korrekturversionen änderbar.
+type LogbookService struct {
+	logbookRepo     repository.LogbookRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	mileageService  *VehicleMileageService
+	activityService *ActivityService
+}
+
+// NewLogbookService erstellt einen neuen LogbookService
+func NewLogbookService(logbookRepo repository.LogbookRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, mileageService *VehicleMileageService, activityService *ActivityService) *LogbookService {
+	return &LogbookService{
+		logbookRepo:     logbookRepo,
+		vehicleRepo:     vehicleRepo,
+		driverRepo:      driverRepo,
+		mileageService:  mileageService,
+		activityService: activityService,
+	}
+}
+
+// CreateEntry erfasst eine neue Fahrt am Ende der Kilometerkette des Fahrzeugs
+func (s *LogbookService) CreateEntry(user *model.User, input LogbookInput) (*model.LogbookEntry, error) {
+	if err := validateLogbookInput(&input); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(input.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	driverID, err := s.resolveDriver(user, input.DriverID)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := s.logbookRepo.FindLast(vehicle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := 1
+	if last != nil {
+		if input.StartMileage != last.EndMileage {
+			return nil, fmt.Errorf("der anfangskilometerstand muss dem endstand der letzten fahrt (%d km) entsprechen", last.EndMileage)
+		}
+		if input.Date.Before(last.Date) {
+			return nil, fmt.Errorf("das fahrtdatum darf nicht vor der letzten fahrt am %s liegen", last.Date.Format("02.01.2006"))
+		}
+		sequence = last.Sequence + 1
+	}
+
+	now := time.Now()
+	entry := &model.LogbookEntry{
+		Sequence:        sequence,
+		VehicleID:       vehicle.ID,
+		DriverID:        driverID,
+		Date:            input.Date,
+		StartMileage:    input.StartMileage,
+		EndMileage:      input.EndMileage,
+		Route:           input.Route,
+		BusinessPartner: input.BusinessPartner,
+		Purpose:         input.Purpose,
+		TripType:        input.TripType,
+		RecordedBy:      user.ID,
+		LockedAt:        now.Add(model.LogbookEditGracePeriod),
+	}
+
+	if err := s.logbookRepo.Create(entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("das fahrtenbuch wurde zwischenzeitlich fortgeschrieben, bitte erneut versuchen")
+		}
+		return nil, fmt.Errorf("fehler beim speichern des fahrtenbucheintrags: %v", err)
+	}
+
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicle.ID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Fahrtenbucheintrag für %s: %v", vehicle.LicensePlate, err)
+	}
+
+	s.activityService.LogActivity(
+		"logbook_entry_created",
+		fmt.Sprintf("Fahrtenbucheintrag für %s erfasst: %s, %d km (%s)",
+			vehicle.LicensePlate, entry.Date.Format("02.01.2006"), entry.Distance(), model.TripTypeText[entry.TripType]),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return entry, nil
+}
+
+// UpdateEntry ändert einen Eintrag direkt, solange die Bearbeitungsfrist läuft
+func (s *LogbookService) UpdateEntry(user *model.User, entryID string, input LogbookInput) (*model.LogbookEntry, error) {
+	entry, err := s.findVisibleEntry(user, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsLocked(time.Now()) {
+		return nil, ErrLogbookEntryLocked
+	}
+
+	updated, err := s.applyInput(user, entry, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.logbookRepo.Update(updated); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des fahrtenbucheintrags: %v", err)
+	}
+
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(updated.VehicleID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Fahrtenbuchänderung: %v", err)
+	}
+
+	return updated, nil
+}
+
+// CorrectEntry legt eine Korrekturversion an. Die bisherige Version bleibt unverändert erhalten.
+func (s *LogbookService) CorrectEntry(user *model.User, entryID string, input LogbookInput) (*model.LogbookEntry, error) {
+	reason := strings.TrimSpace(input.CorrectionReason)
+	if reason == "" {
+		return nil, fmt.Errorf("für eine korrektur ist eine begründung erforderlich")
+	}
+
+	current, err := s.findVisibleEntry(user, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	correction, err := s.applyInput(user, current, input)
+	if err != nil {
+		return nil, err
+	}
+	correction.CorrectionReason = reason
+	correction.RecordedBy = user.ID
+	correction.LockedAt = time.Now() // Korrekturen sind sofort festgeschrieben
+	correction.SupersededAt = nil
+	correction.SupersededBy = nil
+
+	if err := s.logbookRepo.Supersede(current, correction); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("der eintrag wurde zwischenzeitlich geändert, bitte neu laden")
+		}
+		return nil, fmt.Errorf("fehler beim speichern der korrektur: %v", err)
+	}
+
+	if err := s.mileageService.UpdateVehicleMileageFromAllSources(correction.VehicleID.Hex()); err != nil {
+		log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Fahrtenbuchkorrektur: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"logbook_entry_corrected",
+		fmt.Sprintf("Fahrtenbucheintrag vom %s korrigiert (Version %d): %s",
+			correction.Date.Format("02.01.2006"), correction.Version, reason),
+		user.ID,
+		&correction.VehicleID,
+	)
+
+	return correction, nil
+}
+
+// GetEntry liefert die aktuelle Version eines Eintrags
+func (s *LogbookService) GetEntry(user *model.User, entryID string) (*model.LogbookEntry, error) {
+	return s.findVisibleEntry(user, entryID)
+}
+
+// GetVersions liefert alle Versionen eines Eintrags, älteste zuerst
+func (s *LogbookService) GetVersions(user *model.User, entryID string) ([]*model.LogbookEntry, error) {
+	entry, err := s.findVisibleEntry(user, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return s.logbookRepo.FindVersions(entry.EntryID)
+}
+
+// GetEntries liefert die aktuellen Einträge nach Filter. Fahrer sehen nur ihre eigenen Fahrten.
+func (s *LogbookService) GetEntries(user *model.User, filter model.LogbookFilter) ([]*model.LogbookEntry, error) {
+	if !isManagerOrAdmin(user) {
+		driver, err := driverForUser(s.driverRepo, user)
+		if err != nil {
+			return nil, ErrLogbookForbidden
+		}
+		filter.DriverID = &driver.ID
+	}
+	return s.logbookRepo.Find(filter)
+}
+
+// LogbookExportRow ist eine Fahrt im Jahresexport mit lesbarem Kennzeichen und Fahrernamen
+type LogbookExportRow struct {
+	*model.LogbookEntry
+	LicensePlate string `json:"licensePlate"`
+	DriverName   string `json:"driverName"`
+	Distance     int    `json:"distance"`
+}
+
+// LogbookAnnualLog ist das Fahrtenbuch eines Kalenderjahres
+type LogbookAnnualLog struct {
+	Year    int                `json:"year"`
+	Entries []LogbookExportRow `json:"entries"`
+	Summary LogbookSummary     `json:"summary"`
+}
+
+// GetAnnualLog liefert alle Fahrten eines Kalenderjahres für ein Fahrzeug und/oder einen Fahrer
+func (s *LogbookService) GetAnnualLog(user *model.User, year int, vehicleID, driverID *primitive.ObjectID) (*LogbookAnnualLog, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	entries, err := s.GetEntries(user, model.LogbookFilter{VehicleID: vehicleID, DriverID: driverID, From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	plates := make(map[primitive.ObjectID]string)
+	names := make(map[primitive.ObjectID]string)
+	rows := make([]LogbookExportRow, 0, len(entries))
+	for _, entry := range entries {
+		plate, ok := plates[entry.VehicleID]
+		if !ok {
+			if vehicle, err := s.vehicleRepo.FindByID(entry.VehicleID.Hex()); err == nil {
+				plate = vehicle.LicensePlate
+			}
+			plates[entry.VehicleID] = plate
+		}
+		name, ok := names[entry.DriverID]
+		if !ok {
+			if driver, err := s.driverRepo.FindByID(entry.DriverID.Hex()); err == nil {
+				name = driver.FirstName + " " + driver.LastName
+			}
+			names[entry.DriverID] = name
+		}
+		rows = append(rows, LogbookExportRow{LogbookEntry: entry, LicensePlate: plate, DriverName: name, Distance: entry.Distance()})
+	}
+
+	return &LogbookAnnualLog{Year: year, Entries: rows, Summary: SummarizeLogbook(entries)}, nil
+}
+
+// SummarizeLogbook summiert die gefahrenen Kilometer nach Fahrtart
+func SummarizeLogbook(entries []*model.LogbookEntry) LogbookSummary {
+	var summary LogbookSummary
+	for _, entry := range entries {
+		distance := entry.Distance()
+		summary.Trips++
+		summary.TotalKm += distance
+		switch entry.TripType {
+		case model.TripTypeBusiness:
+			summary.BusinessKm += distance
+		case model.TripTypeCommute:
+			summary.CommuteKm += distance
+		case model.TripTypePrivate:
+			summary.PrivateKm += distance
+		}
+	}
+	return summary
+}
+
+// applyInput übernimmt geänderte Angaben in eine Kopie des Eintrags und prüft die Kilometerkette
+func (s *LogbookService) applyInput(user *model.User, entry *model.LogbookEntry, input LogbookInput) (*model.LogbookEntry, error) {
+	if err := validateLogbookInput(&input); err != nil {
+		return nil, err
+	}
+	if input.VehicleID != "" && input.VehicleID != entry.VehicleID.Hex() {
+		return nil, fmt.Errorf("das fahrzeug eines fahrtenbucheintrags kann nicht geändert werden")
+	}
+
+	updated := *entry
+	if input.DriverID != "" && input.DriverID != entry.DriverID.Hex() {
+		if !isManagerOrAdmin(user) {
+			return nil, ErrLogbookForbidden
+		}
+		driverID, err := s.resolveDriver(user, input.DriverID)
+		if err != nil {
+			return nil, err
+		}
+		updated.DriverID = driverID
+	}
+
+	updated.Date = input.Date
+	updated.StartMileage = input.StartMileage
+	updated.EndMileage = input.EndMileage
+	updated.Route = input.Route
+	updated.BusinessPartner = input.BusinessPartner
+	updated.Purpose = input.Purpose
+	updated.TripType = input.TripType
+
+	if err := s.checkChain(&updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// checkChain stellt sicher, dass ein geänderter Eintrag nahtlos an Vorgänger und Nachfolger anschließt
+func (s *LogbookService) checkChain(entry *model.LogbookEntry) error {
+	previous, err := s.logbookRepo.FindBySequence(entry.VehicleID, entry.Sequence-1)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if entry.StartMileage != previous.EndMileage {
+			return fmt.Errorf("der anfangskilometerstand muss dem endstand der vorherigen fahrt (%d km) entsprechen", previous.EndMileage)
+		}
+		if entry.Date.Before(previous.Date) {
+			return fmt.Errorf("das fahrtdatum darf nicht vor der vorherigen fahrt am %s liegen", previous.Date.Format("02.01.2006"))
+		}
+	}
+
+	next, err := s.logbookRepo.FindBySequence(entry.VehicleID, entry.Sequence+1)
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		if entry.EndMileage != next.StartMileage {
+			return fmt.Errorf("der endkilometerstand muss dem anfangsstand der folgenden fahrt (%d km) entsprechen", next.StartMileage)
+		}
+		if entry.Date.After(next.Date) {
+			return fmt.Errorf("das fahrtdatum darf nicht nach der folgenden fahrt am %s liegen", next.Date.Format("02.01.2006"))
+		}
+	}
+	return nil
+}
+
+// findVisibleEntry lädt die aktuelle Version eines Eintrags, sofern der Benutzer ihn sehen darf
+func (s *LogbookService) findVisibleEntry(user *model.User, entryID string) (*model.LogbookEntry, error) {
+	objectID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return nil, ErrLogbookEntryNotFound
+	}
+
+	entry, err := s.logbookRepo.FindCurrent(objectID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrLogbookEntryNotFound
+		}
+		return nil, err
+	}
+
+	if !isManagerOrAdmin(user) {
+		driver, err := driverForUser(s.driverRepo, user)
+		if err != nil || driver.ID != entry.DriverID {
+			return nil, ErrLogbookEntryNotFound
+		}
+	}
+	return entry, nil
+}
+
+// resolveDriver bestimmt den Fahrer eines Eintrags. Fahrer erfassen immer für sich selbst,
+// Manager und Administratoren dürfen einen beliebigen Fahrer angeben.
+func (s *LogbookService) resolveDriver(user *model.User, driverID string) (primitive.ObjectID, error) {
+	if isManagerOrAdmin(user) && driverID != "" {
+		driver, err := s.driverRepo.FindByID(driverID)
+		if err != nil {
+			return primitive.NilObjectID, fmt.Errorf("fahrer nicht gefunden")
+		}
+		return driver.ID, nil
+	}
+
+	driver, err := driverForUser(s.driverRepo, user)
+	if err != nil {
+		if isManagerOrAdmin(user) {
+			return primitive.NilObjectID, fmt.Errorf("fahrer ist erforderlich")
+		}
+		return primitive.NilObjectID, fmt.Errorf("für ihr benutzerkonto ist kein fahrerprofil hinterlegt")
+	}
+	if driverID != "" && driverID != driver.ID.Hex() {
+		return primitive.NilObjectID, ErrLogbookForbidden
+	}
+	return driver.ID, nil
+}
+
+// validateLogbookInput prüft die Pflichtangaben einer Fahrt je nach Fahrtart
+func validateLogbookInput(input *LogbookInput) error {
+	input.Route = strings.TrimSpace(input.Route)
+	input.BusinessPartner = strings.TrimSpace(input.BusinessPartner)
+	input.Purpose = strings.TrimSpace(input.Purpose)
+
+	if input.Date.IsZero() {
+		return fmt.Errorf("fahrtdatum ist erforderlich")
+	}
+	if input.Date.After(time.Now()) {
+		return fmt.Errorf("fahrten können nicht im voraus erfasst werden")
+	}
+	if input.StartMileage < 0 {
+		return fmt.Errorf("anfangskilometerstand darf nicht negativ sein")
+	}
+	if input.EndMileage <= input.StartMileage {
+		return fmt.Errorf("endkilometerstand muss größer als der anfangskilometerstand sein")
+	}
+
+	switch input.TripType {
+	case model.TripTypeBusiness:
+		// Für Dienstfahrten verlangt das Finanzamt Reiseroute, Geschäftspartner und Zweck
+		if input.Route == "" || input.BusinessPartner == "" || input.Purpose == "" {
+			return fmt.Errorf("für dienstfahrten sind reiseroute, geschäftspartner und zweck erforderlich")
+		}
+	case model.TripTypeCommute, model.TripTypePrivate:
+	default:
+		return fmt.Errorf("ungültige fahrtart: %s", input.TripType)
+	}
+	return nil
+}
@@ -0,0 +1,598 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultMaintenanceLeadKm ist die Restdistanz, ab der eine Wartung ohne eigene Angabe fällig wird
+	defaultMaintenanceLeadKm = 1000
+	// defaultMaintenanceLeadDays ist die Restzeit, ab der eine Wartung ohne eigene Angabe fällig wird
+	defaultMaintenanceLeadDays = 30
+	// mileageTrendDays ist der Zeitraum, aus dem die durchschnittliche Tagesfahrleistung berechnet wird
+	mileageTrendDays = 180
+	// minMileageTrendDays ist die Mindestspanne zwischen zwei Kilometerständen für eine Hochrechnung
+	minMileageTrendDays = 7
+)
+
+// ErrMaintenancePlanNotFound wird für unbekannte Wartungspläne zurückgegeben
+var ErrMaintenancePlanNotFound = errors.New("wartungsplan nicht gefunden")
+
+// MaintenancePlanInput enthält die Angaben eines Wartungsplans
+type MaintenancePlanInput struct {
+	Name           string
+	Type           model.MaintenanceType
+	VehicleID      string // Plan für ein einzelnes Fahrzeug
+	Brand          string // Oder Plan für alle Fahrzeuge einer Marke ...
+	Model          string // ... bzw. eines Modells
+	IntervalKm     int
+	IntervalMonths int
+	LeadKm         int
+	LeadDays       int
+	Active         bool
+	Notes          string
+}
+
+// MaintenancePlanService verwaltet Wartungspläne und erzeugt daraus fällige Wartungen. Jeder Plan
+// führt pro Fahrzeug einen offenen Zyklus, dessen Fälligkeit aus Kilometerstand und
+// durchschnittlicher Tagesfahrleistung hochgerechnet wird.
+type MaintenancePlanService struct {
+	planRepo            repository.MaintenancePlanRepository
+	maintenanceRepo     repository.MaintenanceRepository
+	vehicleRepo         repository.VehicleRepository
+	mileageService      *VehicleMileageService
+	notificationService *NotificationService
+	activityService     *ActivityService
+}
+
+// NewMaintenancePlanService erstellt einen neuen MaintenancePlanService
+func NewMaintenancePlanService(planRepo repository.MaintenancePlanRepository, maintenanceRepo repository.MaintenanceRepository, vehicleRepo repository.VehicleRepository, mileageService *VehicleMileageService, notificationService *NotificationService, activityService *ActivityService) *MaintenancePlanService {
+	return &MaintenancePlanService{
+		planRepo:            planRepo,
+		maintenanceRepo:     maintenanceRepo,
+		vehicleRepo:         vehicleRepo,
+		mileageService:      mileageService,
+		notificationService: notificationService,
+		activityService:     activityService,
+	}
+}
+
+// GetPlans liefert alle Wartungspläne
+func (s *MaintenancePlanService) GetPlans() ([]*model.MaintenancePlan, error) {
+	return s.planRepo.FindAllPlans()
+}
+
+// GetPlan liefert einen Wartungsplan
+func (s *MaintenancePlanService) GetPlan(id string) (*model.MaintenancePlan, error) {
+	plan, err := s.planRepo.FindPlanByID(id)
+	if err != nil {
+		return nil, ErrMaintenancePlanNotFound
+	}
+	return plan, nil
+}
+
+// CreatePlan legt einen Wartungsplan an und berechnet sofort die Fälligkeiten der betroffenen Fahrzeuge
+func (s *MaintenancePlanService) CreatePlan(user *model.User, input MaintenancePlanInput) (*model.MaintenancePlan, error) {
+	plan := &model.MaintenancePlan{CreatedBy: user.ID}
+	if err := s.applyPlanInput(plan, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.planRepo.CreatePlan(plan); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des wartungsplans: %v", err)
+	}
+
+	if _, err := s.evaluatePlan(plan, time.Now()); err != nil {
+		log.Printf("Fehler beim Auswerten des Wartungsplans %s: %v", plan.Name, err)
+	}
+
+	s.activityService.LogActivity(
+		"maintenance_plan_created",
+		fmt.Sprintf("Wartungsplan \"%s\" angelegt (%s)", plan.Name, describeInterval(plan)),
+		user.ID,
+		plan.VehicleID,
+	)
+
+	return plan, nil
+}
+
+// UpdatePlan ändert einen Wartungsplan. Offene Zyklen behalten ihren Ausgangspunkt und werden
+// mit den neuen Intervallen neu berechnet; Fahrzeuge, für die der Plan nicht mehr gilt, verlieren ihren offenen Zyklus.
+func (s *MaintenancePlanService) UpdatePlan(user *model.User, id string, input MaintenancePlanInput) (*model.MaintenancePlan, error) {
+	plan, err := s.GetPlan(id)
+	if err != nil {
+		return nil, err
+	}
+	previousType := plan.Type
+	if err := s.applyPlanInput(plan, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.planRepo.UpdatePlan(plan); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des wartungsplans: %v", err)
+	}
+
+	// Bei geändertem Wartungstyp passt der bisherige Ausgangspunkt nicht mehr
+	openDues, err := s.planRepo.FindDues(model.MaintenanceDueFilter{PlanID: &plan.ID, Statuses: openMaintenanceDueStatuses})
+	if err != nil {
+		return nil, err
+	}
+	for _, due := range openDues {
+		vehicle, err := s.vehicleRepo.FindByID(due.VehicleID.Hex())
+		if err != nil || !plan.Active || !plan.AppliesTo(vehicle) || plan.Type != previousType {
+			s.discardDue(due)
+		}
+	}
+
+	if _, err := s.evaluatePlan(plan, time.Now()); err != nil {
+		log.Printf("Fehler beim Auswerten des Wartungsplans %s: %v", plan.Name, err)
+	}
+
+	s.activityService.LogActivity(
+		"maintenance_plan_updated",
+		fmt.Sprintf("Wartungsplan \"%s\" geändert (%s)", plan.Name, describeInterval(plan)),
+		user.ID,
+		plan.VehicleID,
+	)
+
+	return plan, nil
+}
+
+// DeletePlan löscht einen Wartungsplan mit allen Zyklen
+func (s *MaintenancePlanService) DeletePlan(user *model.User, id string) error {
+	plan, err := s.GetPlan(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.planRepo.DeleteDuesByPlan(plan.ID); err != nil {
+		return fmt.Errorf("fehler beim löschen der wartungszyklen: %v", err)
+	}
+	if err := s.planRepo.DeletePlan(id); err != nil {
+		return fmt.Errorf("fehler beim löschen des wartungsplans: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"maintenance_plan_deleted",
+		fmt.Sprintf("Wartungsplan \"%s\" gelöscht", plan.Name),
+		user.ID,
+		plan.VehicleID,
+	)
+	return nil
+}
+
+// GetDues liefert Wartungszyklen zum Filter, nach Fälligkeit sortiert (ohne Fälligkeit zuletzt)
+func (s *MaintenancePlanService) GetDues(filter model.MaintenanceDueFilter) ([]*model.MaintenanceDue, error) {
+	dues, err := s.planRepo.FindDues(filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(dues, func(i, j int) bool {
+		if dues[i].DueDate == nil || dues[j].DueDate == nil {
+			return dues[i].DueDate != nil && dues[j].DueDate == nil
+		}
+		return dues[i].DueDate.Before(*dues[j].DueDate)
+	})
+	return dues, nil
+}
+
+// RunPlans ist der Hintergrundjob, der alle aktiven Pläne auswertet und fällige Wartungen erzeugt
+func (s *MaintenancePlanService) RunPlans(run JobRunContext) error {
+	plans, err := s.planRepo.FindAllPlans()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	generated, failed := 0, 0
+	for _, plan := range plans {
+		if !plan.Active {
+			continue
+		}
+		count, err := s.evaluatePlan(plan, now)
+		generated += count
+		if err != nil {
+			log.Printf("Fehler beim Auswerten des Wartungsplans %s: %v", plan.Name, err)
+			failed++
+		}
+	}
+
+	if generated > 0 {
+		log.Printf("Wartungspläne ausgewertet: %d Wartungen neu fällig", generated)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d von %d wartungsplänen konnten nicht ausgewertet werden", failed, len(plans))
+	}
+	return nil
+}
+
+// CompleteFromMaintenance erledigt die offenen Zyklen des Fahrzeugs, deren Plan den Typ des
+// Wartungseintrags hat, und startet mit diesem Eintrag jeweils den nächsten Zyklus
+func (s *MaintenancePlanService) CompleteFromMaintenance(maintenance *model.Maintenance) error {
+	// Termine in der Zukunft sind geplant, aber noch nicht erledigt
+	if !maintenance.Date.Before(calendarDay(time.Now()).AddDate(0, 0, 1)) {
+		return nil
+	}
+
+	dues, err := s.planRepo.FindDues(model.MaintenanceDueFilter{
+		VehicleID: &maintenance.VehicleID,
+		Type:      maintenance.Type,
+		Statuses:  openMaintenanceDueStatuses,
+	})
+	if err != nil {
+		return err
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(maintenance.VehicleID.Hex())
+	if err != nil {
+		return fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	now := time.Now()
+	for _, due := range dues {
+		// Der Zyklus wurde bereits von diesem oder einem späteren Eintrag gestartet. Zyklen ohne
+		// Wartungseintrag (Start bei Einführung des Plans) setzt auch eine nachgetragene Wartung zurück.
+		if due.BaselineMaintenanceID != nil &&
+			(*due.BaselineMaintenanceID == maintenance.ID || maintenance.Date.Before(calendarDay(due.BaselineDate))) {
+			continue
+		}
+
+		due.Status = model.MaintenanceDueCompleted
+		due.CompletedMaintenanceID = &maintenance.ID
+		due.CompletedAt = &now
+		if err := s.planRepo.UpdateDue(due); err != nil {
+			return err
+		}
+
+		plan, err := s.planRepo.FindPlanByID(due.PlanID.Hex())
+		if err != nil || !plan.Active {
+			continue
+		}
+		next := s.newDue(plan, vehicle, maintenance)
+		s.projectDue(plan, next, vehicle, now)
+		if err := s.planRepo.CreateDue(next); err != nil {
+			return err
+		}
+
+		s.activityService.LogActivity(
+			"maintenance_plan_completed",
+			fmt.Sprintf("Wartungsplan \"%s\" für %s durch Wartung vom %s zurückgesetzt",
+				plan.Name, vehicle.LicensePlate, maintenance.Date.Format("02.01.2006")),
+			primitive.NilObjectID,
+			&vehicle.ID,
+		)
+	}
+	return nil
+}
+
+// evaluatePlan aktualisiert die offenen Zyklen aller Fahrzeuge, für die der Plan gilt, und
+// liefert die Anzahl der Zyklen, die dabei neu fällig oder überfällig wurden
+func (s *MaintenancePlanService) evaluatePlan(plan *model.MaintenancePlan, now time.Time) (int, error) {
+	if !plan.Active {
+		return 0, nil
+	}
+
+	vehicles, err := s.plannedVehicles(plan)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, vehicle := range vehicles {
+		open, err := s.planRepo.FindDues(model.MaintenanceDueFilter{PlanID: &plan.ID, VehicleID: &vehicle.ID, Statuses: openMaintenanceDueStatuses})
+		if err != nil {
+			return generated, err
+		}
+
+		var due *model.MaintenanceDue
+		previousStatus := model.MaintenanceDueScheduled
+		if len(open) > 0 {
+			due = open[0]
+			previousStatus = due.Status
+
+			// Beim Erfassen noch in der Zukunft liegende Wartungen schließen den Zyklus ab, sobald ihr Datum erreicht ist
+			if last := s.lastMaintenance(vehicle.ID.Hex(), plan.Type); last != nil && last.Date.After(due.BaselineDate) {
+				if err := s.CompleteFromMaintenance(last); err != nil {
+					return generated, err
+				}
+				continue
+			}
+		} else {
+			due = s.newDue(plan, vehicle, s.lastMaintenance(vehicle.ID.Hex(), plan.Type))
+		}
+
+		becameDue := s.projectDue(plan, due, vehicle, now)
+		if due.ID.IsZero() {
+			err = s.planRepo.CreateDue(due)
+		} else {
+			err = s.planRepo.UpdateDue(due)
+		}
+		if err != nil {
+			return generated, err
+		}
+
+		if becameDue && due.Status != previousStatus {
+			generated++
+			s.activityService.LogActivity(
+				"maintenance_due",
+				fmt.Sprintf("%s für %s %s: %s", plan.Name, vehicle.LicensePlate,
+					strings.ToLower(model.MaintenanceDueStatusText[due.Status]), describeDue(due)),
+				primitive.NilObjectID,
+				&vehicle.ID,
+			)
+			// Benachrichtigt wird nur beim Statuswechsel, also je Zyklus höchstens bei Fälligkeit und Überfälligkeit
+			if err := s.notificationService.NotifyMaintenanceDue(plan, due, vehicle); err != nil {
+				log.Printf("Fehler bei der Wartungsbenachrichtigung für %s: %v", vehicle.LicensePlate, err)
+			}
+		}
+	}
+	return generated, nil
+}
+
+// newDue startet einen Zyklus ab der letzten passenden Wartung oder, ohne Wartung, ab heute
+func (s *MaintenancePlanService) newDue(plan *model.MaintenancePlan, vehicle *model.Vehicle, last *model.Maintenance) *model.MaintenanceDue {
+	due := &model.MaintenanceDue{
+		PlanID:          plan.ID,
+		VehicleID:       vehicle.ID,
+		Type:            plan.Type,
+		BaselineDate:    calendarDay(time.Now()),
+		BaselineMileage: vehicle.Mileage,
+		Status:          model.MaintenanceDueScheduled,
+	}
+	if last != nil {
+		due.BaselineDate = last.Date
+		due.BaselineMaintenanceID = &last.ID
+		// Ohne Kilometerangabe im Wartungseintrag gilt der aktuelle Stand
+		if last.Mileage > 0 {
+			due.BaselineMileage = last.Mileage
+		}
+	}
+	return due
+}
+
+// projectDue berechnet Fälligkeit und Status eines Zyklus und meldet, ob er fällig oder überfällig ist
+func (s *MaintenancePlanService) projectDue(plan *model.MaintenancePlan, due *model.MaintenanceDue, vehicle *model.Vehicle, now time.Time) bool {
+	today := calendarDay(now)
+	due.Type = plan.Type
+	due.CurrentMileage = vehicle.Mileage
+	due.AverageDailyKm = s.averageDailyKm(vehicle, due, now)
+	due.DueMileage = 0
+	due.DueDateByTime = nil
+	due.DueDateByMileage = nil
+	due.DueDate = nil
+	due.EvaluatedAt = now
+
+	if plan.IntervalMonths > 0 {
+		byTime := calendarDay(due.BaselineDate).AddDate(0, plan.IntervalMonths, 0)
+		due.DueDateByTime = &byTime
+		due.DueDate = &byTime
+	}
+
+	remainingKm := 0
+	if plan.IntervalKm > 0 {
+		due.DueMileage = due.BaselineMileage + plan.IntervalKm
+		remainingKm = due.DueMileage - due.CurrentMileage
+		if due.AverageDailyKm > 0 {
+			days := 0
+			if remainingKm > 0 {
+				days = int(math.Ceil(float64(remainingKm) / due.AverageDailyKm))
+			}
+			byMileage := today.AddDate(0, 0, days)
+			due.DueDateByMileage = &byMileage
+			if due.DueDate == nil || byMileage.Before(*due.DueDate) {
+				due.DueDate = &byMileage
+			}
+		}
+	}
+
+	overdue := (plan.IntervalKm > 0 && remainingKm <= 0) ||
+		(due.DueDateByTime != nil && today.After(*due.DueDateByTime))
+	soon := (plan.IntervalKm > 0 && remainingKm <= plan.LeadKm) ||
+		(due.DueDate != nil && !due.DueDate.After(today.AddDate(0, 0, plan.LeadDays)))
+
+	switch {
+	case overdue:
+		due.Status = model.MaintenanceDueOverdue
+	case soon:
+		due.Status = model.MaintenanceDueDue
+	default:
+		due.Status = model.MaintenanceDueScheduled
+	}
+	return due.Status != model.MaintenanceDueScheduled
+}
+
+// averageDailyKm berechnet die durchschnittliche Tagesfahrleistung aus den Kilometerständen der
+// letzten Monate; reichen diese nicht aus, zählt die Strecke seit Beginn des Zyklus
+func (s *MaintenancePlanService) averageDailyKm(vehicle *model.Vehicle, due *model.MaintenanceDue, now time.Time) float64 {
+	baseline := mileageReading{date: calendarDay(due.BaselineDate), mileage: due.BaselineMileage}
+	return dailyKmTrend(s.mileageService.datedReadings(vehicle.ID.Hex()), baseline, vehicle.Mileage, now)
+}
+
+// dailyKmTrend berechnet die Tagesfahrleistung aus den Kilometerständen der letzten Monate;
+// reichen diese nicht aus, zählt die Strecke seit dem Ausgangsstand
+func dailyKmTrend(readings []mileageReading, baseline mileageReading, currentMileage int, now time.Time) float64 {
+	readings = append([]mileageReading{baseline}, readings...)
+
+	windowStart := calendarDay(now).AddDate(0, 0, -mileageTrendDays)
+	var first, last *mileageReading
+	for i := range readings {
+		r := &readings[i]
+		if r.date.Before(windowStart) || r.date.After(now) {
+			continue
+		}
+		if first == nil || r.date.Before(first.date) {
+			first = r
+		}
+		if last == nil || r.date.After(last.date) || (r.date.Equal(last.date) && r.mileage > last.mileage) {
+			last = r
+		}
+	}
+
+	if first != nil && last != nil {
+		days := last.date.Sub(first.date).Hours() / 24
+		if days >= minMileageTrendDays && last.mileage > first.mileage {
+			return roundCents(float64(last.mileage-first.mileage) / days)
+		}
+	}
+
+	days := calendarDay(now).Sub(calendarDay(baseline.date)).Hours() / 24
+	if days >= minMileageTrendDays && currentMileage > baseline.mileage {
+		return roundCents(float64(currentMileage-baseline.mileage) / days)
+	}
+	return 0
+}
+
+// plannedVehicles liefert alle Fahrzeuge, für die der Plan gilt
+func (s *MaintenancePlanService) plannedVehicles(plan *model.MaintenancePlan) ([]*model.Vehicle, error) {
+	if plan.VehicleID != nil {
+		vehicle, err := s.vehicleRepo.FindByID(plan.VehicleID.Hex())
+		if err != nil {
+			return nil, nil
+		}
+		return []*model.Vehicle{vehicle}, nil
+	}
+
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	var matched []*model.Vehicle
+	for _, vehicle := range vehicles {
+		if plan.AppliesTo(vehicle) {
+			matched = append(matched, vehicle)
+		}
+	}
+	return matched, nil
+}
+
+// lastMaintenance liefert den letzten Wartungseintrag des Typs oder nil
+func (s *MaintenancePlanService) lastMaintenance(vehicleID string, maintenanceType model.MaintenanceType) *model.Maintenance {
+	entries, err := s.maintenanceRepo.FindByVehicle(vehicleID)
+	if err != nil {
+		return nil
+	}
+
+	today := calendarDay(time.Now()).AddDate(0, 0, 1)
+	var last *model.Maintenance
+	for _, entry := range entries {
+		// Geplante Einträge in der Zukunft zählen nicht als erledigte Wartung
+		if entry.Type != maintenanceType || !entry.Date.Before(today) {
+			continue
+		}
+		if last == nil || entry.Date.After(last.Date) {
+			last = entry
+		}
+	}
+	return last
+}
+
+// discardDue entfernt einen offenen Zyklus, indem er ohne Wartungseintrag abgeschlossen wird
+func (s *MaintenancePlanService) discardDue(due *model.MaintenanceDue) {
+	now := time.Now()
+	due.Status = model.MaintenanceDueCompleted
+	due.CompletedAt = &now
+	if err := s.planRepo.UpdateDue(due); err != nil {
+		log.Printf("Fehler beim Schließen des Wartungszyklus %s: %v", due.ID.Hex(), err)
+	}
+}
+
+// applyPlanInput prüft die Eingabe und überträgt sie auf den Plan
+func (s *MaintenancePlanService) applyPlanInput(plan *model.MaintenancePlan, input MaintenancePlanInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Brand = strings.TrimSpace(input.Brand)
+	input.Model = strings.TrimSpace(input.Model)
+
+	if input.Name == "" {
+		return fmt.Errorf("ein name für den wartungsplan ist erforderlich")
+	}
+	switch input.Type {
+	case model.MaintenanceTypeInspection, model.MaintenanceTypeOilChange, model.MaintenanceTypeTireChange,
+		model.MaintenanceTypeRepair, model.MaintenanceTypeOther:
+	default:
+		return fmt.Errorf("ungültiger wartungstyp")
+	}
+	if input.IntervalKm < 0 || input.IntervalMonths < 0 || input.LeadKm < 0 || input.LeadDays < 0 {
+		return fmt.Errorf("intervalle und vorlauf dürfen nicht negativ sein")
+	}
+	if input.IntervalKm == 0 && input.IntervalMonths == 0 {
+		return fmt.Errorf("ein intervall nach kilometern oder monaten ist erforderlich")
+	}
+	if input.IntervalKm > 0 && input.LeadKm >= input.IntervalKm {
+		return fmt.Errorf("der vorlauf in kilometern muss kleiner als das intervall sein")
+	}
+
+	plan.VehicleID = nil
+	plan.Brand = ""
+	plan.Model = ""
+	switch {
+	case input.VehicleID != "":
+		vehicle, err := s.vehicleRepo.FindByID(input.VehicleID)
+		if err != nil {
+			return fmt.Errorf("fahrzeug nicht gefunden")
+		}
+		plan.VehicleID = &vehicle.ID
+	case input.Brand != "":
+		plan.Brand = input.Brand
+		plan.Model = input.Model
+	default:
+		return fmt.Errorf("ein fahrzeug oder eine marke ist erforderlich")
+	}
+
+	if input.IntervalKm > 0 && input.LeadKm == 0 {
+		input.LeadKm = min(defaultMaintenanceLeadKm, input.IntervalKm/10)
+	}
+	if input.LeadDays == 0 {
+		input.LeadDays = defaultMaintenanceLeadDays
+	}
+
+	plan.Name = input.Name
+	plan.Type = input.Type
+	plan.IntervalKm = input.IntervalKm
+	plan.IntervalMonths = input.IntervalMonths
+	plan.LeadKm = input.LeadKm
+	plan.LeadDays = input.LeadDays
+	plan.Active = input.Active
+	plan.Notes = strings.TrimSpace(input.Notes)
+	return nil
+}
+
+// openMaintenanceDueStatuses sind alle Status eines noch nicht erledigten Zyklus
+var openMaintenanceDueStatuses = []model.MaintenanceDueStatus{
+	model.MaintenanceDueScheduled,
+	model.MaintenanceDueDue,
+	model.MaintenanceDueOverdue,
+}
+
+// describeInterval beschreibt das Intervall eines Plans, z.B. "alle 15000 km oder 12 Monate"
+func describeInterval(plan *model.MaintenancePlan) string {
+	switch {
+	case plan.IntervalKm > 0 && plan.IntervalMonths > 0:
+		return fmt.Sprintf("alle %d km oder %d Monate, was zuerst eintritt", plan.IntervalKm, plan.IntervalMonths)
+	case plan.IntervalKm > 0:
+		return fmt.Sprintf("alle %d km", plan.IntervalKm)
+	default:
+		return fmt.Sprintf("alle %d Monate", plan.IntervalMonths)
+	}
+}
+
+// describeDue beschreibt die Fälligkeit eines Zyklus, z.B. "bei 45000 km bzw. am 01.03.2027"
+func describeDue(due *model.MaintenanceDue) string {
+	var parts []string
+	if due.DueMileage > 0 {
+		parts = append(parts, fmt.Sprintf("bei %d km", due.DueMileage))
+	}
+	if due.DueDate != nil {
+		parts = append(parts, "am "+due.DueDate.Format("02.01.2006"))
+	}
+	return strings.Join(parts, " bzw. ")
+}
@@ -5,21 +5,23 @@ import (
 	"FleetFlow/backend/repository"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 )
 
 // NotificationService verwaltet Benachrichtigungen
 type NotificationService struct {
-	userRepo        *repository.UserRepository
+	userRepo        repository.UserRepository
 	emailService    *EmailService
 	activityService *ActivityService
 }
 
 // NewNotificationService erstellt einen neuen NotificationService
-func NewNotificationService() *NotificationService {
+func NewNotificationService(userRepo repository.UserRepository, emailService *EmailService, activityService *ActivityService) *NotificationService {
 	return &NotificationService{
-		userRepo:        repository.NewUserRepository(),
-		emailService:    NewEmailService(),
-		activityService: NewActivityService(),
+		userRepo:        userRepo,
+		emailService:    emailService,
+		activityService: activityService,
 	}
 }
 
@@ -41,20 +43,26 @@ func (s *NotificationService) NotifyUrgentReport(report *model.VehicleReport, ve
 	subject := fmt.Sprintf("🚨 DRINGENDE Fahrzeugmeldung: %s", report.Title)
 	body := s.createUrgentReportEmailBody(report, vehicle, driver)
 
-	// E-Mails an alle Manager senden
+	// E-Mails an alle Manager senden, die diese Benachrichtigung wünschen
+	notified := 0
 	for _, manager := range managers {
-		err := s.emailService.SendEmail(manager.Email, subject, "", body)
+		sendAt, ok := s.deliveryTime(manager, model.NotificationUrgentReport)
+		if !ok {
+			continue
+		}
+		err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt)
 		if err != nil {
 			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
 		} else {
-			log.Printf("Dringende Fahrzeugmeldung E-Mail an %s gesendet", manager.Email)
+			log.Printf("Dringende Fahrzeugmeldung E-Mail an %s in Warteschlange eingereiht", manager.Email)
+			notified++
 		}
 	}
 
 	// Aktivität protokollieren
 	s.activityService.LogActivity(
 		"urgent_report_notification_sent",
-		fmt.Sprintf("Dringende Meldung %s - Benachrichtigungen an %d Manager gesendet", report.ID.Hex(), len(managers)),
+		fmt.Sprintf("Dringende Meldung %s - Benachrichtigungen an %d Manager gesendet", report.ID.Hex(), notified),
 		driver.ID,
 		&vehicle.ID,
 	)
@@ -67,13 +75,18 @@ func (s *NotificationService) NotifyReservationApproval(reservation *model.Vehic
 	subject := fmt.Sprintf("✅ Reservierung genehmigt: %s %s", vehicle.Brand, vehicle.Model)
 	body := s.createReservationApprovalEmailBody(reservation, vehicle, driver, approvedBy)
 
-	err := s.emailService.SendEmail(driver.Email, subject, "", body)
+	sendAt, ok := s.driverDeliveryTime(driver, model.NotificationReservationApproved)
+	if !ok {
+		return nil
+	}
+
+	err := s.emailService.SendEmailAt(driver.Email, subject, "", body, sendAt)
 	if err != nil {
 		log.Printf("Fehler beim Senden der Genehmigungs-E-Mail an %s: %v", driver.Email, err)
 		return err
 	}
 
-	log.Printf("Genehmigungs-E-Mail an %s gesendet", driver.Email)
+	log.Printf("Genehmigungs-E-Mail an %s in Warteschlange eingereiht", driver.Email)
 	return nil
 }
 
@@ -82,13 +95,18 @@ func (s *NotificationService) NotifyReservationRejection(reservation *model.Vehi
 	subject := fmt.Sprintf("❌ Reservierung abgelehnt: %s %s", vehicle.Brand, vehicle.Model)
 	body := s.createReservationRejectionEmailBody(reservation, vehicle, driver, rejectedBy)
 
-	err := s.emailService.SendEmail(driver.Email, subject, "", body)
+	sendAt, ok := s.driverDeliveryTime(driver, model.NotificationReservationRejected)
+	if !ok {
+		return nil
+	}
+
+	err := s.emailService.SendEmailAt(driver.Email, subject, "", body, sendAt)
 	if err != nil {
 		log.Printf("Fehler beim Senden der Ablehnungs-E-Mail an %s: %v", driver.Email, err)
 		return err
 	}
 
-	log.Printf("Ablehnungs-E-Mail an %s gesendet", driver.Email)
+	log.Printf("Ablehnungs-E-Mail an %s in Warteschlange eingereiht", driver.Email)
 	return nil
 }
 
@@ -111,17 +129,70 @@ func (s *NotificationService) NotifyNewReservationRequest(reservation *model.Veh
 
 	// E-Mails an alle Manager senden
 	for _, manager := range managers {
-		err := s.emailService.SendEmail(manager.Email, subject, "", body)
+		sendAt, ok := s.deliveryTime(manager, model.NotificationReservationRequest)
+		if !ok {
+			continue
+		}
+		err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt)
 		if err != nil {
 			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
 		} else {
-			log.Printf("Neue Reservierungsanfrage E-Mail an %s gesendet", manager.Email)
+			log.Printf("Neue Reservierungsanfrage E-Mail an %s in Warteschlange eingereiht", manager.Email)
 		}
 	}
 
 	return nil
 }
 
+// ShouldNotifyUser prüft anhand der Benachrichtigungseinstellungen, ob der Benutzer jetzt
+// eine E-Mail zu diesem Ereignis erhalten soll. Dringende Meldungen ignorieren die Ruhezeit.
+func (s *NotificationService) ShouldNotifyUser(user *model.User, event model.NotificationEvent) bool {
+	sendAt, ok := s.deliveryTime(user, event)
+	return ok && !sendAt.After(time.Now())
+}
+
+// ShouldNotifyDriver prüft die Einstellungen des Benutzerkontos mit der E-Mail-Adresse des Fahrers.
+// Fahrer ohne Benutzerkonto werden immer benachrichtigt.
+func (s *NotificationService) ShouldNotifyDriver(driver *model.Driver, event model.NotificationEvent) bool {
+	sendAt, ok := s.driverDeliveryTime(driver, event)
+	return ok && !sendAt.After(time.Now())
+}
+
+// deliveryTime liefert, ob und ab wann der Benutzer eine E-Mail zu diesem Ereignis erhalten soll.
+// Während der Ruhezeit wird der Versand bis zu deren Ende zurückgestellt.
+func (s *NotificationService) deliveryTime(user *model.User, event model.NotificationEvent) (time.Time, bool) {
+	now := time.Now()
+	settings, err := s.emailService.GetNotificationSettings(user.ID.Hex())
+	if err != nil {
+		// Im Zweifel lieber benachrichtigen als eine Meldung zu verschlucken
+		log.Printf("Fehler beim Laden der Benachrichtigungseinstellungen von %s: %v", user.Email, err)
+		return now, true
+	}
+
+	if !settings.Allows(event) {
+		return now, false
+	}
+
+	local := now.In(berlinLocation())
+	if event != model.NotificationUrgentReport && settings.InQuietHours(local) {
+		return settings.QuietHoursEndAfter(local), true
+	}
+	return now, true
+}
+
+// driverDeliveryTime ermittelt den Versandzeitpunkt über das Benutzerkonto mit der E-Mail-Adresse des Fahrers
+func (s *NotificationService) driverDeliveryTime(driver *model.Driver, event model.NotificationEvent) (time.Time, bool) {
+	if driver.Email == "" {
+		return time.Time{}, false
+	}
+
+	user, err := s.userRepo.FindByEmail(driver.Email)
+	if err != nil || user == nil {
+		return time.Now(), true
+	}
+	return s.deliveryTime(user, event)
+}
+
 // getManagersAndAdmins findet alle Benutzer mit Manager- oder Admin-Rolle
 func (s *NotificationService) getManagersAndAdmins() ([]*model.User, error) {
 	allUsers, err := s.userRepo.FindAll()
@@ -327,6 +398,424 @@ func (s *NotificationService) createNewReservationEmailBody(reservation *model.V
 	)
 }
 
+// NotifyTrafficFine informiert den Fahrer über ein Bußgeld, das ihm zugeordnet wurde.
+// Erinnerungen kurz vor Ablauf der Frist verwenden denselben Inhalt mit anderem Betreff.
+func (s *NotificationService) NotifyTrafficFine(fine *model.TrafficFine, vehicle *model.Vehicle, driver *model.Driver, reminder bool) error {
+	if driver.Email == "" {
+		return fmt.Errorf("für den fahrer ist keine e-mail-adresse hinterlegt")
+	}
+
+	subject := fmt.Sprintf("Bußgeld zu %s: Rückmeldung bis %s", vehicle.LicensePlate, fine.ResponseDeadline.Format("02.01.2006"))
+	if reminder {
+		subject = "Erinnerung: " + subject
+	}
+	body := s.createTrafficFineEmailBody(fine, vehicle, driver)
+
+	sendAt, ok := s.driverDeliveryTime(driver, model.NotificationTrafficFine)
+	if !ok {
+		return nil
+	}
+
+	if err := s.emailService.SendEmailAt(driver.Email, subject, "", body, sendAt); err != nil {
+		log.Printf("Fehler beim Senden der Bußgeld-E-Mail an %s: %v", driver.Email, err)
+		return err
+	}
+
+	log.Printf("Bußgeld-E-Mail an %s in Warteschlange eingereiht", driver.Email)
+	return nil
+}
+
+// NotifyUnresolvedTrafficFine erinnert Manager an ein Bußgeld kurz vor Fristablauf, dem noch kein Fahrer zugeordnet ist
+func (s *NotificationService) NotifyUnresolvedTrafficFine(fine *model.TrafficFine, vehicle *model.Vehicle) error {
+	managers, err := s.getManagersAndAdmins()
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Bußgeld zu %s ohne Fahrer: Frist endet am %s", vehicle.LicensePlate, fine.ResponseDeadline.Format("02.01.2006"))
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #dc2626; color: white; padding: 20px; text-align: center;">
+		<h1>Fahrer für Bußgeld nicht ermittelt</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Für das folgende Bußgeld ist noch kein Fahrer festgelegt. Die Frist zur Benennung endet am <strong>%s</strong>.</p>
+
+		<div style="background: #fef2f2; border: 1px solid #fecaca; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Tatzeit:</strong> %s</p>
+			<p><strong>Tatort:</strong> %s</p>
+			<p><strong>Aktenzeichen:</strong> %s</p>
+		</div>
+%s
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		fine.ResponseDeadline.Format("02.01.2006"),
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		fine.OffenseTime.In(berlinLocation()).Format("02.01.2006 15:04 Uhr"),
+		getLocationOrDefault(fine.Location),
+		fine.ReferenceNumber,
+		getNotesSection(strings.Join(fine.Resolution.Warnings, "<br>")),
+	)
+
+	notified := 0
+	for _, manager := range managers {
+		sendAt, ok := s.deliveryTime(manager, model.NotificationTrafficFine)
+		if !ok {
+			continue
+		}
+		if err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt); err != nil {
+			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
+			continue
+		}
+		notified++
+	}
+	log.Printf("Bußgeld %s ohne Fahrer: %d Manager benachrichtigt", fine.ID.Hex(), notified)
+	return nil
+}
+
+// NotifyLeaseMileageOverage warnt einen Manager, dass ein Leasingfahrzeug auf Mehrkilometer zusteuert.
+// Liefert false, wenn der Manager keine Ablauferinnerungen erhalten möchte.
+func (s *NotificationService) NotifyLeaseMileageOverage(manager *model.User, vehicle *model.Vehicle, forecast *model.LeaseForecast) (bool, error) {
+	sendAt, ok := s.deliveryTime(manager, model.NotificationExpiryReminder)
+	if !ok {
+		return false, nil
+	}
+
+	subject := fmt.Sprintf("Mehrkilometer erwartet: %s (%+d km bis %s)", vehicle.LicensePlate, forecast.ProjectedExcessKm, vehicle.LeaseEndDate.Format("02.01.2006"))
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #d97706; color: white; padding: 20px; text-align: center;">
+		<h1>Leasingfahrzeug über der Laufleistung</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Hallo %s %s,</p>
+
+		<p>nach der bisherigen Fahrleistung wird das folgende Leasingfahrzeug die vereinbarte Laufleistung bis Vertragsende überschreiten.</p>
+
+		<div style="background: #fffbeb; border: 1px solid #fde68a; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Leasingende:</strong> %s (noch %d Tage)</p>
+			<p><strong>Vereinbarte Laufleistung:</strong> %d km</p>
+			<p><strong>Erwartete Laufleistung:</strong> %d km (%.0f km pro Tag)</p>
+			<p><strong>Erwartete Mehrkilometer:</strong> %d km</p>
+			<p><strong>Erwartete Kosten:</strong> %.2f €</p>
+		</div>
+
+		<p>Prüfen Sie, ob ein Tausch mit einem weniger gefahrenen Leasingfahrzeug möglich ist.</p>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		manager.FirstName, manager.LastName,
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		vehicle.LeaseEndDate.Format("02.01.2006"), forecast.DaysRemaining,
+		forecast.AllowedMileage,
+		forecast.ProjectedMileage, forecast.DailyKm,
+		forecast.ProjectedExcessKm,
+		forecast.ExpectedExcessCost,
+	)
+
+	if err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NotifyLeaseReturnStarted informiert die Manager über eine neu angelegte Rückgabe-Checkliste
+func (s *NotificationService) NotifyLeaseReturnStarted(leaseReturn *model.LeaseReturn, vehicle *model.Vehicle) error {
+	managers, err := s.getManagersAndAdmins()
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Leasingrückgabe vorbereiten: %s zum %s", vehicle.LicensePlate, leaseReturn.LeaseEndDate.Format("02.01.2006"))
+	items := make([]string, 0, len(leaseReturn.Items))
+	for _, item := range leaseReturn.Items {
+		items = append(items, "<li>"+item.Title+"</li>")
+	}
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #2563eb; color: white; padding: 20px; text-align: center;">
+		<h1>Leasingrückgabe vorbereiten</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Der Leasingvertrag für das folgende Fahrzeug endet bald. Die Checkliste für die Rückgabe wurde angelegt.</p>
+
+		<div style="background: #eff6ff; border: 1px solid #bfdbfe; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Leasingende:</strong> %s</p>
+			<p><strong>Leasinggesellschaft / Vertrag:</strong> %s / %s</p>
+			<p><strong>Hochgerechnete Mehrkilometer:</strong> %d km</p>
+		</div>
+
+		<ul>%s</ul>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		leaseReturn.LeaseEndDate.Format("02.01.2006"),
+		valueOrDash(leaseReturn.LeaseCompany), valueOrDash(leaseReturn.ContractNumber),
+		leaseReturn.ForecastExcessKm,
+		strings.Join(items, ""),
+	)
+
+	notified := 0
+	for _, manager := range managers {
+		sendAt, ok := s.deliveryTime(manager, model.NotificationExpiryReminder)
+		if !ok {
+			continue
+		}
+		if err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt); err != nil {
+			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
+			continue
+		}
+		notified++
+	}
+	log.Printf("Leasingrückgabe %s: %d Manager benachrichtigt", leaseReturn.ID.Hex(), notified)
+	return nil
+}
+
+// NotifyBookingReminder erinnert den Fahrer an eine Buchung, die in Kürze beginnt
+func (s *NotificationService) NotifyBookingReminder(reservation *model.VehicleReservation, vehicle *model.Vehicle, driver *model.Driver) error {
+	sendAt, ok := s.driverDeliveryTime(driver, model.NotificationBookingReminder)
+	if !ok {
+		return nil
+	}
+
+	start := reservation.StartTime.In(berlinLocation())
+	subject := fmt.Sprintf("Erinnerung: %s %s (%s) ab %s", vehicle.Brand, vehicle.Model, vehicle.LicensePlate, start.Format("02.01.2006 15:04"))
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #2563eb; color: white; padding: 20px; text-align: center;">
+		<h1>Ihre Fahrzeugbuchung beginnt bald</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Hallo %s %s,</p>
+
+		<p>wir erinnern Sie an Ihre anstehende Fahrzeugbuchung.</p>
+
+		<div style="background: #eff6ff; border: 1px solid #bfdbfe; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Zeitraum:</strong> %s - %s</p>
+			<p><strong>Zweck:</strong> %s</p>
+		</div>
+
+		<p>Falls Sie das Fahrzeug nicht mehr benötigen, stornieren Sie die Buchung bitte rechtzeitig.</p>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		driver.FirstName, driver.LastName,
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		start.Format("02.01.2006 15:04"),
+		reservation.EndTime.In(berlinLocation()).Format("02.01.2006 15:04"),
+		getPurposeOrDefault(reservation.Purpose),
+	)
+
+	if err := s.emailService.SendEmailAt(driver.Email, subject, "", body, sendAt); err != nil {
+		log.Printf("Fehler beim Senden der Buchungserinnerung an %s: %v", driver.Email, err)
+		return err
+	}
+
+	log.Printf("Buchungserinnerung an %s in Warteschlange eingereiht", driver.Email)
+	return nil
+}
+
+// NotifyMaintenanceDue informiert die Manager, dass eine Wartung laut Wartungsplan fällig oder überfällig ist
+func (s *NotificationService) NotifyMaintenanceDue(plan *model.MaintenancePlan, due *model.MaintenanceDue, vehicle *model.Vehicle) error {
+	managers, err := s.getManagersAndAdmins()
+	if err != nil {
+		return err
+	}
+
+	status := model.MaintenanceDueStatusText[due.Status]
+	subject := fmt.Sprintf("Wartung %s: %s für %s", strings.ToLower(status), plan.Name, vehicle.LicensePlate)
+
+	dueMileage := "–"
+	if due.DueMileage > 0 {
+		dueMileage = fmt.Sprintf("%d km (aktuell %d km)", due.DueMileage, due.CurrentMileage)
+	}
+	dueDate := "–"
+	if due.DueDate != nil {
+		dueDate = due.DueDate.Format("02.01.2006")
+	}
+
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #d97706; color: white; padding: 20px; text-align: center;">
+		<h1>Wartung %s</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Für das folgende Fahrzeug ist laut Wartungsplan eine Wartung %s.</p>
+
+		<div style="background: #fffbeb; border: 1px solid #fde68a; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Wartungsplan:</strong> %s (%s)</p>
+			<p><strong>Fällig bei:</strong> %s</p>
+			<p><strong>Fällig am:</strong> %s</p>
+		</div>
+
+		<p>Bitte vereinbaren Sie einen Werkstatttermin.</p>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		strings.ToLower(status),
+		strings.ToLower(status),
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		plan.Name, model.MaintenanceTypeText[plan.Type],
+		dueMileage,
+		dueDate,
+	)
+
+	notified := 0
+	for _, manager := range managers {
+		sendAt, ok := s.deliveryTime(manager, model.NotificationMaintenanceAlert)
+		if !ok {
+			continue
+		}
+		if err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt); err != nil {
+			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
+			continue
+		}
+		notified++
+	}
+	log.Printf("Wartung %s für %s: %d Manager benachrichtigt", plan.Name, vehicle.LicensePlate, notified)
+	return nil
+}
+
+// NotifyFuelReviewQueue erinnert die Manager an Tankvorgänge aus Tankkartenimporten, die noch keinem
+// Fahrzeug zugeordnet sind
+func (s *NotificationService) NotifyFuelReviewQueue(rows []*model.FuelImportRow) error {
+	managers, err := s.getManagersAndAdmins()
+	if err != nil {
+		return err
+	}
+
+	// Die Liste in der E-Mail bleibt kurz, die vollständige Prüfliste steht in FleetFlow
+	const maxItems = 20
+	total := 0.0
+	items := make([]string, 0, maxItems+1)
+	for i, row := range rows {
+		total += row.TotalCost
+		if i < maxItems {
+			items = append(items, fmt.Sprintf("<li>%s: %s / Karte %s, %.2f €</li>",
+				row.Date.Format("02.01.2006"), valueOrDash(row.LicensePlate), valueOrDash(row.CardNumber), row.TotalCost))
+		}
+	}
+	if len(rows) > maxItems {
+		items = append(items, fmt.Sprintf("<li>… und %d weitere</li>", len(rows)-maxItems))
+	}
+
+	subject := fmt.Sprintf("%d Tankvorgänge warten auf Zuordnung", len(rows))
+	body := fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #2563eb; color: white; padding: 20px; text-align: center;">
+		<h1>Ausstehende Tankmeldungen</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>%d Tankvorgänge aus Tankkartenimporten (zusammen %.2f €) konnten keinem Fahrzeug zugeordnet werden und
+		fehlen deshalb in den Tankkosten. Bitte ordnen Sie sie in der Prüfliste zu oder verwerfen Sie sie.</p>
+
+		<ul>%s</ul>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		len(rows), total,
+		strings.Join(items, ""),
+	)
+
+	notified := 0
+	for _, manager := range managers {
+		sendAt, ok := s.deliveryTime(manager, model.NotificationFuelReminder)
+		if !ok {
+			continue
+		}
+		if err := s.emailService.SendEmailAt(manager.Email, subject, "", body, sendAt); err != nil {
+			log.Printf("Fehler beim Senden der E-Mail an %s: %v", manager.Email, err)
+			continue
+		}
+		notified++
+	}
+	log.Printf("Prüfliste Tankkartenimport (%d Zeilen): %d Manager benachrichtigt", len(rows), notified)
+	return nil
+}
+
+// createTrafficFineEmailBody erstellt den E-Mail-Inhalt für Bußgelder an den Fahrer
+func (s *NotificationService) createTrafficFineEmailBody(fine *model.TrafficFine, vehicle *model.Vehicle, driver *model.Driver) string {
+	points := ""
+	if fine.Points > 0 {
+		points = fmt.Sprintf("<p><strong>Punkte:</strong> %d</p>", fine.Points)
+	}
+	return fmt.Sprintf(`
+<html>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+	<div style="background: #d97706; color: white; padding: 20px; text-align: center;">
+		<h1>Bußgeld zu Ihrer Fahrt</h1>
+	</div>
+
+	<div style="padding: 20px;">
+		<p>Hallo %s %s,</p>
+
+		<p>zu einer Fahrt, bei der Sie laut unseren Unterlagen das Fahrzeug geführt haben, ist ein Schreiben der Bußgeldstelle eingegangen.</p>
+
+		<div style="background: #fffbeb; border: 1px solid #fde68a; padding: 15px; border-radius: 5px; margin: 20px 0;">
+			<p><strong>Fahrzeug:</strong> %s %s (%s)</p>
+			<p><strong>Tatzeit:</strong> %s</p>
+			<p><strong>Tatort:</strong> %s</p>
+			<p><strong>Vorwurf:</strong> %s</p>
+			<p><strong>Betrag:</strong> %.2f €</p>
+			%s
+			<p><strong>Behörde / Aktenzeichen:</strong> %s / %s</p>
+		</div>
+
+		<p>Bitte melden Sie sich bis spätestens <strong>%s</strong> bei der Fuhrparkverwaltung, damit wir fristgerecht antworten können.</p>
+
+		<p>Mit freundlichen Grüßen<br>
+		Ihr FleetFlow Team</p>
+	</div>
+</body>
+</html>`,
+		driver.FirstName, driver.LastName,
+		vehicle.Brand, vehicle.Model, vehicle.LicensePlate,
+		fine.OffenseTime.In(berlinLocation()).Format("02.01.2006 15:04 Uhr"),
+		getLocationOrDefault(fine.Location),
+		fine.Offense,
+		fine.Amount,
+		points,
+		fine.Authority, fine.ReferenceNumber,
+		fine.ResponseDeadline.Format("02.01.2006"),
+	)
+}
+
 // Helper functions
 func getLocationOrDefault(location string) string {
 	if location == "" {
@@ -22,8 +22,8 @@ import (
 
 // PeopleFlowService verwaltet die Integration mit PeopleFlow
 type PeopleFlowService struct {
-	Repo       *repository.PeopleFlowRepository // Exportiert (großer Anfangsbuchstabe)
-	driverRepo *repository.DriverRepository
+	Repo       repository.PeopleFlowRepository // Exportiert (großer Anfangsbuchstabe)
+	driverRepo repository.DriverRepository
 	httpClient *http.Client
 	encryptKey []byte
 }
@@ -57,7 +57,7 @@ type PeopleFlowAPIResponse struct {
 }
 
 // NewPeopleFlowService erstellt einen neuen PeopleFlowService
-func NewPeopleFlowService() *PeopleFlowService {
+func NewPeopleFlowService(peopleFlowRepo repository.PeopleFlowRepository, driverRepo repository.DriverRepository) *PeopleFlowService {
 	// HTTP Client mit Timeout und TLS-Konfiguration
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -75,8 +75,8 @@ func NewPeopleFlowService() *PeopleFlowService {
 	encryptKey := hash[:]
 
 	return &PeopleFlowService{
-		Repo:       repository.NewPeopleFlowRepository(),
-		driverRepo: repository.NewDriverRepository(),
+		Repo:       peopleFlowRepo,
+		driverRepo: driverRepo,
 		httpClient: client,
 		encryptKey: encryptKey,
 	}
@@ -380,6 +380,45 @@ func (s *PeopleFlowService) SyncDriverEligibleEmployees() error {
 	return nil
 }
 
+// RunAutoSync synchronisiert die Mitarbeiter, sobald das konfigurierte Sync-Intervall seit
+// dem letzten Lauf abgelaufen ist. Manuelle Läufe synchronisieren unabhängig vom Intervall.
+func (s *PeopleFlowService) RunAutoSync(run JobRunContext) error {
+	integration, err := s.Repo.GetIntegration()
+	if err != nil {
+		return err
+	}
+
+	manual := run.Trigger == model.JobTriggerManual
+	if integration == nil || !integration.IsActive {
+		if manual {
+			return errors.New("keine aktive PeopleFlow-Integration konfiguriert")
+		}
+		return ErrJobSkipped
+	}
+
+	if !manual {
+		if !integration.AutoSync {
+			return ErrJobSkipped
+		}
+
+		// Letzte Synchronisation: automatischer Lauf oder manueller Sync über die Integrationsseite
+		lastSync := integration.LastSync
+		if run.LastRunAt != nil && run.LastRunAt.After(lastSync) {
+			lastSync = *run.LastRunAt
+		}
+		interval := integration.SyncInterval
+		if interval < 5 {
+			interval = 5
+		}
+		if time.Since(lastSync) < time.Duration(interval)*time.Minute {
+			return ErrJobSkipped
+		}
+	}
+
+	_, err = s.SyncEmployees("auto")
+	return err
+}
+
 // === Helper Methods ===
 
 // fetchEmployeesFromAPI ruft alle Mitarbeiter von der PeopleFlow API ab
@@ -0,0 +1,512 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReservationScope legt fest, ob eine Änderung nur einen Termin oder die ganze Serie betrifft
+type ReservationScope string
+
+const (
+	ReservationScopeOccurrence ReservationScope = "occurrence" // Nur dieser Termin
+	ReservationScopeSeries     ReservationScope = "series"     // Alle offenen Termine der Serie
+)
+
+// ErrSeriesConflict wird zurückgegeben, wenn Termine einer Serie mit bestehenden Reservierungen kollidieren
+// oder nach dem Ablauf des Führerscheins liegen
+var ErrSeriesConflict = errors.New("termine der serie überschneiden sich mit bestehenden reservierungen oder liegen nach dem ablauf des führerscheins")
+
+// ReservationOccurrence beschreibt einen einzelnen Termin einer Serie
+type ReservationOccurrence struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// OccurrenceConflict beschreibt den Konflikt eines einzelnen Termins
+type OccurrenceConflict struct {
+	ReservationOccurrence
+	ReservationID string                      `json:"reservationId,omitempty"` // Nur bei bestehenden Terminen
+	Conflicts     *repository.ConflictDetails `json:"conflicts"`
+}
+
+// ReservationSeriesResult fasst das Ergebnis einer Serienoperation zusammen
+type ReservationSeriesResult struct {
+	Series       *model.ReservationSeries   `json:"series,omitempty"`
+	Reservations []model.VehicleReservation `json:"reservations"`
+	Conflicts    []OccurrenceConflict       `json:"conflicts"`
+}
+
+// CreateReservationSeries erstellt eine Serie wiederkehrender Reservierungen.
+// Jeder Termin wird einzeln auf Konflikte geprüft. Mit skipConflicts werden
+// kollidierende Termine ausgelassen, sonst wird bei Konflikten nichts angelegt.
+func (s *ReservationService) CreateReservationSeries(vehicleID, driverID string, startTime, endTime time.Time, rule model.RecurrenceRule, purpose, notes string, skipConflicts bool, createdBy primitive.ObjectID) (*ReservationSeriesResult, error) {
+	vehicle, driver, err := s.validateReservationRequest(vehicleID, driverID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := ExpandRecurrence(startTime, endTime, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// Der Führerschein muss bis zum Ende jedes Termins gültig sein, nicht nur bis zum ersten
+	licenseExpiry, err := s.eligibility.LicenseExpiry(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jeden Termin einzeln auf Konflikte prüfen
+	result := &ReservationSeriesResult{}
+	var free []ReservationOccurrence
+	for _, occurrence := range occurrences {
+		if details := licenseExpiryConflict(driver, licenseExpiry, occurrence.EndTime); details != nil {
+			result.Conflicts = append(result.Conflicts, OccurrenceConflict{ReservationOccurrence: occurrence, Conflicts: details})
+			continue
+		}
+		details, err := s.reservationRepo.CheckConflictDetails(vehicleID, occurrence.StartTime, occurrence.EndTime, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fehler beim prüfen auf konflikte: %v", err)
+		}
+		if details.HasConflict {
+			result.Conflicts = append(result.Conflicts, OccurrenceConflict{ReservationOccurrence: occurrence, Conflicts: details})
+			continue
+		}
+		free = append(free, occurrence)
+	}
+
+	if len(result.Conflicts) > 0 && !skipConflicts {
+		return result, ErrSeriesConflict
+	}
+	if len(free) == 0 {
+		return result, fmt.Errorf("alle termine der serie stehen in konflikt mit bestehenden reservierungen oder dem ablauf des führerscheins")
+	}
+
+	vehicleObjectID := vehicle.ID
+	series := &model.ReservationSeries{
+		VehicleID:  vehicleObjectID,
+		DriverID:   driver.ID,
+		StartTime:  free[0].StartTime,
+		EndTime:    free[0].EndTime,
+		Recurrence: rule,
+		Status:     model.ReservationSeriesStatusActive,
+		Purpose:    purpose,
+		Notes:      notes,
+		CreatedBy:  createdBy,
+	}
+	if err := s.seriesRepo.Create(series); err != nil {
+		return nil, fmt.Errorf("fehler beim erstellen der serie: %v", err)
+	}
+	result.Series = series
+
+	for _, occurrence := range free {
+		reservation := &model.VehicleReservation{
+			VehicleID: vehicleObjectID,
+			DriverID:  driver.ID,
+			StartTime: occurrence.StartTime,
+			EndTime:   occurrence.EndTime,
+			Status:    model.ReservationStatusPending,
+			Purpose:   purpose,
+			Notes:     notes,
+			CreatedBy: createdBy,
+			SeriesID:  &series.ID,
+		}
+		if err := s.reservationRepo.Create(reservation); err != nil {
+			return result, fmt.Errorf("fehler beim erstellen der reservierung vom %s: %v", occurrence.StartTime.Format("02.01.2006"), err)
+		}
+		result.Reservations = append(result.Reservations, *reservation)
+	}
+
+	// Aktivität protokollieren
+	s.activityService.LogActivity(
+		"vehicle_reservation_series_created",
+		fmt.Sprintf("Reservierungsserie für Fahrzeug %s (%s) erstellt für Fahrer %s %s: %d Termine ab %s, %d Konflikte",
+			vehicle.LicensePlate, vehicle.Brand+" "+vehicle.Model,
+			driver.FirstName, driver.LastName,
+			len(result.Reservations),
+			series.StartTime.Format("02.01.2006 15:04"),
+			len(result.Conflicts)),
+		createdBy,
+		&vehicleObjectID,
+	)
+
+	return result, nil
+}
+
+// GetReservationSeries lädt eine Serie mit allen zugehörigen Reservierungen
+func (s *ReservationService) GetReservationSeries(seriesID string) (*ReservationSeriesResult, error) {
+	series, err := s.seriesRepo.FindByID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("serie nicht gefunden: %v", err)
+	}
+
+	reservations, err := s.reservationRepo.FindBySeriesID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der serientermine: %v", err)
+	}
+
+	return &ReservationSeriesResult{Series: series, Reservations: reservations}, nil
+}
+
+// UpdateReservationSeries überträgt die Änderung eines Termins auf alle offenen Termine seiner Serie.
+// Die Verschiebung von Beginn und Ende wird in Tagen und Uhrzeit (Europe/Berlin) auf jeden ausstehenden
+// oder genehmigten Termin angewendet; laufende und abgeschlossene Termine bleiben unverändert.
+func (s *ReservationService) UpdateReservationSeries(reservationID string, startTime, endTime time.Time, purpose, notes string, updatedBy primitive.ObjectID) (*ReservationSeriesResult, error) {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+	if reservation.SeriesID == nil {
+		return nil, fmt.Errorf("reservierung gehört zu keiner serie")
+	}
+	if startTime.After(endTime) {
+		return nil, fmt.Errorf("startzeit muss vor endzeit liegen")
+	}
+
+	series, err := s.seriesRepo.FindByID(reservation.SeriesID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("serie nicht gefunden: %v", err)
+	}
+
+	reservations, err := s.reservationRepo.FindBySeriesID(series.ID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der serientermine: %v", err)
+	}
+
+	startShift := newWallClockShift(reservation.StartTime, startTime)
+	endShift := newWallClockShift(reservation.EndTime, endTime)
+
+	// Neue Zeiträume berechnen und die verschobenen Termine merken
+	var editable []model.VehicleReservation
+	shifted := make(map[primitive.ObjectID]bool)
+	for _, occurrence := range reservations {
+		if !isSeriesEditable(occurrence.Status) {
+			continue
+		}
+		previousStart := occurrence.StartTime
+		occurrence.StartTime = startShift.apply(occurrence.StartTime)
+		occurrence.EndTime = endShift.apply(occurrence.EndTime)
+		if !occurrence.StartTime.Equal(previousStart) {
+			occurrence.ReminderSentAt = nil
+		}
+		editable = append(editable, occurrence)
+		shifted[occurrence.ID] = true
+	}
+	if len(editable) == 0 {
+		return nil, fmt.Errorf("die serie enthält keine bearbeitbaren termine")
+	}
+
+	for i := 1; i < len(editable); i++ {
+		if editable[i-1].EndTime.After(editable[i].StartTime) {
+			return nil, fmt.Errorf("termine der serie überschneiden sich gegenseitig")
+		}
+	}
+
+	driver, err := s.driverRepo.FindByID(series.DriverID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrer nicht gefunden: %v", err)
+	}
+	licenseExpiry, err := s.eligibility.LicenseExpiry(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	// Konflikte pro Termin prüfen; andere verschobene Termine der Serie zählen nicht als Konflikt
+	result := &ReservationSeriesResult{Series: series}
+	for _, occurrence := range editable {
+		occurrenceID := occurrence.ID.Hex()
+		if details := licenseExpiryConflict(driver, licenseExpiry, occurrence.EndTime); details != nil {
+			result.Conflicts = append(result.Conflicts, OccurrenceConflict{
+				ReservationOccurrence: ReservationOccurrence{StartTime: occurrence.StartTime, EndTime: occurrence.EndTime},
+				ReservationID:         occurrenceID,
+				Conflicts:             details,
+			})
+			continue
+		}
+		details, err := s.reservationRepo.CheckConflictDetails(occurrence.VehicleID.Hex(), occurrence.StartTime, occurrence.EndTime, &occurrenceID)
+		if err != nil {
+			return nil, fmt.Errorf("fehler beim prüfen auf konflikte: %v", err)
+		}
+
+		var remaining []model.VehicleReservation
+		for _, conflict := range details.ConflictingReservations {
+			if !shifted[conflict.ID] {
+				remaining = append(remaining, conflict)
+			}
+		}
+		if len(remaining) > 0 {
+			result.Conflicts = append(result.Conflicts, OccurrenceConflict{
+				ReservationOccurrence: ReservationOccurrence{StartTime: occurrence.StartTime, EndTime: occurrence.EndTime},
+				ReservationID:         occurrenceID,
+				Conflicts:             repository.NewConflictDetails(remaining),
+			})
+		}
+	}
+	if len(result.Conflicts) > 0 {
+		return result, ErrSeriesConflict
+	}
+
+	for _, occurrence := range editable {
+		occurrence.Purpose = purpose
+		occurrence.Notes = notes
+		if err := s.reservationRepo.Update(&occurrence); err != nil {
+			return result, fmt.Errorf("fehler beim aktualisieren der reservierung %s: %v", occurrence.ID.Hex(), err)
+		}
+		result.Reservations = append(result.Reservations, occurrence)
+	}
+
+	series.StartTime = startShift.apply(series.StartTime)
+	series.EndTime = endShift.apply(series.EndTime)
+	series.Purpose = purpose
+	series.Notes = notes
+	if err := s.seriesRepo.Update(series); err != nil {
+		return result, fmt.Errorf("fehler beim aktualisieren der serie: %v", err)
+	}
+
+	// Aktivität protokollieren
+	s.activityService.LogActivity(
+		"vehicle_reservation_series_updated",
+		fmt.Sprintf("Reservierungsserie %s aktualisiert (%d Termine)", series.ID.Hex(), len(result.Reservations)),
+		updatedBy,
+		&series.VehicleID,
+	)
+
+	return result, nil
+}
+
+// CancelReservationSeries storniert alle offenen Termine der Serie, zu der die Reservierung gehört
+func (s *ReservationService) CancelReservationSeries(reservationID string, cancelledBy primitive.ObjectID) error {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+	if reservation.SeriesID == nil {
+		return fmt.Errorf("reservierung gehört zu keiner serie")
+	}
+
+	series, err := s.seriesRepo.FindByID(reservation.SeriesID.Hex())
+	if err != nil {
+		return fmt.Errorf("serie nicht gefunden: %v", err)
+	}
+
+	reservations, err := s.reservationRepo.FindBySeriesID(series.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("fehler beim laden der serientermine: %v", err)
+	}
+
+	cancelled := 0
+	for _, occurrence := range reservations {
+		if !isSeriesEditable(occurrence.Status) && occurrence.Status != model.ReservationStatusActive {
+			continue
+		}
+		if err := s.CancelReservation(occurrence.ID.Hex(), cancelledBy); err != nil {
+			return fmt.Errorf("fehler beim stornieren des termins vom %s: %v", occurrence.StartTime.Format("02.01.2006"), err)
+		}
+		cancelled++
+	}
+
+	series.Status = model.ReservationSeriesStatusCancelled
+	if err := s.seriesRepo.Update(series); err != nil {
+		return fmt.Errorf("fehler beim stornieren der serie: %v", err)
+	}
+
+	// Aktivität protokollieren
+	s.activityService.LogActivity(
+		"vehicle_reservation_series_cancelled",
+		fmt.Sprintf("Reservierungsserie %s storniert (%d Termine)", series.ID.Hex(), cancelled),
+		cancelledBy,
+		&series.VehicleID,
+	)
+
+	return nil
+}
+
+// licenseExpiryConflict meldet einen Termin, der nach dem Ablauf des Führerscheins endet, wie einen Konflikt
+func licenseExpiryConflict(driver *model.Driver, licenseExpiry *time.Time, endTime time.Time) *repository.ConflictDetails {
+	if licenseExpiry == nil || !licenseExpiry.Before(endTime) {
+		return nil
+	}
+	return &repository.ConflictDetails{
+		HasConflict:             true,
+		ConflictingReservations: []model.VehicleReservation{},
+		Message:                 licenseExpiryError(driver, *licenseExpiry).Error(),
+	}
+}
+
+// isSeriesEditable prüft, ob ein Serientermin bei Änderungen der ganzen Serie angepasst wird
+func isSeriesEditable(status model.ReservationStatus) bool {
+	return status == model.ReservationStatusPending || status == model.ReservationStatusApproved
+}
+
+// ExpandRecurrence berechnet alle Termine einer Wiederholungsregel ab dem ersten Zeitraum.
+// Die Uhrzeit bleibt auch über Zeitumstellungen hinweg erhalten.
+func ExpandRecurrence(startTime, endTime time.Time, rule model.RecurrenceRule) ([]ReservationOccurrence, error) {
+	if err := validateRecurrenceRule(startTime, rule); err != nil {
+		return nil, err
+	}
+
+	interval := rule.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	duration := endTime.Sub(startTime)
+
+	var occurrences []ReservationOccurrence
+	var limitErr error
+	// emit fügt einen Termin hinzu und meldet, ob weitere Termine berechnet werden sollen
+	emit := func(start time.Time) bool {
+		if rule.Until != nil && start.After(*rule.Until) {
+			return false
+		}
+		if len(occurrences) >= model.MaxSeriesOccurrences {
+			limitErr = fmt.Errorf("die serie überschreitet die maximale anzahl von %d terminen", model.MaxSeriesOccurrences)
+			return false
+		}
+		occurrences = append(occurrences, ReservationOccurrence{StartTime: start, EndTime: start.Add(duration)})
+		return rule.Count == 0 || len(occurrences) < rule.Count
+	}
+
+	switch rule.Frequency {
+	case model.RecurrenceDaily:
+		for i := 0; emit(startTime.AddDate(0, 0, i*interval)); i++ {
+		}
+
+	case model.RecurrenceWeekly:
+		weekdays := rule.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{startTime.Weekday()}
+		}
+		// Wochentage ab Montag sortieren
+		offsets := make([]int, 0, len(weekdays))
+		seen := make(map[int]bool)
+		for _, weekday := range weekdays {
+			offset := (int(weekday) + 6) % 7
+			if !seen[offset] {
+				seen[offset] = true
+				offsets = append(offsets, offset)
+			}
+		}
+		sort.Ints(offsets)
+
+		weekStart := startTime.AddDate(0, 0, -((int(startTime.Weekday()) + 6) % 7))
+		for week := 0; ; week++ {
+			more := true
+			for _, offset := range offsets {
+				start := weekStart.AddDate(0, 0, week*7*interval+offset)
+				if start.Before(startTime) {
+					continue
+				}
+				if more = emit(start); !more {
+					break
+				}
+			}
+			if !more {
+				break
+			}
+		}
+
+	case model.RecurrenceMonthly:
+		day := startTime.Day()
+		// Monate ohne diesen Tag (z.B. der 31.) werden übersprungen
+		for i := 0; i <= model.MaxSeriesOccurrences*12; i++ {
+			start := time.Date(startTime.Year(), startTime.Month()+time.Month(i*interval), day,
+				startTime.Hour(), startTime.Minute(), startTime.Second(), startTime.Nanosecond(), startTime.Location())
+			if start.Day() != day {
+				continue
+			}
+			if !emit(start) {
+				break
+			}
+		}
+	}
+
+	if limitErr != nil {
+		return nil, limitErr
+	}
+	if len(occurrences) == 0 {
+		return nil, fmt.Errorf("die wiederholungsregel ergibt keine termine")
+	}
+	for i := 1; i < len(occurrences); i++ {
+		if occurrences[i-1].EndTime.After(occurrences[i].StartTime) {
+			return nil, fmt.Errorf("termine der serie überschneiden sich gegenseitig")
+		}
+	}
+
+	return occurrences, nil
+}
+
+// validateRecurrenceRule prüft eine Wiederholungsregel auf Vollständigkeit
+func validateRecurrenceRule(startTime time.Time, rule model.RecurrenceRule) error {
+	switch rule.Frequency {
+	case model.RecurrenceDaily, model.RecurrenceMonthly:
+		if len(rule.Weekdays) > 0 {
+			return fmt.Errorf("wochentage sind nur bei wöchentlicher wiederholung möglich")
+		}
+	case model.RecurrenceWeekly:
+		for _, weekday := range rule.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return fmt.Errorf("ungültiger wochentag: %d", weekday)
+			}
+		}
+	default:
+		return fmt.Errorf("ungültige wiederholungsart: %s", rule.Frequency)
+	}
+
+	if rule.Interval < 0 {
+		return fmt.Errorf("intervall darf nicht negativ sein")
+	}
+	if rule.Count < 0 {
+		return fmt.Errorf("anzahl der termine darf nicht negativ sein")
+	}
+	if (rule.Until == nil) == (rule.Count == 0) {
+		return fmt.Errorf("entweder enddatum oder anzahl der termine muss angegeben werden")
+	}
+	if rule.Count > model.MaxSeriesOccurrences {
+		return fmt.Errorf("die serie überschreitet die maximale anzahl von %d terminen", model.MaxSeriesOccurrences)
+	}
+	if rule.Until != nil && rule.Until.Before(startTime) {
+		return fmt.Errorf("enddatum der serie muss nach dem ersten termin liegen")
+	}
+
+	return nil
+}
+
+// wallClockShift beschreibt eine Verschiebung in Kalendertagen und Uhrzeit (Europe/Berlin), damit
+// Serientermine jenseits einer Zeitumstellung ihre Uhrzeit behalten
+type wallClockShift struct {
+	location *time.Location
+	days     int
+	clock    time.Duration
+}
+
+// newWallClockShift ermittelt die Verschiebung von from nach to
+func newWallClockShift(from, to time.Time) wallClockShift {
+	location := berlinLocation()
+	from, to = from.In(location), to.In(location)
+	return wallClockShift{
+		location: location,
+		days:     int(calendarDay(to).Sub(calendarDay(from)).Hours() / 24),
+		clock:    wallClock(to) - wallClock(from),
+	}
+}
+
+// apply verschiebt t um die Tage und die Uhrzeitdifferenz
+func (s wallClockShift) apply(t time.Time) time.Time {
+	t = t.In(s.location)
+	// time.Date normalisiert die Uhrzeit über Tagesgrenzen hinweg in der Ortszeit
+	return time.Date(t.Year(), t.Month(), t.Day()+s.days, 0, 0, 0, int(wallClock(t)+s.clock), s.location)
+}
+
+// wallClock liefert die Uhrzeit eines Zeitpunkts als Dauer seit Mitternacht
+func wallClock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
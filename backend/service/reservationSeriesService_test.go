@@ -0,0 +1,205 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"testing"
+	"time"
+)
+
+func TestExpandRecurrence(t *testing.T) {
+	// Montag, 6. Januar 2025, 08:00–10:00 Uhr
+	start := time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	until := func(year int, month time.Month, day int) *time.Time {
+		t := time.Date(year, month, day, 23, 59, 0, 0, time.UTC)
+		return &t
+	}
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 8, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		rule    model.RecurrenceRule
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name:  "täglich mit anzahl",
+			start: start,
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceDaily, Count: 3},
+			want:  []time.Time{day(1, 6), day(1, 7), day(1, 8)},
+		},
+		{
+			name:  "jeden zweiten tag bis enddatum",
+			start: start,
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceDaily, Interval: 2, Until: until(2025, 1, 11)},
+			want:  []time.Time{day(1, 6), day(1, 8), day(1, 10)},
+		},
+		{
+			name:  "wöchentlich ohne wochentage nutzt den starttag",
+			start: start,
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceWeekly, Count: 3},
+			want:  []time.Time{day(1, 6), day(1, 13), day(1, 20)},
+		},
+		{
+			name:  "wöchentlich an mehreren tagen, sonntag zählt zum wochenende",
+			start: start,
+			rule: model.RecurrenceRule{
+				Frequency: model.RecurrenceWeekly,
+				Weekdays:  []time.Weekday{time.Sunday, time.Wednesday, time.Monday},
+				Count:     5,
+			},
+			want: []time.Time{day(1, 6), day(1, 8), day(1, 12), day(1, 13), day(1, 15)},
+		},
+		{
+			name:  "wochentage vor dem start werden in der ersten woche übersprungen",
+			start: day(1, 8),
+			rule: model.RecurrenceRule{
+				Frequency: model.RecurrenceWeekly,
+				Weekdays:  []time.Weekday{time.Monday, time.Friday},
+				Count:     3,
+			},
+			want: []time.Time{day(1, 10), day(1, 13), day(1, 17)},
+		},
+		{
+			name:  "alle zwei wochen",
+			start: start,
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceWeekly, Interval: 2, Until: until(2025, 2, 3)},
+			want:  []time.Time{day(1, 6), day(1, 20), day(2, 3)},
+		},
+		{
+			name:  "monatlich überspringt monate ohne den tag",
+			start: day(1, 31),
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceMonthly, Count: 3},
+			want:  []time.Time{day(1, 31), day(3, 31), day(5, 31)},
+		},
+		{
+			name:  "quartalsweise",
+			start: start,
+			rule:  model.RecurrenceRule{Frequency: model.RecurrenceMonthly, Interval: 3, Until: until(2025, 12, 31)},
+			want:  []time.Time{day(1, 6), day(4, 6), day(7, 6), day(10, 6)},
+		},
+		{
+			name:    "weder anzahl noch enddatum",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: model.RecurrenceDaily},
+			wantErr: true,
+		},
+		{
+			name:    "anzahl und enddatum gleichzeitig",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: model.RecurrenceDaily, Count: 2, Until: until(2025, 2, 1)},
+			wantErr: true,
+		},
+		{
+			name:    "wochentage bei täglicher wiederholung",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: model.RecurrenceDaily, Weekdays: []time.Weekday{time.Monday}, Count: 2},
+			wantErr: true,
+		},
+		{
+			name:    "ungültige wiederholungsart",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: "yearly", Count: 2},
+			wantErr: true,
+		},
+		{
+			name:    "negatives intervall",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: model.RecurrenceDaily, Interval: -1, Count: 2},
+			wantErr: true,
+		},
+		{
+			name:    "zu viele termine",
+			start:   start,
+			rule:    model.RecurrenceRule{Frequency: model.RecurrenceDaily, Count: model.MaxSeriesOccurrences + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandRecurrence(tt.start, tt.start.Add(2*time.Hour), tt.rule)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fehler erwartet, %d termine erhalten", len(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("%d termine erhalten, %d erwartet: %v", len(got), len(tt.want), got)
+			}
+			for i, occurrence := range got {
+				if !occurrence.StartTime.Equal(tt.want[i]) {
+					t.Errorf("termin %d beginnt %v, erwartet %v", i, occurrence.StartTime, tt.want[i])
+				}
+				if occurrence.EndTime.Sub(occurrence.StartTime) != end.Sub(start) {
+					t.Errorf("termin %d dauert %v, erwartet %v", i, occurrence.EndTime.Sub(occurrence.StartTime), end.Sub(start))
+				}
+			}
+		})
+	}
+}
+
+func TestExpandRecurrenceOverlap(t *testing.T) {
+	start := time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC)
+	rule := model.RecurrenceRule{Frequency: model.RecurrenceDaily, Count: 2}
+
+	if _, err := ExpandRecurrence(start, start.Add(25*time.Hour), rule); err == nil {
+		t.Fatal("überschneidende termine sollten abgelehnt werden")
+	}
+}
+
+func TestExpandRecurrenceKeepsWallClockAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("zeitzonendaten nicht verfügbar: %v", err)
+	}
+
+	// Die Zeitumstellung am 30. März 2025 liegt zwischen den beiden Terminen
+	start := time.Date(2025, time.March, 29, 8, 0, 0, 0, berlin)
+	got, err := ExpandRecurrence(start, start.Add(time.Hour), model.RecurrenceRule{Frequency: model.RecurrenceDaily, Count: 2})
+	if err != nil {
+		t.Fatalf("unerwarteter fehler: %v", err)
+	}
+	if hour := got[1].StartTime.Hour(); hour != 8 {
+		t.Errorf("zweiter termin beginnt um %d uhr, erwartet 8 uhr", hour)
+	}
+}
+
+func TestWallClockShiftAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("zeitzonendaten nicht verfügbar: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, berlin)
+	}
+
+	// Die Zeitumstellung am 30. März 2025 liegt zwischen den Terminen
+	tests := []struct {
+		name     string
+		from, to time.Time
+		apply    time.Time
+		want     time.Time
+	}{
+		{"eine stunde später", at(29, 8, 0), at(29, 9, 0), at(31, 8, 0), at(31, 9, 0)},
+		{"einen tag später", at(29, 8, 0), at(30, 8, 0), at(29, 8, 0), at(30, 8, 0)},
+		{"drei tage später, anderer termin über die umstellung", at(28, 8, 0), at(31, 8, 0), at(27, 8, 0), at(30, 8, 0)},
+		{"über mitternacht zurück", at(28, 1, 0), at(27, 23, 30), at(31, 1, 0), at(30, 23, 30)},
+		{"unverändert", at(29, 8, 0), at(29, 8, 0), at(31, 8, 0), at(31, 8, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newWallClockShift(tt.from, tt.to).apply(tt.apply); !got.Equal(tt.want) {
+				t.Errorf("apply = %v, erwartet %v", got.In(berlin), tt.want)
+			}
+		})
+	}
+}
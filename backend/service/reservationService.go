@@ -11,63 +11,36 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// bookingReminderHours ist der Vorlauf, mit dem Fahrer an eine anstehende Buchung erinnert werden
+const bookingReminderHours = 24
+
 type ReservationService struct {
-	reservationRepo *repository.VehicleReservationRepository
-	vehicleRepo     *repository.VehicleRepository
-	driverRepo      *repository.DriverRepository
-	activityService *ActivityService
+	reservationRepo     repository.VehicleReservationRepository
+	seriesRepo          repository.ReservationSeriesRepository
+	vehicleRepo         repository.VehicleRepository
+	driverRepo          repository.DriverRepository
+	eligibility         *EligibilityService
+	notificationService *NotificationService
+	activityService     *ActivityService
 }
 
-func NewReservationService() *ReservationService {
+func NewReservationService(reservationRepo repository.VehicleReservationRepository, seriesRepo repository.ReservationSeriesRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, eligibility *EligibilityService, notificationService *NotificationService, activityService *ActivityService) *ReservationService {
 	return &ReservationService{
-		reservationRepo: repository.NewVehicleReservationRepository(),
-		vehicleRepo:     repository.NewVehicleRepository(),
-		driverRepo:      repository.NewDriverRepository(),
-		activityService: NewActivityService(),
+		reservationRepo:     reservationRepo,
+		seriesRepo:          seriesRepo,
+		vehicleRepo:         vehicleRepo,
+		driverRepo:          driverRepo,
+		eligibility:         eligibility,
+		notificationService: notificationService,
+		activityService:     activityService,
 	}
 }
 
 // CreateReservation erstellt eine neue Fahrzeug-Reservierung
 func (s *ReservationService) CreateReservation(vehicleID, driverID string, startTime, endTime time.Time, purpose, notes string, createdBy primitive.ObjectID) (*model.VehicleReservation, error) {
-	// Input-Validierung
-	if vehicleID == "" {
-		return nil, fmt.Errorf("fahrzeug-id ist erforderlich")
-	}
-	if driverID == "" {
-		return nil, fmt.Errorf("fahrer-id ist erforderlich")
-	}
-
-	// Zeit-Validierung
-	if startTime.After(endTime) {
-		return nil, fmt.Errorf("startzeit muss vor endzeit liegen")
-	}
-
-	if startTime.Before(time.Now()) {
-		return nil, fmt.Errorf("startzeit kann nicht in der vergangenheit liegen")
-	}
-
-	// ObjectID-Validierung für Fahrzeug
-	_, err := primitive.ObjectIDFromHex(vehicleID)
-	if err != nil {
-		return nil, fmt.Errorf("ungültige fahrzeug-id format: %v", err)
-	}
-
-	// ObjectID-Validierung für Fahrer
-	_, err = primitive.ObjectIDFromHex(driverID)
-	if err != nil {
-		return nil, fmt.Errorf("ungültige fahrer-id format: %v", err)
-	}
-
-	// Fahrzeug validieren
-	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
-	if err != nil {
-		return nil, fmt.Errorf("fahrzeug nicht gefunden: %v", err)
-	}
-
-	// Fahrer validieren
-	driver, err := s.driverRepo.FindByID(driverID)
+	vehicle, driver, err := s.validateReservationRequest(vehicleID, driverID, startTime, endTime)
 	if err != nil {
-		return nil, fmt.Errorf("fahrer nicht gefunden: %v", err)
+		return nil, err
 	}
 
 	// Auf Konflikte prüfen
@@ -136,6 +109,57 @@ func (s *ReservationService) CreateReservation(vehicleID, driverID string, start
 	return reservation, nil
 }
 
+// validateReservationRequest prüft Fahrzeug, Fahrer und Zeitraum einer neuen Reservierung
+func (s *ReservationService) validateReservationRequest(vehicleID, driverID string, startTime, endTime time.Time) (*model.Vehicle, *model.Driver, error) {
+	// Input-Validierung
+	if vehicleID == "" {
+		return nil, nil, fmt.Errorf("fahrzeug-id ist erforderlich")
+	}
+	if driverID == "" {
+		return nil, nil, fmt.Errorf("fahrer-id ist erforderlich")
+	}
+
+	// Zeit-Validierung
+	if startTime.After(endTime) {
+		return nil, nil, fmt.Errorf("startzeit muss vor endzeit liegen")
+	}
+
+	if startTime.Before(time.Now()) {
+		return nil, nil, fmt.Errorf("startzeit kann nicht in der vergangenheit liegen")
+	}
+
+	// ObjectID-Validierung für Fahrzeug
+	_, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ungültige fahrzeug-id format: %v", err)
+	}
+
+	// ObjectID-Validierung für Fahrer
+	_, err = primitive.ObjectIDFromHex(driverID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ungültige fahrer-id format: %v", err)
+	}
+
+	// Fahrzeug validieren
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fahrzeug nicht gefunden: %v", err)
+	}
+
+	// Fahrer validieren
+	driver, err := s.driverRepo.FindByID(driverID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fahrer nicht gefunden: %v", err)
+	}
+
+	// Führerscheinklasse und Gültigkeit für den gesamten Zeitraum prüfen
+	if err := s.eligibility.CheckDriverEligibility(driver, vehicle, endTime); err != nil {
+		return nil, nil, err
+	}
+
+	return vehicle, driver, nil
+}
+
 // UpdateReservation aktualisiert eine bestehende Reservierung
 func (s *ReservationService) UpdateReservation(reservationID string, startTime, endTime time.Time, purpose, notes string, updatedBy primitive.ObjectID) error {
 	// Bestehende Reservierung laden
@@ -154,6 +178,26 @@ func (s *ReservationService) UpdateReservation(reservationID string, startTime,
 		return fmt.Errorf("startzeit muss vor endzeit liegen")
 	}
 
+	// Bei einer Verschiebung Fahrzeug, Fahrer und Führerschein wie bei einer neuen Reservierung prüfen.
+	// Wird nur das Ende einer laufenden Reservierung geändert, darf der Beginn in der Vergangenheit liegen.
+	if !reservation.StartTime.Equal(startTime) {
+		if _, _, err := s.validateReservationRequest(reservation.VehicleID.Hex(), reservation.DriverID.Hex(), startTime, endTime); err != nil {
+			return err
+		}
+	} else if !reservation.EndTime.Equal(endTime) {
+		vehicle, err := s.vehicleRepo.FindByID(reservation.VehicleID.Hex())
+		if err != nil {
+			return fmt.Errorf("fahrzeug nicht gefunden: %v", err)
+		}
+		driver, err := s.driverRepo.FindByID(reservation.DriverID.Hex())
+		if err != nil {
+			return fmt.Errorf("fahrer nicht gefunden: %v", err)
+		}
+		if err := s.eligibility.CheckDriverEligibility(driver, vehicle, endTime); err != nil {
+			return err
+		}
+	}
+
 	// Auf Konflikte prüfen (ausgenommen die aktuelle Reservierung)
 	hasConflict, err := s.reservationRepo.CheckConflict(reservation.VehicleID.Hex(), startTime, endTime, &reservationID)
 	if err != nil {
@@ -164,7 +208,10 @@ func (s *ReservationService) UpdateReservation(reservationID string, startTime,
 		return fmt.Errorf("das fahrzeug ist für den gewählten zeitraum bereits reserviert")
 	}
 
-	// Reservierung aktualisieren
+	// Reservierung aktualisieren; nach einer Verschiebung wird erneut erinnert
+	if !reservation.StartTime.Equal(startTime) {
+		reservation.ReminderSentAt = nil
+	}
 	reservation.StartTime = startTime
 	reservation.EndTime = endTime
 	reservation.Purpose = purpose
@@ -245,8 +292,30 @@ func (s *ReservationService) ActivateReservation(reservationID string) error {
 
 	// Entferne Zeit-Check - Scheduler kann Reservierungen zum passenden Zeitpunkt aktivieren
 
+	return s.activate(reservation)
+}
+
+// CheckOutReservation aktiviert eine ausstehende oder genehmigte Reservierung bei der Fahrzeugausgabe
+func (s *ReservationService) CheckOutReservation(reservationID string) error {
+	reservation, err := s.reservationRepo.FindByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("reservierung nicht gefunden: %v", err)
+	}
+
+	switch reservation.Status {
+	case model.ReservationStatusActive:
+		return nil
+	case model.ReservationStatusPending, model.ReservationStatusApproved:
+		return s.activate(reservation)
+	default:
+		return fmt.Errorf("reservierung kann im status %s nicht ausgegeben werden", reservation.Status)
+	}
+}
+
+// activate setzt Reservierung, Fahrzeug und Fahrer auf aktiv bzw. reserviert
+func (s *ReservationService) activate(reservation *model.VehicleReservation) error {
 	reservation.Status = model.ReservationStatusActive
-	err = s.reservationRepo.Update(reservation)
+	err := s.reservationRepo.Update(reservation)
 	if err != nil {
 		return fmt.Errorf("fehler beim aktivieren der reservierung: %v", err)
 	}
@@ -352,9 +421,11 @@ func (s *ReservationService) ProcessScheduledReservations() error {
 		return err
 	}
 
+	processed := 0
 	for _, reservation := range reservations {
 		// Ausstehende Reservierungen aktivieren (wenn Startzeit erreicht ist)
 		if reservation.Status == model.ReservationStatusPending && now.After(reservation.StartTime) {
+			processed++
 			log.Printf("Aktiviere Reservierung %s (Start: %v, Jetzt: %v)", reservation.ID.Hex(), reservation.StartTime, now)
 			err := s.ActivateReservation(reservation.ID.Hex())
 			if err != nil {
@@ -364,6 +435,7 @@ func (s *ReservationService) ProcessScheduledReservations() error {
 
 		// Abgelaufene aktive Reservierungen automatisch abschließen
 		if reservation.Status == model.ReservationStatusActive && now.After(reservation.EndTime) {
+			processed++
 			log.Printf("Schließe abgelaufene Reservierung %s ab (Ende: %v, Jetzt: %v)", reservation.ID.Hex(), reservation.EndTime, now)
 			// Systembenutzer ID verwenden (könnte konfigurierbar sein)
 			systemUserID := primitive.NewObjectID() // TODO: Konfigurierbare System-User-ID
@@ -374,9 +446,65 @@ func (s *ReservationService) ProcessScheduledReservations() error {
 		}
 	}
 
+	reminded, err := s.SendBookingReminders()
+	if err != nil {
+		return err
+	}
+
+	// Minütliche Läufe ohne fällige Reservierungen nicht in der Jobhistorie speichern
+	if processed == 0 && reminded == 0 {
+		return ErrJobSkipped
+	}
 	return nil
 }
 
+// SendBookingReminders erinnert Fahrer an Buchungen, die innerhalb der nächsten 24 Stunden beginnen,
+// und liefert die Anzahl der versendeten Erinnerungen.
+// Jede Reservierung wird nur einmal erinnert; eine Verschiebung setzt die Erinnerung zurück.
+func (s *ReservationService) SendBookingReminders() (int, error) {
+	reservations, err := s.reservationRepo.FindUpcomingReservations(bookingReminderHours)
+	if err != nil {
+		return 0, err
+	}
+
+	sent, failed := 0, 0
+	for i := range reservations {
+		reservation := &reservations[i]
+		if reservation.ReminderSentAt != nil {
+			continue
+		}
+		vehicle, err := s.vehicleRepo.FindByID(reservation.VehicleID.Hex())
+		if err != nil {
+			failed++
+			continue
+		}
+		driver, err := s.driverRepo.FindByID(reservation.DriverID.Hex())
+		if err != nil {
+			failed++
+			continue
+		}
+
+		if err := s.notificationService.NotifyBookingReminder(reservation, vehicle, driver); err != nil {
+			log.Printf("Fehler bei der Buchungserinnerung %s: %v", reservation.ID.Hex(), err)
+			failed++
+			continue
+		}
+
+		sent++
+		now := time.Now()
+		reservation.ReminderSentAt = &now
+		if err := s.reservationRepo.Update(reservation); err != nil {
+			log.Printf("Fehler beim Speichern der Buchungserinnerung %s: %v", reservation.ID.Hex(), err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return sent, fmt.Errorf("%d buchungserinnerungen konnten nicht versendet werden", failed)
+	}
+	return sent, nil
+}
+
 // ApproveReservation genehmigt eine ausstehende Reservierung
 func (s *ReservationService) ApproveReservation(reservationID string, approvedBy primitive.ObjectID) error {
 	reservation, err := s.reservationRepo.FindByID(reservationID)
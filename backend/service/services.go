@@ -0,0 +1,86 @@
+// backend/service/services.go
+package service
+
+import (
+	"FleetFlow/backend/repository"
+	"log"
+)
+
+// Services bündelt alle Services, die auf einem gemeinsamen Satz von Repositories arbeiten
+type Services struct {
+	AccidentClaim   *AccidentClaimService
+	Activity        *ActivityService
+	Assignment      *AssignmentService
+	BulkImport      *BulkImportService
+	Calendar        *CalendarService
+	Charging        *ChargingService
+	Eligibility     *EligibilityService
+	Email           *EmailService
+	ExpiryReminder  *ExpiryReminderService
+	Financing       *FinancingService
+	FuelConsumption *FuelConsumptionService
+	FuelImport      *FuelImportService
+	Handover        *HandoverService
+	Lease           *LeaseService
+	Logbook         *LogbookService
+	MaintenancePlan *MaintenancePlanService
+	Notification    *NotificationService
+	PeopleFlow      *PeopleFlowService
+	Reservation     *ReservationService
+	Scheduler       *JobScheduler
+	TaxableBenefit  *TaxableBenefitService
+	TCO             *TCOService
+	Tire            *TireService
+	TrafficFine     *TrafficFineService
+	VehicleMileage  *VehicleMileageService
+	WorkOrder       *WorkOrderService
+}
+
+// NewServices erstellt alle Services für die übergebenen Repositories
+func NewServices(repos *repository.Repositories) *Services {
+	activityService := NewActivityService(repos.Activity)
+	emailService := NewEmailService(repos.SMTP)
+	notificationService := NewNotificationService(repos.User, emailService, activityService)
+	eligibilityService := NewEligibilityService(repos.DriverDocument)
+	reservationService := NewReservationService(repos.VehicleReservation, repos.ReservationSeries, repos.Vehicle, repos.Driver, eligibilityService, notificationService, activityService)
+	mileageService := NewVehicleMileageService(repos.Vehicle, repos.Maintenance, repos.VehicleUsage, repos.FuelCost, repos.Logbook, repos.ChargingSession)
+	handoverService := NewHandoverService(repos.Handover, repos.VehicleReservation, repos.Vehicle, repos.Driver, repos.VehicleUsage,
+		repos.VehicleReport, repos.VehicleDocument, reservationService, mileageService, activityService)
+	maintenancePlanService := NewMaintenancePlanService(repos.MaintenancePlan, repos.Maintenance, repos.Vehicle, mileageService, notificationService, activityService)
+
+	services := &Services{
+		AccidentClaim:   NewAccidentClaimService(repos.AccidentClaim, repos.VehicleReport, repos.Vehicle, repos.Driver, repos.User, repos.WorkOrder, repos.VehicleDocument, activityService),
+		Activity:        activityService,
+		Assignment:      NewAssignmentService(repos.Vehicle, repos.Driver, repos.VehicleAssignment, eligibilityService),
+		BulkImport:      NewBulkImportService(repos.BulkImport, repos.Vehicle, repos.Driver, activityService),
+		Calendar:        NewCalendarService(repos.CalendarFeed, repos.User, repos.Vehicle, repos.Driver, reservationService),
+		Charging:        NewChargingService(repos.ChargingSession, repos.Vehicle, repos.Driver, mileageService, activityService),
+		Eligibility:     eligibilityService,
+		Email:           emailService,
+		ExpiryReminder:  NewExpiryReminderService(repos.ExpiryReminder, repos.DriverDocument, repos.VehicleDocument, repos.Vehicle, repos.Driver, repos.User, emailService, notificationService),
+		Financing:       NewFinancingService(repos.Vehicle),
+		FuelConsumption: NewFuelConsumptionService(repos.Vehicle, repos.FuelCost),
+		FuelImport:      NewFuelImportService(repos.FuelImport, repos.FuelCost, repos.Vehicle, mileageService, notificationService, activityService),
+		Handover:        handoverService,
+		Lease:           NewLeaseService(repos.LeaseReturn, repos.Vehicle, repos.Driver, repos.ExpiryReminder, mileageService, eligibilityService, notificationService, activityService),
+		Logbook:         NewLogbookService(repos.Logbook, repos.Vehicle, repos.Driver, mileageService, activityService),
+		MaintenancePlan: maintenancePlanService,
+		Notification:    notificationService,
+		PeopleFlow:      NewPeopleFlowService(repos.PeopleFlow, repos.Driver),
+		Reservation:     reservationService,
+		Scheduler:       NewJobScheduler(repos.ScheduledJob),
+		TaxableBenefit:  NewTaxableBenefitService(repos.Driver, repos.Vehicle, repos.VehicleAssignment, repos.Logbook, repos.FuelCost, repos.Maintenance),
+		TCO:             NewTCOService(repos.Vehicle, repos.FuelCost, repos.ChargingSession, repos.Maintenance, repos.VehicleUsage, repos.AccidentClaim, repos.TrafficFine),
+		Tire:            NewTireService(repos.Tire, repos.Vehicle, repos.Maintenance, mileageService, maintenancePlanService, activityService),
+		TrafficFine:     NewTrafficFineService(repos.TrafficFine, repos.Vehicle, repos.Driver, repos.VehicleReservation, repos.VehicleUsage, repos.VehicleAssignment, notificationService, activityService),
+		VehicleMileage:  mileageService,
+		WorkOrder: NewWorkOrderService(repos.WorkOrder, repos.VehicleReport, repos.Vehicle, repos.Maintenance,
+			mileageService, maintenancePlanService, activityService),
+	}
+
+	if err := registerJobs(services.Scheduler, services); err != nil {
+		log.Printf("⚠️  Hintergrundjobs konnten nicht registriert werden: %v", err)
+	}
+
+	return services
+}
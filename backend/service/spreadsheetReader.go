@@ -0,0 +1,335 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSpreadsheetPartSize begrenzt die entpackte Größe eines Bestandteils einer XLSX-Datei
+const maxSpreadsheetPartSize = 64 << 20
+
+// spreadsheetRecord ist eine nicht leere Zeile einer Tabelle mit ihrer Zeilennummer in der Datei
+type spreadsheetRecord struct {
+	line   int
+	values []string
+}
+
+// readSpreadsheet liest eine CSV- oder XLSX-Datei. Die erste nicht leere Zeile gilt als Kopfzeile;
+// leere und doppelte Überschriften werden eindeutig benannt, damit sie zugeordnet werden können.
+func readSpreadsheet(fileName string, data []byte) ([]string, []spreadsheetRecord, error) {
+	var records []spreadsheetRecord
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		records, err = readXLSXRecords(data)
+	case strings.EqualFold(path.Ext(fileName), ".xls") || bytes.HasPrefix(data, []byte("\xD0\xCF\x11\xE0")):
+		return nil, nil, fmt.Errorf("das alte excel-format (.xls) wird nicht unterstützt, bitte als xlsx oder csv speichern")
+	default:
+		records, err = readCSVRecords(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("die datei enthält keine kopfzeile")
+	}
+
+	header := records[0].values
+	seen := make(map[string]int, len(header))
+	headers := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = "Spalte " + spreadsheetColumnName(i)
+		}
+		seen[strings.ToLower(name)]++
+		if n := seen[strings.ToLower(name)]; n > 1 {
+			name = fmt.Sprintf("%s (%d)", name, n)
+		}
+		headers[i] = name
+	}
+	return headers, records[1:], nil
+}
+
+// readCSVRecords liest eine CSV-Datei. Das Trennzeichen (Semikolon, Komma oder Tabulator) wird
+// an der ersten Zeile erkannt; Dateien, die kein gültiges UTF-8 sind, gelten als Windows-1252/Latin-1.
+func readCSVRecords(data []byte) ([]spreadsheetRecord, error) {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	delimiter, best := ';', -1
+	for _, candidate := range []rune{';', ',', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(candidate))); n > best {
+			delimiter, best = candidate, n
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var records []spreadsheetRecord
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("die csv-datei konnte nicht gelesen werden: %v", err)
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, spreadsheetRecord{line: line, values: record})
+	}
+	return records, nil
+}
+
+// latin1ToUTF8 wandelt Text in Windows-1252/Latin-1 nach UTF-8 um (Umlaute aus älteren Excel-Exporten)
+func latin1ToUTF8(data []byte) []byte {
+	var b bytes.Buffer
+	b.Grow(len(data) + len(data)/8)
+	for _, c := range data {
+		if c == 0x80 {
+			b.WriteRune('€')
+			continue
+		}
+		b.WriteRune(rune(c))
+	}
+	return b.Bytes()
+}
+
+// xlsxSheetXML ist der für den Import benötigte Ausschnitt eines Tabellenblatts
+type xlsxSheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			V      string `xml:"v"`
+			Inline struct {
+				Text string `xml:",innerxml"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXRecords liest das erste Tabellenblatt einer XLSX-Datei. Datumszellen werden als
+// Excel-Seriennummer geliefert; parseImportDate wandelt sie um.
+func readXLSXRecords(data []byte) ([]spreadsheetRecord, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("die xlsx-datei konnte nicht geöffnet werden: %v", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings []string
+	if file := files["xl/sharedStrings.xml"]; file != nil {
+		var sst struct {
+			Items []struct {
+				Text string `xml:",innerxml"`
+			} `xml:"si"`
+		}
+		if err := decodeXLSXPart(file, &sst); err != nil {
+			return nil, err
+		}
+		sharedStrings = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			sharedStrings[i] = xlsxInnerText(item.Text)
+		}
+	}
+
+	file := files[sheetPath]
+	if file == nil {
+		return nil, fmt.Errorf("die xlsx-datei enthält kein tabellenblatt")
+	}
+	var sheet xlsxSheetXML
+	if err := decodeXLSXPart(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var records []spreadsheetRecord
+	for i, row := range sheet.Rows {
+		line := row.R
+		if line == 0 {
+			line = i + 1
+		}
+		var values []string
+		for j, cell := range row.Cells {
+			column := j
+			if cell.R != "" {
+				column = xlsxColumnIndex(cell.R)
+			}
+			if column < 0 || column > 16383 {
+				continue
+			}
+
+			var value string
+			switch cell.T {
+			case "s":
+				index, err := strconv.Atoi(strings.TrimSpace(cell.V))
+				if err != nil || index < 0 || index >= len(sharedStrings) {
+					return nil, fmt.Errorf("ungültiger textverweis in zeile %d", line)
+				}
+				value = sharedStrings[index]
+			case "inlineStr":
+				value = xlsxInnerText(cell.Inline.Text)
+			case "b":
+				value = map[string]string{"1": "Ja", "0": "Nein"}[cell.V]
+			default:
+				value = cell.V
+			}
+
+			for len(values) < column {
+				values = append(values, "")
+			}
+			values = append(values[:column], value)
+		}
+		if isEmptyRecord(values) {
+			continue
+		}
+		records = append(records, spreadsheetRecord{line: line, values: values})
+	}
+	return records, nil
+}
+
+// xlsxFirstSheetPath ermittelt über Arbeitsmappe und Beziehungen die Datei des ersten Tabellenblatts
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	workbookFile, relsFile := files["xl/workbook.xml"], files["xl/_rels/workbook.xml.rels"]
+	if workbookFile == nil || relsFile == nil {
+		return fallback, nil
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("die xlsx-datei enthält kein tabellenblatt")
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// decodeXLSXPart entpackt und dekodiert einen XML-Bestandteil der Arbeitsmappe
+func decodeXLSXPart(file *zip.File, target interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("die xlsx-datei konnte nicht gelesen werden: %v", err)
+	}
+	defer reader.Close()
+
+	limited := &io.LimitedReader{R: reader, N: maxSpreadsheetPartSize + 1}
+	if err := xml.NewDecoder(limited).Decode(target); err != nil {
+		if limited.N <= 0 {
+			return fmt.Errorf("die xlsx-datei ist zu groß")
+		}
+		return fmt.Errorf("die xlsx-datei konnte nicht gelesen werden: %v", err)
+	}
+	return nil
+}
+
+// xlsxInnerText liefert den Text aller <t>-Elemente eines Textes mit Formatierungen,
+// ohne Lautschrift-Angaben (<rPh>)
+func xlsxInnerText(inner string) string {
+	decoder := xml.NewDecoder(strings.NewReader("<x>" + inner + "</x>"))
+	var b strings.Builder
+	inText, inPhonetic := false, false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "rPh":
+				inPhonetic = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "rPh":
+				inPhonetic = false
+			}
+		case xml.CharData:
+			if inText && !inPhonetic {
+				b.Write(t)
+			}
+		}
+	}
+	return b.String()
+}
+
+// xlsxColumnIndex liefert den Spaltenindex ab 0 zu einem Zellbezug wie "AB12"
+func xlsxColumnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A') + 1
+	}
+	return index - 1
+}
+
+// spreadsheetColumnName liefert den Spaltenbuchstaben (A, B, …, Z, AA, …) zu einem Index ab 0
+func spreadsheetColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// buildXLSX packt die angegebenen Bestandteile zu einer XLSX-Datei
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("xlsx erstellen: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("xlsx erstellen: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("xlsx erstellen: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const testXLSXSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>Aktiv</t></is></c><c r="D1" t="s"><v>0</v></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>45678</v></c><c r="C2" t="b"><v>1</v></c></row>
+<row r="3"></row>
+<row r="5"><c r="A5" t="s"><v>3</v></c><c r="C5" t="b"><v>0</v></c><c r="D5" t="inlineStr"><is><r><t>Teil </t></r><r><t>zwei</t></r></is></c></row>
+</sheetData></worksheet>`
+
+const testXLSXSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Kennzeichen</t></si>
+<si><r><t>Listen</t></r><r><t>preis</t></r></si>
+<si><t>B-FF 100</t></si>
+<si><t>Müller</t><rPh><t>myura</t></rPh></si>
+</sst>`
+
+func TestReadSpreadsheet(t *testing.T) {
+	workbook := `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Fahrzeuge" sheetId="1" r:id="rId3"/></sheets></workbook>`
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Target="styles.xml"/><Relationship Id="rId3" Target="worksheets/fahrzeuge.xml"/></Relationships>`
+
+	tests := []struct {
+		name        string
+		fileName    string
+		data        []byte
+		wantHeaders []string
+		wantRecords []spreadsheetRecord
+		wantErr     bool
+	}{
+		{
+			name:        "csv mit semikolon",
+			fileName:    "fahrzeuge.csv",
+			data:        []byte("Kennzeichen;Marke;Modell\nB-FF 100;VW;Golf\nB-FF 200;Audi;A4\n"),
+			wantHeaders: []string{"Kennzeichen", "Marke", "Modell"},
+			wantRecords: []spreadsheetRecord{
+				{line: 2, values: []string{"B-FF 100", "VW", "Golf"}},
+				{line: 3, values: []string{"B-FF 200", "Audi", "A4"}},
+			},
+		},
+		{
+			name:        "csv mit komma und anführungszeichen",
+			fileName:    "fahrzeuge.csv",
+			data:        []byte("Kennzeichen,Notiz\r\nB-FF 100,\"Kratzer; Delle\"\r\n"),
+			wantHeaders: []string{"Kennzeichen", "Notiz"},
+			wantRecords: []spreadsheetRecord{{line: 2, values: []string{"B-FF 100", "Kratzer; Delle"}}},
+		},
+		{
+			name:        "csv mit tabulator",
+			fileName:    "fahrzeuge.txt",
+			data:        []byte("Kennzeichen\tMarke\nB-FF 100\tVW\n"),
+			wantHeaders: []string{"Kennzeichen", "Marke"},
+			wantRecords: []spreadsheetRecord{{line: 2, values: []string{"B-FF 100", "VW"}}},
+		},
+		{
+			name:        "byte order mark wird entfernt",
+			fileName:    "fahrer.csv",
+			data:        []byte("\xEF\xBB\xBFNachname;Vorname\nMüller;Anna\n"),
+			wantHeaders: []string{"Nachname", "Vorname"},
+			wantRecords: []spreadsheetRecord{{line: 2, values: []string{"Müller", "Anna"}}},
+		},
+		{
+			name:        "latin-1 wird nach utf-8 umgewandelt",
+			fileName:    "fahrer.csv",
+			data:        []byte("Nachname;Betrag\nM\xfcller;12 \x80\n"),
+			wantHeaders: []string{"Nachname", "Betrag"},
+			wantRecords: []spreadsheetRecord{{line: 2, values: []string{"Müller", "12 €"}}},
+		},
+		{
+			name:        "leere zeilen behalten die zeilennummer",
+			fileName:    "fahrzeuge.csv",
+			data:        []byte("\nKennzeichen;Marke\n;\nB-FF 100;VW\n\n\"B-FF\n200\";Audi\nB-FF 300;Opel\n"),
+			wantHeaders: []string{"Kennzeichen", "Marke"},
+			wantRecords: []spreadsheetRecord{
+				{line: 4, values: []string{"B-FF 100", "VW"}},
+				{line: 6, values: []string{"B-FF\n200", "Audi"}},
+				{line: 8, values: []string{"B-FF 300", "Opel"}},
+			},
+		},
+		{
+			name:        "leere und doppelte überschriften",
+			fileName:    "fahrzeuge.csv",
+			data:        []byte("Name;;name; NAME \na;b;c;d\n"),
+			wantHeaders: []string{"Name", "Spalte B", "name (2)", "NAME (3)"},
+			wantRecords: []spreadsheetRecord{{line: 2, values: []string{"a", "b", "c", "d"}}},
+		},
+		{
+			name:        "nur kopfzeile",
+			fileName:    "fahrzeuge.csv",
+			data:        []byte("Kennzeichen;Marke\n"),
+			wantHeaders: []string{"Kennzeichen", "Marke"},
+			wantRecords: nil,
+		},
+		{
+			name:     "xlsx mit gemeinsamen texten, lücken und wahrheitswerten",
+			fileName: "fahrzeuge.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/workbook.xml":             workbook,
+				"xl/_rels/workbook.xml.rels":  rels,
+				"xl/worksheets/fahrzeuge.xml": testXLSXSheet,
+				"xl/sharedStrings.xml":        testXLSXSharedStrings,
+			}),
+			wantHeaders: []string{"Kennzeichen", "Listenpreis", "Aktiv", "Kennzeichen (2)"},
+			wantRecords: []spreadsheetRecord{
+				{line: 2, values: []string{"B-FF 100", "45678", "Ja"}},
+				{line: 5, values: []string{"Müller", "", "Nein", "Teil zwei"}},
+			},
+		},
+		{
+			name:     "xlsx ohne arbeitsmappe nutzt das erste blatt",
+			fileName: "export",
+			data: buildXLSX(t, map[string]string{
+				"xl/worksheets/sheet1.xml": testXLSXSheet,
+				"xl/sharedStrings.xml":     testXLSXSharedStrings,
+			}),
+			wantHeaders: []string{"Kennzeichen", "Listenpreis", "Aktiv", "Kennzeichen (2)"},
+			wantRecords: []spreadsheetRecord{
+				{line: 2, values: []string{"B-FF 100", "45678", "Ja"}},
+				{line: 5, values: []string{"Müller", "", "Nein", "Teil zwei"}},
+			},
+		},
+		{
+			name:     "xlsx mit ungültigem textverweis",
+			fileName: "fahrzeuge.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>7</v></c></row></sheetData></worksheet>`,
+			}),
+			wantErr: true,
+		},
+		{
+			name:     "xlsx ohne tabellenblatt",
+			fileName: "fahrzeuge.xlsx",
+			data:     buildXLSX(t, map[string]string{"docProps/app.xml": "<Properties/>"}),
+			wantErr:  true,
+		},
+		{
+			name:     "beschädigte xlsx",
+			fileName: "fahrzeuge.xlsx",
+			data:     []byte("PK\x03\x04kaputt"),
+			wantErr:  true,
+		},
+		{
+			name:     "altes excel-format nach endung",
+			fileName: "fahrzeuge.XLS",
+			data:     []byte("Kennzeichen;Marke\n"),
+			wantErr:  true,
+		},
+		{
+			name:     "altes excel-format nach inhalt",
+			fileName: "fahrzeuge",
+			data:     []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1"),
+			wantErr:  true,
+		},
+		{
+			name:     "leere datei",
+			fileName: "fahrzeuge.csv",
+			data:     []byte("\n ; \n"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, records, err := readSpreadsheet(tt.fileName, tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fehler erwartet, kopfzeile %v erhalten", headers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if !reflect.DeepEqual(headers, tt.wantHeaders) {
+				t.Errorf("kopfzeile %q, erwartet %q", headers, tt.wantHeaders)
+			}
+			if len(records) != len(tt.wantRecords) {
+				t.Fatalf("%d zeilen erhalten, erwartet %d: %v", len(records), len(tt.wantRecords), records)
+			}
+			for i := range records {
+				if !reflect.DeepEqual(records[i], tt.wantRecords[i]) {
+					t.Errorf("zeile %d = %+v, erwartet %+v", i, records[i], tt.wantRecords[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSpreadsheetColumns(t *testing.T) {
+	tests := []struct {
+		index int
+		name  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := spreadsheetColumnName(tt.index); got != tt.name {
+			t.Errorf("spreadsheetColumnName(%d) = %q, erwartet %q", tt.index, got, tt.name)
+		}
+		if got := xlsxColumnIndex(tt.name + "12"); got != tt.index {
+			t.Errorf("xlsxColumnIndex(%q) = %d, erwartet %d", tt.name+"12", got, tt.index)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// benefitFlatRate ist der monatliche Anteil des Listenpreises für die Privatnutzung (1%)
+	benefitFlatRate = 0.01
+	// benefitCommuteMonthlyRate ist der monatliche Anteil je Entfernungskilometer (0,03%)
+	benefitCommuteMonthlyRate = 0.0003
+	// benefitCommuteDailyRate ist der Anteil je Entfernungskilometer und Fahrtag bei Einzelbewertung (0,002%)
+	benefitCommuteDailyRate = 0.00002
+	// benefitCommuteMaxDaysPerMonth begrenzt die Einzelbewertung, damit 180 Tage im Jahr nicht überschritten werden
+	benefitCommuteMaxDaysPerMonth = 15
+	// benefitDepreciationMonths ist die Nutzungsdauer eines Pkw laut AfA-Tabelle (6 Jahre)
+	benefitDepreciationMonths = 72
+)
+
+// TaxableBenefitService ermittelt den geldwerten Vorteil aus der Privatnutzung zugewiesener Dienstwagen
+type TaxableBenefitService struct {
+	driverRepo      repository.DriverRepository
+	vehicleRepo     repository.VehicleRepository
+	assignmentRepo  repository.VehicleAssignmentRepository
+	logbookRepo     repository.LogbookRepository
+	fuelCostRepo    repository.FuelCostRepository
+	maintenanceRepo repository.MaintenanceRepository
+}
+
+// NewTaxableBenefitService erstellt einen neuen TaxableBenefitService
+func NewTaxableBenefitService(driverRepo repository.DriverRepository, vehicleRepo repository.VehicleRepository, assignmentRepo repository.VehicleAssignmentRepository, logbookRepo repository.LogbookRepository, fuelCostRepo repository.FuelCostRepository, maintenanceRepo repository.MaintenanceRepository) *TaxableBenefitService {
+	return &TaxableBenefitService{
+		driverRepo:      driverRepo,
+		vehicleRepo:     vehicleRepo,
+		assignmentRepo:  assignmentRepo,
+		logbookRepo:     logbookRepo,
+		fuelCostRepo:    fuelCostRepo,
+		maintenanceRepo: maintenanceRepo,
+	}
+}
+
+// ParseBenefitMonth wandelt einen Monat im Format YYYY-MM in den Monatsersten um
+func ParseBenefitMonth(value string) (time.Time, error) {
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ungültiger monat, erwartet wird YYYY-MM")
+	}
+	return month, nil
+}
+
+// GetMonthlyStatements erstellt die Abrechnungen aller Fahrer, denen im Monat ein Dienstwagen zugewiesen war.
+// Ist method gesetzt, wird sie statt der beim Fahrer hinterlegten Methode verwendet.
+func (s *TaxableBenefitService) GetMonthlyStatements(month time.Time, method model.BenefitMethod) ([]*model.TaxableBenefitStatement, error) {
+	drivers, err := s.driverRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim laden der fahrer: %v", err)
+	}
+
+	statements := make([]*model.TaxableBenefitStatement, 0)
+	for _, driver := range drivers {
+		vehicleID := s.companyCarForMonth(driver, month)
+		if vehicleID.IsZero() {
+			continue
+		}
+		statement, err := s.calculate(driver, vehicleID, month, method)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+
+	sort.Slice(statements, func(i, j int) bool { return statements[i].DriverName < statements[j].DriverName })
+	return statements, nil
+}
+
+// GetDriverStatement erstellt die Abrechnung eines einzelnen Fahrers
+func (s *TaxableBenefitService) GetDriverStatement(driverID string, month time.Time, method model.BenefitMethod) (*model.TaxableBenefitStatement, error) {
+	driver, err := s.driverRepo.FindByID(driverID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrer nicht gefunden")
+	}
+
+	vehicleID := s.companyCarForMonth(driver, month)
+	if vehicleID.IsZero() {
+		return nil, fmt.Errorf("dem fahrer war im %s kein dienstwagen zugewiesen", month.Format("01/2006"))
+	}
+
+	return s.calculate(driver, vehicleID, month, method)
+}
+
+// companyCarForMonth ermittelt das im Monat überwiegend zugewiesene Fahrzeug. Nach der 1%-Regelung
+// ist auch bei nur tageweiser Überlassung der volle Monatswert anzusetzen.
+func (s *TaxableBenefitService) companyCarForMonth(driver *model.Driver, month time.Time) primitive.ObjectID {
+	monthEnd := month.AddDate(0, 1, 0)
+
+	hasHistory := false
+	assignments, err := s.assignmentRepo.FindByDriverID(driver.ID.Hex())
+	if err == nil {
+		var best primitive.ObjectID
+		var bestOverlap time.Duration
+		for _, assignment := range assignments {
+			if assignment.Type != model.AssignmentTypeAssigned || assignment.VehicleID.IsZero() {
+				continue
+			}
+			hasHistory = true
+			start := assignment.AssignedAt
+			end := monthEnd
+			if assignment.UnassignedAt != nil && assignment.UnassignedAt.Before(end) {
+				end = *assignment.UnassignedAt
+			}
+			if start.Before(month) {
+				start = month
+			}
+			if overlap := end.Sub(start); overlap > bestOverlap {
+				best = assignment.VehicleID
+				bestOverlap = overlap
+			}
+		}
+		if !best.IsZero() {
+			return best
+		}
+	}
+
+	// Zuweisungen ohne Historie (z.B. aus älteren Datenbeständen) gelten ab sofort. Gibt es eine
+	// Historie, die den Monat nicht berührt, war dem Fahrer in diesem Monat kein Fahrzeug zugewiesen.
+	if !hasHistory && !driver.AssignedVehicleID.IsZero() && time.Now().After(month) {
+		return driver.AssignedVehicleID
+	}
+	return primitive.NilObjectID
+}
+
+// calculate erstellt die Abrechnung eines Fahrers für ein Fahrzeug und einen Monat
+func (s *TaxableBenefitService) calculate(driver *model.Driver, vehicleID primitive.ObjectID, month time.Time, method model.BenefitMethod) (*model.TaxableBenefitStatement, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug %s nicht gefunden", vehicleID.Hex())
+	}
+
+	if method == "" {
+		method = driver.BenefitMethod
+	}
+	if method == "" {
+		method = model.BenefitMethodFlatRate
+	}
+
+	factor := listPriceFactor(vehicle)
+	statement := &model.TaxableBenefitStatement{
+		Month:             month.Format("2006-01"),
+		DriverID:          driver.ID,
+		DriverName:        driver.FirstName + " " + driver.LastName,
+		DriverNumber:      driver.DriverNumber,
+		VehicleID:         vehicle.ID,
+		LicensePlate:      vehicle.LicensePlate,
+		FuelType:          vehicle.FuelType,
+		Method:            method,
+		ListPrice:         vehicle.ListPrice,
+		ListPriceFactor:   factor,
+		CommuteDistanceKm: driver.CommuteDistanceKm,
+	}
+
+	switch method {
+	case model.BenefitMethodFlatRate:
+		err = s.calculateFlatRate(statement, driver, month)
+	case model.BenefitMethodLogbook:
+		err = s.calculateLogbook(statement, vehicle, month)
+	default:
+		return nil, fmt.Errorf("unbekannte methode: %s", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statement.TotalBenefit = roundCents(statement.PrivateUseBenefit + statement.CommuteBenefit)
+	return statement, nil
+}
+
+// calculateFlatRate bewertet die Privatnutzung mit 1% und die Arbeitswege mit 0,03% bzw. 0,002% des Listenpreises
+func (s *TaxableBenefitService) calculateFlatRate(statement *model.TaxableBenefitStatement, driver *model.Driver, month time.Time) error {
+	if statement.ListPrice <= 0 {
+		statement.Warnings = append(statement.Warnings, "kein bruttolistenpreis hinterlegt")
+		return nil
+	}
+
+	// Erst mindern, dann auf volle 100 Euro abrunden
+	base := math.Floor(statement.ListPrice*statement.ListPriceFactor/100) * 100
+	statement.AssessmentBase = base
+	statement.PrivateUseBenefit = roundCents(base * benefitFlatRate)
+
+	if driver.CommuteDistanceKm <= 0 {
+		return nil
+	}
+
+	if !driver.CommuteDailyValuation {
+		statement.CommuteBenefit = roundCents(base * benefitCommuteMonthlyRate * float64(driver.CommuteDistanceKm))
+		return nil
+	}
+
+	days, err := s.commuteDays(driver.ID, month)
+	if err != nil {
+		return err
+	}
+	if days > benefitCommuteMaxDaysPerMonth {
+		days = benefitCommuteMaxDaysPerMonth
+	}
+	statement.CommuteDaily = true
+	statement.CommuteDays = days
+	statement.CommuteBenefit = roundCents(base * benefitCommuteDailyRate * float64(driver.CommuteDistanceKm) * float64(days))
+	return nil
+}
+
+// calculateLogbook verteilt die tatsächlichen Monatskosten des Fahrzeugs nach den Fahrtenbuchkilometern
+func (s *TaxableBenefitService) calculateLogbook(statement *model.TaxableBenefitStatement, vehicle *model.Vehicle, month time.Time) error {
+	monthEnd := month.AddDate(0, 1, 0)
+
+	entries, err := s.logbookRepo.Find(model.LogbookFilter{VehicleID: &vehicle.ID, From: &month, To: &monthEnd})
+	if err != nil {
+		return fmt.Errorf("fehler beim laden des fahrtenbuchs: %v", err)
+	}
+	for _, entry := range entries {
+		distance := entry.Distance()
+		statement.TotalKm += distance
+		if entry.DriverID != statement.DriverID {
+			continue
+		}
+		switch entry.TripType {
+		case model.TripTypePrivate:
+			statement.PrivateKm += distance
+		case model.TripTypeCommute:
+			statement.CommuteKm += distance
+		}
+	}
+
+	if fuelCosts, err := s.fuelCostRepo.FindByVehicle(vehicle.ID.Hex()); err == nil {
+		for _, fuelCost := range fuelCosts {
+			if inMonth(fuelCost.Date, month, monthEnd) {
+				statement.FuelCosts += fuelCost.TotalCost
+			}
+		}
+	}
+	if maintenances, err := s.maintenanceRepo.FindByVehicle(vehicle.ID.Hex()); err == nil {
+		for _, maintenance := range maintenances {
+			if inMonth(maintenance.Date, month, monthEnd) {
+				statement.MaintenanceCosts += maintenance.Cost
+			}
+		}
+	}
+	statement.InsuranceCosts = vehicle.InsuranceCost / 12 // Jahresbeitrag
+	// Die Minderung für Elektro- und Hybridfahrzeuge gilt nur für Abschreibung bzw. Leasingrate, nicht für Zinsen
+	acquisitionCost, interest := monthlyAcquisitionCost(vehicle, month, &statement.Warnings)
+	statement.FinancingCosts = acquisitionCost*statement.ListPriceFactor + interest
+
+	statement.FuelCosts = roundCents(statement.FuelCosts)
+	statement.MaintenanceCosts = roundCents(statement.MaintenanceCosts)
+	statement.InsuranceCosts = roundCents(statement.InsuranceCosts)
+	statement.FinancingCosts = roundCents(statement.FinancingCosts)
+	statement.TotalCosts = roundCents(statement.FuelCosts + statement.MaintenanceCosts + statement.InsuranceCosts + statement.FinancingCosts)
+
+	if statement.TotalKm == 0 {
+		statement.Warnings = append(statement.Warnings, "keine fahrten im fahrtenbuch für diesen monat")
+		return nil
+	}
+
+	costPerKm := statement.TotalCosts / float64(statement.TotalKm)
+	statement.CostPerKm = math.Round(costPerKm*10000) / 10000
+	statement.PrivateUseBenefit = roundCents(costPerKm * float64(statement.PrivateKm))
+	statement.CommuteBenefit = roundCents(costPerKm * float64(statement.CommuteKm))
+	return nil
+}
+
+// commuteDays zählt die Tage mit Fahrten zur Arbeitsstätte laut Fahrtenbuch
+func (s *TaxableBenefitService) commuteDays(driverID primitive.ObjectID, month time.Time) (int, error) {
+	monthEnd := month.AddDate(0, 1, 0)
+	entries, err := s.logbookRepo.Find(model.LogbookFilter{DriverID: &driverID, From: &month, To: &monthEnd})
+	if err != nil {
+		return 0, fmt.Errorf("fehler beim laden des fahrtenbuchs: %v", err)
+	}
+
+	days := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.TripType == model.TripTypeCommute {
+			days[entry.Date.Format("2006-01-02")] = true
+		}
+	}
+	return len(days), nil
+}
+
+// listPriceFactor liefert den Minderungsfaktor für Elektro- und Hybridfahrzeuge (§ 6 Abs. 1 Nr. 4 EStG).
+// Bei Hybridfahrzeugen wird unterstellt, dass die Anforderungen an Reichweite bzw. CO2-Ausstoß erfüllt sind.
+func listPriceFactor(vehicle *model.Vehicle) float64 {
+	acquired := vehicleAcquisitionDate(vehicle)
+	if acquired.Year() < 2019 {
+		return 1
+	}
+
+	switch vehicle.FuelType {
+	case model.FuelTypeElectric, model.FuelTypeHydrogen:
+		if acquired.Year() == 2019 {
+			return 0.5
+		}
+		if vehicle.ListPrice <= electricListPriceLimit(acquired) {
+			return 0.25
+		}
+		return 0.5
+	case model.FuelTypeHybridGas, model.FuelTypeHybridDiesel:
+		return 0.5
+	}
+	return 1
+}
+
+// electricListPriceLimit liefert die Preisgrenze, bis zu der reine Elektrofahrzeuge mit einem Viertel angesetzt werden
+func electricListPriceLimit(acquired time.Time) float64 {
+	switch {
+	case acquired.Before(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)):
+		return 60000
+	case acquired.Before(time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)):
+		return 70000
+	default:
+		return 100000
+	}
+}
+
+// vehicleAcquisitionDate ermittelt den Anschaffungs- bzw. Überlassungszeitpunkt eines Fahrzeugs
+func vehicleAcquisitionDate(vehicle *model.Vehicle) time.Time {
+	for _, date := range []time.Time{vehicle.RegistrationDate, vehicle.PurchaseDate, vehicle.FinanceStartDate, vehicle.LeaseStartDate} {
+		if !date.IsZero() {
+			return date
+		}
+	}
+	if vehicle.Year > 0 {
+		return time.Date(vehicle.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Now()
+}
+
+// monthlyAcquisitionCost liefert die im Monat anfallenden Anschaffungskosten: als cost bei Leasing die Rate,
+// bei Kauf und Finanzierung die lineare Abschreibung, als interest bei Finanzierung den Zinsanteil laut
+// Tilgungsplan. Der Tilgungsanteil der Finanzierungsrate ist bereits über die Abschreibung erfasst.
+func monthlyAcquisitionCost(vehicle *model.Vehicle, month time.Time, warnings *[]string) (cost, interest float64) {
+	monthEnd := month.AddDate(0, 1, 0)
+	activeIn := func(start, end time.Time) bool {
+		return (start.IsZero() || start.Before(monthEnd)) && (end.IsZero() || !end.Before(month))
+	}
+
+	switch vehicle.AcquisitionType {
+	case model.AcquisitionTypeLeased:
+		if activeIn(vehicle.LeaseStartDate, vehicle.LeaseEndDate) {
+			return vehicle.LeaseMonthlyRate, 0
+		}
+	case model.AcquisitionTypeFinanced:
+		basis := vehicle.PurchasePrice
+		if basis <= 0 {
+			basis = vehicle.FinanceTotalAmount + vehicle.FinanceDownPayment
+		}
+		acquired := vehicle.PurchaseDate
+		if acquired.IsZero() {
+			acquired = vehicle.FinanceStartDate
+		}
+		cost = monthlyDepreciation(basis, acquired, month)
+
+		if activeIn(vehicle.FinanceStartDate, vehicle.FinanceEndDate) {
+			var tco model.VehicleTCO
+			calculateFinanceInterest(vehicle, &tco, month, monthEnd)
+			for _, warning := range tco.Warnings {
+				*warnings = append(*warnings, "finanzierung: "+strings.ToLower(warning))
+			}
+			interest = tco.Costs.FinanceInterest
+		}
+		return cost, interest
+	case model.AcquisitionTypePurchased:
+		return monthlyDepreciation(vehicle.PurchasePrice, vehicle.PurchaseDate, month), 0
+	}
+	return 0, 0
+}
+
+// monthlyDepreciation liefert die lineare Abschreibung eines Monats über die Nutzungsdauer ab acquired.
+// Der Anschaffungsmonat zählt voll, die Abschreibung endet nach benefitDepreciationMonths Monaten.
+func monthlyDepreciation(basis float64, acquired, month time.Time) float64 {
+	if basis <= 0 {
+		return 0
+	}
+	if !acquired.IsZero() {
+		first := time.Date(acquired.Year(), acquired.Month(), 1, 0, 0, 0, 0, month.Location())
+		if month.Before(first) || !month.Before(first.AddDate(0, benefitDepreciationMonths, 0)) {
+			return 0
+		}
+	}
+	return basis / benefitDepreciationMonths
+}
+
+// inMonth prüft, ob ein Datum im Zeitraum [from, to) liegt
+func inMonth(date, from, to time.Time) bool {
+	return !date.Before(from) && date.Before(to)
+}
+
+// roundCents rundet einen Betrag auf volle Cent
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
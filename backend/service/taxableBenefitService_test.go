@@ -0,0 +1,446 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benefitTestSetup enthält die Repositories für einen Abrechnungstest
+type benefitTestSetup struct {
+	service     *TaxableBenefitService
+	drivers     *repository.MemoryDriverRepository
+	vehicles    *repository.MemoryVehicleRepository
+	logbook     *repository.MemoryLogbookRepository
+	fuelCosts   *repository.MemoryFuelCostRepository
+	maintenance *repository.MemoryMaintenanceRepository
+}
+
+func newBenefitTestSetup() *benefitTestSetup {
+	setup := &benefitTestSetup{
+		drivers:     repository.NewMemoryDriverRepository(),
+		vehicles:    repository.NewMemoryVehicleRepository(),
+		logbook:     repository.NewMemoryLogbookRepository(),
+		fuelCosts:   repository.NewMemoryFuelCostRepository(),
+		maintenance: repository.NewMemoryMaintenanceRepository(),
+	}
+	setup.service = NewTaxableBenefitService(setup.drivers, setup.vehicles, repository.NewMemoryVehicleAssignmentRepository(),
+		setup.logbook, setup.fuelCosts, setup.maintenance)
+	return setup
+}
+
+// assign legt Fahrzeug und Fahrer an und weist das Fahrzeug dem Fahrer zu
+func (s *benefitTestSetup) assign(t *testing.T, vehicle *model.Vehicle, driver *model.Driver) {
+	t.Helper()
+	if err := s.vehicles.Create(vehicle); err != nil {
+		t.Fatalf("fahrzeug anlegen: %v", err)
+	}
+	driver.AssignedVehicleID = vehicle.ID
+	if err := s.drivers.Create(driver); err != nil {
+		t.Fatalf("fahrer anlegen: %v", err)
+	}
+}
+
+// trip trägt eine Fahrt ins Fahrtenbuch ein
+func (s *benefitTestSetup) trip(t *testing.T, vehicle *model.Vehicle, driver *model.Driver, date time.Time, start, end int, tripType model.TripType) {
+	t.Helper()
+	entries, _ := s.logbook.Find(model.LogbookFilter{VehicleID: &vehicle.ID})
+	entry := &model.LogbookEntry{
+		Sequence:     len(entries) + 1,
+		VehicleID:    vehicle.ID,
+		DriverID:     driver.ID,
+		Date:         date,
+		StartMileage: start,
+		EndMileage:   end,
+		TripType:     tripType,
+	}
+	if err := s.logbook.Create(entry); err != nil {
+		t.Fatalf("fahrt anlegen: %v", err)
+	}
+}
+
+func TestListPriceFactor(t *testing.T) {
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		vehicle model.Vehicle
+		want    float64
+	}{
+		{"verbrenner", model.Vehicle{FuelType: model.FuelTypeDiesel, ListPrice: 45000, RegistrationDate: date(2022, 5)}, 1},
+		{"elektro vor 2019", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 40000, RegistrationDate: date(2018, 12)}, 1},
+		{"elektro 2019", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 40000, RegistrationDate: date(2019, 6)}, 0.5},
+		{"elektro bis 60.000 euro", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 60000, RegistrationDate: date(2022, 5)}, 0.25},
+		{"elektro über 60.000 euro", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 65000, RegistrationDate: date(2022, 5)}, 0.5},
+		{"elektro 2024 bis 70.000 euro", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 65000, RegistrationDate: date(2024, 3)}, 0.25},
+		{"elektro ab juli 2025 bis 100.000 euro", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 95000, RegistrationDate: date(2025, 7)}, 0.25},
+		{"wasserstoff", model.Vehicle{FuelType: model.FuelTypeHydrogen, ListPrice: 50000, RegistrationDate: date(2021, 1)}, 0.25},
+		{"hybrid", model.Vehicle{FuelType: model.FuelTypeHybridGas, ListPrice: 40000, RegistrationDate: date(2023, 1)}, 0.5},
+		{"erwerb aus dem kaufdatum", model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 40000, PurchaseDate: date(2018, 1)}, 1},
+		{"erwerb aus dem baujahr", model.Vehicle{FuelType: model.FuelTypeHybridDiesel, ListPrice: 40000, Year: 2020}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listPriceFactor(&tt.vehicle); got != tt.want {
+				t.Errorf("listPriceFactor = %v, erwartet %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthlyDepreciation(t *testing.T) {
+	month := func(year int, m time.Month) time.Time {
+		return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	acquired := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		basis    float64
+		acquired time.Time
+		month    time.Time
+		want     float64
+	}{
+		{"anschaffungsmonat zählt voll", 36000, acquired, month(2024, 1), 500},
+		{"vor der anschaffung", 36000, acquired, month(2023, 12), 0},
+		{"letzter monat der nutzungsdauer", 36000, acquired, month(2029, 12), 500},
+		{"nach 72 monaten", 36000, acquired, month(2030, 1), 0},
+		{"ohne anschaffungsdatum", 36000, time.Time{}, month(2024, 1), 500},
+		{"ohne kaufpreis", 0, acquired, month(2024, 1), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := monthlyDepreciation(tt.basis, tt.acquired, tt.month); got != tt.want {
+				t.Errorf("monthlyDepreciation = %v, erwartet %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthlyAcquisitionCost(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	month := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		vehicle      model.Vehicle
+		month        time.Time
+		want         float64
+		wantInterest float64
+		wantWarning  bool
+	}{
+		{
+			name: "leasing im vertragszeitraum",
+			vehicle: model.Vehicle{
+				AcquisitionType:  model.AcquisitionTypeLeased,
+				LeaseMonthlyRate: 450,
+				LeaseStartDate:   start,
+				LeaseEndDate:     start.AddDate(3, 0, 0),
+			},
+			month: month,
+			want:  450,
+		},
+		{
+			name: "leasing nach vertragsende",
+			vehicle: model.Vehicle{
+				AcquisitionType:  model.AcquisitionTypeLeased,
+				LeaseMonthlyRate: 450,
+				LeaseStartDate:   start,
+				LeaseEndDate:     start.AddDate(3, 0, 0),
+			},
+			month: month.AddDate(3, 1, 0),
+			want:  0,
+		},
+		{
+			name: "kauf",
+			vehicle: model.Vehicle{
+				AcquisitionType: model.AcquisitionTypePurchased,
+				PurchasePrice:   36000,
+				PurchaseDate:    start,
+			},
+			month: month,
+			want:  500,
+		},
+		{
+			name: "finanzierung mit abschreibung und zinsanteil",
+			vehicle: model.Vehicle{
+				AcquisitionType:     model.AcquisitionTypeFinanced,
+				FinanceTotalAmount:  24000,
+				FinanceDownPayment:  12000,
+				FinanceInterestRate: 6,
+				FinanceStartDate:    start,
+				FinanceEndDate:      start.AddDate(4, 0, 0),
+			},
+			month:        month,
+			want:         500,
+			wantInterest: 120,
+		},
+		{
+			name: "finanzierung nach vertragsende nur abschreibung",
+			vehicle: model.Vehicle{
+				AcquisitionType:     model.AcquisitionTypeFinanced,
+				FinanceTotalAmount:  24000,
+				FinanceDownPayment:  12000,
+				FinanceInterestRate: 6,
+				FinanceStartDate:    start,
+				FinanceEndDate:      start.AddDate(4, 0, 0),
+			},
+			month: month.AddDate(4, 1, 0),
+			want:  500,
+		},
+		{
+			name: "unvollständige finanzierung",
+			vehicle: model.Vehicle{
+				AcquisitionType:    model.AcquisitionTypeFinanced,
+				PurchasePrice:      36000,
+				FinanceTotalAmount: 24000,
+				FinanceStartDate:   start,
+			},
+			month:       month,
+			want:        500,
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			got, interest := monthlyAcquisitionCost(&tt.vehicle, tt.month, &warnings)
+			if math.Abs(got-tt.want) > 0.005 || math.Abs(interest-tt.wantInterest) > 0.005 {
+				t.Errorf("monthlyAcquisitionCost = %.2f / %.2f, erwartet %.2f / %.2f", got, interest, tt.want, tt.wantInterest)
+			}
+			if tt.wantWarning != (len(warnings) > 0) {
+				t.Errorf("warnungen %v, erwartet: %v", warnings, tt.wantWarning)
+			}
+			for _, warning := range warnings {
+				if !strings.HasPrefix(warning, "finanzierung: ") {
+					t.Errorf("warnung %q ohne präfix", warning)
+				}
+			}
+		})
+	}
+}
+
+func TestFlatRateStatement(t *testing.T) {
+	month := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	registered := time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		vehicle         model.Vehicle
+		driver          model.Driver
+		commuteDays     int
+		wantBase        float64
+		wantPrivate     float64
+		wantCommute     float64
+		wantCommuteDays int
+		wantWarning     bool
+	}{
+		{
+			name:        "verbrenner mit pauschalen arbeitswegen",
+			vehicle:     model.Vehicle{FuelType: model.FuelTypeDiesel, ListPrice: 45678, RegistrationDate: registered},
+			driver:      model.Driver{CommuteDistanceKm: 20},
+			wantBase:    45600,
+			wantPrivate: 456,
+			wantCommute: 273.60,
+		},
+		{
+			name:        "elektro mit viertel listenpreis",
+			vehicle:     model.Vehicle{FuelType: model.FuelTypeElectric, ListPrice: 50000, RegistrationDate: registered},
+			driver:      model.Driver{},
+			wantBase:    12500,
+			wantPrivate: 125,
+		},
+		{
+			name:        "minderung vor der rundung",
+			vehicle:     model.Vehicle{FuelType: model.FuelTypeHybridGas, ListPrice: 40150, RegistrationDate: registered},
+			driver:      model.Driver{CommuteDistanceKm: 10},
+			wantBase:    20000,
+			wantPrivate: 200,
+			wantCommute: 60,
+		},
+		{
+			name:            "einzelbewertung der fahrtage",
+			vehicle:         model.Vehicle{FuelType: model.FuelTypeDiesel, ListPrice: 45678, RegistrationDate: registered},
+			driver:          model.Driver{CommuteDistanceKm: 20, CommuteDailyValuation: true},
+			commuteDays:     3,
+			wantBase:        45600,
+			wantPrivate:     456,
+			wantCommute:     54.72,
+			wantCommuteDays: 3,
+		},
+		{
+			name:            "einzelbewertung höchstens 15 tage",
+			vehicle:         model.Vehicle{FuelType: model.FuelTypeDiesel, ListPrice: 45678, RegistrationDate: registered},
+			driver:          model.Driver{CommuteDistanceKm: 20, CommuteDailyValuation: true},
+			commuteDays:     20,
+			wantBase:        45600,
+			wantPrivate:     456,
+			wantCommute:     273.60,
+			wantCommuteDays: 15,
+		},
+		{
+			name:        "ohne listenpreis",
+			vehicle:     model.Vehicle{FuelType: model.FuelTypeDiesel, RegistrationDate: registered},
+			driver:      model.Driver{CommuteDistanceKm: 20},
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newBenefitTestSetup()
+			setup.assign(t, &tt.vehicle, &tt.driver)
+			mileage := 0
+			for day := 0; day < tt.commuteDays; day++ {
+				// Hin- und Rückfahrt am selben Tag zählen als ein Fahrtag
+				date := month.AddDate(0, 0, day)
+				setup.trip(t, &tt.vehicle, &tt.driver, date, mileage, mileage+20, model.TripTypeCommute)
+				setup.trip(t, &tt.vehicle, &tt.driver, date.Add(9*time.Hour), mileage+20, mileage+40, model.TripTypeCommute)
+				mileage += 40
+			}
+
+			statement, err := setup.service.GetDriverStatement(tt.driver.ID.Hex(), month, model.BenefitMethodFlatRate)
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if statement.AssessmentBase != tt.wantBase {
+				t.Errorf("AssessmentBase = %.2f, erwartet %.2f", statement.AssessmentBase, tt.wantBase)
+			}
+			if statement.PrivateUseBenefit != tt.wantPrivate {
+				t.Errorf("PrivateUseBenefit = %.2f, erwartet %.2f", statement.PrivateUseBenefit, tt.wantPrivate)
+			}
+			if statement.CommuteBenefit != tt.wantCommute {
+				t.Errorf("CommuteBenefit = %.2f, erwartet %.2f", statement.CommuteBenefit, tt.wantCommute)
+			}
+			if statement.CommuteDays != tt.wantCommuteDays {
+				t.Errorf("CommuteDays = %d, erwartet %d", statement.CommuteDays, tt.wantCommuteDays)
+			}
+			if want := roundCents(tt.wantPrivate + tt.wantCommute); statement.TotalBenefit != want {
+				t.Errorf("TotalBenefit = %.2f, erwartet %.2f", statement.TotalBenefit, want)
+			}
+			if tt.wantWarning != (len(statement.Warnings) > 0) {
+				t.Errorf("warnungen %v, erwartet: %v", statement.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestLogbookStatement(t *testing.T) {
+	month := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		fuelType     model.FuelType
+		financed     bool
+		withTrips    bool
+		wantCosts    float64
+		wantPerKm    float64
+		wantPrivate  float64
+		wantCommute  float64
+		wantWarnings bool
+	}{
+		{
+			name:        "kosten nach kilometern verteilt",
+			fuelType:    model.FuelTypeDiesel,
+			withTrips:   true,
+			wantCosts:   1100,
+			wantPerKm:   1,
+			wantPrivate: 300,
+			wantCommute: 100,
+		},
+		{
+			name:        "elektro mindert nur die anschaffungskosten",
+			fuelType:    model.FuelTypeElectric,
+			withTrips:   true,
+			wantCosts:   725,
+			wantPerKm:   0.6591,
+			wantPrivate: 197.73,
+			wantCommute: 65.91,
+		},
+		{
+			name:        "finanziertes elektrofahrzeug mindert die zinsen nicht",
+			fuelType:    model.FuelTypeElectric,
+			financed:    true,
+			withTrips:   true,
+			wantCosts:   825,
+			wantPerKm:   0.75,
+			wantPrivate: 225,
+			wantCommute: 75,
+		},
+		{
+			name:         "ohne fahrten",
+			fuelType:     model.FuelTypeDiesel,
+			wantCosts:    1100,
+			wantWarnings: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newBenefitTestSetup()
+			vehicle := &model.Vehicle{
+				FuelType:         tt.fuelType,
+				ListPrice:        50000,
+				RegistrationDate: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+				AcquisitionType:  model.AcquisitionTypePurchased,
+				PurchasePrice:    36000,
+				PurchaseDate:     time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+				InsuranceCost:    1200,
+			}
+			if tt.financed {
+				// Zinsfreie Raten über dem Betrag ergeben 100 € Zinsen im Monat
+				vehicle.AcquisitionType = model.AcquisitionTypeFinanced
+				vehicle.FinanceTotalAmount = 12000
+				vehicle.FinanceMonthlyRate = 1100
+				vehicle.FinanceStartDate = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+				vehicle.FinanceEndDate = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+			}
+			driver := &model.Driver{}
+			other := &model.Driver{}
+			setup.assign(t, vehicle, driver)
+			if err := setup.drivers.Create(other); err != nil {
+				t.Fatalf("fahrer anlegen: %v", err)
+			}
+
+			day := month.AddDate(0, 0, 4)
+			setup.fuelCosts.Create(&model.FuelCost{VehicleID: vehicle.ID, Date: day, TotalCost: 300})
+			setup.fuelCosts.Create(&model.FuelCost{VehicleID: vehicle.ID, Date: month.AddDate(0, 1, 0), TotalCost: 999})
+			setup.maintenance.Create(&model.Maintenance{VehicleID: vehicle.ID, Date: day, Cost: 200})
+			if tt.withTrips {
+				setup.trip(t, vehicle, driver, day, 0, 600, model.TripTypeBusiness)
+				setup.trip(t, vehicle, driver, day.AddDate(0, 0, 1), 600, 900, model.TripTypePrivate)
+				setup.trip(t, vehicle, driver, day.AddDate(0, 0, 2), 900, 1000, model.TripTypeCommute)
+				// Privatfahrten anderer Fahrer zählen nur zur Gesamtstrecke
+				setup.trip(t, vehicle, other, day.AddDate(0, 0, 3), 1000, 1100, model.TripTypePrivate)
+			}
+
+			statement, err := setup.service.GetDriverStatement(driver.ID.Hex(), month, model.BenefitMethodLogbook)
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if statement.TotalCosts != tt.wantCosts {
+				t.Errorf("TotalCosts = %.2f, erwartet %.2f", statement.TotalCosts, tt.wantCosts)
+			}
+			if statement.CostPerKm != tt.wantPerKm {
+				t.Errorf("CostPerKm = %.4f, erwartet %.4f", statement.CostPerKm, tt.wantPerKm)
+			}
+			if statement.PrivateUseBenefit != tt.wantPrivate {
+				t.Errorf("PrivateUseBenefit = %.2f, erwartet %.2f", statement.PrivateUseBenefit, tt.wantPrivate)
+			}
+			if statement.CommuteBenefit != tt.wantCommute {
+				t.Errorf("CommuteBenefit = %.2f, erwartet %.2f", statement.CommuteBenefit, tt.wantCommute)
+			}
+			if tt.wantWarnings != (len(statement.Warnings) > 0) {
+				t.Errorf("warnungen %v, erwartet: %v", statement.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,588 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// daysPerMonth ist die durchschnittliche Monatslänge für anteilige Kosten
+const daysPerMonth = 365.25 / 12
+
+// ErrTCOVehicleNotFound wird für unbekannte Fahrzeuge zurückgegeben
+var ErrTCOVehicleNotFound = errors.New("fahrzeug nicht gefunden")
+
+// TCOService berechnet die Gesamtkosten (Total Cost of Ownership) je Fahrzeug und für die Flotte.
+// Laufende Kosten werden aus den erfassten Belegen summiert, Wertverlust, Zinsen, Leasingraten und
+// Versicherung anteilig für den Auswertungszeitraum aus den Fahrzeugdaten berechnet.
+type TCOService struct {
+	vehicleRepo     repository.VehicleRepository
+	fuelCostRepo    repository.FuelCostRepository
+	chargingRepo    repository.ChargingSessionRepository
+	maintenanceRepo repository.MaintenanceRepository
+	usageRepo       repository.VehicleUsageRepository
+	claimRepo       repository.AccidentClaimRepository
+	fineRepo        repository.TrafficFineRepository
+}
+
+// NewTCOService erstellt einen neuen TCOService
+func NewTCOService(vehicleRepo repository.VehicleRepository, fuelCostRepo repository.FuelCostRepository, chargingRepo repository.ChargingSessionRepository, maintenanceRepo repository.MaintenanceRepository, usageRepo repository.VehicleUsageRepository, claimRepo repository.AccidentClaimRepository, fineRepo repository.TrafficFineRepository) *TCOService {
+	return &TCOService{
+		vehicleRepo:     vehicleRepo,
+		fuelCostRepo:    fuelCostRepo,
+		chargingRepo:    chargingRepo,
+		maintenanceRepo: maintenanceRepo,
+		usageRepo:       usageRepo,
+		claimRepo:       claimRepo,
+		fineRepo:        fineRepo,
+	}
+}
+
+// tcoRecords enthält die Belege, aus denen die laufenden Kosten summiert werden
+type tcoRecords struct {
+	fuelCosts   []*model.FuelCost
+	charging    []*model.ChargingSession
+	maintenance []*model.Maintenance
+	usage       []*model.VehicleUsage
+	claims      []*model.AccidentClaim
+	fines       []*model.TrafficFine
+}
+
+// GetVehicleTCO berechnet die Gesamtkosten eines Fahrzeugs
+func (s *TCOService) GetVehicleTCO(vehicleID string, options model.TCOOptions) (*model.VehicleTCO, error) {
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, ErrTCOVehicleNotFound
+	}
+	if err := normalizeTCOOptions(&options); err != nil {
+		return nil, err
+	}
+
+	records := &tcoRecords{}
+	if records.fuelCosts, err = s.fuelCostRepo.FindByVehicle(vehicleID); err != nil {
+		return nil, err
+	}
+	if records.charging, err = s.chargingRepo.FindByVehicle(vehicleID); err != nil {
+		return nil, err
+	}
+	if records.maintenance, err = s.maintenanceRepo.FindByVehicle(vehicleID); err != nil {
+		return nil, err
+	}
+	if records.usage, err = s.usageRepo.FindByVehicle(vehicleID); err != nil {
+		return nil, err
+	}
+	if records.claims, err = s.claimRepo.Find(model.AccidentClaimFilter{VehicleID: &vehicle.ID}); err != nil {
+		return nil, err
+	}
+	if records.fines, err = s.fineRepo.Find(model.TrafficFineFilter{VehicleID: &vehicle.ID}); err != nil {
+		return nil, err
+	}
+
+	tco := calculateVehicleTCO(vehicle, records, options)
+	return &tco, nil
+}
+
+// GetFleetTCO berechnet die Gesamtkosten aller Fahrzeuge
+func (s *TCOService) GetFleetTCO(options model.TCOOptions) (*model.FleetTCO, error) {
+	if err := normalizeTCOOptions(&options); err != nil {
+		return nil, err
+	}
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.loadAllRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	fleet := &model.FleetTCO{
+		From:     options.From,
+		To:       *options.To,
+		Vehicles: []model.VehicleTCO{},
+	}
+	for _, vehicle := range vehicles {
+		tco := calculateVehicleTCO(vehicle, records.forVehicle(vehicle.ID), options)
+		if tco.Months <= 0 {
+			// Fahrzeug im Zeitraum noch nicht im Bestand
+			continue
+		}
+		fleet.Vehicles = append(fleet.Vehicles, tco)
+		fleet.Costs.Add(tco.Costs)
+		fleet.Kilometers += tco.Kilometers
+		fleet.CostPerMonth += tco.CostPerMonth
+	}
+	roundTCOCosts(&fleet.Costs)
+	fleet.CostPerMonth = roundCents(fleet.CostPerMonth)
+	if fleet.Kilometers > 0 {
+		fleet.CostPerKm = math.Round(fleet.Costs.Total/float64(fleet.Kilometers)*1000) / 1000
+	}
+
+	// Teuerste Fahrzeuge je Kilometer zuerst
+	sort.SliceStable(fleet.Vehicles, func(i, j int) bool { return fleet.Vehicles[i].CostPerKm > fleet.Vehicles[j].CostPerKm })
+	return fleet, nil
+}
+
+// GetModelComparison vergleicht die Gesamtkosten nach Marke und Modell
+func (s *TCOService) GetModelComparison(options model.TCOOptions) ([]model.ModelTCO, error) {
+	fleet, err := s.GetFleetTCO(options)
+	if err != nil {
+		return nil, err
+	}
+
+	byModel := make(map[string]*model.ModelTCO)
+	var keys []string
+	for _, tco := range fleet.Vehicles {
+		key := tco.Brand + "\x00" + tco.Model
+		entry, exists := byModel[key]
+		if !exists {
+			entry = &model.ModelTCO{Brand: tco.Brand, Model: tco.Model}
+			byModel[key] = entry
+			keys = append(keys, key)
+		}
+		entry.Vehicles++
+		entry.Months += tco.Months
+		entry.Kilometers += tco.Kilometers
+		entry.Costs.Add(tco.Costs)
+	}
+
+	comparison := make([]model.ModelTCO, 0, len(keys))
+	for _, key := range keys {
+		entry := byModel[key]
+		roundTCOCosts(&entry.Costs)
+		if entry.Kilometers > 0 {
+			entry.CostPerKm = math.Round(entry.Costs.Total/float64(entry.Kilometers)*1000) / 1000
+		}
+		if entry.Months > 0 {
+			entry.CostPerMonth = roundCents(entry.Costs.Total / entry.Months)
+		}
+		entry.Months = roundCents(entry.Months)
+		comparison = append(comparison, *entry)
+	}
+
+	// Günstigste Modelle je Kilometer zuerst, Modelle ohne Fahrleistung am Ende
+	sort.SliceStable(comparison, func(i, j int) bool {
+		if (comparison[i].Kilometers == 0) != (comparison[j].Kilometers == 0) {
+			return comparison[j].Kilometers == 0
+		}
+		return comparison[i].CostPerKm < comparison[j].CostPerKm
+	})
+	return comparison, nil
+}
+
+// loadAllRecords lädt die Belege aller Fahrzeuge
+func (s *TCOService) loadAllRecords() (*tcoRecords, error) {
+	records := &tcoRecords{}
+	var err error
+	if records.fuelCosts, err = s.fuelCostRepo.FindAll(); err != nil {
+		return nil, err
+	}
+	if records.charging, err = s.chargingRepo.Find(model.ChargingSessionFilter{}); err != nil {
+		return nil, err
+	}
+	if records.maintenance, err = s.maintenanceRepo.FindAll(); err != nil {
+		return nil, err
+	}
+	if records.usage, err = s.usageRepo.FindAll(); err != nil {
+		return nil, err
+	}
+	if records.claims, err = s.claimRepo.Find(model.AccidentClaimFilter{}); err != nil {
+		return nil, err
+	}
+	if records.fines, err = s.fineRepo.Find(model.TrafficFineFilter{}); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// forVehicle filtert die Belege auf ein Fahrzeug
+func (r *tcoRecords) forVehicle(vehicleID primitive.ObjectID) *tcoRecords {
+	filtered := &tcoRecords{}
+	for _, cost := range r.fuelCosts {
+		if cost.VehicleID == vehicleID {
+			filtered.fuelCosts = append(filtered.fuelCosts, cost)
+		}
+	}
+	for _, session := range r.charging {
+		if session.VehicleID == vehicleID {
+			filtered.charging = append(filtered.charging, session)
+		}
+	}
+	for _, entry := range r.maintenance {
+		if entry.VehicleID == vehicleID {
+			filtered.maintenance = append(filtered.maintenance, entry)
+		}
+	}
+	for _, usage := range r.usage {
+		if usage.VehicleID == vehicleID {
+			filtered.usage = append(filtered.usage, usage)
+		}
+	}
+	for _, claim := range r.claims {
+		if claim.VehicleID == vehicleID {
+			filtered.claims = append(filtered.claims, claim)
+		}
+	}
+	for _, fine := range r.fines {
+		if fine.VehicleID == vehicleID {
+			filtered.fines = append(filtered.fines, fine)
+		}
+	}
+	return filtered
+}
+
+// normalizeTCOOptions prüft die Optionen und setzt Standardwerte
+func normalizeTCOOptions(options *model.TCOOptions) error {
+	if options.To == nil {
+		now := time.Now()
+		options.To = &now
+	}
+	if options.From != nil && !options.From.Before(*options.To) {
+		return fmt.Errorf("der beginn des zeitraums muss vor dem ende liegen")
+	}
+	switch options.Method {
+	case "":
+		options.Method = model.DepreciationLinear
+	case model.DepreciationLinear, model.DepreciationDecliningBalance:
+	default:
+		return fmt.Errorf("ungültiges abschreibungsverfahren: %s", options.Method)
+	}
+	if options.DepreciationYears == 0 {
+		options.DepreciationYears = model.DefaultDepreciationYears
+	}
+	if options.DepreciationYears < 1 || options.DepreciationYears > 30 {
+		return fmt.Errorf("die nutzungsdauer muss zwischen 1 und 30 jahren liegen")
+	}
+	if options.DecliningRate == 0 {
+		options.DecliningRate = model.DefaultDecliningBalanceRate
+	}
+	if options.DecliningRate <= 0 || options.DecliningRate >= 1 {
+		return fmt.Errorf("der degressive satz muss zwischen 0 und 100 prozent liegen")
+	}
+	return nil
+}
+
+// calculateVehicleTCO berechnet die Gesamtkosten eines Fahrzeugs aus seinen Belegen
+func calculateVehicleTCO(vehicle *model.Vehicle, records *tcoRecords, options model.TCOOptions) model.VehicleTCO {
+	tco := model.VehicleTCO{
+		VehicleID:       vehicle.ID,
+		LicensePlate:    vehicle.LicensePlate,
+		Brand:           vehicle.Brand,
+		Model:           vehicle.Model,
+		AcquisitionType: vehicle.AcquisitionType,
+		Warnings:        []string{},
+	}
+
+	acquired := acquisitionDate(vehicle)
+	from := acquired
+	if options.From != nil && options.From.After(from) {
+		from = *options.From
+	}
+	to := *options.To
+	tco.From, tco.To = from, to
+	if !to.After(from) {
+		return tco
+	}
+	tco.Months = roundCents(to.Sub(from).Hours() / 24 / daysPerMonth)
+	inPeriod := func(t time.Time) bool { return !t.Before(from) && !t.After(to) }
+
+	costs := &tco.Costs
+
+	// Laufende Kosten aus Belegen
+	for _, cost := range records.fuelCosts {
+		if inPeriod(cost.Date) {
+			costs.Fuel += cost.TotalCost
+		}
+	}
+	for _, session := range records.charging {
+		if !inPeriod(session.StartTime) {
+			continue
+		}
+		if session.LocationType == model.ChargingLocationHome {
+			if session.Reimbursement.Status == model.ReimbursementApproved {
+				costs.Charging += session.Reimbursement.Amount
+			}
+			continue
+		}
+		costs.Charging += session.Cost
+	}
+	for _, entry := range records.maintenance {
+		if inPeriod(entry.Date) {
+			costs.Maintenance += entry.Cost
+		}
+	}
+	for _, claim := range records.claims {
+		if inPeriod(claim.IncidentDate) {
+			costs.Claims += claim.AdditionalCost()
+		}
+	}
+	for _, fine := range records.fines {
+		if fine.Status == model.TrafficFineStatusPaid && fine.PaidBy == model.TrafficFinePayerCompany &&
+			fine.PaidAt != nil && inPeriod(*fine.PaidAt) {
+			costs.Fines += fine.PaidAmount
+		}
+	}
+
+	// Versicherung: Jahresbeitrag anteilig
+	if vehicle.InsuranceCost > 0 {
+		costs.Insurance = vehicle.InsuranceCost * to.Sub(from).Hours() / 24 / 365.25
+	} else {
+		tco.Warnings = append(tco.Warnings, "Keine Versicherungskosten hinterlegt")
+	}
+
+	readings := collectMileageReadings(records)
+	tco.Kilometers = drivenKilometers(vehicle, readings, from, to, !from.After(acquired), &tco.Warnings)
+
+	switch vehicle.AcquisitionType {
+	case model.AcquisitionTypeLeased:
+		calculateLeaseCosts(vehicle, &tco, readings, from, to)
+	case model.AcquisitionTypeFinanced:
+		calculateFinanceInterest(vehicle, &tco, from, to)
+		calculateDepreciation(vehicle, &tco, acquired, from, to, options)
+	default:
+		calculateDepreciation(vehicle, &tco, acquired, from, to, options)
+	}
+
+	costs.Total = costs.Depreciation + costs.FinanceInterest + costs.LeaseRates + costs.ExcessMileage +
+		costs.Insurance + costs.Fuel + costs.Charging + costs.Maintenance + costs.Claims + costs.Fines
+	roundTCOCosts(costs)
+	if tco.Kilometers > 0 {
+		tco.CostPerKm = math.Round(costs.Total/float64(tco.Kilometers)*1000) / 1000
+	}
+	if tco.Months > 0 {
+		tco.CostPerMonth = roundCents(costs.Total / tco.Months)
+	}
+	return tco
+}
+
+// acquisitionDate ermittelt, seit wann das Fahrzeug im Bestand ist
+func acquisitionDate(vehicle *model.Vehicle) time.Time {
+	var date time.Time
+	switch vehicle.AcquisitionType {
+	case model.AcquisitionTypeLeased:
+		date = vehicle.LeaseStartDate
+	case model.AcquisitionTypeFinanced:
+		date = vehicle.FinanceStartDate
+		if date.IsZero() {
+			date = vehicle.PurchaseDate
+		}
+	default:
+		date = vehicle.PurchaseDate
+	}
+	if date.IsZero() {
+		date = vehicle.RegistrationDate
+	}
+	if date.IsZero() {
+		date = vehicle.CreatedAt
+	}
+	return date
+}
+
+// collectMileageReadings sammelt die datierten Kilometerstände aus den Belegen eines Fahrzeugs
+func collectMileageReadings(records *tcoRecords) []mileageReading {
+	var readings []mileageReading
+	for _, cost := range records.fuelCosts {
+		if cost.Mileage > 0 {
+			readings = append(readings, mileageReading{cost.Date, cost.Mileage})
+		}
+	}
+	for _, session := range records.charging {
+		if session.Mileage > 0 {
+			readings = append(readings, mileageReading{session.StartTime, session.Mileage})
+		}
+	}
+	for _, entry := range records.maintenance {
+		if entry.Mileage > 0 {
+			readings = append(readings, mileageReading{entry.Date, entry.Mileage})
+		}
+	}
+	for _, usage := range records.usage {
+		if usage.StartMileage > 0 {
+			readings = append(readings, mileageReading{usage.StartDate, usage.StartMileage})
+		}
+		if usage.EndMileage > 0 && !usage.EndDate.IsZero() {
+			readings = append(readings, mileageReading{usage.EndDate, usage.EndMileage})
+		}
+	}
+	return readings
+}
+
+// drivenKilometers ermittelt die Fahrleistung im Zeitraum aus datierten Kilometerständen.
+// Beginnt der Zeitraum mit dem Erwerb und liegt davor kein Stand vor, wird ein Neufahrzeug angenommen.
+func drivenKilometers(vehicle *model.Vehicle, readings []mileageReading, from, to time.Time, fromAcquisition bool, warnings *[]string) int {
+	if vehicle.Mileage > 0 {
+		// Der aktuelle Stand gilt ab der letzten Änderung des Fahrzeugs
+		readings = append(readings, mileageReading{vehicle.UpdatedAt, vehicle.Mileage})
+	}
+
+	start, end := -1, 0
+	for _, reading := range readings {
+		if !reading.date.After(from) && reading.mileage > start {
+			start = reading.mileage
+		}
+		if !reading.date.After(to) && reading.mileage > end {
+			end = reading.mileage
+		}
+	}
+	if start < 0 {
+		if fromAcquisition {
+			start = 0
+		} else {
+			// Frühester Stand im Zeitraum als Näherung für den Beginn
+			start = end
+			for _, reading := range readings {
+				if reading.date.After(from) && !reading.date.After(to) && reading.mileage < start {
+					start = reading.mileage
+				}
+			}
+			*warnings = append(*warnings, "Kein Kilometerstand zu Beginn des Zeitraums, Fahrleistung ab dem ersten erfassten Stand")
+		}
+	}
+	if end <= start {
+		return 0
+	}
+	return end - start
+}
+
+// calculateDepreciation berechnet den Wertverlust im Zeitraum und den Restbuchwert an dessen Ende
+func calculateDepreciation(vehicle *model.Vehicle, tco *model.VehicleTCO, acquired, from, to time.Time, options model.TCOOptions) {
+	basis := vehicle.PurchasePrice
+	if basis <= 0 && vehicle.AcquisitionType == model.AcquisitionTypeFinanced {
+		basis = vehicle.FinanceTotalAmount + vehicle.FinanceDownPayment
+	}
+	if basis <= 0 {
+		tco.Warnings = append(tco.Warnings, "Kein Kaufpreis hinterlegt, Wertverlust nicht berechnet")
+		return
+	}
+	tco.DepreciationMethod = options.Method
+
+	lifeMonths := options.DepreciationYears * 12
+	bookValue := basis
+	for month := 0; month < lifeMonths && bookValue > 0; month++ {
+		monthStart := acquired.AddDate(0, month, 0)
+		if !monthStart.Before(to) {
+			break
+		}
+		var amount float64
+		if options.Method == model.DepreciationDecliningBalance {
+			// Wechsel zur linearen Restabschreibung, sobald diese höher ist
+			amount = math.Max(bookValue*options.DecliningRate/12, bookValue/float64(lifeMonths-month))
+		} else {
+			amount = basis / float64(lifeMonths)
+		}
+		amount = math.Min(amount, bookValue)
+
+		share := monthShare(monthStart, acquired.AddDate(0, month+1, 0), from, to)
+		tco.Costs.Depreciation += amount * share
+		// Der Restbuchwert läuft bis zum Ende des Zeitraums mit
+		bookValue -= amount * monthShare(monthStart, acquired.AddDate(0, month+1, 0), monthStart, to)
+	}
+	bookValue = roundCents(math.Max(bookValue, 0))
+	tco.BookValue = &bookValue
+}
+
+// calculateFinanceInterest berechnet den Zinsanteil der Finanzierungsraten im Zeitraum aus dem Tilgungsplan
+func calculateFinanceInterest(vehicle *model.Vehicle, tco *model.VehicleTCO, from, to time.Time) {
+	schedule := BuildAmortizationSchedule(vehicle, time.Now())
+	for _, issue := range schedule.Issues {
+		if issue.Severity == model.FinancingIssueError {
+			tco.Warnings = append(tco.Warnings, issue.Message+", Zinsen nicht berechnet")
+			return
+		}
+	}
+
+	if schedule.InterestRate == 0 {
+		// Ohne Zinssatz gilt die Differenz aus Ratensumme und Darlehensbetrag als Finanzierungskosten
+		if vehicle.FinanceEndDate.IsZero() {
+			tco.Warnings = append(tco.Warnings, "Kein Zinssatz hinterlegt, Zinsen nicht berechnet")
+			return
+		}
+		term := monthsBetween(schedule.StartDate, vehicle.FinanceEndDate)
+		totalInterest := schedule.MonthlyRate*float64(term) - schedule.Principal
+		if totalInterest <= 0 {
+			return
+		}
+		for month := 0; month < term; month++ {
+			share := monthShare(schedule.StartDate.AddDate(0, month, 0), schedule.StartDate.AddDate(0, month+1, 0), from, to)
+			tco.Costs.FinanceInterest += totalInterest / float64(term) * share
+		}
+		return
+	}
+
+	// Jede Rate deckt die Zinsen des Monats vor ihrer Fälligkeit
+	for _, entry := range schedule.Entries {
+		tco.Costs.FinanceInterest += entry.Interest * monthShare(entry.Date.AddDate(0, -1, 0), entry.Date, from, to)
+	}
+}
+
+// calculateLeaseCosts berechnet Leasingraten und anteilige Mehrkilometer-Kosten im Zeitraum.
+// Die Mehrkilometer stammen aus der Hochrechnung des Leasingvertrags bis Vertragsende.
+func calculateLeaseCosts(vehicle *model.Vehicle, tco *model.VehicleTCO, readings []mileageReading, from, to time.Time) {
+	start, end := vehicle.LeaseStartDate, vehicle.LeaseEndDate
+	if start.IsZero() || end.IsZero() || !end.After(start) {
+		tco.Warnings = append(tco.Warnings, "Leasingbeginn oder -ende fehlt, Leasingkosten nicht berechnet")
+		return
+	}
+	if vehicle.LeaseMonthlyRate <= 0 {
+		tco.Warnings = append(tco.Warnings, "Keine Leasingrate hinterlegt")
+	}
+
+	term := monthsBetween(start, end)
+	for month := 0; month < term; month++ {
+		share := monthShare(start.AddDate(0, month, 0), start.AddDate(0, month+1, 0), from, to)
+		tco.Costs.LeaseRates += vehicle.LeaseMonthlyRate * share
+	}
+
+	forecast := forecastLease(vehicle, readings, time.Now())
+	if forecast.ExpectedExcessCost <= 0 {
+		return
+	}
+	tco.ProjectedExcessKm = forecast.ProjectedExcessKm
+	contractDays := end.Sub(start).Hours() / 24
+	tco.Costs.ExcessMileage = forecast.ExpectedExcessCost * overlapDays(start, end, from, to) / contractDays
+}
+
+// monthShare liefert den Anteil des Monats [monthStart, monthEnd), der im Zeitraum [from, to] liegt
+func monthShare(monthStart, monthEnd, from, to time.Time) float64 {
+	total := monthEnd.Sub(monthStart).Hours()
+	if total <= 0 {
+		return 0
+	}
+	return overlapDays(monthStart, monthEnd, from, to) * 24 / total
+}
+
+// overlapDays liefert die Überschneidung zweier Zeiträume in Tagen
+func overlapDays(aStart, aEnd, bStart, bEnd time.Time) float64 {
+	start, end := aStart, aEnd
+	if bStart.After(start) {
+		start = bStart
+	}
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Hours() / 24
+}
+
+// roundTCOCosts rundet alle Kostenarten auf Cent
+func roundTCOCosts(costs *model.TCOCosts) {
+	costs.Depreciation = roundCents(costs.Depreciation)
+	costs.FinanceInterest = roundCents(costs.FinanceInterest)
+	costs.LeaseRates = roundCents(costs.LeaseRates)
+	costs.ExcessMileage = roundCents(costs.ExcessMileage)
+	costs.Insurance = roundCents(costs.Insurance)
+	costs.Fuel = roundCents(costs.Fuel)
+	costs.Charging = roundCents(costs.Charging)
+	costs.Maintenance = roundCents(costs.Maintenance)
+	costs.Claims = roundCents(costs.Claims)
+	costs.Fines = roundCents(costs.Fines)
+	costs.Total = roundCents(costs.Total)
+}
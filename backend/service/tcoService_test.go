@@ -0,0 +1,235 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculateDepreciation(t *testing.T) {
+	acquired := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	linear := model.TCOOptions{Method: model.DepreciationLinear, DepreciationYears: 6}
+	declining := model.TCOOptions{Method: model.DepreciationDecliningBalance, DepreciationYears: 6, DecliningRate: 0.25}
+
+	tests := []struct {
+		name             string
+		vehicle          model.Vehicle
+		from, to         time.Time
+		options          model.TCOOptions
+		wantDepreciation float64
+		wantBookValue    float64
+		wantWarning      bool
+	}{
+		{
+			name:             "linear im ersten jahr",
+			vehicle:          model.Vehicle{PurchasePrice: 36000},
+			from:             acquired,
+			to:               acquired.AddDate(1, 0, 0),
+			options:          linear,
+			wantDepreciation: 6000,
+			wantBookValue:    30000,
+		},
+		{
+			name:             "linear im zweiten halbjahr",
+			vehicle:          model.Vehicle{PurchasePrice: 36000},
+			from:             acquired.AddDate(0, 6, 0),
+			to:               acquired.AddDate(1, 0, 0),
+			options:          linear,
+			wantDepreciation: 3000,
+			wantBookValue:    30000,
+		},
+		{
+			name:             "linear über die nutzungsdauer hinaus",
+			vehicle:          model.Vehicle{PurchasePrice: 36000},
+			from:             acquired,
+			to:               acquired.AddDate(8, 0, 0),
+			options:          linear,
+			wantDepreciation: 36000,
+			wantBookValue:    0,
+		},
+		{
+			name:             "degressiv im ersten jahr",
+			vehicle:          model.Vehicle{PurchasePrice: 36000},
+			from:             acquired,
+			to:               acquired.AddDate(1, 0, 0),
+			options:          declining,
+			wantDepreciation: 8037.12,
+			wantBookValue:    27962.88,
+		},
+		{
+			name:             "degressiv wechselt zu linear und endet bei null",
+			vehicle:          model.Vehicle{PurchasePrice: 36000},
+			from:             acquired,
+			to:               acquired.AddDate(6, 0, 0),
+			options:          declining,
+			wantDepreciation: 36000,
+			wantBookValue:    0,
+		},
+		{
+			name: "finanziert ohne kaufpreis nutzt betrag und anzahlung",
+			vehicle: model.Vehicle{
+				AcquisitionType:    model.AcquisitionTypeFinanced,
+				FinanceTotalAmount: 30000,
+				FinanceDownPayment: 6000,
+			},
+			from:             acquired,
+			to:               acquired.AddDate(1, 0, 0),
+			options:          linear,
+			wantDepreciation: 6000,
+			wantBookValue:    30000,
+		},
+		{
+			name:        "ohne kaufpreis",
+			vehicle:     model.Vehicle{AcquisitionType: model.AcquisitionTypePurchased},
+			from:        acquired,
+			to:          acquired.AddDate(1, 0, 0),
+			options:     linear,
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tco := &model.VehicleTCO{}
+			calculateDepreciation(&tt.vehicle, tco, acquired, tt.from, tt.to, tt.options)
+
+			if tt.wantWarning {
+				if len(tco.Warnings) == 0 || tco.BookValue != nil {
+					t.Fatalf("warnung ohne restbuchwert erwartet, erhalten %v / %v", tco.Warnings, tco.BookValue)
+				}
+				return
+			}
+			if math.Abs(tco.Costs.Depreciation-tt.wantDepreciation) > 0.01 {
+				t.Errorf("Depreciation = %.2f, erwartet %.2f", tco.Costs.Depreciation, tt.wantDepreciation)
+			}
+			if tco.BookValue == nil {
+				t.Fatalf("kein restbuchwert berechnet")
+			}
+			if *tco.BookValue != tt.wantBookValue {
+				t.Errorf("BookValue = %.2f, erwartet %.2f", *tco.BookValue, tt.wantBookValue)
+			}
+			if tco.DepreciationMethod != tt.options.Method {
+				t.Errorf("DepreciationMethod = %q, erwartet %q", tco.DepreciationMethod, tt.options.Method)
+			}
+		})
+	}
+}
+
+func TestCalculateFinanceInterest(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	annuity := model.Vehicle{
+		FinanceTotalAmount:  10000,
+		FinanceInterestRate: 6,
+		FinanceStartDate:    start,
+		FinanceEndDate:      start.AddDate(1, 0, 0),
+	}
+	interestFree := model.Vehicle{
+		FinanceTotalAmount: 12000,
+		FinanceMonthlyRate: 1100,
+		FinanceStartDate:   start,
+		FinanceEndDate:     start.AddDate(1, 0, 0),
+	}
+
+	tests := []struct {
+		name        string
+		vehicle     model.Vehicle
+		from, to    time.Time
+		want        float64
+		wantWarning bool
+	}{
+		{
+			name:    "annuität über die gesamte laufzeit",
+			vehicle: annuity,
+			from:    start,
+			to:      start.AddDate(1, 0, 0),
+			want:    327.96,
+		},
+		{
+			name:    "annuität im ersten monat",
+			vehicle: annuity,
+			from:    start,
+			to:      start.AddDate(0, 1, 0),
+			want:    50,
+		},
+		{
+			name:    "zeitraum nach vertragsende",
+			vehicle: annuity,
+			from:    start.AddDate(2, 0, 0),
+			to:      start.AddDate(3, 0, 0),
+			want:    0,
+		},
+		{
+			name:    "ohne zinssatz aus der differenz der raten",
+			vehicle: interestFree,
+			from:    start,
+			to:      start.AddDate(1, 0, 0),
+			want:    1200,
+		},
+		{
+			name:    "ohne zinssatz im ersten halbjahr",
+			vehicle: interestFree,
+			from:    start,
+			to:      start.AddDate(0, 6, 0),
+			want:    600,
+		},
+		{
+			name: "ohne zinssatz und vertragsende",
+			vehicle: model.Vehicle{
+				FinanceTotalAmount: 12000,
+				FinanceMonthlyRate: 1000,
+				FinanceStartDate:   start,
+			},
+			from:        start,
+			to:          start.AddDate(1, 0, 0),
+			wantWarning: true,
+		},
+		{
+			name:        "unvollständige finanzierung",
+			vehicle:     model.Vehicle{FinanceMonthlyRate: 300},
+			from:        start,
+			to:          start.AddDate(1, 0, 0),
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tco := &model.VehicleTCO{}
+			calculateFinanceInterest(&tt.vehicle, tco, tt.from, tt.to)
+
+			if tt.wantWarning != (len(tco.Warnings) > 0) {
+				t.Errorf("warnungen %v, erwartet: %v", tco.Warnings, tt.wantWarning)
+			}
+			if math.Abs(tco.Costs.FinanceInterest-tt.want) > 0.01 {
+				t.Errorf("FinanceInterest = %.2f, erwartet %.2f", tco.Costs.FinanceInterest, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthShare(t *testing.T) {
+	monthStart := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     float64
+	}{
+		{"ganzer monat", monthStart, monthEnd, 1},
+		{"zeitraum umfasst den monat", monthStart.AddDate(-1, 0, 0), monthEnd.AddDate(1, 0, 0), 1},
+		{"erste hälfte", monthStart, monthStart.AddDate(0, 0, 15), 0.5},
+		{"ein tag", monthStart.AddDate(0, 0, 10), monthStart.AddDate(0, 0, 11), 1.0 / 30},
+		{"vor dem monat", monthStart.AddDate(0, -2, 0), monthStart, 0},
+		{"nach dem monat", monthEnd, monthEnd.AddDate(0, 1, 0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := monthShare(monthStart, monthEnd, tt.from, tt.to); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("monthShare = %v, erwartet %v", got, tt.want)
+			}
+		})
+	}
+}
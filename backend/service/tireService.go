@@ -0,0 +1,886 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrTireSetNotFound wird für unbekannte Reifensätze zurückgegeben
+	ErrTireSetNotFound = errors.New("reifensatz nicht gefunden")
+	// ErrTireCampaignNotFound wird für unbekannte Wechselaktionen zurückgegeben
+	ErrTireCampaignNotFound = errors.New("wechselaktion nicht gefunden")
+	// ErrTireState wird zurückgegeben, wenn die Aktion im aktuellen Zustand nicht möglich ist
+	ErrTireState = errors.New("aktion im aktuellen zustand des reifensatzes nicht möglich")
+)
+
+// TireSetInput enthält die Stammdaten eines Reifensatzes
+type TireSetInput struct {
+	VehicleID      string
+	Season         model.TireSeason
+	Brand          string
+	Model          string
+	Size           string // Ohne Angabe Reifengröße des Fahrzeugs
+	RimType        string // Ohne Angabe Felgentyp des Fahrzeugs
+	DOTCode        string
+	Location       model.TireLocation // Nur beim Anlegen, danach über den Reifenwechsel
+	StorageDetails string
+	Retired        bool
+	Notes          string
+}
+
+// TireSwapInput enthält die Angaben eines Reifenwechsels
+type TireSwapInput struct {
+	VehicleID      string
+	TireSetID      string // Zu montierender Satz
+	Date           *time.Time
+	Mileage        int // Ohne Angabe aktueller Kilometerstand des Fahrzeugs
+	Cost           float64
+	Workshop       string
+	StorageDetails string // Lagerort des abmontierten Satzes, ohne Angabe der freiwerdende Platz
+}
+
+// TireSwapResult ist das Ergebnis eines Reifenwechsels
+type TireSwapResult struct {
+	Mounted     *model.TireSet     `json:"mounted"`
+	Unmounted   []*model.TireSet   `json:"unmounted"`
+	Maintenance *model.Maintenance `json:"maintenance"`
+}
+
+// TireCampaignItemInput enthält Änderungen an einem geplanten Wechsel
+type TireCampaignItemInput struct {
+	ScheduledDate *time.Time
+	Status        model.TireSwapItemStatus
+}
+
+// TireService verwaltet Reifensätze, prüft Profiltiefe und Alter gegen gesetzliche und
+// firmeneigene Vorgaben und plant die saisonalen Wechselaktionen. Ein Reifenwechsel
+// erzeugt einen Wartungseintrag vom Typ "tire-change".
+type TireService struct {
+	tireRepo        repository.TireRepository
+	vehicleRepo     repository.VehicleRepository
+	maintenanceRepo repository.MaintenanceRepository
+	mileageService  *VehicleMileageService
+	planService     *MaintenancePlanService
+	activityService *ActivityService
+}
+
+// NewTireService erstellt einen neuen TireService
+func NewTireService(tireRepo repository.TireRepository, vehicleRepo repository.VehicleRepository, maintenanceRepo repository.MaintenanceRepository, mileageService *VehicleMileageService, planService *MaintenancePlanService, activityService *ActivityService) *TireService {
+	return &TireService{
+		tireRepo:        tireRepo,
+		vehicleRepo:     vehicleRepo,
+		maintenanceRepo: maintenanceRepo,
+		mileageService:  mileageService,
+		planService:     planService,
+		activityService: activityService,
+	}
+}
+
+// GetSets liefert alle Reifensätze zum Filter
+func (s *TireService) GetSets(filter model.TireSetFilter) ([]*model.TireSet, error) {
+	return s.tireRepo.FindSets(filter)
+}
+
+// GetSet liefert einen Reifensatz
+func (s *TireService) GetSet(id string) (*model.TireSet, error) {
+	set, err := s.tireRepo.FindSetByID(id)
+	if err != nil {
+		return nil, ErrTireSetNotFound
+	}
+	return set, nil
+}
+
+// CreateSet legt einen Reifensatz an. Wird er direkt als montiert erfasst, beginnt die
+// Saisonlaufleistung beim aktuellen Kilometerstand des Fahrzeugs.
+func (s *TireService) CreateSet(user *model.User, input TireSetInput) (*model.TireSet, error) {
+	set := &model.TireSet{
+		Location:     input.Location,
+		Measurements: []model.TireTreadMeasurement{},
+		SeasonUsage:  []model.TireSeasonUsage{},
+	}
+	if set.Location == "" {
+		set.Location = model.TireLocationStorage
+	}
+	if set.Location != model.TireLocationVehicle && set.Location != model.TireLocationStorage {
+		return nil, fmt.Errorf("ungültiger lagerort, erlaubt sind vehicle und storage")
+	}
+
+	vehicle, err := s.applyInput(set, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if set.Location == model.TireLocationVehicle {
+		if vehicle == nil {
+			return nil, fmt.Errorf("ein montierter reifensatz benötigt ein fahrzeug")
+		}
+		mounted, err := s.tireRepo.FindSets(model.TireSetFilter{VehicleID: &vehicle.ID, Location: model.TireLocationVehicle})
+		if err != nil {
+			return nil, err
+		}
+		if len(mounted) > 0 {
+			return nil, fmt.Errorf("%w: am fahrzeug ist bereits ein reifensatz montiert, bitte den reifenwechsel verwenden", ErrTireState)
+		}
+		now := time.Now()
+		set.StorageDetails = ""
+		set.SeasonUsage = []model.TireSeasonUsage{{
+			Label:          tireSeasonLabel(set.Season, now),
+			VehicleID:      vehicle.ID,
+			MountedAt:      now,
+			MountedMileage: vehicle.Mileage,
+		}}
+	}
+	if set.Retired {
+		return nil, fmt.Errorf("ein neuer reifensatz kann nicht ausgemustert angelegt werden")
+	}
+
+	if err := s.tireRepo.CreateSet(set); err != nil {
+		return nil, fmt.Errorf("fehler beim anlegen des reifensatzes: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_set_created",
+		fmt.Sprintf("%s %s %s (%s) angelegt", model.TireSeasonText[set.Season], set.Brand, set.Model, set.Size),
+		user.ID,
+		set.VehicleID,
+	)
+
+	return set, nil
+}
+
+// UpdateSet ändert die Stammdaten eines Reifensatzes; der Lagerort ändert sich nur über den Reifenwechsel
+func (s *TireService) UpdateSet(user *model.User, id string, input TireSetInput) (*model.TireSet, error) {
+	set, err := s.GetSet(id)
+	if err != nil {
+		return nil, err
+	}
+
+	mounted := set.Location == model.TireLocationVehicle
+	previousVehicle := set.VehicleID
+	if _, err := s.applyInput(set, input); err != nil {
+		return nil, err
+	}
+	if mounted {
+		if set.Retired {
+			return nil, fmt.Errorf("%w: ein montierter reifensatz kann nicht ausgemustert werden", ErrTireState)
+		}
+		if previousVehicle == nil || set.VehicleID == nil || *previousVehicle != *set.VehicleID {
+			return nil, fmt.Errorf("%w: das fahrzeug eines montierten reifensatzes ändert sich nur über den reifenwechsel", ErrTireState)
+		}
+		set.StorageDetails = ""
+	}
+
+	if err := s.tireRepo.UpdateSet(set); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des reifensatzes: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_set_updated",
+		fmt.Sprintf("%s %s %s (%s) geändert", model.TireSeasonText[set.Season], set.Brand, set.Model, set.Size),
+		user.ID,
+		set.VehicleID,
+	)
+
+	return set, nil
+}
+
+// DeleteSet löscht einen eingelagerten Reifensatz
+func (s *TireService) DeleteSet(user *model.User, id string) error {
+	set, err := s.GetSet(id)
+	if err != nil {
+		return err
+	}
+	if set.Location == model.TireLocationVehicle {
+		return fmt.Errorf("%w: ein montierter reifensatz kann nicht gelöscht werden", ErrTireState)
+	}
+
+	if err := s.tireRepo.DeleteSet(id); err != nil {
+		return fmt.Errorf("fehler beim löschen des reifensatzes: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_set_deleted",
+		fmt.Sprintf("%s %s %s (%s) gelöscht", model.TireSeasonText[set.Season], set.Brand, set.Model, set.Size),
+		user.ID,
+		set.VehicleID,
+	)
+	return nil
+}
+
+// AddMeasurement erfasst eine Profilmessung und liefert die danach gültigen Warnungen des Satzes
+func (s *TireService) AddMeasurement(user *model.User, id string, measurement model.TireTreadMeasurement) (*model.TireSet, []model.TireWarning, error) {
+	set, err := s.GetSet(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, depth := range []float64{measurement.FrontLeft, measurement.FrontRight, measurement.RearLeft, measurement.RearRight} {
+		if depth <= 0 || depth > 20 {
+			return nil, nil, fmt.Errorf("die profiltiefe muss für alle vier positionen zwischen 0 und 20 mm liegen")
+		}
+	}
+	if measurement.Mileage < 0 {
+		return nil, nil, fmt.Errorf("der kilometerstand darf nicht negativ sein")
+	}
+	if measurement.Date.IsZero() {
+		measurement.Date = time.Now()
+	}
+	if measurement.Date.After(time.Now()) {
+		return nil, nil, fmt.Errorf("das messdatum darf nicht in der zukunft liegen")
+	}
+	if measurement.Mileage == 0 && set.Location == model.TireLocationVehicle && set.VehicleID != nil {
+		if vehicle, err := s.vehicleRepo.FindByID(set.VehicleID.Hex()); err == nil {
+			measurement.Mileage = vehicle.Mileage
+		}
+	}
+	measurement.Notes = strings.TrimSpace(measurement.Notes)
+
+	set.Measurements = append(set.Measurements, measurement)
+	if err := s.tireRepo.UpdateSet(set); err != nil {
+		return nil, nil, fmt.Errorf("fehler beim speichern der messung: %v", err)
+	}
+
+	settings, err := s.tireRepo.GetSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings := tireWarnings(set, settings, time.Now())
+
+	s.activityService.LogActivity(
+		"tire_tread_measured",
+		fmt.Sprintf("Profiltiefe %s %s gemessen: min. %.1f mm", set.Brand, set.Model, measurement.MinDepth()),
+		user.ID,
+		set.VehicleID,
+	)
+
+	return set, warnings, nil
+}
+
+// Swap montiert einen eingelagerten Reifensatz am Fahrzeug. Der bisher montierte Satz wird
+// eingelagert, die Saisonlaufleistung beider Sätze fortgeschrieben und ein Wartungseintrag
+// "tire-change" erstellt. Ein passender Wechsel einer Wechselaktion wird als erledigt markiert.
+func (s *TireService) Swap(user *model.User, input TireSwapInput) (*TireSwapResult, error) {
+	vehicle, err := s.vehicleRepo.FindByID(input.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	target, err := s.GetSet(input.TireSetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Retired {
+		return nil, fmt.Errorf("%w: der reifensatz ist ausgemustert", ErrTireState)
+	}
+	if target.Location == model.TireLocationVehicle {
+		return nil, fmt.Errorf("%w: der reifensatz ist bereits montiert", ErrTireState)
+	}
+	if target.VehicleID != nil && *target.VehicleID != vehicle.ID {
+		return nil, fmt.Errorf("%w: der reifensatz gehört zu einem anderen fahrzeug", ErrTireState)
+	}
+	if input.Cost < 0 || input.Mileage < 0 {
+		return nil, fmt.Errorf("kosten und kilometerstand dürfen nicht negativ sein")
+	}
+
+	date := time.Now()
+	if input.Date != nil {
+		date = *input.Date
+	}
+	if date.After(time.Now()) {
+		return nil, fmt.Errorf("der reifenwechsel darf nicht in der zukunft liegen")
+	}
+	mileage := input.Mileage
+	if mileage == 0 {
+		mileage = vehicle.Mileage
+	}
+
+	mounted, err := s.tireRepo.FindSets(model.TireSetFilter{VehicleID: &vehicle.ID, Location: model.TireLocationVehicle})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TireSwapResult{Mounted: target}
+	storage := strings.TrimSpace(input.StorageDetails)
+	if storage == "" {
+		// Der abmontierte Satz kommt auf den Platz des montierten
+		storage = target.StorageDetails
+	}
+	for _, previous := range mounted {
+		closeTireSeasonUsage(previous, date, mileage)
+		previous.Location = model.TireLocationStorage
+		previous.StorageDetails = storage
+		if err := s.tireRepo.UpdateSet(previous); err != nil {
+			return nil, fmt.Errorf("fehler beim einlagern des bisherigen reifensatzes: %v", err)
+		}
+		result.Unmounted = append(result.Unmounted, previous)
+	}
+
+	target.VehicleID = &vehicle.ID
+	target.Location = model.TireLocationVehicle
+	target.StorageDetails = ""
+	target.SeasonUsage = append(target.SeasonUsage, model.TireSeasonUsage{
+		Label:          tireSeasonLabel(target.Season, date),
+		VehicleID:      vehicle.ID,
+		MountedAt:      date,
+		MountedMileage: mileage,
+	})
+	if err := s.tireRepo.UpdateSet(target); err != nil {
+		return nil, fmt.Errorf("fehler beim montieren des reifensatzes: %v", err)
+	}
+
+	if target.Size != "" && target.Size != vehicle.TireSize || target.RimType != "" && target.RimType != vehicle.RimType {
+		if target.Size != "" {
+			vehicle.TireSize = target.Size
+		}
+		if target.RimType != "" {
+			vehicle.RimType = target.RimType
+		}
+		if err := s.vehicleRepo.Update(vehicle); err != nil {
+			log.Printf("Fehler beim Aktualisieren der Reifendaten des Fahrzeugs: %v", err)
+		}
+	}
+
+	maintenance := &model.Maintenance{
+		VehicleID: vehicle.ID,
+		Date:      time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Type:      model.MaintenanceTypeTireChange,
+		Mileage:   mileage,
+		Cost:      roundCents(input.Cost),
+		Workshop:  strings.TrimSpace(input.Workshop),
+		Notes:     fmt.Sprintf("Umrüstung auf %s %s %s (%s)", model.TireSeasonText[target.Season], target.Brand, target.Model, target.Size),
+	}
+	if err := s.maintenanceRepo.Create(maintenance); err != nil {
+		return nil, fmt.Errorf("fehler beim erstellen des wartungseintrags: %v", err)
+	}
+	result.Maintenance = maintenance
+
+	if input.Mileage > 0 {
+		if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicle.ID.Hex()); err != nil {
+			log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Reifenwechsel: %v", err)
+		}
+	}
+	if err := s.planService.CompleteFromMaintenance(maintenance); err != nil {
+		log.Printf("Fehler beim Zurücksetzen der Wartungspläne: %v", err)
+	}
+	s.completeCampaignItem(vehicle.ID, target.Season, date)
+
+	s.activityService.LogActivity(
+		"tire_swap",
+		fmt.Sprintf("Reifenwechsel an %s: %s %s %s montiert", vehicle.LicensePlate, model.TireSeasonText[target.Season], target.Brand, target.Model),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return result, nil
+}
+
+// GetWarnings prüft alle aktiven Reifensätze gegen Mindestprofiltiefe und Altersgrenze
+func (s *TireService) GetWarnings() ([]model.TireWarning, error) {
+	settings, err := s.tireRepo.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	sets, err := s.tireRepo.FindSets(model.TireSetFilter{})
+	if err != nil {
+		return nil, err
+	}
+	plates, err := s.licensePlates()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	warnings := []model.TireWarning{}
+	for _, set := range sets {
+		for _, warning := range tireWarnings(set, settings, now) {
+			if warning.VehicleID != nil {
+				warning.LicensePlate = plates[*warning.VehicleID]
+			}
+			warnings = append(warnings, warning)
+		}
+	}
+
+	// Gesetzliche Verstöße zuerst, dann montierte vor eingelagerten Sätzen
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if rank, other := tireWarningRank(warnings[i].Type), tireWarningRank(warnings[j].Type); rank != other {
+			return rank < other
+		}
+		return warnings[i].Location == model.TireLocationVehicle && warnings[j].Location != model.TireLocationVehicle
+	})
+	return warnings, nil
+}
+
+// GetSetWarnings liefert die Warnungen eines einzelnen Reifensatzes
+func (s *TireService) GetSetWarnings(set *model.TireSet) ([]model.TireWarning, error) {
+	settings, err := s.tireRepo.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	return tireWarnings(set, settings, time.Now()), nil
+}
+
+// GetSettings liefert die Reifenvorgaben
+func (s *TireService) GetSettings() (*model.TireSettings, error) {
+	return s.tireRepo.GetSettings()
+}
+
+// SaveSettings prüft und speichert die Reifenvorgaben
+func (s *TireService) SaveSettings(user *model.User, settings *model.TireSettings) error {
+	if settings.MinTreadDepthSummer < model.LegalMinTreadDepth || settings.MinTreadDepthWinter < model.LegalMinTreadDepth {
+		return fmt.Errorf("die mindestprofiltiefe darf die gesetzlichen %.1f mm nicht unterschreiten", model.LegalMinTreadDepth)
+	}
+	if settings.MaxAgeYears < 0 {
+		return fmt.Errorf("die altersgrenze darf nicht negativ sein")
+	}
+	if settings.SwapsPerDay < 1 {
+		return fmt.Errorf("es muss mindestens ein wechsel pro tag möglich sein")
+	}
+	for _, window := range [][2]string{
+		{settings.SpringWindowStart, settings.SpringWindowEnd},
+		{settings.AutumnWindowStart, settings.AutumnWindowEnd},
+	} {
+		start, errStart := time.Parse("01-02", window[0])
+		end, errEnd := time.Parse("01-02", window[1])
+		if errStart != nil || errEnd != nil {
+			return fmt.Errorf("zeitfenster müssen im format MM-TT angegeben werden")
+		}
+		if end.Before(start) {
+			return fmt.Errorf("das zeitfenster %s bis %s endet vor seinem beginn", window[0], window[1])
+		}
+	}
+
+	if err := s.tireRepo.SaveSettings(settings); err != nil {
+		return fmt.Errorf("fehler beim speichern der reifenvorgaben: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_settings_updated",
+		fmt.Sprintf("Reifenvorgaben geändert: Sommer %.1f mm, Winter %.1f mm, max. %d Jahre",
+			settings.MinTreadDepthSummer, settings.MinTreadDepthWinter, settings.MaxAgeYears),
+		user.ID,
+		nil,
+	)
+	return nil
+}
+
+// GetCampaigns liefert alle Wechselaktionen
+func (s *TireService) GetCampaigns() ([]*model.TireSwapCampaign, error) {
+	return s.tireRepo.FindCampaigns()
+}
+
+// GetCampaign liefert eine Wechselaktion
+func (s *TireService) GetCampaign(id string) (*model.TireSwapCampaign, error) {
+	campaign, err := s.tireRepo.FindCampaignByID(id)
+	if err != nil {
+		return nil, ErrTireCampaignNotFound
+	}
+	return campaign, nil
+}
+
+// PlanCampaign plant die Wechselaktion im Frühjahr oder Herbst eines Jahres: Jedes Fahrzeug,
+// das noch nicht auf die Zielsaison umgerüstet ist, erhält einen Termin im Zeitfenster der
+// Reifenvorgaben. Fehlende Sätze und Warnungen zum einzulagernden Satz werden als Hinweis vermerkt.
+func (s *TireService) PlanCampaign(user *model.User, window model.TireSwapWindow, year int) (*model.TireSwapCampaign, error) {
+	if window != model.TireSwapSpring && window != model.TireSwapAutumn {
+		return nil, fmt.Errorf("ungültiges zeitfenster, erlaubt sind spring und autumn")
+	}
+	if year < 2000 || year > 2100 {
+		return nil, fmt.Errorf("ungültiges jahr %d", year)
+	}
+
+	campaigns, err := s.tireRepo.FindCampaigns()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range campaigns {
+		if existing.Window == window && existing.Year == year {
+			return nil, fmt.Errorf("%w: für dieses zeitfenster gibt es bereits eine wechselaktion", ErrTireState)
+		}
+	}
+
+	settings, err := s.tireRepo.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	startValue, endValue := settings.SpringWindowStart, settings.SpringWindowEnd
+	if window == model.TireSwapAutumn {
+		startValue, endValue = settings.AutumnWindowStart, settings.AutumnWindowEnd
+	}
+	start, err := campaignDate(startValue, year)
+	if err != nil {
+		return nil, err
+	}
+	end, err := campaignDate(endValue, year)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(vehicles, func(i, j int) bool { return vehicles[i].LicensePlate < vehicles[j].LicensePlate })
+	sets, err := s.tireRepo.FindSets(model.TireSetFilter{})
+	if err != nil {
+		return nil, err
+	}
+	setsByVehicle := make(map[primitive.ObjectID][]*model.TireSet)
+	for _, set := range sets {
+		if set.VehicleID != nil {
+			setsByVehicle[*set.VehicleID] = append(setsByVehicle[*set.VehicleID], set)
+		}
+	}
+
+	target := window.TargetSeason()
+	now := time.Now()
+	campaign := &model.TireSwapCampaign{
+		Window:       window,
+		Year:         year,
+		TargetSeason: target,
+		StartDate:    start,
+		EndDate:      end,
+		Items:        []model.TireSwapItem{},
+		CreatedBy:    user.ID,
+	}
+
+	for _, vehicle := range vehicles {
+		var current, replacement *model.TireSet
+		for _, set := range setsByVehicle[vehicle.ID] {
+			switch {
+			case set.Location == model.TireLocationVehicle:
+				current = set
+			case set.Season == target && (replacement == nil || tireDepth(set) > tireDepth(replacement)):
+				replacement = set
+			}
+		}
+		// Ganzjahresreifen und bereits umgerüstete Fahrzeuge brauchen keinen Wechsel
+		if current != nil && (current.Season == model.TireSeasonAllSeason || current.Season == target) {
+			continue
+		}
+
+		item := model.TireSwapItem{
+			VehicleID:    vehicle.ID,
+			LicensePlate: vehicle.LicensePlate,
+			Status:       model.TireSwapPlanned,
+			Issues:       []string{},
+		}
+		if current != nil {
+			item.CurrentSetID = &current.ID
+		}
+		if replacement != nil {
+			item.TargetSetID = &replacement.ID
+			for _, warning := range tireWarnings(replacement, settings, start) {
+				item.Issues = append(item.Issues, warning.Message)
+			}
+		} else if len(setsByVehicle[vehicle.ID]) == 0 {
+			item.Issues = append(item.Issues, "Keine Reifensätze erfasst")
+		} else {
+			item.Issues = append(item.Issues, fmt.Sprintf("Kein eingelagerter Satz %s vorhanden", model.TireSeasonText[target]))
+		}
+		campaign.Items = append(campaign.Items, item)
+	}
+
+	scheduleCampaignItems(campaign, settings.SwapsPerDay, now)
+
+	if err := s.tireRepo.CreateCampaign(campaign); err != nil {
+		return nil, fmt.Errorf("fehler beim anlegen der wechselaktion: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_campaign_planned",
+		fmt.Sprintf("Reifenwechselaktion auf %s %d geplant: %d Fahrzeuge vom %s bis %s",
+			model.TireSeasonText[target], year, len(campaign.Items), start.Format("02.01.2006"), end.Format("02.01.2006")),
+		user.ID,
+		nil,
+	)
+
+	return campaign, nil
+}
+
+// UpdateCampaignItem verschiebt einen geplanten Wechsel oder setzt ihn aus
+func (s *TireService) UpdateCampaignItem(user *model.User, campaignID, vehicleID string, input TireCampaignItemInput) (*model.TireSwapCampaign, error) {
+	campaign, err := s.GetCampaign(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	objID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("ungültige fahrzeug-id")
+	}
+
+	index := -1
+	for i := range campaign.Items {
+		if campaign.Items[i].VehicleID == objID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("das fahrzeug ist nicht teil der wechselaktion")
+	}
+	item := &campaign.Items[index]
+	if item.Status == model.TireSwapDone {
+		return nil, fmt.Errorf("%w: der reifenwechsel ist bereits erledigt", ErrTireState)
+	}
+
+	switch input.Status {
+	case "":
+	case model.TireSwapPlanned, model.TireSwapSkipped:
+		item.Status = input.Status
+	default:
+		return nil, fmt.Errorf("ungültiger status, erlaubt sind planned und skipped")
+	}
+	if input.ScheduledDate != nil {
+		item.ScheduledDate = calendarDay(*input.ScheduledDate)
+	}
+
+	if err := s.tireRepo.UpdateCampaign(campaign); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren der wechselaktion: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"tire_campaign_updated",
+		fmt.Sprintf("Reifenwechsel %s: %s am %s", item.LicensePlate, item.Status, item.ScheduledDate.Format("02.01.2006")),
+		user.ID,
+		&item.VehicleID,
+	)
+
+	return campaign, nil
+}
+
+// completeCampaignItem markiert den offenen Wechsel des Fahrzeugs auf die Saison als erledigt
+func (s *TireService) completeCampaignItem(vehicleID primitive.ObjectID, season model.TireSeason, date time.Time) {
+	campaigns, err := s.tireRepo.FindCampaigns()
+	if err != nil {
+		log.Printf("Fehler beim Laden der Wechselaktionen: %v", err)
+		return
+	}
+	for _, campaign := range campaigns {
+		if campaign.TargetSeason != season {
+			continue
+		}
+		for i := range campaign.Items {
+			item := &campaign.Items[i]
+			if item.VehicleID != vehicleID || item.Status == model.TireSwapDone {
+				continue
+			}
+			item.Status = model.TireSwapDone
+			item.CompletedAt = &date
+			if err := s.tireRepo.UpdateCampaign(campaign); err != nil {
+				log.Printf("Fehler beim Aktualisieren der Wechselaktion: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// licensePlates liefert die Kennzeichen aller Fahrzeuge
+func (s *TireService) licensePlates() (map[primitive.ObjectID]string, error) {
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	plates := make(map[primitive.ObjectID]string, len(vehicles))
+	for _, vehicle := range vehicles {
+		plates[vehicle.ID] = vehicle.LicensePlate
+	}
+	return plates, nil
+}
+
+// applyInput übernimmt die Stammdaten in den Reifensatz und liefert das zugeordnete Fahrzeug
+func (s *TireService) applyInput(set *model.TireSet, input TireSetInput) (*model.Vehicle, error) {
+	if _, ok := model.TireSeasonText[input.Season]; !ok {
+		return nil, fmt.Errorf("ungültige saison, erlaubt sind summer, winter und all_season")
+	}
+	set.Season = input.Season
+	set.Brand = strings.TrimSpace(input.Brand)
+	set.Model = strings.TrimSpace(input.Model)
+	set.Size = strings.TrimSpace(input.Size)
+	set.RimType = strings.TrimSpace(input.RimType)
+	set.StorageDetails = strings.TrimSpace(input.StorageDetails)
+	set.Retired = input.Retired
+	set.Notes = strings.TrimSpace(input.Notes)
+	if set.Brand == "" {
+		return nil, fmt.Errorf("die marke ist erforderlich")
+	}
+
+	set.DOTCode = strings.TrimSpace(input.DOTCode)
+	set.ProductionDate = nil
+	if set.DOTCode != "" {
+		produced, err := model.ParseDOTDate(set.DOTCode)
+		if err != nil {
+			return nil, err
+		}
+		if produced.After(time.Now()) {
+			return nil, fmt.Errorf("das dot-datum %s liegt in der zukunft", set.DOTCode)
+		}
+		set.ProductionDate = &produced
+	}
+
+	set.VehicleID = nil
+	if input.VehicleID == "" {
+		if set.Size == "" {
+			return nil, fmt.Errorf("die reifengröße ist erforderlich")
+		}
+		return nil, nil
+	}
+	vehicle, err := s.vehicleRepo.FindByID(input.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	set.VehicleID = &vehicle.ID
+	if set.Size == "" {
+		set.Size = vehicle.TireSize
+	}
+	if set.RimType == "" {
+		set.RimType = vehicle.RimType
+	}
+	if set.Size == "" {
+		return nil, fmt.Errorf("die reifengröße ist erforderlich")
+	}
+	return vehicle, nil
+}
+
+// tireWarnings prüft einen Reifensatz gegen gesetzliche Mindestprofiltiefe und Firmenvorgaben
+func tireWarnings(set *model.TireSet, settings *model.TireSettings, now time.Time) []model.TireWarning {
+	if set.Retired {
+		return nil
+	}
+
+	var warnings []model.TireWarning
+	newWarning := func(warningType model.TireWarningType, message string, value, limit float64) {
+		warnings = append(warnings, model.TireWarning{
+			TireSetID: set.ID,
+			VehicleID: set.VehicleID,
+			Season:    set.Season,
+			Location:  set.Location,
+			Type:      warningType,
+			Message:   message,
+			Value:     value,
+			Limit:     limit,
+		})
+	}
+
+	if latest := set.LatestMeasurement(); latest == nil {
+		newWarning(model.TireWarningNoMeasure, fmt.Sprintf("%s %s: noch keine Profilmessung erfasst", set.Brand, set.Model), 0, 0)
+	} else {
+		depth := latest.MinDepth()
+		companyMin := settings.MinTreadDepthWinter
+		if set.Season == model.TireSeasonSummer {
+			companyMin = settings.MinTreadDepthSummer
+		}
+		switch {
+		case depth < model.LegalMinTreadDepth:
+			newWarning(model.TireWarningTreadLegal,
+				fmt.Sprintf("%s %s: Profiltiefe %.1f mm unter dem gesetzlichen Minimum von %.1f mm", set.Brand, set.Model, depth, model.LegalMinTreadDepth),
+				depth, model.LegalMinTreadDepth)
+		case depth < companyMin:
+			newWarning(model.TireWarningTreadCompany,
+				fmt.Sprintf("%s %s: Profiltiefe %.1f mm unter der Firmenvorgabe von %.1f mm", set.Brand, set.Model, depth, companyMin),
+				depth, companyMin)
+		}
+	}
+
+	if settings.MaxAgeYears > 0 && set.ProductionDate != nil {
+		if age := set.AgeYears(now); age >= float64(settings.MaxAgeYears) {
+			newWarning(model.TireWarningAge,
+				fmt.Sprintf("%s %s: Reifen sind %.1f Jahre alt (DOT %s), Firmenvorgabe max. %d Jahre", set.Brand, set.Model, age, set.DOTCode, settings.MaxAgeYears),
+				age, float64(settings.MaxAgeYears))
+		}
+	}
+
+	return warnings
+}
+
+// tireWarningRank legt die Reihenfolge der Warnungen fest
+func tireWarningRank(warningType model.TireWarningType) int {
+	switch warningType {
+	case model.TireWarningTreadLegal:
+		return 0
+	case model.TireWarningTreadCompany:
+		return 1
+	case model.TireWarningAge:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// tireDepth liefert die geringste Profiltiefe der letzten Messung (ohne Messung 0)
+func tireDepth(set *model.TireSet) float64 {
+	if latest := set.LatestMeasurement(); latest != nil {
+		return latest.MinDepth()
+	}
+	return 0
+}
+
+// closeTireSeasonUsage schließt die laufende Saison eines Satzes ab und schreibt die Laufleistung fort
+func closeTireSeasonUsage(set *model.TireSet, date time.Time, mileage int) {
+	for i := range set.SeasonUsage {
+		usage := &set.SeasonUsage[i]
+		if usage.UnmountedAt != nil {
+			continue
+		}
+		usage.UnmountedAt = &date
+		usage.UnmountedMileage = mileage
+		usage.Distance = max(0, mileage-usage.MountedMileage)
+		set.TotalMileage += usage.Distance
+	}
+}
+
+// tireSeasonLabel benennt die Saison, in der ein Satz montiert wird (z.B. "Winter 2026/27")
+func tireSeasonLabel(season model.TireSeason, date time.Time) string {
+	year := date.Year()
+	switch season {
+	case model.TireSeasonWinter:
+		if date.Month() < time.July {
+			year--
+		}
+		return fmt.Sprintf("Winter %d/%02d", year, (year+1)%100)
+	case model.TireSeasonSummer:
+		return fmt.Sprintf("Sommer %d", year)
+	default:
+		return fmt.Sprintf("Ganzjahr %d", year)
+	}
+}
+
+// campaignDate wandelt ein Datum im Format MM-TT in einen Tag des angegebenen Jahres um
+func campaignDate(value string, year int) (time.Time, error) {
+	parsed, err := time.Parse("01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ungültiges zeitfenster %s, erwartet wird MM-TT", value)
+	}
+	return time.Date(year, parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// scheduleCampaignItems verteilt die Wechsel auf die Werktage des Zeitfensters. Liegt der Beginn
+// in der Vergangenheit, wird ab morgen geplant; reicht die Kapazität nicht, wird über das Ende
+// hinaus geplant und ein Hinweis vermerkt.
+func scheduleCampaignItems(campaign *model.TireSwapCampaign, perDay int, now time.Time) {
+	day := campaign.StartDate
+	if tomorrow := calendarDay(now).AddDate(0, 0, 1); day.Before(tomorrow) {
+		day = tomorrow
+	}
+	slots := 0
+	for i := range campaign.Items {
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || slots >= perDay {
+			day = day.AddDate(0, 0, 1)
+			if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+				slots = 0
+			}
+		}
+		campaign.Items[i].ScheduledDate = day
+		if day.After(campaign.EndDate) {
+			campaign.Items[i].Issues = append(campaign.Items[i].Issues, "Termin außerhalb des Zeitfensters, Werkstattkapazität reicht nicht aus")
+		}
+		slots++
+	}
+}
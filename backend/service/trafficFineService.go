@@ -0,0 +1,703 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultFineResponseDays ist die Frist zur Benennung des Fahrers, wenn im Schreiben keine angegeben ist
+	defaultFineResponseDays = 7
+	// fineReminderDays ist der Vorlauf der Erinnerung vor Fristablauf
+	fineReminderDays = 3
+	// fineBoundaryTolerance ist der Abstand zu Fahrtbeginn oder -ende, ab dem die Zuordnung als unsicher gilt
+	fineBoundaryTolerance = 30 * time.Minute
+)
+
+var (
+	// ErrTrafficFineNotFound wird für unbekannte Bußgelder zurückgegeben
+	ErrTrafficFineNotFound = errors.New("bußgeld nicht gefunden")
+	// ErrTrafficFineState wird zurückgegeben, wenn das Bußgeld im aktuellen Status nicht geändert werden kann
+	ErrTrafficFineState = errors.New("das bußgeld kann im aktuellen status nicht geändert werden")
+)
+
+// TrafficFineInput enthält die Angaben aus dem Schreiben der Bußgeldstelle
+type TrafficFineInput struct {
+	VehicleID        string // Alternativ LicensePlate
+	LicensePlate     string
+	OffenseTime      time.Time
+	Location         string
+	Offense          string
+	Authority        string
+	ReferenceNumber  string
+	Amount           float64
+	Points           int
+	ReceivedAt       *time.Time // Ohne Angabe heute
+	ResponseDeadline *time.Time // Ohne Angabe eine Woche nach Eingang
+	Notes            string
+}
+
+// TrafficFineForwardInput enthält die Angaben zur Rückmeldung an die Bußgeldstelle
+type TrafficFineForwardInput struct {
+	ForwardedTo string
+	Date        *time.Time // Ohne Angabe jetzt
+	Notes       string
+}
+
+// TrafficFinePaymentInput enthält die Angaben zur Zahlung eines Bußgelds
+type TrafficFinePaymentInput struct {
+	Amount float64 // Ohne Angabe Betrag des Bescheids
+	Date   *time.Time
+	PaidBy model.TrafficFinePayer
+}
+
+// TrafficFineService verwaltet Bußgelder. Der Fahrer zum Tatzeitpunkt wird aus Fahrzeugnutzungen,
+// Reservierungen und festen Zuweisungen ermittelt; widersprüchliche Treffer werden nicht automatisch
+// zugeordnet, sondern mit Hinweisen zur manuellen Entscheidung vorgelegt.
+type TrafficFineService struct {
+	fineRepo            repository.TrafficFineRepository
+	vehicleRepo         repository.VehicleRepository
+	driverRepo          repository.DriverRepository
+	reservationRepo     repository.VehicleReservationRepository
+	usageRepo           repository.VehicleUsageRepository
+	assignmentRepo      repository.VehicleAssignmentRepository
+	notificationService *NotificationService
+	activityService     *ActivityService
+}
+
+// NewTrafficFineService erstellt einen neuen TrafficFineService
+func NewTrafficFineService(fineRepo repository.TrafficFineRepository, vehicleRepo repository.VehicleRepository, driverRepo repository.DriverRepository, reservationRepo repository.VehicleReservationRepository, usageRepo repository.VehicleUsageRepository, assignmentRepo repository.VehicleAssignmentRepository, notificationService *NotificationService, activityService *ActivityService) *TrafficFineService {
+	return &TrafficFineService{
+		fineRepo:            fineRepo,
+		vehicleRepo:         vehicleRepo,
+		driverRepo:          driverRepo,
+		reservationRepo:     reservationRepo,
+		usageRepo:           usageRepo,
+		assignmentRepo:      assignmentRepo,
+		notificationService: notificationService,
+		activityService:     activityService,
+	}
+}
+
+// GetFines liefert alle Bußgelder zum Filter, auf Wunsch nur die mit abgelaufener Frist
+func (s *TrafficFineService) GetFines(filter model.TrafficFineFilter, overdueOnly bool) ([]*model.TrafficFine, error) {
+	fines, err := s.fineRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	if !overdueOnly {
+		return fines, nil
+	}
+
+	now := time.Now()
+	overdue := []*model.TrafficFine{}
+	for _, fine := range fines {
+		if fine.IsOverdue(now) {
+			overdue = append(overdue, fine)
+		}
+	}
+	return overdue, nil
+}
+
+// GetFine liefert ein Bußgeld
+func (s *TrafficFineService) GetFine(id string) (*model.TrafficFine, error) {
+	fine, err := s.fineRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrTrafficFineNotFound
+	}
+	return fine, nil
+}
+
+// ResolveDriverByPlate ermittelt den Fahrer eines Fahrzeugs (ID oder Kennzeichen) zu einem Zeitpunkt
+func (s *TrafficFineService) ResolveDriverByPlate(vehicleID, licensePlate string, at time.Time) (*model.DriverResolution, error) {
+	vehicle, err := s.findVehicle(vehicleID, licensePlate)
+	if err != nil {
+		return nil, err
+	}
+	return s.ResolveDriver(vehicle.ID, at)
+}
+
+// ResolveDriver ermittelt, wer das Fahrzeug zum Zeitpunkt geführt hat. Nutzungen und Reservierungen
+// gehen einer festen Zuweisung vor; verweisen sie auf verschiedene Fahrer, ist das Ergebnis mehrdeutig.
+func (s *TrafficFineService) ResolveDriver(vehicleID primitive.ObjectID, at time.Time) (*model.DriverResolution, error) {
+	result := &model.DriverResolution{
+		VehicleID:  vehicleID,
+		At:         at,
+		Candidates: []model.DriverCandidate{},
+		Warnings:   []string{},
+	}
+	names := make(map[primitive.ObjectID]string)
+	nameOf := func(driverID primitive.ObjectID) string {
+		if name, ok := names[driverID]; ok {
+			return name
+		}
+		name := driverID.Hex()
+		if driver, err := s.driverRepo.FindByID(driverID.Hex()); err == nil {
+			name = driver.FirstName + " " + driver.LastName
+		}
+		names[driverID] = name
+		return name
+	}
+	nearBoundary := func(label string, from time.Time, to *time.Time, driverID primitive.ObjectID) {
+		if at.Sub(from) < fineBoundaryTolerance {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s von %s beginnt nur %d Minuten vor dem Tatzeitpunkt",
+				label, nameOf(driverID), int(at.Sub(from).Minutes())))
+		}
+		if to != nil && to.Sub(at) < fineBoundaryTolerance {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s von %s endet nur %d Minuten nach dem Tatzeitpunkt",
+				label, nameOf(driverID), int(to.Sub(at).Minutes())))
+		}
+	}
+
+	usages, err := s.usageRepo.FindByVehicle(vehicleID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	for _, usage := range usages {
+		if usage.Status == model.UsageStatusCancelled || at.Before(usage.StartDate) {
+			continue
+		}
+		var end *time.Time
+		if usage.Status != model.UsageStatusActive && !usage.EndDate.IsZero() {
+			endDate := usage.EndDate
+			end = &endDate
+		}
+		if end != nil && at.After(*end) {
+			continue
+		}
+		result.Candidates = append(result.Candidates, model.DriverCandidate{
+			DriverID: usage.DriverID, DriverName: nameOf(usage.DriverID),
+			Source: model.DriverSourceUsage, SourceID: usage.ID, From: usage.StartDate, To: end,
+		})
+		nearBoundary("Fahrzeugnutzung", usage.StartDate, end, usage.DriverID)
+	}
+
+	reservations, err := s.reservationRepo.FindByVehicleID(vehicleID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	for _, reservation := range reservations {
+		switch reservation.Status {
+		case model.ReservationStatusApproved, model.ReservationStatusActive, model.ReservationStatusCompleted:
+		default:
+			continue
+		}
+		if at.Before(reservation.StartTime) || at.After(reservation.EndTime) {
+			continue
+		}
+		end := reservation.EndTime
+		result.Candidates = append(result.Candidates, model.DriverCandidate{
+			DriverID: reservation.DriverID, DriverName: nameOf(reservation.DriverID),
+			Source: model.DriverSourceReservation, SourceID: reservation.ID, From: reservation.StartTime, To: &end,
+		})
+		nearBoundary("Reservierung", reservation.StartTime, &end, reservation.DriverID)
+	}
+
+	assignments, err := s.assignmentRepo.FindByVehicleID(vehicleID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		if assignment.Type == model.AssignmentTypeUnassigned || at.Before(assignment.AssignedAt) {
+			continue
+		}
+		if assignment.UnassignedAt != nil && at.After(*assignment.UnassignedAt) {
+			continue
+		}
+		result.Candidates = append(result.Candidates, model.DriverCandidate{
+			DriverID: assignment.DriverID, DriverName: nameOf(assignment.DriverID),
+			Source: model.DriverSourceAssignment, SourceID: assignment.ID, From: assignment.AssignedAt, To: assignment.UnassignedAt,
+		})
+	}
+
+	// Fahrer aus Nutzungen und Reservierungen haben Vorrang vor festen Zuweisungen
+	var tripDrivers, assignedDrivers []primitive.ObjectID
+	for _, candidate := range result.Candidates {
+		if candidate.Source == model.DriverSourceAssignment {
+			if !containsObjectID(assignedDrivers, candidate.DriverID) {
+				assignedDrivers = append(assignedDrivers, candidate.DriverID)
+			}
+		} else if !containsObjectID(tripDrivers, candidate.DriverID) {
+			tripDrivers = append(tripDrivers, candidate.DriverID)
+		}
+	}
+
+	switch {
+	case len(tripDrivers) == 1:
+		result.DriverID = &tripDrivers[0]
+		// Eine erfasste Fahrt ist belastbarer als die Reservierung
+		result.Source = model.DriverSourceReservation
+		for _, candidate := range result.Candidates {
+			if candidate.Source == model.DriverSourceUsage {
+				result.Source = model.DriverSourceUsage
+			}
+		}
+		for _, driverID := range assignedDrivers {
+			if driverID != tripDrivers[0] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("Das Fahrzeug war zugleich %s fest zugewiesen", nameOf(driverID)))
+			}
+		}
+	case len(tripDrivers) > 1:
+		result.Ambiguous = true
+		labels := make([]string, 0, len(tripDrivers))
+		for _, driverID := range tripDrivers {
+			labels = append(labels, nameOf(driverID))
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Mehrere Fahrer zum Tatzeitpunkt: %s", strings.Join(labels, ", ")))
+	case len(assignedDrivers) == 1:
+		result.DriverID = &assignedDrivers[0]
+		result.Source = model.DriverSourceAssignment
+		result.Warnings = append(result.Warnings, "Nur über die feste Zuweisung ermittelt, keine Fahrt oder Reservierung zum Tatzeitpunkt")
+	case len(assignedDrivers) > 1:
+		result.Ambiguous = true
+		result.Warnings = append(result.Warnings, "Das Fahrzeug war zum Tatzeitpunkt mehreren Fahrern fest zugewiesen")
+	default:
+		result.Warnings = append(result.Warnings, "Zum Tatzeitpunkt ist keine Nutzung, Reservierung oder Zuweisung erfasst")
+	}
+
+	sort.SliceStable(result.Candidates, func(i, j int) bool { return result.Candidates[i].From.Before(result.Candidates[j].From) })
+	return result, nil
+}
+
+// CreateFine erfasst ein Bußgeld und ermittelt den Fahrer zum Tatzeitpunkt
+func (s *TrafficFineService) CreateFine(user *model.User, input TrafficFineInput) (*model.TrafficFine, error) {
+	fine := &model.TrafficFine{
+		Status:    model.TrafficFineStatusOpen,
+		CreatedBy: user.ID,
+	}
+	if err := s.applyInput(fine, input); err != nil {
+		return nil, err
+	}
+	if err := s.resolve(fine); err != nil {
+		return nil, err
+	}
+
+	if err := s.fineRepo.Create(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim anlegen des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_created",
+		fmt.Sprintf("Bußgeld zu %s vom %s erfasst (%.2f €), Frist %s",
+			fine.LicensePlate, fine.OffenseTime.In(berlinLocation()).Format("02.01.2006 15:04"), fine.Amount, fine.ResponseDeadline.Format("02.01.2006")),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// UpdateFine ändert die Angaben eines Bußgelds, solange der Behörde noch kein Fahrer benannt wurde.
+// Ändern sich Fahrzeug oder Tatzeit, wird der Fahrer neu ermittelt, außer er wurde manuell festgelegt.
+func (s *TrafficFineService) UpdateFine(user *model.User, id string, input TrafficFineInput) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if !fine.AwaitsResponse() {
+		return nil, ErrTrafficFineState
+	}
+
+	previousVehicle, previousTime := fine.VehicleID, fine.OffenseTime
+	if err := s.applyInput(fine, input); err != nil {
+		return nil, err
+	}
+	if fine.VehicleID != previousVehicle || !fine.OffenseTime.Equal(previousTime) {
+		if err := s.resolve(fine); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_updated",
+		fmt.Sprintf("Bußgeld %s zu %s geändert", fine.ReferenceNumber, fine.LicensePlate),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// DeleteFine löscht ein versehentlich erfasstes Bußgeld
+func (s *TrafficFineService) DeleteFine(user *model.User, id string) error {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return err
+	}
+	if err := s.fineRepo.Delete(id); err != nil {
+		return fmt.Errorf("fehler beim löschen des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_deleted",
+		fmt.Sprintf("Bußgeld %s zu %s gelöscht", fine.ReferenceNumber, fine.LicensePlate),
+		user.ID,
+		&fine.VehicleID,
+	)
+	return nil
+}
+
+// AssignDriver legt den Fahrer manuell fest, z.B. bei mehrdeutiger Ermittlung
+func (s *TrafficFineService) AssignDriver(user *model.User, id, driverID string) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if !fine.AwaitsResponse() {
+		return nil, ErrTrafficFineState
+	}
+	driver, err := s.driverRepo.FindByID(driverID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrer nicht gefunden")
+	}
+
+	changed := fine.DriverID == nil || *fine.DriverID != driver.ID
+	fine.DriverID = &driver.ID
+	fine.DriverSource = model.DriverSourceManual
+	if changed {
+		// Ein anderer Fahrer muss erneut informiert werden
+		fine.Status = model.TrafficFineStatusOpen
+		fine.NotifiedAt = nil
+		fine.ReminderSentAt = nil
+	}
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_driver_assigned",
+		fmt.Sprintf("Bußgeld %s zu %s: Fahrer %s %s festgelegt", fine.ReferenceNumber, fine.LicensePlate, driver.FirstName, driver.LastName),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// NotifyDriver informiert den zugeordneten Fahrer per E-Mail über das Bußgeld
+func (s *TrafficFineService) NotifyDriver(user *model.User, id string) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if !fine.AwaitsResponse() {
+		return nil, ErrTrafficFineState
+	}
+	if fine.DriverID == nil {
+		return nil, fmt.Errorf("dem bußgeld ist noch kein fahrer zugeordnet")
+	}
+
+	driver, err := s.driverRepo.FindByID(fine.DriverID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrer nicht gefunden")
+	}
+	vehicle, err := s.vehicleRepo.FindByID(fine.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+	if err := s.notificationService.NotifyTrafficFine(fine, vehicle, driver, false); err != nil {
+		return nil, fmt.Errorf("fehler beim benachrichtigen des fahrers: %v", err)
+	}
+
+	now := time.Now()
+	fine.NotifiedAt = &now
+	fine.Status = model.TrafficFineStatusNotified
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_driver_notified",
+		fmt.Sprintf("Fahrer %s %s über Bußgeld zu %s informiert", driver.FirstName, driver.LastName, fine.LicensePlate),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// ForwardFine vermerkt, dass der Fahrer der Bußgeldstelle benannt wurde
+func (s *TrafficFineService) ForwardFine(user *model.User, id string, input TrafficFineForwardInput) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if !fine.AwaitsResponse() {
+		return nil, ErrTrafficFineState
+	}
+	if fine.DriverID == nil {
+		return nil, fmt.Errorf("vor der rückmeldung an die behörde muss ein fahrer zugeordnet sein")
+	}
+
+	forwardedAt := time.Now()
+	if input.Date != nil {
+		forwardedAt = *input.Date
+	}
+	fine.ForwardedAt = &forwardedAt
+	fine.ForwardedTo = strings.TrimSpace(input.ForwardedTo)
+	if fine.ForwardedTo == "" {
+		fine.ForwardedTo = fine.Authority
+	}
+	fine.ResponseNotes = strings.TrimSpace(input.Notes)
+	fine.Status = model.TrafficFineStatusForwarded
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	late := ""
+	if forwardedAt.After(fine.ResponseDeadline) {
+		late = " (nach Fristablauf)"
+	}
+	s.activityService.LogActivity(
+		"traffic_fine_forwarded",
+		fmt.Sprintf("Fahrer zu Bußgeld %s an %s gemeldet%s", fine.ReferenceNumber, fine.ForwardedTo, late),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// RecordPayment vermerkt die Zahlung des Bußgelds
+func (s *TrafficFineService) RecordPayment(user *model.User, id string, input TrafficFinePaymentInput) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if fine.Status == model.TrafficFineStatusPaid || fine.Status == model.TrafficFineStatusCancelled {
+		return nil, ErrTrafficFineState
+	}
+	if input.Amount < 0 {
+		return nil, fmt.Errorf("der betrag darf nicht negativ sein")
+	}
+
+	switch input.PaidBy {
+	case "":
+		input.PaidBy = model.TrafficFinePayerDriver
+	case model.TrafficFinePayerDriver, model.TrafficFinePayerCompany:
+	default:
+		return nil, fmt.Errorf("ungültiger zahler, erlaubt sind driver und company")
+	}
+
+	paidAt := time.Now()
+	if input.Date != nil {
+		paidAt = *input.Date
+	}
+	fine.PaidAt = &paidAt
+	fine.PaidAmount = roundCents(input.Amount)
+	if fine.PaidAmount == 0 {
+		fine.PaidAmount = fine.Amount
+	}
+	fine.PaidBy = input.PaidBy
+	fine.Status = model.TrafficFineStatusPaid
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_paid",
+		fmt.Sprintf("Bußgeld %s zu %s bezahlt: %.2f € (%s)", fine.ReferenceNumber, fine.LicensePlate, fine.PaidAmount, fine.PaidBy),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// CancelFine vermerkt die Einstellung des Verfahrens
+func (s *TrafficFineService) CancelFine(user *model.User, id, notes string) (*model.TrafficFine, error) {
+	fine, err := s.GetFine(id)
+	if err != nil {
+		return nil, err
+	}
+	if fine.Status == model.TrafficFineStatusPaid || fine.Status == model.TrafficFineStatusCancelled {
+		return nil, ErrTrafficFineState
+	}
+
+	fine.Status = model.TrafficFineStatusCancelled
+	if notes = strings.TrimSpace(notes); notes != "" {
+		fine.Notes = strings.TrimSpace(fine.Notes + "\n" + notes)
+	}
+	if err := s.fineRepo.Update(fine); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des bußgelds: %v", err)
+	}
+
+	s.activityService.LogActivity(
+		"traffic_fine_cancelled",
+		fmt.Sprintf("Verfahren zu Bußgeld %s (%s) eingestellt", fine.ReferenceNumber, fine.LicensePlate),
+		user.ID,
+		&fine.VehicleID,
+	)
+
+	return fine, nil
+}
+
+// RunDeadlineReminders erinnert kurz vor Fristablauf einmalig an Bußgelder ohne Rückmeldung:
+// den zugeordneten Fahrer oder, wenn noch keiner feststeht, die Manager
+func (s *TrafficFineService) RunDeadlineReminders(run JobRunContext) error {
+	fines, err := s.fineRepo.Find(model.TrafficFineFilter{
+		Statuses: []model.TrafficFineStatus{model.TrafficFineStatusOpen, model.TrafficFineStatusNotified},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reminderFrom := calendarDay(now).AddDate(0, 0, fineReminderDays)
+	sent, failed := 0, 0
+	for _, fine := range fines {
+		if fine.ReminderSentAt != nil || fine.ResponseDeadline.After(reminderFrom) {
+			continue
+		}
+		vehicle, err := s.vehicleRepo.FindByID(fine.VehicleID.Hex())
+		if err != nil {
+			failed++
+			continue
+		}
+
+		if fine.DriverID != nil {
+			driver, err := s.driverRepo.FindByID(fine.DriverID.Hex())
+			if err == nil {
+				err = s.notificationService.NotifyTrafficFine(fine, vehicle, driver, true)
+			}
+			if err != nil {
+				log.Printf("Fehler bei der Bußgeld-Erinnerung %s: %v", fine.ID.Hex(), err)
+				failed++
+				continue
+			}
+			if fine.Status == model.TrafficFineStatusOpen {
+				fine.Status = model.TrafficFineStatusNotified
+				fine.NotifiedAt = &now
+			}
+		} else if err := s.notificationService.NotifyUnresolvedTrafficFine(fine, vehicle); err != nil {
+			log.Printf("Fehler bei der Bußgeld-Erinnerung %s: %v", fine.ID.Hex(), err)
+			failed++
+			continue
+		}
+
+		fine.ReminderSentAt = &now
+		if err := s.fineRepo.Update(fine); err != nil {
+			log.Printf("Fehler beim Speichern der Bußgeld-Erinnerung %s: %v", fine.ID.Hex(), err)
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		log.Printf("Bußgeld-Fristen geprüft: %d Erinnerungen versendet", sent)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d bußgeld-erinnerungen konnten nicht versendet werden", failed)
+	}
+	return nil
+}
+
+// resolve ermittelt den Fahrer zum Tatzeitpunkt; ein manuell festgelegter Fahrer bleibt erhalten
+func (s *TrafficFineService) resolve(fine *model.TrafficFine) error {
+	resolution, err := s.ResolveDriver(fine.VehicleID, fine.OffenseTime)
+	if err != nil {
+		return fmt.Errorf("fehler bei der fahrerermittlung: %v", err)
+	}
+	fine.Resolution = *resolution
+	if fine.DriverSource == model.DriverSourceManual {
+		return nil
+	}
+	fine.DriverID = resolution.DriverID
+	fine.DriverSource = resolution.Source
+	return nil
+}
+
+// applyInput übernimmt die Angaben aus dem Schreiben in das Bußgeld
+func (s *TrafficFineService) applyInput(fine *model.TrafficFine, input TrafficFineInput) error {
+	vehicle, err := s.findVehicle(input.VehicleID, input.LicensePlate)
+	if err != nil {
+		return err
+	}
+	if input.OffenseTime.IsZero() {
+		return fmt.Errorf("der tatzeitpunkt ist erforderlich")
+	}
+	if input.OffenseTime.After(time.Now()) {
+		return fmt.Errorf("der tatzeitpunkt darf nicht in der zukunft liegen")
+	}
+	if input.Amount < 0 || input.Points < 0 {
+		return fmt.Errorf("betrag und punkte dürfen nicht negativ sein")
+	}
+
+	receivedAt := calendarDay(time.Now())
+	if input.ReceivedAt != nil {
+		receivedAt = calendarDay(*input.ReceivedAt)
+	}
+	if receivedAt.Before(calendarDay(input.OffenseTime)) {
+		return fmt.Errorf("das schreiben kann nicht vor dem tatzeitpunkt eingegangen sein")
+	}
+	deadline := receivedAt.AddDate(0, 0, defaultFineResponseDays)
+	if input.ResponseDeadline != nil {
+		deadline = calendarDay(*input.ResponseDeadline)
+	}
+	if deadline.Before(receivedAt) {
+		return fmt.Errorf("die frist muss nach dem eingang des schreibens liegen")
+	}
+
+	fine.VehicleID = vehicle.ID
+	fine.LicensePlate = vehicle.LicensePlate
+	fine.OffenseTime = input.OffenseTime
+	fine.Location = strings.TrimSpace(input.Location)
+	fine.Offense = strings.TrimSpace(input.Offense)
+	fine.Authority = strings.TrimSpace(input.Authority)
+	fine.ReferenceNumber = strings.TrimSpace(input.ReferenceNumber)
+	fine.Amount = roundCents(input.Amount)
+	fine.Points = input.Points
+	fine.ReceivedAt = receivedAt
+	fine.ResponseDeadline = deadline
+	fine.Notes = strings.TrimSpace(input.Notes)
+	return nil
+}
+
+// findVehicle sucht das Fahrzeug über die ID oder das Kennzeichen aus dem Schreiben
+func (s *TrafficFineService) findVehicle(vehicleID, licensePlate string) (*model.Vehicle, error) {
+	if vehicleID != "" {
+		vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+		if err != nil {
+			return nil, fmt.Errorf("fahrzeug nicht gefunden")
+		}
+		return vehicle, nil
+	}
+	plate := strings.ToUpper(strings.TrimSpace(licensePlate))
+	if plate == "" {
+		return nil, fmt.Errorf("fahrzeug oder kennzeichen ist erforderlich")
+	}
+	if vehicle, err := s.vehicleRepo.FindByLicensePlate(plate); err == nil && vehicle != nil {
+		return vehicle, nil
+	}
+
+	// Kennzeichen in Schreiben weichen oft in Leer- und Bindestrichen ab
+	vehicles, err := s.vehicleRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	normalized := normalizePlate(plate)
+	for _, vehicle := range vehicles {
+		if normalizePlate(vehicle.LicensePlate) == normalized {
+			return vehicle, nil
+		}
+	}
+	return nil, fmt.Errorf("kein fahrzeug mit kennzeichen %s gefunden", plate)
+}
+
+// normalizePlate entfernt Leer- und Bindestriche aus einem Kennzeichen
+func normalizePlate(plate string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(plate))
+}
@@ -5,24 +5,31 @@ import (
 	"log"
 	"time"
 
+	"FleetFlow/backend/model"
 	"FleetFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // VehicleMileageService verwaltet die Kilometerstand-Logik für Fahrzeuge
 type VehicleMileageService struct {
-	vehicleRepo     *repository.VehicleRepository
-	maintenanceRepo *repository.MaintenanceRepository
-	usageRepo       *repository.VehicleUsageRepository
-	fuelCostRepo    *repository.FuelCostRepository
+	vehicleRepo     repository.VehicleRepository
+	maintenanceRepo repository.MaintenanceRepository
+	usageRepo       repository.VehicleUsageRepository
+	fuelCostRepo    repository.FuelCostRepository
+	logbookRepo     repository.LogbookRepository
+	chargingRepo    repository.ChargingSessionRepository
 }
 
 // NewVehicleMileageService erstellt einen neuen VehicleMileageService
-func NewVehicleMileageService() *VehicleMileageService {
+func NewVehicleMileageService(vehicleRepo repository.VehicleRepository, maintenanceRepo repository.MaintenanceRepository, usageRepo repository.VehicleUsageRepository, fuelCostRepo repository.FuelCostRepository, logbookRepo repository.LogbookRepository, chargingRepo repository.ChargingSessionRepository) *VehicleMileageService {
 	return &VehicleMileageService{
-		vehicleRepo:     repository.NewVehicleRepository(),
-		maintenanceRepo: repository.NewMaintenanceRepository(),
-		usageRepo:       repository.NewVehicleUsageRepository(),
-		fuelCostRepo:    repository.NewFuelCostRepository(),
+		vehicleRepo:     vehicleRepo,
+		maintenanceRepo: maintenanceRepo,
+		usageRepo:       usageRepo,
+		fuelCostRepo:    fuelCostRepo,
+		logbookRepo:     logbookRepo,
+		chargingRepo:    chargingRepo,
 	}
 }
 
@@ -62,8 +69,8 @@ func (s *VehicleMileageService) GetLatestMileageForVehicle(vehicleID string) (*M
 					return
 				}
 				
-				// Wenn das neue Datum neuer ist, aktualisieren
-				if entryDate.After(currentDate) {
+				// Wenn das neue Datum neuer ist, aktualisieren (am selben Tag gilt der höhere Stand)
+				if entryDate.After(currentDate) || (entryDate.Equal(currentDate) && value > latestMileage.Value) {
 					latestMileage = &MileageSource{
 						Value:  value,
 						Source: source,
@@ -124,7 +131,30 @@ func (s *VehicleMileageService) GetLatestMileageForVehicle(vehicleID string) (*M
 		}
 	}
 
-	// 4. Aktueller Fahrzeug-Kilometerstand als Fallback (ohne Datum)
+	// 4. Fahrtenbuch (Endstand der letzten Fahrt)
+	if lastTrip := s.lastLogbookEntry(vehicleID); lastTrip != nil {
+		updateIfNewer(
+			lastTrip.EndMileage,
+			"logbook",
+			lastTrip.Date.Format("2006-01-02"),
+			lastTrip.EntryID.Hex(),
+		)
+	}
+
+	// 5. Ladevorgänge
+	chargingSessions, err := s.chargingRepo.FindByVehicle(vehicleID)
+	if err == nil {
+		for _, session := range chargingSessions {
+			updateIfNewer(
+				session.Mileage,
+				"charging_session",
+				session.StartTime.Format("2006-01-02"),
+				session.ID.Hex(),
+			)
+		}
+	}
+
+	// 6. Aktueller Fahrzeug-Kilometerstand als Fallback (ohne Datum)
 	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
 	if err == nil && vehicle.Mileage > 0 && latestMileage == nil {
 		latestMileage = &MileageSource{
@@ -274,5 +304,72 @@ func (s *VehicleMileageService) GetMileageAnalysisForVehicle(vehicleID string) (
 		}
 	}
 
+	// 5. Fahrtenbuch
+	if lastTrip := s.lastLogbookEntry(vehicleID); lastTrip != nil {
+		allMileages = append(allMileages, MileageSource{
+			Value:  lastTrip.EndMileage,
+			Source: "logbook",
+			Date:   lastTrip.Date.Format("2006-01-02"),
+			ID:     lastTrip.EntryID.Hex(),
+		})
+	}
+
+	// 6. Ladevorgänge
+	chargingSessions, err := s.chargingRepo.FindByVehicle(vehicleID)
+	if err == nil {
+		for _, session := range chargingSessions {
+			if session.Mileage > 0 {
+				allMileages = append(allMileages, MileageSource{
+					Value:  session.Mileage,
+					Source: "charging_session",
+					Date:   session.StartTime.Format("2006-01-02"),
+					ID:     session.ID.Hex(),
+				})
+			}
+		}
+	}
+
 	return allMileages, nil
-}
\ No newline at end of file
+}
+
+// mileageReading ist ein datierter Kilometerstand
+type mileageReading struct {
+	date    time.Time
+	mileage int
+}
+
+// datedReadings liefert alle Kilometerstände mit Datum; der undatierte Stand am Fahrzeug fehlt
+func (s *VehicleMileageService) datedReadings(vehicleID string) []mileageReading {
+	sources, err := s.GetMileageAnalysisForVehicle(vehicleID)
+	if err != nil {
+		return nil
+	}
+	var readings []mileageReading
+	for _, source := range sources {
+		if source.Date == "" || source.Value <= 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", source.Date)
+		if err != nil {
+			continue
+		}
+		readings = append(readings, mileageReading{date: date, mileage: source.Value})
+	}
+	return readings
+}
+
+// lastLogbookEntry liefert die letzte Fahrt aus dem Fahrtenbuch oder nil
+func (s *VehicleMileageService) lastLogbookEntry(vehicleID string) *model.LogbookEntry {
+	if s.logbookRepo == nil {
+		return nil
+	}
+	objectID, err := primitive.ObjectIDFromHex(vehicleID)
+	if err != nil {
+		return nil
+	}
+	entry, err := s.logbookRepo.FindLast(objectID)
+	if err != nil {
+		return nil
+	}
+	return entry
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// vinManufacturer ist ein Eintrag der WMI-Tabelle
+type vinManufacturer struct {
+	manufacturer string
+	brand        string
+}
+
+// vinManufacturers ordnet Herstellercodes (WMI) Hersteller und Marke zu. Gesucht wird zuerst mit
+// drei, dann mit zwei Stellen, da manche Hersteller alle Codes eines Präfixes belegen.
+var vinManufacturers = map[string]vinManufacturer{
+	"WVW": {"Volkswagen AG", "Volkswagen"},
+	"WVG": {"Volkswagen AG (SUV)", "Volkswagen"},
+	"WV1": {"Volkswagen Nutzfahrzeuge", "Volkswagen"},
+	"WV2": {"Volkswagen Nutzfahrzeuge (Bus)", "Volkswagen"},
+	"WAU": {"Audi AG", "Audi"},
+	"WA1": {"Audi AG (SUV)", "Audi"},
+	"WUA": {"Audi Sport GmbH", "Audi"},
+	"TRU": {"Audi Hungaria", "Audi"},
+	"WBA": {"BMW AG", "BMW"},
+	"WBS": {"BMW M GmbH", "BMW"},
+	"WBY": {"BMW AG (BMW i)", "BMW"},
+	"WMW": {"BMW AG (MINI)", "MINI"},
+	"WDB": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"WDD": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"WDC": {"Mercedes-Benz AG (SUV)", "Mercedes-Benz"},
+	"WDF": {"Mercedes-Benz AG (Van)", "Mercedes-Benz"},
+	"W1K": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"W1N": {"Mercedes-Benz AG (SUV)", "Mercedes-Benz"},
+	"W1V": {"Mercedes-Benz AG (Van)", "Mercedes-Benz"},
+	"WME": {"smart", "smart"},
+	"WP0": {"Dr. Ing. h.c. F. Porsche AG", "Porsche"},
+	"WP1": {"Dr. Ing. h.c. F. Porsche AG (SUV)", "Porsche"},
+	"W0L": {"Opel Automobile GmbH", "Opel"},
+	"W0V": {"Opel Automobile GmbH", "Opel"},
+	"WF0": {"Ford-Werke GmbH", "Ford"},
+	"NM0": {"Ford Otosan", "Ford"},
+	"WMA": {"MAN Truck & Bus", "MAN"},
+	"TMB": {"Škoda Auto", "Škoda"},
+	"VSS": {"SEAT", "SEAT"},
+	"VF1": {"Renault", "Renault"},
+	"VF3": {"Peugeot", "Peugeot"},
+	"VR3": {"Peugeot", "Peugeot"},
+	"VF7": {"Citroën", "Citroën"},
+	"VNK": {"Toyota Motor Manufacturing France", "Toyota"},
+	"ZFA": {"Fiat", "Fiat"},
+	"ZAR": {"Alfa Romeo", "Alfa Romeo"},
+	"YV1": {"Volvo Cars", "Volvo"},
+	"YV4": {"Volvo Cars (SUV)", "Volvo"},
+	"YS2": {"Scania", "Scania"},
+	"SAL": {"Jaguar Land Rover (Land Rover)", "Land Rover"},
+	"SAJ": {"Jaguar Land Rover (Jaguar)", "Jaguar"},
+	"SJN": {"Nissan Motor Manufacturing UK", "Nissan"},
+	"TMA": {"Hyundai Motor Manufacturing Czech", "Hyundai"},
+	"KMH": {"Hyundai Motor Company", "Hyundai"},
+	"U5Y": {"Kia Slovakia", "Kia"},
+	"KNA": {"Kia Corporation", "Kia"},
+	"NMT": {"Toyota Motor Manufacturing Turkey", "Toyota"},
+	"5YJ": {"Tesla, Inc.", "Tesla"},
+	"7SA": {"Tesla, Inc.", "Tesla"},
+	"XP7": {"Tesla Manufacturing Brandenburg", "Tesla"},
+	"LRW": {"Tesla Shanghai", "Tesla"},
+	"LSJ": {"SAIC Motor (MG)", "MG"},
+	"LYV": {"Volvo Cars Asia Pacific", "Volvo"},
+	"1FA": {"Ford Motor Company", "Ford"},
+	"1FT": {"Ford Motor Company (Truck)", "Ford"},
+	"JT":  {"Toyota Motor Corporation", "Toyota"},
+	"JN":  {"Nissan Motor Co.", "Nissan"},
+	"JH":  {"Honda Motor Co.", "Honda"},
+	"JM":  {"Mazda Motor Corporation", "Mazda"},
+	"1G":  {"General Motors", "General Motors"},
+}
+
+// vinPlants ordnet je Marke den Werkscode (Stelle 11) einem Werk zu, soweit bekannt
+var vinPlants = map[string]map[string]string{
+	"Volkswagen": {
+		"W": "Wolfsburg",
+		"H": "Hannover",
+		"E": "Emden",
+		"P": "Zwickau",
+		"K": "Osnabrück",
+		"D": "Bratislava",
+		"M": "Puebla",
+		"C": "Chattanooga",
+	},
+	"Audi": {
+		"A": "Ingolstadt",
+		"N": "Neckarsulm",
+		"1": "Győr",
+		"D": "Bratislava",
+	},
+	"Porsche": {
+		"S": "Stuttgart-Zuffenhausen",
+		"L": "Leipzig",
+		"K": "Osnabrück",
+	},
+	"Tesla": {
+		"F": "Fremont",
+		"A": "Austin",
+		"B": "Grünheide (Brandenburg)",
+		"C": "Shanghai",
+	},
+}
+
+// vinYearCodes enthält die Codes für das Modelljahr (Stelle 10) in der Reihenfolge ab 1980;
+// die Folge wiederholt sich alle 30 Jahre
+const vinYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// vinTransliteration sind die Zahlenwerte der Buchstaben für die Prüfziffer nach ISO 3779
+var vinTransliteration = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// vinWeights sind die Gewichte der 17 Stellen für die Prüfziffer
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// NormalizeVIN entfernt Leerzeichen und Bindestriche und wandelt in Großbuchstaben um
+func NormalizeVIN(vin string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(strings.TrimSpace(vin)))
+}
+
+// DecodeVIN prüft eine FIN und entschlüsselt sie anhand der mitgelieferten Tabellen, ohne
+// Online-Abfrage. Geprüft werden Länge, erlaubte Zeichen und – für nordamerikanische und
+// chinesische FIN, bei denen sie Pflicht ist – die Prüfziffer. Unbekannte Hersteller oder
+// Werke sind kein Fehler; die Felder bleiben dann leer.
+func DecodeVIN(vin string) (*model.VINInfo, error) {
+	vin = NormalizeVIN(vin)
+	if len(vin) != 17 {
+		return nil, fmt.Errorf("die fin muss 17 zeichen lang sein, %s hat %d", vin, len(vin))
+	}
+	for i, r := range vin {
+		if r == 'I' || r == 'O' || r == 'Q' {
+			return nil, fmt.Errorf("die fin darf kein %c enthalten (stelle %d)", r, i+1)
+		}
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return nil, fmt.Errorf("ungültiges zeichen %q in der fin (stelle %d)", r, i+1)
+		}
+	}
+
+	info := &model.VINInfo{
+		VIN:           vin,
+		WMI:           vin[:3],
+		Region:        vinRegion(vin[0]),
+		ModelYearCode: vin[9:10],
+		PlantCode:     vin[10:11],
+		SerialNumber:  vin[11:],
+		CheckDigit:    vin[8:9],
+	}
+
+	expected := vinCheckDigit(vin)
+	info.CheckDigitValid = info.CheckDigit == expected
+	info.CheckDigitRequired = (vin[0] >= '1' && vin[0] <= '5') || vin[0] == 'L'
+	if info.CheckDigitRequired && !info.CheckDigitValid {
+		return nil, fmt.Errorf("die prüfziffer der fin stimmt nicht (stelle 9 ist %s, erwartet %s)", info.CheckDigit, expected)
+	}
+
+	manufacturer, ok := vinManufacturers[info.WMI]
+	if !ok {
+		manufacturer, ok = vinManufacturers[vin[:2]]
+	}
+	if ok {
+		info.Manufacturer = manufacturer.manufacturer
+		info.Brand = manufacturer.brand
+		info.Plant = vinPlants[manufacturer.brand][info.PlantCode]
+	}
+
+	info.ModelYear = vinModelYear(vin, time.Now().Year())
+	return info, nil
+}
+
+// vinCheckDigit berechnet die Prüfziffer (Stelle 9) nach ISO 3779 bzw. 49 CFR 565
+func vinCheckDigit(vin string) string {
+	sum := 0
+	for i, r := range vin {
+		value, ok := vinTransliteration[r]
+		if !ok {
+			value = int(r - '0')
+		}
+		sum += value * vinWeights[i]
+	}
+	if remainder := sum % 11; remainder != 10 {
+		return string(rune('0' + remainder))
+	}
+	return "X"
+}
+
+// vinModelYear liefert das Modelljahr aus Stelle 10. Bei nordamerikanischen Pkw zeigt Stelle 7 den
+// Zyklus an (Ziffer bis 2009, Buchstabe ab 2010); sonst gilt das jüngste Jahr, das nicht mehr als
+// ein Jahr in der Zukunft liegt. Europäische Hersteller müssen Stelle 10 nicht belegen und setzen
+// dort teils 0 oder Z, was kein Modelljahr ergibt.
+func vinModelYear(vin string, currentYear int) int {
+	index := strings.IndexByte(vinYearCodes, vin[9])
+	if index < 0 {
+		return 0
+	}
+	year := 1980 + index
+
+	if vin[0] >= '1' && vin[0] <= '5' {
+		if vin[6] >= 'A' && vin[6] <= 'Z' {
+			year += 30
+		}
+		return year
+	}
+	for year+30 <= currentYear+1 {
+		year += 30
+	}
+	return year
+}
+
+// vinRegion liefert die Herkunftsregion nach der ersten Stelle der FIN
+func vinRegion(first byte) string {
+	switch {
+	case first >= 'A' && first <= 'H':
+		return "Afrika"
+	case first >= 'J' && first <= 'R':
+		return "Asien"
+	case first >= 'S' && first <= 'Z':
+		return "Europa"
+	case first >= '1' && first <= '5':
+		return "Nordamerika"
+	case first == '6' || first == '7':
+		return "Ozeanien"
+	default:
+		return "Südamerika"
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"testing"
+)
+
+// withCheckDigit setzt die passende Prüfziffer an Stelle 9 einer FIN
+func withCheckDigit(vin string) string {
+	return vin[:8] + vinCheckDigit(vin) + vin[9:]
+}
+
+func TestDecodeVIN(t *testing.T) {
+	tests := []struct {
+		name             string
+		vin              string
+		wantErr          bool
+		wantVIN          string
+		wantRegion       string
+		wantBrand        string
+		wantPlant        string
+		wantCheckValid   bool
+		wantCheckNeeded  bool
+		wantSerialNumber string
+	}{
+		{
+			name:             "nordamerikanische fin mit prüfziffer x",
+			vin:              "1M8GDM9AXKP042788",
+			wantVIN:          "1M8GDM9AXKP042788",
+			wantRegion:       "Nordamerika",
+			wantCheckValid:   true,
+			wantCheckNeeded:  true,
+			wantSerialNumber: "042788",
+		},
+		{
+			name:             "europäische fin ohne gültige prüfziffer",
+			vin:              "WVWZZZ1KZAW000123",
+			wantVIN:          "WVWZZZ1KZAW000123",
+			wantRegion:       "Europa",
+			wantBrand:        "Volkswagen",
+			wantPlant:        "Wolfsburg",
+			wantSerialNumber: "000123",
+		},
+		{
+			name:             "normalisiert leerzeichen, bindestriche und kleinbuchstaben",
+			vin:              " wauzzz8v-5ka 123456 ",
+			wantVIN:          "WAUZZZ8V5KA123456",
+			wantRegion:       "Europa",
+			wantBrand:        "Audi",
+			wantPlant:        "Ingolstadt",
+			wantSerialNumber: "123456",
+		},
+		{
+			name:             "herstellercode mit zwei stellen",
+			vin:              "JTDKB20U993123456",
+			wantVIN:          "JTDKB20U993123456",
+			wantRegion:       "Asien",
+			wantBrand:        "Toyota",
+			wantSerialNumber: "123456",
+		},
+		{
+			name:             "chinesische fin mit korrekter prüfziffer",
+			vin:              withCheckDigit("LRW3E7EK0PC000001"),
+			wantVIN:          withCheckDigit("LRW3E7EK0PC000001"),
+			wantRegion:       "Asien",
+			wantBrand:        "Tesla",
+			wantPlant:        "Shanghai",
+			wantCheckValid:   true,
+			wantCheckNeeded:  true,
+			wantSerialNumber: "000001",
+		},
+		{
+			name:             "unbekannter hersteller ist kein fehler",
+			vin:              "XXXZZZ00000000001",
+			wantVIN:          "XXXZZZ00000000001",
+			wantRegion:       "Europa",
+			wantSerialNumber: "000001",
+		},
+		{
+			name:    "nordamerikanische fin mit falscher prüfziffer",
+			vin:     "1M8GDM9A1KP042788",
+			wantErr: true,
+		},
+		{
+			name:    "chinesische fin mit falscher prüfziffer",
+			vin:     "LRW3E7EKXPC000001",
+			wantErr: true,
+		},
+		{
+			name:    "zu kurz",
+			vin:     "WVWZZZ1KZAW00012",
+			wantErr: true,
+		},
+		{
+			name:    "zu lang",
+			vin:     "WVWZZZ1KZAW0001234",
+			wantErr: true,
+		},
+		{
+			name:    "enthält den buchstaben o",
+			vin:     "WVWZZZ1KZAW00O123",
+			wantErr: true,
+		},
+		{
+			name:    "enthält ein sonderzeichen",
+			vin:     "WVWZZZ1KZAW000.23",
+			wantErr: true,
+		},
+		{
+			name:    "leer",
+			vin:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := DecodeVIN(tt.vin)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("fehler erwartet für %q", tt.vin)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unerwarteter fehler: %v", err)
+			}
+			if info.VIN != tt.wantVIN {
+				t.Errorf("VIN = %q, erwartet %q", info.VIN, tt.wantVIN)
+			}
+			if info.Region != tt.wantRegion {
+				t.Errorf("Region = %q, erwartet %q", info.Region, tt.wantRegion)
+			}
+			if info.Brand != tt.wantBrand {
+				t.Errorf("Brand = %q, erwartet %q", info.Brand, tt.wantBrand)
+			}
+			if info.Plant != tt.wantPlant {
+				t.Errorf("Plant = %q, erwartet %q", info.Plant, tt.wantPlant)
+			}
+			if info.CheckDigitValid != tt.wantCheckValid {
+				t.Errorf("CheckDigitValid = %v, erwartet %v", info.CheckDigitValid, tt.wantCheckValid)
+			}
+			if info.CheckDigitRequired != tt.wantCheckNeeded {
+				t.Errorf("CheckDigitRequired = %v, erwartet %v", info.CheckDigitRequired, tt.wantCheckNeeded)
+			}
+			if info.SerialNumber != tt.wantSerialNumber {
+				t.Errorf("SerialNumber = %q, erwartet %q", info.SerialNumber, tt.wantSerialNumber)
+			}
+		})
+	}
+}
+
+func TestVINModelYear(t *testing.T) {
+	tests := []struct {
+		name        string
+		vin         string
+		currentYear int
+		want        int
+	}{
+		{"nordamerika mit ziffer an stelle 7", "1M8GDM9AXKP042788", 2026, 1989},
+		{"nordamerika mit buchstabe an stelle 7", "5YJ3E1EA7KF317000", 2026, 2019},
+		{"europa jüngster zyklus", "WVWZZZ1KZLW000123", 2026, 2020},
+		{"europa ein jahr in der zukunft", "WVWZZZ1KZVW000123", 2026, 2027},
+		{"europa mehr als ein jahr in der zukunft", "WVWZZZ1KZVW000123", 2025, 1997},
+		{"stelle 10 ohne modelljahr", "WVWZZZ1KZZW000123", 2026, 0},
+		{"stelle 10 mit null", "WVWZZZ1KZ0W000123", 2026, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vinModelYear(tt.vin, tt.currentYear); got != tt.want {
+				t.Errorf("vinModelYear(%q, %d) = %d, erwartet %d", tt.vin, tt.currentYear, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVINCheckDigit(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want string
+	}{
+		{"1M8GDM9AXKP042788", "X"},
+		{"1HGCM82633A004352", "3"},
+		{"11111111111111111", "1"},
+	}
+
+	for _, tt := range tests {
+		if got := vinCheckDigit(tt.vin); got != tt.want {
+			t.Errorf("vinCheckDigit(%q) = %q, erwartet %q", tt.vin, got, tt.want)
+		}
+	}
+}
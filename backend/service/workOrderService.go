@@ -0,0 +1,502 @@
+package service
+
+import (
+	"FleetFlow/backend/model"
+	"FleetFlow/backend/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrWorkOrderNotFound wird für unbekannte Werkstattaufträge zurückgegeben
+	ErrWorkOrderNotFound = errors.New("werkstattauftrag nicht gefunden")
+	// ErrWorkOrderState wird zurückgegeben, wenn der Auftrag im aktuellen Status nicht geändert werden kann
+	ErrWorkOrderState = errors.New("der werkstattauftrag ist bereits abgeschlossen oder storniert")
+)
+
+// WorkOrderInput enthält die Angaben eines Werkstattauftrags
+type WorkOrderInput struct {
+	VehicleID       string   // Optional, wenn Meldungen angegeben sind
+	ReportIDs       []string // Meldungen, die mit dem Auftrag behoben werden
+	Workshop        string
+	Description     string
+	MaintenanceType model.MaintenanceType // Ohne Angabe Reparatur
+	LineItems       []model.WorkOrderLineItem
+	EstimatedCost   float64 // Ohne Angabe Summe der Positionen
+	DowntimeStart   *time.Time
+}
+
+// WorkOrderCloseInput enthält die Angaben zum Abschluss eines Werkstattauftrags
+type WorkOrderCloseInput struct {
+	LineItems   []model.WorkOrderLineItem // Optional: ersetzt die Positionen durch die Rechnung
+	ActualCost  float64                   // Ohne Angabe Summe der Positionen
+	DowntimeEnd *time.Time                // Ohne Angabe jetzt
+	Mileage     int
+	Resolution  string
+}
+
+// WorkOrderService verwaltet Werkstattaufträge. Während der Auftrag läuft, steht das Fahrzeug auf
+// "maintenance"; der Abschluss erstellt den Wartungseintrag und behebt die verknüpften Meldungen.
+type WorkOrderService struct {
+	workOrderRepo   repository.WorkOrderRepository
+	reportRepo      repository.VehicleReportRepository
+	vehicleRepo     repository.VehicleRepository
+	maintenanceRepo repository.MaintenanceRepository
+	mileageService  *VehicleMileageService
+	planService     *MaintenancePlanService
+	activityService *ActivityService
+}
+
+// NewWorkOrderService erstellt einen neuen WorkOrderService
+func NewWorkOrderService(workOrderRepo repository.WorkOrderRepository, reportRepo repository.VehicleReportRepository, vehicleRepo repository.VehicleRepository, maintenanceRepo repository.MaintenanceRepository, mileageService *VehicleMileageService, planService *MaintenancePlanService, activityService *ActivityService) *WorkOrderService {
+	return &WorkOrderService{
+		workOrderRepo:   workOrderRepo,
+		reportRepo:      reportRepo,
+		vehicleRepo:     vehicleRepo,
+		maintenanceRepo: maintenanceRepo,
+		mileageService:  mileageService,
+		planService:     planService,
+		activityService: activityService,
+	}
+}
+
+// GetWorkOrders liefert alle Werkstattaufträge zum Filter
+func (s *WorkOrderService) GetWorkOrders(filter model.WorkOrderFilter) ([]*model.WorkOrder, error) {
+	return s.workOrderRepo.Find(filter)
+}
+
+// GetWorkOrder liefert einen Werkstattauftrag
+func (s *WorkOrderService) GetWorkOrder(id string) (*model.WorkOrder, error) {
+	order, err := s.workOrderRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWorkOrderNotFound
+	}
+	return order, nil
+}
+
+// CreateWorkOrder legt einen Werkstattauftrag an und setzt die verknüpften Meldungen auf "in Bearbeitung"
+func (s *WorkOrderService) CreateWorkOrder(user *model.User, input WorkOrderInput) (*model.WorkOrder, error) {
+	order := &model.WorkOrder{
+		Status:    model.WorkOrderStatusOpen,
+		CreatedBy: user.ID,
+	}
+	vehicle, err := s.applyInput(order, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.workOrderRepo.Create(order); err != nil {
+		return nil, fmt.Errorf("fehler beim speichern des werkstattauftrags: %v", err)
+	}
+
+	for _, reportID := range order.ReportIDs {
+		if err := s.reportRepo.AssignTo(reportID, user.ID); err != nil {
+			log.Printf("Fehler beim Zuweisen der Meldung %s: %v", reportID.Hex(), err)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"work_order_created",
+		fmt.Sprintf("Werkstattauftrag für %s bei %s angelegt (%d Meldungen, geschätzt %.2f €)",
+			vehicle.LicensePlate, order.Workshop, len(order.ReportIDs), order.EstimatedCost),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return order, nil
+}
+
+// UpdateWorkOrder ändert einen offenen oder laufenden Werkstattauftrag
+func (s *WorkOrderService) UpdateWorkOrder(user *model.User, id string, input WorkOrderInput) (*model.WorkOrder, error) {
+	order, err := s.activeWorkOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if input.VehicleID != "" && input.VehicleID != order.VehicleID.Hex() && order.Status == model.WorkOrderStatusInProgress {
+		return nil, fmt.Errorf("das fahrzeug eines laufenden werkstattauftrags kann nicht geändert werden")
+	}
+
+	previousReports := order.ReportIDs
+	if _, err := s.applyInput(order, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.workOrderRepo.Update(order); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des werkstattauftrags: %v", err)
+	}
+
+	// Neu verknüpfte Meldungen übernehmen, entfernte wieder öffnen
+	for _, reportID := range order.ReportIDs {
+		if !containsObjectID(previousReports, reportID) {
+			if err := s.reportRepo.AssignTo(reportID, user.ID); err != nil {
+				log.Printf("Fehler beim Zuweisen der Meldung %s: %v", reportID.Hex(), err)
+			}
+		}
+	}
+	for _, reportID := range previousReports {
+		if !containsObjectID(order.ReportIDs, reportID) {
+			if err := s.reportRepo.UpdateStatus(reportID, model.ReportStatusOpen, user.ID); err != nil {
+				log.Printf("Fehler beim Zurücksetzen der Meldung %s: %v", reportID.Hex(), err)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// StartWorkOrder vermerkt den Beginn der Standzeit und setzt das Fahrzeug auf "maintenance"
+func (s *WorkOrderService) StartWorkOrder(user *model.User, id string, start *time.Time) (*model.WorkOrder, error) {
+	order, err := s.activeWorkOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.WorkOrderStatusOpen {
+		return nil, fmt.Errorf("der werkstattauftrag läuft bereits")
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(order.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	if start == nil {
+		now := time.Now()
+		start = &now
+	}
+	order.DowntimeStart = start
+	order.Status = model.WorkOrderStatusInProgress
+	order.PreviousVehicleStatus = vehicle.Status
+
+	if err := s.workOrderRepo.Update(order); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des werkstattauftrags: %v", err)
+	}
+
+	if vehicle.Status != model.VehicleStatusMaintenance {
+		vehicle.Status = model.VehicleStatusMaintenance
+		if err := s.vehicleRepo.Update(vehicle); err != nil {
+			return nil, fmt.Errorf("fehler beim aktualisieren des fahrzeugstatus: %v", err)
+		}
+	}
+
+	s.activityService.LogActivity(
+		"work_order_started",
+		fmt.Sprintf("%s ab %s in der Werkstatt (%s)", vehicle.LicensePlate, start.Format("02.01.2006 15:04"), order.Workshop),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return order, nil
+}
+
+// CloseWorkOrder schließt einen Werkstattauftrag ab: Wartungseintrag erstellen, verknüpfte
+// Meldungen beheben und den Fahrzeugstatus von vor dem Werkstattaufenthalt wiederherstellen
+func (s *WorkOrderService) CloseWorkOrder(user *model.User, id string, input WorkOrderCloseInput) (*model.WorkOrder, error) {
+	order, err := s.activeWorkOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(order.VehicleID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	if input.LineItems != nil {
+		items, err := normalizeLineItems(input.LineItems)
+		if err != nil {
+			return nil, err
+		}
+		order.LineItems = items
+	}
+	if input.ActualCost < 0 || input.Mileage < 0 {
+		return nil, fmt.Errorf("kosten und kilometerstand dürfen nicht negativ sein")
+	}
+	order.ActualCost = input.ActualCost
+	if order.ActualCost == 0 {
+		order.ActualCost = roundCents(order.LineItemTotal())
+	}
+
+	end := time.Now()
+	if input.DowntimeEnd != nil {
+		end = *input.DowntimeEnd
+	}
+	if order.DowntimeStart != nil && end.Before(*order.DowntimeStart) {
+		return nil, fmt.Errorf("das ende der standzeit muss nach dem beginn liegen")
+	}
+	order.DowntimeEnd = &end
+	order.Mileage = input.Mileage
+	order.Resolution = strings.TrimSpace(input.Resolution)
+	if order.Resolution == "" {
+		order.Resolution = fmt.Sprintf("Behoben mit Werkstattauftrag bei %s", order.Workshop)
+	}
+
+	maintenance := &model.Maintenance{
+		VehicleID: order.VehicleID,
+		Date:      time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC),
+		Type:      order.MaintenanceType,
+		Mileage:   order.Mileage,
+		Cost:      order.ActualCost,
+		Workshop:  order.Workshop,
+		Notes:     workOrderNotes(order),
+	}
+	if err := s.maintenanceRepo.Create(maintenance); err != nil {
+		return nil, fmt.Errorf("fehler beim erstellen des wartungseintrags: %v", err)
+	}
+
+	now := time.Now()
+	order.MaintenanceID = &maintenance.ID
+	order.Status = model.WorkOrderStatusClosed
+	order.ClosedBy = &user.ID
+	order.ClosedAt = &now
+	if err := s.workOrderRepo.Update(order); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des werkstattauftrags: %v", err)
+	}
+
+	for _, reportID := range order.ReportIDs {
+		if err := s.reportRepo.Resolve(reportID, user.ID, order.Resolution); err != nil {
+			log.Printf("Fehler beim Beheben der Meldung %s: %v", reportID.Hex(), err)
+		}
+	}
+
+	if order.Mileage > 0 {
+		if err := s.mileageService.UpdateVehicleMileageFromAllSources(vehicle.ID.Hex()); err != nil {
+			log.Printf("Fehler beim Aktualisieren des Kilometerstands nach Werkstattauftrag: %v", err)
+		}
+	}
+	if err := s.planService.CompleteFromMaintenance(maintenance); err != nil {
+		log.Printf("Fehler beim Zurücksetzen der Wartungspläne: %v", err)
+	}
+	s.restoreVehicleStatus(order)
+
+	s.activityService.LogActivity(
+		"work_order_closed",
+		fmt.Sprintf("Werkstattauftrag für %s abgeschlossen: %.2f € (geschätzt %.2f €), %d Tage Standzeit, %d Meldungen behoben",
+			vehicle.LicensePlate, order.ActualCost, order.EstimatedCost, order.DowntimeDays(), len(order.ReportIDs)),
+		user.ID,
+		&vehicle.ID,
+	)
+
+	return order, nil
+}
+
+// CancelWorkOrder storniert einen Werkstattauftrag und öffnet die verknüpften Meldungen wieder
+func (s *WorkOrderService) CancelWorkOrder(user *model.User, id string) (*model.WorkOrder, error) {
+	order, err := s.activeWorkOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	wasInProgress := order.Status == model.WorkOrderStatusInProgress
+	now := time.Now()
+	order.Status = model.WorkOrderStatusCancelled
+	order.ClosedBy = &user.ID
+	order.ClosedAt = &now
+	if wasInProgress {
+		order.DowntimeEnd = &now
+	}
+	if err := s.workOrderRepo.Update(order); err != nil {
+		return nil, fmt.Errorf("fehler beim aktualisieren des werkstattauftrags: %v", err)
+	}
+
+	for _, reportID := range order.ReportIDs {
+		if err := s.reportRepo.UpdateStatus(reportID, model.ReportStatusOpen, user.ID); err != nil {
+			log.Printf("Fehler beim Zurücksetzen der Meldung %s: %v", reportID.Hex(), err)
+		}
+	}
+	if wasInProgress {
+		s.restoreVehicleStatus(order)
+	}
+
+	s.activityService.LogActivity(
+		"work_order_cancelled",
+		fmt.Sprintf("Werkstattauftrag bei %s storniert", order.Workshop),
+		user.ID,
+		&order.VehicleID,
+	)
+
+	return order, nil
+}
+
+// restoreVehicleStatus setzt das Fahrzeug auf den Status vor dem Werkstattaufenthalt zurück,
+// sofern es nicht noch für einen anderen laufenden Auftrag in der Werkstatt steht
+func (s *WorkOrderService) restoreVehicleStatus(order *model.WorkOrder) {
+	vehicle, err := s.vehicleRepo.FindByID(order.VehicleID.Hex())
+	if err != nil || vehicle.Status != model.VehicleStatusMaintenance {
+		return
+	}
+
+	running, err := s.workOrderRepo.Find(model.WorkOrderFilter{
+		VehicleID: &order.VehicleID,
+		Statuses:  []model.WorkOrderStatus{model.WorkOrderStatusInProgress},
+	})
+	if err == nil && len(running) > 0 {
+		return
+	}
+
+	status := order.PreviousVehicleStatus
+	if status == "" || status == model.VehicleStatusMaintenance {
+		status = model.VehicleStatusAvailable
+	}
+	vehicle.Status = status
+	if err := s.vehicleRepo.Update(vehicle); err != nil {
+		log.Printf("Fehler beim Zurücksetzen des Fahrzeugstatus für %s: %v", vehicle.LicensePlate, err)
+	}
+}
+
+// activeWorkOrder lädt einen Werkstattauftrag, der weder abgeschlossen noch storniert ist
+func (s *WorkOrderService) activeWorkOrder(id string) (*model.WorkOrder, error) {
+	order, err := s.GetWorkOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsActive() {
+		return nil, ErrWorkOrderState
+	}
+	return order, nil
+}
+
+// applyInput prüft die Eingabe, löst Fahrzeug und Meldungen auf und überträgt sie auf den Auftrag
+func (s *WorkOrderService) applyInput(order *model.WorkOrder, input WorkOrderInput) (*model.Vehicle, error) {
+	input.Workshop = strings.TrimSpace(input.Workshop)
+	if input.Workshop == "" {
+		return nil, fmt.Errorf("eine werkstatt ist erforderlich")
+	}
+	if input.EstimatedCost < 0 {
+		return nil, fmt.Errorf("die geschätzten kosten dürfen nicht negativ sein")
+	}
+	if input.MaintenanceType == "" {
+		input.MaintenanceType = model.MaintenanceTypeRepair
+	}
+	switch input.MaintenanceType {
+	case model.MaintenanceTypeInspection, model.MaintenanceTypeOilChange, model.MaintenanceTypeTireChange,
+		model.MaintenanceTypeRepair, model.MaintenanceTypeOther:
+	default:
+		return nil, fmt.Errorf("ungültiger wartungstyp")
+	}
+
+	vehicleID := input.VehicleID
+	reportIDs := make([]primitive.ObjectID, 0, len(input.ReportIDs))
+	for _, value := range input.ReportIDs {
+		reportID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("ungültige meldungs-id %s", value)
+		}
+		if containsObjectID(reportIDs, reportID) {
+			continue
+		}
+		report, err := s.reportRepo.FindByID(reportID)
+		if err != nil {
+			return nil, fmt.Errorf("meldung %s nicht gefunden", value)
+		}
+		if vehicleID == "" {
+			vehicleID = report.VehicleID.Hex()
+		}
+		if report.VehicleID.Hex() != vehicleID {
+			return nil, fmt.Errorf("meldung \"%s\" gehört zu einem anderen fahrzeug", report.Title)
+		}
+		if !containsObjectID(order.ReportIDs, reportID) {
+			if report.Status == model.ReportStatusResolved || report.Status == model.ReportStatusClosed {
+				return nil, fmt.Errorf("meldung \"%s\" ist bereits behoben", report.Title)
+			}
+			linked, err := s.workOrderRepo.Find(model.WorkOrderFilter{
+				ReportID: &reportID,
+				Statuses: []model.WorkOrderStatus{model.WorkOrderStatusOpen, model.WorkOrderStatusInProgress},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(linked) > 0 {
+				return nil, fmt.Errorf("meldung \"%s\" gehört bereits zu einem offenen werkstattauftrag", report.Title)
+			}
+		}
+		reportIDs = append(reportIDs, reportID)
+	}
+
+	if vehicleID == "" {
+		return nil, fmt.Errorf("ein fahrzeug oder mindestens eine meldung ist erforderlich")
+	}
+	vehicle, err := s.vehicleRepo.FindByID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("fahrzeug nicht gefunden")
+	}
+
+	items, err := normalizeLineItems(input.LineItems)
+	if err != nil {
+		return nil, err
+	}
+
+	order.VehicleID = vehicle.ID
+	order.ReportIDs = reportIDs
+	order.Workshop = input.Workshop
+	order.Description = strings.TrimSpace(input.Description)
+	order.MaintenanceType = input.MaintenanceType
+	order.LineItems = items
+	order.EstimatedCost = input.EstimatedCost
+	if order.EstimatedCost == 0 {
+		order.EstimatedCost = roundCents(order.LineItemTotal())
+	}
+	if input.DowntimeStart != nil {
+		order.DowntimeStart = input.DowntimeStart
+	}
+	return vehicle, nil
+}
+
+// normalizeLineItems prüft die Positionen und berechnet fehlende Gesamtbeträge
+func normalizeLineItems(items []model.WorkOrderLineItem) ([]model.WorkOrderLineItem, error) {
+	normalized := make([]model.WorkOrderLineItem, 0, len(items))
+	for i, item := range items {
+		item.Description = strings.TrimSpace(item.Description)
+		item.PartNumber = strings.TrimSpace(item.PartNumber)
+		if item.Type != model.WorkOrderLinePart && item.Type != model.WorkOrderLineLabour {
+			return nil, fmt.Errorf("position %d: ungültiger typ, erlaubt sind part und labour", i+1)
+		}
+		if item.Description == "" {
+			return nil, fmt.Errorf("position %d: eine beschreibung ist erforderlich", i+1)
+		}
+		if item.Quantity < 0 || item.UnitPrice < 0 || item.Total < 0 {
+			return nil, fmt.Errorf("position %d: menge und preise dürfen nicht negativ sein", i+1)
+		}
+		if item.Quantity == 0 {
+			item.Quantity = 1
+		}
+		if item.Total == 0 {
+			item.Total = roundCents(item.Quantity * item.UnitPrice)
+		}
+		normalized = append(normalized, item)
+	}
+	return normalized, nil
+}
+
+// workOrderNotes fasst Auftrag und Positionen für den Wartungseintrag zusammen
+func workOrderNotes(order *model.WorkOrder) string {
+	var lines []string
+	if order.Description != "" {
+		lines = append(lines, order.Description)
+	}
+	for _, item := range order.LineItems {
+		kind := "Teil"
+		if item.Type == model.WorkOrderLineLabour {
+			kind = "Arbeit"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s (%g × %.2f € = %.2f €)", kind, item.Description, item.Quantity, item.UnitPrice, item.Total))
+	}
+	if order.Resolution != "" {
+		lines = append(lines, order.Resolution)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// containsObjectID prüft, ob die ID in der Liste enthalten ist
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
@@ -397,6 +397,9 @@ function openEditDriverModal(driverId) {
             document.getElementById('driver-phone').value = driver.phone || '';
             document.getElementById('driver-status').value = driver.status || 'available';
             document.getElementById('driver-notes').value = driver.notes || '';
+            document.getElementById('driver-benefitMethod').value = driver.benefitMethod || 'flat_rate';
+            document.getElementById('driver-commuteDistanceKm').value = driver.commuteDistanceKm || '';
+            document.getElementById('driver-commuteDailyValuation').checked = !!driver.commuteDailyValuation;
 
             // Führerscheinklassen setzen
             document.querySelectorAll('input[name="licenseClasses"]').forEach(cb => cb.checked = false);
@@ -441,7 +444,10 @@ function handleDriverSubmit(e) {
         phone: formData.get('phone'),
         status: formData.get('status'),
         notes: formData.get('notes'),
-        licenseClasses: formData.getAll('licenseClasses')
+        licenseClasses: formData.getAll('licenseClasses'),
+        benefitMethod: formData.get('benefitMethod') || 'flat_rate',
+        commuteDistanceKm: parseInt(formData.get('commuteDistanceKm'), 10) || 0,
+        commuteDailyValuation: formData.get('commuteDailyValuation') === 'on'
     };
 
     // Bei Bearbeitung auch Fahrzeugzuweisung berücksichtigen
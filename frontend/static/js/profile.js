@@ -136,6 +136,7 @@ document.addEventListener('DOMContentLoaded', function() {
     loadProfileStats();
     loadNotificationSettings();
     loadActivityHistory();
+    loadCalendarFeeds();
     
     // Setup event listeners
     setupEventListeners();
@@ -261,6 +262,18 @@ function setupEventListeners() {
     if (saveNotificationsBtn) {
         saveNotificationsBtn.addEventListener('click', handleNotificationSave);
     }
+    
+    // Kalender-Abonnements
+    const calendarFeedForm = document.getElementById('calendar-feed-form');
+    if (calendarFeedForm) {
+        calendarFeedForm.addEventListener('submit', handleCalendarFeedCreate);
+    }
+    
+    const calendarFeedScope = document.getElementById('calendar-feed-scope');
+    if (calendarFeedScope) {
+        calendarFeedScope.addEventListener('change', loadCalendarFeedTargets);
+        loadCalendarFeedTargets();
+    }
 }
 
 // Profile Update Handler
@@ -534,7 +547,12 @@ function updateNotificationSettings(settings) {
         'email-notifications': settings.emailNotifications,
         'booking-reminders': settings.bookingReminders,
         'fuel-reminders': settings.fuelReminders,
-        'maintenance-alerts': settings.maintenanceAlerts
+        'maintenance-alerts': settings.maintenanceAlerts,
+        'reservation-decisions': settings.reservationDecisions,
+        'reservation-requests': settings.reservationRequests,
+        'urgent-reports': settings.urgentReports,
+        'expiry-reminders': settings.expiryReminders,
+        'quiet-hours-enabled': settings.quietHoursEnabled
     };
     
     Object.entries(checkboxes).forEach(([id, checked]) => {
@@ -543,6 +561,13 @@ function updateNotificationSettings(settings) {
             checkbox.checked = checked;
         }
     });
+
+    if (settings.quietHoursStart) {
+        document.getElementById('quiet-hours-start').value = settings.quietHoursStart;
+    }
+    if (settings.quietHoursEnd) {
+        document.getElementById('quiet-hours-end').value = settings.quietHoursEnd;
+    }
 }
 
 // Handle Notification Settings Save
@@ -551,7 +576,14 @@ function handleNotificationSave() {
         emailNotifications: document.getElementById('email-notifications').checked,
         bookingReminders: document.getElementById('booking-reminders').checked,
         fuelReminders: document.getElementById('fuel-reminders').checked,
-        maintenanceAlerts: document.getElementById('maintenance-alerts').checked
+        maintenanceAlerts: document.getElementById('maintenance-alerts').checked,
+        reservationDecisions: document.getElementById('reservation-decisions').checked,
+        reservationRequests: document.getElementById('reservation-requests').checked,
+        urgentReports: document.getElementById('urgent-reports').checked,
+        expiryReminders: document.getElementById('expiry-reminders').checked,
+        quietHoursEnabled: document.getElementById('quiet-hours-enabled').checked,
+        quietHoursStart: document.getElementById('quiet-hours-start').value,
+        quietHoursEnd: document.getElementById('quiet-hours-end').value
     };
     
     const saveBtn = document.getElementById('save-notifications-btn');
@@ -568,7 +600,9 @@ function handleNotificationSave() {
     })
     .then(response => {
         if (!response.ok) {
-            throw new Error('Fehler beim Speichern der Benachrichtigungseinstellungen');
+            return response.json().then(data => {
+                throw new Error(data.error || 'Fehler beim Speichern der Benachrichtigungseinstellungen');
+            });
         }
         return response.json();
     })
@@ -585,6 +619,137 @@ function handleNotificationSave() {
     });
 }
 
+// Load Calendar Feeds
+function loadCalendarFeeds() {
+    fetch('/api/profile/calendar-feeds')
+        .then(response => {
+            if (!response.ok) {
+                throw new Error('Fehler beim Laden der Kalender-Abonnements');
+            }
+            return response.json();
+        })
+        .then(data => {
+            renderCalendarFeeds(data.feeds || []);
+        })
+        .catch(error => {
+            console.error('Error loading calendar feeds:', error);
+        });
+}
+
+// Render Calendar Feeds
+function renderCalendarFeeds(feeds) {
+    const container = document.getElementById('calendar-feed-list');
+    if (!container) return;
+    
+    const activeFeeds = feeds.filter(feed => !feed.revokedAt);
+    if (activeFeeds.length === 0) {
+        container.innerHTML = '<p class="text-center py-8 text-gray-500">Noch keine Kalender-Abonnements vorhanden</p>';
+        return;
+    }
+    
+    container.innerHTML = '';
+    activeFeeds.forEach(feed => {
+        const item = document.createElement('div');
+        item.className = 'border border-gray-200 rounded-lg p-4';
+        item.innerHTML = `
+            <div class="flex items-center justify-between mb-2">
+                <div>
+                    <p class="font-medium text-gray-900"></p>
+                    <p class="text-xs text-gray-500">Erstellt am ${formatDate(feed.createdAt)} · Letzter Abruf: ${feed.lastAccessedAt ? formatDate(feed.lastAccessedAt) : 'noch nie'}</p>
+                </div>
+                <button type="button" class="text-sm font-medium text-red-600 hover:text-red-800">Widerrufen</button>
+            </div>
+            <input type="text" readonly class="block w-full rounded-md border-gray-300 bg-gray-50 text-xs font-mono">
+        `;
+        item.querySelector('p.font-medium').textContent = feed.name;
+        const urlInput = item.querySelector('input');
+        urlInput.value = feed.url;
+        urlInput.addEventListener('focus', () => urlInput.select());
+        item.querySelector('button').addEventListener('click', () => revokeCalendarFeed(feed.id));
+        container.appendChild(item);
+    });
+}
+
+// Load selectable vehicles or drivers for managers
+function loadCalendarFeedTargets() {
+    const scope = document.getElementById('calendar-feed-scope').value;
+    const target = document.getElementById('calendar-feed-target');
+    if (!target) return;
+    
+    target.innerHTML = '';
+    target.disabled = scope === 'fleet';
+    if (scope === 'fleet') return;
+    
+    const endpoint = scope === 'vehicle' ? '/api/vehicles' : '/api/drivers';
+    fetch(endpoint)
+        .then(response => response.json())
+        .then(data => {
+            const items = (scope === 'vehicle' ? data.vehicles : data.drivers) || [];
+            items.forEach(item => {
+                const option = document.createElement('option');
+                option.value = item.id;
+                option.textContent = scope === 'vehicle'
+                    ? `${item.licensePlate} (${item.brand} ${item.model})`
+                    : `${item.firstName} ${item.lastName}`;
+                target.appendChild(option);
+            });
+        })
+        .catch(error => {
+            console.error('Error loading calendar targets:', error);
+        });
+}
+
+// Handle Calendar Feed Creation
+function handleCalendarFeedCreate(event) {
+    event.preventDefault();
+    
+    const target = document.getElementById('calendar-feed-target');
+    const payload = {
+        scope: document.getElementById('calendar-feed-scope').value,
+        targetId: target && !target.disabled ? target.value : '',
+        name: document.getElementById('calendar-feed-name').value
+    };
+    
+    fetch('/api/profile/calendar-feeds', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json',
+        },
+        body: JSON.stringify(payload)
+    })
+    .then(response => response.json().then(data => ({ ok: response.ok, data })))
+    .then(({ ok, data }) => {
+        if (!ok) {
+            throw new Error(data.error || 'Fehler beim Erstellen des Kalender-Abonnements');
+        }
+        document.getElementById('calendar-feed-name').value = '';
+        showNotification('Kalender-Abonnement erstellt', 'success');
+        loadCalendarFeeds();
+    })
+    .catch(error => {
+        showNotification(error.message, 'error');
+    });
+}
+
+// Revoke Calendar Feed
+function revokeCalendarFeed(id) {
+    if (!confirm('Möchten Sie dieses Kalender-Abonnement wirklich widerrufen? Kalender-Apps können es danach nicht mehr abrufen.')) {
+        return;
+    }
+    
+    fetch(`/api/profile/calendar-feeds/${id}`, { method: 'DELETE' })
+        .then(response => {
+            if (!response.ok) {
+                throw new Error('Fehler beim Widerrufen des Kalender-Abonnements');
+            }
+            showNotification('Kalender-Abonnement widerrufen', 'success');
+            loadCalendarFeeds();
+        })
+        .catch(error => {
+            showNotification(error.message, 'error');
+        });
+}
+
 // Load Activity History
 function loadActivityHistory() {
     // Placeholder for activity history - would need backend endpoint
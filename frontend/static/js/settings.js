@@ -575,9 +575,11 @@ function renderEmailLogs(logs) {
                 <div class="max-w-xs truncate">${log.subject}</div>
             </td>
             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
-                <span class="inline-flex rounded-full px-2 text-xs font-semibold leading-5 ${getEmailStatusClass(log.status)}">
+                <span class="inline-flex rounded-full px-2 text-xs font-semibold leading-5 ${getEmailStatusClass(log.status)}" title="${log.error ? escapeHtml(log.error) : ''}">
                     ${getEmailStatusText(log.status)}
                 </span>
+                ${log.attempts > 1 ? `<span class="ml-1 text-xs text-gray-400">${log.attempts} Versuche</span>` : ''}
+                ${log.status === 'failed' ? `<button onclick="resendEmail('${log.id}')" class="ml-2 text-xs font-medium text-indigo-600 hover:text-indigo-800">Erneut senden</button>` : ''}
             </td>
             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-500">
                 ${log.sentAt ? formatDateTime(log.sentAt) : '-'}
@@ -586,6 +588,22 @@ function renderEmailLogs(logs) {
     `).join('');
 }
 
+function resendEmail(id) {
+    fetch(`/api/smtp/logs/${id}/resend`, { method: 'POST' })
+        .then(response => response.json().then(data => ({ ok: response.ok, data })))
+        .then(({ ok, data }) => {
+            if (!ok) {
+                throw new Error(data.error || 'Fehler beim erneuten Senden');
+            }
+            showNotification('E-Mail wird erneut gesendet', 'success');
+            loadEmailLogs();
+        })
+        .catch(error => {
+            console.error('Error resending email:', error);
+            showNotification(error.message, 'error');
+        });
+}
+
 function renderEmailLogsError() {
     const tableBody = document.getElementById('email-logs-table');
     if (tableBody) {
@@ -625,6 +643,12 @@ function getEmailStatusText(status) {
     }
 }
 
+function escapeHtml(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML.replace(/"/g, '&quot;');
+}
+
 function formatDateTime(dateString) {
     if (!dateString) return '-';
     const date = new Date(dateString);
@@ -640,6 +640,7 @@ async function handleVehicleEditSubmit(e) {
         emissionClass: formData.get('emission_class'),
         maxSpeed: parseInt(formData.get('max_speed')) || 0,
         towingCapacity: parseInt(formData.get('towing_capacity')) || 0,
+        tankCapacity: parseFloat(formData.get('tank_capacity')) || 0,
 
         // Abmessungen & Gewichte
         length: parseInt(formData.get('length')) || 0,
@@ -1024,6 +1025,7 @@ window.openEditVehicleModal = async function(vehicleId) {
         setFieldValue('emission_class', vehicle.emissionClass);
         setFieldValue('max_speed', vehicle.maxSpeed);
         setFieldValue('towing_capacity', vehicle.towingCapacity);
+        setFieldValue('tank_capacity', vehicle.tankCapacity);
 
         // Abmessungen & Gewichte
         setFieldValue('length', vehicle.length);
@@ -1114,7 +1116,8 @@ async function handleFinancingSubmit(e) {
     try {
         // Nur die relevanten Finanzierungsdaten senden
         const data = {
-            acquisitionType: acquisitionType
+            acquisitionType: acquisitionType,
+            listPrice: parseFloat(formData.get('list-price')) || 0
         };
 
         // Je nach Erwerbsart die entsprechenden Felder setzen
@@ -1191,6 +1194,8 @@ window.openEditFinancingModal = async function(vehicleId) {
             }
         };
 
+        setFieldValue('list-price', vehicle.listPrice);
+
         // Kaufdaten
         setFieldValue('purchase-date', vehicle.purchaseDate, true);
         setFieldValue('purchase-price', vehicle.purchasePrice);
@@ -25,31 +25,49 @@ func main() {
 
 	log.Println("🚀 Starting FleetFlow Application...")
 
-	// Datenbankverbindung herstellen
-	log.Println("📊 Connecting to database...")
-	if err := db.ConnectDB(); err != nil {
-		log.Fatalf("❌ Database connection failed: %v", err)
+	// Speicher-Backend wählen und Repositories erstellen
+	var repos *repository.Repositories
+	if getStorageBackend() == "memory" {
+		log.Println("🧪 Storage backend: MEMORY (data is lost on restart)")
+		repos = repository.NewMemoryRepositories()
+	} else {
+		// Datenbankverbindung herstellen
+		log.Println("📊 Connecting to database...")
+		if err := db.ConnectDB(); err != nil {
+			log.Fatalf("❌ Database connection failed: %v", err)
+		}
+		defer db.DisconnectDB()
+		log.Println("✅ Database connected successfully")
+		repos = repository.NewMongoRepositories()
 	}
-	defer db.DisconnectDB()
-	log.Println("✅ Database connected successfully")
+	services := service.NewServices(repos)
 
 	// Admin-Benutzer erstellen, falls keiner existiert
-	userRepo := repository.NewUserRepository()
-	if err := userRepo.CreateAdminUserIfNotExists(); err != nil {
+	if err := repos.User.CreateAdminUserIfNotExists(); err != nil {
 		log.Printf("⚠️  Admin user creation warning: %v", err)
 	} else {
 		log.Println("👤 Admin user verified/created")
 	}
 
-	// Reservierungs-Scheduler starten
-	log.Println("📅 Starting reservation scheduler...")
-	scheduler := service.NewReservationScheduler()
-	scheduler.Start(1)
-	log.Println("✅ Reservation scheduler started")
+	// Fehlende Standard-E-Mail-Vorlagen anlegen (Willkommen, Ablauferinnerung, ...)
+	if err := services.Email.InitializeDefaultTemplates(); err != nil {
+		log.Printf("⚠️  Email template initialization warning: %v", err)
+	}
+
+	// Hintergrundjobs starten (Reservierungen, PeopleFlow-Sync, ...)
+	log.Println("📅 Starting job scheduler...")
+	services.Scheduler.Start()
+	defer services.Scheduler.Stop()
+	log.Println("✅ Job scheduler started")
+
+	// E-Mail-Warteschlange abarbeiten
+	services.Email.StartQueue()
+	defer services.Email.StopQueue()
+	log.Println("✉️  Email queue worker started")
 
 	// Initialize router
 	log.Println("🌐 Setting up routes...")
-	router := setupRouter()
+	router := setupRouter(repos, services)
 	log.Println("✅ Routes configured")
 
 	// Create and configure the server
@@ -70,7 +88,7 @@ func main() {
 	}
 }
 
-func setupRouter() *gin.Engine {
+func setupRouter(repos *repository.Repositories, services *service.Services) *gin.Engine {
 	// Create router based on log level
 	logLevel := getLogLevel()
 	
@@ -106,7 +124,7 @@ func setupRouter() *gin.Engine {
 	router.SetHTMLTemplate(templ)
 
 	// Import routes from router.go
-	backend.InitializeRoutes(router)
+	backend.InitializeRoutes(router, repos, services)
 
 	return router
 }
@@ -131,6 +149,15 @@ func setupLogging() {
 	}
 }
 
+// getStorageBackend returns the configured storage backend ("mongo" or "memory")
+func getStorageBackend() string {
+	backend := strings.ToLower(os.Getenv("STORAGE_BACKEND"))
+	if backend == "" {
+		backend = "mongo" // default
+	}
+	return backend
+}
+
 // getLogLevel returns the current log level from environment or default
 func getLogLevel() string {
 	level := strings.ToLower(os.Getenv("LOG_LEVEL"))